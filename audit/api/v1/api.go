@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"net/http"
+)
+
+var _ StrictServerInterface = (*Wrapper)(nil)
+var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
+
+// Wrapper implements the API for querying the persistent audit trail.
+type Wrapper struct {
+	Trail audit.Trail
+}
+
+func (w *Wrapper) ResolveStatusCode(err error) int {
+	switch {
+	case errors.Is(err, audit.ErrNoSinks):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (w *Wrapper) Routes(router core.EchoRouter) {
+	RegisterHandlers(router, NewStrictHandler(w, []StrictMiddlewareFunc{
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return func(ctx echo.Context, request interface{}) (response interface{}, err error) {
+				ctx.Set(core.OperationIDContextKey, operationID)
+				ctx.Set(core.ModuleNameContextKey, audit.ModuleName)
+				ctx.Set(core.StatusCodeResolverContextKey, w)
+				return f(ctx, request)
+			}
+		},
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return audit.StrictMiddleware(f, audit.ModuleName, operationID)
+		},
+	}))
+}
+
+func (w *Wrapper) GetAuditEvents(_ context.Context, request GetAuditEventsRequestObject) (GetAuditEventsResponseObject, error) {
+	query := audit.Query{
+		Since: request.Params.Since,
+		Until: request.Params.Until,
+	}
+	if request.Params.Actor != nil {
+		query.Actor = *request.Params.Actor
+	}
+	if request.Params.Operation != nil {
+		query.Operation = *request.Params.Operation
+	}
+	if request.Params.After != nil {
+		if *request.Params.After < 0 {
+			return nil, core.InvalidInputError("after must not be negative")
+		}
+		query.After = uint64(*request.Params.After)
+	}
+	if request.Params.Limit != nil {
+		if *request.Params.Limit < 1 || *request.Params.Limit > audit.MaxQueryLimit {
+			return nil, core.InvalidInputError("limit must be between 1 and %d", audit.MaxQueryLimit)
+		}
+		query.Limit = *request.Params.Limit
+	}
+	events, err := w.Trail.Events(query)
+	if err != nil {
+		return nil, err
+	}
+	result := make(GetAuditEvents200JSONResponse, 0, len(events))
+	for _, event := range events {
+		event := event
+		curr := AuditEvent{
+			Actor:        event.Actor,
+			Event:        event.Event,
+			Hash:         event.Hash,
+			Message:      event.Message,
+			Operation:    event.Operation,
+			PreviousHash: event.PreviousHash,
+			Sequence:     int64(event.Sequence),
+			Timestamp:    event.Timestamp,
+		}
+		if event.Module != "" {
+			curr.Module = &event.Module
+		}
+		if event.Fields != nil {
+			curr.Fields = &event.Fields
+		}
+		result = append(result, curr)
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"errors"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWrapper_GetAuditEvents(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := audit.Event{
+		Sequence:     2,
+		Timestamp:    timestamp,
+		Actor:        "alice",
+		Operation:    "Crypto.SignJwt",
+		Event:        audit.CryptoSignJWTEvent,
+		Module:       "Crypto",
+		Message:      "Signing a JWT",
+		Fields:       map[string]interface{}{"kid": "key-1"},
+		PreviousHash: "previous",
+		Hash:         "hash",
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trail := audit.NewMockTrail(ctrl)
+		wrapper := Wrapper{Trail: trail}
+		actor := "alice"
+		operation := "Crypto.SignJwt"
+		until := timestamp.Add(time.Hour)
+		trail.EXPECT().Events(audit.Query{Actor: actor, Operation: operation, Since: &timestamp, Until: &until}).Return([]audit.Event{event}, nil)
+
+		response, err := wrapper.GetAuditEvents(nil, GetAuditEventsRequestObject{Params: GetAuditEventsParams{
+			Actor:     &actor,
+			Operation: &operation,
+			Since:     &timestamp,
+			Until:     &until,
+		}})
+
+		require.NoError(t, err)
+		require.IsType(t, GetAuditEvents200JSONResponse{}, response)
+		events := response.(GetAuditEvents200JSONResponse)
+		require.Len(t, events, 1)
+		assert.Equal(t, int64(2), events[0].Sequence)
+		assert.Equal(t, timestamp, events[0].Timestamp)
+		assert.Equal(t, "alice", events[0].Actor)
+		assert.Equal(t, "Crypto.SignJwt", events[0].Operation)
+		assert.Equal(t, audit.CryptoSignJWTEvent, events[0].Event)
+		assert.Equal(t, "Crypto", *events[0].Module)
+		assert.Equal(t, "Signing a JWT", events[0].Message)
+		assert.Equal(t, map[string]interface{}{"kid": "key-1"}, *events[0].Fields)
+		assert.Equal(t, "previous", events[0].PreviousHash)
+		assert.Equal(t, "hash", events[0].Hash)
+	})
+	t.Run("paging", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trail := audit.NewMockTrail(ctrl)
+		wrapper := Wrapper{Trail: trail}
+		after := int64(10)
+		limit := 5
+		trail.EXPECT().Events(audit.Query{After: 10, Limit: 5}).Return(nil, nil)
+
+		_, err := wrapper.GetAuditEvents(nil, GetAuditEventsRequestObject{Params: GetAuditEventsParams{After: &after, Limit: &limit}})
+
+		require.NoError(t, err)
+	})
+	t.Run("invalid limit", func(t *testing.T) {
+		wrapper := Wrapper{}
+		limit := audit.MaxQueryLimit + 1
+
+		_, err := wrapper.GetAuditEvents(nil, GetAuditEventsRequestObject{Params: GetAuditEventsParams{Limit: &limit}})
+
+		assert.EqualError(t, err, "limit must be between 1 and 1000")
+	})
+	t.Run("invalid after", func(t *testing.T) {
+		wrapper := Wrapper{}
+		after := int64(-1)
+
+		_, err := wrapper.GetAuditEvents(nil, GetAuditEventsRequestObject{Params: GetAuditEventsParams{After: &after}})
+
+		assert.EqualError(t, err, "after must not be negative")
+	})
+	t.Run("no events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trail := audit.NewMockTrail(ctrl)
+		wrapper := Wrapper{Trail: trail}
+		trail.EXPECT().Events(audit.Query{}).Return(nil, nil)
+
+		response, err := wrapper.GetAuditEvents(nil, GetAuditEventsRequestObject{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, response)
+		assert.Empty(t, response)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trail := audit.NewMockTrail(ctrl)
+		wrapper := Wrapper{Trail: trail}
+		trail.EXPECT().Events(gomock.Any()).Return(nil, audit.ErrNoSinks)
+
+		_, err := wrapper.GetAuditEvents(nil, GetAuditEventsRequestObject{})
+
+		assert.ErrorIs(t, err, audit.ErrNoSinks)
+	})
+}
+
+func TestWrapper_ResolveStatusCode(t *testing.T) {
+	expected := map[error]int{
+		audit.ErrNoSinks:  http.StatusNotFound,
+		errors.New("foo"): http.StatusInternalServerError,
+	}
+	wrapper := Wrapper{}
+	for err, expectedCode := range expected {
+		t.Run(err.Error(), func(t *testing.T) {
+			assert.Equal(t, expectedCode, wrapper.ResolveStatusCode(err))
+		})
+	}
+}
@@ -51,6 +51,12 @@ const (
 	InvalidOAuthTokenEvent = "InvalidOAuthToken"
 	// VerifiableCredentialRetrievedEvent occurs when a VC is retrieved by the remote wallet.
 	VerifiableCredentialRetrievedEvent = "VerifiableCredentialRetrievedEvent"
+	// DiscoveryPresentationRemovedEvent occurs when a presentation is removed from a Discovery Service, by an administrator or because it became invalid.
+	DiscoveryPresentationRemovedEvent = "DiscoveryPresentationRemoved"
+	// DiscoverySubjectBlockedEvent occurs when a DID or presentation is blocked from registering on a Discovery Service.
+	DiscoverySubjectBlockedEvent = "DiscoverySubjectBlocked"
+	// DiscoverySubjectUnblockedEvent occurs when a DID or presentation is allowed to register on a Discovery Service again.
+	DiscoverySubjectUnblockedEvent = "DiscoverySubjectUnblocked"
 )
 
 const auditLogLevel = "audit"
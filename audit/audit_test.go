@@ -51,6 +51,11 @@ func TestLog(t *testing.T) {
 
 func Test_auditLogger(t *testing.T) {
 	t.Run("invalid formatter", func(t *testing.T) {
+		formatter := logrus.StandardLogger().Formatter
+		defer func() {
+			logrus.StandardLogger().SetFormatter(formatter)
+			initAuditLoggerOnce = &sync.Once{}
+		}()
 		initAuditLoggerOnce = &sync.Once{}
 		logrus.StandardLogger().SetFormatter(&textAuditFormatter{})
 		assert.Panics(t, func() {
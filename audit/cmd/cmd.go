@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sort"
+)
+
+// FlagSet returns the configuration flags for the audit module.
+func FlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("audit", pflag.ContinueOnError)
+
+	defs := audit.DefaultConfig()
+	flags.StringSlice("audit.sinks", defs.Sinks, fmt.Sprintf("Persistent sinks audit events are written to, in addition to the audit log. "+
+		"Supported sinks: '%s' (append-only file) and '%s' (SQL database). Events in each sink are hash-chained to detect tampering.", audit.SinkFile, audit.SinkSQL))
+	flags.String("audit.file.path", defs.File.Path, "Path of the audit trail file used by the 'file' sink. Defaults to audit/audit.log in the data directory.")
+
+	return flags
+}
+
+// ServerCmd returns the CLI commands for the audit module that use the server configuration.
+func ServerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "audit commands",
+	}
+	cmd.AddCommand(verifyCommand())
+	return cmd
+}
+
+func verifyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the hash chain of the persistent audit trail.",
+		Long: "Verifies the hash chain of the events in the configured audit sinks, to detect altered, removed or inserted events. " +
+			"Can only be run on the local Nuts node, from the directory where nuts.yaml resides.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			instance, storageInstance, err := loadAuditModule(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = instance.Shutdown()
+				_ = storageInstance.Shutdown()
+			}()
+			counts, err := instance.Verify()
+			if err != nil {
+				return err
+			}
+			sinks := make([]string, 0, len(counts))
+			for sink := range counts {
+				sinks = append(sinks, sink)
+			}
+			sort.Strings(sinks)
+			for _, sink := range sinks {
+				cmd.Println(fmt.Sprintf("Verified %d events in %s audit sink", counts[sink], sink))
+			}
+			return nil
+		},
+	}
+}
+
+// loadAuditModule creates an audit module instance and configures it using the given server root command.
+// The storage module is configured as well, since it provides the SQL database used by the SQL sink.
+// The caller is responsible for shutting down both modules.
+func loadAuditModule(cmd *cobra.Command) (*audit.Engine, storage.Engine, error) {
+	cfg := core.NewServerConfig()
+	err := cfg.Load(cmd.Flags())
+	if err != nil {
+		return nil, nil, err
+	}
+	storageInstance := storage.New()
+	if err = cfg.InjectIntoEngine(storageInstance.(core.Injectable)); err != nil {
+		return nil, nil, err
+	}
+	if err = storageInstance.Configure(*cfg); err != nil {
+		return nil, nil, err
+	}
+	instance := audit.New(storageInstance)
+	if err = cfg.InjectIntoEngine(instance); err == nil {
+		err = instance.Configure(*cfg)
+	}
+	if err != nil {
+		_ = storageInstance.Shutdown()
+		return nil, nil, err
+	}
+	return instance, storageInstance, nil
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	testIo "github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestFlagSet(t *testing.T) {
+	flags := FlagSet()
+
+	var keys []string
+	flags.VisitAll(func(flag *pflag.Flag) {
+		keys = append(keys, flag.Name)
+	})
+	sort.Strings(keys)
+
+	assert.Equal(t, []string{"audit.file.path", "audit.sinks"}, keys)
+}
+
+func Test_verifyCommand(t *testing.T) {
+	setup := func(t *testing.T) string {
+		dataDir := testIo.TestDirectory(t)
+		sink, err := audit.NewFileSink(path.Join(dataDir, "audit", "audit.log"))
+		require.NoError(t, err)
+		defer sink.Close()
+		for i := 0; i < 2; i++ {
+			_, err = sink.Append(audit.Event{
+				Timestamp: time.Now(),
+				Actor:     "alice",
+				Operation: "Crypto.SignJwt",
+				Event:     audit.CryptoSignJWTEvent,
+				Message:   "Signing a JWT",
+			})
+			require.NoError(t, err)
+		}
+		return dataDir
+	}
+	execute := func(args ...string) (string, error) {
+		outBuf := new(bytes.Buffer)
+		auditCmd := ServerCmd()
+		for _, cmd := range auditCmd.Commands() {
+			cmd.Flags().AddFlagSet(core.FlagSet())
+			cmd.Flags().AddFlagSet(FlagSet())
+		}
+		auditCmd.SetOut(outBuf)
+		auditCmd.SetErr(outBuf)
+		auditCmd.SetArgs(append([]string{"verify"}, args...))
+		err := auditCmd.Execute()
+		return outBuf.String(), err
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		dataDir := setup(t)
+
+		output, err := execute("--datadir", dataDir, "--audit.sinks", "file,sql")
+
+		require.NoError(t, err)
+		assert.Contains(t, output, "Verified 2 events in file audit sink")
+		assert.Contains(t, output, "Verified 0 events in sql audit sink")
+	})
+	t.Run("tampered", func(t *testing.T) {
+		dataDir := setup(t)
+		filePath := path.Join(dataDir, "audit", "audit.log")
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		data = bytes.Replace(data, []byte(`"actor":"alice"`), []byte(`"actor":"bob"`), 1)
+		require.NoError(t, os.WriteFile(filePath, data, 0600))
+
+		_, err = execute("--datadir", dataDir, "--audit.sinks", "file")
+
+		assert.ErrorIs(t, err, audit.ErrInvalidChain)
+		assert.ErrorContains(t, err, "file audit sink")
+	})
+	t.Run("no sinks", func(t *testing.T) {
+		_, err := execute("--datadir", testIo.TestDirectory(t))
+
+		assert.ErrorIs(t, err, audit.ErrNoSinks)
+	})
+}
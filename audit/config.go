@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+const (
+	// SinkFile is the sink type that writes audit events to an append-only file.
+	SinkFile = "file"
+	// SinkSQL is the sink type that writes audit events to the SQL database.
+	SinkSQL = "sql"
+)
+
+// Config holds the configuration of the persistent audit trail.
+type Config struct {
+	// Sinks specifies the sinks audit events are written to. If empty, audit events are only logged.
+	Sinks []string `koanf:"sinks"`
+	// File holds the configuration of the file sink.
+	File FileSinkConfig `koanf:"file"`
+}
+
+// FileSinkConfig holds the configuration of the file sink.
+type FileSinkConfig struct {
+	// Path is the path of the audit trail file. If empty, it defaults to audit/audit.log in the data directory.
+	Path string `koanf:"path"`
+}
+
+// DefaultConfig returns the default configuration of the audit engine.
+func DefaultConfig() Config {
+	return Config{}
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/sirupsen/logrus"
+	"path"
+	"sync/atomic"
+)
+
+// ModuleName is the name of the audit engine.
+const ModuleName = "Audit"
+
+// ErrNoSinks is returned when the persistent audit trail is queried or verified, but no audit sinks are configured.
+var ErrNoSinks = errors.New("no audit sinks configured")
+
+var _logger = logrus.StandardLogger().WithField(core.LogFieldModule, "audit")
+
+var _ Trail = (*Engine)(nil)
+var _ core.Engine = (*Engine)(nil)
+var _ core.Configurable = (*Engine)(nil)
+var _ core.Runnable = (*Engine)(nil)
+var _ core.Injectable = (*Engine)(nil)
+var _ core.Diagnosable = (*Engine)(nil)
+
+// Engine writes audit events to the configured persistent audit sinks.
+type Engine struct {
+	config        Config
+	storageEngine storage.Engine
+	sinks         []Sink
+	hook          *sinkHook
+}
+
+// New creates a new audit engine.
+func New(storageEngine storage.Engine) *Engine {
+	return &Engine{
+		config:        DefaultConfig(),
+		storageEngine: storageEngine,
+	}
+}
+
+func (e *Engine) Name() string {
+	return ModuleName
+}
+
+func (e *Engine) Config() interface{} {
+	return &e.config
+}
+
+func (e *Engine) Configure(config core.ServerConfig) error {
+	for _, sinkType := range e.config.Sinks {
+		var sink Sink
+		switch sinkType {
+		case SinkFile:
+			filePath := e.config.File.Path
+			if filePath == "" {
+				filePath = path.Join(config.Datadir, "audit", "audit.log")
+			}
+			var err error
+			sink, err = NewFileSink(filePath)
+			if err != nil {
+				return err
+			}
+		case SinkSQL:
+			sink = NewSQLSink(e.storageEngine.GetSQLDatabase())
+		default:
+			return fmt.Errorf("invalid audit sink: %s", sinkType)
+		}
+		e.sinks = append(e.sinks, sink)
+	}
+	return nil
+}
+
+func (e *Engine) Start() error {
+	if len(e.sinks) == 0 {
+		return nil
+	}
+	e.hook = &sinkHook{sinks: e.sinks}
+	auditLogger().AddHook(e.hook)
+	return nil
+}
+
+func (e *Engine) Shutdown() error {
+	if e.hook != nil {
+		removeHook(auditLogger(), e.hook)
+		e.hook = nil
+	}
+	var errs []error
+	for _, sink := range e.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	e.sinks = nil
+	return errors.Join(errs...)
+}
+
+// Events returns the audit events matching the query from the first configured audit sink.
+func (e *Engine) Events(query Query) ([]Event, error) {
+	if len(e.sinks) == 0 {
+		return nil, ErrNoSinks
+	}
+	return e.sinks[0].Events(query)
+}
+
+// Verify checks the hash chain of all configured audit sinks. It returns the number of verified events per sink type.
+func (e *Engine) Verify() (map[string]uint64, error) {
+	if len(e.sinks) == 0 {
+		return nil, ErrNoSinks
+	}
+	result := make(map[string]uint64)
+	for i, sink := range e.sinks {
+		count, err := Verify(sink)
+		if err != nil {
+			return nil, fmt.Errorf("%s audit sink: %w", e.config.Sinks[i], err)
+		}
+		result[e.config.Sinks[i]] = count
+	}
+	return result, nil
+}
+
+// Diagnostics returns the number of audit events that couldn't be written to the audit sinks since the node started.
+func (e *Engine) Diagnostics() []core.DiagnosticResult {
+	if e.hook == nil {
+		return nil
+	}
+	return []core.DiagnosticResult{
+		core.GenericDiagnosticResult{Title: "failed_writes", Outcome: e.hook.failedWrites.Load()},
+	}
+}
+
+// sinkHook is a logrus.Hook that writes audit log entries to the audit sinks.
+type sinkHook struct {
+	sinks []Sink
+	// failedWrites counts the events that couldn't be written to an audit sink.
+	failedWrites atomic.Uint64
+}
+
+func (s *sinkHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire writes the entry to all audit sinks. Failures are logged and counted (see Engine.Diagnostics),
+// and returned so logrus reports them as well.
+func (s *sinkHook) Fire(entry *logrus.Entry) error {
+	event := newEvent(entry)
+	var errs []error
+	for _, sink := range s.sinks {
+		if _, err := sink.Append(event); err != nil {
+			s.failedWrites.Add(1)
+			_logger.WithError(err).
+				WithField("event", event.Event).
+				Error("Failed to write event to audit sink")
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// removeHook removes the given hook from the logger.
+func removeHook(logger *logrus.Logger, hook logrus.Hook) {
+	hooks := make(logrus.LevelHooks)
+	for level, levelHooks := range logger.Hooks {
+		for _, curr := range levelHooks {
+			if curr != hook {
+				hooks[level] = append(hooks[level], curr)
+			}
+		}
+	}
+	logger.ReplaceHooks(hooks)
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"errors"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestEngine_Configure(t *testing.T) {
+	t.Run("no sinks", func(t *testing.T) {
+		engine := New(nil)
+
+		err := engine.Configure(core.TestServerConfig())
+
+		require.NoError(t, err)
+		assert.Empty(t, engine.sinks)
+	})
+	t.Run("file sink in data directory", func(t *testing.T) {
+		dataDir := io.TestDirectory(t)
+		engine := New(nil)
+		engine.config.Sinks = []string{SinkFile}
+
+		err := engine.Configure(core.TestServerConfig(func(config *core.ServerConfig) {
+			config.Datadir = dataDir
+		}))
+
+		require.NoError(t, err)
+		defer engine.Shutdown()
+		assert.Len(t, engine.sinks, 1)
+		assert.FileExists(t, path.Join(dataDir, "audit", "audit.log"))
+	})
+	t.Run("file sink with configured path", func(t *testing.T) {
+		filePath := path.Join(io.TestDirectory(t), "trail.log")
+		engine := New(nil)
+		engine.config.Sinks = []string{SinkFile}
+		engine.config.File.Path = filePath
+
+		err := engine.Configure(core.TestServerConfig())
+
+		require.NoError(t, err)
+		defer engine.Shutdown()
+		assert.FileExists(t, filePath)
+	})
+	t.Run("sql sink", func(t *testing.T) {
+		engine := New(storage.NewTestStorageEngine(t))
+		engine.config.Sinks = []string{SinkSQL}
+
+		err := engine.Configure(core.TestServerConfig())
+
+		require.NoError(t, err)
+		assert.Len(t, engine.sinks, 1)
+	})
+	t.Run("invalid sink", func(t *testing.T) {
+		engine := New(nil)
+		engine.config.Sinks = []string{"syslog"}
+
+		err := engine.Configure(core.TestServerConfig())
+
+		assert.EqualError(t, err, "invalid audit sink: syslog")
+	})
+	t.Run("file sink can't be opened", func(t *testing.T) {
+		dir := io.TestDirectory(t)
+		engine := New(nil)
+		engine.config.Sinks = []string{SinkFile}
+		engine.config.File.Path = dir // directory, not a file
+
+		err := engine.Configure(core.TestServerConfig())
+
+		assert.ErrorContains(t, err, "unable to open audit trail")
+	})
+}
+
+func TestEngine_audit(t *testing.T) {
+	engine := New(storage.NewTestStorageEngine(t))
+	engine.config.Sinks = []string{SinkFile, SinkSQL}
+	engine.config.File.Path = path.Join(io.TestDirectory(t), "audit.log")
+	require.NoError(t, engine.Configure(core.TestServerConfig()))
+	require.NoError(t, engine.Start())
+	defer engine.Shutdown()
+
+	Log(TestContext(), logrus.StandardLogger().WithField(core.LogFieldModule, "Crypto").WithField("kid", "key-1"), CryptoSignJWTEvent).Info("Signing a JWT")
+	Log(Context(TestContext(), "other-actor", "Crypto", "SignJws"), logrus.StandardLogger().WithField(core.LogFieldModule, "Crypto"), CryptoSignJWSEvent).Info("Signing a JWS")
+
+	t.Run("events are written to all sinks", func(t *testing.T) {
+		for i, sink := range engine.sinks {
+			events, err := sink.Events(Query{})
+			require.NoError(t, err)
+			require.Len(t, events, 2, engine.config.Sinks[i])
+			assert.Equal(t, TestActor, events[0].Actor)
+			assert.Equal(t, "TestModule.TestOperation", events[0].Operation)
+			assert.Equal(t, CryptoSignJWTEvent, events[0].Event)
+			assert.Equal(t, "Crypto", events[0].Module)
+			assert.Equal(t, "Signing a JWT", events[0].Message)
+			assert.Equal(t, map[string]interface{}{"kid": "key-1"}, events[0].Fields)
+		}
+	})
+	t.Run("Events", func(t *testing.T) {
+		events, err := engine.Events(Query{Actor: "other-actor"})
+
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "Crypto.SignJws", events[0].Operation)
+	})
+	t.Run("Verify", func(t *testing.T) {
+		counts, err := engine.Verify()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]uint64{SinkFile: 2, SinkSQL: 2}, counts)
+	})
+	t.Run("Verify detects tampering", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(engine.config.File.Path, []byte("{}\n"), 0600))
+
+		_, err := engine.Verify()
+
+		assert.ErrorIs(t, err, ErrInvalidChain)
+		assert.ErrorContains(t, err, "file audit sink")
+	})
+	t.Run("hook is removed on shutdown", func(t *testing.T) {
+		hook := engine.hook
+		require.NoError(t, engine.Shutdown())
+
+		for _, hooks := range auditLogger().Hooks {
+			assert.NotContains(t, hooks, hook)
+		}
+	})
+}
+
+func TestEngine_noSinks(t *testing.T) {
+	engine := New(nil)
+	require.NoError(t, engine.Configure(core.TestServerConfig()))
+	require.NoError(t, engine.Start())
+
+	_, err := engine.Events(Query{})
+	assert.ErrorIs(t, err, ErrNoSinks)
+	_, err = engine.Verify()
+	assert.ErrorIs(t, err, ErrNoSinks)
+	assert.NoError(t, engine.Shutdown())
+}
+
+func TestEngine_Diagnostics(t *testing.T) {
+	engine := New(nil)
+	engine.sinks = []Sink{failingSink{}}
+	require.NoError(t, engine.Start())
+	defer engine.Shutdown()
+
+	Log(TestContext(), logrus.StandardLogger().WithField(core.LogFieldModule, "Crypto"), CryptoSignJWTEvent).Info("Signing a JWT")
+
+	diagnostics := engine.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "failed_writes", diagnostics[0].Name())
+	assert.Equal(t, uint64(1), diagnostics[0].Result())
+}
+
+// failingSink is a Sink that fails to append events.
+type failingSink struct {
+	Sink
+}
+
+func (f failingSink) Append(_ Event) (Event, error) {
+	return Event{}, errors.New("disk full")
+}
+
+func (f failingSink) Close() error {
+	return nil
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// ErrInvalidChain is returned when the hash chain of an audit trail is broken, indicating the audit trail was tampered with.
+var ErrInvalidChain = errors.New("audit trail hash chain is invalid")
+
+// Event is an audit event as stored in an audit sink.
+// Each event is chained to the previous event in the sink by including the previous event's hash in its own hash.
+type Event struct {
+	// Sequence is the position of the event in the audit trail, starting at 1.
+	Sequence uint64 `json:"sequence"`
+	// Timestamp is the time the event occurred, with millisecond precision.
+	Timestamp time.Time `json:"timestamp"`
+	// Actor is the user or service that performed the operation.
+	Actor string `json:"actor"`
+	// Operation is the name of the operation that was performed.
+	Operation string `json:"operation"`
+	// Event is the name of the audit event (e.g. SignJWT).
+	Event string `json:"event"`
+	// Module is the module that logged the event.
+	Module string `json:"module,omitempty"`
+	// Message is the log message of the event.
+	Message string `json:"message"`
+	// Fields contains the other fields of the log entry.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// PreviousHash is the hash of the previous event in the audit trail, empty for the first event.
+	PreviousHash string `json:"previous_hash"`
+	// Hash is the hex-encoded SHA-256 hash over the event's contents and PreviousHash.
+	Hash string `json:"hash"`
+}
+
+// DefaultQueryLimit is the maximum number of events returned for a query, if the query doesn't specify a limit.
+const DefaultQueryLimit = 100
+
+// MaxQueryLimit is the maximum number of events that can be returned for a query.
+const MaxQueryLimit = 1000
+
+// Query specifies which events to return from an audit sink. Empty fields are not used for filtering.
+type Query struct {
+	// After only returns events with a sequence number greater than the given one, for paging through the audit trail.
+	After uint64
+	// Limit is the maximum number of events to return. If not set (or larger than MaxQueryLimit), DefaultQueryLimit or MaxQueryLimit is used.
+	Limit int
+	// Actor filters events on actor.
+	Actor string
+	// Operation filters events on operation.
+	Operation string
+	// Since filters events that occurred at or after the given time.
+	Since *time.Time
+	// Until filters events that occurred before the given time.
+	Until *time.Time
+}
+
+// Matches returns true if the event matches the query.
+func (q Query) Matches(event Event) bool {
+	if q.After > 0 && event.Sequence <= q.After {
+		return false
+	}
+	if q.Actor != "" && event.Actor != q.Actor {
+		return false
+	}
+	if q.Operation != "" && event.Operation != q.Operation {
+		return false
+	}
+	if q.Since != nil && event.Timestamp.Before(*q.Since) {
+		return false
+	}
+	if q.Until != nil && !event.Timestamp.Before(*q.Until) {
+		return false
+	}
+	return true
+}
+
+// limit returns the maximum number of events to return for the query.
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return DefaultQueryLimit
+	}
+	if q.Limit > MaxQueryLimit {
+		return MaxQueryLimit
+	}
+	return q.Limit
+}
+
+// Sink is a durable store for audit events.
+type Sink interface {
+	// Append adds the event to the end of the audit trail.
+	// It sets the event's sequence number and hash, chaining it to the last event in the sink, and returns the stored event.
+	Append(event Event) (Event, error)
+	// Events returns the events matching the query, ordered by sequence number.
+	// It returns at most the number of events specified by the query's limit.
+	Events(query Query) ([]Event, error)
+	// Walk calls fn for every event in the sink, ordered by sequence number. It stops when fn returns an error, which is then returned.
+	Walk(fn func(event Event) error) error
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// newEvent creates an (unchained) Event from the given audit log entry.
+func newEvent(entry *logrus.Entry) Event {
+	event := Event{
+		Timestamp: entry.Time.UTC().Truncate(time.Millisecond),
+		Message:   entry.Message,
+		Fields:    make(map[string]interface{}),
+	}
+	for key, value := range entry.Data {
+		switch key {
+		case "actor":
+			event.Actor = fmt.Sprintf("%v", value)
+		case "operation":
+			event.Operation = fmt.Sprintf("%v", value)
+		case "event":
+			event.Event = fmt.Sprintf("%v", value)
+		case core.LogFieldModule:
+			event.Module = fmt.Sprintf("%v", value)
+		default:
+			event.Fields[key] = normalizeFieldValue(value)
+		}
+	}
+	if len(event.Fields) == 0 {
+		event.Fields = nil
+	}
+	return event
+}
+
+// normalizeFieldValue converts a log field value to its JSON representation,
+// so the event hashes the same before it is stored and after it's been read back from the sink.
+func normalizeFieldValue(value interface{}) interface{} {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	var result interface{}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return result
+}
+
+// chain sets the sequence number, previous hash and hash of the event, chaining it to the given previous event (nil if it's the first event).
+func chain(previous *Event, event Event) Event {
+	event.Sequence = 1
+	event.PreviousHash = ""
+	if previous != nil {
+		event.Sequence = previous.Sequence + 1
+		event.PreviousHash = previous.Hash
+	}
+	event.Hash = event.computeHash()
+	return event
+}
+
+// computeHash computes the hash over the event's contents, including the previous hash but excluding its own hash.
+func (e Event) computeHash() string {
+	data, _ := json.Marshal(struct {
+		Sequence     uint64                 `json:"sequence"`
+		Timestamp    int64                  `json:"timestamp"`
+		Actor        string                 `json:"actor"`
+		Operation    string                 `json:"operation"`
+		Event        string                 `json:"event"`
+		Module       string                 `json:"module"`
+		Message      string                 `json:"message"`
+		Fields       map[string]interface{} `json:"fields"`
+		PreviousHash string                 `json:"previous_hash"`
+	}{
+		Sequence:     e.Sequence,
+		Timestamp:    e.Timestamp.UnixMilli(),
+		Actor:        e.Actor,
+		Operation:    e.Operation,
+		Event:        e.Event,
+		Module:       e.Module,
+		Message:      e.Message,
+		Fields:       e.Fields,
+		PreviousHash: e.PreviousHash,
+	})
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// Verify checks the hash chain of all events in the sink. It returns the number of verified events.
+// If an event was altered, removed or inserted, an error wrapping ErrInvalidChain is returned.
+func Verify(sink Sink) (uint64, error) {
+	var previous *Event
+	var count uint64
+	err := sink.Walk(func(event Event) error {
+		expectedSequence := uint64(1)
+		expectedPreviousHash := ""
+		if previous != nil {
+			expectedSequence = previous.Sequence + 1
+			expectedPreviousHash = previous.Hash
+		}
+		if event.Sequence != expectedSequence {
+			return fmt.Errorf("%w: expected event %d, found event %d", ErrInvalidChain, expectedSequence, event.Sequence)
+		}
+		if event.PreviousHash != expectedPreviousHash {
+			return fmt.Errorf("%w: event %d does not refer to the hash of the previous event", ErrInvalidChain, event.Sequence)
+		}
+		if event.Hash != event.computeHash() {
+			return fmt.Errorf("%w: hash of event %d does not match its contents", ErrInvalidChain, event.Sequence)
+		}
+		previous = &event
+		count++
+		return nil
+	})
+	return count, err
+}
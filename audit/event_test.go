@@ -0,0 +1,187 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// memorySink is an in-memory Sink, used for testing the hash chain.
+type memorySink struct {
+	events []Event
+}
+
+func (m *memorySink) Append(event Event) (Event, error) {
+	var previous *Event
+	if len(m.events) > 0 {
+		previous = &m.events[len(m.events)-1]
+	}
+	event = chain(previous, event)
+	m.events = append(m.events, event)
+	return event, nil
+}
+
+func (m *memorySink) Events(query Query) ([]Event, error) {
+	var result []Event
+	for _, event := range m.events {
+		if query.Matches(event) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (m *memorySink) Walk(fn func(event Event) error) error {
+	for _, event := range m.events {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memorySink) Close() error {
+	return nil
+}
+
+func testEvent(actor string, operation string, timestamp time.Time) Event {
+	return Event{
+		Timestamp: timestamp.UTC().Truncate(time.Millisecond),
+		Actor:     actor,
+		Operation: operation,
+		Event:     CryptoSignJWTEvent,
+		Module:    "Crypto",
+		Message:   "Signing a JWT",
+		Fields:    map[string]interface{}{"kid": "key-1"},
+	}
+}
+
+func Test_newEvent(t *testing.T) {
+	entry := logrus.NewEntry(logrus.New()).WithFields(logrus.Fields{
+		"actor":     TestActor,
+		"operation": "Crypto.SignJwt",
+		"event":     CryptoSignJWTEvent,
+		"module":    "Crypto",
+		"kid":       "key-1",
+		"count":     2,
+		"error":     errors.New("failed"),
+	})
+	entry.Time = time.Date(2024, 1, 1, 12, 0, 0, 123456789, time.FixedZone("CET", 3600))
+	entry.Message = "Signing a JWT"
+
+	event := newEvent(entry)
+
+	assert.Equal(t, TestActor, event.Actor)
+	assert.Equal(t, "Crypto.SignJwt", event.Operation)
+	assert.Equal(t, CryptoSignJWTEvent, event.Event)
+	assert.Equal(t, "Crypto", event.Module)
+	assert.Equal(t, "Signing a JWT", event.Message)
+	assert.Equal(t, time.Date(2024, 1, 1, 11, 0, 0, 123000000, time.UTC), event.Timestamp)
+	assert.Equal(t, map[string]interface{}{"kid": "key-1", "count": float64(2), "error": "failed"}, event.Fields)
+}
+
+func TestQuery_Matches(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	event := testEvent("alice", "Crypto.SignJwt", now)
+
+	assert.True(t, Query{}.Matches(event))
+	assert.True(t, Query{Actor: "alice", Operation: "Crypto.SignJwt"}.Matches(event))
+	assert.False(t, Query{Actor: "bob"}.Matches(event))
+	assert.False(t, Query{Operation: "Crypto.SignJws"}.Matches(event))
+	assert.True(t, Query{Since: &earlier}.Matches(event))
+	assert.False(t, Query{Until: &earlier}.Matches(event))
+	assert.False(t, Query{Until: &event.Timestamp}.Matches(event), "until is exclusive")
+	assert.True(t, Query{Since: &event.Timestamp}.Matches(event), "since is inclusive")
+	event.Sequence = 2
+	assert.True(t, Query{After: 1}.Matches(event))
+	assert.False(t, Query{After: 2}.Matches(event), "after is exclusive")
+}
+
+func TestQuery_limit(t *testing.T) {
+	assert.Equal(t, DefaultQueryLimit, Query{}.limit())
+	assert.Equal(t, 10, Query{Limit: 10}.limit())
+	assert.Equal(t, MaxQueryLimit, Query{Limit: MaxQueryLimit + 1}.limit())
+}
+
+func TestVerify(t *testing.T) {
+	newSink := func(t *testing.T) *memorySink {
+		sink := &memorySink{}
+		for i := 0; i < 3; i++ {
+			_, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+			require.NoError(t, err)
+		}
+		return sink
+	}
+	t.Run("ok", func(t *testing.T) {
+		sink := newSink(t)
+
+		count, err := Verify(sink)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), count)
+		assert.Empty(t, sink.events[0].PreviousHash)
+		assert.Equal(t, sink.events[0].Hash, sink.events[1].PreviousHash)
+	})
+	t.Run("empty", func(t *testing.T) {
+		count, err := Verify(&memorySink{})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+	t.Run("altered event", func(t *testing.T) {
+		sink := newSink(t)
+		sink.events[1].Actor = "mallory"
+
+		_, err := Verify(sink)
+
+		assert.ErrorIs(t, err, ErrInvalidChain)
+		assert.EqualError(t, err, "audit trail hash chain is invalid: hash of event 2 does not match its contents")
+	})
+	t.Run("altered event with recomputed hash", func(t *testing.T) {
+		sink := newSink(t)
+		sink.events[1].Actor = "mallory"
+		sink.events[1].Hash = sink.events[1].computeHash()
+
+		_, err := Verify(sink)
+
+		assert.EqualError(t, err, "audit trail hash chain is invalid: event 3 does not refer to the hash of the previous event")
+	})
+	t.Run("removed event", func(t *testing.T) {
+		sink := newSink(t)
+		sink.events = append(sink.events[:1], sink.events[2:]...)
+
+		_, err := Verify(sink)
+
+		assert.EqualError(t, err, "audit trail hash chain is invalid: expected event 2, found event 3")
+	})
+	t.Run("removed first event", func(t *testing.T) {
+		sink := newSink(t)
+		sink.events = sink.events[1:]
+
+		_, err := Verify(sink)
+
+		assert.EqualError(t, err, "audit trail hash chain is invalid: expected event 1, found event 2")
+	})
+}
@@ -0,0 +1,27 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+// Trail provides access to the persistent audit trail.
+type Trail interface {
+	// Events returns the audit events matching the query. It returns ErrNoSinks if no audit sinks are configured.
+	Events(query Query) ([]Event, error)
+	// Verify checks the hash chain of the audit trail. It returns ErrNoSinks if no audit sinks are configured.
+	Verify() (map[string]uint64, error)
+}
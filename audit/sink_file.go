@@ -0,0 +1,247 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sync"
+)
+
+var _ Sink = (*fileSink)(nil)
+
+// maxEventSize is the maximum size of a single event (line) in the audit trail file.
+const maxEventSize = 10 * 1024 * 1024
+
+// readBackwardsChunkSize is the number of bytes read at once when reading the audit trail file backwards from its end.
+const readBackwardsChunkSize = 4096
+
+// errLimitReached is used to stop walking the audit trail when the query limit is reached.
+var errLimitReached = errors.New("limit reached")
+
+// fileSink is a Sink that writes audit events as JSON lines to an append-only file.
+// Every event is synced to disk before Append returns.
+type fileSink struct {
+	path string
+	file *os.File
+	// size is the size of the file after the last complete event.
+	size  int64
+	last  *Event
+	mutex sync.Mutex
+}
+
+// NewFileSink opens (or creates) the audit trail file at the given path.
+// It reads the last event from the file, so new events are chained to it.
+// An incomplete last line, which is left when the node crashed while writing an event, is removed.
+// Invalid events are not reported here, since they're reported when verifying the audit trail.
+func NewFileSink(filePath string) (Sink, error) {
+	if err := os.MkdirAll(path.Dir(filePath), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("unable to create audit trail directory: %w", err)
+	}
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit trail (path=%s): %w", filePath, err)
+	}
+	result := &fileSink{path: filePath, file: file}
+	result.size, err = removeTornTail(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("unable to recover audit trail (path=%s): %w", filePath, err)
+	}
+	result.last, err = readLastEvent(file, result.size)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("unable to read audit trail (path=%s): %w", filePath, err)
+	}
+	return result, nil
+}
+
+// removeTornTail truncates the file after its last complete line, removing the partially written event that is left
+// when the node crashed while appending an event. It returns the size of the file after truncation.
+// Only the end of the file is read.
+func removeTornTail(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	if size == 0 {
+		return 0, nil
+	}
+	lastByte := make([]byte, 1)
+	if _, err = file.ReadAt(lastByte, size-1); err != nil {
+		return 0, err
+	}
+	if lastByte[0] == '\n' {
+		return size, nil
+	}
+	newSize, err := lineStart(file, size)
+	if err != nil {
+		return 0, err
+	}
+	_logger.Warnf("Audit trail ends with an incomplete event, probably written when the node crashed. Removing it (path=%s, bytes=%d).", file.Name(), size-newSize)
+	return newSize, file.Truncate(newSize)
+}
+
+// readLastEvent returns the last valid event in the file of the given size, or nil if there is none.
+// The file is read backwards from its end, so only the last event(s) are read.
+// Invalid events are skipped: new events are chained to the last valid event, and the invalid ones are reported by Verify.
+func readLastEvent(file *os.File, size int64) (*Event, error) {
+	invalid := 0
+	defer func() {
+		if invalid > 0 {
+			_logger.Errorf("Audit trail ends with %d invalid event(s), verify the audit trail (path=%s)", invalid, file.Name())
+		}
+	}()
+	// end is the offset just after the newline that terminates the current line
+	for end := size; end > 0; {
+		start, err := lineStart(file, end-1)
+		if err != nil {
+			return nil, err
+		}
+		if end-start > maxEventSize {
+			return nil, bufio.ErrTooLong
+		}
+		line := make([]byte, end-start)
+		if _, err = file.ReadAt(line, start); err != nil {
+			return nil, err
+		}
+		var event Event
+		if err := json.Unmarshal(line, &event); err == nil {
+			return &event, nil
+		}
+		invalid++
+		end = start
+	}
+	return nil, nil
+}
+
+// lineStart returns the offset of the line that contains the byte before offset end:
+// the offset just after the last newline before end, or 0 if there is none.
+// It reads the file backwards in chunks.
+func lineStart(file *os.File, end int64) (int64, error) {
+	buf := make([]byte, readBackwardsChunkSize)
+	for offset := end; offset > 0; {
+		n := min(int64(len(buf)), offset)
+		offset -= n
+		if _, err := file.ReadAt(buf[:n], offset); err != nil {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			return offset + int64(i) + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (f *fileSink) Append(event Event) (Event, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	event = chain(f.last, event)
+	data, err := json.Marshal(event)
+	if err != nil {
+		return Event{}, err
+	}
+	data = append(data, '\n')
+	if _, err = f.file.Write(data); err != nil {
+		f.truncate()
+		return Event{}, fmt.Errorf("unable to write audit event: %w", err)
+	}
+	if err = f.file.Sync(); err != nil {
+		f.truncate()
+		return Event{}, fmt.Errorf("unable to sync audit trail: %w", err)
+	}
+	f.size += int64(len(data))
+	f.last = &event
+	return event, nil
+}
+
+// truncate removes a (partially) written event after a failed Append, by truncating the file back to the newline of the last complete event.
+// Otherwise, the next event would be appended to the partially written event, corrupting both.
+func (f *fileSink) truncate() {
+	if err := os.Truncate(f.path, f.size); err != nil {
+		_logger.WithError(err).Errorf("Unable to remove partially written audit event, verify the audit trail (path=%s)", f.path)
+	}
+}
+
+func (f *fileSink) Events(query Query) ([]Event, error) {
+	var result []Event
+	limit := query.limit()
+	err := f.Walk(func(event Event) error {
+		if query.Matches(event) {
+			result = append(result, event)
+		}
+		if len(result) == limit {
+			return errLimitReached
+		}
+		return nil
+	})
+	if errors.Is(err, errLimitReached) {
+		err = nil
+	}
+	return result, err
+}
+
+func (f *fileSink) Walk(fn func(event Event) error) error {
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(data) == 0 {
+				return nil
+			}
+			// last line is incomplete, which happens when the node crashed while writing.
+			// Treat it as a regular line, so Verify reports it if it's not a valid event.
+		} else if err != nil {
+			return err
+		}
+		var event Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("%w: invalid event on line %d: %s", ErrInvalidChain, line, err)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
+
+func (f *fileSink) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSink tests the Sink contract, used for all Sink implementations.
+func testSink(t *testing.T, sink Sink) {
+	now := time.Now()
+	_, err := sink.Append(testEvent("alice", "Crypto.SignJwt", now.Add(-2*time.Hour)))
+	require.NoError(t, err)
+	_, err = sink.Append(testEvent("bob", "Crypto.SignJwt", now.Add(-time.Hour)))
+	require.NoError(t, err)
+	last, err := sink.Append(testEvent("alice", "Crypto.SignJws", now))
+	require.NoError(t, err)
+
+	t.Run("append", func(t *testing.T) {
+		assert.Equal(t, uint64(3), last.Sequence)
+		assert.NotEmpty(t, last.PreviousHash)
+		assert.Equal(t, last.computeHash(), last.Hash)
+	})
+	t.Run("verify", func(t *testing.T) {
+		count, err := Verify(sink)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), count)
+	})
+	t.Run("query", func(t *testing.T) {
+		events, err := sink.Events(Query{})
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, last, events[2])
+
+		events, err = sink.Events(Query{Actor: "alice"})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, uint64(1), events[0].Sequence)
+		assert.Equal(t, uint64(3), events[1].Sequence)
+
+		events, err = sink.Events(Query{Operation: "Crypto.SignJwt"})
+		require.NoError(t, err)
+		assert.Len(t, events, 2)
+
+		since := now.Add(-90 * time.Minute)
+		until := now.Add(-time.Minute)
+		events, err = sink.Events(Query{Since: &since, Until: &until})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "bob", events[0].Actor)
+	})
+	t.Run("query with limit and after", func(t *testing.T) {
+		events, err := sink.Events(Query{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, uint64(2), events[1].Sequence)
+
+		events, err = sink.Events(Query{After: events[1].Sequence, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, last, events[0])
+	})
+}
+
+func TestFileSink(t *testing.T) {
+	filePath := path.Join(io.TestDirectory(t), "audit", "audit.log")
+	sink, err := NewFileSink(filePath)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	testSink(t, sink)
+
+	t.Run("chain continues after reopening", func(t *testing.T) {
+		require.NoError(t, sink.Close())
+		sink, err = NewFileSink(filePath)
+		require.NoError(t, err)
+
+		event, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), event.Sequence)
+		count, err := Verify(sink)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), count)
+	})
+	t.Run("file permissions", func(t *testing.T) {
+		info, err := os.Stat(filePath)
+
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+}
+
+func TestFileSink_tampering(t *testing.T) {
+	setup := func(t *testing.T) (string, []string) {
+		filePath := path.Join(io.TestDirectory(t), "audit.log")
+		sink, err := NewFileSink(filePath)
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			_, err = sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+			require.NoError(t, err)
+		}
+		require.NoError(t, sink.Close())
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		return filePath, strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+	verify := func(t *testing.T, filePath string, lines []string) error {
+		require.NoError(t, os.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+		sink := &fileSink{path: filePath}
+		_, err := Verify(sink)
+		return err
+	}
+
+	t.Run("altered event", func(t *testing.T) {
+		filePath, lines := setup(t)
+		lines[1] = strings.Replace(lines[1], `"actor":"alice"`, `"actor":"mallory"`, 1)
+
+		err := verify(t, filePath, lines)
+
+		assert.EqualError(t, err, "audit trail hash chain is invalid: hash of event 2 does not match its contents")
+	})
+	t.Run("removed event", func(t *testing.T) {
+		filePath, lines := setup(t)
+
+		err := verify(t, filePath, append(lines[:1], lines[2:]...))
+
+		assert.EqualError(t, err, "audit trail hash chain is invalid: expected event 2, found event 3")
+	})
+	t.Run("invalid line", func(t *testing.T) {
+		filePath, lines := setup(t)
+		lines[2] = lines[2][:10]
+
+		err := verify(t, filePath, lines)
+
+		assert.ErrorIs(t, err, ErrInvalidChain)
+		assert.ErrorContains(t, err, "invalid event on line 3")
+	})
+	t.Run("opening tampered file succeeds, verify reports it", func(t *testing.T) {
+		filePath, lines := setup(t)
+		lines[1] = "not JSON"
+		require.NoError(t, os.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+		sink, err := NewFileSink(filePath)
+
+		require.NoError(t, err)
+		defer sink.Close()
+		event, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), event.Sequence, "should be chained to the last valid event")
+		_, err = Verify(sink)
+		assert.ErrorIs(t, err, ErrInvalidChain)
+		assert.ErrorContains(t, err, "invalid event on line 2")
+	})
+	t.Run("incomplete last event is removed on open", func(t *testing.T) {
+		filePath, lines := setup(t)
+		// node crashed while writing the 4th event
+		require.NoError(t, os.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"+lines[2][:10]), 0600))
+
+		sink, err := NewFileSink(filePath)
+
+		require.NoError(t, err)
+		defer sink.Close()
+		event, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), event.Sequence)
+		count, err := Verify(sink)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), count)
+	})
+	t.Run("incomplete last event larger than read chunk is removed on open", func(t *testing.T) {
+		filePath, lines := setup(t)
+		require.NoError(t, os.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"+strings.Repeat("x", 3*readBackwardsChunkSize)), 0600))
+
+		sink, err := NewFileSink(filePath)
+
+		require.NoError(t, err)
+		defer sink.Close()
+		event, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), event.Sequence)
+		count, err := Verify(sink)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), count)
+	})
+	t.Run("invalid last event is skipped on open", func(t *testing.T) {
+		filePath, lines := setup(t)
+		lines[2] = "not JSON"
+		require.NoError(t, os.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+		sink, err := NewFileSink(filePath)
+
+		require.NoError(t, err)
+		defer sink.Close()
+		event, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), event.Sequence, "should be chained to the last valid event")
+	})
+}
+
+func TestFileSink_Append(t *testing.T) {
+	t.Run("partially written event is removed when writing fails", func(t *testing.T) {
+		filePath := path.Join(io.TestDirectory(t), "audit.log")
+		s, err := NewFileSink(filePath)
+		require.NoError(t, err)
+		defer s.Close()
+		_, err = s.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+		require.NoError(t, err)
+		sink := s.(*fileSink)
+		// simulate a partial write, followed by a write error
+		require.NoError(t, sink.file.Close())
+		sink.file, err = os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = sink.file.WriteString(`{"partial`)
+		require.NoError(t, err)
+		require.NoError(t, sink.file.Close())
+		sink.file, err = os.Open(filePath)
+		require.NoError(t, err)
+
+		_, err = sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+
+		assert.ErrorContains(t, err, "unable to write audit event")
+		count, err := Verify(sink)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), count)
+	})
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+var _ Sink = (*sqlSink)(nil)
+
+// sqlAppendAttempts is the number of times an append is attempted when another node appended an event with the same sequence number.
+const sqlAppendAttempts = 5
+
+// sqlWalkBatchSize is the number of events that are read from the database at once when walking the audit trail.
+const sqlWalkBatchSize = 1000
+
+type sqlEvent struct {
+	Sequence     uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Timestamp    int64
+	Actor        string
+	Operation    string
+	Event        string
+	Module       string
+	Message      string
+	Fields       string
+	PreviousHash string
+	Hash         string
+}
+
+func (s sqlEvent) TableName() string {
+	return "audit_event"
+}
+
+func (s sqlEvent) toEvent() (Event, error) {
+	result := Event{
+		Sequence:     s.Sequence,
+		Timestamp:    time.UnixMilli(s.Timestamp).UTC(),
+		Actor:        s.Actor,
+		Operation:    s.Operation,
+		Event:        s.Event,
+		Module:       s.Module,
+		Message:      s.Message,
+		PreviousHash: s.PreviousHash,
+		Hash:         s.Hash,
+	}
+	if s.Fields != "" {
+		if err := json.Unmarshal([]byte(s.Fields), &result.Fields); err != nil {
+			return Event{}, fmt.Errorf("%w: invalid fields of event %d: %s", ErrInvalidChain, s.Sequence, err)
+		}
+	}
+	return result, nil
+}
+
+// sqlSink is a Sink that stores audit events in the SQL database.
+// It can be shared by multiple nodes, which then write to the same audit trail.
+type sqlSink struct {
+	db *gorm.DB
+	// mutex serializes appends of this node. SQLite allows only 1 active write transaction at any time (failing any other),
+	// and for other databases it reduces sequence number conflicts, which then only occur when multiple nodes append concurrently.
+	mutex sync.Mutex
+}
+
+// NewSQLSink creates a Sink that stores audit events in the given SQL database.
+func NewSQLSink(db *gorm.DB) Sink {
+	return &sqlSink{db: db}
+}
+
+func (s *sqlSink) Append(event Event) (Event, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var err error
+	for i := 0; i < sqlAppendAttempts; i++ {
+		var result Event
+		result, err = s.append(event)
+		if err == nil {
+			return result, nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			break
+		}
+		// another node appended an event concurrently, retry with the new last event
+	}
+	return Event{}, fmt.Errorf("unable to store audit event: %w", err)
+}
+
+func (s *sqlSink) append(event Event) (Event, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var last []sqlEvent
+		if err := tx.Order("sequence desc").Limit(1).Find(&last).Error; err != nil {
+			return err
+		}
+		var previous *Event
+		if len(last) > 0 {
+			previous = &Event{Sequence: last[0].Sequence, Hash: last[0].Hash}
+		}
+		event = chain(previous, event)
+		var fields string
+		if event.Fields != nil {
+			data, err := json.Marshal(event.Fields)
+			if err != nil {
+				return err
+			}
+			fields = string(data)
+		}
+		return tx.Create(&sqlEvent{
+			Sequence:     event.Sequence,
+			Timestamp:    event.Timestamp.UnixMilli(),
+			Actor:        event.Actor,
+			Operation:    event.Operation,
+			Event:        event.Event,
+			Module:       event.Module,
+			Message:      event.Message,
+			Fields:       fields,
+			PreviousHash: event.PreviousHash,
+			Hash:         event.Hash,
+		}).Error
+	})
+	return event, err
+}
+
+func (s *sqlSink) Events(query Query) ([]Event, error) {
+	tx := s.db.Model(&sqlEvent{})
+	if query.Actor != "" {
+		tx = tx.Where("actor = ?", query.Actor)
+	}
+	if query.Operation != "" {
+		tx = tx.Where("operation = ?", query.Operation)
+	}
+	if query.Since != nil {
+		tx = tx.Where("timestamp >= ?", query.Since.UnixMilli())
+	}
+	if query.Until != nil {
+		tx = tx.Where("timestamp < ?", query.Until.UnixMilli())
+	}
+	if query.After > 0 {
+		tx = tx.Where("sequence > ?", query.After)
+	}
+	var rows []sqlEvent
+	if err := tx.Order("sequence asc").Limit(query.limit()).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make([]Event, 0, len(rows))
+	for _, row := range rows {
+		event, err := row.toEvent()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+func (s *sqlSink) Walk(fn func(event Event) error) error {
+	var after uint64
+	for {
+		var rows []sqlEvent
+		if err := s.db.Where("sequence > ?", after).Order("sequence asc").Limit(sqlWalkBatchSize).Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			event, err := row.toEvent()
+			if err != nil {
+				return err
+			}
+			if err = fn(event); err != nil {
+				return err
+			}
+			after = row.Sequence
+		}
+		if len(rows) < sqlWalkBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *sqlSink) Close() error {
+	// database connection is owned by the storage engine
+	return nil
+}
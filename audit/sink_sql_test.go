@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSQLSink(t *testing.T) {
+	db := storage.NewTestStorageEngine(t).GetSQLDatabase()
+	sink := NewSQLSink(db)
+
+	testSink(t, sink)
+
+	t.Run("altered event", func(t *testing.T) {
+		require.NoError(t, db.Model(&sqlEvent{}).Where("sequence = ?", 2).Update("actor", "mallory").Error)
+
+		_, err := Verify(sink)
+
+		assert.EqualError(t, err, "audit trail hash chain is invalid: hash of event 2 does not match its contents")
+	})
+	t.Run("removed event", func(t *testing.T) {
+		require.NoError(t, db.Where("sequence = ?", 2).Delete(&sqlEvent{}).Error)
+
+		_, err := Verify(sink)
+
+		assert.EqualError(t, err, "audit trail hash chain is invalid: expected event 2, found event 3")
+	})
+}
+
+func TestSQLSink_Append(t *testing.T) {
+	t.Run("concurrent appends", func(t *testing.T) {
+		db := storage.NewTestStorageEngine(t).GetSQLDatabase()
+		sink := NewSQLSink(db)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		count, err := Verify(sink)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), count)
+	})
+	t.Run("walk in batches", func(t *testing.T) {
+		db := storage.NewTestStorageEngine(t).GetSQLDatabase()
+		sink := NewSQLSink(db)
+		for i := 0; i < sqlWalkBatchSize+1; i++ {
+			_, err := sink.Append(testEvent("alice", "Crypto.SignJwt", time.Now()))
+			require.NoError(t, err)
+		}
+
+		count, err := Verify(sink)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(sqlWalkBatchSize+1), count)
+	})
+}
@@ -72,6 +72,10 @@ func (m *mockAuthClient) V2APIEnabled() bool {
 	return true
 }
 
+func (m *mockAuthClient) AccessTokenFormat() string {
+	return pkg2.AccessTokenFormatOpaque
+}
+
 func (m *mockAuthClient) AuthzServer() oauth.AuthorizationServer {
 	return m.authzServer
 }
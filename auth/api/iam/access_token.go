@@ -0,0 +1,113 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/didweb"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// accessTokenJWTType is the typ header of JWT access tokens, as specified by RFC9068.
+const accessTokenJWTType = "at+jwt"
+
+// signAccessToken creates a JWT access token as specified by RFC9068, signed with the assertion key of the issuer.
+// It contains the grant of the given access token, so resource servers can validate it without introspection using the issuer's JWKS.
+// The token value of the given access token is used as JWT ID and the client is the subject.
+// The audience is the issuer's DID: the tenant whose resources the token grants access to, so its resource servers only accept tokens issued for that tenant.
+func (r Wrapper) signAccessToken(ctx context.Context, accessToken AccessToken) (string, error) {
+	issuer, err := did.ParseDID(accessToken.Issuer)
+	if err != nil {
+		return "", fmt.Errorf("invalid access token issuer: %w", err)
+	}
+	// iss must match the issuer of the Authorization Server metadata, which is the identity URL of the issuer's did:web DID.
+	issuerURL, err := didweb.DIDToURL(*issuer)
+	if err != nil {
+		return "", fmt.Errorf("invalid access token issuer: %w", err)
+	}
+	keyResolver := resolver.DIDKeyResolver{Resolver: r.vdr.Resolver()}
+	keyID, _, err := keyResolver.ResolveKey(*issuer, nil, resolver.AssertionMethod)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve access token signing key: %w", err)
+	}
+	claims := map[string]interface{}{
+		jwt.IssuerKey:     issuerURL.String(),
+		jwt.SubjectKey:    accessToken.ClientId,
+		jwt.AudienceKey:   issuer.String(),
+		jwt.JwtIDKey:      accessToken.Token,
+		jwt.IssuedAtKey:   accessToken.IssuedAt,
+		jwt.ExpirationKey: accessToken.Expiration,
+		"client_id":       accessToken.ClientId,
+		"scope":           accessToken.Scope,
+	}
+	if accessToken.InputDescriptorConstraintIdMap != nil {
+		claims["input_descriptor_constraint_id_map"] = accessToken.InputDescriptorConstraintIdMap
+	}
+	if accessToken.PresentationDefinition != nil {
+		claims["presentation_definition"] = accessToken.PresentationDefinition
+	}
+	if accessToken.DPoPKeyThumbprint != "" {
+		claims["cnf"] = Cnf{Jkt: accessToken.DPoPKeyThumbprint}
+	}
+	headers := map[string]interface{}{
+		jws.TypeKey: accessTokenJWTType,
+	}
+	return r.jwtSigner.SignJWT(ctx, claims, headers, keyID.String())
+}
+
+// accessTokenKey returns the key of the given access token in the access token store.
+// JWT access tokens are stored by the SHA-256 hash of the JWT, since the JWT itself is too large to be used as key.
+// Deriving the key from the complete JWT (instead of a claim) means a tampered JWT can't be used to look up a stored token.
+// Opaque access tokens are stored by their value.
+func accessTokenKey(token string) string {
+	if strings.Count(token, ".") != 2 {
+		return token
+	}
+	hash := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// verificationMethodToJWK converts the verification method to a JWK for the JWKS endpoint, using the verification method ID as key ID.
+func verificationMethodToJWK(verificationMethod did.VerificationMethod) (map[string]interface{}, error) {
+	key, err := verificationMethod.JWK()
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification method (id=%s): %w", verificationMethod.ID, err)
+	}
+	if err = key.Set(jwk.KeyIDKey, verificationMethod.ID.String()); err != nil {
+		return nil, err
+	}
+	// marshal to JSON to get the JWK representation of the key (e.g. base64url encoded key material)
+	data, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	crypto2 "crypto"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWrapper_issueTokens_JWT(t *testing.T) {
+	accessToken := AccessToken{
+		Token:                          "token-id",
+		Issuer:                         webDID.String(),
+		ClientId:                       holderDID.String(),
+		IssuedAt:                       time.Now(),
+		Expiration:                     time.Now().Add(accessTokenValidity),
+		Scope:                          "read",
+		InputDescriptorConstraintIdMap: map[string]any{"organization_name": "Hospital"},
+		PresentationDefinition:         &PresentationDefinition{Id: "definition"},
+		DPoPKeyThumbprint:              "thumbprint",
+	}
+	refreshToken := RefreshToken{Scope: "read", Expiration: time.Now().Add(refreshTokenValidity)}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.accessTokenFormat = auth.AccessTokenFormatJWT
+		key := createAccessTokenSigningKey(t, ctx)
+
+		response, err := ctx.client.issueTokens(audit.TestContext(), accessToken, refreshToken)
+
+		require.NoError(t, err)
+		token, err := crypto.ParseJWT(response.AccessToken, func(kid string) (crypto2.PublicKey, error) {
+			assert.Equal(t, key.KID(), kid)
+			return key.Public(), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/iam/123", token.Issuer())
+		assert.Equal(t, holderDID.String(), token.Subject())
+		assert.Equal(t, []string{webDID.String()}, token.Audience())
+		assert.Equal(t, "token-id", token.JwtID())
+		clientID, _ := token.Get("client_id")
+		assert.Equal(t, holderDID.String(), clientID)
+		scope, _ := token.Get("scope")
+		assert.Equal(t, "read", scope)
+		constraints, _ := token.Get("input_descriptor_constraint_id_map")
+		assert.Equal(t, map[string]interface{}{"organization_name": "Hospital"}, constraints)
+		definition, _ := token.Get("presentation_definition")
+		assert.Equal(t, "definition", definition.(map[string]interface{})["id"])
+		cnf, _ := token.Get("cnf")
+		assert.Equal(t, map[string]interface{}{"jkt": "thumbprint"}, cnf)
+		message, err := jws.ParseString(response.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "at+jwt", message.Signatures()[0].ProtectedHeaders().Type())
+		t.Run("JWT is stored by its hash", func(t *testing.T) {
+			var storedToken AccessToken
+			require.NoError(t, ctx.client.accessTokenServerStore().Get(accessTokenKey(response.AccessToken), &storedToken))
+			assert.Equal(t, "token-id", storedToken.Token)
+			assert.False(t, ctx.client.accessTokenServerStore().Exists("token-id"))
+			assert.False(t, ctx.client.accessTokenServerStore().Exists(response.AccessToken))
+		})
+		t.Run("JWT can be introspected", func(t *testing.T) {
+			result, err := ctx.client.IntrospectAccessToken(context.Background(), IntrospectAccessTokenRequestObject{Body: &TokenIntrospectionRequest{Token: response.AccessToken}})
+
+			require.NoError(t, err)
+			introspection := result.(IntrospectAccessToken200JSONResponse)
+			assert.True(t, introspection.Active)
+			assert.Equal(t, holderDID.String(), *introspection.ClientId)
+		})
+		t.Run("tampered JWT can't be introspected", func(t *testing.T) {
+			parts := strings.Split(response.AccessToken, ".")
+			payload, err := json.Marshal(map[string]interface{}{"jti": "token-id", "scope": "write"})
+			require.NoError(t, err)
+			tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+
+			result, err := ctx.client.IntrospectAccessToken(context.Background(), IntrospectAccessTokenRequestObject{Body: &TokenIntrospectionRequest{Token: tampered}})
+
+			assert.Error(t, err)
+			assert.False(t, result.(IntrospectAccessToken200JSONResponse).Active)
+		})
+		t.Run("JWT can be revoked", func(t *testing.T) {
+			require.NoError(t, ctx.client.revokeToken(webDID, holderDID.String(), response.AccessToken))
+
+			assert.False(t, ctx.client.accessTokenServerStore().Exists(accessTokenKey(response.AccessToken)))
+		})
+	})
+	t.Run("JWT is revoked along with its refresh token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.accessTokenFormat = auth.AccessTokenFormatJWT
+		createAccessTokenSigningKey(t, ctx)
+		response, err := ctx.client.issueTokens(audit.TestContext(), accessToken, refreshToken)
+		require.NoError(t, err)
+
+		require.NoError(t, ctx.client.revokeToken(webDID, holderDID.String(), *response.RefreshToken))
+
+		assert.False(t, ctx.client.accessTokenServerStore().Exists(accessTokenKey(response.AccessToken)))
+	})
+	t.Run("error - no signing key", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.accessTokenFormat = auth.AccessTokenFormatJWT
+		ctx.resolver.EXPECT().Resolve(webDID, gomock.Any()).Return(&did.Document{ID: webDID}, &resolver.DocumentMetadata{}, nil)
+
+		response, err := ctx.client.issueTokens(audit.TestContext(), accessToken, refreshToken)
+
+		assert.ErrorIs(t, err, resolver.ErrKeyNotFound)
+		assert.Nil(t, response)
+	})
+}
+
+// createAccessTokenSigningKey creates a key in the test keystore and sets up the DID document of webDID to contain it as assertionMethod.
+func createAccessTokenSigningKey(t *testing.T, ctx *testCtx) crypto.Key {
+	keyID := did.DIDURL{DID: webDID, Fragment: "key-1"}
+	key, err := ctx.keyStore.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc(keyID.String()))
+	require.NoError(t, err)
+	document := did.Document{ID: webDID}
+	verificationMethod, err := did.NewVerificationMethod(keyID, ssi.JsonWebKey2020, webDID, key.Public())
+	require.NoError(t, err)
+	document.AddAssertionMethod(verificationMethod)
+	ctx.resolver.EXPECT().Resolve(webDID, gomock.Any()).Return(&document, &resolver.DocumentMetadata{}, nil).AnyTimes()
+	ctx.vdr.EXPECT().ResolveManaged(webDID).Return(&document, nil).AnyTimes()
+	return key
+}
@@ -20,13 +20,11 @@ package iam
 
 import (
 	"context"
-	crypto2 "crypto"
 	"embed"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/labstack/echo/v4"
-	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/auth"
@@ -71,9 +69,10 @@ type Wrapper struct {
 	policyBackend policy.PDPBackend
 	templates     *template.Template
 	storageEngine storage.Engine
+	jwtSigner     crypto.JWTSigner
 }
 
-func New(authInstance auth.AuthenticationServices, vcrInstance vcr.VCR, vdrInstance vdr.VDR, storageEngine storage.Engine, policyBackend policy.PDPBackend) *Wrapper {
+func New(authInstance auth.AuthenticationServices, vcrInstance vcr.VCR, vdrInstance vdr.VDR, storageEngine storage.Engine, policyBackend policy.PDPBackend, jwtSigner crypto.JWTSigner) *Wrapper {
 	templates := template.New("oauth2 templates")
 	_, err := templates.ParseFS(assets, "assets/*.html")
 	if err != nil {
@@ -86,6 +85,7 @@ func New(authInstance auth.AuthenticationServices, vcrInstance vcr.VCR, vdrInsta
 		vcr:           vcrInstance,
 		vdr:           vdrInstance,
 		templates:     templates,
+		jwtSigner:     jwtSigner,
 	}
 }
 
@@ -147,12 +147,16 @@ func (r Wrapper) HandleTokenRequest(ctx context.Context, request HandleTokenRequ
 	if err != nil {
 		return nil, err
 	}
+	dpopKeyThumbprint, err := r.validateDPoPProof(request.Id, request.Params.DPoP)
+	if err != nil {
+		return nil, err
+	}
 	switch request.Body.GrantType {
 	case "authorization_code":
 		// Options:
 		// - OpenID4VCI
 		// - OpenID4VP
-		return r.handleAccessTokenRequest(ctx, *ownDID, request.Body.Code, request.Body.RedirectUri, request.Body.ClientId)
+		return r.handleAccessTokenRequest(ctx, *ownDID, request.Body.Code, request.Body.RedirectUri, request.Body.ClientId, dpopKeyThumbprint)
 	case "urn:ietf:params:oauth:grant-type:pre-authorized_code":
 		// Options:
 		// - OpenID4VCI
@@ -160,6 +164,8 @@ func (r Wrapper) HandleTokenRequest(ctx context.Context, request HandleTokenRequ
 			Code:        oauth.UnsupportedGrantType,
 			Description: "not implemented yet",
 		}
+	case grantTypeRefreshToken:
+		return r.handleRefreshTokenRequest(ctx, *ownDID, request.Body.RefreshToken, request.Body.ClientId, request.Body.Scope, dpopKeyThumbprint)
 	case "vp_token-bearer":
 		// Nuts RFC021 vp_token bearer flow
 		if request.Body.PresentationSubmission == nil || request.Body.Scope == nil || request.Body.Assertion == nil {
@@ -168,7 +174,7 @@ func (r Wrapper) HandleTokenRequest(ctx context.Context, request HandleTokenRequ
 				Description: "missing required parameters",
 			}
 		}
-		return r.handleS2SAccessTokenRequest(ctx, *ownDID, *request.Body.Scope, *request.Body.PresentationSubmission, *request.Body.Assertion)
+		return r.handleS2SAccessTokenRequest(ctx, *ownDID, *request.Body.Scope, *request.Body.PresentationSubmission, *request.Body.Assertion, dpopKeyThumbprint)
 	default:
 		return nil, oauth.OAuth2Error{
 			Code:        oauth.UnsupportedGrantType,
@@ -224,7 +230,7 @@ func (r Wrapper) IntrospectAccessToken(_ context.Context, request IntrospectAcce
 	}
 
 	token := AccessToken{}
-	if err := r.accessTokenServerStore().Get(request.Body.Token, &token); err != nil {
+	if err := r.accessTokenServerStore().Get(accessTokenKey(request.Body.Token), &token); err != nil {
 		// Return 200 + 'Active = false' when token is invalid or malformed
 		log.Logger().Debug("IntrospectAccessToken: failed to get token from store")
 		return IntrospectAccessToken200JSONResponse{}, err
@@ -252,6 +258,7 @@ func (r Wrapper) IntrospectAccessToken(_ context.Context, request IntrospectAcce
 		PresentationDefinition:         nil,
 		PresentationSubmission:         nil,
 		Vps:                            &token.VPToken,
+		Cnf:                            nil,
 
 		// TODO: user authentication, used in OpenID4VP flow
 		FamilyName:     nil,
@@ -263,6 +270,11 @@ func (r Wrapper) IntrospectAccessToken(_ context.Context, request IntrospectAcce
 		Username:       nil,
 	}
 
+	// set confirmation claim if the token is bound to a DPoP key
+	if token.DPoPKeyThumbprint != "" {
+		response.Cnf = &Cnf{Jkt: token.DPoPKeyThumbprint}
+	}
+
 	// set presentation definition if in token
 	var err error
 	response.PresentationDefinition, err = toAnyMap(token.PresentationDefinition)
@@ -280,6 +292,26 @@ func (r Wrapper) IntrospectAccessToken(_ context.Context, request IntrospectAcce
 	return response, nil
 }
 
+// RevokeToken allows clients to revoke access and refresh tokens issued by this node, as specified by RFC7009.
+func (r Wrapper) RevokeToken(ctx context.Context, request RevokeTokenRequestObject) (RevokeTokenResponseObject, error) {
+	ownDID, err := r.idToOwnedDID(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Body == nil || request.Body.Token == "" {
+		return nil, oauthError(oauth.InvalidRequest, "missing token parameter")
+	}
+	// RFC7009 requires the authorization server to check the token was issued to the client that requests revocation.
+	if request.Body.ClientId == "" {
+		return nil, oauthError(oauth.InvalidRequest, "missing client_id parameter")
+	}
+	// token_type_hint is not used: both token types are looked up, which RFC7009 requires anyway when the hint is wrong.
+	if err = r.revokeToken(*ownDID, request.Body.ClientId, request.Body.Token); err != nil {
+		return nil, oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to revoke token", InternalError: err}
+	}
+	return RevokeToken200Response{}, nil
+}
+
 // toAnyMap marshals and unmarshals input into *map[string]any. Useful to generate OAPI response objects.
 func toAnyMap(input any) (*map[string]any, error) {
 	if input == nil {
@@ -366,35 +398,11 @@ func (r Wrapper) HandleAuthorizeRequest(ctx context.Context, request HandleAutho
 // validateJARRequest validates a JAR (JWT Authorization Request) and returns the JWT claims.
 // the client_id must match the signer of the JWT.
 func (r *Wrapper) validateJARRequest(ctx context.Context, rawToken string, clientId string) (oauthParameters, error) {
-	var signerKid string
-	// Parse and validate the JWT
-	token, err := crypto.ParseJWT(rawToken, func(kid string) (crypto2.PublicKey, error) {
-		signerKid = kid
-		return resolver.DIDKeyResolver{Resolver: r.vdr}.ResolveKeyByID(kid, nil, resolver.AssertionMethod)
-	}, jwt.WithValidate(true))
-	if err != nil {
-		return nil, oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "invalid request parameter", InternalError: err}
-	}
-	claimsAsMap, err := token.AsMap(ctx)
-	if err != nil {
-		// very unlikely
-		return nil, oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "invalid request parameter", InternalError: err}
-	}
-	params := parseJWTClaims(claimsAsMap)
-	// check client_id claim, it must be the same as the client_id in the request
-	if clientId != params.get(oauth.ClientIDParam) {
-		return nil, oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "invalid client_id claim in signed authorization request"}
-	}
-	// check if the signer of the JWT is the client
-	signer, err := did.ParseDIDURL(signerKid)
+	claims, err := oauth.ValidateJARRequest(ctx, resolver.DIDKeyResolver{Resolver: r.vdr}, rawToken, clientId)
 	if err != nil {
-		// very unlikely since the key has already been resolved
-		return nil, oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "invalid signer", InternalError: err}
-	}
-	if signer.DID.String() != clientId {
-		return nil, oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "client_id does not match signer of authorization request"}
+		return nil, err
 	}
-	return params, nil
+	return parseJWTClaims(claims), nil
 }
 
 // OAuthAuthorizationServerMetadata returns the Authorization Server's metadata
@@ -408,6 +416,27 @@ func (r Wrapper) OAuthAuthorizationServerMetadata(ctx context.Context, request O
 	return OAuthAuthorizationServerMetadata200JSONResponse(authorizationServerMetadata(*identity)), nil
 }
 
+// GetJWKS returns the JSON Web Key Set of the authorization server, containing the keys resource servers use to validate JWT access tokens.
+func (r Wrapper) GetJWKS(ctx context.Context, request GetJWKSRequestObject) (GetJWKSResponseObject, error) {
+	ownDID, err := r.idToOwnedDID(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	document, err := r.vdr.ResolveManaged(*ownDID)
+	if err != nil {
+		return nil, fmt.Errorf("DID resolution failed: %w", err)
+	}
+	keys := make([]map[string]interface{}, 0, len(document.AssertionMethod))
+	for _, verificationMethod := range document.AssertionMethod {
+		key, err := verificationMethodToJWK(*verificationMethod.VerificationMethod)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return GetJWKS200JSONResponse{Keys: keys}, nil
+}
+
 func (r Wrapper) GetWebDID(_ context.Context, request GetWebDIDRequestObject) (GetWebDIDResponseObject, error) {
 	ownDID := r.idToDID(request.Id)
 	document, err := r.vdr.ResolveManaged(ownDID)
@@ -440,13 +469,22 @@ func (r Wrapper) PresentationDefinition(ctx context.Context, request Presentatio
 		return nil, err
 	}
 
-	presentationDefinition, err := r.policyBackend.PresentationDefinition(ctx, *authorizer, request.Params.Scope)
+	presentationDefinitions, err := r.policyBackend.PresentationDefinitions(ctx, *authorizer, request.Params.Scope)
 	if err != nil {
 		return nil, oauth.OAuth2Error{
 			Code:        oauth.InvalidScope,
 			Description: err.Error(),
 		}
 	}
+	// the client gets a single presentation definition for all scopes
+	presentationDefinition, err := presentationDefinitions.Merge()
+	if err != nil {
+		return nil, oauth.OAuth2Error{
+			Code:          oauth.ServerError,
+			Description:   "failed to combine presentation definitions of the requested scopes",
+			InternalError: err,
+		}
+	}
 
 	return PresentationDefinition200JSONResponse(*presentationDefinition), nil
 }
@@ -492,6 +530,22 @@ func (r Wrapper) RequestServiceAccessToken(ctx context.Context, request RequestS
 	return RequestServiceAccessToken200JSONResponse(*tokenResult), nil
 }
 
+// CreateDPoPProof creates a DPoP proof for a request to a resource server using a DPoP-bound access token.
+func (r Wrapper) CreateDPoPProof(ctx context.Context, request CreateDPoPProofRequestObject) (CreateDPoPProofResponseObject, error) {
+	requester, err := r.getWalletDID(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	if request.Body.Htm == "" || request.Body.Htu == "" || request.Body.Token == "" {
+		return nil, core.InvalidInputError("htm, htu and token are required")
+	}
+	proof, err := r.auth.IAMClient().CreateDPoPProof(ctx, *requester, request.Body.Htm, request.Body.Htu, request.Body.Token)
+	if err != nil {
+		return nil, err
+	}
+	return CreateDPoPProof200JSONResponse{Dpop: proof}, nil
+}
+
 // getWalletDID resolves a web did and checks if it's owned by this node
 // it differs from idToOwnedDID in that it does require the id to be a web did (and not a partial)
 func (r Wrapper) getWalletDID(ctx context.Context, didString string) (*did.DID, error) {
@@ -111,6 +111,32 @@ func TestWrapper_OAuthAuthorizationServerMetadata(t *testing.T) {
 	})
 }
 
+func TestWrapper_GetJWKS(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+		key := createAccessTokenSigningKey(t, ctx)
+
+		response, err := ctx.client.GetJWKS(nil, GetJWKSRequestObject{Id: webIDPart})
+
+		require.NoError(t, err)
+		keys := response.(GetJWKS200JSONResponse).Keys
+		require.Len(t, keys, 1)
+		assert.Equal(t, key.KID(), keys[0]["kid"])
+		assert.Equal(t, "EC", keys[0]["kty"])
+		assert.NotContains(t, keys[0], "d")
+	})
+	t.Run("error - DID not owned", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(false, nil)
+
+		response, err := ctx.client.GetJWKS(nil, GetJWKSRequestObject{Id: webIDPart})
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "issuer DID not owned by the server")
+		assert.Nil(t, response)
+	})
+}
+
 func TestWrapper_GetWebDID(t *testing.T) {
 	ctx := audit.TestContext()
 	expectedWebDIDDoc := did.Document{
@@ -186,7 +212,7 @@ func TestWrapper_PresentationDefinition(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		test := newTestClient(t)
-		test.policy.EXPECT().PresentationDefinition(gomock.Any(), webDID, "eOverdracht-overdrachtsbericht").Return(&presentationDefinition, nil)
+		test.policy.EXPECT().PresentationDefinitions(gomock.Any(), webDID, "eOverdracht-overdrachtsbericht").Return(policy.ScopedPresentationDefinitions{{Scope: "eOverdracht-overdrachtsbericht", PresentationDefinition: presentationDefinition}}, nil)
 		test.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
 
 		response, err := test.client.PresentationDefinition(ctx, PresentationDefinitionRequestObject{Id: "123", Params: PresentationDefinitionParams{Scope: "eOverdracht-overdrachtsbericht"}})
@@ -211,7 +237,7 @@ func TestWrapper_PresentationDefinition(t *testing.T) {
 	t.Run("error - unknown scope", func(t *testing.T) {
 		test := newTestClient(t)
 		test.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
-		test.policy.EXPECT().PresentationDefinition(gomock.Any(), webDID, "unknown").Return(nil, policy.ErrNotFound)
+		test.policy.EXPECT().PresentationDefinitions(gomock.Any(), webDID, "unknown").Return(nil, policy.ErrNotFound)
 
 		response, err := test.client.PresentationDefinition(ctx, PresentationDefinitionRequestObject{Id: "123", Params: PresentationDefinitionParams{Scope: "unknown"}})
 
@@ -467,6 +493,133 @@ func TestWrapper_HandleTokenRequest(t *testing.T) {
 		requireOAuthError(t, err, oauth.UnsupportedGrantType, "grant_type 'unsupported' is not supported")
 		assert.Nil(t, res)
 	})
+	t.Run("refresh_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+		tokens, err := ctx.client.issueTokens(context.Background(), AccessToken{Token: "token", Issuer: webDID.String(), ClientId: holderDID.String(), Scope: "read"},
+			RefreshToken{Scope: "read", Expiration: time.Now().Add(time.Minute)})
+		require.NoError(t, err)
+		clientID := holderDID.String()
+
+		res, err := ctx.client.HandleTokenRequest(nil, HandleTokenRequestRequestObject{
+			Id: webIDPart,
+			Body: &HandleTokenRequestFormdataRequestBody{
+				GrantType:    "refresh_token",
+				ClientId:     &clientID,
+				RefreshToken: tokens.RefreshToken,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.(HandleTokenRequest200JSONResponse).AccessToken)
+	})
+	t.Run("refresh_token - DPoP", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+		proof, thumbprint := createDPoPProof(t, "https://example.com/iam/123/token")
+		tokens, err := ctx.client.issueTokens(context.Background(), AccessToken{Token: "token", Issuer: webDID.String(), ClientId: holderDID.String(), Scope: "read", DPoPKeyThumbprint: thumbprint},
+			RefreshToken{Scope: "read", Expiration: time.Now().Add(time.Minute)})
+		require.NoError(t, err)
+		clientID := holderDID.String()
+
+		res, err := ctx.client.HandleTokenRequest(nil, HandleTokenRequestRequestObject{
+			Id: webIDPart,
+			Body: &HandleTokenRequestFormdataRequestBody{
+				GrantType:    "refresh_token",
+				ClientId:     &clientID,
+				RefreshToken: tokens.RefreshToken,
+			},
+			Params: HandleTokenRequestParams{DPoP: &proof},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "DPoP", res.(HandleTokenRequest200JSONResponse).TokenType)
+	})
+	t.Run("invalid DPoP proof", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+		proof := "invalid"
+
+		res, err := ctx.client.HandleTokenRequest(nil, HandleTokenRequestRequestObject{
+			Id: webIDPart,
+			Body: &HandleTokenRequestFormdataRequestBody{
+				GrantType: "refresh_token",
+			},
+			Params: HandleTokenRequestParams{DPoP: &proof},
+		})
+
+		var oauthErr oauth.OAuth2Error
+		require.ErrorAs(t, err, &oauthErr)
+		assert.Equal(t, oauth.InvalidDPoPProof, oauthErr.Code)
+		assert.Nil(t, res)
+	})
+}
+
+func TestWrapper_RevokeToken(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+		require.NoError(t, ctx.client.accessTokenServerStore().Put("token", AccessToken{Token: "token", Issuer: webDID.String(), ClientId: holderDID.String()}))
+
+		res, err := ctx.client.RevokeToken(nil, RevokeTokenRequestObject{
+			Id:   webIDPart,
+			Body: &RevokeTokenFormdataRequestBody{Token: "token", ClientId: holderDID.String()},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, RevokeToken200Response{}, res)
+		assert.False(t, ctx.client.accessTokenServerStore().Exists("token"))
+	})
+	t.Run("token issued to other client is not revoked", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+		require.NoError(t, ctx.client.accessTokenServerStore().Put("token", AccessToken{Token: "token", Issuer: webDID.String(), ClientId: holderDID.String()}))
+
+		res, err := ctx.client.RevokeToken(nil, RevokeTokenRequestObject{
+			Id:   webIDPart,
+			Body: &RevokeTokenFormdataRequestBody{Token: "token", ClientId: verifierDID.String()},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, RevokeToken200Response{}, res)
+		assert.True(t, ctx.client.accessTokenServerStore().Exists("token"))
+	})
+	t.Run("missing token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+
+		res, err := ctx.client.RevokeToken(nil, RevokeTokenRequestObject{
+			Id:   webIDPart,
+			Body: &RevokeTokenFormdataRequestBody{ClientId: holderDID.String()},
+		})
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing token parameter")
+		assert.Nil(t, res)
+	})
+	t.Run("missing client_id", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(true, nil)
+
+		res, err := ctx.client.RevokeToken(nil, RevokeTokenRequestObject{
+			Id:   webIDPart,
+			Body: &RevokeTokenFormdataRequestBody{Token: "token"},
+		})
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing client_id parameter")
+		assert.Nil(t, res)
+	})
+	t.Run("unknown did", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(gomock.Any(), webDID).Return(false, nil)
+
+		res, err := ctx.client.RevokeToken(nil, RevokeTokenRequestObject{
+			Id:   webIDPart,
+			Body: &RevokeTokenFormdataRequestBody{Token: "token"},
+		})
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "issuer DID not owned by the server")
+		assert.Nil(t, res)
+	})
 }
 
 func TestWrapper_Callback(t *testing.T) {
@@ -589,6 +742,18 @@ func TestWrapper_IntrospectAccessToken(t *testing.T) {
 		require.True(t, ok)
 		assert.True(t, tokenResponse.Active)
 	})
+	t.Run("ok - DPoP bound", func(t *testing.T) {
+		token := AccessToken{Expiration: time.Now().Add(time.Second), DPoPKeyThumbprint: "thumbprint"}
+		require.NoError(t, ctx.client.accessTokenServerStore().Put("token", token))
+
+		res, err := ctx.client.IntrospectAccessToken(context.Background(), IntrospectAccessTokenRequestObject{Body: &TokenIntrospectionRequest{Token: "token"}})
+
+		require.NoError(t, err)
+		tokenResponse, ok := res.(IntrospectAccessToken200JSONResponse)
+		require.True(t, ok)
+		require.NotNil(t, tokenResponse.Cnf)
+		assert.Equal(t, "thumbprint", tokenResponse.Cnf.Jkt)
+	})
 
 	t.Run(" ok - s2s flow", func(t *testing.T) {
 		// TODO: this should be an integration test to make sure all fields are set
@@ -727,6 +892,38 @@ func TestWrapper_idToOwnedDID(t *testing.T) {
 	})
 }
 
+func TestWrapper_CreateDPoPProof(t *testing.T) {
+	walletDID := did.MustParseDID("did:web:test.test:iam:123")
+	body := &CreateDPoPProofJSONRequestBody{Htm: "GET", Htu: "https://example.com/resource", Token: "token"}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(nil, walletDID).Return(true, nil)
+		ctx.iamClient.EXPECT().CreateDPoPProof(nil, walletDID, "GET", "https://example.com/resource", "token").Return("proof", nil)
+
+		res, err := ctx.client.CreateDPoPProof(nil, CreateDPoPProofRequestObject{Did: walletDID.String(), Body: body})
+
+		require.NoError(t, err)
+		assert.Equal(t, "proof", res.(CreateDPoPProof200JSONResponse).Dpop)
+	})
+	t.Run("error - DID not owned", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(nil, walletDID).Return(false, nil)
+
+		_, err := ctx.client.CreateDPoPProof(nil, CreateDPoPProofRequestObject{Did: walletDID.String(), Body: body})
+
+		assert.EqualError(t, err, "DID not owned by this node")
+	})
+	t.Run("error - missing parameters", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vdr.EXPECT().IsOwner(nil, walletDID).Return(true, nil)
+
+		_, err := ctx.client.CreateDPoPProof(nil, CreateDPoPProofRequestObject{Did: walletDID.String(), Body: &CreateDPoPProofJSONRequestBody{Htm: "GET"}})
+
+		assert.EqualError(t, err, "htm, htu and token are required")
+	})
+}
+
 func TestWrapper_RequestServiceAccessToken(t *testing.T) {
 	walletDID := did.MustParseDID("did:web:test.test:iam:123")
 	verifierDID := did.MustParseDID("did:web:test.test:iam:456")
@@ -914,6 +1111,9 @@ type testCtx struct {
 	vcIssuer      *issuer.MockIssuer
 	vcVerifier    *verifier.MockVerifier
 	wallet        *holder.MockWallet
+	keyStore      crypto.KeyStore
+	// accessTokenFormat is the access token format returned by authnServices, defaults to opaque.
+	accessTokenFormat string
 }
 
 func newTestClient(t testing.TB) *testCtx {
@@ -940,8 +1140,9 @@ func newTestClient(t testing.TB) *testCtx {
 	mockVCR.EXPECT().Wallet().Return(mockWallet).AnyTimes()
 	authnServices.EXPECT().IAMClient().Return(iamClient).AnyTimes()
 	mockVDR.EXPECT().Resolver().Return(mockResolver).AnyTimes()
+	keyStore := crypto.NewMemoryCryptoInstance()
 
-	return &testCtx{
+	ctx := &testCtx{
 		ctrl:          ctrl,
 		authnServices: authnServices,
 		policy:        policyInstance,
@@ -953,12 +1154,19 @@ func newTestClient(t testing.TB) *testCtx {
 		iamClient:     iamClient,
 		vcr:           mockVCR,
 		wallet:        mockWallet,
+		keyStore:      keyStore,
 		client: &Wrapper{
 			auth:          authnServices,
 			vdr:           mockVDR,
 			vcr:           mockVCR,
 			storageEngine: storageEngine,
 			policyBackend: policyInstance,
+			jwtSigner:     keyStore,
 		},
+		accessTokenFormat: auth.AccessTokenFormatOpaque,
 	}
+	authnServices.EXPECT().AccessTokenFormat().DoAndReturn(func() string {
+		return ctx.accessTokenFormat
+	}).AnyTimes()
+	return ctx
 }
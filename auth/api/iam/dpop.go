@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/storage"
+)
+
+// validateDPoPProof validates the DPoP proof (RFC9449) sent to the token endpoint of the given tenant, if any.
+// It returns the thumbprint of the key the access token must be bound to, or an empty string if no proof was sent.
+func (r Wrapper) validateDPoPProof(id string, dpopProof *string) (string, error) {
+	if dpopProof == nil {
+		return "", nil
+	}
+	tokenEndpoint := r.identityURL(id).JoinPath("token")
+	proof, err := oauth.ParseDPoPProof(*dpopProof, http.MethodPost, *tokenEndpoint, "")
+	if err != nil {
+		return "", oauth.OAuth2Error{
+			Code:          oauth.InvalidDPoPProof,
+			Description:   err.Error(),
+			InternalError: err,
+		}
+	}
+	// A proof can only be used once, also when it's sent in concurrent requests
+	err = r.dpopProofStore().PutIfAbsent(proof.ID, true)
+	if errors.Is(err, storage.ErrExists) {
+		return "", oauthError(oauth.InvalidDPoPProof, "DPoP proof has already been used")
+	} else if err != nil {
+		return "", oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to store DPoP proof", InternalError: err}
+	}
+	return proof.KeyThumbprint, nil
+}
+
+// dpopProofStore is used to store the IDs of used DPoP proofs, to detect replay.
+// Proofs older than oauth.DPoPProofMaxAge are rejected, so IDs don't need to be remembered much longer (allowing for clock skew).
+func (r Wrapper) dpopProofStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(2*oauth.DPoPProofMaxAge, "dpop", "jti")
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapper_validateDPoPProof(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		proof, thumbprint := createDPoPProof(t, "https://example.com/iam/123/token")
+
+		result, err := ctx.client.validateDPoPProof(webIDPart, &proof)
+
+		require.NoError(t, err)
+		assert.Equal(t, thumbprint, result)
+	})
+	t.Run("no proof", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		result, err := ctx.client.validateDPoPProof(webIDPart, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("error - replay", func(t *testing.T) {
+		ctx := newTestClient(t)
+		proof, _ := createDPoPProof(t, "https://example.com/iam/123/token")
+		_, err := ctx.client.validateDPoPProof(webIDPart, &proof)
+		require.NoError(t, err)
+
+		result, err := ctx.client.validateDPoPProof(webIDPart, &proof)
+
+		requireOAuthError(t, err, oauth.InvalidDPoPProof, "DPoP proof has already been used")
+		assert.Empty(t, result)
+	})
+	t.Run("error - concurrent replay", func(t *testing.T) {
+		ctx := newTestClient(t)
+		proof, _ := createDPoPProof(t, "https://example.com/iam/123/token")
+		const numRequests = 10
+		var succeeded atomic.Int32
+		wg := sync.WaitGroup{}
+		for i := 0; i < numRequests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := ctx.client.validateDPoPProof(webIDPart, &proof); err == nil {
+					succeeded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), succeeded.Load())
+	})
+	t.Run("error - proof for other tenant", func(t *testing.T) {
+		ctx := newTestClient(t)
+		proof, _ := createDPoPProof(t, "https://example.com/iam/456/token")
+
+		result, err := ctx.client.validateDPoPProof(webIDPart, &proof)
+
+		requireOAuthError(t, err, oauth.InvalidDPoPProof, "invalid DPoP proof: htu does not match the URL of the request")
+		assert.Empty(t, result)
+	})
+}
+
+// createDPoPProof creates a DPoP proof for a POST request to the given URL, signed with a new key.
+// It returns the proof and the thumbprint of the key.
+func createDPoPProof(t *testing.T, target string) (string, string) {
+	keyStore := crypto.NewMemoryCryptoInstance()
+	key, err := keyStore.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc("did:web:example.com:iam:holder#1"))
+	require.NoError(t, err)
+	targetURL, err := url.Parse(target)
+	require.NoError(t, err)
+	proof, err := oauth.CreateDPoPProof(audit.TestContext(), keyStore, key.KID(), key.Public(), http.MethodPost, *targetURL, "")
+	require.NoError(t, err)
+	thumbprint, err := oauth.DPoPKeyThumbprint(key.Public())
+	require.NoError(t, err)
+	return proof, thumbprint
+}
@@ -26,6 +26,18 @@ const (
 	Substantial TokenIntrospectionResponseAssuranceLevel = "substantial"
 )
 
+// Cnf Confirmation claim as specified by RFC7800, containing the key the access token is bound to.
+// The resource server must verify the DPoP proof (RFC9449) of the request was signed with this key.
+type Cnf struct {
+	// Jkt Base64url encoded SHA-256 JWK thumbprint (RFC7638) of the key the access token is bound to, as specified by RFC9449.
+	Jkt string `json:"jkt"`
+}
+
+// JWKS JSON Web Key Set as specified by RFC7517.
+type JWKS struct {
+	Keys []map[string]interface{} `json:"keys"`
+}
+
 // RedirectResponseWithID defines model for RedirectResponseWithID.
 type RedirectResponseWithID struct {
 	// RedirectUri The URL to which the user-agent will be redirected after the authorization request.
@@ -48,6 +60,10 @@ type TokenIntrospectionResponse struct {
 	// AssuranceLevel Assurance level of the identity of the End-User.
 	AssuranceLevel *TokenIntrospectionResponseAssuranceLevel `json:"assurance_level,omitempty"`
 
+	// Cnf Confirmation claim as specified by RFC7800, containing the key the access token is bound to.
+	// The resource server must verify the DPoP proof (RFC9449) of the request was signed with this key.
+	Cnf *Cnf `json:"cnf,omitempty"`
+
 	// Aud RFC7662 - Service-specific string identifier or list of string identifiers representing the intended audience for this token, as defined in JWT [RFC7519].
 	Aud *string `json:"aud,omitempty"`
 
@@ -145,6 +161,25 @@ type HandleAuthorizeResponseFormdataBody struct {
 	VpToken *string `form:"vp_token,omitempty" json:"vp_token,omitempty"`
 }
 
+// RevokeTokenFormdataBody defines parameters for RevokeToken.
+type RevokeTokenFormdataBody struct {
+	// ClientId The client_id of the client the token was issued to.
+	ClientId string `form:"client_id" json:"client_id"`
+
+	// Token The token to revoke.
+	Token string `form:"token" json:"token"`
+
+	// TokenTypeHint A hint about the type of the token, either 'access_token' or 'refresh_token'.
+	TokenTypeHint *string `form:"token_type_hint,omitempty" json:"token_type_hint,omitempty"`
+}
+
+// HandleTokenRequestParams defines parameters for HandleTokenRequest.
+type HandleTokenRequestParams struct {
+	// DPoP DPoP proof as specified by RFC9449. If present, the issued access token is bound to the key of the proof and has token_type 'DPoP'.
+	// Refreshing an access token that is bound to a key requires a DPoP proof signed by the same key.
+	DPoP *string `json:"DPoP,omitempty"`
+}
+
 // HandleTokenRequestFormdataBody defines parameters for HandleTokenRequest.
 type HandleTokenRequestFormdataBody struct {
 	Assertion              *string `form:"assertion,omitempty" json:"assertion,omitempty"`
@@ -153,9 +188,22 @@ type HandleTokenRequestFormdataBody struct {
 	GrantType              string  `form:"grant_type" json:"grant_type"`
 	PresentationSubmission *string `form:"presentation_submission,omitempty" json:"presentation_submission,omitempty"`
 	RedirectUri            *string `form:"redirect_uri,omitempty" json:"redirect_uri,omitempty"`
+	RefreshToken           *string `form:"refresh_token,omitempty" json:"refresh_token,omitempty"`
 	Scope                  *string `form:"scope,omitempty" json:"scope,omitempty"`
 }
 
+// CreateDPoPProofJSONBody defines parameters for CreateDPoPProof.
+type CreateDPoPProofJSONBody struct {
+	// Htm The HTTP method of the request the proof is for.
+	Htm string `json:"htm"`
+
+	// Htu The URL of the request the proof is for.
+	Htu string `json:"htu"`
+
+	// Token The DPoP-bound access token used in the request.
+	Token string `json:"token"`
+}
+
 // RequestServiceAccessTokenJSONBody defines parameters for RequestServiceAccessToken.
 type RequestServiceAccessTokenJSONBody struct {
 	// Scope The scope that will be the service for which this access token can be used.
@@ -183,12 +231,18 @@ type RequestUserAccessTokenJSONBody struct {
 // HandleAuthorizeResponseFormdataRequestBody defines body for HandleAuthorizeResponse for application/x-www-form-urlencoded ContentType.
 type HandleAuthorizeResponseFormdataRequestBody HandleAuthorizeResponseFormdataBody
 
+// RevokeTokenFormdataRequestBody defines body for RevokeToken for application/x-www-form-urlencoded ContentType.
+type RevokeTokenFormdataRequestBody RevokeTokenFormdataBody
+
 // HandleTokenRequestFormdataRequestBody defines body for HandleTokenRequest for application/x-www-form-urlencoded ContentType.
 type HandleTokenRequestFormdataRequestBody HandleTokenRequestFormdataBody
 
 // IntrospectAccessTokenFormdataRequestBody defines body for IntrospectAccessToken for application/x-www-form-urlencoded ContentType.
 type IntrospectAccessTokenFormdataRequestBody = TokenIntrospectionRequest
 
+// CreateDPoPProofJSONRequestBody defines body for CreateDPoPProof for application/json ContentType.
+type CreateDPoPProofJSONRequestBody CreateDPoPProofJSONBody
+
 // RequestServiceAccessTokenJSONRequestBody defines body for RequestServiceAccessToken for application/json ContentType.
 type RequestServiceAccessTokenJSONRequestBody RequestServiceAccessTokenJSONBody
 
@@ -209,6 +263,9 @@ type ServerInterface interface {
 	// Returns the did:web version of a Nuts DID document
 	// (GET /iam/{id}/did.json)
 	GetWebDID(ctx echo.Context, id string) error
+	// Get the JSON Web Key Set of the authorization server
+	// (GET /iam/{id}/jwks)
+	GetJWKS(ctx echo.Context, id string) error
 	// Get the OAuth2 Client metadata
 	// (GET /iam/{id}/oauth-client)
 	OAuthClientMetadata(ctx echo.Context, id string) error
@@ -218,18 +275,24 @@ type ServerInterface interface {
 	// Used by wallets to post the authorization response or error to.
 	// (POST /iam/{id}/response)
 	HandleAuthorizeResponse(ctx echo.Context, id string) error
-	// Get the StatusList2021Credential for the given DID and page
+	// Used by clients to revoke an access- or refresh token.
+	// (POST /iam/{id}/revoke)
+	RevokeToken(ctx echo.Context, id string) error
+	// Get the StatusList2021Credential or BitstringStatusListCredential for the given DID and page
 	// (GET /iam/{id}/statuslist/{page})
 	StatusList(ctx echo.Context, id string, page int) error
 	// Used by to request access- or refresh tokens.
 	// (POST /iam/{id}/token)
-	HandleTokenRequest(ctx echo.Context, id string) error
+	HandleTokenRequest(ctx echo.Context, id string, params HandleTokenRequestParams) error
 	// Introspection endpoint to retrieve information from an Access Token as described by RFC7662
 	// (POST /internal/auth/v2/accesstoken/introspect)
 	IntrospectAccessToken(ctx echo.Context) error
 	// Get the access token from the Nuts node that was requested through /request-user-access-token.
 	// (GET /internal/auth/v2/accesstoken/{sessionID})
 	RetrieveAccessToken(ctx echo.Context, sessionID string) error
+	// Create a DPoP proof for a request to a resource server using a DPoP-bound access token.
+	// (POST /internal/auth/v2/{did}/dpop)
+	CreateDPoPProof(ctx echo.Context, did string) error
 	// Start the authorization flow to get an access token from a remote authorization server.
 	// (POST /internal/auth/v2/{did}/request-service-access-token)
 	RequestServiceAccessToken(ctx echo.Context, did string) error
@@ -354,6 +417,24 @@ func (w *ServerInterfaceWrapper) GetWebDID(ctx echo.Context) error {
 	return err
 }
 
+// GetJWKS converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJWKS(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetJWKS(ctx, id)
+	return err
+}
+
 // OAuthClientMetadata converts echo context to params.
 func (w *ServerInterfaceWrapper) OAuthClientMetadata(ctx echo.Context) error {
 	var err error
@@ -417,6 +498,24 @@ func (w *ServerInterfaceWrapper) HandleAuthorizeResponse(ctx echo.Context) error
 	return err
 }
 
+// RevokeToken converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeToken(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeToken(ctx, id)
+	return err
+}
+
 // StatusList converts echo context to params.
 func (w *ServerInterfaceWrapper) StatusList(ctx echo.Context) error {
 	var err error
@@ -456,8 +555,28 @@ func (w *ServerInterfaceWrapper) HandleTokenRequest(ctx echo.Context) error {
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HandleTokenRequestParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "DPoP" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("DPoP")]; found {
+		var DPoP string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for DPoP, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "DPoP", runtime.ParamLocationHeader, valueList[0], &DPoP)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter DPoP: %s", err))
+		}
+
+		params.DPoP = &DPoP
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.HandleTokenRequest(ctx, id)
+	err = w.Handler.HandleTokenRequest(ctx, id, params)
 	return err
 }
 
@@ -490,6 +609,24 @@ func (w *ServerInterfaceWrapper) RetrieveAccessToken(ctx echo.Context) error {
 	return err
 }
 
+// CreateDPoPProof converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateDPoPProof(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateDPoPProof(ctx, did)
+	return err
+}
+
 // RequestServiceAccessToken converts echo context to params.
 func (w *ServerInterfaceWrapper) RequestServiceAccessToken(ctx echo.Context) error {
 	var err error
@@ -558,13 +695,16 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/iam/:id/authorize", wrapper.HandleAuthorizeRequest)
 	router.GET(baseURL+"/iam/:id/callback", wrapper.Callback)
 	router.GET(baseURL+"/iam/:id/did.json", wrapper.GetWebDID)
+	router.GET(baseURL+"/iam/:id/jwks", wrapper.GetJWKS)
 	router.GET(baseURL+"/iam/:id/oauth-client", wrapper.OAuthClientMetadata)
 	router.GET(baseURL+"/iam/:id/presentation_definition", wrapper.PresentationDefinition)
 	router.POST(baseURL+"/iam/:id/response", wrapper.HandleAuthorizeResponse)
+	router.POST(baseURL+"/iam/:id/revoke", wrapper.RevokeToken)
 	router.GET(baseURL+"/iam/:id/statuslist/:page", wrapper.StatusList)
 	router.POST(baseURL+"/iam/:id/token", wrapper.HandleTokenRequest)
 	router.POST(baseURL+"/internal/auth/v2/accesstoken/introspect", wrapper.IntrospectAccessToken)
 	router.GET(baseURL+"/internal/auth/v2/accesstoken/:sessionID", wrapper.RetrieveAccessToken)
+	router.POST(baseURL+"/internal/auth/v2/:did/dpop", wrapper.CreateDPoPProof)
 	router.POST(baseURL+"/internal/auth/v2/:did/request-service-access-token", wrapper.RequestServiceAccessToken)
 	router.POST(baseURL+"/internal/auth/v2/:did/request-user-access-token", wrapper.RequestUserAccessToken)
 
@@ -719,6 +859,44 @@ func (response GetWebDID404Response) VisitGetWebDIDResponse(w http.ResponseWrite
 	return nil
 }
 
+type GetJWKSRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetJWKSResponseObject interface {
+	VisitGetJWKSResponse(w http.ResponseWriter) error
+}
+
+type GetJWKS200JSONResponse JWKS
+
+func (response GetJWKS200JSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJWKSdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response GetJWKSdefaultApplicationProblemPlusJSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type OAuthClientMetadataRequestObject struct {
 	Id string `json:"id"`
 }
@@ -814,6 +992,44 @@ func (response HandleAuthorizeResponse200JSONResponse) VisitHandleAuthorizeRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type RevokeTokenRequestObject struct {
+	Id   string `json:"id"`
+	Body *RevokeTokenFormdataRequestBody
+}
+
+type RevokeTokenResponseObject interface {
+	VisitRevokeTokenResponse(w http.ResponseWriter) error
+}
+
+type RevokeToken200Response struct {
+}
+
+func (response RevokeToken200Response) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type RevokeTokendefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RevokeTokendefaultApplicationProblemPlusJSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type StatusListRequestObject struct {
 	Id   string `json:"id"`
 	Page int    `json:"page"`
@@ -854,8 +1070,9 @@ func (response StatusListdefaultApplicationProblemPlusJSONResponse) VisitStatusL
 }
 
 type HandleTokenRequestRequestObject struct {
-	Id   string `json:"id"`
-	Body *HandleTokenRequestFormdataRequestBody
+	Id     string `json:"id"`
+	Params HandleTokenRequestParams
+	Body   *HandleTokenRequestFormdataRequestBody
 }
 
 type HandleTokenRequestResponseObject interface {
@@ -955,6 +1172,48 @@ func (response RetrieveAccessTokendefaultApplicationProblemPlusJSONResponse) Vis
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type CreateDPoPProofRequestObject struct {
+	Did  string `json:"did"`
+	Body *CreateDPoPProofJSONRequestBody
+}
+
+type CreateDPoPProofResponseObject interface {
+	VisitCreateDPoPProofResponse(w http.ResponseWriter) error
+}
+
+type CreateDPoPProof200JSONResponse struct {
+	// Dpop The DPoP proof, to be sent in the DPoP HTTP header of the request.
+	Dpop string `json:"dpop"`
+}
+
+func (response CreateDPoPProof200JSONResponse) VisitCreateDPoPProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDPoPProofdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response CreateDPoPProofdefaultApplicationProblemPlusJSONResponse) VisitCreateDPoPProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type RequestServiceAccessTokenRequestObject struct {
 	Did  string `json:"did"`
 	Body *RequestServiceAccessTokenJSONRequestBody
@@ -1047,6 +1306,9 @@ type StrictServerInterface interface {
 	// Returns the did:web version of a Nuts DID document
 	// (GET /iam/{id}/did.json)
 	GetWebDID(ctx context.Context, request GetWebDIDRequestObject) (GetWebDIDResponseObject, error)
+	// Get the JSON Web Key Set of the authorization server
+	// (GET /iam/{id}/jwks)
+	GetJWKS(ctx context.Context, request GetJWKSRequestObject) (GetJWKSResponseObject, error)
 	// Get the OAuth2 Client metadata
 	// (GET /iam/{id}/oauth-client)
 	OAuthClientMetadata(ctx context.Context, request OAuthClientMetadataRequestObject) (OAuthClientMetadataResponseObject, error)
@@ -1056,7 +1318,10 @@ type StrictServerInterface interface {
 	// Used by wallets to post the authorization response or error to.
 	// (POST /iam/{id}/response)
 	HandleAuthorizeResponse(ctx context.Context, request HandleAuthorizeResponseRequestObject) (HandleAuthorizeResponseResponseObject, error)
-	// Get the StatusList2021Credential for the given DID and page
+	// Used by clients to revoke an access- or refresh token.
+	// (POST /iam/{id}/revoke)
+	RevokeToken(ctx context.Context, request RevokeTokenRequestObject) (RevokeTokenResponseObject, error)
+	// Get the StatusList2021Credential or BitstringStatusListCredential for the given DID and page
 	// (GET /iam/{id}/statuslist/{page})
 	StatusList(ctx context.Context, request StatusListRequestObject) (StatusListResponseObject, error)
 	// Used by to request access- or refresh tokens.
@@ -1068,6 +1333,9 @@ type StrictServerInterface interface {
 	// Get the access token from the Nuts node that was requested through /request-user-access-token.
 	// (GET /internal/auth/v2/accesstoken/{sessionID})
 	RetrieveAccessToken(ctx context.Context, request RetrieveAccessTokenRequestObject) (RetrieveAccessTokenResponseObject, error)
+	// Create a DPoP proof for a request to a resource server using a DPoP-bound access token.
+	// (POST /internal/auth/v2/{did}/dpop)
+	CreateDPoPProof(ctx context.Context, request CreateDPoPProofRequestObject) (CreateDPoPProofResponseObject, error)
 	// Start the authorization flow to get an access token from a remote authorization server.
 	// (POST /internal/auth/v2/{did}/request-service-access-token)
 	RequestServiceAccessToken(ctx context.Context, request RequestServiceAccessTokenRequestObject) (RequestServiceAccessTokenResponseObject, error)
@@ -1190,6 +1458,31 @@ func (sh *strictHandler) GetWebDID(ctx echo.Context, id string) error {
 	return nil
 }
 
+// GetJWKS operation middleware
+func (sh *strictHandler) GetJWKS(ctx echo.Context, id string) error {
+	var request GetJWKSRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJWKS(ctx.Request().Context(), request.(GetJWKSRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJWKS")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetJWKSResponseObject); ok {
+		return validResponse.VisitGetJWKSResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // OAuthClientMetadata operation middleware
 func (sh *strictHandler) OAuthClientMetadata(ctx echo.Context, id string) error {
 	var request OAuthClientMetadataRequestObject
@@ -1276,6 +1569,41 @@ func (sh *strictHandler) HandleAuthorizeResponse(ctx echo.Context, id string) er
 	return nil
 }
 
+// RevokeToken operation middleware
+func (sh *strictHandler) RevokeToken(ctx echo.Context, id string) error {
+	var request RevokeTokenRequestObject
+
+	request.Id = id
+
+	if form, err := ctx.FormParams(); err == nil {
+		var body RevokeTokenFormdataRequestBody
+		if err := runtime.BindForm(&body, form, nil, nil); err != nil {
+			return err
+		}
+		request.Body = &body
+	} else {
+		return err
+	}
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeToken(ctx.Request().Context(), request.(RevokeTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeToken")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RevokeTokenResponseObject); ok {
+		return validResponse.VisitRevokeTokenResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // StatusList operation middleware
 func (sh *strictHandler) StatusList(ctx echo.Context, id string, page int) error {
 	var request StatusListRequestObject
@@ -1303,10 +1631,11 @@ func (sh *strictHandler) StatusList(ctx echo.Context, id string, page int) error
 }
 
 // HandleTokenRequest operation middleware
-func (sh *strictHandler) HandleTokenRequest(ctx echo.Context, id string) error {
+func (sh *strictHandler) HandleTokenRequest(ctx echo.Context, id string, params HandleTokenRequestParams) error {
 	var request HandleTokenRequestRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	if form, err := ctx.FormParams(); err == nil {
 		var body HandleTokenRequestFormdataRequestBody
@@ -1395,6 +1724,37 @@ func (sh *strictHandler) RetrieveAccessToken(ctx echo.Context, sessionID string)
 	return nil
 }
 
+// CreateDPoPProof operation middleware
+func (sh *strictHandler) CreateDPoPProof(ctx echo.Context, did string) error {
+	var request CreateDPoPProofRequestObject
+
+	request.Did = did
+
+	var body CreateDPoPProofJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDPoPProof(ctx.Request().Context(), request.(CreateDPoPProofRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDPoPProof")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateDPoPProofResponseObject); ok {
+		return validResponse.VisitCreateDPoPProofResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // RequestServiceAccessToken operation middleware
 func (sh *strictHandler) RequestServiceAccessToken(ctx echo.Context, did string) error {
 	var request RequestServiceAccessTokenRequestObject
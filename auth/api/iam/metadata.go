@@ -37,15 +37,18 @@ func IssuerIdToWellKnown(issuer string, wellKnown string, strictmode bool) (*url
 
 func authorizationServerMetadata(identity url.URL) oauth.AuthorizationServerMetadata {
 	return oauth.AuthorizationServerMetadata{
-		AuthorizationEndpoint:    identity.JoinPath("authorize").String(),
-		ClientIdSchemesSupported: clientIdSchemesSupported,
-		GrantTypesSupported:      grantTypesSupported,
-		Issuer:                   identity.String(),
+		AuthorizationEndpoint:         identity.JoinPath("authorize").String(),
+		ClientIdSchemesSupported:      clientIdSchemesSupported,
+		DPoPSigningAlgValuesSupported: oauth.DPoPSigningAlgValuesSupported(),
+		GrantTypesSupported:           grantTypesSupported,
+		Issuer:                        identity.String(),
+		JwksURI:                       identity.JoinPath("jwks").String(),
 		PreAuthorizedGrantAnonymousAccessSupported: true,
 		PresentationDefinitionEndpoint:             identity.JoinPath("presentation_definition").String(),
 		RequireSignedRequestObject:                 true,
 		ResponseModesSupported:                     responseModesSupported,
 		ResponseTypesSupported:                     responseTypesSupported,
+		RevocationEndpoint:                         identity.JoinPath("revoke").String(),
 		TokenEndpoint:                              identity.JoinPath("token").String(),
 		VPFormats:                                  oauth.DefaultOpenIDSupportedFormats(),
 		VPFormatsSupported:                         oauth.DefaultOpenIDSupportedFormats(),
@@ -69,8 +69,8 @@ func TestIssuerIdToWellKnown(t *testing.T) {
 }
 
 var vpFormats = map[string]map[string][]string{
-	"jwt_vc_json": {"alg_values_supported": []string{"PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}},
-	"jwt_vp_json": {"alg_values_supported": []string{"PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}},
+	"jwt_vc_json": {"alg_values_supported": []string{"PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}},
+	"jwt_vp_json": {"alg_values_supported": []string{"PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}},
 	"ldp_vc":      {"proof_type_values_supported": []string{"JsonWebSignature2020"}},
 	"ldp_vp":      {"proof_type_values_supported": []string{"JsonWebSignature2020"}},
 }
@@ -84,10 +84,13 @@ func Test_authorizationServerMetadata(t *testing.T) {
 		ResponseTypesSupported: []string{"code", "vp_token", "vp_token id_token"},
 		ResponseModesSupported: []string{"query", "direct_post"},
 		TokenEndpoint:          identity + "/token",
-		GrantTypesSupported:    []string{"authorization_code", "vp_token", "urn:ietf:params:oauth:grant-type:pre-authorized_code"},
+		GrantTypesSupported:    []string{"authorization_code", "vp_token", "urn:ietf:params:oauth:grant-type:pre-authorized_code", "refresh_token"},
 		PreAuthorizedGrantAnonymousAccessSupported: true,
 		PresentationDefinitionEndpoint:             identity + "/presentation_definition",
 		RequireSignedRequestObject:                 true,
+		RevocationEndpoint:                         identity + "/revoke",
+		JwksURI:                                    identity + "/jwks",
+		DPoPSigningAlgValuesSupported:              []string{"PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"},
 		VPFormats:                                  oauth.DefaultOpenIDSupportedFormats(),
 		VPFormatsSupported:                         oauth.DefaultOpenIDSupportedFormats(),
 		ClientIdSchemesSupported:                   []string{"did"},
@@ -100,7 +103,7 @@ func Test_clientMetadata(t *testing.T) {
 	expected := OAuthClientMetadata{
 		RedirectURIs:            nil,
 		TokenEndpointAuthMethod: "none",
-		GrantTypes:              []string{"authorization_code", "vp_token", "urn:ietf:params:oauth:grant-type:pre-authorized_code"},
+		GrantTypes:              []string{"authorization_code", "vp_token", "urn:ietf:params:oauth:grant-type:pre-authorized_code", "refresh_token"},
 		ResponseTypes:           []string{"code", "vp_token", "vp_token id_token"},
 		Scope:                   "",
 		Contacts:                nil,
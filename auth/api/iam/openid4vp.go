@@ -413,7 +413,7 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 
 	// validate the presentation_submission against the presentation_definition (by scope)
 	// the resulting credential map is stored and later used to generate the access token
-	credentialMap, _, err := r.validatePresentationSubmission(ctx, *verifier, oauthSession.Scope, submission, pexEnvelope)
+	credentialMap, _, grantedScope, err := r.validatePresentationSubmission(ctx, *verifier, oauthSession.Scope, submission, pexEnvelope)
 	if err != nil {
 		return nil, withCallbackURI(err, callbackURI)
 	}
@@ -439,6 +439,8 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 	// we take the existing OAuthSession and add the credential map to it
 	// the credential map contains InputDescriptor.Id -> VC mappings
 	// todo: use the InputDescriptor.Path to map the Id to Value@JSONPath since this will be later used to set the state for the access token
+	// the access token is issued for the granted scope, which may be a subset of the requested scope
+	oauthSession.Scope = grantedScope
 	oauthSession.ServerState = ServerState{}
 	oauthSession.ServerState[credentialMapStateKey] = credentialMap
 	oauthSession.ServerState[presentationsStateKey] = pexEnvelope.Presentations
@@ -522,7 +524,7 @@ func (r Wrapper) validatePresentationNonce(presentations []vc.VerifiablePresenta
 	return returnErr
 }
 
-func (r Wrapper) handleAccessTokenRequest(ctx context.Context, verifier did.DID, authorizationCode *string, redirectURI *string, clientId *string) (HandleTokenRequestResponseObject, error) {
+func (r Wrapper) handleAccessTokenRequest(ctx context.Context, verifier did.DID, authorizationCode *string, redirectURI *string, clientId *string, dpopKeyThumbprint string) (HandleTokenRequestResponseObject, error) {
 	// first check redirectURI
 	if redirectURI == nil {
 		return nil, oauthError(oauth.InvalidRequest, "missing redirect_uri parameter")
@@ -551,14 +553,19 @@ func (r Wrapper) handleAccessTokenRequest(ctx context.Context, verifier did.DID,
 
 	presentations := oauthSession.ServerState.VerifiablePresentations()
 	submission := oauthSession.ServerState.PresentationSubmission()
-	definition, err := r.policyBackend.PresentationDefinition(ctx, verifier, oauthSession.Scope)
+	definitions, err := r.policyBackend.PresentationDefinitions(ctx, verifier, oauthSession.Scope)
+	if err != nil {
+		return nil, withCallbackURI(oauthError(oauth.ServerError, fmt.Sprintf("failed to fetch presentation definition: %s", err.Error())), callbackURI)
+	}
+	// the session scope only contains the granted scopes, so all definitions have been fulfilled
+	definition, err := definitions.Merge()
 	if err != nil {
 		return nil, withCallbackURI(oauthError(oauth.ServerError, fmt.Sprintf("failed to fetch presentation definition: %s", err.Error())), callbackURI)
 	}
 	credentialMap := oauthSession.ServerState.CredentialMap()
 	subject, _ := did.ParseDID(oauthSession.ClientID)
 
-	response, err := r.createAccessToken(verifier, time.Now(), presentations, submission, *definition, oauthSession.Scope, *subject, credentialMap)
+	response, err := r.createAccessToken(ctx, verifier, time.Now(), presentations, submission, *definition, oauthSession.Scope, *subject, credentialMap, dpopKeyThumbprint)
 	if err != nil {
 		return nil, withCallbackURI(oauthError(oauth.ServerError, fmt.Sprintf("failed to create access token: %s", err.Error())), callbackURI)
 	}
@@ -297,7 +297,7 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			ctx := newTestClient(t)
 			putNonce(ctx, challenge)
 			ctx.vdr.EXPECT().IsOwner(gomock.Any(), verifierDID).Return(true, nil)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), gomock.Any(), "test").Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), gomock.Any(), "test").Return(policy.ScopedPresentationDefinitions{{Scope: "test", PresentationDefinition: definition}}, nil)
 			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, nil)
 
 			response, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
@@ -311,7 +311,7 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			ctx := newTestClient(t)
 			putNonce(ctx, challenge)
 			ctx.vdr.EXPECT().IsOwner(gomock.Any(), verifierDID).Return(true, nil)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), gomock.Any(), "test").Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), gomock.Any(), "test").Return(policy.ScopedPresentationDefinitions{{Scope: "test", PresentationDefinition: definition}}, nil)
 			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, assert.AnError)
 
 			_, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
@@ -422,7 +422,7 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			submission := `{"id":"1", "definition_id":"2", "descriptor_map":[{"id":"2","format":"ldp_vc","path":"$.verifiableCredential"}]}`
 			request.Body.PresentationSubmission = &submission
 			ctx.vdr.EXPECT().IsOwner(gomock.Any(), verifierDID).Return(true, nil)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), gomock.Any(), "test").Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), gomock.Any(), "test").Return(policy.ScopedPresentationDefinitions{{Scope: "test", PresentationDefinition: definition}}, nil)
 
 			_, err := ctx.client.HandleAuthorizeResponse(context.Background(), request)
 
@@ -490,9 +490,9 @@ func Test_handleAccessTokenRequest(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctx := newTestClient(t)
 		putSession(ctx, code, validSession)
-		ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), verifierDID, "scope").Return(&definition, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), verifierDID, "scope").Return(policy.ScopedPresentationDefinitions{{Scope: "scope", PresentationDefinition: definition}}, nil)
 
-		response, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID)
+		response, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID, "")
 
 		require.NoError(t, err)
 		token, ok := response.(HandleTokenRequest200JSONResponse)
@@ -506,7 +506,7 @@ func Test_handleAccessTokenRequest(t *testing.T) {
 	t.Run("invalid authorization code", func(t *testing.T) {
 		ctx := newTestClient(t)
 
-		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID)
+		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID, "")
 
 		require.Error(t, err)
 		_ = assertOAuthError(t, err, "invalid authorization code")
@@ -516,7 +516,7 @@ func Test_handleAccessTokenRequest(t *testing.T) {
 		putSession(ctx, code, validSession)
 		clientID := "other"
 
-		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID)
+		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID, "")
 
 		require.Error(t, err)
 		_ = assertOAuthError(t, err, "client_id does not match: did:web:example.com:iam:holder vs other")
@@ -526,7 +526,7 @@ func Test_handleAccessTokenRequest(t *testing.T) {
 		putSession(ctx, code, validSession)
 		redirectURI := "other"
 
-		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID)
+		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID, "")
 
 		require.Error(t, err)
 		_ = assertOAuthError(t, err, "redirect_uri does not match: https://example.com/iam/holder/cb vs other")
@@ -534,9 +534,9 @@ func Test_handleAccessTokenRequest(t *testing.T) {
 	t.Run("presentation definition backend server error", func(t *testing.T) {
 		ctx := newTestClient(t)
 		putSession(ctx, code, validSession)
-		ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), verifierDID, "scope").Return(nil, assert.AnError)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), verifierDID, "scope").Return(nil, assert.AnError)
 
-		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID)
+		_, err := ctx.client.handleAccessTokenRequest(context.Background(), verifierDID, &code, &redirectURI, &clientID, "")
 
 		require.Error(t, err)
 		oauthErr, ok := err.(oauth.OAuth2Error)
@@ -698,7 +698,7 @@ func TestWrapper_sendAndHandleDirectPostError(t *testing.T) {
 }
 
 func TestWrapper_sendPresentationRequest(t *testing.T) {
-	instance := New(nil, nil, nil, nil, nil)
+	instance := New(nil, nil, nil, nil, nil, nil)
 
 	redirectURI, _ := url.Parse("https://example.com/redirect")
 	verifierID, _ := url.Parse("https://example.com/verifier")
@@ -755,7 +755,7 @@ func TestWrapper_handlePresentationRequest(t *testing.T) {
 		mockAuth := auth.NewMockAuthenticationServices(ctrl)
 		mockWallet.EXPECT().List(gomock.Any(), holderDID).Return(walletCredentials, nil)
 		mockVDR.EXPECT().IsOwner(gomock.Any(), holderDID).Return(true, nil)
-		instance := New(mockAuth, mockVCR, mockVDR, storage.NewTestStorageEngine(t), mockPolicy)
+		instance := New(mockAuth, mockVCR, mockVDR, storage.NewTestStorageEngine(t), mockPolicy, nil)
 
 		params := map[string]interface{}{
 			"scope":                   "eOverdracht-overdrachtsbericht",
@@ -774,7 +774,7 @@ func TestWrapper_handlePresentationRequest(t *testing.T) {
 		assert.Contains(t, httpResponse.body.String(), "</html>")
 	})
 	t.Run("invalid response_mode", func(t *testing.T) {
-		instance := New(nil, nil, nil, nil, nil)
+		instance := New(nil, nil, nil, nil, nil, nil)
 		params := map[string]interface{}{
 			"scope":                   "eOverdracht-overdrachtsbericht",
 			"response_type":           "code",
@@ -0,0 +1,250 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/auth"
+	"github.com/nuts-foundation/nuts-node/auth/log"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+)
+
+// refreshTokenValidity defines how long refresh tokens are valid.
+// Refresh tokens are rotated on use, but the rotated token keeps the expiration of the original,
+// so this limits how long a client can keep obtaining access tokens without presenting a new grant.
+const refreshTokenValidity = 24 * time.Hour
+
+// RefreshToken is a refresh token issued by the authorization server, which can be exchanged for a new access token using the refresh_token grant.
+type RefreshToken struct {
+	Token string
+	// FamilyID identifies the refresh tokens obtained by rotating the refresh token of the same original grant.
+	// If a rotated refresh token is presented again, all tokens of the family are revoked.
+	FamilyID string
+	// Scope is the scope of the original grant. Access tokens obtained using the refresh token can't have a broader scope.
+	Scope string
+	// Expiration is the time the refresh token expires. It is not extended when the refresh token is rotated.
+	Expiration time.Time
+	// AccessToken is the last access token issued along with the refresh token.
+	// It's used as template for access tokens issued using the refresh token, and revoked when the refresh token is revoked.
+	AccessToken AccessToken
+	// AccessTokenKey is the key of AccessToken in the access token store.
+	AccessTokenKey string
+}
+
+// issueTokens stores the access token and a new refresh token for it, and returns them as token response.
+// If the node is configured to issue JWT access tokens, a signed JWT containing its grant is returned instead of the access token.
+// The access token is still stored (by the hash of the JWT), so it can be introspected and revoked.
+func (r Wrapper) issueTokens(ctx context.Context, accessToken AccessToken, refreshToken RefreshToken) (*oauth.TokenResponse, error) {
+	responseToken := accessToken.Token
+	if r.auth.AccessTokenFormat() == auth.AccessTokenFormatJWT {
+		token, err := r.signAccessToken(ctx, accessToken)
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign access token: %w", err)
+		}
+		responseToken = token
+	}
+	storageKey := accessTokenKey(responseToken)
+	err := r.accessTokenServerStore().Put(storageKey, accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store access token: %w", err)
+	}
+	refreshToken.Token = crypto.GenerateNonce()
+	if refreshToken.FamilyID == "" {
+		refreshToken.FamilyID = crypto.GenerateNonce()
+	}
+	refreshToken.AccessToken = accessToken
+	refreshToken.AccessTokenKey = storageKey
+	err = r.refreshTokenStore().Put(refreshToken.Token, refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store refresh token: %w", err)
+	}
+	err = r.refreshTokenFamilyStore().Put(refreshToken.FamilyID, refreshToken.Token)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store refresh token family: %w", err)
+	}
+	tokenType := "bearer"
+	if accessToken.DPoPKeyThumbprint != "" {
+		tokenType = oauth.DPoPTokenType
+	}
+	expiresIn := int(accessTokenValidity.Seconds())
+	return &oauth.TokenResponse{
+		AccessToken:  responseToken,
+		ExpiresIn:    &expiresIn,
+		RefreshToken: &refreshToken.Token,
+		Scope:        &accessToken.Scope,
+		TokenType:    tokenType,
+	}, nil
+}
+
+// handleRefreshTokenRequest handles the /token request with refresh_token grant type.
+// The refresh token is rotated: it can only be used once, and a new refresh token is returned along with the new access token.
+// If scope is given, it must be a subset of the originally granted scope.
+// If the refresh token was issued along with a DPoP-bound access token, dpopKeyThumbprint must match the key the access token is bound to.
+// If a refresh token that was already rotated is presented (again), it is considered stolen and all tokens of its family are revoked.
+func (r Wrapper) handleRefreshTokenRequest(ctx context.Context, issuer did.DID, refreshTokenValue *string, clientId *string, scope *string, dpopKeyThumbprint string) (HandleTokenRequestResponseObject, error) {
+	if refreshTokenValue == nil || clientId == nil {
+		return nil, oauthError(oauth.InvalidRequest, "missing required parameters")
+	}
+	var refreshToken RefreshToken
+	err := r.refreshTokenStore().Get(*refreshTokenValue, &refreshToken)
+	if errors.Is(err, storage.ErrNotFound) {
+		if err = r.detectRefreshTokenReuse(issuer, *refreshTokenValue); err != nil {
+			return nil, oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to revoke refresh tokens", InternalError: err}
+		}
+		return nil, oauthError(oauth.InvalidGrant, "invalid refresh token")
+	} else if err != nil {
+		return nil, oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to retrieve refresh token", InternalError: err}
+	}
+	// Tokens issued by another tenant are treated as unknown
+	if refreshToken.AccessToken.Issuer != issuer.String() || refreshToken.Expiration.Before(time.Now()) {
+		return nil, oauthError(oauth.InvalidGrant, "invalid refresh token")
+	}
+	if refreshToken.AccessToken.ClientId != *clientId {
+		return nil, oauthError(oauth.InvalidGrant, "invalid refresh token")
+	}
+	if refreshToken.AccessToken.DPoPKeyThumbprint != "" && refreshToken.AccessToken.DPoPKeyThumbprint != dpopKeyThumbprint {
+		return nil, oauthError(oauth.InvalidDPoPProof, "refresh token is bound to another DPoP key")
+	}
+	accessTokenScope := refreshToken.Scope
+	if scope != nil && *scope != "" {
+		grantedScopes := strings.Fields(refreshToken.Scope)
+		for _, requestedScope := range strings.Fields(*scope) {
+			if !slices.Contains(grantedScopes, requestedScope) {
+				return nil, oauthError(oauth.InvalidScope, fmt.Sprintf("scope '%s' was not granted", requestedScope))
+			}
+		}
+		accessTokenScope = *scope
+	}
+
+	// Rotate refresh token: it can't be used again.
+	// If another request redeemed it concurrently, the refresh token is being reused.
+	err = r.refreshTokenStore().GetAndDelete(refreshToken.Token, &refreshToken)
+	if errors.Is(err, storage.ErrNotFound) {
+		if err = r.detectRefreshTokenReuse(issuer, *refreshTokenValue); err != nil {
+			return nil, oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to revoke refresh tokens", InternalError: err}
+		}
+		return nil, oauthError(oauth.InvalidGrant, "invalid refresh token")
+	} else if err != nil {
+		return nil, oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to rotate refresh token", InternalError: err}
+	}
+	if err = r.rotatedRefreshTokenStore().Put(refreshToken.Token, refreshToken.FamilyID); err != nil {
+		return nil, oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to rotate refresh token", InternalError: err}
+	}
+	issueTime := time.Now()
+	accessToken := refreshToken.AccessToken
+	accessToken.Token = crypto.GenerateNonce()
+	accessToken.IssuedAt = issueTime
+	accessToken.Expiration = issueTime.Add(accessTokenValidity)
+	accessToken.Scope = accessTokenScope
+	response, err := r.issueTokens(ctx, accessToken, refreshToken)
+	if err != nil {
+		return nil, oauth.OAuth2Error{Code: oauth.ServerError, Description: "failed to create access token", InternalError: err}
+	}
+	return HandleTokenRequest200JSONResponse(*response), nil
+}
+
+// detectRefreshTokenReuse checks whether the given refresh token was already rotated.
+// If so, the refresh token was presented before, by either the client or an attacker, and can't be trusted anymore:
+// the current refresh token of its family and the access token issued with it are revoked.
+func (r Wrapper) detectRefreshTokenReuse(issuer did.DID, token string) error {
+	var familyID string
+	err := r.rotatedRefreshTokenStore().Get(token, &familyID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to retrieve rotated refresh token: %w", err)
+	}
+	var currentToken string
+	err = r.refreshTokenFamilyStore().Get(familyID, &currentToken)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to retrieve refresh token family: %w", err)
+	}
+	log.Logger().Warnf("Rotated refresh token was used again, revoking its token family (issuer=%s)", issuer)
+	// the token family is revoked regardless of the client presenting the token, since it's considered stolen
+	return r.revokeToken(issuer, "", currentToken)
+}
+
+// revokeToken revokes the given access or refresh token, if it was issued by the given issuer to the given client.
+// If clientID is empty, the token is revoked regardless of the client it was issued to.
+// Revoking a refresh token also revokes the last access token issued with it.
+// Unknown tokens and tokens issued to other clients are ignored, as specified by RFC7009.
+func (r Wrapper) revokeToken(issuer did.DID, clientID string, token string) error {
+	var refreshToken RefreshToken
+	err := r.refreshTokenStore().Get(token, &refreshToken)
+	if err == nil {
+		if refreshToken.AccessToken.Issuer != issuer.String() {
+			return nil
+		}
+		if clientID != "" && refreshToken.AccessToken.ClientId != clientID {
+			return nil
+		}
+		if err = r.refreshTokenStore().Delete(token); err != nil {
+			return fmt.Errorf("unable to revoke refresh token: %w", err)
+		}
+		token = refreshToken.AccessTokenKey
+	} else if errors.Is(err, storage.ErrNotFound) {
+		token = accessTokenKey(token)
+	} else {
+		return fmt.Errorf("unable to retrieve refresh token: %w", err)
+	}
+
+	var accessToken AccessToken
+	err = r.accessTokenServerStore().Get(token, &accessToken)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to retrieve access token: %w", err)
+	}
+	if accessToken.Issuer != issuer.String() {
+		return nil
+	}
+	if clientID != "" && accessToken.ClientId != clientID {
+		return nil
+	}
+	if err = r.accessTokenServerStore().Delete(token); err != nil {
+		return fmt.Errorf("unable to revoke access token: %w", err)
+	}
+	return nil
+}
+
+// refreshTokenStore is used by the Auth server to store issued refresh tokens
+func (r Wrapper) refreshTokenStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(refreshTokenValidity, "serverrefreshtoken")
+}
+
+// refreshTokenFamilyStore is used by the Auth server to keep track of the current refresh token of a refresh token family
+func (r Wrapper) refreshTokenFamilyStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(refreshTokenValidity, "serverrefreshtokenfamily")
+}
+
+// rotatedRefreshTokenStore is used by the Auth server to detect reuse of refresh tokens that were already rotated
+func (r Wrapper) rotatedRefreshTokenStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(refreshTokenValidity, "serverrotatedrefreshtoken")
+}
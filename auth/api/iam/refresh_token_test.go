@@ -0,0 +1,287 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapper_handleRefreshTokenRequest(t *testing.T) {
+	clientID := holderDID.String()
+	issueTokens := func(t *testing.T, ctx *testCtx) *oauth.TokenResponse {
+		accessToken := AccessToken{
+			Token:      "access-token",
+			Issuer:     webDID.String(),
+			ClientId:   clientID,
+			IssuedAt:   time.Now(),
+			Expiration: time.Now().Add(accessTokenValidity),
+			Scope:      "read write",
+			InputDescriptorConstraintIdMap: map[string]any{
+				"organization_name": "Hospital",
+			},
+		}
+		response, err := ctx.client.issueTokens(context.Background(), accessToken, RefreshToken{
+			Scope:      "read write",
+			Expiration: time.Now().Add(refreshTokenValidity),
+		})
+		require.NoError(t, err)
+		return response
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, nil, "")
+
+		require.NoError(t, err)
+		tokenResponse := TokenResponse(response.(HandleTokenRequest200JSONResponse))
+		assert.NotEqual(t, tokens.AccessToken, tokenResponse.AccessToken)
+		assert.Equal(t, "read write", *tokenResponse.Scope)
+		assert.Equal(t, 900, *tokenResponse.ExpiresIn)
+		t.Run("refresh token is rotated", func(t *testing.T) {
+			require.NotNil(t, tokenResponse.RefreshToken)
+			assert.NotEqual(t, *tokens.RefreshToken, *tokenResponse.RefreshToken)
+			assert.False(t, ctx.client.refreshTokenStore().Exists(*tokens.RefreshToken))
+			assert.True(t, ctx.client.refreshTokenStore().Exists(*tokenResponse.RefreshToken))
+		})
+		t.Run("new access token is based on the original grant", func(t *testing.T) {
+			var accessToken AccessToken
+			require.NoError(t, ctx.client.accessTokenServerStore().Get(tokenResponse.AccessToken, &accessToken))
+			assert.Equal(t, webDID.String(), accessToken.Issuer)
+			assert.Equal(t, clientID, accessToken.ClientId)
+			assert.Equal(t, "Hospital", accessToken.InputDescriptorConstraintIdMap["organization_name"])
+			assert.True(t, accessToken.Expiration.After(time.Now()))
+		})
+		t.Run("refresh token can't be used twice", func(t *testing.T) {
+			response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, nil, "")
+
+			requireOAuthError(t, err, oauth.InvalidGrant, "invalid refresh token")
+			assert.Nil(t, response)
+			t.Run("token family is revoked", func(t *testing.T) {
+				assert.False(t, ctx.client.refreshTokenStore().Exists(*tokenResponse.RefreshToken))
+				assert.False(t, ctx.client.accessTokenServerStore().Exists(tokenResponse.AccessToken))
+			})
+		})
+	})
+	t.Run("concurrent requests with the same refresh token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+		const numRequests = 10
+		var succeeded atomic.Int32
+		wg := sync.WaitGroup{}
+		for i := 0; i < numRequests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, nil, "")
+				if err == nil {
+					succeeded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), succeeded.Load())
+	})
+	t.Run("ok - narrowed scope", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+		scope := "read"
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, &scope, "")
+
+		require.NoError(t, err)
+		tokenResponse := TokenResponse(response.(HandleTokenRequest200JSONResponse))
+		assert.Equal(t, "read", *tokenResponse.Scope)
+		// the new refresh token still grants the original scope
+		var refreshToken RefreshToken
+		require.NoError(t, ctx.client.refreshTokenStore().Get(*tokenResponse.RefreshToken, &refreshToken))
+		assert.Equal(t, "read write", refreshToken.Scope)
+	})
+	t.Run("error - scope broader than granted", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+		scope := "read delete"
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, &scope, "")
+
+		requireOAuthError(t, err, oauth.InvalidScope, "scope 'delete' was not granted")
+		assert.Nil(t, response)
+		assert.True(t, ctx.client.refreshTokenStore().Exists(*tokens.RefreshToken))
+	})
+	t.Run("error - missing parameters", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, nil, &clientID, nil, "")
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing required parameters")
+		assert.Nil(t, response)
+	})
+	t.Run("error - unknown refresh token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		refreshToken := "unknown"
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, &refreshToken, &clientID, nil, "")
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "invalid refresh token")
+		assert.Nil(t, response)
+	})
+	t.Run("error - issued by other tenant", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), verifierDID, tokens.RefreshToken, &clientID, nil, "")
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "invalid refresh token")
+		assert.Nil(t, response)
+	})
+	t.Run("error - expired", func(t *testing.T) {
+		ctx := newTestClient(t)
+		refreshToken := RefreshToken{
+			Token:       "refresh-token",
+			Scope:       "read",
+			Expiration:  time.Now().Add(-time.Second),
+			AccessToken: AccessToken{Issuer: webDID.String(), ClientId: clientID},
+		}
+		require.NoError(t, ctx.client.refreshTokenStore().Put(refreshToken.Token, refreshToken))
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, &refreshToken.Token, &clientID, nil, "")
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "invalid refresh token")
+		assert.Nil(t, response)
+	})
+	t.Run("error - client_id mismatch", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+		otherClientID := verifierDID.String()
+
+		response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &otherClientID, nil, "")
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "invalid refresh token")
+		assert.Nil(t, response)
+		assert.True(t, ctx.client.refreshTokenStore().Exists(*tokens.RefreshToken))
+	})
+	t.Run("DPoP", func(t *testing.T) {
+		issueBoundTokens := func(t *testing.T, ctx *testCtx) *oauth.TokenResponse {
+			accessToken := AccessToken{Token: "access-token", Issuer: webDID.String(), ClientId: clientID, DPoPKeyThumbprint: "thumbprint"}
+			response, err := ctx.client.issueTokens(context.Background(), accessToken, RefreshToken{Scope: "read", Expiration: time.Now().Add(refreshTokenValidity)})
+			require.NoError(t, err)
+			return response
+		}
+		t.Run("ok", func(t *testing.T) {
+			ctx := newTestClient(t)
+			tokens := issueBoundTokens(t, ctx)
+			assert.Equal(t, oauth.DPoPTokenType, tokens.TokenType)
+
+			response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, nil, "thumbprint")
+
+			require.NoError(t, err)
+			tokenResponse := TokenResponse(response.(HandleTokenRequest200JSONResponse))
+			assert.Equal(t, oauth.DPoPTokenType, tokenResponse.TokenType)
+		})
+		t.Run("error - other key", func(t *testing.T) {
+			ctx := newTestClient(t)
+			tokens := issueBoundTokens(t, ctx)
+
+			response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, nil, "other")
+
+			requireOAuthError(t, err, oauth.InvalidDPoPProof, "refresh token is bound to another DPoP key")
+			assert.Nil(t, response)
+		})
+		t.Run("error - no proof", func(t *testing.T) {
+			ctx := newTestClient(t)
+			tokens := issueBoundTokens(t, ctx)
+
+			response, err := ctx.client.handleRefreshTokenRequest(context.Background(), webDID, tokens.RefreshToken, &clientID, nil, "")
+
+			requireOAuthError(t, err, oauth.InvalidDPoPProof, "refresh token is bound to another DPoP key")
+			assert.Nil(t, response)
+		})
+	})
+}
+
+func TestWrapper_revokeToken(t *testing.T) {
+	issueTokens := func(t *testing.T, ctx *testCtx) *oauth.TokenResponse {
+		accessToken := AccessToken{
+			Token:      "access-token",
+			Issuer:     webDID.String(),
+			ClientId:   holderDID.String(),
+			Expiration: time.Now().Add(accessTokenValidity),
+		}
+		response, err := ctx.client.issueTokens(context.Background(), accessToken, RefreshToken{Expiration: time.Now().Add(refreshTokenValidity)})
+		require.NoError(t, err)
+		return response
+	}
+	t.Run("access token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+
+		err := ctx.client.revokeToken(webDID, holderDID.String(), tokens.AccessToken)
+
+		require.NoError(t, err)
+		assert.False(t, ctx.client.accessTokenServerStore().Exists(tokens.AccessToken))
+		assert.True(t, ctx.client.refreshTokenStore().Exists(*tokens.RefreshToken))
+	})
+	t.Run("refresh token also revokes access token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+
+		err := ctx.client.revokeToken(webDID, holderDID.String(), *tokens.RefreshToken)
+
+		require.NoError(t, err)
+		assert.False(t, ctx.client.accessTokenServerStore().Exists(tokens.AccessToken))
+		assert.False(t, ctx.client.refreshTokenStore().Exists(*tokens.RefreshToken))
+	})
+	t.Run("unknown token is ignored", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		err := ctx.client.revokeToken(webDID, holderDID.String(), "unknown")
+
+		assert.NoError(t, err)
+	})
+	t.Run("tokens of other clients are not revoked", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+
+		require.NoError(t, ctx.client.revokeToken(webDID, verifierDID.String(), tokens.AccessToken))
+		require.NoError(t, ctx.client.revokeToken(webDID, verifierDID.String(), *tokens.RefreshToken))
+
+		assert.True(t, ctx.client.accessTokenServerStore().Exists(tokens.AccessToken))
+		assert.True(t, ctx.client.refreshTokenStore().Exists(*tokens.RefreshToken))
+	})
+	t.Run("tokens of other tenants are not revoked", func(t *testing.T) {
+		ctx := newTestClient(t)
+		tokens := issueTokens(t, ctx)
+
+		require.NoError(t, ctx.client.revokeToken(verifierDID, holderDID.String(), tokens.AccessToken))
+		require.NoError(t, ctx.client.revokeToken(verifierDID, holderDID.String(), *tokens.RefreshToken))
+
+		assert.True(t, ctx.client.accessTokenServerStore().Exists(tokens.AccessToken))
+		assert.True(t, ctx.client.refreshTokenStore().Exists(*tokens.RefreshToken))
+	})
+}
@@ -43,7 +43,8 @@ const s2sMaxClockSkew = 5 * time.Second
 
 // handleS2SAccessTokenRequest handles the /token request with vp_token bearer grant type, intended for service-to-service exchanges.
 // It performs cheap checks first (parameter presence and validity, matching VCs to the presentation definition), then the more expensive ones (checking signatures).
-func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, issuer did.DID, scope string, submissionJSON string, assertionJSON string) (HandleTokenRequestResponseObject, error) {
+// If dpopKeyThumbprint is not empty, the access token is bound to the DPoP key with that thumbprint.
+func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, issuer did.DID, scope string, submissionJSON string, assertionJSON string, dpopKeyThumbprint string) (HandleTokenRequestResponseObject, error) {
 	pexEnvelope, err := pe.ParseEnvelope([]byte(assertionJSON))
 	if err != nil {
 		return nil, oauth.OAuth2Error{
@@ -74,7 +75,7 @@ func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, issuer did.DID
 			return nil, err
 		}
 	}
-	credentialMap, definition, err := r.validatePresentationSubmission(ctx, issuer, scope, submission, pexEnvelope)
+	credentialMap, definition, grantedScope, err := r.validatePresentationSubmission(ctx, issuer, scope, submission, pexEnvelope)
 	if err != nil {
 		return nil, err
 	}
@@ -97,14 +98,14 @@ func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, issuer did.DID
 	}
 
 	// All OK, allow access
-	response, err := r.createAccessToken(issuer, time.Now(), pexEnvelope.Presentations, submission, *definition, scope, credentialSubjectID, credentialMap)
+	response, err := r.createAccessToken(ctx, issuer, time.Now(), pexEnvelope.Presentations, submission, *definition, grantedScope, credentialSubjectID, credentialMap, dpopKeyThumbprint)
 	if err != nil {
 		return nil, err
 	}
 	return HandleTokenRequest200JSONResponse(*response), nil
 }
 
-func (r Wrapper) createAccessToken(issuer did.DID, issueTime time.Time, presentations []vc.VerifiablePresentation, submission *pe.PresentationSubmission, definition PresentationDefinition, scope string, credentialSubjectDID did.DID, credentialMap map[string]vc.VerifiableCredential) (*oauth.TokenResponse, error) {
+func (r Wrapper) createAccessToken(ctx context.Context, issuer did.DID, issueTime time.Time, presentations []vc.VerifiablePresentation, submission *pe.PresentationSubmission, definition PresentationDefinition, scope string, credentialSubjectDID did.DID, credentialMap map[string]vc.VerifiableCredential, dpopKeyThumbprint string) (*oauth.TokenResponse, error) {
 	fieldsMap, err := definition.ResolveConstraintsFields(credentialMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to resolve Presentation Definition Constraints Fields: %w", err)
@@ -120,18 +121,13 @@ func (r Wrapper) createAccessToken(issuer did.DID, issueTime time.Time, presenta
 		PresentationDefinition:         &definition,
 		PresentationSubmission:         submission,
 		InputDescriptorConstraintIdMap: fieldsMap,
+		DPoPKeyThumbprint:              dpopKeyThumbprint,
 	}
-	err = r.accessTokenServerStore().Put(accessToken.Token, accessToken)
-	if err != nil {
-		return nil, fmt.Errorf("unable to store access token: %w", err)
-	}
-	expiresIn := int(accessTokenValidity.Seconds())
-	return &oauth.TokenResponse{
-		AccessToken: accessToken.Token,
-		ExpiresIn:   &expiresIn,
-		Scope:       &scope,
-		TokenType:   "bearer",
-	}, nil
+	refreshToken := RefreshToken{
+		Scope:      scope,
+		Expiration: issueTime.Add(refreshTokenValidity),
+	}
+	return r.issueTokens(ctx, accessToken, refreshToken)
 }
 
 // validateS2SPresentationMaxValidity checks that the presentation is valid for a reasonable amount of time.
@@ -223,6 +219,9 @@ type AccessToken struct {
 	// InputDescriptorConstraintIdMap maps the ID field of a PresentationDefinition input descriptor constraint to the value provided in the VPToken for the constraint.
 	// The Policy Decision Point can use this map to make decisions without having to deal with PEX/VCs/VPs/SignatureValidation
 	InputDescriptorConstraintIdMap map[string]any
+	// DPoPKeyThumbprint is the JWK thumbprint of the key the token is bound to using DPoP (RFC9449).
+	// It is empty for bearer tokens.
+	DPoPKeyThumbprint string
 
 	// additional fields to support unforeseen policy decision requirements
 
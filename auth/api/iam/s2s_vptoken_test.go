@@ -110,9 +110,9 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	t.Run("JSON-LD VP", func(t *testing.T) {
 		ctx := newTestClient(t)
 		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
-		ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 		require.NoError(t, err)
 		require.IsType(t, HandleTokenRequest200JSONResponse{}, resp)
@@ -122,13 +122,25 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 		assert.Equal(t, int(accessTokenValidity.Seconds()), *tokenResponse.ExpiresIn)
 		assert.NotEmpty(t, tokenResponse.AccessToken)
 	})
+	t.Run("subset of requested scopes is granted", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope+" unsupported").Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
+
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope+" unsupported", submissionJSON, presentation.Raw(), "")
+
+		require.NoError(t, err)
+		require.IsType(t, HandleTokenRequest200JSONResponse{}, resp)
+		tokenResponse := TokenResponse(resp.(HandleTokenRequest200JSONResponse))
+		assert.Equal(t, requestedScope, *tokenResponse.Scope)
+	})
 	t.Run("missing presentation expiry date", func(t *testing.T) {
 		ctx := newTestClient(t)
 		presentation, _ := test.CreateJWTPresentation(t, *subjectDID, func(token jwt.Token) {
 			require.NoError(t, token.Remove(jwt.ExpirationKey))
 		}, verifiableCredential)
 
-		_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+		_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 		require.EqualError(t, err, "invalid_request - presentation is missing creation or expiration date")
 	})
@@ -138,7 +150,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 			require.NoError(t, token.Remove(jwt.NotBeforeKey))
 		}, verifiableCredential)
 
-		_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+		_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 		require.EqualError(t, err, "invalid_request - presentation is missing creation or expiration date")
 	})
@@ -148,7 +160,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 			require.NoError(t, token.Set(jwt.ExpirationKey, time.Now().Add(time.Hour)))
 		}, verifiableCredential)
 
-		_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+		_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 		require.EqualError(t, err, "invalid_request - presentation is valid for too long (max 5s)")
 	})
@@ -157,10 +169,10 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 		presentation, _ := test.CreateJWTPresentation(t, *subjectDID, func(token jwt.Token) {
 			require.NoError(t, token.Set(jwt.AudienceKey, issuerDID.String()))
 		}, verifiableCredential)
-		ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
 
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 		require.NoError(t, err)
 		require.IsType(t, HandleTokenRequest200JSONResponse{}, resp)
@@ -172,7 +184,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	})
 	t.Run("VP is not valid JSON", func(t *testing.T) {
 		ctx := newTestClient(t)
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, "[true, false]")
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, "[true, false]", "")
 
 		assert.EqualError(t, err, "invalid_request - assertion parameter is invalid: unable to parse PEX envelope as verifiable presentation: invalid JWT")
 		assert.Nil(t, resp)
@@ -184,7 +196,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 		secondPresentation := test.CreateJSONLDPresentation(t, secondSubjectID, proofVisitor, test.JWTNutsOrganizationCredential(t, secondSubjectID))
 		assertionJSON, _ := json.Marshal([]VerifiablePresentation{presentation, secondPresentation})
 
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, string(assertionJSON))
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, string(assertionJSON), "")
 		assert.EqualError(t, err, "invalid_request - not all presentations have the same credential subject ID")
 		assert.Nil(t, resp)
 	})
@@ -192,74 +204,74 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 		t.Run("replay attack (nonce is reused)", func(t *testing.T) {
 			ctx := newTestClient(t)
 			ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil).Times(2)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil).Times(2)
 
-			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 			require.NoError(t, err)
 
-			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 			assert.EqualError(t, err, "invalid_request - presentation nonce has already been used")
 			assert.Nil(t, resp)
 		})
 		t.Run("JSON-LD VP is missing nonce", func(t *testing.T) {
 			ctx := newTestClient(t)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 			proofVisitor := test.LDProofVisitor(func(proof *proof.LDProof) {
 				proof.Domain = &issuerDIDStr
 				proof.Nonce = nil
 			})
 			presentation := test.CreateJSONLDPresentation(t, *subjectDID, proofVisitor, verifiableCredential)
 
-			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 			assert.EqualError(t, err, "invalid_request - presentation has invalid/missing nonce")
 			assert.Nil(t, resp)
 		})
 		t.Run("JSON-LD VP has empty nonce", func(t *testing.T) {
 			ctx := newTestClient(t)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 			proofVisitor := test.LDProofVisitor(func(proof *proof.LDProof) {
 				proof.Domain = &issuerDIDStr
 				proof.Nonce = new(string)
 			})
 			presentation := test.CreateJSONLDPresentation(t, *subjectDID, proofVisitor, verifiableCredential)
 
-			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 			assert.EqualError(t, err, "invalid_request - presentation has invalid/missing nonce")
 			assert.Nil(t, resp)
 		})
 		t.Run("JWT VP is missing nonce", func(t *testing.T) {
 			ctx := newTestClient(t)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 			presentation, _ := test.CreateJWTPresentation(t, *subjectDID, func(token jwt.Token) {
 				_ = token.Set(jwt.AudienceKey, issuerDID.String())
 				_ = token.Remove("nonce")
 			}, verifiableCredential)
 
-			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 			require.EqualError(t, err, "invalid_request - presentation has invalid/missing nonce")
 		})
 		t.Run("JWT VP has empty nonce", func(t *testing.T) {
 			ctx := newTestClient(t)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 			presentation, _ := test.CreateJWTPresentation(t, *subjectDID, func(token jwt.Token) {
 				_ = token.Set(jwt.AudienceKey, issuerDID.String())
 				_ = token.Set("nonce", "")
 			}, verifiableCredential)
 
-			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 			require.EqualError(t, err, "invalid_request - presentation has invalid/missing nonce")
 		})
 		t.Run("JWT VP nonce is not a string", func(t *testing.T) {
 			ctx := newTestClient(t)
-			ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 			presentation, _ := test.CreateJWTPresentation(t, *subjectDID, func(token jwt.Token) {
 				_ = token.Set(jwt.AudienceKey, issuerDID.String())
 				_ = token.Set("nonce", true)
 			}, verifiableCredential)
 
-			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			_, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 			require.EqualError(t, err, "invalid_request - presentation has invalid/missing nonce")
 		})
@@ -269,7 +281,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 			ctx := newTestClient(t)
 			presentation, _ := test.CreateJWTPresentation(t, *subjectDID, nil, verifiableCredential)
 
-			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 			assert.EqualError(t, err, "invalid_request - expected: did:web:example.com:iam:123, got: [] - presentation audience/domain is missing or does not match")
 			assert.Nil(t, resp)
@@ -280,7 +292,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 				require.NoError(t, token.Set(jwt.AudienceKey, "did:example:other"))
 			}, verifiableCredential)
 
-			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 			assert.EqualError(t, err, "invalid_request - expected: did:web:example.com:iam:123, got: [did:example:other] - presentation audience/domain is missing or does not match")
 			assert.Nil(t, resp)
@@ -289,9 +301,9 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	t.Run("VP verification fails", func(t *testing.T) {
 		ctx := newTestClient(t)
 		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(nil, errors.New("invalid"))
-		ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 		assert.EqualError(t, err, "invalid_request - invalid - presentation(s) or contained credential(s) are invalid")
 		assert.Nil(t, resp)
@@ -303,7 +315,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 				CredentialSubject: []interface{}{map[string]string{}},
 			})
 
-			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 
 			assert.EqualError(t, err, `invalid_request - unable to get subject DID from VC: credential subjects have no ID`)
 			assert.Nil(t, resp)
@@ -318,7 +330,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 			}
 			verifiablePresentationJSON, _ := verifiablePresentation.MarshalJSON()
 
-			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, string(verifiablePresentationJSON))
+			resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, string(verifiablePresentationJSON), "")
 
 			assert.EqualError(t, err, `invalid_request - presentation signer is not credential subject`)
 			assert.Nil(t, resp)
@@ -327,16 +339,16 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	t.Run("submission is not valid JSON", func(t *testing.T) {
 		ctx := newTestClient(t)
 
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, "not-a-valid-submission", presentation.Raw())
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, "not-a-valid-submission", presentation.Raw(), "")
 
 		assert.EqualError(t, err, `invalid_request - invalid presentation submission: invalid character 'o' in literal null (expecting 'u')`)
 		assert.Nil(t, resp)
 	})
 	t.Run("unsupported scope", func(t *testing.T) {
 		ctx := newTestClient(t)
-		ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, "everything").Return(nil, policy.ErrNotFound)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, "everything").Return(nil, policy.ErrNotFound)
 
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, "everything", submissionJSON, presentation.Raw())
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, "everything", submissionJSON, presentation.Raw(), "")
 
 		assert.EqualError(t, err, `invalid_scope - not found - unsupported scope (everything) for presentation exchange: not found`)
 		assert.Nil(t, resp)
@@ -356,9 +368,9 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 		presentation := test.CreateJSONLDPresentation(t, *subjectDID, proofVisitor, otherVerifiableCredential)
 
 		ctx := newTestClient(t)
-		ctx.policy.EXPECT().PresentationDefinition(gomock.Any(), issuerDID, requestedScope).Return(&definition, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), issuerDID, requestedScope).Return(policy.ScopedPresentationDefinitions{{Scope: requestedScope, PresentationDefinition: definition}}, nil)
 
-		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw())
+		resp, err := ctx.client.handleS2SAccessTokenRequest(context.Background(), issuerDID, requestedScope, submissionJSON, presentation.Raw(), "")
 		assert.EqualError(t, err, "invalid_request - presentation submission doesn't match presentation definition - presentation submission does not conform to Presentation Definition")
 		assert.Nil(t, resp)
 	})
@@ -387,13 +399,19 @@ func TestWrapper_createAccessToken(t *testing.T) {
 		ctx := newTestClient(t)
 
 		vps := []VerifiablePresentation{test.ParsePresentation(t, presentation)}
-		accessToken, err := ctx.client.createAccessToken(issuerDID, time.Now(), vps, &submission, definition, "everything", credentialSubjectID, nil)
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerDID, time.Now(), vps, &submission, definition, "everything", credentialSubjectID, nil, "")
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, accessToken.AccessToken)
 		assert.Equal(t, "bearer", accessToken.TokenType)
 		assert.Equal(t, 900, *accessToken.ExpiresIn)
 		assert.Equal(t, "everything", *accessToken.Scope)
+		require.NotNil(t, accessToken.RefreshToken)
+		var storedRefreshToken RefreshToken
+		err = ctx.client.refreshTokenStore().Get(*accessToken.RefreshToken, &storedRefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, "everything", storedRefreshToken.Scope)
+		assert.Equal(t, accessToken.AccessToken, storedRefreshToken.AccessToken.Token)
 
 		var storedToken AccessToken
 		err = ctx.client.accessTokenServerStore().Get(accessToken.AccessToken, &storedToken)
@@ -408,3 +426,91 @@ func TestWrapper_createAccessToken(t *testing.T) {
 		assert.NotEmpty(t, storedToken.Expiration)
 	})
 }
+
+func TestWrapper_validatePresentationSubmission(t *testing.T) {
+	const scope = "eOverdracht-overdrachtsbericht"
+	authorizer := did.MustParseDID("did:web:example.com:iam:123")
+	verifiableCredential := test.ValidNutsOrganizationCredential(t)
+	subjectDID, _ := verifiableCredential.SubjectDID()
+	fieldID := "credential_type"
+	credentialType := "NutsOrganizationCredential"
+	definition := pe.PresentationDefinition{
+		InputDescriptors: []*pe.InputDescriptor{
+			{
+				Id: "1",
+				Constraints: &pe.Constraints{
+					Fields: []pe.Field{
+						{
+							Id:     &fieldID,
+							Path:   []string{"$.type"},
+							Filter: &pe.Filter{Type: "string", Const: &credentialType},
+						},
+					},
+					IsHolder: []*pe.IsHolderItems{
+						{Directive: pe.DirectiveRequired, FieldId: []string{fieldID}},
+					},
+				},
+			},
+		},
+	}
+	submission := pe.PresentationSubmission{
+		DescriptorMap: []pe.InputDescriptorMappingObject{
+			{Id: "1", Path: "$.verifiableCredential", Format: vc.JSONLDCredentialProofFormat},
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		presentation := test.CreateJSONLDPresentation(t, *subjectDID, nil, verifiableCredential)
+		envelope, err := pe.ParseEnvelope([]byte(presentation.Raw()))
+		require.NoError(t, err)
+		ctx := newTestClient(t)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), authorizer, scope).Return(policy.ScopedPresentationDefinitions{{Scope: scope, PresentationDefinition: definition}}, nil)
+
+		credentialMap, _, grantedScope, err := ctx.client.validatePresentationSubmission(context.Background(), authorizer, scope, &submission, envelope)
+
+		require.NoError(t, err)
+		assert.Len(t, credentialMap, 1)
+		assert.Equal(t, scope, grantedScope)
+	})
+	t.Run("only scopes of which the presentation definition is fulfilled are granted", func(t *testing.T) {
+		presentation := test.CreateJSONLDPresentation(t, *subjectDID, nil, verifiableCredential)
+		envelope, err := pe.ParseEnvelope([]byte(presentation.Raw()))
+		require.NoError(t, err)
+		otherCredentialType := "OtherCredential"
+		otherDefinition := pe.PresentationDefinition{
+			Id: "other",
+			InputDescriptors: []*pe.InputDescriptor{
+				{
+					Id: "other",
+					Constraints: &pe.Constraints{
+						Fields: []pe.Field{{Path: []string{"$.type"}, Filter: &pe.Filter{Type: "string", Const: &otherCredentialType}}},
+					},
+				},
+			},
+		}
+		ctx := newTestClient(t)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), authorizer, "other "+scope).Return(policy.ScopedPresentationDefinitions{
+			{Scope: "other", PresentationDefinition: otherDefinition},
+			{Scope: scope, PresentationDefinition: definition},
+		}, nil)
+
+		credentialMap, resultDefinition, grantedScope, err := ctx.client.validatePresentationSubmission(context.Background(), authorizer, "other "+scope, &submission, envelope)
+
+		require.NoError(t, err)
+		assert.Len(t, credentialMap, 1)
+		assert.Equal(t, scope, grantedScope)
+		assert.Equal(t, definition.Id, resultDefinition.Id)
+	})
+	t.Run("is_holder constraint violated", func(t *testing.T) {
+		presentation := test.CreateJSONLDPresentation(t, did.MustParseDID("did:web:example.com:iam:other"), nil, verifiableCredential)
+		envelope, err := pe.ParseEnvelope([]byte(presentation.Raw()))
+		require.NoError(t, err)
+		ctx := newTestClient(t)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), authorizer, scope).Return(policy.ScopedPresentationDefinitions{{Scope: scope, PresentationDefinition: definition}}, nil)
+
+		credentialMap, _, _, err := ctx.client.validatePresentationSubmission(context.Background(), authorizer, scope, &submission, envelope)
+
+		assert.EqualError(t, err, "invalid_request - presentation submission violates presentation definition: is_holder constraint of input descriptor '1' not satisfied: credential subject is not the holder - presentation submission does not conform to Presentation Definition")
+		assert.Nil(t, credentialMap)
+	})
+}
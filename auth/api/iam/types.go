@@ -114,9 +114,12 @@ const (
 	// grantTypePreAuthorizedCode is defined in the pre-authorized_code flow of OpenID4VCI
 	// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-sub-namespace-registration
 	grantTypePreAuthorizedCode = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+	// grantTypeRefreshToken is used to exchange a refresh token for a new access token
+	// https://datatracker.ietf.org/doc/html/rfc6749#section-6
+	grantTypeRefreshToken = "refresh_token"
 )
 
-var grantTypesSupported = []string{grantTypeAuthorizationCode, grantTypeVPToken, grantTypePreAuthorizedCode}
+var grantTypesSupported = []string{grantTypeAuthorizationCode, grantTypeVPToken, grantTypePreAuthorizedCode, grantTypeRefreshToken}
 
 // clientIdSchemesSupported lists the supported client_id_scheme
 // https://openid.bitbucket.io/connect/openid-4-verifiable-presentations-1_0.html#name-verifier-metadata-managemen
@@ -75,34 +75,64 @@ func (r Wrapper) validatePresentationAudience(presentation vc.VerifiablePresenta
 }
 
 // validatePresentationSubmission checks if the presentation submission is valid for the given scope:
-//  1. Resolve presentation definition for the requested scope
-//  2. Check submission against presentation and definition
+//  1. Resolve the presentation definition of each requested scope
+//  2. Grant the scopes of which the presentation definition is fulfilled by the presented credentials
+//  3. Check submission against presentation and the (combined) definition of the granted scopes
 //
+// It returns the granted scope, which may be a subset of the requested scope.
 // Errors are returned as OAuth2 errors.
-func (r Wrapper) validatePresentationSubmission(ctx context.Context, authorizer did.DID, scope string, submission *pe.PresentationSubmission, pexEnvelope *pe.Envelope) (map[string]vc.VerifiableCredential, *PresentationDefinition, error) {
-	definition, err := r.policyBackend.PresentationDefinition(ctx, authorizer, scope)
+func (r Wrapper) validatePresentationSubmission(ctx context.Context, authorizer did.DID, scope string, submission *pe.PresentationSubmission, pexEnvelope *pe.Envelope) (map[string]vc.VerifiableCredential, *PresentationDefinition, string, error) {
+	definitions, err := r.policyBackend.PresentationDefinitions(ctx, authorizer, scope)
 	if err != nil {
 		if errors.Is(err, policy.ErrNotFound) {
-			return nil, nil, oauth.OAuth2Error{
+			return nil, nil, "", oauth.OAuth2Error{
 				Code:          oauth.InvalidScope,
 				InternalError: err,
 				Description:   fmt.Sprintf("unsupported scope (%s) for presentation exchange: %s", scope, err.Error()),
 			}
 		}
-		return nil, nil, oauth.OAuth2Error{
+		return nil, nil, "", oauth.OAuth2Error{
 			Code:          oauth.ServerError,
 			InternalError: err,
 			Description:   fmt.Sprintf("failed to retrieve presentation definition for scope (%s): %s", scope, err.Error()),
 		}
 	}
 
+	// each scope's presentation definition is evaluated separately, only the scopes of which it is fulfilled are granted
+	var granted policy.ScopedPresentationDefinitions
+	for _, curr := range definitions {
+		fulfilled, err := curr.PresentationDefinition.IsFulfilledBy(*pexEnvelope)
+		if err != nil {
+			return nil, nil, "", oauth.OAuth2Error{
+				Code:          oauth.InvalidRequest,
+				Description:   "presentation submission does not conform to Presentation Definition",
+				InternalError: fmt.Errorf("evaluating presentation definition of scope (%s): %w", curr.Scope, err),
+			}
+		}
+		if fulfilled {
+			granted = append(granted, curr)
+		}
+	}
+	if len(granted) == 0 {
+		// none of the scopes can be granted, validating the submission against all of them explains why
+		granted = definitions
+	}
+	definition, err := granted.Merge()
+	if err != nil {
+		return nil, nil, "", oauth.OAuth2Error{
+			Code:          oauth.ServerError,
+			InternalError: err,
+			Description:   fmt.Sprintf("failed to retrieve presentation definition for scope (%s): %s", granted.Scope(), err.Error()),
+		}
+	}
+
 	credentialMap, err := submission.Validate(*pexEnvelope, *definition)
 	if err != nil {
-		return nil, nil, oauth.OAuth2Error{
+		return nil, nil, "", oauth.OAuth2Error{
 			Code:          oauth.InvalidRequest,
 			Description:   "presentation submission does not conform to Presentation Definition",
 			InternalError: err,
 		}
 	}
-	return credentialMap, definition, err
+	return credentialMap, definition, granted.Scope(), err
 }
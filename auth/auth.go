@@ -21,6 +21,7 @@ package auth
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"github.com/nuts-foundation/nuts-node/auth/client/iam"
 	"github.com/nuts-foundation/nuts-node/vdr"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -77,6 +78,11 @@ func (auth *Auth) V2APIEnabled() bool {
 	return auth.config.V2APIEnabled
 }
 
+// AccessTokenFormat returns the format of access tokens issued by the v2 API.
+func (auth *Auth) AccessTokenFormat() string {
+	return auth.config.AccessTokenFormat
+}
+
 // PublicURL returns the public URL of the node.
 func (auth *Auth) PublicURL() *url.URL {
 	return auth.publicURL
@@ -126,6 +132,10 @@ func (auth *Auth) Configure(config core.ServerConfig) error {
 		return errors.New("in strictmode the only valid irma-scheme-manager is 'pbdf'")
 	}
 
+	if auth.config.AccessTokenFormat != AccessTokenFormatOpaque && auth.config.AccessTokenFormat != AccessTokenFormatJWT {
+		return fmt.Errorf("invalid access token format '%s', must be '%s' or '%s'", auth.config.AccessTokenFormat, AccessTokenFormatOpaque, AccessTokenFormatJWT)
+	}
+
 	var err error
 	auth.publicURL, err = config.ServerURL()
 	if err != nil {
@@ -94,6 +94,14 @@ func TestAuth_Configure(t *testing.T) {
 		assert.EqualError(t, err, "in strictmode the only valid irma-scheme-manager is 'pbdf'")
 	})
 
+	t.Run("error - invalid access token format", func(t *testing.T) {
+		authCfg := TestConfig()
+		authCfg.AccessTokenFormat = "xml"
+		i := testInstance(t, authCfg)
+		err := i.Configure(tlsServerConfig)
+		assert.EqualError(t, err, "invalid access token format 'xml', must be 'opaque' or 'jwt'")
+	})
+
 	t.Run("error - TLS required in strict mode", func(t *testing.T) {
 		authCfg := TestConfig()
 		i := testInstance(t, authCfg)
@@ -115,7 +115,9 @@ func (hb HTTPClient) PresentationDefinition(ctx context.Context, presentationDef
 	return &presentationDefinition, hb.doRequest(ctx, request, &presentationDefinition)
 }
 
-func (hb HTTPClient) AccessToken(ctx context.Context, tokenEndpoint string, data url.Values) (oauth.TokenResponse, error) {
+// AccessToken requests an access token at the given token endpoint.
+// If dpopProof is not empty, it's sent in the DPoP header to request an access token bound to the proof's key.
+func (hb HTTPClient) AccessToken(ctx context.Context, tokenEndpoint string, data url.Values, dpopProof string) (oauth.TokenResponse, error) {
 	var token oauth.TokenResponse
 	tokenURL, err := url.Parse(tokenEndpoint)
 	if err != nil {
@@ -129,6 +131,9 @@ func (hb HTTPClient) AccessToken(ctx context.Context, tokenEndpoint string, data
 	if err != nil {
 		return token, err
 	}
+	if dpopProof != "" {
+		request.Header.Set(oauth.DPoPHeader, dpopProof)
+	}
 	response, err := hb.httpClient.Do(request.WithContext(ctx))
 	if err != nil {
 		return token, fmt.Errorf("failed to call endpoint: %w", err)
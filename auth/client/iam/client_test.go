@@ -155,18 +155,28 @@ func TestHTTPClient_AccessToken(t *testing.T) {
 		handler := http2.Handler{StatusCode: http.StatusOK, ResponseData: tokenResponse}
 		tlsServer, client := testServerAndClient(t, &handler)
 
-		response, err := client.AccessToken(ctx, tlsServer.URL, data)
+		response, err := client.AccessToken(ctx, tlsServer.URL, data, "")
 
 		require.NoError(t, err)
 		require.NotNil(t, response)
 		assert.Equal(t, tokenResponse, response)
 		require.NotNil(t, handler.Request)
 	})
+	t.Run("ok - with DPoP proof", func(t *testing.T) {
+		handler := http2.Handler{StatusCode: http.StatusOK, ResponseData: tokenResponse}
+		tlsServer, client := testServerAndClient(t, &handler)
+
+		_, err := client.AccessToken(ctx, tlsServer.URL, data, "proof")
+
+		require.NoError(t, err)
+		require.NotNil(t, handler.Request)
+		assert.Equal(t, "proof", handler.Request.Header.Get("DPoP"))
+	})
 	t.Run("error - incorrect url", func(t *testing.T) {
 		handler := http2.Handler{StatusCode: http.StatusOK, ResponseData: tokenResponse}
 		_, client := testServerAndClient(t, &handler)
 
-		_, err := client.AccessToken(ctx, ":", data)
+		_, err := client.AccessToken(ctx, ":", data, "")
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "parse \":\": missing protocol scheme")
@@ -175,7 +185,7 @@ func TestHTTPClient_AccessToken(t *testing.T) {
 		handler := http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: oauth.OAuth2Error{Code: oauth.InvalidRequest}}
 		tlsServer, client := testServerAndClient(t, &handler)
 
-		_, err := client.AccessToken(ctx, tlsServer.URL, data)
+		_, err := client.AccessToken(ctx, tlsServer.URL, data, "")
 
 		require.Error(t, err)
 		// check if the error is an OAuth error
@@ -187,7 +197,7 @@ func TestHTTPClient_AccessToken(t *testing.T) {
 		handler := http2.Handler{StatusCode: http.StatusBadGateway, ResponseData: "offline"}
 		tlsServer, client := testServerAndClient(t, &handler)
 
-		_, err := client.AccessToken(ctx, tlsServer.URL, data)
+		_, err := client.AccessToken(ctx, tlsServer.URL, data, "")
 
 		require.Error(t, err)
 		// check if the error is a http error
@@ -199,7 +209,7 @@ func TestHTTPClient_AccessToken(t *testing.T) {
 		handler := http2.Handler{StatusCode: http.StatusOK, ResponseData: "}"}
 		tlsServer, client := testServerAndClient(t, &handler)
 
-		_, err := client.AccessToken(ctx, tlsServer.URL, data)
+		_, err := client.AccessToken(ctx, tlsServer.URL, data, "")
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "unable to unmarshal response: invalid character '}' looking for beginning of value, }")
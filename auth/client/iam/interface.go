@@ -35,6 +35,9 @@ type Client interface {
 	AuthorizationServerMetadata(ctx context.Context, webdid did.DID) (*oauth.AuthorizationServerMetadata, error)
 	// ClientMetadata returns the metadata of the remote verifier.
 	ClientMetadata(ctx context.Context, endpoint string) (*oauth.OAuthClientMetadata, error)
+	// CreateDPoPProof creates a DPoP proof (RFC9449) for a request with the given HTTP method and target URL,
+	// signed with the requester's assertion key. If accessToken is not empty, the proof is bound to it.
+	CreateDPoPProof(ctx context.Context, requester did.DID, method string, target string, accessToken string) (string, error)
 	// CreateAuthorizationRequest creates an OAuth2.0 authorizationRequest redirect URL that redirects to the authorization server.
 	// It can create both regular OAuth2 requests and OpenID4VP requests due to the RequestModifier.
 	// It's able to create an unsigned request and a signed request (JAR) based on the OAuth Server Metadata.
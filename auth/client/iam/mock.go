@@ -104,6 +104,21 @@ func (mr *MockClientMockRecorder) CreateAuthorizationRequest(ctx, client, server
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthorizationRequest", reflect.TypeOf((*MockClient)(nil).CreateAuthorizationRequest), ctx, client, server, modifier)
 }
 
+// CreateDPoPProof mocks base method.
+func (m *MockClient) CreateDPoPProof(ctx context.Context, requester did.DID, method, target, accessToken string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDPoPProof", ctx, requester, method, target, accessToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDPoPProof indicates an expected call of CreateDPoPProof.
+func (mr *MockClientMockRecorder) CreateDPoPProof(ctx, requester, method, target, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDPoPProof", reflect.TypeOf((*MockClient)(nil).CreateDPoPProof), ctx, requester, method, target, accessToken)
+}
+
 // PostAuthorizationResponse mocks base method.
 func (m *MockClient) PostAuthorizationResponse(ctx context.Context, vp vc.VerifiablePresentation, presentationSubmission pe.PresentationSubmission, verifierResponseURI, state string) (string, error) {
 	m.ctrl.T.Helper()
@@ -27,6 +27,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/nuts-foundation/nuts-node/auth/log"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	stdHttp "net/http"
 	"net/url"
 	"time"
 
@@ -147,7 +148,11 @@ func (c *OpenID4VPClient) AccessToken(ctx context.Context, code string, verifier
 	data.Set(oauth.GrantTypeParam, oauth.AuthorizationCodeGrantType)
 	data.Set(oauth.CodeParam, code)
 	data.Set(oauth.RedirectURIParam, callbackURI)
-	token, err := iamClient.AccessToken(ctx, metadata.TokenEndpoint, data)
+	dpopProof, err := c.tokenRequestDPoPProof(ctx, clientID, *metadata)
+	if err != nil {
+		return nil, err
+	}
+	token, err := iamClient.AccessToken(ctx, metadata.TokenEndpoint, data, dpopProof)
 	if err != nil {
 		return nil, fmt.Errorf("remote server: error creating access token: %w", err)
 	}
@@ -251,8 +256,12 @@ func (c *OpenID4VPClient) RequestRFC021AccessToken(ctx context.Context, requeste
 	data.Set(oauth.AssertionParam, assertion)
 	data.Set(oauth.PresentationSubmissionParam, string(presentationSubmission))
 	data.Set(oauth.ScopeParam, scopes)
+	dpopProof, err := c.tokenRequestDPoPProof(ctx, requester, *metadata)
+	if err != nil {
+		return nil, err
+	}
 	log.Logger().Tracef("Requesting access token from '%s' for scope '%s'\n  VP: %s\n  Submission: %s", metadata.TokenEndpoint, scopes, assertion, string(presentationSubmission))
-	token, err := iamClient.AccessToken(ctx, metadata.TokenEndpoint, data)
+	token, err := iamClient.AccessToken(ctx, metadata.TokenEndpoint, data, dpopProof)
 	if err != nil {
 		// the error could be a http error, we just relay it here to make use of any 400 status codes.
 		return nil, err
@@ -264,3 +273,28 @@ func (c *OpenID4VPClient) RequestRFC021AccessToken(ctx context.Context, requeste
 		Scope:       &scopes,
 	}, nil
 }
+
+func (c *OpenID4VPClient) CreateDPoPProof(ctx context.Context, requester did.DID, method string, target string, accessToken string) (string, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil || !targetURL.IsAbs() {
+		return "", core.InvalidInputError("invalid DPoP target URL: %s", target)
+	}
+	keyID, publicKey, err := c.keyResolver.ResolveKey(requester, nil, resolver.AssertionMethod)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve DPoP key: %w", err)
+	}
+	proof, err := oauth.CreateDPoPProof(ctx, c.jwtSigner, keyID.String(), publicKey, method, *targetURL, accessToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to create DPoP proof: %w", err)
+	}
+	return proof, nil
+}
+
+// tokenRequestDPoPProof creates a DPoP proof for a request to the token endpoint, binding the access token to the requester's key.
+// If the authorization server doesn't support DPoP, it returns an empty string and a bearer token will be requested.
+func (c *OpenID4VPClient) tokenRequestDPoPProof(ctx context.Context, requester did.DID, metadata oauth.AuthorizationServerMetadata) (string, error) {
+	if len(metadata.DPoPSigningAlgValuesSupported) == 0 {
+		return "", nil
+	}
+	return c.CreateDPoPProof(ctx, requester, stdHttp.MethodPost, metadata.TokenEndpoint, "")
+}
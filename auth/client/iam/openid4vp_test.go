@@ -314,6 +314,21 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 		assert.Equal(t, "token", response.AccessToken)
 		assert.Equal(t, "bearer", response.TokenType)
 	})
+	t.Run("ok - DPoP", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		ctx.authzServerMetadata.DPoPSigningAlgValuesSupported = []string{"ES256"}
+		keyID := walletDID.URI()
+		keyID.Fragment = "1"
+		key := crypto.NewTestKey(keyID.String())
+		ctx.wallet.EXPECT().BuildSubmission(gomock.Any(), walletDID, gomock.Any(), oauth.DefaultOpenIDSupportedFormats(), gomock.Any()).Return(&vc.VerifiablePresentation{}, &pe.PresentationSubmission{}, nil)
+		ctx.keyResolver.EXPECT().ResolveKey(walletDID, nil, resolver.AssertionMethod).Return(keyID, key.Public(), nil)
+		ctx.jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), keyID.String()).Return("proof", nil)
+
+		_, err := ctx.client.RequestRFC021AccessToken(context.Background(), walletDID, ctx.verifierDID, scopes)
+
+		require.NoError(t, err)
+		assert.Equal(t, "proof", ctx.tokenRequestDPoPProof)
+	})
 	t.Run("error - access denied", func(t *testing.T) {
 		oauthError := oauth.OAuth2Error{
 			Code:        "invalid_scope",
@@ -377,6 +392,45 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 	})
 }
 
+func TestIAMClient_CreateDPoPProof(t *testing.T) {
+	walletDID := did.MustParseDID("did:web:test.test:iam:123")
+	keyID := walletDID.URI()
+	keyID.Fragment = "1"
+	key := crypto.NewTestKey(keyID.String())
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := createClientTestContext(t, nil)
+		ctx.keyResolver.EXPECT().ResolveKey(walletDID, nil, resolver.AssertionMethod).Return(keyID, key.Public(), nil)
+		ctx.jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), keyID.String()).DoAndReturn(func(_ context.Context, claims map[string]interface{}, headers map[string]interface{}, _ string) (string, error) {
+			assert.Equal(t, "GET", claims["htm"])
+			assert.Equal(t, "https://example.com/resource", claims["htu"])
+			assert.NotEmpty(t, claims["ath"])
+			assert.Equal(t, "dpop+jwt", headers["typ"])
+			return "proof", nil
+		})
+
+		proof, err := ctx.client.CreateDPoPProof(context.Background(), walletDID, http.MethodGet, "https://example.com/resource?foo=bar", "token")
+
+		require.NoError(t, err)
+		assert.Equal(t, "proof", proof)
+	})
+	t.Run("error - invalid URL", func(t *testing.T) {
+		ctx := createClientTestContext(t, nil)
+
+		_, err := ctx.client.CreateDPoPProof(context.Background(), walletDID, http.MethodGet, "/resource", "token")
+
+		assert.EqualError(t, err, "invalid DPoP target URL: /resource")
+	})
+	t.Run("error - key not found", func(t *testing.T) {
+		ctx := createClientTestContext(t, nil)
+		ctx.keyResolver.EXPECT().ResolveKey(walletDID, nil, resolver.AssertionMethod).Return(keyID, nil, resolver.ErrKeyNotFound)
+
+		_, err := ctx.client.CreateDPoPProof(context.Background(), walletDID, http.MethodGet, "https://example.com/resource", "token")
+
+		assert.ErrorIs(t, err, resolver.ErrKeyNotFound)
+	})
+}
+
 func createClientTestContext(t *testing.T, tlsConfig *tls.Config) *clientTestContext {
 	ctrl := gomock.NewController(t)
 	jwtSigner := crypto.NewMockJWTSigner(ctrl)
@@ -422,6 +476,7 @@ type clientServerTestContext struct {
 	presentationDefinition func(writer http.ResponseWriter)
 	response               func(writer http.ResponseWriter)
 	token                  func(writer http.ResponseWriter)
+	tokenRequestDPoPProof  string
 }
 
 func createClientServerTestContext(t *testing.T) *clientServerTestContext {
@@ -495,6 +550,7 @@ func createClientServerTestContext(t *testing.T) *clientServerTestContext {
 				return
 			}
 		case "/token":
+			ctx.tokenRequestDPoPProof = request.Header.Get(oauth.DPoPHeader)
 			if ctx.token != nil {
 				ctx.token(writer)
 				return
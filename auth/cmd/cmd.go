@@ -41,6 +41,9 @@ const ConfHTTPTimeout = "auth.http.timeout"
 // ConfAccessTokenLifeSpan defines how long (in seconds) an access token is valid
 const ConfAccessTokenLifeSpan = "auth.accesstokenlifespan"
 
+// ConfAccessTokenFormat defines the format of access tokens issued by the v2 API
+const ConfAccessTokenFormat = "auth.accesstokenformat"
+
 // ConfV2APIEnabled enables experimental v2 API endpoints
 const ConfV2APIEnabled = "auth.v2apienabled"
 
@@ -54,6 +57,7 @@ func FlagSet() *pflag.FlagSet {
 	flags.Int(ConfHTTPTimeout, defs.HTTPTimeout, "HTTP timeout (in seconds) used by the Auth API HTTP client")
 	flags.Int(ConfClockSkew, defs.ClockSkew, "allowed JWT Clock skew in milliseconds")
 	flags.Int(ConfAccessTokenLifeSpan, defs.AccessTokenLifeSpan, "defines how long (in seconds) an access token is valid. Uses default in strict mode.")
+	flags.String(ConfAccessTokenFormat, defs.AccessTokenFormat, "format of access tokens issued by the v2 API. Either 'opaque' (must be introspected) or 'jwt' (signed JWT as specified by RFC9068, can be validated using the authorization server's JWKS).")
 	flags.StringSlice(ConfContractValidators, defs.ContractValidators, "sets the different contract validators to use")
 	flags.Bool(ConfV2APIEnabled, defs.V2APIEnabled, "enables experimental v2 API endpoints")
 	_ = flags.MarkHidden(ConfV2APIEnabled)
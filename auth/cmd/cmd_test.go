@@ -42,6 +42,7 @@ func TestFlagSet(t *testing.T) {
 	sort.Strings(keys)
 
 	assert.Equal(t, []string{
+		ConfAccessTokenFormat,
 		ConfAccessTokenLifeSpan,
 		ConfClockSkew,
 		ConfContractValidators,
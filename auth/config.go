@@ -25,6 +25,13 @@ import (
 	"github.com/nuts-foundation/nuts-node/auth/services/uzi"
 )
 
+// AccessTokenFormatOpaque is the access token format for opaque access tokens, which must be introspected by resource servers.
+const AccessTokenFormatOpaque = "opaque"
+
+// AccessTokenFormatJWT is the access token format for self-contained, signed JWT access tokens (RFC9068),
+// which can be validated by resource servers using the authorization server's JWKS.
+const AccessTokenFormatJWT = "jwt"
+
 // Config holds all the configuration params
 type Config struct {
 	Irma                IrmaConfig `koanf:"irma"`
@@ -32,6 +39,7 @@ type Config struct {
 	ClockSkew           int        `koanf:"clockskew"`
 	ContractValidators  []string   `koanf:"contractvalidators"`
 	AccessTokenLifeSpan int        `koanf:"accesstokenlifespan"`
+	AccessTokenFormat   string     `koanf:"accesstokenformat"`
 	V2APIEnabled        bool       `koanf:"v2apienabled"`
 }
 
@@ -55,5 +63,6 @@ func DefaultConfig() Config {
 			selfsigned.ContractFormat,
 		},
 		AccessTokenLifeSpan: 60, // seconds, as specced in RFC003
+		AccessTokenFormat:   AccessTokenFormatOpaque,
 	}
 }
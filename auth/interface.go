@@ -41,6 +41,8 @@ type AuthenticationServices interface {
 	// V2APIEnabled returns true if the V2 API is enabled.
 	// It is disabled by default, since it's still in development.
 	V2APIEnabled() bool
+	// AccessTokenFormat returns the format of access tokens issued by the v2 API: AccessTokenFormatOpaque or AccessTokenFormatJWT.
+	AccessTokenFormat() string
 	// PublicURL returns the public URL of the node.
 	PublicURL() *url.URL
 }
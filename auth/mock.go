@@ -42,6 +42,20 @@ func (m *MockAuthenticationServices) EXPECT() *MockAuthenticationServicesMockRec
 	return m.recorder
 }
 
+// AccessTokenFormat mocks base method.
+func (m *MockAuthenticationServices) AccessTokenFormat() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessTokenFormat")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AccessTokenFormat indicates an expected call of AccessTokenFormat.
+func (mr *MockAuthenticationServicesMockRecorder) AccessTokenFormat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessTokenFormat", reflect.TypeOf((*MockAuthenticationServices)(nil).AccessTokenFormat))
+}
+
 // AuthzServer mocks base method.
 func (m *MockAuthenticationServices) AuthzServer() oauth.AuthorizationServer {
 	m.ctrl.T.Helper()
@@ -0,0 +1,186 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package oauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+)
+
+// DPoPHeader is the HTTP header that contains the DPoP proof, as specified by RFC9449.
+const DPoPHeader = "DPoP"
+
+// DPoPTokenType is the token_type of access tokens that are bound to a DPoP key, as specified by RFC9449.
+const DPoPTokenType = "DPoP"
+
+// DPoPProofMaxAge defines how old a DPoP proof may be, based on its iat claim.
+// Proofs are created right before a request is sent, so this only needs to accommodate for clock skew and network latency.
+const DPoPProofMaxAge = time.Minute
+
+// dpopJWTType is the typ header of a DPoP proof.
+const dpopJWTType = "dpop+jwt"
+
+// DPoPSigningAlgValuesSupported returns the JWS algorithms supported for DPoP proofs,
+// used in the Authorization Server's metadata field `dpop_signing_alg_values_supported`.
+func DPoPSigningAlgValuesSupported() []string {
+	return algValuesSupported
+}
+
+// DPoPProof contains the validated contents of a DPoP proof.
+type DPoPProof struct {
+	// ID is the unique identifier (jti) of the proof, used to detect replay.
+	ID string
+	// IssuedAt is the time the proof was created.
+	IssuedAt time.Time
+	// KeyThumbprint is the base64url encoded SHA-256 JWK thumbprint (RFC7638) of the key that signed the proof.
+	// An access token bound to the key contains it as cnf.jkt claim.
+	KeyThumbprint string
+}
+
+// CreateDPoPProof creates a DPoP proof for an HTTP request with the given method and target URL, signed with the given key.
+// If accessToken is not empty, the proof contains its hash (ath claim), which is required when using a DPoP-bound access token.
+func CreateDPoPProof(ctx context.Context, signer nutsCrypto.JWTSigner, kid string, publicKey crypto.PublicKey, method string, target url.URL, accessToken string) (string, error) {
+	publicKeyAsJWK, err := jwk.FromRaw(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid DPoP key: %w", err)
+	}
+	claims := map[string]interface{}{
+		jwt.JwtIDKey:    nutsCrypto.GenerateNonce(),
+		jwt.IssuedAtKey: time.Now().Unix(),
+		"htm":           method,
+		"htu":           dpopTargetURI(target),
+	}
+	if accessToken != "" {
+		claims["ath"] = accessTokenHash(accessToken)
+	}
+	headers := map[string]interface{}{
+		jws.TypeKey: dpopJWTType,
+		jws.JWKKey:  publicKeyAsJWK,
+	}
+	return signer.SignJWT(ctx, claims, headers, kid)
+}
+
+// ParseDPoPProof parses and validates a DPoP proof for an HTTP request with the given method and target URL, as specified by RFC9449 section 4.3.
+// If accessToken is not empty, the proof must contain its hash (ath claim).
+// It does not check whether the proof has been used before; the caller must do so using the returned proof ID.
+func ParseDPoPProof(proof string, method string, target url.URL, accessToken string) (*DPoPProof, error) {
+	message, err := jws.ParseString(proof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DPoP proof: %w", err)
+	}
+	if len(message.Signatures()) != 1 {
+		return nil, errors.New("invalid DPoP proof: incorrect number of signatures")
+	}
+	headers := message.Signatures()[0].ProtectedHeaders()
+	if headers.Type() != dpopJWTType {
+		return nil, fmt.Errorf("invalid DPoP proof: typ must be %s", dpopJWTType)
+	}
+	key := headers.JWK()
+	if key == nil {
+		return nil, errors.New("invalid DPoP proof: missing jwk header")
+	}
+	var privateKey crypto.Signer
+	if err := key.Raw(&privateKey); err == nil {
+		return nil, errors.New("invalid DPoP proof: jwk header contains a private key")
+	}
+	var publicKey crypto.PublicKey
+	if err := key.Raw(&publicKey); err != nil {
+		return nil, fmt.Errorf("invalid DPoP proof: invalid jwk header: %w", err)
+	}
+	token, err := nutsCrypto.ParseJWT(proof, func(_ string) (crypto.PublicKey, error) {
+		return publicKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid DPoP proof: %w", err)
+	}
+
+	if token.JwtID() == "" {
+		return nil, errors.New("invalid DPoP proof: missing jti claim")
+	}
+	if htm, _ := token.Get("htm"); htm != method {
+		return nil, errors.New("invalid DPoP proof: htm does not match the HTTP method of the request")
+	}
+	htu, _ := token.Get("htu")
+	htuString, _ := htu.(string)
+	htuURL, err := url.Parse(htuString)
+	if err != nil || dpopTargetURI(*htuURL) != dpopTargetURI(target) {
+		return nil, errors.New("invalid DPoP proof: htu does not match the URL of the request")
+	}
+	issuedAt := token.IssuedAt()
+	if issuedAt.IsZero() {
+		return nil, errors.New("invalid DPoP proof: missing iat claim")
+	}
+	if time.Since(issuedAt) > DPoPProofMaxAge || time.Until(issuedAt) > DPoPProofMaxAge {
+		return nil, errors.New("invalid DPoP proof: iat is too far from the current time")
+	}
+	if accessToken != "" {
+		if ath, _ := token.Get("ath"); ath != accessTokenHash(accessToken) {
+			return nil, errors.New("invalid DPoP proof: ath does not match the access token")
+		}
+	}
+	thumbprint, err := DPoPKeyThumbprint(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DPoP proof: %w", err)
+	}
+	return &DPoPProof{
+		ID:            token.JwtID(),
+		IssuedAt:      issuedAt,
+		KeyThumbprint: thumbprint,
+	}, nil
+}
+
+// DPoPKeyThumbprint returns the base64url encoded SHA-256 JWK thumbprint (RFC7638) of the given public key,
+// as used in the cnf.jkt claim of DPoP-bound access tokens.
+func DPoPKeyThumbprint(publicKey crypto.PublicKey) (string, error) {
+	key, err := jwk.FromRaw(publicKey)
+	if err != nil {
+		return "", err
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// dpopTargetURI returns the URI used in the htu claim: the URL without query and fragment parts.
+func dpopTargetURI(target url.URL) string {
+	target.RawQuery = ""
+	target.ForceQuery = false
+	target.Fragment = ""
+	target.RawFragment = ""
+	return target.String()
+}
+
+// accessTokenHash returns the value of the ath claim for the given access token.
+func accessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package oauth
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDPoPProof(t *testing.T) {
+	ctx := audit.TestContext()
+	keyStore := nutsCrypto.NewMemoryCryptoInstance()
+	key, err := keyStore.New(ctx, nutsCrypto.ECP256Key, nutsCrypto.StringNamingFunc("did:web:example.com#key-1"))
+	require.NoError(t, err)
+	target, _ := url.Parse("https://example.com/iam/123/token")
+	expectedThumbprint, err := DPoPKeyThumbprint(key.Public())
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		proof, err := CreateDPoPProof(ctx, keyStore, key.KID(), key.Public(), http.MethodPost, *target, "")
+		require.NoError(t, err)
+
+		result, err := ParseDPoPProof(proof, http.MethodPost, *target, "")
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.ID)
+		assert.Equal(t, expectedThumbprint, result.KeyThumbprint)
+		assert.WithinDuration(t, time.Now(), result.IssuedAt, 2*time.Second)
+	})
+	t.Run("ok - query and fragment are ignored", func(t *testing.T) {
+		proof, err := CreateDPoPProof(ctx, keyStore, key.KID(), key.Public(), http.MethodPost, *target, "")
+		require.NoError(t, err)
+		requestURL, _ := url.Parse(target.String() + "?foo=bar#fragment")
+
+		_, err = ParseDPoPProof(proof, http.MethodPost, *requestURL, "")
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - with access token", func(t *testing.T) {
+		proof, err := CreateDPoPProof(ctx, keyStore, key.KID(), key.Public(), http.MethodGet, *target, "token")
+		require.NoError(t, err)
+
+		_, err = ParseDPoPProof(proof, http.MethodGet, *target, "token")
+
+		assert.NoError(t, err)
+	})
+	t.Run("access token mismatch", func(t *testing.T) {
+		proof, err := CreateDPoPProof(ctx, keyStore, key.KID(), key.Public(), http.MethodGet, *target, "token")
+		require.NoError(t, err)
+
+		_, err = ParseDPoPProof(proof, http.MethodGet, *target, "other token")
+
+		assert.EqualError(t, err, "invalid DPoP proof: ath does not match the access token")
+	})
+	t.Run("method mismatch", func(t *testing.T) {
+		proof, err := CreateDPoPProof(ctx, keyStore, key.KID(), key.Public(), http.MethodGet, *target, "")
+		require.NoError(t, err)
+
+		_, err = ParseDPoPProof(proof, http.MethodPost, *target, "")
+
+		assert.EqualError(t, err, "invalid DPoP proof: htm does not match the HTTP method of the request")
+	})
+	t.Run("URL mismatch", func(t *testing.T) {
+		proof, err := CreateDPoPProof(ctx, keyStore, key.KID(), key.Public(), http.MethodPost, *target, "")
+		require.NoError(t, err)
+		otherURL, _ := url.Parse("https://example.com/iam/456/token")
+
+		_, err = ParseDPoPProof(proof, http.MethodPost, *otherURL, "")
+
+		assert.EqualError(t, err, "invalid DPoP proof: htu does not match the URL of the request")
+	})
+	t.Run("too old", func(t *testing.T) {
+		claims := map[string]interface{}{
+			"jti": "1",
+			"iat": time.Now().Add(-2 * DPoPProofMaxAge).Unix(),
+			"htm": http.MethodPost,
+			"htu": target.String(),
+		}
+		proof := signDPoPProof(t, keyStore, key, claims, nil)
+
+		_, err := ParseDPoPProof(proof, http.MethodPost, *target, "")
+
+		assert.EqualError(t, err, "invalid DPoP proof: iat is too far from the current time")
+	})
+	t.Run("missing jti", func(t *testing.T) {
+		claims := map[string]interface{}{
+			"iat": time.Now().Unix(),
+			"htm": http.MethodPost,
+			"htu": target.String(),
+		}
+		proof := signDPoPProof(t, keyStore, key, claims, nil)
+
+		_, err := ParseDPoPProof(proof, http.MethodPost, *target, "")
+
+		assert.EqualError(t, err, "invalid DPoP proof: missing jti claim")
+	})
+	t.Run("invalid typ", func(t *testing.T) {
+		proof := signDPoPProof(t, keyStore, key, map[string]interface{}{"jti": "1"}, map[string]interface{}{jws.TypeKey: "JWT"})
+
+		_, err := ParseDPoPProof(proof, http.MethodPost, *target, "")
+
+		assert.EqualError(t, err, "invalid DPoP proof: typ must be dpop+jwt")
+	})
+	t.Run("signed with other key than in jwk header", func(t *testing.T) {
+		otherKey, err := keyStore.New(ctx, nutsCrypto.ECP256Key, nutsCrypto.StringNamingFunc("did:web:example.com#key-2"))
+		require.NoError(t, err)
+		proof, err := CreateDPoPProof(ctx, keyStore, otherKey.KID(), key.Public(), http.MethodPost, *target, "")
+		require.NoError(t, err)
+
+		_, err = ParseDPoPProof(proof, http.MethodPost, *target, "")
+
+		assert.ErrorContains(t, err, "invalid DPoP proof: could not verify message")
+	})
+	t.Run("not a JWT", func(t *testing.T) {
+		_, err := ParseDPoPProof("not a JWT", http.MethodPost, *target, "")
+
+		assert.ErrorContains(t, err, "invalid DPoP proof")
+	})
+}
+
+func signDPoPProof(t *testing.T, keyStore nutsCrypto.JWTSigner, key nutsCrypto.Key, claims map[string]interface{}, headers map[string]interface{}) string {
+	publicKeyJWK, err := jwk.FromRaw(key.Public())
+	require.NoError(t, err)
+	allHeaders := map[string]interface{}{
+		jws.TypeKey: dpopJWTType,
+		jws.JWKKey:  publicKeyJWK,
+	}
+	for k, v := range headers {
+		allHeaders[k] = v
+	}
+	proof, err := keyStore.SignJWT(audit.TestContext(), claims, allHeaders, key.KID())
+	require.NoError(t, err)
+	return proof
+}
@@ -36,6 +36,9 @@ const (
 	// InvalidRequest is returned when the request is missing a required parameter, includes an invalid parameter value,
 	// includes a parameter more than once, or is otherwise malformed.
 	InvalidRequest ErrorCode = "invalid_request"
+	// InvalidGrant is returned when the provided authorization grant (e.g. authorization code, refresh token) is invalid, expired, revoked,
+	// or was issued to another client.
+	InvalidGrant ErrorCode = "invalid_grant"
 	// UnsupportedGrantType is returned when the authorization grant type is not supported by the authorization server.
 	UnsupportedGrantType ErrorCode = "unsupported_grant_type"
 	// UnsupportedResponseType is returned when the authorization server does not support obtaining an authorization code using this method.
@@ -44,6 +47,9 @@ const (
 	ServerError ErrorCode = "server_error"
 	// InvalidScope is returned when the requested scope is invalid, unknown or malformed.
 	InvalidScope = ErrorCode("invalid_scope")
+	// InvalidDPoPProof is returned when the DPoP proof in the DPoP header of the request is missing (while required) or invalid.
+	// Specified by https://datatracker.ietf.org/doc/html/rfc9449#section-12.2
+	InvalidDPoPProof = ErrorCode("invalid_dpop_proof")
 	// InvalidPresentationDefinitionURI is returned when the requested presentation definition URI is invalid or can't be reached.
 	InvalidPresentationDefinitionURI = ErrorCode("invalid_presentation_definition_uri")
 )
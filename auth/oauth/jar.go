@@ -0,0 +1,63 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package oauth
+
+import (
+	"context"
+	"crypto"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/nuts-foundation/go-did/did"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// ValidateJARRequest validates a JAR (JWT Authorization Request, RFC9101) and returns the JWT claims.
+// The JWT must be signed with an assertionMethod key of the DID in the client_id, which must match the given clientID.
+// This authenticates the client, so the returned claims are the authorization request parameters to use.
+func ValidateJARRequest(ctx context.Context, keyResolver resolver.KeyResolver, rawToken string, clientID string) (map[string]interface{}, error) {
+	var signerKid string
+	// Parse and validate the JWT
+	token, err := nutsCrypto.ParseJWT(rawToken, func(kid string) (crypto.PublicKey, error) {
+		signerKid = kid
+		return keyResolver.ResolveKeyByID(kid, nil, resolver.AssertionMethod)
+	}, jwt.WithValidate(true))
+	if err != nil {
+		return nil, OAuth2Error{Code: InvalidRequest, Description: "invalid request parameter", InternalError: err}
+	}
+	claims, err := token.AsMap(ctx)
+	if err != nil {
+		// very unlikely
+		return nil, OAuth2Error{Code: InvalidRequest, Description: "invalid request parameter", InternalError: err}
+	}
+	// check client_id claim, it must be the same as the client_id in the request
+	if claimedClientID, _ := claims[ClientIDParam].(string); claimedClientID != clientID {
+		return nil, OAuth2Error{Code: InvalidRequest, Description: "invalid client_id claim in signed authorization request"}
+	}
+	// check if the signer of the JWT is the client
+	signer, err := did.ParseDIDURL(signerKid)
+	if err != nil {
+		// very unlikely since the key has already been resolved
+		return nil, OAuth2Error{Code: InvalidRequest, Description: "invalid signer", InternalError: err}
+	}
+	if signer.DID.String() != clientID {
+		return nil, OAuth2Error{Code: InvalidRequest, Description: "client_id does not match signer of authorization request"}
+	}
+	return claims, nil
+}
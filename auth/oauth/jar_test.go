@@ -0,0 +1,75 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package oauth
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateJARRequest(t *testing.T) {
+	ctx := audit.TestContext()
+	const clientID = "did:web:example.com"
+	const kid = clientID + "#key-1"
+	keyStore := nutsCrypto.NewMemoryCryptoInstance()
+	key, err := keyStore.New(ctx, nutsCrypto.ECP256Key, nutsCrypto.StringNamingFunc(kid))
+	require.NoError(t, err)
+	keyResolver := resolver.NewMockKeyResolver(gomock.NewController(t))
+	keyResolver.EXPECT().ResolveKeyByID(kid, nil, resolver.AssertionMethod).Return(key.Public(), nil).AnyTimes()
+	sign := func(claims map[string]interface{}) string {
+		token, err := keyStore.SignJWT(ctx, claims, nil, kid)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		token := sign(map[string]interface{}{ClientIDParam: clientID, ResponseTypeParam: CodeResponseType})
+
+		claims, err := ValidateJARRequest(ctx, keyResolver, token, clientID)
+
+		require.NoError(t, err)
+		assert.Equal(t, CodeResponseType, claims[ResponseTypeParam])
+	})
+	t.Run("invalid JWT", func(t *testing.T) {
+		_, err := ValidateJARRequest(ctx, keyResolver, "invalid", clientID)
+
+		require.ErrorAs(t, err, new(OAuth2Error))
+		assert.ErrorContains(t, err, "invalid request parameter")
+	})
+	t.Run("client_id claim does not match client_id", func(t *testing.T) {
+		token := sign(map[string]interface{}{ClientIDParam: clientID})
+
+		_, err := ValidateJARRequest(ctx, keyResolver, token, "did:web:other.com")
+
+		assert.EqualError(t, err, "invalid_request - invalid client_id claim in signed authorization request")
+	})
+	t.Run("signer does not match client_id", func(t *testing.T) {
+		token := sign(map[string]interface{}{ClientIDParam: "did:web:other.com"})
+
+		_, err := ValidateJARRequest(ctx, keyResolver, token, "did:web:other.com")
+
+		assert.EqualError(t, err, "invalid_request - client_id does not match signer of authorization request")
+	})
+}
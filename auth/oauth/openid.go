@@ -18,10 +18,10 @@
 
 package oauth
 
-// algValuesSupported contains a list of supported cipher suites for jwt_vc_json & jwt_vp_json presentation formats
+// algValuesSupported contains a list of supported cipher suites for jwt_vc_json, jwt_vp_json & vc+sd-jwt presentation formats
 // Recommended list of options https://www.iana.org/assignments/jose/jose.xhtml#web-signature-encryption-algorithms
 // TODO: validate list, should reflect current recommendations from https://www.ncsc.nl
-var algValuesSupported = []string{"PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+var algValuesSupported = []string{"PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
 
 // proofTypeValuesSupported contains a list of supported cipher suites for ldp_vc & ldp_vp presentation formats
 // Recommended list of options https://w3c-ccg.github.io/ld-cryptosuite-registry/
@@ -39,6 +39,7 @@ func DefaultOpenIDSupportedFormats() map[string]map[string][]string {
 		"jwt_vc_json": {"alg_values_supported": algValuesSupported},
 		"ldp_vc":      {"proof_type_values_supported": proofTypeValuesSupported},
 		"ldp_vp":      {"proof_type_values_supported": proofTypeValuesSupported},
+		"vc+sd-jwt":   {"sd-jwt_alg_values": algValuesSupported, "kb-jwt_alg_values": algValuesSupported},
 	}
 }
 
@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// this file contains Proof Key for Code Exchange (PKCE) support, as specified by https://www.rfc-editor.org/rfc/rfc7636
+
+const (
+	// CodeChallengeParam is the parameter name for the code_challenge parameter
+	CodeChallengeParam = "code_challenge"
+	// CodeChallengeMethodParam is the parameter name for the code_challenge_method parameter
+	CodeChallengeMethodParam = "code_challenge_method"
+	// CodeVerifierParam is the parameter name for the code_verifier parameter
+	CodeVerifierParam = "code_verifier"
+	// PKCEMethodS256 is the code_challenge_method for SHA-256 based code challenges.
+	// The "plain" method is not supported, since it doesn't protect against interception of the authorization request.
+	PKCEMethodS256 = "S256"
+)
+
+// PKCEParams contains the code verifier and derived code challenge of a PKCE protected authorization code flow.
+type PKCEParams struct {
+	// Challenge is sent in the authorization request.
+	Challenge string
+	// ChallengeMethod is sent in the authorization request, it is always S256.
+	ChallengeMethod string
+	// Verifier is kept secret by the client and sent in the token request.
+	Verifier string
+}
+
+// GeneratePKCEParams generates a random code verifier and its S256 code challenge.
+func GeneratePKCEParams() PKCEParams {
+	// 32 bytes result in a 43 character verifier, the minimum length specified by RFC7636
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(buf)
+	return PKCEParams{
+		Challenge:       pkceS256Challenge(verifier),
+		ChallengeMethod: PKCEMethodS256,
+		Verifier:        verifier,
+	}
+}
+
+// ValidatePKCEParams checks whether the code verifier matches the code challenge, given the challenge method.
+// Only the S256 method is supported.
+func ValidatePKCEParams(challenge string, challengeMethod string, verifier string) bool {
+	if challengeMethod != PKCEMethodS256 || challenge == "" || len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(pkceS256Challenge(verifier)), []byte(challenge)) == 1
+}
+
+func pkceS256Challenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
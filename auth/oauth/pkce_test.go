@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package oauth
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGeneratePKCEParams(t *testing.T) {
+	params := GeneratePKCEParams()
+
+	assert.Equal(t, PKCEMethodS256, params.ChallengeMethod)
+	assert.Len(t, params.Verifier, 43)
+	assert.NotEqual(t, params.Verifier, params.Challenge)
+	assert.True(t, ValidatePKCEParams(params.Challenge, params.ChallengeMethod, params.Verifier))
+	assert.NotEqual(t, params.Verifier, GeneratePKCEParams().Verifier)
+}
+
+func TestValidatePKCEParams(t *testing.T) {
+	// example from RFC7636, appendix B
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	const challenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	t.Run("ok", func(t *testing.T) {
+		assert.True(t, ValidatePKCEParams(challenge, PKCEMethodS256, verifier))
+	})
+	t.Run("verifier does not match challenge", func(t *testing.T) {
+		assert.False(t, ValidatePKCEParams(challenge, PKCEMethodS256, GeneratePKCEParams().Verifier))
+	})
+	t.Run("plain method is not supported", func(t *testing.T) {
+		assert.False(t, ValidatePKCEParams(verifier, "plain", verifier))
+	})
+	t.Run("verifier too short", func(t *testing.T) {
+		assert.False(t, ValidatePKCEParams(challenge, PKCEMethodS256, "short"))
+	})
+	t.Run("empty challenge", func(t *testing.T) {
+		assert.False(t, ValidatePKCEParams("", PKCEMethodS256, verifier))
+	})
+}
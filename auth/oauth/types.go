@@ -28,12 +28,13 @@ import (
 
 // TokenResponse is the OAuth access token response
 type TokenResponse struct {
-	AccessToken string  `json:"access_token"`
-	ExpiresIn   *int    `json:"expires_in,omitempty"`
-	TokenType   string  `json:"token_type"`
-	CNonce      *string `json:"c_nonce,omitempty"`
-	Scope       *string `json:"scope,omitempty"`
-	Status      *string `json:"status,omitempty"`
+	AccessToken  string  `json:"access_token"`
+	ExpiresIn    *int    `json:"expires_in,omitempty"`
+	TokenType    string  `json:"token_type"`
+	CNonce       *string `json:"c_nonce,omitempty"`
+	RefreshToken *string `json:"refresh_token,omitempty"`
+	Scope        *string `json:"scope,omitempty"`
+	Status       *string `json:"status,omitempty"`
 }
 
 const (
@@ -60,6 +61,8 @@ const (
 	ClientIDParam = "client_id"
 	// CodeParam is the parameter name for the code parameter
 	CodeParam = "code"
+	// CodeResponseType is the response_type for the authorization code flow
+	CodeResponseType = "code"
 	// GrantTypeParam is the parameter name for the grant_type parameter
 	GrantTypeParam = "grant_type"
 	// NonceParam is the parameter name for the nonce parameter
@@ -107,6 +110,10 @@ type AuthorizationServerMetadata struct {
 	// Issuer defines the authorization server's identifier, which is a URL that uses the "https" scheme and has no query or fragment components.
 	Issuer string `json:"issuer"`
 
+	// JwksURI defines the URL of the authorization server's JWK Set [RFC7517] document,
+	// which contains the keys resource servers use to validate JWT access tokens [RFC9068].
+	JwksURI string `json:"jwks_uri,omitempty"`
+
 	/* ******** /authorize ******** */
 
 	// AuthorizationEndpoint defines the URL of the authorization server's authorization endpoint [RFC6749]
@@ -130,6 +137,11 @@ type AuthorizationServerMetadata struct {
 	// GrantTypesSupported is a list of the OAuth 2.0 grant type values that this authorization server supports.
 	GrantTypesSupported []string `json:"grant_types_supported,omitempty"`
 
+	/* ******** /revoke ******** */
+
+	// RevocationEndpoint defines the URL of the authorization server's token revocation endpoint [RFC7009].
+	RevocationEndpoint string `json:"revocation_endpoint,omitempty"`
+
 	//// TODO: what do we support?
 	//// TokenEndpointAuthMethodsSupported is a JSON array containing a list of client authentication methods supported by this token endpoint.
 	//// Client authentication method values are used in the "token_endpoint_auth_method" parameter defined in Section 2 of [RFC7591].
@@ -143,6 +155,12 @@ type AuthorizationServerMetadata struct {
 	//// No default algorithms are implied if this entry is omitted. Servers SHOULD support "RS256". The value "none" MUST NOT be used.
 	//TokenEndpointAuthSigningAlgValuesSupported []string `json:"token_endpoint_auth_signing_alg_values_supported,omitempty"`
 
+	/* ******** DPoP ******** */
+
+	// DPoPSigningAlgValuesSupported is a list of the JWS algorithms supported for DPoP proofs [RFC9449].
+	// If omitted, the authorization server does not support DPoP.
+	DPoPSigningAlgValuesSupported []string `json:"dpop_signing_alg_values_supported,omitempty"`
+
 	/* ******** openid4vc ******** */
 
 	// PreAuthorizedGrantAnonymousAccessSupported indicates whether anonymous access (requests without client_id) for pre-authorized code grant flows.
@@ -30,6 +30,9 @@ import (
 	"os"
 	"runtime/pprof"
 
+	"github.com/nuts-foundation/nuts-node/audit"
+	auditAPI "github.com/nuts-foundation/nuts-node/audit/api/v1"
+	auditCmd "github.com/nuts-foundation/nuts-node/audit/cmd"
 	"github.com/nuts-foundation/nuts-node/auth"
 	authAPIv1 "github.com/nuts-foundation/nuts-node/auth/api/auth/v1"
 	authIAMAPI "github.com/nuts-foundation/nuts-node/auth/api/iam"
@@ -190,6 +193,7 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	httpServerInstance := httpEngine.New(shutdownCallback, cryptoInstance)
 	jsonld := jsonld.NewJSONLDInstance()
 	storageInstance := storage.New()
+	auditInstance := audit.New(storageInstance)
 	didStore := didstore.New(storageInstance.GetProvider(vdr.ModuleName))
 	eventManager := events.NewManager()
 	networkInstance := network.NewNetworkInstance(network.DefaultConfig(), didStore, cryptoInstance, eventManager, storageInstance.GetProvider(network.ModuleName), pkiInstance)
@@ -221,10 +225,11 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	system.RegisterRoutes(statusEngine.(core.Routable))
 	system.RegisterRoutes(metricsEngine.(core.Routable))
 	system.RegisterRoutes(&authAPIv1.Wrapper{Auth: authInstance, CredentialResolver: credentialInstance})
-	system.RegisterRoutes(authIAMAPI.New(authInstance, credentialInstance, vdrInstance, storageInstance, policyInstance))
+	system.RegisterRoutes(authIAMAPI.New(authInstance, credentialInstance, vdrInstance, storageInstance, policyInstance, cryptoInstance))
 	system.RegisterRoutes(&authMeansAPI.Wrapper{Auth: authInstance})
 	system.RegisterRoutes(&didmanAPI.Wrapper{Didman: didmanInstance})
-	system.RegisterRoutes(&discoveryAPI.Wrapper{Server: discoveryInstance, Client: discoveryInstance})
+	system.RegisterRoutes(&discoveryAPI.Wrapper{Server: discoveryInstance, Client: discoveryInstance, Definitions: discoveryInstance, Moderator: discoveryInstance})
+	system.RegisterRoutes(&auditAPI.Wrapper{Trail: auditInstance})
 
 	// Register engines
 	// without dependencies
@@ -232,6 +237,8 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	system.RegisterEngine(cryptoInstance)
 	system.RegisterEngine(jsonld)
 	system.RegisterEngine(storageInstance)
+	// audit depends on storage for the SQL sink
+	system.RegisterEngine(auditInstance)
 	system.RegisterEngine(statusEngine)
 	system.RegisterEngine(metricsEngine)
 	system.RegisterEngine(eventManager)
@@ -269,6 +276,7 @@ func addSubCommands(system *core.System, root *cobra.Command) {
 		vcrCmd.Cmd(),
 		vdrCmd.Cmd(),
 		didmanCmd.Cmd(),
+		discoveryCmd.Cmd(),
 	}
 	for _, cmd := range clientCommands {
 		registerClientErrorHandler(cmd)
@@ -285,6 +293,7 @@ func addSubCommands(system *core.System, root *cobra.Command) {
 		createPrintConfigCommand(system),
 		cryptoCmd.ServerCmd(),
 		httpCmd.ServerCmd(),
+		auditCmd.ServerCmd(),
 	}
 	flagSet := serverConfigFlags()
 	registerFlags(serverCommands, flagSet)
@@ -345,6 +354,7 @@ func serverConfigFlags() *pflag.FlagSet {
 	set.AddFlagSet(goldenHammerCmd.FlagSet())
 	set.AddFlagSet(discoveryCmd.FlagSet())
 	set.AddFlagSet(policy.FlagSet())
+	set.AddFlagSet(auditCmd.FlagSet())
 
 	return set
 }
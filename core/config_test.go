@@ -143,6 +143,16 @@ func TestServerConfig_Load(t *testing.T) {
 
 		assert.EqualError(t, err, "flag database.password is a secret, please set it in the config file or environment variable to avoid leaking it")
 	})
+	t.Run("error - secret (pin) set on commandline", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("crypto.pkcs11.pin", "", "")
+		cmd.Flags().Parse([]string{"command", "--crypto.pkcs11.pin=1234"})
+		config := NewServerConfig()
+
+		err := config.Load(cmd.Flags())
+
+		assert.EqualError(t, err, "flag crypto.pkcs11.pin is a secret, please set it in the config file or environment variable to avoid leaking it")
+	})
 }
 
 func Test_loadFromFile(t *testing.T) {
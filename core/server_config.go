@@ -46,6 +46,7 @@ const configValueListSeparator = ","
 
 // redactedConfigKeys contains the configuration keys that are masked when logged, to avoid leaking secrets.
 var redactedConfigKeys = []string{
+	"crypto.pkcs11.pin",
 	"crypto.vault.token",
 	"storage.redis.password",
 	"storage.redis.sentinel.password",
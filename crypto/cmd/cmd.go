@@ -26,6 +26,7 @@ import (
 	cryptoEngine "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/external"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/pkcs11"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/vault"
 	"github.com/spf13/cobra"
@@ -39,13 +40,17 @@ func FlagSet() *pflag.FlagSet {
 	defs := cryptoEngine.DefaultCryptoConfig()
 	flags.String("crypto.storage", defs.Storage, fmt.Sprintf("Storage to use, '%s' for an external backend (experimental), "+
 		"'%s' for file system (for development purposes), "+
-		"'%s' for Vault KV store (recommended, will be replaced by external backend in future).", external.StorageType, fs.StorageType, vault.StorageType))
+		"'%s' for a hardware security module accessed through PKCS#11, "+
+		"'%s' for Vault KV store (recommended, will be replaced by external backend in future).", external.StorageType, fs.StorageType, pkcs11.StorageType, vault.StorageType))
 	flags.String("crypto.vault.token", defs.Vault.Token, "The Vault token. If set it overwrites the VAULT_TOKEN env var.")
 	flags.String("crypto.vault.address", defs.Vault.Address, "The Vault address. If set it overwrites the VAULT_ADDR env var.")
 	flags.Duration("crypto.vault.timeout", defs.Vault.Timeout, "Timeout of client calls to Vault, in Golang time.Duration string format (e.g. 1s).")
 	flags.String("crypto.vault.pathprefix", defs.Vault.PathPrefix, "The Vault path prefix.")
 	flags.String("crypto.external.address", defs.External.Address, "Address of the external storage service.")
 	flags.Duration("crypto.external.timeout", defs.External.Timeout, "Time-out when invoking the external storage backend, in Golang time.Duration string format (e.g. 1s).")
+	flags.String("crypto.pkcs11.library", defs.PKCS11.Library, "Path to the PKCS#11 module (shared library) of the HSM.")
+	flags.String("crypto.pkcs11.tokenlabel", defs.PKCS11.TokenLabel, "Label of the PKCS#11 token that holds the private keys.")
+	flags.String("crypto.pkcs11.pin", defs.PKCS11.PIN, "User PIN used to log in to the PKCS#11 token.")
 
 	return flags
 }
@@ -58,6 +63,7 @@ func ServerCmd() *cobra.Command {
 	}
 	cmd.AddCommand(fs2VaultCommand())
 	cmd.AddCommand(fs2ExternalStore())
+	cmd.AddCommand(migrateCommand())
 	return cmd
 }
 
@@ -0,0 +1,239 @@
+/*
+ * Nuts node
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	cryptoEngine "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/spf13/cobra"
+)
+
+// migrationTestPayload is signed with both the source and target key to verify a migrated key.
+var migrationTestPayload = []byte("nuts-node key migration test payload")
+
+func migrateCommand() *cobra.Command {
+	var options migrateOptions
+	var from, to string
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrates private keys from one crypto storage backend to another.",
+		Long: "Migrates all private keys from one crypto storage backend (--from) to another (--to), e.g. from 'vaultkv' to 'external' or 'pkcs11'. " +
+			"Both backends are configured using the node's config. Every copied key is verified by signing a test payload with both the source and target key. " +
+			"Keys that were already migrated are verified and skipped, so the command can be rerun after a partial failure. " +
+			"Can only be run on the local Nuts node, from the directory where nuts.yaml resides.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if from == to {
+				return errors.New("source and target storage must differ")
+			}
+			source, err := loadStorage(cmd, from)
+			if err != nil {
+				return fmt.Errorf("unable to set up source storage (%s): %w", from, err)
+			}
+			defer closeStorage(source)
+			target, err := loadStorage(cmd, to)
+			if err != nil {
+				return fmt.Errorf("unable to set up target storage (%s): %w", to, err)
+			}
+			defer closeStorage(target)
+
+			if options.dryRun {
+				cmd.Printf("Migrating private keys from %s to %s (dry run)...\n", from, to)
+			} else {
+				cmd.Printf("Migrating private keys from %s to %s...\n", from, to)
+			}
+			result, err := migrateKeys(cmd.Context(), source, target, options)
+			for _, kid := range result.migrated {
+				cmd.Println("  migrated:", kid)
+			}
+			for _, kid := range result.skipped {
+				cmd.Println("  skipped (already migrated):", kid)
+			}
+			for _, kid := range result.deleted {
+				cmd.Println("  deleted from source:", kid)
+			}
+			cmd.Printf("Migrated %d keys, skipped %d keys, deleted %d keys from source.\n", len(result.migrated), len(result.skipped), len(result.deleted))
+			if err != nil {
+				cmd.Println("Failed to migrate all keys, rerun the command to resume:", err)
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "Storage backend to migrate the keys from (fs, vaultkv, external or pkcs11).")
+	cmd.Flags().StringVar(&to, "to", "", "Storage backend to migrate the keys to (fs, vaultkv, external or pkcs11).")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "List the keys that would be migrated, without migrating them.")
+	cmd.Flags().BoolVar(&options.deleteSource, "delete-source", false, "Delete the keys from the source storage after all keys have been migrated and verified.")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// loadStorage creates the crypto storage backend of the given type, using the server configuration.
+func loadStorage(cmd *cobra.Command, storageType string) (spi.Storage, error) {
+	cfg := core.NewServerConfig()
+	err := cfg.Load(cmd.Flags())
+	if err != nil {
+		return nil, err
+	}
+	instance := cryptoEngine.NewCryptoInstance()
+	err = cfg.InjectIntoEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+	return cryptoEngine.NewStorage(storageType, *instance.Config().(*cryptoEngine.Config), *cfg)
+}
+
+func closeStorage(storage spi.Storage) {
+	if closer, ok := storage.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+type migrateOptions struct {
+	// dryRun only lists the keys that would be migrated.
+	dryRun bool
+	// deleteSource deletes the keys from the source storage after all keys were migrated and verified.
+	deleteSource bool
+}
+
+type migrationResult struct {
+	// migrated contains the keys that were copied to the target storage (or would be, in case of a dry run).
+	migrated []string
+	// skipped contains the keys that were already present in the target storage.
+	skipped []string
+	// deleted contains the keys that were deleted from the source storage.
+	deleted []string
+}
+
+// migrateKeys copies all private keys from the source storage to the target storage.
+// Keys that already exist in the target storage are verified and skipped, which allows resuming a migration that failed partially.
+// A failure to migrate a key doesn't stop the migration of other keys; all errors are returned.
+// Keys are only deleted from the source if all keys were migrated successfully and deletion was requested.
+func migrateKeys(ctx context.Context, source, target spi.Storage, options migrateOptions) (migrationResult, error) {
+	var result migrationResult
+	var errs []error
+	for _, kid := range source.ListPrivateKeys(ctx) {
+		if target.PrivateKeyExists(ctx, kid) {
+			if err := verifyMigratedKey(ctx, kid, source, target); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			result.skipped = append(result.skipped, kid)
+			continue
+		}
+		if options.dryRun {
+			result.migrated = append(result.migrated, kid)
+			continue
+		}
+		if err := migrateKey(ctx, kid, source, target); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.migrated = append(result.migrated, kid)
+	}
+	if len(errs) > 0 || options.dryRun || !options.deleteSource {
+		return result, errors.Join(errs...)
+	}
+	for _, kid := range append(result.migrated, result.skipped...) {
+		if err := source.DeletePrivateKey(ctx, kid); err != nil {
+			return result, fmt.Errorf("unable to delete private key from source storage (kid=%s): %w", kid, err)
+		}
+		result.deleted = append(result.deleted, kid)
+	}
+	return result, nil
+}
+
+// migrateKey copies a single private key from the source to the target storage and verifies the copy.
+func migrateKey(ctx context.Context, kid string, source, target spi.Storage) error {
+	privateKey, err := source.GetPrivateKey(ctx, kid)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve private key (kid=%s): %w", kid, err)
+	}
+	if err = target.SavePrivateKey(ctx, kid, privateKey); err != nil {
+		return fmt.Errorf("unable to store private key in target storage (kid=%s): %w", kid, err)
+	}
+	return verifyMigratedKey(ctx, kid, source, target)
+}
+
+// verifyMigratedKey checks that the key in the target storage is the same as in the source storage,
+// by signing a test payload with both keys and verifying both signatures.
+func verifyMigratedKey(ctx context.Context, kid string, source, target spi.Storage) error {
+	sourceKey, err := source.GetPrivateKey(ctx, kid)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve private key (kid=%s): %w", kid, err)
+	}
+	targetKey, err := target.GetPrivateKey(ctx, kid)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve private key from target storage (kid=%s): %w", kid, err)
+	}
+	publicKey := sourceKey.Public()
+	for _, signer := range []crypto.Signer{sourceKey, targetKey} {
+		if err = signAndVerify(signer, publicKey); err != nil {
+			return fmt.Errorf("verification of migrated private key failed (kid=%s): %w", kid, err)
+		}
+	}
+	return nil
+}
+
+// signAndVerify signs the test payload with the given signer and verifies the signature using the given public key.
+func signAndVerify(signer crypto.Signer, publicKey crypto.PublicKey) error {
+	digest := sha256.Sum256(migrationTestPayload)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			return err
+		}
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			return err
+		}
+		if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		signature, err := signer.Sign(rand.Reader, migrationTestPayload, crypto.Hash(0))
+		if err != nil {
+			return err
+		}
+		if !ed25519.Verify(key, migrationTestPayload, signature) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported key type: %T", publicKey)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+/*
+ * Nuts node
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/nuts-foundation/nuts-node/crypto/test"
+	testIo "github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_migrateCommand(t *testing.T) {
+	newCommand := func(t *testing.T, args ...string) (*bytes.Buffer, error) {
+		outBuf := new(bytes.Buffer)
+		cryptoCmd := ServerCmd()
+		for _, cmd := range cryptoCmd.Commands() {
+			cmd.Flags().AddFlagSet(core.FlagSet())
+			cmd.Flags().AddFlagSet(FlagSet())
+		}
+		cryptoCmd.SetOut(outBuf)
+		cryptoCmd.SetErr(outBuf)
+		cryptoCmd.SetArgs(append([]string{"migrate"}, args...))
+		return outBuf, cryptoCmd.Execute()
+	}
+	setup := func(t *testing.T) *vaultStub {
+		stub := newVaultStub()
+		s := httptest.NewServer(stub)
+		t.Cleanup(s.Close)
+		dataDir := testIo.TestDirectory(t)
+		setupFSStoreData(t, path.Join(dataDir, "crypto"))
+		t.Setenv("NUTS_DATADIR", dataDir)
+		t.Setenv("NUTS_CRYPTO_VAULT_ADDRESS", s.URL)
+		return stub
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		stub := setup(t)
+
+		output, err := newCommand(t, "--from", "fs", "--to", "vaultkv")
+
+		require.NoError(t, err)
+		assert.Len(t, stub.values, 3)
+		assert.Contains(t, output.String(), "Migrating private keys from fs to vaultkv...")
+		assert.Contains(t, output.String(), "migrated: pk1")
+		assert.Contains(t, output.String(), "Migrated 3 keys, skipped 0 keys, deleted 0 keys from source.")
+	})
+	t.Run("resume", func(t *testing.T) {
+		stub := setup(t)
+		_, err := newCommand(t, "--from", "fs", "--to", "vaultkv")
+		require.NoError(t, err)
+
+		output, err := newCommand(t, "--from", "fs", "--to", "vaultkv", "--delete-source")
+
+		require.NoError(t, err)
+		assert.Len(t, stub.values, 3)
+		assert.Contains(t, output.String(), "skipped (already migrated): pk1")
+		assert.Contains(t, output.String(), "Migrated 0 keys, skipped 3 keys, deleted 3 keys from source.")
+	})
+	t.Run("dry run", func(t *testing.T) {
+		stub := setup(t)
+
+		output, err := newCommand(t, "--from", "fs", "--to", "vaultkv", "--dry-run")
+
+		require.NoError(t, err)
+		assert.Empty(t, stub.values)
+		assert.Contains(t, output.String(), "Migrating private keys from fs to vaultkv (dry run)...")
+		assert.Contains(t, output.String(), "Migrated 3 keys, skipped 0 keys, deleted 0 keys from source.")
+	})
+	t.Run("error - source and target are the same", func(t *testing.T) {
+		_ = setup(t)
+
+		_, err := newCommand(t, "--from", "fs", "--to", "fs")
+
+		assert.EqualError(t, err, "source and target storage must differ")
+	})
+	t.Run("error - unknown storage", func(t *testing.T) {
+		_ = setup(t)
+
+		_, err := newCommand(t, "--from", "fs", "--to", "unknown")
+
+		assert.ErrorContains(t, err, "unable to set up target storage (unknown): invalid config for crypto.storage")
+	})
+	t.Run("error - missing flags", func(t *testing.T) {
+		_ = setup(t)
+
+		_, err := newCommand(t, "--from", "fs")
+
+		assert.ErrorContains(t, err, `required flag(s) "to" not set`)
+	})
+}
+
+func Test_migrateKeys(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (spi.Storage, spi.Storage) {
+		sourceDir := testIo.TestDirectory(t)
+		setupFSStoreData(t, sourceDir)
+		source, _ := fs.NewFileSystemBackend(sourceDir)
+		target, _ := fs.NewFileSystemBackend(testIo.TestDirectory(t))
+		return source, target
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		source, target := setup(t)
+
+		result, err := migrateKeys(ctx, source, target, migrateOptions{})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pk1", "pk2", "pk3"}, result.migrated)
+		assert.ElementsMatch(t, []string{"pk1", "pk2", "pk3"}, target.ListPrivateKeys(ctx))
+		assert.Len(t, source.ListPrivateKeys(ctx), 3)
+	})
+	t.Run("already migrated keys are skipped", func(t *testing.T) {
+		source, target := setup(t)
+		key, _ := source.GetPrivateKey(ctx, "pk1")
+		require.NoError(t, target.SavePrivateKey(ctx, "pk1", key))
+
+		result, err := migrateKeys(ctx, source, target, migrateOptions{})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pk2", "pk3"}, result.migrated)
+		assert.Equal(t, []string{"pk1"}, result.skipped)
+	})
+	t.Run("dry run", func(t *testing.T) {
+		source, target := setup(t)
+
+		result, err := migrateKeys(ctx, source, target, migrateOptions{dryRun: true, deleteSource: true})
+
+		require.NoError(t, err)
+		assert.Len(t, result.migrated, 3)
+		assert.Empty(t, result.deleted)
+		assert.Empty(t, target.ListPrivateKeys(ctx))
+		assert.Len(t, source.ListPrivateKeys(ctx), 3)
+	})
+	t.Run("delete source", func(t *testing.T) {
+		source, target := setup(t)
+
+		result, err := migrateKeys(ctx, source, target, migrateOptions{deleteSource: true})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pk1", "pk2", "pk3"}, result.deleted)
+		assert.Empty(t, source.ListPrivateKeys(ctx))
+		assert.Len(t, target.ListPrivateKeys(ctx), 3)
+	})
+	t.Run("different key in target", func(t *testing.T) {
+		source, target := setup(t)
+		require.NoError(t, target.SavePrivateKey(ctx, "pk1", test.GenerateECKey()))
+
+		result, err := migrateKeys(ctx, source, target, migrateOptions{deleteSource: true})
+
+		assert.EqualError(t, err, "verification of migrated private key failed (kid=pk1): invalid signature")
+		assert.ElementsMatch(t, []string{"pk2", "pk3"}, result.migrated)
+		assert.Empty(t, result.deleted, "keys should not be deleted if migration failed")
+		assert.Len(t, source.ListPrivateKeys(ctx), 3)
+	})
+	t.Run("storing key fails", func(t *testing.T) {
+		source, _ := setup(t)
+		ctrl := gomock.NewController(t)
+		target := spi.NewMockStorage(ctrl)
+		target.EXPECT().PrivateKeyExists(ctx, gomock.Any()).Return(false).Times(3)
+		target.EXPECT().SavePrivateKey(ctx, gomock.Any(), gomock.Any()).Return(errors.New("failed")).Times(3)
+
+		result, err := migrateKeys(ctx, source, target, migrateOptions{})
+
+		assert.ErrorContains(t, err, "unable to store private key in target storage (kid=pk1): failed")
+		assert.ErrorContains(t, err, "unable to store private key in target storage (kid=pk3): failed")
+		assert.Empty(t, result.migrated)
+	})
+}
+
+func Test_signAndVerify(t *testing.T) {
+	t.Run("ecdsa", func(t *testing.T) {
+		key := test.GenerateECKey()
+
+		assert.NoError(t, signAndVerify(key, key.Public()))
+	})
+	t.Run("rsa", func(t *testing.T) {
+		key := test.GenerateRSAKey()
+
+		assert.NoError(t, signAndVerify(key, key.Public()))
+	})
+	t.Run("ed25519", func(t *testing.T) {
+		_, key, _ := ed25519.GenerateKey(rand.Reader)
+
+		assert.NoError(t, signAndVerify(key, key.Public()))
+	})
+	t.Run("different key", func(t *testing.T) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+		err := signAndVerify(key, test.GenerateECKey().Public())
+
+		assert.EqualError(t, err, "invalid signature")
+	})
+	t.Run("unsupported key type", func(t *testing.T) {
+		err := signAndVerify(test.GenerateECKey(), "foo")
+
+		assert.EqualError(t, err, "unsupported key type: string")
+	})
+}
+
+// vaultStub is an in-memory stub of the Vault KV store.
+type vaultStub struct {
+	mux    sync.Mutex
+	values map[string]map[string]interface{}
+}
+
+func newVaultStub() *vaultStub {
+	return &vaultStub{values: map[string]map[string]interface{}{}}
+}
+
+func (v *vaultStub) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	key := strings.TrimPrefix(request.URL.Path, "/v1/")
+	var data interface{}
+	switch {
+	case key == "auth/token/lookup-self":
+		data = map[string]interface{}{"id": "token"}
+	case request.Method == http.MethodGet && request.URL.Query().Get("list") == "true":
+		var keys []string
+		for k := range v.values {
+			keys = append(keys, path.Base(k))
+		}
+		data = map[string]interface{}{"keys": keys}
+	case request.Method == http.MethodGet:
+		value, ok := v.values[key]
+		if !ok {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data = value
+	case request.Method == http.MethodPut || request.Method == http.MethodPost:
+		value := map[string]interface{}{}
+		_ = json.NewDecoder(request.Body).Decode(&value)
+		v.values[key] = value
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	case request.Method == http.MethodDelete:
+		delete(v.values, key)
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	_ = json.NewEncoder(writer).Encode(map[string]interface{}{"data": data})
+}
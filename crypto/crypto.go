@@ -22,10 +22,13 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"errors"
 	"fmt"
+	"io"
 	"path"
 	"time"
 
@@ -34,6 +37,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto/log"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/external"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/pkcs11"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/vault"
 )
@@ -48,6 +52,7 @@ type Config struct {
 	Storage  string          `koanf:"storage"`
 	Vault    vault.Config    `koanf:"vault"`
 	External external.Config `koanf:"external"`
+	PKCS11   pkcs11.Config   `koanf:"pkcs11"`
 }
 
 // DefaultCryptoConfig returns a Config with a fs backend storage
@@ -73,6 +78,18 @@ func (client *Crypto) CheckHealth() map[string]core.Health {
 	return client.storage.CheckHealth()
 }
 
+func (client *Crypto) Start() error {
+	return nil
+}
+
+// Shutdown closes the storage backend, if it needs to be closed.
+func (client *Crypto) Shutdown() error {
+	if closer, ok := client.storage.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // NewCryptoInstance creates a new instance of the crypto engine.
 func NewCryptoInstance() *Crypto {
 	return &Crypto{
@@ -124,6 +141,30 @@ func (client *Crypto) setupVaultBackend(_ core.ServerConfig) error {
 	return nil
 }
 
+func (client *Crypto) setupPKCS11Backend() error {
+	log.Logger().Debug("Setting up PKCS#11 backend for storage of private key material.")
+	pkcs11Backend, err := pkcs11.NewPKCS11Storage(client.config.PKCS11)
+	if err != nil {
+		return fmt.Errorf("unable to set up PKCS#11 backend: %w", err)
+	}
+	client.storage = spi.NewValidatedKIDBackendWrapper(pkcs11Backend, spi.KidPattern)
+	return nil
+}
+
+// NewStorage creates the storage backend of the given type, using the given crypto and server configuration.
+// It allows other storage backends than the configured one to be used, e.g. when migrating keys.
+func NewStorage(storageType string, config Config, serverConfig core.ServerConfig) (spi.Storage, error) {
+	if storageType == "" {
+		return nil, errors.New("storage type must be specified")
+	}
+	instance := &Crypto{config: config}
+	instance.config.Storage = storageType
+	if err := instance.Configure(serverConfig); err != nil {
+		return nil, err
+	}
+	return instance.storage, nil
+}
+
 // List returns the KIDs of the private keys that are present in the key store.
 func (client *Crypto) List(ctx context.Context) []string {
 	return client.storage.ListPrivateKeys(ctx)
@@ -138,6 +179,8 @@ func (client *Crypto) Configure(config core.ServerConfig) error {
 		return client.setupVaultBackend(config)
 	case external.StorageType:
 		return client.setupStorageAPIBackend()
+	case pkcs11.StorageType:
+		return client.setupPKCS11Backend()
 	case "":
 		if config.Strictmode {
 			return errors.New("backend must be explicitly set in strict mode")
@@ -145,15 +188,34 @@ func (client *Crypto) Configure(config core.ServerConfig) error {
 		// default to file system and run this setup again
 		return client.setupFSBackend(config)
 	default:
-		return fmt.Errorf("invalid config for crypto.storage. Available options are: vaultkv, fs, %s(experimental)", external.StorageType)
+		return fmt.Errorf("invalid config for crypto.storage. Available options are: vaultkv, fs, %s, %s(experimental)", pkcs11.StorageType, external.StorageType)
 	}
 }
 
-// New generates a new key pair.
+// New generates a new key pair of the given type.
 // Stores the private key, returns the public basicKey.
 // It returns an error when a key with the resulting ID already exists.
-func (client *Crypto) New(ctx context.Context, namingFunc KIDNamingFunc) (Key, error) {
-	keyPair, kid, err := generateKeyPairAndKID(namingFunc)
+func (client *Crypto) New(ctx context.Context, keyType KeyType, namingFunc KIDNamingFunc) (Key, error) {
+	if generator, ok := client.storage.(spi.KeyGenerator); ok {
+		// the storage backend generates the key, so the private key never leaves it.
+		// Key types the backend can't generate are refused, rather than generated here and imported into the backend.
+		publicKey, kid, err := generator.NewPrivateKey(ctx, string(keyType), namingFunc)
+		if err != nil {
+			if errors.Is(err, spi.ErrKeyAlreadyExists) {
+				return nil, errors.New("key with the given ID already exists")
+			}
+			if errors.Is(err, spi.ErrUnsupportedKeyType) {
+				return nil, fmt.Errorf("%w for %s storage: %s", ErrUnsupportedKeyType, client.storage.Name(), keyType)
+			}
+			return nil, fmt.Errorf("could not create new keypair: %w", err)
+		}
+		audit.Log(ctx, log.Logger(), audit.CryptoNewKeyEvent).Infof("Generated new key pair: %s", kid)
+		return basicKey{
+			publicKey: publicKey,
+			kid:       kid,
+		}, nil
+	}
+	keyPair, kid, err := generateKeyPairAndKID(keyType, namingFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -177,8 +239,8 @@ func (client *Crypto) Delete(ctx context.Context, kid string) error {
 	return client.storage.DeletePrivateKey(ctx, kid)
 }
 
-func generateKeyPairAndKID(namingFunc KIDNamingFunc) (*ecdsa.PrivateKey, string, error) {
-	keyPair, err := generateECKeyPair()
+func generateKeyPairAndKID(keyType KeyType, namingFunc KIDNamingFunc) (crypto.Signer, string, error) {
+	keyPair, err := generateKeyPair(keyType)
 	if err != nil {
 		return nil, "", err
 	}
@@ -193,6 +255,42 @@ func generateKeyPairAndKID(namingFunc KIDNamingFunc) (*ecdsa.PrivateKey, string,
 	return keyPair, kid, nil
 }
 
+// generateKeyPair generates a new key pair of the given type.
+func generateKeyPair(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case ECP256Key:
+		return generateECKeyPair()
+	case ECP384Key:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case Ed25519Key:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		return privateKey, err
+	case RSAKey:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, keyType)
+	}
+}
+
+// KeyTypeOf returns the key type of the given public key.
+// It returns ErrUnsupportedKeyType if the key isn't of a supported key type.
+func KeyTypeOf(publicKey crypto.PublicKey) (KeyType, error) {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return ECP256Key, nil
+		case elliptic.P384():
+			return ECP384Key, nil
+		}
+	case ed25519.PublicKey:
+		return Ed25519Key, nil
+	case *rsa.PublicKey:
+		return RSAKey, nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedKeyType, publicKey)
+}
+
 func generateECKeyPair() (*ecdsa.PrivateKey, error) {
 	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 }
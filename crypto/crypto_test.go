@@ -21,10 +21,16 @@ package crypto
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"errors"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/nuts-foundation/nuts-node/crypto/test"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -45,7 +51,7 @@ func TestCrypto_Exists(t *testing.T) {
 	client := createCrypto(t)
 
 	kid := "kid"
-	client.New(audit.TestContext(), StringNamingFunc(kid))
+	client.New(audit.TestContext(), ECP256Key, StringNamingFunc(kid))
 
 	t.Run("returns true for existing key", func(t *testing.T) {
 		assert.True(t, client.Exists(ctx, kid))
@@ -69,7 +75,7 @@ func TestCrypto_New(t *testing.T) {
 		kid := "kid"
 		auditLogs := audit.CaptureLogs(t)
 
-		key, err := client.New(ctx, StringNamingFunc(kid))
+		key, err := client.New(ctx, ECP256Key, StringNamingFunc(kid))
 
 		assert.NoError(t, err)
 		assert.NotNil(t, key.Public())
@@ -77,10 +83,42 @@ func TestCrypto_New(t *testing.T) {
 		auditLogs.AssertContains(t, ModuleName, "CreateNewKey", audit.TestActor, "Generating new key pair: kid")
 	})
 
+	t.Run("key types", func(t *testing.T) {
+		testCases := []struct {
+			keyType  KeyType
+			expected interface{}
+		}{
+			{ECP256Key, &ecdsa.PublicKey{}},
+			{ECP384Key, &ecdsa.PublicKey{}},
+			{Ed25519Key, ed25519.PublicKey{}},
+			{RSAKey, &rsa.PublicKey{}},
+		}
+		for _, tc := range testCases {
+			t.Run(string(tc.keyType), func(t *testing.T) {
+				key, err := client.New(ctx, tc.keyType, StringNamingFunc("kid-"+string(tc.keyType)))
+
+				require.NoError(t, err)
+				assert.IsType(t, tc.expected, key.Public())
+			})
+		}
+		t.Run("P-384 uses the right curve", func(t *testing.T) {
+			key, err := client.New(ctx, ECP384Key, StringNamingFunc("kid-p384-curve"))
+
+			require.NoError(t, err)
+			assert.Equal(t, elliptic.P384(), key.Public().(*ecdsa.PublicKey).Curve)
+		})
+		t.Run("error - unsupported key type", func(t *testing.T) {
+			key, err := client.New(ctx, "X25519", StringNamingFunc("kid-unsupported"))
+
+			assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+			assert.Nil(t, key)
+		})
+	})
+
 	t.Run("error - invalid KID", func(t *testing.T) {
 		kid := "../certificate"
 
-		key, err := client.New(ctx, StringNamingFunc(kid))
+		key, err := client.New(ctx, ECP256Key, StringNamingFunc(kid))
 
 		assert.ErrorContains(t, err, "invalid key ID")
 		assert.Nil(t, key)
@@ -90,7 +128,7 @@ func TestCrypto_New(t *testing.T) {
 		errorNamingFunc := func(key crypto.PublicKey) (string, error) {
 			return "", errors.New("b00m!")
 		}
-		_, err := client.New(ctx, errorNamingFunc)
+		_, err := client.New(ctx, ECP256Key, errorNamingFunc)
 		assert.Error(t, err)
 	})
 
@@ -101,7 +139,7 @@ func TestCrypto_New(t *testing.T) {
 		storageMock.EXPECT().SavePrivateKey(ctx, gomock.Any(), gomock.Any()).Return(errors.New("foo"))
 
 		client := &Crypto{storage: storageMock}
-		key, err := client.New(ctx, StringNamingFunc("123"))
+		key, err := client.New(ctx, ECP256Key, StringNamingFunc("123"))
 		assert.Nil(t, key)
 		assert.Error(t, err)
 		assert.Equal(t, "could not create new keypair: could not save private key: foo", err.Error())
@@ -113,10 +151,113 @@ func TestCrypto_New(t *testing.T) {
 		storageMock.EXPECT().PrivateKeyExists(ctx, "123").Return(true)
 
 		client := &Crypto{storage: storageMock}
-		key, err := client.New(ctx, StringNamingFunc("123"))
+		key, err := client.New(ctx, ECP256Key, StringNamingFunc("123"))
 		assert.Nil(t, key)
 		assert.EqualError(t, err, "key with the given ID already exists", err)
 	})
+
+	t.Run("key generated by storage backend", func(t *testing.T) {
+		publicKey := test.GenerateECKey().Public()
+		t.Run("ok", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storageMock := generatingStorage{MockStorage: spi.NewMockStorage(ctrl), MockKeyGenerator: spi.NewMockKeyGenerator(ctrl)}
+			storageMock.MockKeyGenerator.EXPECT().NewPrivateKey(ctx, "P-256", gomock.Any()).Return(publicKey, "123", nil)
+			auditLogs := audit.CaptureLogs(t)
+
+			client := &Crypto{storage: storageMock}
+			key, err := client.New(ctx, ECP256Key, StringNamingFunc("123"))
+
+			require.NoError(t, err)
+			assert.Equal(t, publicKey, key.Public())
+			assert.Equal(t, "123", key.KID())
+			auditLogs.AssertContains(t, ModuleName, "CreateNewKey", audit.TestActor, "Generated new key pair: 123")
+		})
+		t.Run("error - ID already in use", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storageMock := generatingStorage{MockStorage: spi.NewMockStorage(ctrl), MockKeyGenerator: spi.NewMockKeyGenerator(ctrl)}
+			storageMock.MockKeyGenerator.EXPECT().NewPrivateKey(ctx, "P-256", gomock.Any()).Return(nil, "", spi.ErrKeyAlreadyExists)
+
+			client := &Crypto{storage: storageMock}
+			key, err := client.New(ctx, ECP256Key, StringNamingFunc("123"))
+
+			assert.Nil(t, key)
+			assert.EqualError(t, err, "key with the given ID already exists")
+		})
+		t.Run("error - generation fails", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storageMock := generatingStorage{MockStorage: spi.NewMockStorage(ctrl), MockKeyGenerator: spi.NewMockKeyGenerator(ctrl)}
+			storageMock.MockKeyGenerator.EXPECT().NewPrivateKey(ctx, "P-256", gomock.Any()).Return(nil, "", errors.New("foo"))
+
+			client := &Crypto{storage: storageMock}
+			key, err := client.New(ctx, ECP256Key, StringNamingFunc("123"))
+
+			assert.Nil(t, key)
+			assert.EqualError(t, err, "could not create new keypair: foo")
+		})
+		t.Run("error - key type not supported by storage backend", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storageMock := generatingStorage{MockStorage: spi.NewMockStorage(ctrl), MockKeyGenerator: spi.NewMockKeyGenerator(ctrl)}
+			storageMock.MockKeyGenerator.EXPECT().NewPrivateKey(ctx, "Ed25519", gomock.Any()).Return(nil, "", spi.ErrUnsupportedKeyType)
+			storageMock.MockStorage.EXPECT().Name().Return("pkcs11")
+
+			client := &Crypto{storage: storageMock}
+			key, err := client.New(ctx, Ed25519Key, StringNamingFunc("123"))
+
+			assert.Nil(t, key)
+			assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+			assert.EqualError(t, err, "unsupported key type for pkcs11 storage: Ed25519")
+		})
+	})
+}
+
+func TestParseKeyType(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		for _, keyType := range SupportedKeyTypes() {
+			actual, err := ParseKeyType(string(keyType))
+
+			require.NoError(t, err)
+			assert.Equal(t, keyType, actual)
+		}
+	})
+	t.Run("empty string returns default", func(t *testing.T) {
+		actual, err := ParseKeyType("")
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultKeyType, actual)
+	})
+	t.Run("error - unsupported", func(t *testing.T) {
+		_, err := ParseKeyType("secp256k1")
+
+		assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+		assert.EqualError(t, err, "unsupported key type: secp256k1")
+	})
+}
+
+func TestKeyTypeOf(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		for _, keyType := range SupportedKeyTypes() {
+			keyPair, err := generateKeyPair(keyType)
+			require.NoError(t, err)
+
+			actual, err := KeyTypeOf(keyPair.Public())
+
+			require.NoError(t, err)
+			assert.Equal(t, keyType, actual)
+		}
+	})
+	t.Run("error - unsupported curve", func(t *testing.T) {
+		keyPair, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+
+		_, err := KeyTypeOf(keyPair.Public())
+
+		assert.EqualError(t, err, "unsupported key type: *ecdsa.PublicKey")
+	})
+}
+
+// generatingStorage is a storage backend that generates keys itself.
+type generatingStorage struct {
+	*spi.MockStorage
+	*spi.MockKeyGenerator
 }
 
 func TestCrypto_Delete(t *testing.T) {
@@ -142,7 +283,7 @@ func TestCrypto_Resolve(t *testing.T) {
 	ctx := context.Background()
 	client := createCrypto(t)
 	kid := "kid"
-	key, _ := client.New(audit.TestContext(), StringNamingFunc(kid))
+	key, _ := client.New(audit.TestContext(), ECP256Key, StringNamingFunc(kid))
 
 	t.Run("ok", func(t *testing.T) {
 		resolvedKey, err := client.Resolve(ctx, "kid")
@@ -214,12 +355,71 @@ func TestCrypto_Configure(t *testing.T) {
 		err := client.Configure(cfg)
 		assert.EqualError(t, err, "backend must be explicitly set in strict mode", "expected error")
 	})
+	t.Run("error - pkcs11 backend without library", func(t *testing.T) {
+		client := createCrypto(t)
+		client.config.Storage = "pkcs11"
+		err := client.Configure(cfg)
+		assert.EqualError(t, err, "unable to set up PKCS#11 backend: PKCS#11 library not configured")
+	})
 	t.Run("error - unknown backend", func(t *testing.T) {
 		client := createCrypto(t)
 		client.config.Storage = "unknown"
 		err := client.Configure(cfg)
-		assert.EqualError(t, err, "invalid config for crypto.storage. Available options are: vaultkv, fs, external(experimental)", "expected error")
+		assert.EqualError(t, err, "invalid config for crypto.storage. Available options are: vaultkv, fs, pkcs11, external(experimental)", "expected error")
+	})
+}
+
+func TestNewStorage(t *testing.T) {
+	cfg := core.TestServerConfig(func(config *core.ServerConfig) {
+		config.Datadir = io.TestDirectory(t)
+	})
+	t.Run("ok", func(t *testing.T) {
+		storage, err := NewStorage(fs.StorageType, DefaultCryptoConfig(), cfg)
+
+		require.NoError(t, err)
+		assert.Equal(t, "spi.wrapper", reflect.TypeOf(storage).String())
 	})
+	t.Run("error - no storage type", func(t *testing.T) {
+		storage, err := NewStorage("", DefaultCryptoConfig(), cfg)
+
+		assert.EqualError(t, err, "storage type must be specified")
+		assert.Nil(t, storage)
+	})
+	t.Run("error - unknown storage type", func(t *testing.T) {
+		storage, err := NewStorage("unknown", DefaultCryptoConfig(), cfg)
+
+		assert.ErrorContains(t, err, "invalid config for crypto.storage")
+		assert.Nil(t, storage)
+	})
+}
+
+func TestCrypto_Shutdown(t *testing.T) {
+	t.Run("storage is closed", func(t *testing.T) {
+		storage := &closingStorage{}
+		client := &Crypto{storage: storage}
+
+		err := client.Shutdown()
+
+		assert.NoError(t, err)
+		assert.True(t, storage.closed)
+	})
+	t.Run("storage can't be closed", func(t *testing.T) {
+		client := createCrypto(t)
+
+		err := client.Shutdown()
+
+		assert.NoError(t, err)
+	})
+}
+
+type closingStorage struct {
+	spi.Storage
+	closed bool
+}
+
+func (c *closingStorage) Close() error {
+	c.closed = true
+	return nil
 }
 
 func Test_CryptoGetters(t *testing.T) {
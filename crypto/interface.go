@@ -22,6 +22,7 @@ import (
 	"context"
 	"crypto"
 	"errors"
+	"fmt"
 )
 
 // ErrPrivateKeyNotFound is returned when the private key doesn't exist
@@ -30,11 +31,51 @@ var ErrPrivateKeyNotFound = errors.New("private key not found")
 // KIDNamingFunc is a function passed to New() which generates the kid for the pub/priv key
 type KIDNamingFunc func(key crypto.PublicKey) (string, error)
 
+// KeyType specifies the type of key pair to generate.
+type KeyType string
+
+const (
+	// ECP256Key is an ECDSA key pair on the NIST P-256 curve, used for ES256 signatures. It's the default key type.
+	ECP256Key KeyType = "P-256"
+	// ECP384Key is an ECDSA key pair on the NIST P-384 curve, used for ES384 signatures.
+	ECP384Key KeyType = "P-384"
+	// Ed25519Key is an Ed25519 key pair, used for EdDSA signatures.
+	Ed25519Key KeyType = "Ed25519"
+	// RSAKey is a 2048-bit RSA key pair, used for PS256 signatures.
+	RSAKey KeyType = "RSA"
+)
+
+// DefaultKeyType is the key type used when no key type is specified.
+const DefaultKeyType = ECP256Key
+
+// ErrUnsupportedKeyType is returned when a key pair of an unknown or unsupported key type is requested.
+var ErrUnsupportedKeyType = errors.New("unsupported key type")
+
+// SupportedKeyTypes returns the key types that can be generated.
+func SupportedKeyTypes() []KeyType {
+	return []KeyType{ECP256Key, ECP384Key, Ed25519Key, RSAKey}
+}
+
+// ParseKeyType parses the given key type. An empty string yields the DefaultKeyType.
+// It returns ErrUnsupportedKeyType if the key type is not supported.
+func ParseKeyType(keyType string) (KeyType, error) {
+	if keyType == "" {
+		return DefaultKeyType, nil
+	}
+	for _, supported := range SupportedKeyTypes() {
+		if KeyType(keyType) == supported {
+			return supported, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedKeyType, keyType)
+}
+
 // KeyCreator is the interface for creating key pairs.
 type KeyCreator interface {
-	// New generates a keypair and returns a Key. The context is used to pass audit information.
+	// New generates a keypair of the given type and returns a Key. The context is used to pass audit information.
 	// The KIDNamingFunc will provide the kid.
-	New(ctx context.Context, namingFunc KIDNamingFunc) (Key, error)
+	// It returns ErrUnsupportedKeyType if the key type is not supported.
+	New(ctx context.Context, keyType KeyType, namingFunc KIDNamingFunc) (Key, error)
 }
 
 // KeyResolver is the interface for resolving keys.
@@ -38,7 +38,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 )
 
-// ErrUnsupportedSigningKey is returned when an unsupported private key is used to sign. Currently only ecdsa and rsa keys are supported
+// ErrUnsupportedSigningKey is returned when an unsupported private key is used to sign. Currently ecdsa, rsa and ed25519 keys are supported
 var ErrUnsupportedSigningKey = errors.New("signing key algorithm not supported")
 
 var supportedAlgorithms = []jwa.SignatureAlgorithm{jwa.PS256, jwa.PS384, jwa.PS512, jwa.ES256, jwa.EdDSA, jwa.ES384, jwa.ES512}
@@ -65,16 +65,7 @@ func (client *Crypto) SignJWT(ctx context.Context, claims map[string]interface{}
 
 	audit.Log(ctx, log.Logger(), audit.CryptoSignJWTEvent).Infof("Signing a JWT with key: %s (issuer: %s, subject: %s)", kid, claims["iss"], claims["sub"])
 
-	keyAsJWK, err := jwkKey(privateKey)
-	if err != nil {
-		return "", err
-	}
-
-	if err = keyAsJWK.Set(jwk.KeyIDKey, kid); err != nil {
-		return "", err
-	}
-
-	return signJWT(keyAsJWK, claims, headers)
+	return signJWT(privateKey, kid, claims, headers)
 }
 
 // SignJWS creates a signed JWS using the indicated key and map of headers and payload as bytes.
@@ -125,27 +116,10 @@ func (client *Crypto) DecryptJWE(ctx context.Context, message string) (body []by
 	return body, headers, err
 }
 
-func jwkKey(signer crypto.Signer) (key jwk.Key, err error) {
-	key, err = jwk.FromRaw(signer)
-	if err != nil {
-		return nil, err
-	}
-
-	switch k := signer.(type) {
-	case *rsa.PrivateKey:
-		key.Set(jwk.AlgorithmKey, jwa.PS256)
-	case *ecdsa.PrivateKey:
-		var alg jwa.SignatureAlgorithm
-		alg, err = ecAlg(k)
-		key.Set(jwk.AlgorithmKey, alg)
-	default:
-		err = errors.New("unsupported signing private key")
-	}
-	return
-}
-
-// signJWT signs claims with the signer and returns the compacted token. The headers param can be used to add additional headers
-func signJWT(key jwk.Key, claims map[string]interface{}, headers map[string]interface{}) (token string, err error) {
+// signJWT signs claims with the signer and returns the compacted token. The headers param can be used to add additional headers.
+// The kid is added as header, unless the headers param already contains a kid.
+// The signature algorithm is derived from the signer's public key, so signers that don't expose their private key (e.g. keys in an HSM) can be used.
+func signJWT(signer crypto.Signer, kid string, claims map[string]interface{}, headers map[string]interface{}) (token string, err error) {
 	var sig []byte
 	t := jwt.New()
 
@@ -158,8 +132,17 @@ func signJWT(key jwk.Key, claims map[string]interface{}, headers map[string]inte
 	if err != nil {
 		return "", fmt.Errorf("invalid JWT headers: %w", err)
 	}
+	if _, ok := hdr.Get(jws.KeyIDKey); !ok && kid != "" {
+		if err = hdr.Set(jws.KeyIDKey, kid); err != nil {
+			return "", err
+		}
+	}
+	alg, err := SignatureAlgorithm(signer.Public())
+	if err != nil {
+		return "", err
+	}
 
-	sig, err = jwt.Sign(t, jwt.WithKey(jwa.SignatureAlgorithm(key.Algorithm().String()), key, jws.WithProtectedHeaders(hdr)))
+	sig, err = jwt.Sign(t, jwt.WithKey(alg, signer, jws.WithProtectedHeaders(hdr)))
 	token = string(sig)
 
 	return
@@ -260,7 +243,10 @@ func signJWS(payload []byte, protectedHeaders map[string]interface{}, privateKey
 			return "", fmt.Errorf("unable to set header %s: %w", key, err)
 		}
 	}
-	privateKeyAsJWK, err := jwkKey(privateKey)
+	if privateKey == nil {
+		return "", errors.New("no private key provided")
+	}
+	algo, err := SignatureAlgorithm(privateKey.Public())
 	if err != nil {
 		return "", err
 	}
@@ -275,7 +261,6 @@ func signJWS(payload []byte, protectedHeaders map[string]interface{}, privateKey
 			return "", errors.New("refusing to sign JWS with private key in JWK header")
 		}
 	}
-	algo := jwa.SignatureAlgorithm(privateKeyAsJWK.Algorithm().String())
 
 	var (
 		data []byte
@@ -367,11 +352,6 @@ func convertHeaders(headers map[string]interface{}) (jws.Headers, error) {
 	return hdr, nil
 }
 
-func ecAlg(key *ecdsa.PrivateKey) (alg jwa.SignatureAlgorithm, err error) {
-	alg, err = ecAlgUsingPublicKey(key.PublicKey)
-	return
-}
-
 func ecAlgUsingPublicKey(key ecdsa.PublicKey) (alg jwa.SignatureAlgorithm, err error) {
 	switch key.Params().BitSize {
 	case 256:
@@ -29,6 +29,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/mr-tron/base58"
 	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/pkcs11"
 	"github.com/nuts-foundation/nuts-node/crypto/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,8 +50,7 @@ func TestSignJWT(t *testing.T) {
 	claims := map[string]interface{}{"iss": "nuts"}
 	t.Run("creates valid JWT using rsa keys", func(t *testing.T) {
 		rsaKey := test.GenerateRSAKey()
-		key, _ := jwkKey(rsaKey)
-		tokenString, err := signJWT(key, claims, nil)
+		tokenString, err := signJWT(rsaKey, "", claims, nil)
 
 		assert.Nil(t, err)
 
@@ -72,8 +73,7 @@ func TestSignJWT(t *testing.T) {
 		for _, ecKey := range keys {
 			name := fmt.Sprintf("using %s", ecKey.Params().Name)
 			t.Run(name, func(t *testing.T) {
-				key, _ := jwkKey(ecKey)
-				tokenString, err := signJWT(key, claims, nil)
+				tokenString, err := signJWT(ecKey, "", claims, nil)
 
 				require.NoError(t, err)
 
@@ -89,8 +89,7 @@ func TestSignJWT(t *testing.T) {
 
 	t.Run("sets correct headers", func(t *testing.T) {
 		ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-		key, _ := jwkKey(ecKey)
-		tokenString, err := signJWT(key, claims, nil)
+		tokenString, err := signJWT(ecKey, "", claims, nil)
 
 		require.NoError(t, err)
 
@@ -102,7 +101,7 @@ func TestSignJWT(t *testing.T) {
 	})
 
 	t.Run("invalid claim", func(t *testing.T) {
-		tokenString, err := signJWT(nil, map[string]interface{}{jwt.IssuedAtKey: "foobar"}, nil)
+		tokenString, err := signJWT(nil, "", map[string]interface{}{jwt.IssuedAtKey: "foobar"}, nil)
 		assert.Empty(t, tokenString)
 		assert.EqualError(t, err, "invalid value for iat key: failed to accept string \"foobar\": value is not number of seconds since the epoch, and attempt to parse it as RFC3339 timestamp failed: parsing time \"foobar\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"foobar\" as \"2006\"")
 	})
@@ -153,7 +152,7 @@ func TestCrypto_SignJWT(t *testing.T) {
 	client := createCrypto(t)
 
 	kid := "kid"
-	key, _ := client.New(audit.TestContext(), StringNamingFunc(kid))
+	key, _ := client.New(audit.TestContext(), ECP256Key, StringNamingFunc(kid))
 
 	t.Run("creates valid JWT", func(t *testing.T) {
 		tokenString, err := client.SignJWT(audit.TestContext(), map[string]interface{}{"iss": "nuts", "sub": "subject"}, nil, key)
@@ -179,6 +178,57 @@ func TestCrypto_SignJWT(t *testing.T) {
 		require.NoError(t, err)
 		auditLogs.AssertContains(t, ModuleName, "SignJWT", audit.TestActor, "Signing a JWT with key: kid (issuer: nuts, subject: subject)")
 	})
+	t.Run("signs with a PKCS#11 key", func(t *testing.T) {
+		client := NewTestCryptoInstance(pkcs11.NewTestStorage())
+		key, err := client.New(audit.TestContext(), ECP256Key, StringNamingFunc("hsm-key"))
+		require.NoError(t, err)
+
+		tokenString, err := client.SignJWT(audit.TestContext(), map[string]interface{}{"iss": "nuts"}, nil, key)
+		require.NoError(t, err)
+
+		var actualKID string
+		token, err := ParseJWT(tokenString, func(kid string) (crypto.PublicKey, error) {
+			actualKID = kid
+			return key.Public(), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "nuts", token.Issuer())
+		assert.Equal(t, "hsm-key", actualKID)
+	})
+
+	t.Run("supports all key types", func(t *testing.T) {
+		for _, keyType := range SupportedKeyTypes() {
+			t.Run(string(keyType), func(t *testing.T) {
+				key, err := client.New(audit.TestContext(), keyType, StringNamingFunc("kid-"+string(keyType)))
+				require.NoError(t, err)
+
+				tokenString, err := client.SignJWT(audit.TestContext(), map[string]interface{}{"iss": "nuts"}, nil, key)
+				require.NoError(t, err)
+
+				token, err := ParseJWT(tokenString, func(_ string) (crypto.PublicKey, error) {
+					return key.Public(), nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, "nuts", token.Issuer())
+			})
+		}
+	})
+	t.Run("signs with a key that can't be exported", func(t *testing.T) {
+		privateKey := test.GenerateECKey()
+		opaqueKey := opaqueSigner{signer: privateKey}
+
+		tokenString, err := signJWT(opaqueKey, "kid", map[string]interface{}{"iss": "nuts"}, nil)
+		require.NoError(t, err)
+
+		var actualKID string
+		token, err := ParseJWT(tokenString, func(kid string) (crypto.PublicKey, error) {
+			actualKID = kid
+			return privateKey.Public(), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "nuts", token.Issuer())
+		assert.Equal(t, "kid", actualKID)
+	})
 
 	t.Run("returns error for not found", func(t *testing.T) {
 		_, err := client.SignJWT(audit.TestContext(), map[string]interface{}{"iss": "nuts"}, nil, basicKey{kid: "unknown"})
@@ -197,7 +247,26 @@ func TestCrypto_SignJWS(t *testing.T) {
 	client := createCrypto(t)
 
 	kid := "kid"
-	key, _ := client.New(audit.TestContext(), StringNamingFunc(kid))
+	key, _ := client.New(audit.TestContext(), ECP256Key, StringNamingFunc(kid))
+
+	t.Run("supports all key types", func(t *testing.T) {
+		for _, keyType := range SupportedKeyTypes() {
+			t.Run(string(keyType), func(t *testing.T) {
+				key, err := client.New(audit.TestContext(), keyType, StringNamingFunc("kid-"+string(keyType)))
+				require.NoError(t, err)
+				payload := []byte("hello world")
+
+				signature, err := client.SignJWS(audit.TestContext(), payload, map[string]interface{}{}, key, false)
+				require.NoError(t, err)
+
+				actual, err := ParseJWS([]byte(signature), func(_ string) (crypto.PublicKey, error) {
+					return key.Public(), nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, payload, actual)
+			})
+		}
+	})
 
 	t.Run("creates valid JWS", func(t *testing.T) {
 		payload, _ := json.Marshal(map[string]interface{}{"iss": "nuts"})
@@ -244,7 +313,7 @@ func TestCrypto_SignJWS(t *testing.T) {
 func TestCrypto_EncryptJWE(t *testing.T) {
 	client := createCrypto(t)
 
-	key, _ := client.New(audit.TestContext(), StringNamingFunc("did:nuts:1234#key-1"))
+	key, _ := client.New(audit.TestContext(), ECP256Key, StringNamingFunc("did:nuts:1234#key-1"))
 	public := key.Public()
 
 	headers := map[string]interface{}{"typ": "JWT", "kid": key.KID()}
@@ -327,7 +396,7 @@ func TestCrypto_DecryptJWE(t *testing.T) {
 	client := createCrypto(t)
 
 	kid := "did:nuts:1234#key-1"
-	key, _ := client.New(audit.TestContext(), StringNamingFunc(kid))
+	key, _ := client.New(audit.TestContext(), ECP256Key, StringNamingFunc(kid))
 
 	t.Run("decrypts valid JWE", func(t *testing.T) {
 		payload, _ := json.Marshal(map[string]interface{}{"iss": "nuts"})
@@ -439,7 +508,7 @@ func TestSignJWS(t *testing.T) {
 			publicKeyAsJWK, _ := jwk.FromRaw(key.Public())
 			hdrs := map[string]interface{}{"jwk": publicKeyAsJWK}
 			signature, err := signJWS(payload, hdrs, nil, false)
-			assert.EqualError(t, err, "jwk.FromRaw requires a non-nil key")
+			assert.EqualError(t, err, "no private key provided")
 			assert.Empty(t, signature)
 		})
 	})
@@ -565,3 +634,16 @@ func TestThumbprint(t *testing.T) {
 		assert.Equal(t, expectedThumbPrint, thumbPrint)
 	})
 }
+
+// opaqueSigner is a crypto.Signer that doesn't expose its private key, like keys stored in an HSM.
+type opaqueSigner struct {
+	signer crypto.Signer
+}
+
+func (o opaqueSigner) Public() crypto.PublicKey {
+	return o.signer.Public()
+}
+
+func (o opaqueSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return o.signer.Sign(rand, digest, opts)
+}
@@ -41,18 +41,18 @@ func (m *MockKeyCreator) EXPECT() *MockKeyCreatorMockRecorder {
 }
 
 // New mocks base method.
-func (m *MockKeyCreator) New(ctx context.Context, namingFunc KIDNamingFunc) (Key, error) {
+func (m *MockKeyCreator) New(ctx context.Context, keyType KeyType, namingFunc KIDNamingFunc) (Key, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "New", ctx, namingFunc)
+	ret := m.ctrl.Call(m, "New", ctx, keyType, namingFunc)
 	ret0, _ := ret[0].(Key)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // New indicates an expected call of New.
-func (mr *MockKeyCreatorMockRecorder) New(ctx, namingFunc any) *gomock.Call {
+func (mr *MockKeyCreatorMockRecorder) New(ctx, keyType, namingFunc any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockKeyCreator)(nil).New), ctx, namingFunc)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockKeyCreator)(nil).New), ctx, keyType, namingFunc)
 }
 
 // MockKeyResolver is a mock of KeyResolver interface.
@@ -233,18 +233,18 @@ func (mr *MockKeyStoreMockRecorder) List(ctx any) *gomock.Call {
 }
 
 // New mocks base method.
-func (m *MockKeyStore) New(ctx context.Context, namingFunc KIDNamingFunc) (Key, error) {
+func (m *MockKeyStore) New(ctx context.Context, keyType KeyType, namingFunc KIDNamingFunc) (Key, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "New", ctx, namingFunc)
+	ret := m.ctrl.Call(m, "New", ctx, keyType, namingFunc)
 	ret0, _ := ret[0].(Key)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // New indicates an expected call of New.
-func (mr *MockKeyStoreMockRecorder) New(ctx, namingFunc any) *gomock.Call {
+func (mr *MockKeyStoreMockRecorder) New(ctx, keyType, namingFunc any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockKeyStore)(nil).New), ctx, namingFunc)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockKeyStore)(nil).New), ctx, keyType, namingFunc)
 }
 
 // Resolve mocks base method.
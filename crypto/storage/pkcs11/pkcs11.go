@@ -0,0 +1,557 @@
+/*
+ * Nuts node
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/log"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+)
+
+// StorageType is the name of this storage type, used in health check reports and configuration.
+const StorageType = "pkcs11"
+
+// namedCurve is an EC curve supported by the storage backend, identified by its ASN.1 object identifier in CKA_EC_PARAMS.
+type namedCurve struct {
+	keyType string
+	oid     asn1.ObjectIdentifier
+	curve   elliptic.Curve
+}
+
+// supportedCurves contains the EC curves of which keys are generated and used in the token.
+// EdDSA and RSA keys aren't supported.
+var supportedCurves = []namedCurve{
+	{keyType: "P-256", oid: asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}, curve: elliptic.P256()},
+	{keyType: "P-384", oid: asn1.ObjectIdentifier{1, 3, 132, 0, 34}, curve: elliptic.P384()},
+}
+
+// curveByKeyType returns the supported curve for the given key type (see crypto.KeyType).
+func curveByKeyType(keyType string) (namedCurve, error) {
+	for _, curve := range supportedCurves {
+		if curve.keyType == keyType {
+			return curve, nil
+		}
+	}
+	return namedCurve{}, fmt.Errorf("%w in PKCS#11 token: %s", spi.ErrUnsupportedKeyType, keyType)
+}
+
+// curveOf returns the supported curve for the given elliptic.Curve.
+func curveOf(ellipticCurve elliptic.Curve) (namedCurve, bool) {
+	for _, curve := range supportedCurves {
+		if curve.curve == ellipticCurve {
+			return curve, true
+		}
+	}
+	return namedCurve{}, false
+}
+
+// curveByOID returns the supported curve with the given ASN.1 object identifier.
+func curveByOID(oid asn1.ObjectIdentifier) (namedCurve, bool) {
+	for _, curve := range supportedCurves {
+		if curve.oid.Equal(oid) {
+			return curve, true
+		}
+	}
+	return namedCurve{}, false
+}
+
+// Config contains the config options to configure the PKCS#11 storage backend.
+type Config struct {
+	// Library is the path to the PKCS#11 module (shared library) of the HSM.
+	Library string `koanf:"library"`
+	// TokenLabel is the label of the token that holds the keys.
+	TokenLabel string `koanf:"tokenlabel"`
+	// PIN is the user PIN used to log in to the token.
+	PIN string `koanf:"pin"`
+}
+
+// module is the part of the PKCS#11 API used by the storage backend. It is implemented by *pkcs11.Ctx,
+// and allows the backend to be tested without an HSM.
+type module interface {
+	GetSlotList(tokenPresent bool) ([]uint, error)
+	GetTokenInfo(slotID uint) (p11.TokenInfo, error)
+	OpenSession(slotID uint, flags uint) (p11.SessionHandle, error)
+	CloseSession(sh p11.SessionHandle) error
+	Login(sh p11.SessionHandle, userType uint, pin string) error
+	Logout(sh p11.SessionHandle) error
+	FindObjectsInit(sh p11.SessionHandle, temp []*p11.Attribute) error
+	FindObjects(sh p11.SessionHandle, max int) ([]p11.ObjectHandle, bool, error)
+	FindObjectsFinal(sh p11.SessionHandle) error
+	GetAttributeValue(sh p11.SessionHandle, o p11.ObjectHandle, a []*p11.Attribute) ([]*p11.Attribute, error)
+	SetAttributeValue(sh p11.SessionHandle, o p11.ObjectHandle, a []*p11.Attribute) error
+	GenerateKeyPair(sh p11.SessionHandle, m []*p11.Mechanism, public, private []*p11.Attribute) (p11.ObjectHandle, p11.ObjectHandle, error)
+	CreateObject(sh p11.SessionHandle, temp []*p11.Attribute) (p11.ObjectHandle, error)
+	DestroyObject(sh p11.SessionHandle, oh p11.ObjectHandle) error
+	SignInit(sh p11.SessionHandle, m []*p11.Mechanism, o p11.ObjectHandle) error
+	Sign(sh p11.SessionHandle, message []byte) ([]byte, error)
+}
+
+// pkcs11Storage is a storage backend that keeps private keys in a PKCS#11 token (e.g. an HSM).
+// Keys are generated inside the token and can't be extracted: signing happens inside the token.
+// Keys are identified by their CKA_LABEL, which contains the kid.
+type pkcs11Storage struct {
+	module module
+	slot   uint
+	// loginSession is kept open for the lifetime of the backend, since login state is shared by all sessions of the application.
+	loginSession p11.SessionHandle
+	// close finalizes the PKCS#11 library
+	close func() error
+	// generateMux makes sure no key with the same kid is generated concurrently.
+	generateMux sync.Mutex
+}
+
+// NewPKCS11Storage loads the configured PKCS#11 library and logs in to the token with the configured label.
+func NewPKCS11Storage(config Config) (spi.Storage, error) {
+	if config.Library == "" {
+		return nil, errors.New("PKCS#11 library not configured")
+	}
+	ctx := p11.New(config.Library)
+	if ctx == nil {
+		return nil, fmt.Errorf("unable to load PKCS#11 library: %s", config.Library)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("unable to initialize PKCS#11 library: %w", err)
+	}
+	storage, err := newStorage(ctx, config, func() error {
+		defer ctx.Destroy()
+		return ctx.Finalize()
+	})
+	if err != nil {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+	return storage, nil
+}
+
+func newStorage(module module, config Config, close func() error) (*pkcs11Storage, error) {
+	slot, err := findSlot(module, config.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	session, err := module.OpenSession(slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open PKCS#11 session: %w", err)
+	}
+	if err = module.Login(session, p11.CKU_USER, config.PIN); err != nil && !errors.Is(err, p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = module.CloseSession(session)
+		return nil, fmt.Errorf("unable to log in to PKCS#11 token: %w", err)
+	}
+	return &pkcs11Storage{
+		module:       module,
+		slot:         slot,
+		loginSession: session,
+		close:        close,
+	}, nil
+}
+
+// findSlot returns the slot that holds the token with the given label.
+func findSlot(module module, tokenLabel string) (uint, error) {
+	slots, err := module.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("unable to list PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := module.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("unable to read PKCS#11 token info: %w", err)
+		}
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11 token not found: %s", tokenLabel)
+}
+
+func (s *pkcs11Storage) Name() string {
+	return StorageType
+}
+
+func (s *pkcs11Storage) CheckHealth() map[string]core.Health {
+	health := make(map[string]core.Health)
+	if _, err := s.module.GetTokenInfo(s.slot); err != nil {
+		health[s.Name()] = core.Health{Status: core.HealthStatusDown, Details: err.Error()}
+	} else {
+		health[s.Name()] = core.Health{Status: core.HealthStatusUp}
+	}
+	return health
+}
+
+// Close logs out of the token and finalizes the PKCS#11 library.
+func (s *pkcs11Storage) Close() error {
+	_ = s.module.Logout(s.loginSession)
+	_ = s.module.CloseSession(s.loginSession)
+	if s.close != nil {
+		return s.close()
+	}
+	return nil
+}
+
+// withSession opens a session for a single operation, since a session can only be used for one operation at a time.
+func (s *pkcs11Storage) withSession(fn func(session p11.SessionHandle) error) error {
+	session, err := s.module.OpenSession(s.slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION)
+	if err != nil {
+		return fmt.Errorf("unable to open PKCS#11 session: %w", err)
+	}
+	defer s.module.CloseSession(session)
+	return fn(session)
+}
+
+func (s *pkcs11Storage) GetPrivateKey(_ context.Context, kid string) (crypto.Signer, error) {
+	var publicKey *ecdsa.PublicKey
+	err := s.withSession(func(session p11.SessionHandle) error {
+		handles, err := s.findObjects(session, p11.CKO_PUBLIC_KEY, kid)
+		if err != nil {
+			return err
+		}
+		if len(handles) == 0 {
+			return spi.ErrNotFound
+		}
+		publicKey, err = s.readPublicKey(session, handles[0])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &signer{storage: s, kid: kid, publicKey: publicKey}, nil
+}
+
+func (s *pkcs11Storage) PrivateKeyExists(_ context.Context, kid string) bool {
+	var exists bool
+	err := s.withSession(func(session p11.SessionHandle) error {
+		handles, err := s.findObjects(session, p11.CKO_PRIVATE_KEY, kid)
+		exists = len(handles) > 0
+		return err
+	})
+	if err != nil {
+		log.Logger().WithError(err).Errorf("Unable to check if private key exists in PKCS#11 token (kid=%s)", kid)
+	}
+	return exists
+}
+
+// SavePrivateKey imports the given private key into the token. It can't be extracted afterwards.
+// Only EC P-256 and P-384 keys are supported.
+func (s *pkcs11Storage) SavePrivateKey(_ context.Context, kid string, key crypto.PrivateKey) error {
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	var curve namedCurve
+	if ok {
+		curve, ok = curveOf(privateKey.Curve)
+	}
+	if !ok {
+		return errors.New("unsupported private key type, only EC P-256 and P-384 keys are supported")
+	}
+	s.generateMux.Lock()
+	defer s.generateMux.Unlock()
+	return s.withSession(func(session p11.SessionHandle) error {
+		handles, err := s.findObjects(session, p11.CKO_PRIVATE_KEY, kid)
+		if err != nil {
+			return err
+		}
+		if len(handles) > 0 {
+			return spi.ErrKeyAlreadyExists
+		}
+		id, err := newObjectID()
+		if err != nil {
+			return err
+		}
+		ecParams, _ := asn1.Marshal(curve.oid)
+		ecPoint, _ := asn1.Marshal(elliptic.Marshal(privateKey.Curve, privateKey.X, privateKey.Y))
+		publicKeyHandle, err := s.module.CreateObject(session, []*p11.Attribute{
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY),
+			p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_EC),
+			p11.NewAttribute(p11.CKA_TOKEN, true),
+			p11.NewAttribute(p11.CKA_VERIFY, true),
+			p11.NewAttribute(p11.CKA_EC_PARAMS, ecParams),
+			p11.NewAttribute(p11.CKA_EC_POINT, ecPoint),
+			p11.NewAttribute(p11.CKA_ID, id),
+			p11.NewAttribute(p11.CKA_LABEL, kid),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to import public key into PKCS#11 token: %w", err)
+		}
+		_, err = s.module.CreateObject(session, append(privateKeyTemplate(id), []*p11.Attribute{
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY),
+			p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_EC),
+			p11.NewAttribute(p11.CKA_EC_PARAMS, ecParams),
+			p11.NewAttribute(p11.CKA_VALUE, privateKey.D.FillBytes(make([]byte, (curve.curve.Params().BitSize+7)/8))),
+			p11.NewAttribute(p11.CKA_LABEL, kid),
+		}...))
+		if err != nil {
+			_ = s.module.DestroyObject(session, publicKeyHandle)
+			return fmt.Errorf("unable to import private key into PKCS#11 token: %w", err)
+		}
+		return nil
+	})
+}
+
+// NewPrivateKey generates a new EC P-256 or P-384 key pair inside the token, so the private key never leaves it.
+// The key pair is labeled with the kid returned by namingFunc for its public key.
+func (s *pkcs11Storage) NewPrivateKey(_ context.Context, keyType string, namingFunc func(crypto.PublicKey) (string, error)) (crypto.PublicKey, string, error) {
+	curve, err := curveByKeyType(keyType)
+	if err != nil {
+		return nil, "", err
+	}
+	s.generateMux.Lock()
+	defer s.generateMux.Unlock()
+	var publicKey *ecdsa.PublicKey
+	var kid string
+	err = s.withSession(func(session p11.SessionHandle) error {
+		id, err := newObjectID()
+		if err != nil {
+			return err
+		}
+		ecParams, _ := asn1.Marshal(curve.oid)
+		publicKeyHandle, privateKeyHandle, err := s.module.GenerateKeyPair(session,
+			[]*p11.Mechanism{p11.NewMechanism(p11.CKM_EC_KEY_PAIR_GEN, nil)},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_VERIFY, true),
+				p11.NewAttribute(p11.CKA_EC_PARAMS, ecParams),
+				p11.NewAttribute(p11.CKA_ID, id),
+			},
+			privateKeyTemplate(id),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to generate key pair in PKCS#11 token: %w", err)
+		}
+		// the kid is derived from the public key, so the key pair is labeled after generation.
+		// If that fails, the key pair is removed again.
+		err = func() error {
+			publicKey, err = s.readPublicKey(session, publicKeyHandle)
+			if err != nil {
+				return err
+			}
+			kid, err = namingFunc(publicKey)
+			if err != nil {
+				return err
+			}
+			existing, err := s.findObjects(session, p11.CKO_PRIVATE_KEY, kid)
+			if err != nil {
+				return err
+			}
+			if len(existing) > 0 {
+				return spi.ErrKeyAlreadyExists
+			}
+			label := []*p11.Attribute{p11.NewAttribute(p11.CKA_LABEL, kid)}
+			if err = s.module.SetAttributeValue(session, publicKeyHandle, label); err != nil {
+				return fmt.Errorf("unable to label public key in PKCS#11 token: %w", err)
+			}
+			if err = s.module.SetAttributeValue(session, privateKeyHandle, label); err != nil {
+				return fmt.Errorf("unable to label private key in PKCS#11 token: %w", err)
+			}
+			return nil
+		}()
+		if err != nil {
+			_ = s.module.DestroyObject(session, privateKeyHandle)
+			_ = s.module.DestroyObject(session, publicKeyHandle)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return publicKey, kid, nil
+}
+
+func (s *pkcs11Storage) ListPrivateKeys(_ context.Context) []string {
+	var result []string
+	err := s.withSession(func(session p11.SessionHandle) error {
+		handles, err := s.findObjects(session, p11.CKO_PRIVATE_KEY, "")
+		if err != nil {
+			return err
+		}
+		for _, handle := range handles {
+			attributes, err := s.module.GetAttributeValue(session, handle, []*p11.Attribute{p11.NewAttribute(p11.CKA_LABEL, nil)})
+			if err != nil {
+				return err
+			}
+			// key pairs without label are left-overs of failed key generation
+			if len(attributes) > 0 && len(attributes[0].Value) > 0 {
+				result = append(result, string(attributes[0].Value))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Logger().WithError(err).Error("Unable to list private keys in PKCS#11 token")
+		return nil
+	}
+	return result
+}
+
+func (s *pkcs11Storage) DeletePrivateKey(_ context.Context, kid string) error {
+	return s.withSession(func(session p11.SessionHandle) error {
+		privateKeys, err := s.findObjects(session, p11.CKO_PRIVATE_KEY, kid)
+		if err != nil {
+			return err
+		}
+		if len(privateKeys) == 0 {
+			return spi.ErrNotFound
+		}
+		publicKeys, err := s.findObjects(session, p11.CKO_PUBLIC_KEY, kid)
+		if err != nil {
+			return err
+		}
+		for _, handle := range append(privateKeys, publicKeys...) {
+			if err := s.module.DestroyObject(session, handle); err != nil {
+				return fmt.Errorf("unable to delete key from PKCS#11 token: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// findObjects returns the handles of the objects of the given class. If label is not empty, only objects with the given label are returned.
+func (s *pkcs11Storage) findObjects(session p11.SessionHandle, class uint, label string) ([]p11.ObjectHandle, error) {
+	template := []*p11.Attribute{p11.NewAttribute(p11.CKA_CLASS, class)}
+	if label != "" {
+		template = append(template, p11.NewAttribute(p11.CKA_LABEL, label))
+	}
+	if err := s.module.FindObjectsInit(session, template); err != nil {
+		return nil, fmt.Errorf("unable to search PKCS#11 token: %w", err)
+	}
+	defer s.module.FindObjectsFinal(session)
+	var result []p11.ObjectHandle
+	for {
+		handles, _, err := s.module.FindObjects(session, 100)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search PKCS#11 token: %w", err)
+		}
+		if len(handles) == 0 {
+			return result, nil
+		}
+		result = append(result, handles...)
+	}
+}
+
+// readPublicKey reads the EC public key from the given public key object.
+func (s *pkcs11Storage) readPublicKey(session p11.SessionHandle, handle p11.ObjectHandle) (*ecdsa.PublicKey, error) {
+	attributes, err := s.module.GetAttributeValue(session, handle, []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_EC_PARAMS, nil),
+		p11.NewAttribute(p11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read public key from PKCS#11 token: %w", err)
+	}
+	return parseECPublicKey(attributes[0].Value, attributes[1].Value)
+}
+
+// parseECPublicKey parses the CKA_EC_PARAMS and CKA_EC_POINT attributes of a P-256 or P-384 public key.
+func parseECPublicKey(ecParams []byte, ecPoint []byte) (*ecdsa.PublicKey, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ecParams, &oid); err != nil {
+		return nil, errors.New("invalid EC parameters in PKCS#11 token")
+	}
+	curve, ok := curveByOID(oid)
+	if !ok {
+		return nil, errors.New("unsupported key type in PKCS#11 token, only EC P-256 and P-384 keys are supported")
+	}
+	// CKA_EC_POINT should be a DER-encoded OCTET STRING, but some tokens return the raw point
+	var point []byte
+	if rest, err := asn1.Unmarshal(ecPoint, &point); err != nil || len(rest) > 0 {
+		point = ecPoint
+	}
+	x, y := elliptic.Unmarshal(curve.curve, point)
+	if x == nil {
+		return nil, errors.New("invalid EC point in PKCS#11 token")
+	}
+	return &ecdsa.PublicKey{Curve: curve.curve, X: x, Y: y}, nil
+}
+
+// privateKeyTemplate returns the attributes for private keys: they can only be used for signing and never leave the token.
+func privateKeyTemplate(id []byte) []*p11.Attribute {
+	return []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_TOKEN, true),
+		p11.NewAttribute(p11.CKA_PRIVATE, true),
+		p11.NewAttribute(p11.CKA_SENSITIVE, true),
+		p11.NewAttribute(p11.CKA_EXTRACTABLE, false),
+		p11.NewAttribute(p11.CKA_SIGN, true),
+		p11.NewAttribute(p11.CKA_ID, id),
+	}
+}
+
+// newObjectID returns a random CKA_ID, which links the private and public key objects of a key pair.
+func newObjectID() ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// signer is a crypto.Signer that signs inside the PKCS#11 token.
+type signer struct {
+	storage   *pkcs11Storage
+	kid       string
+	publicKey *ecdsa.PublicKey
+}
+
+func (s *signer) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs the digest using ECDSA inside the token. The signature is returned ASN.1 DER encoded, like ecdsa.PrivateKey does.
+func (s *signer) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	var signature []byte
+	err := s.storage.withSession(func(session p11.SessionHandle) error {
+		handles, err := s.storage.findObjects(session, p11.CKO_PRIVATE_KEY, s.kid)
+		if err != nil {
+			return err
+		}
+		if len(handles) == 0 {
+			return spi.ErrNotFound
+		}
+		if err = s.storage.module.SignInit(session, []*p11.Mechanism{p11.NewMechanism(p11.CKM_ECDSA, nil)}, handles[0]); err != nil {
+			return fmt.Errorf("unable to sign with PKCS#11 token: %w", err)
+		}
+		signature, err = s.storage.module.Sign(session, digest)
+		if err != nil {
+			return fmt.Errorf("unable to sign with PKCS#11 token: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// PKCS#11 returns the signature as r || s
+	if len(signature) == 0 || len(signature)%2 != 0 {
+		return nil, errors.New("invalid signature from PKCS#11 token")
+	}
+	half := len(signature) / 2
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(signature[:half]),
+		S: new(big.Int).SetBytes(signature[half:]),
+	})
+}
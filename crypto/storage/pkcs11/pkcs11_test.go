@@ -0,0 +1,496 @@
+/*
+ * Nuts node
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/nuts-foundation/nuts-node/crypto/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func kidNamingFunc(kid string) func(crypto.PublicKey) (string, error) {
+	return func(_ crypto.PublicKey) (string, error) {
+		return kid, nil
+	}
+}
+
+func TestNewPKCS11Storage(t *testing.T) {
+	t.Run("library not configured", func(t *testing.T) {
+		storage, err := NewPKCS11Storage(Config{})
+
+		assert.EqualError(t, err, "PKCS#11 library not configured")
+		assert.Nil(t, storage)
+	})
+	t.Run("library does not exist", func(t *testing.T) {
+		storage, err := NewPKCS11Storage(Config{Library: filepath.Join(t.TempDir(), "libnotfound.so")})
+
+		assert.ErrorContains(t, err, "unable to load PKCS#11 library")
+		assert.Nil(t, storage)
+	})
+}
+
+func Test_newStorage(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		module := newFakeModule()
+
+		storage, err := newStorage(module, Config{TokenLabel: tokenLabel, PIN: "1234"}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), storage.slot)
+		assert.True(t, module.loggedIn)
+	})
+	t.Run("already logged in", func(t *testing.T) {
+		module := newFakeModule()
+		module.loginErr = p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN)
+
+		_, err := newStorage(module, Config{TokenLabel: tokenLabel}, nil)
+
+		assert.NoError(t, err)
+	})
+	t.Run("token not found", func(t *testing.T) {
+		_, err := newStorage(newFakeModule(), Config{TokenLabel: "other"}, nil)
+
+		assert.EqualError(t, err, "PKCS#11 token not found: other")
+	})
+	t.Run("login fails", func(t *testing.T) {
+		module := newFakeModule()
+		module.loginErr = p11.Error(p11.CKR_PIN_INCORRECT)
+
+		_, err := newStorage(module, Config{TokenLabel: tokenLabel, PIN: "wrong"}, nil)
+
+		assert.ErrorIs(t, err, p11.Error(p11.CKR_PIN_INCORRECT))
+		assert.ErrorContains(t, err, "unable to log in to PKCS#11 token")
+		assert.Equal(t, 0, module.openSessions)
+	})
+}
+
+func TestPKCS11Storage_NewPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ok", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+
+		publicKey, kid, err := storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "kid", kid)
+		require.IsType(t, &ecdsa.PublicKey{}, publicKey)
+		assert.Equal(t, elliptic.P256(), publicKey.(*ecdsa.PublicKey).Curve)
+		assert.True(t, storage.PrivateKeyExists(ctx, "kid"))
+	})
+	t.Run("P-384", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+
+		publicKey, _, err := storage.NewPrivateKey(ctx, "P-384", kidNamingFunc("kid"))
+
+		require.NoError(t, err)
+		require.IsType(t, &ecdsa.PublicKey{}, publicKey)
+		assert.Equal(t, elliptic.P384(), publicKey.(*ecdsa.PublicKey).Curve)
+		signer, err := storage.GetPrivateKey(ctx, "kid")
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte("hello"))
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), digest[:], signature))
+	})
+	t.Run("unsupported key type", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+
+		_, _, err := storage.NewPrivateKey(ctx, "Ed25519", kidNamingFunc("kid"))
+
+		assert.ErrorIs(t, err, spi.ErrUnsupportedKeyType)
+		assert.EqualError(t, err, "unsupported key type in PKCS#11 token: Ed25519")
+		assert.Empty(t, module.objects)
+	})
+	t.Run("kid is derived from the generated public key", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+		var namedKey crypto.PublicKey
+
+		publicKey, _, err := storage.NewPrivateKey(ctx, "P-256", func(key crypto.PublicKey) (string, error) {
+			namedKey = key
+			return "kid", nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, namedKey)
+	})
+	t.Run("key already exists", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		_, _, err := storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+		require.NoError(t, err)
+
+		_, _, err = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+
+		assert.ErrorIs(t, err, spi.ErrKeyAlreadyExists)
+		assert.Len(t, module.objects, 2, "generated key pair should be removed")
+	})
+	t.Run("naming func fails", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+
+		_, _, err := storage.NewPrivateKey(ctx, "P-256", func(_ crypto.PublicKey) (string, error) {
+			return "", errors.New("failed")
+		})
+
+		assert.EqualError(t, err, "failed")
+		assert.Empty(t, module.objects, "generated key pair should be removed")
+	})
+	t.Run("labeling fails", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		module.setAttributeErr = p11.Error(p11.CKR_ATTRIBUTE_READ_ONLY)
+
+		_, _, err := storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+
+		assert.ErrorContains(t, err, "unable to label public key in PKCS#11 token")
+		assert.Empty(t, module.objects, "generated key pair should be removed")
+	})
+	t.Run("generation fails", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		module.generateErr = p11.Error(p11.CKR_DEVICE_MEMORY)
+
+		_, _, err := storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+
+		assert.ErrorIs(t, err, p11.Error(p11.CKR_DEVICE_MEMORY))
+		assert.ErrorContains(t, err, "unable to generate key pair in PKCS#11 token")
+	})
+	t.Run("sessions are closed", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+
+		_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+
+		assert.Equal(t, 1, module.openSessions, "only the login session should remain open")
+	})
+}
+
+func TestPKCS11Storage_GetPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	t.Run("signs inside the token", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		publicKey, _, err := storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte("hello world"))
+
+		signer, err := storage.GetPrivateKey(ctx, "kid")
+		require.NoError(t, err)
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, signer.Public())
+		assert.True(t, ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), digest[:], signature))
+		assert.Equal(t, 1, module.signCount)
+	})
+	t.Run("not found", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+
+		signer, err := storage.GetPrivateKey(ctx, "unknown")
+
+		assert.ErrorIs(t, err, spi.ErrNotFound)
+		assert.Nil(t, signer)
+	})
+	t.Run("key deleted after signer was obtained", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+		_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+		signer, _ := storage.GetPrivateKey(ctx, "kid")
+		require.NoError(t, storage.DeletePrivateKey(ctx, "kid"))
+
+		_, err := signer.Sign(rand.Reader, []byte{1, 2, 3}, crypto.SHA256)
+
+		assert.ErrorIs(t, err, spi.ErrNotFound)
+	})
+	t.Run("signing fails", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+		signer, _ := storage.GetPrivateKey(ctx, "kid")
+		module.signErr = p11.Error(p11.CKR_FUNCTION_FAILED)
+
+		_, err := signer.Sign(rand.Reader, []byte{1, 2, 3}, crypto.SHA256)
+
+		assert.ErrorIs(t, err, p11.Error(p11.CKR_FUNCTION_FAILED))
+	})
+}
+
+func TestPKCS11Storage_SavePrivateKey(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ok", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+		privateKey := test.GenerateECKey()
+		digest := sha256.Sum256([]byte("hello world"))
+
+		err := storage.SavePrivateKey(ctx, "kid", privateKey)
+		require.NoError(t, err)
+
+		signer, err := storage.GetPrivateKey(ctx, "kid")
+		require.NoError(t, err)
+		assert.True(t, privateKey.PublicKey.Equal(signer.Public()))
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(&privateKey.PublicKey, digest[:], signature))
+	})
+	t.Run("already exists", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+		require.NoError(t, storage.SavePrivateKey(ctx, "kid", test.GenerateECKey()))
+
+		err := storage.SavePrivateKey(ctx, "kid", test.GenerateECKey())
+
+		assert.ErrorIs(t, err, spi.ErrKeyAlreadyExists)
+	})
+	t.Run("unsupported key type", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+
+		err := storage.SavePrivateKey(ctx, "kid", test.GenerateRSAKey())
+
+		assert.EqualError(t, err, "unsupported private key type, only EC P-256 and P-384 keys are supported")
+	})
+	t.Run("unsupported curve", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+
+		err := storage.SavePrivateKey(ctx, "kid", privateKey)
+
+		assert.EqualError(t, err, "unsupported private key type, only EC P-256 and P-384 keys are supported")
+	})
+}
+
+func TestPKCS11Storage_ListPrivateKeys(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ok", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+		_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid-1"))
+		_ = storage.SavePrivateKey(ctx, "kid-2", test.GenerateECKey())
+
+		keys := storage.ListPrivateKeys(ctx)
+
+		assert.ElementsMatch(t, []string{"kid-1", "kid-2"}, keys)
+	})
+	t.Run("unlabeled keys are skipped", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+		_, _, _ = module.GenerateKeyPair(0, nil, nil, nil)
+
+		keys := storage.ListPrivateKeys(ctx)
+
+		assert.Equal(t, []string{"kid"}, keys)
+	})
+	t.Run("error", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+		module.findErr = p11.Error(p11.CKR_DEVICE_ERROR)
+
+		keys := storage.ListPrivateKeys(ctx)
+
+		assert.Nil(t, keys)
+	})
+}
+
+func TestPKCS11Storage_PrivateKeyExists(t *testing.T) {
+	ctx := context.Background()
+	storage, module := newTestStorage(t)
+	_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+
+	t.Run("exists", func(t *testing.T) {
+		assert.True(t, storage.PrivateKeyExists(ctx, "kid"))
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		assert.False(t, storage.PrivateKeyExists(ctx, "unknown"))
+	})
+	t.Run("error", func(t *testing.T) {
+		module.findErr = p11.Error(p11.CKR_DEVICE_ERROR)
+		defer func() {
+			module.findErr = nil
+		}()
+
+		assert.False(t, storage.PrivateKeyExists(ctx, "kid"))
+	})
+}
+
+func TestPKCS11Storage_DeletePrivateKey(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ok", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		_, _, _ = storage.NewPrivateKey(ctx, "P-256", kidNamingFunc("kid"))
+
+		err := storage.DeletePrivateKey(ctx, "kid")
+
+		require.NoError(t, err)
+		assert.False(t, storage.PrivateKeyExists(ctx, "kid"))
+		assert.Empty(t, module.objects)
+	})
+	t.Run("not found", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+
+		err := storage.DeletePrivateKey(ctx, "kid")
+
+		assert.ErrorIs(t, err, spi.ErrNotFound)
+	})
+}
+
+func TestPKCS11Storage_CheckHealth(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		storage, _ := newTestStorage(t)
+
+		result := storage.CheckHealth()
+
+		assert.Equal(t, core.HealthStatusUp, result[StorageType].Status)
+	})
+	t.Run("down", func(t *testing.T) {
+		storage, module := newTestStorage(t)
+		module.tokenInfoErr = p11.Error(p11.CKR_DEVICE_REMOVED)
+
+		result := storage.CheckHealth()
+
+		assert.Equal(t, core.HealthStatusDown, result[StorageType].Status)
+		assert.Equal(t, p11.Error(p11.CKR_DEVICE_REMOVED).Error(), result[StorageType].Details)
+	})
+}
+
+func TestPKCS11Storage_Close(t *testing.T) {
+	module := newFakeModule()
+	closed := false
+	storage, err := newStorage(module, Config{TokenLabel: tokenLabel}, func() error {
+		closed = true
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = storage.Close()
+
+	require.NoError(t, err)
+	assert.True(t, closed)
+	assert.False(t, module.loggedIn)
+	assert.Equal(t, 0, module.openSessions)
+}
+
+func Test_parseECPublicKey(t *testing.T) {
+	key := test.GenerateECKey()
+	ecParams, _ := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	point := elliptic.Marshal(elliptic.P256(), key.X, key.Y)
+
+	t.Run("DER encoded point", func(t *testing.T) {
+		ecPoint, _ := asn1.Marshal(point)
+
+		publicKey, err := parseECPublicKey(ecParams, ecPoint)
+
+		require.NoError(t, err)
+		assert.True(t, key.PublicKey.Equal(publicKey))
+	})
+	t.Run("raw point", func(t *testing.T) {
+		publicKey, err := parseECPublicKey(ecParams, point)
+
+		require.NoError(t, err)
+		assert.True(t, key.PublicKey.Equal(publicKey))
+	})
+	t.Run("unsupported curve", func(t *testing.T) {
+		p521Params, _ := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 35})
+
+		_, err := parseECPublicKey(p521Params, point)
+
+		assert.EqualError(t, err, "unsupported key type in PKCS#11 token, only EC P-256 and P-384 keys are supported")
+	})
+	t.Run("invalid point", func(t *testing.T) {
+		_, err := parseECPublicKey(ecParams, []byte{4, 1, 2, 3})
+
+		assert.EqualError(t, err, "invalid EC point in PKCS#11 token")
+	})
+}
+
+// TestPKCS11Storage_SoftHSM runs against SoftHSMv2. It's skipped if SoftHSMv2 is not installed.
+// The location of the library can be set using the SOFTHSM2_LIB environment variable.
+func TestPKCS11Storage_SoftHSM(t *testing.T) {
+	library := os.Getenv("SOFTHSM2_LIB")
+	if library == "" {
+		library = "/usr/lib/softhsm/libsofthsm2.so"
+	}
+	if _, err := os.Stat(library); err != nil {
+		t.Skipf("SoftHSMv2 not found (%s), set SOFTHSM2_LIB to run this test", library)
+	}
+	const pin = "1234"
+	tokenDir := t.TempDir()
+	configFile := filepath.Join(t.TempDir(), "softhsm2.conf")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf("directories.tokendir = %s\nobjectstore.backend = file\n", tokenDir)), 0600))
+	t.Setenv("SOFTHSM2_CONF", configFile)
+	initSoftHSMToken(t, library, pin)
+	ctx := context.Background()
+
+	storage, err := NewPKCS11Storage(Config{Library: library, TokenLabel: tokenLabel, PIN: pin})
+	require.NoError(t, err)
+	defer storage.(*pkcs11Storage).Close()
+
+	assert.Equal(t, core.HealthStatusUp, storage.CheckHealth()[StorageType].Status)
+	publicKey, kid, err := storage.(spi.KeyGenerator).NewPrivateKey(ctx, "P-256", kidNamingFunc("generated"))
+	require.NoError(t, err)
+	assert.Equal(t, "generated", kid)
+	require.NoError(t, storage.SavePrivateKey(ctx, "imported", test.GenerateECKey()))
+	assert.ElementsMatch(t, []string{"generated", "imported"}, storage.ListPrivateKeys(ctx))
+
+	signer, err := storage.GetPrivateKey(ctx, "generated")
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("hello world"))
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), digest[:], signature))
+
+	require.NoError(t, storage.DeletePrivateKey(ctx, "generated"))
+	assert.False(t, storage.PrivateKeyExists(ctx, "generated"))
+}
+
+// initSoftHSMToken initializes a SoftHSMv2 token with the test token label and the given user PIN.
+func initSoftHSMToken(t *testing.T, library string, pin string) {
+	const soPIN = "5678"
+	ctx := p11.New(library)
+	require.NotNil(t, ctx)
+	require.NoError(t, ctx.Initialize())
+	defer func() {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+	}()
+	slots, err := ctx.GetSlotList(false)
+	require.NoError(t, err)
+	require.NotEmpty(t, slots)
+	require.NoError(t, ctx.InitToken(slots[0], soPIN, tokenLabel))
+	// SoftHSMv2 assigns a new slot ID to an initialized token
+	slot, err := findSlot(ctx, tokenLabel)
+	require.NoError(t, err)
+	session, err := ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer ctx.CloseSession(session)
+	require.NoError(t, ctx.Login(session, p11.CKU_SO, soPIN))
+	require.NoError(t, ctx.InitPIN(session, pin))
+	require.NoError(t, ctx.Logout(session))
+}
+
+func newTestStorage(t *testing.T) (*pkcs11Storage, *fakeModule) {
+	module := newFakeModule()
+	storage, err := newStorage(module, Config{TokenLabel: tokenLabel, PIN: "1234"}, nil)
+	require.NoError(t, err)
+	return storage, module
+}
@@ -0,0 +1,298 @@
+/*
+ * Nuts node
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"math/big"
+	"sync"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+)
+
+// tokenLabel is the label of the token in the fakeModule.
+const tokenLabel = "nuts"
+
+// NewTestStorage returns a PKCS#11 storage backend to be used for tests, backed by an in-memory PKCS#11 module.
+// Like a real HSM, it doesn't expose its private keys: GetPrivateKey returns a signer that signs inside the module.
+func NewTestStorage() spi.Storage {
+	storage, err := newStorage(newFakeModule(), Config{TokenLabel: tokenLabel}, nil)
+	if err != nil {
+		panic(err)
+	}
+	return storage
+}
+
+// fakeObject is an object in the fakeModule.
+type fakeObject struct {
+	attributes map[uint][]byte
+	privateKey *ecdsa.PrivateKey
+}
+
+// fakeModule is an in-memory implementation of the PKCS#11 module, holding a single token in slot 1.
+type fakeModule struct {
+	mux          sync.Mutex
+	objects      map[p11.ObjectHandle]*fakeObject
+	lastHandle   p11.ObjectHandle
+	lastSession  p11.SessionHandle
+	openSessions int
+	loggedIn     bool
+	// searches contains the pending results of FindObjects per session
+	searches map[p11.SessionHandle][]p11.ObjectHandle
+	// signKeys contains the key initialized by SignInit per session
+	signKeys  map[p11.SessionHandle]*ecdsa.PrivateKey
+	signCount int
+
+	loginErr        error
+	tokenInfoErr    error
+	findErr         error
+	generateErr     error
+	setAttributeErr error
+	signErr         error
+}
+
+func newFakeModule() *fakeModule {
+	return &fakeModule{
+		objects:  map[p11.ObjectHandle]*fakeObject{},
+		searches: map[p11.SessionHandle][]p11.ObjectHandle{},
+		signKeys: map[p11.SessionHandle]*ecdsa.PrivateKey{},
+	}
+}
+
+func (f *fakeModule) GetSlotList(_ bool) ([]uint, error) {
+	return []uint{0, 1}, nil
+}
+
+func (f *fakeModule) GetTokenInfo(slotID uint) (p11.TokenInfo, error) {
+	if f.tokenInfoErr != nil {
+		return p11.TokenInfo{}, f.tokenInfoErr
+	}
+	if slotID == 1 {
+		return p11.TokenInfo{Label: tokenLabel}, nil
+	}
+	return p11.TokenInfo{Label: "other token"}, nil
+}
+
+func (f *fakeModule) OpenSession(_ uint, _ uint) (p11.SessionHandle, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.lastSession++
+	f.openSessions++
+	return f.lastSession, nil
+}
+
+func (f *fakeModule) CloseSession(_ p11.SessionHandle) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.openSessions--
+	return nil
+}
+
+func (f *fakeModule) Login(_ p11.SessionHandle, _ uint, _ string) error {
+	if f.loginErr != nil {
+		return f.loginErr
+	}
+	f.loggedIn = true
+	return nil
+}
+
+func (f *fakeModule) Logout(_ p11.SessionHandle) error {
+	f.loggedIn = false
+	return nil
+}
+
+func (f *fakeModule) FindObjectsInit(sh p11.SessionHandle, template []*p11.Attribute) error {
+	if f.findErr != nil {
+		return f.findErr
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	var result []p11.ObjectHandle
+	for handle, object := range f.objects {
+		if object.matches(template) {
+			result = append(result, handle)
+		}
+	}
+	f.searches[sh] = result
+	return nil
+}
+
+func (f *fakeModule) FindObjects(sh p11.SessionHandle, max int) ([]p11.ObjectHandle, bool, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	result := f.searches[sh]
+	if len(result) > max {
+		result = result[:max]
+	}
+	f.searches[sh] = f.searches[sh][len(result):]
+	return result, false, nil
+}
+
+func (f *fakeModule) FindObjectsFinal(sh p11.SessionHandle) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	delete(f.searches, sh)
+	return nil
+}
+
+func (f *fakeModule) GetAttributeValue(_ p11.SessionHandle, o p11.ObjectHandle, a []*p11.Attribute) ([]*p11.Attribute, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	object, ok := f.objects[o]
+	if !ok {
+		return nil, p11.Error(p11.CKR_OBJECT_HANDLE_INVALID)
+	}
+	var result []*p11.Attribute
+	for _, attribute := range a {
+		result = append(result, &p11.Attribute{Type: attribute.Type, Value: object.attributes[attribute.Type]})
+	}
+	return result, nil
+}
+
+func (f *fakeModule) SetAttributeValue(_ p11.SessionHandle, o p11.ObjectHandle, a []*p11.Attribute) error {
+	if f.setAttributeErr != nil {
+		return f.setAttributeErr
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	object, ok := f.objects[o]
+	if !ok {
+		return p11.Error(p11.CKR_OBJECT_HANDLE_INVALID)
+	}
+	object.set(a)
+	return nil
+}
+
+func (f *fakeModule) GenerateKeyPair(_ p11.SessionHandle, _ []*p11.Mechanism, public, private []*p11.Attribute) (p11.ObjectHandle, p11.ObjectHandle, error) {
+	if f.generateErr != nil {
+		return 0, 0, f.generateErr
+	}
+	publicObject := &fakeObject{attributes: map[uint][]byte{}}
+	publicObject.set(public)
+	curve, err := fakeCurve(publicObject.attributes[p11.CKA_EC_PARAMS])
+	if err != nil {
+		return 0, 0, err
+	}
+	privateKey, _ := ecdsa.GenerateKey(curve, rand.Reader)
+	ecPoint, _ := asn1.Marshal(elliptic.Marshal(curve, privateKey.X, privateKey.Y))
+	publicObject.set([]*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY),
+		p11.NewAttribute(p11.CKA_EC_POINT, ecPoint),
+	})
+	privateObject := &fakeObject{attributes: map[uint][]byte{}, privateKey: privateKey}
+	privateObject.set(private)
+	privateObject.set([]*p11.Attribute{p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY)})
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.add(publicObject), f.add(privateObject), nil
+}
+
+func (f *fakeModule) CreateObject(_ p11.SessionHandle, temp []*p11.Attribute) (p11.ObjectHandle, error) {
+	object := &fakeObject{attributes: map[uint][]byte{}}
+	object.set(temp)
+	if value, ok := object.attributes[p11.CKA_VALUE]; ok {
+		// private key: keep the key for signing, but don't expose the value
+		curve, err := fakeCurve(object.attributes[p11.CKA_EC_PARAMS])
+		if err != nil {
+			return 0, err
+		}
+		d := new(big.Int).SetBytes(value)
+		x, y := curve.ScalarBaseMult(value)
+		object.privateKey = &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y}, D: d}
+		delete(object.attributes, p11.CKA_VALUE)
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.add(object), nil
+}
+
+func (f *fakeModule) DestroyObject(_ p11.SessionHandle, oh p11.ObjectHandle) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if _, ok := f.objects[oh]; !ok {
+		return p11.Error(p11.CKR_OBJECT_HANDLE_INVALID)
+	}
+	delete(f.objects, oh)
+	return nil
+}
+
+func (f *fakeModule) SignInit(sh p11.SessionHandle, _ []*p11.Mechanism, o p11.ObjectHandle) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	object, ok := f.objects[o]
+	if !ok || object.privateKey == nil {
+		return p11.Error(p11.CKR_KEY_HANDLE_INVALID)
+	}
+	f.signKeys[sh] = object.privateKey
+	return nil
+}
+
+func (f *fakeModule) Sign(sh p11.SessionHandle, message []byte) ([]byte, error) {
+	if f.signErr != nil {
+		return nil, f.signErr
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	key := f.signKeys[sh]
+	delete(f.signKeys, sh)
+	r, s, err := ecdsa.Sign(rand.Reader, key, message)
+	if err != nil {
+		return nil, err
+	}
+	f.signCount++
+	size := (key.Curve.Params().BitSize + 7) / 8
+	return append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...), nil
+}
+
+func (f *fakeModule) add(object *fakeObject) p11.ObjectHandle {
+	f.lastHandle++
+	f.objects[f.lastHandle] = object
+	return f.lastHandle
+}
+
+func (o *fakeObject) set(attributes []*p11.Attribute) {
+	for _, attribute := range attributes {
+		o.attributes[attribute.Type] = attribute.Value
+	}
+}
+
+func (o *fakeObject) matches(template []*p11.Attribute) bool {
+	for _, attribute := range template {
+		if !bytes.Equal(o.attributes[attribute.Type], attribute.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// fakeCurve returns the curve identified by the given CKA_EC_PARAMS, like a token that supports P-256 and P-384.
+func fakeCurve(ecParams []byte) (elliptic.Curve, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ecParams, &oid); err == nil {
+		if curve, ok := curveByOID(oid); ok {
+			return curve.curve, nil
+		}
+	}
+	return nil, p11.Error(p11.CKR_DOMAIN_PARAMS_INVALID)
+}
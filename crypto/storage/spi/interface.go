@@ -36,6 +36,9 @@ var ErrNotFound = errors.New("entry not found")
 // ErrKeyAlreadyExists indicates that a private key for this keyID already exists.
 var ErrKeyAlreadyExists = errors.New("key already exists")
 
+// ErrUnsupportedKeyType indicates that a KeyGenerator can't generate key pairs of the requested key type.
+var ErrUnsupportedKeyType = errors.New("unsupported key type")
+
 // KidPattern is the regexp for acceptable kids
 var KidPattern = regexp.MustCompile(`^(?:(?:[\da-zA-Z_\- :#.])|(?:%[0-9a-fA-F]{2}))+$`)
 
@@ -54,6 +57,15 @@ type Storage interface {
 	DeletePrivateKey(ctx context.Context, kid string) error
 }
 
+// KeyGenerator is implemented by storage backends that generate keys themselves (e.g. in an HSM), so private keys never leave the backend.
+type KeyGenerator interface {
+	// NewPrivateKey generates a new key pair of the given key type (e.g. "P-256") in the storage backend
+	// and stores it under the kid returned by namingFunc for its public key.
+	// It returns ErrUnsupportedKeyType if the backend can't generate key pairs of the given type,
+	// and ErrKeyAlreadyExists if a private key with the resulting kid already exists.
+	NewPrivateKey(ctx context.Context, keyType string, namingFunc func(crypto.PublicKey) (string, error)) (crypto.PublicKey, string, error)
+}
+
 // PublicKeyEntry is a public key entry also containing the period it's valid for.
 type PublicKeyEntry struct {
 	Period    core.Period `json:"period"`
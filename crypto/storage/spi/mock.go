@@ -139,3 +139,42 @@ func (mr *MockStorageMockRecorder) SavePrivateKey(ctx, kid, key any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePrivateKey", reflect.TypeOf((*MockStorage)(nil).SavePrivateKey), ctx, kid, key)
 }
+
+// MockKeyGenerator is a mock of KeyGenerator interface.
+type MockKeyGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyGeneratorMockRecorder
+}
+
+// MockKeyGeneratorMockRecorder is the mock recorder for MockKeyGenerator.
+type MockKeyGeneratorMockRecorder struct {
+	mock *MockKeyGenerator
+}
+
+// NewMockKeyGenerator creates a new mock instance.
+func NewMockKeyGenerator(ctrl *gomock.Controller) *MockKeyGenerator {
+	mock := &MockKeyGenerator{ctrl: ctrl}
+	mock.recorder = &MockKeyGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyGenerator) EXPECT() *MockKeyGeneratorMockRecorder {
+	return m.recorder
+}
+
+// NewPrivateKey mocks base method.
+func (m *MockKeyGenerator) NewPrivateKey(ctx context.Context, keyType string, namingFunc func(crypto.PublicKey) (string, error)) (crypto.PublicKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPrivateKey", ctx, keyType, namingFunc)
+	ret0, _ := ret[0].(crypto.PublicKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewPrivateKey indicates an expected call of NewPrivateKey.
+func (mr *MockKeyGeneratorMockRecorder) NewPrivateKey(ctx, keyType, namingFunc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPrivateKey", reflect.TypeOf((*MockKeyGenerator)(nil).NewPrivateKey), ctx, keyType, namingFunc)
+}
@@ -23,6 +23,7 @@ import (
 	"crypto"
 	"fmt"
 	"github.com/nuts-foundation/nuts-node/core"
+	"io"
 	"regexp"
 )
 
@@ -41,13 +42,42 @@ func (w wrapper) CheckHealth() map[string]core.Health {
 	return w.wrappedBackend.CheckHealth()
 }
 
+// Close closes the wrapped backend, if it implements io.Closer.
+func (w wrapper) Close() error {
+	if closer, ok := w.wrappedBackend.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // NewValidatedKIDBackendWrapper creates a new wrapper for storage backends.
 // Every call to the backend which takes a kid as param, gets the kid validated against the provided kidPattern.
+// If the backend implements KeyGenerator, so does the returned wrapper.
 func NewValidatedKIDBackendWrapper(backend Storage, kidPattern *regexp.Regexp) Storage {
-	return wrapper{
+	result := wrapper{
 		kidPattern:     kidPattern,
 		wrappedBackend: backend,
 	}
+	if generator, ok := backend.(KeyGenerator); ok {
+		return generatorWrapper{wrapper: result, generator: generator}
+	}
+	return result
+}
+
+// generatorWrapper is a wrapper for storage backends that implement KeyGenerator.
+type generatorWrapper struct {
+	wrapper
+	generator KeyGenerator
+}
+
+func (w generatorWrapper) NewPrivateKey(ctx context.Context, keyType string, namingFunc func(crypto.PublicKey) (string, error)) (crypto.PublicKey, string, error) {
+	return w.generator.NewPrivateKey(ctx, keyType, func(publicKey crypto.PublicKey) (string, error) {
+		kid, err := namingFunc(publicKey)
+		if err != nil {
+			return "", err
+		}
+		return kid, w.validateKID(kid)
+	})
 }
 
 func (w wrapper) validateKID(kid string) error {
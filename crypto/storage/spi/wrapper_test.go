@@ -20,8 +20,12 @@ package spi
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"io"
 	"testing"
 )
 
@@ -160,3 +164,81 @@ func TestWrapper_ListPrivateKeys(t *testing.T) {
 		ctrl.Finish()
 	})
 }
+
+func TestWrapper_NewPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	publicKey := &ecdsa.PublicKey{}
+	type generatingStorage struct {
+		*MockStorage
+		*MockKeyGenerator
+	}
+	t.Run("not supported by backend", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		w := NewValidatedKIDBackendWrapper(NewMockStorage(ctrl), KidPattern)
+
+		_, ok := w.(KeyGenerator)
+		assert.False(t, ok)
+	})
+	t.Run("expect call to wrapped backend for good KIDs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		generator := NewMockKeyGenerator(ctrl)
+		generator.EXPECT().NewPrivateKey(ctx, "P-256", gomock.Any()).DoAndReturn(func(_ context.Context, namingFunc func(crypto.PublicKey) (string, error)) (crypto.PublicKey, string, error) {
+			kid, err := namingFunc(publicKey)
+			return publicKey, kid, err
+		})
+		w := NewValidatedKIDBackendWrapper(generatingStorage{NewMockStorage(ctrl), generator}, KidPattern)
+
+		result, kid, err := w.(KeyGenerator).NewPrivateKey(ctx, "P-256", func(crypto.PublicKey) (string, error) {
+			return goodKIDs[0], nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, goodKIDs[0], kid)
+		assert.Same(t, publicKey, result)
+	})
+	t.Run("expect error for bad KIDs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		generator := NewMockKeyGenerator(ctrl)
+		generator.EXPECT().NewPrivateKey(ctx, "P-256", gomock.Any()).DoAndReturn(func(_ context.Context, namingFunc func(crypto.PublicKey) (string, error)) (crypto.PublicKey, string, error) {
+			_, err := namingFunc(publicKey)
+			return nil, "", err
+		})
+		w := NewValidatedKIDBackendWrapper(generatingStorage{NewMockStorage(ctrl), generator}, KidPattern)
+
+		_, _, err := w.(KeyGenerator).NewPrivateKey(ctx, "P-256", func(crypto.PublicKey) (string, error) {
+			return badKIDs[0], nil
+		})
+
+		assert.EqualError(t, err, "invalid key ID: ../server-certificate")
+	})
+}
+
+func TestWrapper_Close(t *testing.T) {
+	t.Run("closes wrapped backend", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		backend := &closingStorage{MockStorage: NewMockStorage(ctrl)}
+		w := NewValidatedKIDBackendWrapper(backend, KidPattern)
+
+		err := w.(io.Closer).Close()
+
+		require.NoError(t, err)
+		assert.True(t, backend.closed)
+	})
+	t.Run("wrapped backend can't be closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		w := NewValidatedKIDBackendWrapper(NewMockStorage(ctrl), KidPattern)
+
+		assert.NoError(t, w.(io.Closer).Close())
+	})
+}
+
+type closingStorage struct {
+	*MockStorage
+	closed bool
+}
+
+func (c *closingStorage) Close() error {
+	c.closed = true
+	return nil
+}
@@ -20,6 +20,7 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/labstack/echo/v4"
 	"github.com/nuts-foundation/go-did/did"
@@ -37,8 +38,10 @@ var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
 const requestQueryContextKey = "request.url.query"
 
 type Wrapper struct {
-	Server discovery.Server
-	Client discovery.Client
+	Server      discovery.Server
+	Client      discovery.Client
+	Definitions discovery.DefinitionManager
+	Moderator   discovery.Moderator
 }
 
 func (w *Wrapper) ResolveStatusCode(err error) int {
@@ -47,6 +50,16 @@ func (w *Wrapper) ResolveStatusCode(err error) int {
 		return http.StatusBadRequest
 	case errors.Is(err, discovery.ErrInvalidPresentation):
 		return http.StatusBadRequest
+	case errors.Is(err, discovery.ErrInvalidServiceDefinition):
+		return http.StatusBadRequest
+	case errors.Is(err, discovery.ErrServiceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, discovery.ErrPresentationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, discovery.ErrNotBlocked):
+		return http.StatusNotFound
+	case errors.Is(err, discovery.ErrServiceDefinitionReadOnly):
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
@@ -81,13 +94,14 @@ func (w *Wrapper) GetPresentations(_ context.Context, request GetPresentationsRe
 		tag = new(discovery.Tag)
 		*tag = discovery.Tag(*request.Params.Tag)
 	}
-	presentations, newTag, err := w.Server.Get(request.ServiceID, tag)
+	presentations, newTag, complete, err := w.Server.Get(request.ServiceID, tag)
 	if err != nil {
 		return nil, err
 	}
 	return GetPresentations200JSONResponse{
-		Entries: presentations,
-		Tag:     string(*newTag),
+		Entries:  presentations,
+		Tag:      string(*newTag),
+		Complete: complete,
 	}, nil
 }
 
@@ -168,6 +182,78 @@ func (w *Wrapper) GetServices(_ context.Context, _ GetServicesRequestObject) (Ge
 	return &result, nil
 }
 
+func (w *Wrapper) PutServiceDefinition(_ context.Context, request PutServiceDefinitionRequestObject) (PutServiceDefinitionResponseObject, error) {
+	if id, _ := request.Body.Definition["id"].(string); id != request.ServiceID {
+		return nil, core.InvalidInputError("service definition ID does not match service ID in path")
+	}
+	data, err := json.Marshal(request.Body.Definition)
+	if err != nil {
+		return nil, err
+	}
+	server := request.Body.Server != nil && *request.Body.Server
+	definition, err := w.Definitions.PutServiceDefinition(data, server)
+	if err != nil {
+		return nil, err
+	}
+	return PutServiceDefinition200JSONResponse(*definition), nil
+}
+
+func (w *Wrapper) DeleteServiceDefinition(_ context.Context, request DeleteServiceDefinitionRequestObject) (DeleteServiceDefinitionResponseObject, error) {
+	if err := w.Definitions.RemoveServiceDefinition(request.ServiceID); err != nil {
+		return nil, err
+	}
+	return DeleteServiceDefinition204Response{}, nil
+}
+
+func (w *Wrapper) RemovePresentations(ctx context.Context, request RemovePresentationsRequestObject) (RemovePresentationsResponseObject, error) {
+	if err := validateModerationRequest(*request.Body); err != nil {
+		return nil, err
+	}
+	if err := w.Moderator.RemovePresentations(ctx, request.ServiceID, request.Body.Subject, request.Body.Reason); err != nil {
+		return nil, err
+	}
+	return RemovePresentations204Response{}, nil
+}
+
+func (w *Wrapper) GetBlocks(_ context.Context, request GetBlocksRequestObject) (GetBlocksResponseObject, error) {
+	blocks, err := w.Moderator.Blocks(request.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	return GetBlocks200JSONResponse(blocks), nil
+}
+
+func (w *Wrapper) BlockSubject(ctx context.Context, request BlockSubjectRequestObject) (BlockSubjectResponseObject, error) {
+	if err := validateModerationRequest(*request.Body); err != nil {
+		return nil, err
+	}
+	if err := w.Moderator.Block(ctx, request.ServiceID, request.Body.Subject, request.Body.Reason); err != nil {
+		return nil, err
+	}
+	return BlockSubject204Response{}, nil
+}
+
+func (w *Wrapper) UnblockSubject(ctx context.Context, request UnblockSubjectRequestObject) (UnblockSubjectResponseObject, error) {
+	if err := validateModerationRequest(*request.Body); err != nil {
+		return nil, err
+	}
+	if err := w.Moderator.Unblock(ctx, request.ServiceID, request.Body.Subject, request.Body.Reason); err != nil {
+		return nil, err
+	}
+	return UnblockSubject204Response{}, nil
+}
+
+// validateModerationRequest checks that the subject and reason of a moderation action are given, since the reason is audited.
+func validateModerationRequest(request ModerationRequest) error {
+	if request.Subject == "" {
+		return core.InvalidInputError("subject is required")
+	}
+	if request.Reason == "" {
+		return core.InvalidInputError("reason is required")
+	}
+	return nil
+}
+
 func (w *Wrapper) GetServiceActivation(ctx context.Context, request GetServiceActivationRequestObject) (GetServiceActivationResponseObject, error) {
 	subjectDID, err := did.ParseDID(request.Did)
 	if err != nil {
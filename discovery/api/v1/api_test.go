@@ -34,6 +34,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 )
 
 const serviceID = "wonderland"
@@ -45,7 +46,7 @@ func TestWrapper_GetPresentations(t *testing.T) {
 		latestTag := discovery.Tag("latest")
 		test := newMockContext(t)
 		presentations := []vc.VerifiablePresentation{}
-		test.server.EXPECT().Get(serviceID, nil).Return(presentations, &latestTag, nil)
+		test.server.EXPECT().Get(serviceID, nil).Return(presentations, &latestTag, true, nil)
 
 		response, err := test.wrapper.GetPresentations(nil, GetPresentationsRequestObject{ServiceID: serviceID})
 
@@ -53,13 +54,14 @@ func TestWrapper_GetPresentations(t *testing.T) {
 		require.IsType(t, GetPresentations200JSONResponse{}, response)
 		assert.Equal(t, latestTag, discovery.Tag(response.(GetPresentations200JSONResponse).Tag))
 		assert.Equal(t, presentations, response.(GetPresentations200JSONResponse).Entries)
+		assert.True(t, response.(GetPresentations200JSONResponse).Complete)
 	})
 	t.Run("with tag", func(t *testing.T) {
 		givenTag := discovery.Tag("given")
 		latestTag := discovery.Tag("latest")
 		test := newMockContext(t)
 		presentations := []vc.VerifiablePresentation{}
-		test.server.EXPECT().Get(serviceID, &givenTag).Return(presentations, &latestTag, nil)
+		test.server.EXPECT().Get(serviceID, &givenTag).Return(presentations, &latestTag, false, nil)
 
 		response, err := test.wrapper.GetPresentations(nil, GetPresentationsRequestObject{
 			ServiceID: serviceID,
@@ -75,7 +77,7 @@ func TestWrapper_GetPresentations(t *testing.T) {
 	})
 	t.Run("error", func(t *testing.T) {
 		test := newMockContext(t)
-		test.server.EXPECT().Get(serviceID, nil).Return(nil, nil, errors.New("foo"))
+		test.server.EXPECT().Get(serviceID, nil).Return(nil, nil, false, errors.New("foo"))
 
 		_, err := test.wrapper.GetPresentations(nil, GetPresentationsRequestObject{ServiceID: serviceID})
 
@@ -181,9 +183,14 @@ func TestWrapper_DeactivateServiceForDID(t *testing.T) {
 
 func TestWrapper_ResolveStatusCode(t *testing.T) {
 	expected := map[error]int{
-		discovery.ErrServerModeDisabled:  http.StatusBadRequest,
-		discovery.ErrInvalidPresentation: http.StatusBadRequest,
-		errors.New("foo"):                http.StatusInternalServerError,
+		discovery.ErrServerModeDisabled:        http.StatusBadRequest,
+		discovery.ErrInvalidPresentation:       http.StatusBadRequest,
+		discovery.ErrInvalidServiceDefinition:  http.StatusBadRequest,
+		discovery.ErrServiceNotFound:           http.StatusNotFound,
+		discovery.ErrServiceDefinitionReadOnly: http.StatusConflict,
+		discovery.ErrPresentationNotFound:      http.StatusNotFound,
+		discovery.ErrNotBlocked:                http.StatusNotFound,
+		errors.New("foo"):                      http.StatusInternalServerError,
 	}
 	wrapper := Wrapper{}
 	for err, expectedCode := range expected {
@@ -284,21 +291,224 @@ func TestWrapper_GetServiceActivation(t *testing.T) {
 	})
 }
 
+func TestWrapper_PutServiceDefinition(t *testing.T) {
+	definition := map[string]interface{}{
+		"id":       serviceID,
+		"endpoint": "https://example.com/discovery",
+	}
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		server := true
+		test.definitions.EXPECT().PutServiceDefinition(gomock.Any(), true).DoAndReturn(func(data []byte, _ bool) (*discovery.ServiceDefinition, error) {
+			assert.JSONEq(t, `{"id":"wonderland","endpoint":"https://example.com/discovery"}`, string(data))
+			return &discovery.ServiceDefinition{ID: serviceID}, nil
+		})
+
+		response, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+			Body:      &PutServiceDefinitionJSONRequestBody{Definition: definition, Server: &server},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, serviceID, response.(PutServiceDefinition200JSONResponse).ID)
+	})
+	t.Run("client by default", func(t *testing.T) {
+		test := newMockContext(t)
+		test.definitions.EXPECT().PutServiceDefinition(gomock.Any(), false).Return(&discovery.ServiceDefinition{ID: serviceID}, nil)
+
+		_, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+			Body:      &PutServiceDefinitionJSONRequestBody{Definition: definition},
+		})
+
+		require.NoError(t, err)
+	})
+	t.Run("ID does not match path", func(t *testing.T) {
+		test := newMockContext(t)
+
+		_, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: "other",
+			Body:      &PutServiceDefinitionJSONRequestBody{Definition: definition},
+		})
+
+		assert.EqualError(t, err, "service definition ID does not match service ID in path")
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.definitions.EXPECT().PutServiceDefinition(gomock.Any(), false).Return(nil, discovery.ErrInvalidServiceDefinition)
+
+		_, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+			Body:      &PutServiceDefinitionJSONRequestBody{Definition: definition},
+		})
+
+		assert.ErrorIs(t, err, discovery.ErrInvalidServiceDefinition)
+	})
+}
+
+func TestWrapper_DeleteServiceDefinition(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.definitions.EXPECT().RemoveServiceDefinition(serviceID).Return(nil)
+
+		response, err := test.wrapper.DeleteServiceDefinition(nil, DeleteServiceDefinitionRequestObject{ServiceID: serviceID})
+
+		require.NoError(t, err)
+		assert.IsType(t, DeleteServiceDefinition204Response{}, response)
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.definitions.EXPECT().RemoveServiceDefinition(serviceID).Return(discovery.ErrServiceNotFound)
+
+		_, err := test.wrapper.DeleteServiceDefinition(nil, DeleteServiceDefinitionRequestObject{ServiceID: serviceID})
+
+		assert.ErrorIs(t, err, discovery.ErrServiceNotFound)
+	})
+}
+
+func TestWrapper_RemovePresentations(t *testing.T) {
+	ctx := audit.TestContext()
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.moderator.EXPECT().RemovePresentations(ctx, serviceID, subjectDID.String(), "spam").Return(nil)
+
+		response, err := test.wrapper.RemovePresentations(ctx, RemovePresentationsRequestObject{
+			ServiceID: serviceID,
+			Body:      &RemovePresentationsJSONRequestBody{Subject: subjectDID.String(), Reason: "spam"},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, RemovePresentations204Response{}, response)
+	})
+	t.Run("missing reason", func(t *testing.T) {
+		test := newMockContext(t)
+
+		_, err := test.wrapper.RemovePresentations(ctx, RemovePresentationsRequestObject{
+			ServiceID: serviceID,
+			Body:      &RemovePresentationsJSONRequestBody{Subject: subjectDID.String()},
+		})
+
+		assert.EqualError(t, err, "reason is required")
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.moderator.EXPECT().RemovePresentations(ctx, serviceID, subjectDID.String(), "spam").Return(discovery.ErrPresentationNotFound)
+
+		_, err := test.wrapper.RemovePresentations(ctx, RemovePresentationsRequestObject{
+			ServiceID: serviceID,
+			Body:      &RemovePresentationsJSONRequestBody{Subject: subjectDID.String(), Reason: "spam"},
+		})
+
+		assert.ErrorIs(t, err, discovery.ErrPresentationNotFound)
+	})
+}
+
+func TestWrapper_BlockSubject(t *testing.T) {
+	ctx := audit.TestContext()
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.moderator.EXPECT().Block(ctx, serviceID, subjectDID.String(), "spam").Return(nil)
+
+		response, err := test.wrapper.BlockSubject(ctx, BlockSubjectRequestObject{
+			ServiceID: serviceID,
+			Body:      &BlockSubjectJSONRequestBody{Subject: subjectDID.String(), Reason: "spam"},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, BlockSubject204Response{}, response)
+	})
+	t.Run("missing subject", func(t *testing.T) {
+		test := newMockContext(t)
+
+		_, err := test.wrapper.BlockSubject(ctx, BlockSubjectRequestObject{
+			ServiceID: serviceID,
+			Body:      &BlockSubjectJSONRequestBody{Reason: "spam"},
+		})
+
+		assert.EqualError(t, err, "subject is required")
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.moderator.EXPECT().Block(ctx, serviceID, subjectDID.String(), "spam").Return(discovery.ErrServerModeDisabled)
+
+		_, err := test.wrapper.BlockSubject(ctx, BlockSubjectRequestObject{
+			ServiceID: serviceID,
+			Body:      &BlockSubjectJSONRequestBody{Subject: subjectDID.String(), Reason: "spam"},
+		})
+
+		assert.ErrorIs(t, err, discovery.ErrServerModeDisabled)
+	})
+}
+
+func TestWrapper_UnblockSubject(t *testing.T) {
+	ctx := audit.TestContext()
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.moderator.EXPECT().Unblock(ctx, serviceID, subjectDID.String(), "resolved").Return(nil)
+
+		response, err := test.wrapper.UnblockSubject(ctx, UnblockSubjectRequestObject{
+			ServiceID: serviceID,
+			Body:      &UnblockSubjectJSONRequestBody{Subject: subjectDID.String(), Reason: "resolved"},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, UnblockSubject204Response{}, response)
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.moderator.EXPECT().Unblock(ctx, serviceID, subjectDID.String(), "resolved").Return(discovery.ErrNotBlocked)
+
+		_, err := test.wrapper.UnblockSubject(ctx, UnblockSubjectRequestObject{
+			ServiceID: serviceID,
+			Body:      &UnblockSubjectJSONRequestBody{Subject: subjectDID.String(), Reason: "resolved"},
+		})
+
+		assert.ErrorIs(t, err, discovery.ErrNotBlocked)
+	})
+}
+
+func TestWrapper_GetBlocks(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		blocks := []discovery.Block{{Subject: subjectDID.String(), Reason: "spam", Actor: "admin", CreatedAt: time.Now()}}
+		test.moderator.EXPECT().Blocks(serviceID).Return(blocks, nil)
+
+		response, err := test.wrapper.GetBlocks(nil, GetBlocksRequestObject{ServiceID: serviceID})
+
+		require.NoError(t, err)
+		assert.Equal(t, GetBlocks200JSONResponse(blocks), response)
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.moderator.EXPECT().Blocks(serviceID).Return(nil, discovery.ErrServerModeDisabled)
+
+		_, err := test.wrapper.GetBlocks(nil, GetBlocksRequestObject{ServiceID: serviceID})
+
+		assert.ErrorIs(t, err, discovery.ErrServerModeDisabled)
+	})
+}
+
 type mockContext struct {
-	ctrl    *gomock.Controller
-	server  *discovery.MockServer
-	client  *discovery.MockClient
-	wrapper Wrapper
+	ctrl        *gomock.Controller
+	server      *discovery.MockServer
+	client      *discovery.MockClient
+	definitions *discovery.MockDefinitionManager
+	moderator   *discovery.MockModerator
+	wrapper     Wrapper
 }
 
 func newMockContext(t *testing.T) mockContext {
 	ctrl := gomock.NewController(t)
 	server := discovery.NewMockServer(ctrl)
 	client := discovery.NewMockClient(ctrl)
+	definitions := discovery.NewMockDefinitionManager(ctrl)
+	moderator := discovery.NewMockModerator(ctrl)
 	return mockContext{
-		ctrl:    ctrl,
-		server:  server,
-		client:  client,
-		wrapper: Wrapper{Server: server, Client: client},
+		ctrl:        ctrl,
+		server:      server,
+		client:      client,
+		definitions: definitions,
+		moderator:   moderator,
+		wrapper:     Wrapper{Server: server, Client: client, Definitions: definitions, Moderator: moderator},
 	}
 }
@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"context"
+	"github.com/nuts-foundation/nuts-node/core"
+	"net/http"
+)
+
+// HTTPClient holds the server address and other basic settings for the http client
+type HTTPClient struct {
+	core.ClientConfig
+	TokenGenerator core.AuthorizationTokenGenerator
+}
+
+func (hb HTTPClient) client() ClientInterface {
+	response, err := NewClientWithResponses(hb.GetAddress(), WithHTTPClient(core.MustCreateHTTPClient(hb.ClientConfig, hb.TokenGenerator)))
+	if err != nil {
+		panic(err)
+	}
+	return response
+}
+
+// GetServices returns the Discovery Services known to the node.
+func (hb HTTPClient) GetServices() ([]ServiceDefinition, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().GetServices(ctx)
+	if err != nil {
+		return nil, err
+	} else if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	parsedResponse, err := ParseGetServicesResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return *parsedResponse.JSON200, nil
+}
+
+// PutServiceDefinition adds or updates the given Discovery Service definition.
+// If server is true, the node acts as Discovery Server for the service.
+func (hb HTTPClient) PutServiceDefinition(definition map[string]interface{}, server bool) (*ServiceDefinition, error) {
+	ctx := context.Background()
+
+	serviceID, _ := definition["id"].(string)
+	response, err := hb.client().PutServiceDefinition(ctx, serviceID, PutServiceDefinitionJSONRequestBody{
+		Definition: definition,
+		Server:     &server,
+	})
+	if err != nil {
+		return nil, err
+	} else if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	parsedResponse, err := ParsePutServiceDefinitionResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return parsedResponse.JSON200, nil
+}
+
+// DeleteServiceDefinition removes the Discovery Service definition with the given ID.
+func (hb HTTPClient) DeleteServiceDefinition(serviceID string) error {
+	ctx := context.Background()
+
+	response, err := hb.client().DeleteServiceDefinition(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
+// RemovePresentations removes the presentations of the given subject (DID or presentation ID) from the Discovery Service.
+func (hb HTTPClient) RemovePresentations(serviceID string, subject string, reason string) error {
+	ctx := context.Background()
+
+	response, err := hb.client().RemovePresentations(ctx, serviceID, RemovePresentationsJSONRequestBody{
+		Subject: subject,
+		Reason:  reason,
+	})
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
+// BlockSubject blocks the given subject (DID or presentation ID) from registering on the Discovery Service.
+func (hb HTTPClient) BlockSubject(serviceID string, subject string, reason string) error {
+	ctx := context.Background()
+
+	response, err := hb.client().BlockSubject(ctx, serviceID, BlockSubjectJSONRequestBody{
+		Subject: subject,
+		Reason:  reason,
+	})
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
+// UnblockSubject lifts the block of the given subject on the Discovery Service.
+func (hb HTTPClient) UnblockSubject(serviceID string, subject string, reason string) error {
+	ctx := context.Background()
+
+	response, err := hb.client().UnblockSubject(ctx, serviceID, UnblockSubjectJSONRequestBody{
+		Subject: subject,
+		Reason:  reason,
+	})
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
+// GetBlocks returns the subjects that are blocked on the Discovery Service.
+func (hb HTTPClient) GetBlocks(serviceID string) ([]Block, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().GetBlocks(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	} else if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	parsedResponse, err := ParseGetBlocksResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return *parsedResponse.JSON200, nil
+}
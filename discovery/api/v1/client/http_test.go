@@ -72,12 +72,13 @@ func TestHTTPInvoker_Get(t *testing.T) {
 		server := httptest.NewServer(handler)
 		client := New(false, time.Minute, server.TLS)
 
-		presentations, tag, err := client.Get(context.Background(), server.URL, "")
+		presentations, tag, complete, err := client.Get(context.Background(), server.URL, "")
 
 		assert.NoError(t, err)
 		assert.Len(t, presentations, 1)
 		assert.Empty(t, handler.RequestQuery.Get("tag"))
 		assert.Equal(t, serverTag, tag)
+		assert.False(t, complete)
 	})
 	t.Run("tag provided by client", func(t *testing.T) {
 		handler := &testHTTP.Handler{StatusCode: http.StatusOK}
@@ -88,19 +89,35 @@ func TestHTTPInvoker_Get(t *testing.T) {
 		server := httptest.NewServer(handler)
 		client := New(false, time.Minute, server.TLS)
 
-		presentations, tag, err := client.Get(context.Background(), server.URL, clientTag)
+		presentations, tag, _, err := client.Get(context.Background(), server.URL, clientTag)
 
 		assert.NoError(t, err)
 		assert.Len(t, presentations, 1)
 		assert.Equal(t, clientTag, handler.RequestQuery.Get("tag"))
 		assert.Equal(t, serverTag, tag)
 	})
+	t.Run("server returns complete list", func(t *testing.T) {
+		handler := &testHTTP.Handler{StatusCode: http.StatusOK}
+		handler.ResponseData = map[string]interface{}{
+			"entries":  []interface{}{vp},
+			"tag":      serverTag,
+			"complete": true,
+		}
+		server := httptest.NewServer(handler)
+		client := New(false, time.Minute, server.TLS)
+
+		presentations, _, complete, err := client.Get(context.Background(), server.URL, clientTag)
+
+		assert.NoError(t, err)
+		assert.Len(t, presentations, 1)
+		assert.True(t, complete)
+	})
 	t.Run("server returns invalid status code", func(t *testing.T) {
 		handler := &testHTTP.Handler{StatusCode: http.StatusInternalServerError}
 		server := httptest.NewServer(handler)
 		client := New(false, time.Minute, server.TLS)
 
-		_, _, err := client.Get(context.Background(), server.URL, "")
+		_, _, _, err := client.Get(context.Background(), server.URL, "")
 
 		assert.ErrorContains(t, err, "non-OK response from remote Discovery Service")
 	})
@@ -110,7 +127,7 @@ func TestHTTPInvoker_Get(t *testing.T) {
 		server := httptest.NewServer(handler)
 		client := New(false, time.Minute, server.TLS)
 
-		_, _, err := client.Get(context.Background(), server.URL, "")
+		_, _, _, err := client.Get(context.Background(), server.URL, "")
 
 		assert.ErrorContains(t, err, "failed to unmarshal response from remote Discovery Service")
 	})
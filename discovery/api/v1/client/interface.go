@@ -31,5 +31,7 @@ type HTTPClient interface {
 	// Get retrieves Verifiable Presentations from the remote Discovery Service, that were added since the given tag.
 	// If the call succeeds it returns the Verifiable Presentations and the tag that was returned by the server.
 	// If tag is empty, all Verifiable Presentations are retrieved.
-	Get(ctx context.Context, serviceEndpointURL string, tag string) ([]vc.VerifiablePresentation, string, error)
+	// The boolean indicates whether the server returned the complete list of Verifiable Presentations,
+	// in which case the client should replace its copy of the list.
+	Get(ctx context.Context, serviceEndpointURL string, tag string) ([]vc.VerifiablePresentation, string, bool, error)
 }
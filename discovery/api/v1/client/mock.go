@@ -41,13 +41,14 @@ func (m *MockHTTPClient) EXPECT() *MockHTTPClientMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockHTTPClient) Get(ctx context.Context, serviceEndpointURL, tag string) ([]vc.VerifiablePresentation, string, error) {
+func (m *MockHTTPClient) Get(ctx context.Context, serviceEndpointURL, tag string) ([]vc.VerifiablePresentation, string, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", ctx, serviceEndpointURL, tag)
 	ret0, _ := ret[0].([]vc.VerifiablePresentation)
 	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // Get indicates an expected call of Get.
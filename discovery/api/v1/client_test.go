@@ -0,0 +1,158 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"github.com/nuts-foundation/nuts-node/core"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_GetServices(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: []ServiceDefinition{{ID: "usecase"}}})
+		c := getClient(s)
+
+		services, err := c.GetServices()
+
+		require.NoError(t, err)
+		require.Len(t, services, 1)
+		assert.Equal(t, "usecase", services[0].ID)
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: ""})
+		c := getClient(s)
+
+		services, err := c.GetServices()
+
+		assert.EqualError(t, err, "server returned HTTP 500 (expected: 200)")
+		assert.Nil(t, services)
+	})
+}
+
+func TestHTTPClient_PutServiceDefinition(t *testing.T) {
+	definition := map[string]interface{}{"id": "usecase"}
+	t.Run("ok", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusOK, ResponseData: ServiceDefinition{ID: "usecase"}}
+		s := httptest.NewServer(handler)
+		c := getClient(s)
+
+		result, err := c.PutServiceDefinition(definition, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, "usecase", result.ID)
+		assert.Equal(t, "/internal/discovery/v1/usecase", handler.Request.URL.Path)
+		assert.JSONEq(t, `{"definition":{"id":"usecase"},"server":true}`, string(handler.RequestData))
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: ""})
+		c := getClient(s)
+
+		result, err := c.PutServiceDefinition(definition, false)
+
+		assert.EqualError(t, err, "server returned HTTP 400 (expected: 200)")
+		assert.Nil(t, result)
+	})
+}
+
+func TestHTTPClient_DeleteServiceDefinition(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNoContent})
+		c := getClient(s)
+
+		err := c.DeleteServiceDefinition("usecase")
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNotFound, ResponseData: ""})
+		c := getClient(s)
+
+		err := c.DeleteServiceDefinition("usecase")
+
+		assert.EqualError(t, err, "server returned HTTP 404 (expected: 204)")
+	})
+}
+
+func TestHTTPClient_Moderation(t *testing.T) {
+	t.Run("remove presentations", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusNoContent}
+		s := httptest.NewServer(handler)
+		c := getClient(s)
+
+		err := c.RemovePresentations("usecase", "did:web:example.com", "spam")
+
+		require.NoError(t, err)
+		assert.Equal(t, "/internal/discovery/v1/usecase/moderation/remove", handler.Request.URL.Path)
+		assert.JSONEq(t, `{"subject":"did:web:example.com","reason":"spam"}`, string(handler.RequestData))
+	})
+	t.Run("block subject", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusNoContent}
+		s := httptest.NewServer(handler)
+		c := getClient(s)
+
+		err := c.BlockSubject("usecase", "did:web:example.com", "spam")
+
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, handler.Request.Method)
+		assert.Equal(t, "/internal/discovery/v1/usecase/moderation/block", handler.Request.URL.Path)
+		assert.JSONEq(t, `{"subject":"did:web:example.com","reason":"spam"}`, string(handler.RequestData))
+	})
+	t.Run("unblock subject", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusNoContent}
+		s := httptest.NewServer(handler)
+		c := getClient(s)
+
+		err := c.UnblockSubject("usecase", "did:web:example.com", "resolved")
+
+		require.NoError(t, err)
+		assert.Equal(t, "/internal/discovery/v1/usecase/moderation/unblock", handler.Request.URL.Path)
+	})
+	t.Run("get blocks", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusOK, ResponseData: []Block{{Subject: "did:web:example.com", Reason: "spam"}}}
+		s := httptest.NewServer(handler)
+		c := getClient(s)
+
+		blocks, err := c.GetBlocks("usecase")
+
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, "did:web:example.com", blocks[0].Subject)
+	})
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNotFound, ResponseData: ""})
+		c := getClient(s)
+
+		assert.EqualError(t, c.RemovePresentations("usecase", "did:web:example.com", "spam"), "server returned HTTP 404 (expected: 204)")
+		assert.EqualError(t, c.BlockSubject("usecase", "did:web:example.com", "spam"), "server returned HTTP 404 (expected: 204)")
+		assert.EqualError(t, c.UnblockSubject("usecase", "did:web:example.com", "spam"), "server returned HTTP 404 (expected: 204)")
+		blocks, err := c.GetBlocks("usecase")
+		assert.EqualError(t, err, "server returned HTTP 404 (expected: 200)")
+		assert.Nil(t, blocks)
+	})
+}
+
+func getClient(s *httptest.Server) HTTPClient {
+	return HTTPClient{ClientConfig: core.ClientConfig{Address: s.URL, Timeout: time.Second}}
+}
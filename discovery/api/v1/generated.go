@@ -22,6 +22,15 @@ const (
 	JwtBearerAuthScopes = "jwtBearerAuth.Scopes"
 )
 
+// ModerationRequest defines model for ModerationRequest.
+type ModerationRequest struct {
+	// Reason The reason for the moderation action, which is recorded in the audit log.
+	Reason string `json:"reason"`
+
+	// Subject The DID or the ID of the presentation to moderate.
+	Subject string `json:"subject"`
+}
+
 // SearchResult defines model for SearchResult.
 type SearchResult struct {
 	// Fields Input descriptor IDs and their mapped values that from the Verifiable Credential.
@@ -37,6 +46,15 @@ type SearchResult struct {
 	Vp VerifiablePresentation `json:"vp"`
 }
 
+// ServiceDefinitionRequest defines model for ServiceDefinitionRequest.
+type ServiceDefinitionRequest struct {
+	// Definition The service definition, which must conform to the service definition JSON schema. Its ID must match the service ID in the path.
+	Definition map[string]interface{} `json:"definition"`
+
+	// Server Whether the node acts as Discovery Server for the service. Defaults to false.
+	Server *bool `json:"server,omitempty"`
+}
+
 // GetPresentationsParams defines parameters for GetPresentations.
 type GetPresentationsParams struct {
 	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
@@ -50,6 +68,18 @@ type SearchPresentationsParams struct {
 // RegisterPresentationJSONRequestBody defines body for RegisterPresentation for application/json ContentType.
 type RegisterPresentationJSONRequestBody = VerifiablePresentation
 
+// PutServiceDefinitionJSONRequestBody defines body for PutServiceDefinition for application/json ContentType.
+type PutServiceDefinitionJSONRequestBody = ServiceDefinitionRequest
+
+// BlockSubjectJSONRequestBody defines body for BlockSubject for application/json ContentType.
+type BlockSubjectJSONRequestBody = ModerationRequest
+
+// RemovePresentationsJSONRequestBody defines body for RemovePresentations for application/json ContentType.
+type RemovePresentationsJSONRequestBody = ModerationRequest
+
+// UnblockSubjectJSONRequestBody defines body for UnblockSubject for application/json ContentType.
+type UnblockSubjectJSONRequestBody = ModerationRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -134,9 +164,35 @@ type ClientInterface interface {
 	// GetServices request
 	GetServices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteServiceDefinition request
+	DeleteServiceDefinition(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// SearchPresentations request
 	SearchPresentations(ctx context.Context, serviceID string, params *SearchPresentationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PutServiceDefinitionWithBody request with any body
+	PutServiceDefinitionWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutServiceDefinition(ctx context.Context, serviceID string, body PutServiceDefinitionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBlocks request
+	GetBlocks(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BlockSubjectWithBody request with any body
+	BlockSubjectWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BlockSubject(ctx context.Context, serviceID string, body BlockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemovePresentationsWithBody request with any body
+	RemovePresentationsWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RemovePresentations(ctx context.Context, serviceID string, body RemovePresentationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnblockSubjectWithBody request with any body
+	UnblockSubjectWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UnblockSubject(ctx context.Context, serviceID string, body UnblockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeactivateServiceForDID request
 	DeactivateServiceForDID(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -195,6 +251,18 @@ func (c *Client) GetServices(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
+func (c *Client) DeleteServiceDefinition(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteServiceDefinitionRequest(c.Server, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) SearchPresentations(ctx context.Context, serviceID string, params *SearchPresentationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSearchPresentationsRequest(c.Server, serviceID, params)
 	if err != nil {
@@ -207,6 +275,114 @@ func (c *Client) SearchPresentations(ctx context.Context, serviceID string, para
 	return c.Client.Do(req)
 }
 
+func (c *Client) PutServiceDefinitionWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutServiceDefinitionRequestWithBody(c.Server, serviceID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutServiceDefinition(ctx context.Context, serviceID string, body PutServiceDefinitionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutServiceDefinitionRequest(c.Server, serviceID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBlocks(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBlocksRequest(c.Server, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BlockSubjectWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBlockSubjectRequestWithBody(c.Server, serviceID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BlockSubject(ctx context.Context, serviceID string, body BlockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBlockSubjectRequest(c.Server, serviceID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemovePresentationsWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemovePresentationsRequestWithBody(c.Server, serviceID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemovePresentations(ctx context.Context, serviceID string, body RemovePresentationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemovePresentationsRequest(c.Server, serviceID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnblockSubjectWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnblockSubjectRequestWithBody(c.Server, serviceID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnblockSubject(ctx context.Context, serviceID string, body UnblockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnblockSubjectRequest(c.Server, serviceID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) DeactivateServiceForDID(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeactivateServiceForDIDRequest(c.Server, serviceID, did)
 	if err != nil {
@@ -373,6 +549,40 @@ func NewGetServicesRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewDeleteServiceDefinitionRequest generates requests for DeleteServiceDefinition
+func NewDeleteServiceDefinitionRequest(server string, serviceID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewSearchPresentationsRequest generates requests for SearchPresentations
 func NewSearchPresentationsRequest(server string, serviceID string, params *SearchPresentationsParams) (*http.Request, error) {
 	var err error
@@ -429,20 +639,24 @@ func NewSearchPresentationsRequest(server string, serviceID string, params *Sear
 	return req, nil
 }
 
-// NewDeactivateServiceForDIDRequest generates requests for DeactivateServiceForDID
-func NewDeactivateServiceForDIDRequest(server string, serviceID string, did string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+// NewPutServiceDefinitionRequest calls the generic PutServiceDefinition builder with application/json body
+func NewPutServiceDefinitionRequest(server string, serviceID string, body PutServiceDefinitionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutServiceDefinitionRequestWithBody(server, serviceID, "application/json", bodyReader)
+}
 
-	var pathParam1 string
+// NewPutServiceDefinitionRequestWithBody generates requests for PutServiceDefinition with any type of body
+func NewPutServiceDefinitionRequestWithBody(server string, serviceID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
 	if err != nil {
 		return nil, err
 	}
@@ -452,7 +666,7 @@ func NewDeactivateServiceForDIDRequest(server string, serviceID string, did stri
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -462,16 +676,18 @@ func NewDeactivateServiceForDIDRequest(server string, serviceID string, did stri
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetServiceActivationRequest generates requests for GetServiceActivation
-func NewGetServiceActivationRequest(server string, serviceID string, did string) (*http.Request, error) {
+// NewGetBlocksRequest generates requests for GetBlocks
+func NewGetBlocksRequest(server string, serviceID string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -481,19 +697,12 @@ func NewGetServiceActivationRequest(server string, serviceID string, did string)
 		return nil, err
 	}
 
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/moderation/block", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -511,20 +720,24 @@ func NewGetServiceActivationRequest(server string, serviceID string, did string)
 	return req, nil
 }
 
-// NewActivateServiceForDIDRequest generates requests for ActivateServiceForDID
-func NewActivateServiceForDIDRequest(server string, serviceID string, did string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+// NewBlockSubjectRequest calls the generic BlockSubject builder with application/json body
+func NewBlockSubjectRequest(server string, serviceID string, body BlockSubjectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBlockSubjectRequestWithBody(server, serviceID, "application/json", bodyReader)
+}
 
-	var pathParam1 string
+// NewBlockSubjectRequestWithBody generates requests for BlockSubject with any type of body
+func NewBlockSubjectRequestWithBody(server string, serviceID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
 	if err != nil {
 		return nil, err
 	}
@@ -534,7 +747,7 @@ func NewActivateServiceForDIDRequest(server string, serviceID string, did string
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/moderation/block", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -544,61 +757,280 @@ func NewActivateServiceForDIDRequest(server string, serviceID string, did string
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewRemovePresentationsRequest calls the generic RemovePresentations builder with application/json body
+func NewRemovePresentationsRequest(server string, serviceID string, body RemovePresentationsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewRemovePresentationsRequestWithBody(server, serviceID, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewRemovePresentationsRequestWithBody generates requests for RemovePresentations with any type of body
+func NewRemovePresentationsRequestWithBody(server string, serviceID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
-
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetPresentationsWithResponse request
-	GetPresentationsWithResponse(ctx context.Context, serviceID string, params *GetPresentationsParams, reqEditors ...RequestEditorFn) (*GetPresentationsResponse, error)
 
-	// RegisterPresentationWithBodyWithResponse request with any body
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/moderation/remove", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnblockSubjectRequest calls the generic UnblockSubject builder with application/json body
+func NewUnblockSubjectRequest(server string, serviceID string, body UnblockSubjectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUnblockSubjectRequestWithBody(server, serviceID, "application/json", bodyReader)
+}
+
+// NewUnblockSubjectRequestWithBody generates requests for UnblockSubject with any type of body
+func NewUnblockSubjectRequestWithBody(server string, serviceID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/moderation/unblock", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeactivateServiceForDIDRequest generates requests for DeactivateServiceForDID
+func NewDeactivateServiceForDIDRequest(server string, serviceID string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetServiceActivationRequest generates requests for GetServiceActivation
+func NewGetServiceActivationRequest(server string, serviceID string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewActivateServiceForDIDRequest generates requests for ActivateServiceForDID
+func NewActivateServiceForDIDRequest(server string, serviceID string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/discovery/v1/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetPresentationsWithResponse request
+	GetPresentationsWithResponse(ctx context.Context, serviceID string, params *GetPresentationsParams, reqEditors ...RequestEditorFn) (*GetPresentationsResponse, error)
+
+	// RegisterPresentationWithBodyWithResponse request with any body
 	RegisterPresentationWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error)
 
 	RegisterPresentationWithResponse(ctx context.Context, serviceID string, body RegisterPresentationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error)
@@ -606,9 +1038,35 @@ type ClientWithResponsesInterface interface {
 	// GetServicesWithResponse request
 	GetServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetServicesResponse, error)
 
+	// DeleteServiceDefinitionWithResponse request
+	DeleteServiceDefinitionWithResponse(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*DeleteServiceDefinitionResponse, error)
+
 	// SearchPresentationsWithResponse request
 	SearchPresentationsWithResponse(ctx context.Context, serviceID string, params *SearchPresentationsParams, reqEditors ...RequestEditorFn) (*SearchPresentationsResponse, error)
 
+	// PutServiceDefinitionWithBodyWithResponse request with any body
+	PutServiceDefinitionWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutServiceDefinitionResponse, error)
+
+	PutServiceDefinitionWithResponse(ctx context.Context, serviceID string, body PutServiceDefinitionJSONRequestBody, reqEditors ...RequestEditorFn) (*PutServiceDefinitionResponse, error)
+
+	// GetBlocksWithResponse request
+	GetBlocksWithResponse(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*GetBlocksResponse, error)
+
+	// BlockSubjectWithBodyWithResponse request with any body
+	BlockSubjectWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BlockSubjectResponse, error)
+
+	BlockSubjectWithResponse(ctx context.Context, serviceID string, body BlockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*BlockSubjectResponse, error)
+
+	// RemovePresentationsWithBodyWithResponse request with any body
+	RemovePresentationsWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RemovePresentationsResponse, error)
+
+	RemovePresentationsWithResponse(ctx context.Context, serviceID string, body RemovePresentationsJSONRequestBody, reqEditors ...RequestEditorFn) (*RemovePresentationsResponse, error)
+
+	// UnblockSubjectWithBodyWithResponse request with any body
+	UnblockSubjectWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnblockSubjectResponse, error)
+
+	UnblockSubjectWithResponse(ctx context.Context, serviceID string, body UnblockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*UnblockSubjectResponse, error)
+
 	// DeactivateServiceForDIDWithResponse request
 	DeactivateServiceForDIDWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*DeactivateServiceForDIDResponse, error)
 
@@ -619,10 +1077,178 @@ type ClientWithResponsesInterface interface {
 	ActivateServiceForDIDWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*ActivateServiceForDIDResponse, error)
 }
 
-type GetPresentationsResponse struct {
+type GetPresentationsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *PresentationsResponse
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPresentationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPresentationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterPresentationResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterPresentationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterPresentationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetServicesResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]ServiceDefinition
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetServicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetServicesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteServiceDefinitionResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteServiceDefinitionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteServiceDefinitionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SearchPresentationsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]SearchResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchPresentationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchPresentationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutServiceDefinitionResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *PresentationsResponse
+	JSON200                       *ServiceDefinition
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -636,7 +1262,7 @@ type GetPresentationsResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r GetPresentationsResponse) Status() string {
+func (r PutServiceDefinitionResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -644,17 +1270,18 @@ func (r GetPresentationsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetPresentationsResponse) StatusCode() int {
+func (r PutServiceDefinitionResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type RegisterPresentationResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	ApplicationproblemJSON400 *struct {
+type GetBlocksResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Block
+	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
 
@@ -664,6 +1291,27 @@ type RegisterPresentationResponse struct {
 		// Title A short, human-readable summary of the problem type.
 		Title string `json:"title"`
 	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBlocksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBlocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BlockSubjectResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -677,7 +1325,7 @@ type RegisterPresentationResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r RegisterPresentationResponse) Status() string {
+func (r BlockSubjectResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -685,17 +1333,16 @@ func (r RegisterPresentationResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r RegisterPresentationResponse) StatusCode() int {
+func (r BlockSubjectResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetServicesResponse struct {
+type RemovePresentationsResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *[]ServiceDefinition
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -709,7 +1356,7 @@ type GetServicesResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r GetServicesResponse) Status() string {
+func (r RemovePresentationsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -717,17 +1364,16 @@ func (r GetServicesResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetServicesResponse) StatusCode() int {
+func (r RemovePresentationsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type SearchPresentationsResponse struct {
+type UnblockSubjectResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *[]SearchResult
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -741,7 +1387,7 @@ type SearchPresentationsResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r SearchPresentationsResponse) Status() string {
+func (r UnblockSubjectResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -749,7 +1395,7 @@ func (r SearchPresentationsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r SearchPresentationsResponse) StatusCode() int {
+func (r UnblockSubjectResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -884,93 +1530,391 @@ func (r ActivateServiceForDIDResponse) StatusCode() int {
 	return 0
 }
 
-// GetPresentationsWithResponse request returning *GetPresentationsResponse
-func (c *ClientWithResponses) GetPresentationsWithResponse(ctx context.Context, serviceID string, params *GetPresentationsParams, reqEditors ...RequestEditorFn) (*GetPresentationsResponse, error) {
-	rsp, err := c.GetPresentations(ctx, serviceID, params, reqEditors...)
+// GetPresentationsWithResponse request returning *GetPresentationsResponse
+func (c *ClientWithResponses) GetPresentationsWithResponse(ctx context.Context, serviceID string, params *GetPresentationsParams, reqEditors ...RequestEditorFn) (*GetPresentationsResponse, error) {
+	rsp, err := c.GetPresentations(ctx, serviceID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPresentationsResponse(rsp)
+}
+
+// RegisterPresentationWithBodyWithResponse request with arbitrary body returning *RegisterPresentationResponse
+func (c *ClientWithResponses) RegisterPresentationWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error) {
+	rsp, err := c.RegisterPresentationWithBody(ctx, serviceID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterPresentationResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterPresentationWithResponse(ctx context.Context, serviceID string, body RegisterPresentationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error) {
+	rsp, err := c.RegisterPresentation(ctx, serviceID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterPresentationResponse(rsp)
+}
+
+// GetServicesWithResponse request returning *GetServicesResponse
+func (c *ClientWithResponses) GetServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetServicesResponse, error) {
+	rsp, err := c.GetServices(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetServicesResponse(rsp)
+}
+
+// DeleteServiceDefinitionWithResponse request returning *DeleteServiceDefinitionResponse
+func (c *ClientWithResponses) DeleteServiceDefinitionWithResponse(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*DeleteServiceDefinitionResponse, error) {
+	rsp, err := c.DeleteServiceDefinition(ctx, serviceID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteServiceDefinitionResponse(rsp)
+}
+
+// SearchPresentationsWithResponse request returning *SearchPresentationsResponse
+func (c *ClientWithResponses) SearchPresentationsWithResponse(ctx context.Context, serviceID string, params *SearchPresentationsParams, reqEditors ...RequestEditorFn) (*SearchPresentationsResponse, error) {
+	rsp, err := c.SearchPresentations(ctx, serviceID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchPresentationsResponse(rsp)
+}
+
+// PutServiceDefinitionWithBodyWithResponse request with arbitrary body returning *PutServiceDefinitionResponse
+func (c *ClientWithResponses) PutServiceDefinitionWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutServiceDefinitionResponse, error) {
+	rsp, err := c.PutServiceDefinitionWithBody(ctx, serviceID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutServiceDefinitionResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutServiceDefinitionWithResponse(ctx context.Context, serviceID string, body PutServiceDefinitionJSONRequestBody, reqEditors ...RequestEditorFn) (*PutServiceDefinitionResponse, error) {
+	rsp, err := c.PutServiceDefinition(ctx, serviceID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutServiceDefinitionResponse(rsp)
+}
+
+// GetBlocksWithResponse request returning *GetBlocksResponse
+func (c *ClientWithResponses) GetBlocksWithResponse(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*GetBlocksResponse, error) {
+	rsp, err := c.GetBlocks(ctx, serviceID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBlocksResponse(rsp)
+}
+
+// BlockSubjectWithBodyWithResponse request with arbitrary body returning *BlockSubjectResponse
+func (c *ClientWithResponses) BlockSubjectWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BlockSubjectResponse, error) {
+	rsp, err := c.BlockSubjectWithBody(ctx, serviceID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBlockSubjectResponse(rsp)
+}
+
+func (c *ClientWithResponses) BlockSubjectWithResponse(ctx context.Context, serviceID string, body BlockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*BlockSubjectResponse, error) {
+	rsp, err := c.BlockSubject(ctx, serviceID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBlockSubjectResponse(rsp)
+}
+
+// RemovePresentationsWithBodyWithResponse request with arbitrary body returning *RemovePresentationsResponse
+func (c *ClientWithResponses) RemovePresentationsWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RemovePresentationsResponse, error) {
+	rsp, err := c.RemovePresentationsWithBody(ctx, serviceID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemovePresentationsResponse(rsp)
+}
+
+func (c *ClientWithResponses) RemovePresentationsWithResponse(ctx context.Context, serviceID string, body RemovePresentationsJSONRequestBody, reqEditors ...RequestEditorFn) (*RemovePresentationsResponse, error) {
+	rsp, err := c.RemovePresentations(ctx, serviceID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemovePresentationsResponse(rsp)
+}
+
+// UnblockSubjectWithBodyWithResponse request with arbitrary body returning *UnblockSubjectResponse
+func (c *ClientWithResponses) UnblockSubjectWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnblockSubjectResponse, error) {
+	rsp, err := c.UnblockSubjectWithBody(ctx, serviceID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnblockSubjectResponse(rsp)
+}
+
+func (c *ClientWithResponses) UnblockSubjectWithResponse(ctx context.Context, serviceID string, body UnblockSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*UnblockSubjectResponse, error) {
+	rsp, err := c.UnblockSubject(ctx, serviceID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnblockSubjectResponse(rsp)
+}
+
+// DeactivateServiceForDIDWithResponse request returning *DeactivateServiceForDIDResponse
+func (c *ClientWithResponses) DeactivateServiceForDIDWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*DeactivateServiceForDIDResponse, error) {
+	rsp, err := c.DeactivateServiceForDID(ctx, serviceID, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeactivateServiceForDIDResponse(rsp)
+}
+
+// GetServiceActivationWithResponse request returning *GetServiceActivationResponse
+func (c *ClientWithResponses) GetServiceActivationWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*GetServiceActivationResponse, error) {
+	rsp, err := c.GetServiceActivation(ctx, serviceID, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetServiceActivationResponse(rsp)
+}
+
+// ActivateServiceForDIDWithResponse request returning *ActivateServiceForDIDResponse
+func (c *ClientWithResponses) ActivateServiceForDIDWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*ActivateServiceForDIDResponse, error) {
+	rsp, err := c.ActivateServiceForDID(ctx, serviceID, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseActivateServiceForDIDResponse(rsp)
+}
+
+// ParseGetPresentationsResponse parses an HTTP response from a GetPresentationsWithResponse call
+func ParseGetPresentationsResponse(rsp *http.Response) (*GetPresentationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPresentationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PresentationsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterPresentationResponse parses an HTTP response from a RegisterPresentationWithResponse call
+func ParseRegisterPresentationResponse(rsp *http.Response) (*RegisterPresentationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterPresentationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetServicesResponse parses an HTTP response from a GetServicesWithResponse call
+func ParseGetServicesResponse(rsp *http.Response) (*GetServicesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetPresentationsResponse(rsp)
-}
 
-// RegisterPresentationWithBodyWithResponse request with arbitrary body returning *RegisterPresentationResponse
-func (c *ClientWithResponses) RegisterPresentationWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error) {
-	rsp, err := c.RegisterPresentationWithBody(ctx, serviceID, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetServicesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseRegisterPresentationResponse(rsp)
-}
 
-func (c *ClientWithResponses) RegisterPresentationWithResponse(ctx context.Context, serviceID string, body RegisterPresentationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error) {
-	rsp, err := c.RegisterPresentation(ctx, serviceID, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ServiceDefinition
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
 	}
-	return ParseRegisterPresentationResponse(rsp)
+
+	return response, nil
 }
 
-// GetServicesWithResponse request returning *GetServicesResponse
-func (c *ClientWithResponses) GetServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetServicesResponse, error) {
-	rsp, err := c.GetServices(ctx, reqEditors...)
+// ParseDeleteServiceDefinitionResponse parses an HTTP response from a DeleteServiceDefinitionWithResponse call
+func ParseDeleteServiceDefinitionResponse(rsp *http.Response) (*DeleteServiceDefinitionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetServicesResponse(rsp)
-}
 
-// SearchPresentationsWithResponse request returning *SearchPresentationsResponse
-func (c *ClientWithResponses) SearchPresentationsWithResponse(ctx context.Context, serviceID string, params *SearchPresentationsParams, reqEditors ...RequestEditorFn) (*SearchPresentationsResponse, error) {
-	rsp, err := c.SearchPresentations(ctx, serviceID, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteServiceDefinitionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseSearchPresentationsResponse(rsp)
-}
 
-// DeactivateServiceForDIDWithResponse request returning *DeactivateServiceForDIDResponse
-func (c *ClientWithResponses) DeactivateServiceForDIDWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*DeactivateServiceForDIDResponse, error) {
-	rsp, err := c.DeactivateServiceForDID(ctx, serviceID, did, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
 	}
-	return ParseDeactivateServiceForDIDResponse(rsp)
+
+	return response, nil
 }
 
-// GetServiceActivationWithResponse request returning *GetServiceActivationResponse
-func (c *ClientWithResponses) GetServiceActivationWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*GetServiceActivationResponse, error) {
-	rsp, err := c.GetServiceActivation(ctx, serviceID, did, reqEditors...)
+// ParseSearchPresentationsResponse parses an HTTP response from a SearchPresentationsWithResponse call
+func ParseSearchPresentationsResponse(rsp *http.Response) (*SearchPresentationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetServiceActivationResponse(rsp)
-}
 
-// ActivateServiceForDIDWithResponse request returning *ActivateServiceForDIDResponse
-func (c *ClientWithResponses) ActivateServiceForDIDWithResponse(ctx context.Context, serviceID string, did string, reqEditors ...RequestEditorFn) (*ActivateServiceForDIDResponse, error) {
-	rsp, err := c.ActivateServiceForDID(ctx, serviceID, did, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &SearchPresentationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseActivateServiceForDIDResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []SearchResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseGetPresentationsResponse parses an HTTP response from a GetPresentationsWithResponse call
-func ParseGetPresentationsResponse(rsp *http.Response) (*GetPresentationsResponse, error) {
+// ParsePutServiceDefinitionResponse parses an HTTP response from a PutServiceDefinitionWithResponse call
+func ParsePutServiceDefinitionResponse(rsp *http.Response) (*PutServiceDefinitionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetPresentationsResponse{
+	response := &PutServiceDefinitionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest PresentationsResponse
+		var dest ServiceDefinition
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -997,21 +1941,28 @@ func ParseGetPresentationsResponse(rsp *http.Response) (*GetPresentationsRespons
 	return response, nil
 }
 
-// ParseRegisterPresentationResponse parses an HTTP response from a RegisterPresentationWithResponse call
-func ParseRegisterPresentationResponse(rsp *http.Response) (*RegisterPresentationResponse, error) {
+// ParseGetBlocksResponse parses an HTTP response from a GetBlocksWithResponse call
+func ParseGetBlocksResponse(rsp *http.Response) (*GetBlocksResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RegisterPresentationResponse{
+	response := &GetBlocksResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Block
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
 			Detail string `json:"detail"`
@@ -1025,8 +1976,27 @@ func ParseRegisterPresentationResponse(rsp *http.Response) (*RegisterPresentatio
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.ApplicationproblemJSON400 = &dest
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBlockSubjectResponse parses an HTTP response from a BlockSubjectWithResponse call
+func ParseBlockSubjectResponse(rsp *http.Response) (*BlockSubjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BlockSubjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
+	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -1048,27 +2018,20 @@ func ParseRegisterPresentationResponse(rsp *http.Response) (*RegisterPresentatio
 	return response, nil
 }
 
-// ParseGetServicesResponse parses an HTTP response from a GetServicesWithResponse call
-func ParseGetServicesResponse(rsp *http.Response) (*GetServicesResponse, error) {
+// ParseRemovePresentationsResponse parses an HTTP response from a RemovePresentationsWithResponse call
+func ParseRemovePresentationsResponse(rsp *http.Response) (*RemovePresentationsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetServicesResponse{
+	response := &RemovePresentationsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ServiceDefinition
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -1090,27 +2053,20 @@ func ParseGetServicesResponse(rsp *http.Response) (*GetServicesResponse, error)
 	return response, nil
 }
 
-// ParseSearchPresentationsResponse parses an HTTP response from a SearchPresentationsWithResponse call
-func ParseSearchPresentationsResponse(rsp *http.Response) (*SearchPresentationsResponse, error) {
+// ParseUnblockSubjectResponse parses an HTTP response from a UnblockSubjectWithResponse call
+func ParseUnblockSubjectResponse(rsp *http.Response) (*UnblockSubjectResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SearchPresentationsResponse{
+	response := &UnblockSubjectResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []SearchResult
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -1313,9 +2269,27 @@ type ServerInterface interface {
 	// Retrieves the list of Discovery Services.
 	// (GET /internal/discovery/v1)
 	GetServices(ctx echo.Context) error
+	// Removes a Discovery Service definition.
+	// (DELETE /internal/discovery/v1/{serviceID})
+	DeleteServiceDefinition(ctx echo.Context, serviceID string) error
 	// Searches for presentations registered on the Discovery Service.
 	// (GET /internal/discovery/v1/{serviceID})
 	SearchPresentations(ctx echo.Context, serviceID string, params SearchPresentationsParams) error
+	// Adds or updates a Discovery Service definition.
+	// (PUT /internal/discovery/v1/{serviceID})
+	PutServiceDefinition(ctx echo.Context, serviceID string) error
+	// Retrieves the DIDs and presentations that are blocked on a Discovery Service.
+	// (GET /internal/discovery/v1/{serviceID}/moderation/block)
+	GetBlocks(ctx echo.Context, serviceID string) error
+	// Blocks a DID or a presentation on a Discovery Service.
+	// (POST /internal/discovery/v1/{serviceID}/moderation/block)
+	BlockSubject(ctx echo.Context, serviceID string) error
+	// Removes the presentations of a DID or a presentation from a Discovery Service.
+	// (POST /internal/discovery/v1/{serviceID}/moderation/remove)
+	RemovePresentations(ctx echo.Context, serviceID string) error
+	// Unblocks a DID or a presentation on a Discovery Service.
+	// (POST /internal/discovery/v1/{serviceID}/moderation/unblock)
+	UnblockSubject(ctx echo.Context, serviceID string) error
 	// Client API to deactivate the given DID from the Discovery Service.
 	// (DELETE /internal/discovery/v1/{serviceID}/{did})
 	DeactivateServiceForDID(ctx echo.Context, serviceID string, did string) error
@@ -1327,13 +2301,87 @@ type ServerInterface interface {
 	ActivateServiceForDID(ctx echo.Context, serviceID string, did string) error
 }
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetPresentations converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPresentations(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, ctx.Param("serviceID"), &serviceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPresentationsParams
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", ctx.QueryParams(), &params.Tag)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tag: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPresentations(ctx, serviceID, params)
+	return err
+}
+
+// RegisterPresentation converts echo context to params.
+func (w *ServerInterfaceWrapper) RegisterPresentation(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, ctx.Param("serviceID"), &serviceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RegisterPresentation(ctx, serviceID)
+	return err
+}
+
+// GetServices converts echo context to params.
+func (w *ServerInterfaceWrapper) GetServices(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetServices(ctx)
+	return err
+}
+
+// DeleteServiceDefinition converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteServiceDefinition(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, ctx.Param("serviceID"), &serviceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteServiceDefinition(ctx, serviceID)
+	return err
 }
 
-// GetPresentations converts echo context to params.
-func (w *ServerInterfaceWrapper) GetPresentations(ctx echo.Context) error {
+// SearchPresentations converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchPresentations(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "serviceID" -------------
 	var serviceID string
@@ -1346,21 +2394,21 @@ func (w *ServerInterfaceWrapper) GetPresentations(ctx echo.Context) error {
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPresentationsParams
-	// ------------- Optional query parameter "tag" -------------
+	var params SearchPresentationsParams
+	// ------------- Optional query parameter "query" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "tag", ctx.QueryParams(), &params.Tag)
+	err = runtime.BindQueryParameter("form", true, false, "query", ctx.QueryParams(), &params.Query)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tag: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter query: %s", err))
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetPresentations(ctx, serviceID, params)
+	err = w.Handler.SearchPresentations(ctx, serviceID, params)
 	return err
 }
 
-// RegisterPresentation converts echo context to params.
-func (w *ServerInterfaceWrapper) RegisterPresentation(ctx echo.Context) error {
+// PutServiceDefinition converts echo context to params.
+func (w *ServerInterfaceWrapper) PutServiceDefinition(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "serviceID" -------------
 	var serviceID string
@@ -1373,23 +2421,30 @@ func (w *ServerInterfaceWrapper) RegisterPresentation(ctx echo.Context) error {
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.RegisterPresentation(ctx, serviceID)
+	err = w.Handler.PutServiceDefinition(ctx, serviceID)
 	return err
 }
 
-// GetServices converts echo context to params.
-func (w *ServerInterfaceWrapper) GetServices(ctx echo.Context) error {
+// GetBlocks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBlocks(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, ctx.Param("serviceID"), &serviceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetServices(ctx)
+	err = w.Handler.GetBlocks(ctx, serviceID)
 	return err
 }
 
-// SearchPresentations converts echo context to params.
-func (w *ServerInterfaceWrapper) SearchPresentations(ctx echo.Context) error {
+// BlockSubject converts echo context to params.
+func (w *ServerInterfaceWrapper) BlockSubject(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "serviceID" -------------
 	var serviceID string
@@ -1401,17 +2456,44 @@ func (w *ServerInterfaceWrapper) SearchPresentations(ctx echo.Context) error {
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params SearchPresentationsParams
-	// ------------- Optional query parameter "query" -------------
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.BlockSubject(ctx, serviceID)
+	return err
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "query", ctx.QueryParams(), &params.Query)
+// RemovePresentations converts echo context to params.
+func (w *ServerInterfaceWrapper) RemovePresentations(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, ctx.Param("serviceID"), &serviceID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter query: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
 	}
 
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.SearchPresentations(ctx, serviceID, params)
+	err = w.Handler.RemovePresentations(ctx, serviceID)
+	return err
+}
+
+// UnblockSubject converts echo context to params.
+func (w *ServerInterfaceWrapper) UnblockSubject(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, ctx.Param("serviceID"), &serviceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UnblockSubject(ctx, serviceID)
 	return err
 }
 
@@ -1508,48 +2590,262 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/discovery/:serviceID", wrapper.GetPresentations)
+	router.POST(baseURL+"/discovery/:serviceID", wrapper.RegisterPresentation)
+	router.GET(baseURL+"/internal/discovery/v1", wrapper.GetServices)
+	router.DELETE(baseURL+"/internal/discovery/v1/:serviceID", wrapper.DeleteServiceDefinition)
+	router.GET(baseURL+"/internal/discovery/v1/:serviceID", wrapper.SearchPresentations)
+	router.PUT(baseURL+"/internal/discovery/v1/:serviceID", wrapper.PutServiceDefinition)
+	router.GET(baseURL+"/internal/discovery/v1/:serviceID/moderation/block", wrapper.GetBlocks)
+	router.POST(baseURL+"/internal/discovery/v1/:serviceID/moderation/block", wrapper.BlockSubject)
+	router.POST(baseURL+"/internal/discovery/v1/:serviceID/moderation/remove", wrapper.RemovePresentations)
+	router.POST(baseURL+"/internal/discovery/v1/:serviceID/moderation/unblock", wrapper.UnblockSubject)
+	router.DELETE(baseURL+"/internal/discovery/v1/:serviceID/:did", wrapper.DeactivateServiceForDID)
+	router.GET(baseURL+"/internal/discovery/v1/:serviceID/:did", wrapper.GetServiceActivation)
+	router.POST(baseURL+"/internal/discovery/v1/:serviceID/:did", wrapper.ActivateServiceForDID)
+
+}
+
+type GetPresentationsRequestObject struct {
+	ServiceID string `json:"serviceID"`
+	Params    GetPresentationsParams
+}
+
+type GetPresentationsResponseObject interface {
+	VisitGetPresentationsResponse(w http.ResponseWriter) error
+}
+
+type GetPresentations200JSONResponse PresentationsResponse
+
+func (response GetPresentations200JSONResponse) VisitGetPresentationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPresentationsdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response GetPresentationsdefaultApplicationProblemPlusJSONResponse) VisitGetPresentationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RegisterPresentationRequestObject struct {
+	ServiceID string `json:"serviceID"`
+	Body      *RegisterPresentationJSONRequestBody
+}
+
+type RegisterPresentationResponseObject interface {
+	VisitRegisterPresentationResponse(w http.ResponseWriter) error
+}
+
+type RegisterPresentation201Response struct {
+}
+
+func (response RegisterPresentation201Response) VisitRegisterPresentationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(201)
+	return nil
+}
+
+type RegisterPresentation400ApplicationProblemPlusJSONResponse struct {
+	// Detail A human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail"`
+
+	// Status HTTP statuscode
+	Status float32 `json:"status"`
+
+	// Title A short, human-readable summary of the problem type.
+	Title string `json:"title"`
+}
+
+func (response RegisterPresentation400ApplicationProblemPlusJSONResponse) VisitRegisterPresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterPresentationdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RegisterPresentationdefaultApplicationProblemPlusJSONResponse) VisitRegisterPresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetServicesRequestObject struct {
+}
+
+type GetServicesResponseObject interface {
+	VisitGetServicesResponse(w http.ResponseWriter) error
+}
+
+type GetServices200JSONResponse []ServiceDefinition
+
+func (response GetServices200JSONResponse) VisitGetServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServicesdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response GetServicesdefaultApplicationProblemPlusJSONResponse) VisitGetServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteServiceDefinitionRequestObject struct {
+	ServiceID string `json:"serviceID"`
+}
+
+type DeleteServiceDefinitionResponseObject interface {
+	VisitDeleteServiceDefinitionResponse(w http.ResponseWriter) error
+}
+
+type DeleteServiceDefinition204Response struct {
+}
+
+func (response DeleteServiceDefinition204Response) VisitDeleteServiceDefinitionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteServiceDefinitiondefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response DeleteServiceDefinitiondefaultApplicationProblemPlusJSONResponse) VisitDeleteServiceDefinitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SearchPresentationsRequestObject struct {
+	ServiceID string `json:"serviceID"`
+	Params    SearchPresentationsParams
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+type SearchPresentationsResponseObject interface {
+	VisitSearchPresentationsResponse(w http.ResponseWriter) error
+}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+type SearchPresentations200JSONResponse []SearchResult
+
+func (response SearchPresentations200JSONResponse) VisitSearchPresentationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchPresentationsdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
+	StatusCode int
+}
 
-	router.GET(baseURL+"/discovery/:serviceID", wrapper.GetPresentations)
-	router.POST(baseURL+"/discovery/:serviceID", wrapper.RegisterPresentation)
-	router.GET(baseURL+"/internal/discovery/v1", wrapper.GetServices)
-	router.GET(baseURL+"/internal/discovery/v1/:serviceID", wrapper.SearchPresentations)
-	router.DELETE(baseURL+"/internal/discovery/v1/:serviceID/:did", wrapper.DeactivateServiceForDID)
-	router.GET(baseURL+"/internal/discovery/v1/:serviceID/:did", wrapper.GetServiceActivation)
-	router.POST(baseURL+"/internal/discovery/v1/:serviceID/:did", wrapper.ActivateServiceForDID)
+func (response SearchPresentationsdefaultApplicationProblemPlusJSONResponse) VisitSearchPresentationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetPresentationsRequestObject struct {
+type PutServiceDefinitionRequestObject struct {
 	ServiceID string `json:"serviceID"`
-	Params    GetPresentationsParams
+	Body      *PutServiceDefinitionJSONRequestBody
 }
 
-type GetPresentationsResponseObject interface {
-	VisitGetPresentationsResponse(w http.ResponseWriter) error
+type PutServiceDefinitionResponseObject interface {
+	VisitPutServiceDefinitionResponse(w http.ResponseWriter) error
 }
 
-type GetPresentations200JSONResponse PresentationsResponse
+type PutServiceDefinition200JSONResponse ServiceDefinition
 
-func (response GetPresentations200JSONResponse) VisitGetPresentationsResponse(w http.ResponseWriter) error {
+func (response PutServiceDefinition200JSONResponse) VisitPutServiceDefinitionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetPresentationsdefaultApplicationProblemPlusJSONResponse struct {
+type PutServiceDefinitiondefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1563,49 +2859,69 @@ type GetPresentationsdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response GetPresentationsdefaultApplicationProblemPlusJSONResponse) VisitGetPresentationsResponse(w http.ResponseWriter) error {
+func (response PutServiceDefinitiondefaultApplicationProblemPlusJSONResponse) VisitPutServiceDefinitionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type RegisterPresentationRequestObject struct {
+type GetBlocksRequestObject struct {
 	ServiceID string `json:"serviceID"`
-	Body      *RegisterPresentationJSONRequestBody
 }
 
-type RegisterPresentationResponseObject interface {
-	VisitRegisterPresentationResponse(w http.ResponseWriter) error
+type GetBlocksResponseObject interface {
+	VisitGetBlocksResponse(w http.ResponseWriter) error
 }
 
-type RegisterPresentation201Response struct {
-}
+type GetBlocks200JSONResponse []Block
 
-func (response RegisterPresentation201Response) VisitRegisterPresentationResponse(w http.ResponseWriter) error {
-	w.WriteHeader(201)
-	return nil
+func (response GetBlocks200JSONResponse) VisitGetBlocksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type RegisterPresentation400ApplicationProblemPlusJSONResponse struct {
-	// Detail A human-readable explanation specific to this occurrence of the problem.
-	Detail string `json:"detail"`
+type GetBlocksdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
 
-	// Status HTTP statuscode
-	Status float32 `json:"status"`
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
 
-	// Title A short, human-readable summary of the problem type.
-	Title string `json:"title"`
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
 }
 
-func (response RegisterPresentation400ApplicationProblemPlusJSONResponse) VisitRegisterPresentationResponse(w http.ResponseWriter) error {
+func (response GetBlocksdefaultApplicationProblemPlusJSONResponse) VisitGetBlocksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+	w.WriteHeader(response.StatusCode)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type RegisterPresentationdefaultApplicationProblemPlusJSONResponse struct {
+type BlockSubjectRequestObject struct {
+	ServiceID string `json:"serviceID"`
+	Body      *BlockSubjectJSONRequestBody
+}
+
+type BlockSubjectResponseObject interface {
+	VisitBlockSubjectResponse(w http.ResponseWriter) error
+}
+
+type BlockSubject204Response struct {
+}
+
+func (response BlockSubject204Response) VisitBlockSubjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type BlockSubjectdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1619,30 +2935,31 @@ type RegisterPresentationdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response RegisterPresentationdefaultApplicationProblemPlusJSONResponse) VisitRegisterPresentationResponse(w http.ResponseWriter) error {
+func (response BlockSubjectdefaultApplicationProblemPlusJSONResponse) VisitBlockSubjectResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetServicesRequestObject struct {
+type RemovePresentationsRequestObject struct {
+	ServiceID string `json:"serviceID"`
+	Body      *RemovePresentationsJSONRequestBody
 }
 
-type GetServicesResponseObject interface {
-	VisitGetServicesResponse(w http.ResponseWriter) error
+type RemovePresentationsResponseObject interface {
+	VisitRemovePresentationsResponse(w http.ResponseWriter) error
 }
 
-type GetServices200JSONResponse []ServiceDefinition
-
-func (response GetServices200JSONResponse) VisitGetServicesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type RemovePresentations204Response struct {
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (response RemovePresentations204Response) VisitRemovePresentationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-type GetServicesdefaultApplicationProblemPlusJSONResponse struct {
+type RemovePresentationsdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1656,32 +2973,31 @@ type GetServicesdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response GetServicesdefaultApplicationProblemPlusJSONResponse) VisitGetServicesResponse(w http.ResponseWriter) error {
+func (response RemovePresentationsdefaultApplicationProblemPlusJSONResponse) VisitRemovePresentationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type SearchPresentationsRequestObject struct {
+type UnblockSubjectRequestObject struct {
 	ServiceID string `json:"serviceID"`
-	Params    SearchPresentationsParams
+	Body      *UnblockSubjectJSONRequestBody
 }
 
-type SearchPresentationsResponseObject interface {
-	VisitSearchPresentationsResponse(w http.ResponseWriter) error
+type UnblockSubjectResponseObject interface {
+	VisitUnblockSubjectResponse(w http.ResponseWriter) error
 }
 
-type SearchPresentations200JSONResponse []SearchResult
-
-func (response SearchPresentations200JSONResponse) VisitSearchPresentationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type UnblockSubject204Response struct {
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (response UnblockSubject204Response) VisitUnblockSubjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-type SearchPresentationsdefaultApplicationProblemPlusJSONResponse struct {
+type UnblockSubjectdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1695,7 +3011,7 @@ type SearchPresentationsdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response SearchPresentationsdefaultApplicationProblemPlusJSONResponse) VisitSearchPresentationsResponse(w http.ResponseWriter) error {
+func (response UnblockSubjectdefaultApplicationProblemPlusJSONResponse) VisitUnblockSubjectResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
@@ -1894,9 +3210,27 @@ type StrictServerInterface interface {
 	// Retrieves the list of Discovery Services.
 	// (GET /internal/discovery/v1)
 	GetServices(ctx context.Context, request GetServicesRequestObject) (GetServicesResponseObject, error)
+	// Removes a Discovery Service definition.
+	// (DELETE /internal/discovery/v1/{serviceID})
+	DeleteServiceDefinition(ctx context.Context, request DeleteServiceDefinitionRequestObject) (DeleteServiceDefinitionResponseObject, error)
 	// Searches for presentations registered on the Discovery Service.
 	// (GET /internal/discovery/v1/{serviceID})
 	SearchPresentations(ctx context.Context, request SearchPresentationsRequestObject) (SearchPresentationsResponseObject, error)
+	// Adds or updates a Discovery Service definition.
+	// (PUT /internal/discovery/v1/{serviceID})
+	PutServiceDefinition(ctx context.Context, request PutServiceDefinitionRequestObject) (PutServiceDefinitionResponseObject, error)
+	// Retrieves the DIDs and presentations that are blocked on a Discovery Service.
+	// (GET /internal/discovery/v1/{serviceID}/moderation/block)
+	GetBlocks(ctx context.Context, request GetBlocksRequestObject) (GetBlocksResponseObject, error)
+	// Blocks a DID or a presentation on a Discovery Service.
+	// (POST /internal/discovery/v1/{serviceID}/moderation/block)
+	BlockSubject(ctx context.Context, request BlockSubjectRequestObject) (BlockSubjectResponseObject, error)
+	// Removes the presentations of a DID or a presentation from a Discovery Service.
+	// (POST /internal/discovery/v1/{serviceID}/moderation/remove)
+	RemovePresentations(ctx context.Context, request RemovePresentationsRequestObject) (RemovePresentationsResponseObject, error)
+	// Unblocks a DID or a presentation on a Discovery Service.
+	// (POST /internal/discovery/v1/{serviceID}/moderation/unblock)
+	UnblockSubject(ctx context.Context, request UnblockSubjectRequestObject) (UnblockSubjectResponseObject, error)
 	// Client API to deactivate the given DID from the Discovery Service.
 	// (DELETE /internal/discovery/v1/{serviceID}/{did})
 	DeactivateServiceForDID(ctx context.Context, request DeactivateServiceForDIDRequestObject) (DeactivateServiceForDIDResponseObject, error)
@@ -2000,6 +3334,31 @@ func (sh *strictHandler) GetServices(ctx echo.Context) error {
 	return nil
 }
 
+// DeleteServiceDefinition operation middleware
+func (sh *strictHandler) DeleteServiceDefinition(ctx echo.Context, serviceID string) error {
+	var request DeleteServiceDefinitionRequestObject
+
+	request.ServiceID = serviceID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteServiceDefinition(ctx.Request().Context(), request.(DeleteServiceDefinitionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteServiceDefinition")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteServiceDefinitionResponseObject); ok {
+		return validResponse.VisitDeleteServiceDefinitionResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // SearchPresentations operation middleware
 func (sh *strictHandler) SearchPresentations(ctx echo.Context, serviceID string, params SearchPresentationsParams) error {
 	var request SearchPresentationsRequestObject
@@ -2026,6 +3385,155 @@ func (sh *strictHandler) SearchPresentations(ctx echo.Context, serviceID string,
 	return nil
 }
 
+// PutServiceDefinition operation middleware
+func (sh *strictHandler) PutServiceDefinition(ctx echo.Context, serviceID string) error {
+	var request PutServiceDefinitionRequestObject
+
+	request.ServiceID = serviceID
+
+	var body PutServiceDefinitionJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutServiceDefinition(ctx.Request().Context(), request.(PutServiceDefinitionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutServiceDefinition")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutServiceDefinitionResponseObject); ok {
+		return validResponse.VisitPutServiceDefinitionResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetBlocks operation middleware
+func (sh *strictHandler) GetBlocks(ctx echo.Context, serviceID string) error {
+	var request GetBlocksRequestObject
+
+	request.ServiceID = serviceID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBlocks(ctx.Request().Context(), request.(GetBlocksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBlocks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetBlocksResponseObject); ok {
+		return validResponse.VisitGetBlocksResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// BlockSubject operation middleware
+func (sh *strictHandler) BlockSubject(ctx echo.Context, serviceID string) error {
+	var request BlockSubjectRequestObject
+
+	request.ServiceID = serviceID
+
+	var body BlockSubjectJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.BlockSubject(ctx.Request().Context(), request.(BlockSubjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BlockSubject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(BlockSubjectResponseObject); ok {
+		return validResponse.VisitBlockSubjectResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RemovePresentations operation middleware
+func (sh *strictHandler) RemovePresentations(ctx echo.Context, serviceID string) error {
+	var request RemovePresentationsRequestObject
+
+	request.ServiceID = serviceID
+
+	var body RemovePresentationsJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemovePresentations(ctx.Request().Context(), request.(RemovePresentationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemovePresentations")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RemovePresentationsResponseObject); ok {
+		return validResponse.VisitRemovePresentationsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UnblockSubject operation middleware
+func (sh *strictHandler) UnblockSubject(ctx echo.Context, serviceID string) error {
+	var request UnblockSubjectRequestObject
+
+	request.ServiceID = serviceID
+
+	var body UnblockSubjectJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UnblockSubject(ctx.Request().Context(), request.(UnblockSubjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnblockSubject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UnblockSubjectResponseObject); ok {
+		return validResponse.VisitUnblockSubjectResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // DeactivateServiceForDID operation middleware
 func (sh *strictHandler) DeactivateServiceForDID(ctx echo.Context, serviceID string, did string) error {
 	var request DeactivateServiceForDIDRequestObject
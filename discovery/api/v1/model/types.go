@@ -24,4 +24,7 @@ import "github.com/nuts-foundation/go-did/vc"
 type PresentationsResponse struct {
 	Entries []vc.VerifiablePresentation `json:"entries"`
 	Tag     string                      `json:"tag"`
+	// Complete indicates the entries are the complete list of presentations, instead of only the ones added since the given tag.
+	// The client should then replace its copy of the list.
+	Complete bool `json:"complete,omitempty"`
 }
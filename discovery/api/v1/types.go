@@ -32,3 +32,6 @@ type PresentationsResponse = model.PresentationsResponse
 
 // ServiceDefinition is a type alias
 type ServiceDefinition = discovery.ServiceDefinition
+
+// Block is a type alias
+type Block = discovery.Block
@@ -45,13 +45,13 @@ type clientRegistrationManager interface {
 var _ clientRegistrationManager = &defaultClientRegistrationManager{}
 
 type defaultClientRegistrationManager struct {
-	services map[string]ServiceDefinition
+	services *serviceDefinitions
 	store    *sqlStore
 	client   client.HTTPClient
 	vcr      vcr.VCR
 }
 
-func newRegistrationManager(services map[string]ServiceDefinition, store *sqlStore, client client.HTTPClient, vcr vcr.VCR) *defaultClientRegistrationManager {
+func newRegistrationManager(services *serviceDefinitions, store *sqlStore, client client.HTTPClient, vcr vcr.VCR) *defaultClientRegistrationManager {
 	instance := &defaultClientRegistrationManager{
 		services: services,
 		store:    store,
@@ -62,7 +62,7 @@ func newRegistrationManager(services map[string]ServiceDefinition, store *sqlSto
 }
 
 func (r *defaultClientRegistrationManager) activate(ctx context.Context, serviceID string, subjectDID did.DID) error {
-	service, serviceExists := r.services[serviceID]
+	service, serviceExists := r.services.get(serviceID)
 	if !serviceExists {
 		return ErrServiceNotFound
 	}
@@ -106,7 +106,7 @@ func (r *defaultClientRegistrationManager) deactivate(ctx context.Context, servi
 		return nil
 	}
 	// found an active registration, try to delete it from the discovery server
-	service := r.services[serviceID]
+	service, _ := r.services.get(serviceID)
 	presentation, err := r.buildPresentation(ctx, subjectDID, service, nil, map[string]interface{}{
 		"retract_jti": presentations[0].ID.String(),
 	})
@@ -178,13 +178,13 @@ func (r *defaultClientRegistrationManager) refresh(ctx context.Context, now time
 // clientUpdater is responsible for updating the local copy of Discovery Services
 // Callers should only call update().
 type clientUpdater struct {
-	services map[string]ServiceDefinition
+	services *serviceDefinitions
 	store    *sqlStore
 	client   client.HTTPClient
 	verifier presentationVerifier
 }
 
-func newClientUpdater(services map[string]ServiceDefinition, store *sqlStore, verifier presentationVerifier, client client.HTTPClient) *clientUpdater {
+func newClientUpdater(services *serviceDefinitions, store *sqlStore, verifier presentationVerifier, client client.HTTPClient) *clientUpdater {
 	return &clientUpdater{
 		services: services,
 		store:    store,
@@ -196,7 +196,7 @@ func newClientUpdater(services map[string]ServiceDefinition, store *sqlStore, ve
 func (u *clientUpdater) update(ctx context.Context) error {
 	log.Logger().Debug("Checking for new Verifiable Presentations from Discovery Services")
 	var result error = nil
-	for _, service := range u.services {
+	for _, service := range u.services.all() {
 		if err := u.updateService(ctx, service); err != nil {
 			result = errors.Join(result, err)
 		}
@@ -212,10 +212,20 @@ func (u *clientUpdater) updateService(ctx context.Context, service ServiceDefini
 	log.Logger().
 		WithField("discoveryService", service.ID).
 		Tracef("Checking for new Verifiable Presentations from Discovery Service (tag: %s)", currentTag)
-	presentations, tag, err := u.client.Get(ctx, service.Endpoint, string(currentTag))
+	presentations, tag, complete, err := u.client.Get(ctx, service.Endpoint, string(currentTag))
 	if err != nil {
 		return fmt.Errorf("failed to get presentations from discovery service (id=%s): %w", service.ID, err)
 	}
+	if complete && !currentTag.Empty() {
+		// The server returned the complete list instead of the changes since our tag (e.g. because presentations were removed),
+		// so replace our copy to drop presentations that are no longer on the list.
+		log.Logger().
+			WithField("discoveryService", service.ID).
+			Debug("Discovery Service returned the complete list of Verifiable Presentations, replacing local copy")
+		if err := u.store.resetService(service.ID); err != nil {
+			return fmt.Errorf("failed to reset presentations (service=%s): %w", service.ID, err)
+		}
+	}
 	for _, presentation := range presentations {
 		if err := u.verifier(service, presentation); err != nil {
 			log.Logger().WithError(err).Warnf("Presentation verification failed, not adding it (service=%s, id=%s)", service.ID, presentation.ID)
@@ -48,7 +48,7 @@ func Test_scheduledRegistrationManager_register(t *testing.T) {
 		wallet.EXPECT().BuildPresentation(gomock.Any(), []vc.VerifiableCredential{vcAlice}, gomock.Any(), gomock.Any(), false).Return(&vpAlice, nil)
 		mockVCR.EXPECT().Wallet().Return(wallet).AnyTimes()
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 
 		err := manager.activate(audit.TestContext(), testServiceID, aliceDID)
 
@@ -64,7 +64,7 @@ func Test_scheduledRegistrationManager_register(t *testing.T) {
 		wallet.EXPECT().BuildPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&vpAlice, nil)
 		mockVCR.EXPECT().Wallet().Return(wallet).AnyTimes()
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 
 		err := manager.activate(audit.TestContext(), testServiceID, aliceDID)
 
@@ -79,7 +79,7 @@ func Test_scheduledRegistrationManager_register(t *testing.T) {
 		wallet.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
 		mockVCR.EXPECT().Wallet().Return(wallet).AnyTimes()
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 
 		err := manager.activate(audit.TestContext(), testServiceID, aliceDID)
 
@@ -91,7 +91,7 @@ func Test_scheduledRegistrationManager_register(t *testing.T) {
 		invoker := client.NewMockHTTPClient(ctrl)
 		mockVCR := vcr.NewMockVCR(ctrl)
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 
 		err := manager.activate(audit.TestContext(), "unknown", aliceDID)
 
@@ -108,7 +108,7 @@ func Test_scheduledRegistrationManager_deregister(t *testing.T) {
 		invoker := client.NewMockHTTPClient(ctrl)
 		mockVCR := vcr.NewMockVCR(ctrl)
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 
 		err := manager.deactivate(audit.TestContext(), testServiceID, aliceDID)
 
@@ -123,7 +123,7 @@ func Test_scheduledRegistrationManager_deregister(t *testing.T) {
 		wallet.EXPECT().BuildPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&vpAlice, nil)
 		mockVCR.EXPECT().Wallet().Return(wallet).AnyTimes()
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 		require.NoError(t, store.add(testServiceID, vpAlice, "taggy"))
 
 		err := manager.deactivate(audit.TestContext(), testServiceID, aliceDID)
@@ -139,7 +139,7 @@ func Test_scheduledRegistrationManager_deregister(t *testing.T) {
 		wallet.EXPECT().BuildPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&vpAlice, nil)
 		mockVCR.EXPECT().Wallet().Return(wallet).AnyTimes()
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 		require.NoError(t, store.add(testServiceID, vpAlice, "taggy"))
 
 		err := manager.deactivate(audit.TestContext(), testServiceID, aliceDID)
@@ -158,7 +158,7 @@ func Test_scheduledRegistrationManager_refresh(t *testing.T) {
 		invoker := client.NewMockHTTPClient(ctrl)
 		mockVCR := vcr.NewMockVCR(ctrl)
 		store := setupStore(t, storageEngine.GetSQLDatabase())
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 
 		err := manager.refresh(audit.TestContext(), time.Now())
 
@@ -175,7 +175,7 @@ func Test_scheduledRegistrationManager_refresh(t *testing.T) {
 		mockVCR := vcr.NewMockVCR(ctrl)
 		wallet := holder.NewMockWallet(ctrl)
 		mockVCR.EXPECT().Wallet().Return(wallet).AnyTimes()
-		manager := newRegistrationManager(testDefinitions(), store, invoker, mockVCR)
+		manager := newRegistrationManager(newServiceDefinitions(testDefinitions()), store, invoker, mockVCR)
 		// Alice
 		_ = store.updatePresentationRefreshTime(testServiceID, aliceDID, &time.Time{})
 		wallet.EXPECT().BuildPresentation(gomock.Any(), gomock.Any(), gomock.Any(), &aliceDID, false).Return(&vpAlice, nil)
@@ -204,9 +204,9 @@ func Test_clientUpdater_updateService(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		httpClient := client.NewMockHTTPClient(ctrl)
-		updater := newClientUpdater(testDefinitions(), store, alwaysOkVerifier, httpClient)
+		updater := newClientUpdater(newServiceDefinitions(testDefinitions()), store, alwaysOkVerifier, httpClient)
 
-		httpClient.EXPECT().Get(ctx, testDefinitions()[testServiceID].Endpoint, "").Return([]vc.VerifiablePresentation{}, newTag, nil)
+		httpClient.EXPECT().Get(ctx, testDefinitions()[testServiceID].Endpoint, "").Return([]vc.VerifiablePresentation{}, newTag, false, nil)
 
 		err := updater.updateService(ctx, testDefinitions()[testServiceID])
 
@@ -216,9 +216,9 @@ func Test_clientUpdater_updateService(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		httpClient := client.NewMockHTTPClient(ctrl)
-		updater := newClientUpdater(testDefinitions(), store, alwaysOkVerifier, httpClient)
+		updater := newClientUpdater(newServiceDefinitions(testDefinitions()), store, alwaysOkVerifier, httpClient)
 
-		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, "").Return([]vc.VerifiablePresentation{vpAlice}, newTag, nil)
+		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, "").Return([]vc.VerifiablePresentation{vpAlice}, newTag, false, nil)
 
 		err := updater.updateService(ctx, testDefinitions()[testServiceID])
 
@@ -228,14 +228,14 @@ func Test_clientUpdater_updateService(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		httpClient := client.NewMockHTTPClient(ctrl)
-		updater := newClientUpdater(testDefinitions(), store, func(_ ServiceDefinition, vp vc.VerifiablePresentation) error {
+		updater := newClientUpdater(newServiceDefinitions(testDefinitions()), store, func(_ ServiceDefinition, vp vc.VerifiablePresentation) error {
 			if *vp.ID == *vpAlice.ID {
 				return errors.New("invalid presentation")
 			}
 			return nil
 		}, httpClient)
 
-		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, "").Return([]vc.VerifiablePresentation{vpAlice, vpBob}, newTag, nil)
+		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, "").Return([]vc.VerifiablePresentation{vpAlice, vpBob}, newTag, false, nil)
 
 		err := updater.updateService(ctx, testDefinitions()[testServiceID])
 
@@ -254,14 +254,37 @@ func Test_clientUpdater_updateService(t *testing.T) {
 		httpClient := client.NewMockHTTPClient(ctrl)
 		_, err := store.updateTag(store.db, testServiceID, "test")
 		require.NoError(t, err)
-		updater := newClientUpdater(testDefinitions(), store, alwaysOkVerifier, httpClient)
+		updater := newClientUpdater(newServiceDefinitions(testDefinitions()), store, alwaysOkVerifier, httpClient)
 
-		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, "test").Return([]vc.VerifiablePresentation{vpAlice}, newTag, nil)
+		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, "test").Return([]vc.VerifiablePresentation{vpAlice}, newTag, false, nil)
 
 		err = updater.updateService(ctx, testDefinitions()[testServiceID])
 
 		require.NoError(t, err)
 	})
+	t.Run("complete list replaces local copy", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		ctrl := gomock.NewController(t)
+		httpClient := client.NewMockHTTPClient(ctrl)
+		require.NoError(t, store.add(testServiceID, vpAlice, "test"))
+		updater := newClientUpdater(newServiceDefinitions(testDefinitions()), store, alwaysOkVerifier, httpClient)
+
+		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, "test").Return([]vc.VerifiablePresentation{vpBob}, newTag, true, nil)
+
+		err := updater.updateService(ctx, testDefinitions()[testServiceID])
+
+		require.NoError(t, err)
+		// Alice's VP was removed from the list on the server
+		exists, err := store.exists(testServiceID, aliceDID.String(), vpAlice.ID.String())
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = store.exists(testServiceID, bobDID.String(), vpBob.ID.String())
+		require.NoError(t, err)
+		assert.True(t, exists)
+		tag, err := store.getTag(testServiceID)
+		require.NoError(t, err)
+		assert.Equal(t, Tag(newTag), tag)
+	})
 }
 
 func Test_clientUpdater_update(t *testing.T) {
@@ -271,9 +294,9 @@ func Test_clientUpdater_update(t *testing.T) {
 		store := setupStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		httpClient := client.NewMockHTTPClient(ctrl)
-		httpClient.EXPECT().Get(gomock.Any(), "http://example.com/usecase", gomock.Any()).Return([]vc.VerifiablePresentation{}, "test", nil)
-		httpClient.EXPECT().Get(gomock.Any(), "http://example.com/other", gomock.Any()).Return(nil, "", errors.New("test"))
-		updater := newClientUpdater(testDefinitions(), store, alwaysOkVerifier, httpClient)
+		httpClient.EXPECT().Get(gomock.Any(), "http://example.com/usecase", gomock.Any()).Return([]vc.VerifiablePresentation{}, "test", false, nil)
+		httpClient.EXPECT().Get(gomock.Any(), "http://example.com/other", gomock.Any()).Return(nil, "", false, errors.New("test"))
+		updater := newClientUpdater(newServiceDefinitions(testDefinitions()), store, alwaysOkVerifier, httpClient)
 
 		err := updater.update(context.Background())
 
@@ -285,8 +308,8 @@ func Test_clientUpdater_update(t *testing.T) {
 		store := setupStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		httpClient := client.NewMockHTTPClient(ctrl)
-		httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return([]vc.VerifiablePresentation{}, "test", nil).MinTimes(2)
-		updater := newClientUpdater(testDefinitions(), store, alwaysOkVerifier, httpClient)
+		httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return([]vc.VerifiablePresentation{}, "test", false, nil).MinTimes(2)
+		updater := newClientUpdater(newServiceDefinitions(testDefinitions()), store, alwaysOkVerifier, httpClient)
 
 		err := updater.update(context.Background())
 
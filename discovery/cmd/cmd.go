@@ -19,8 +19,14 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/discovery"
+	v1 "github.com/nuts-foundation/nuts-node/discovery/api/v1"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"os"
 )
 
 // FlagSet contains flags relevant for the module.
@@ -28,11 +34,15 @@ func FlagSet() *pflag.FlagSet {
 	defs := discovery.DefaultConfig()
 	flagSet := pflag.NewFlagSet("discovery", pflag.ContinueOnError)
 	flagSet.String("discovery.definitions.directory", defs.Definitions.Directory,
-		"Directory to load Discovery Service Definitions from. If not set, the discovery service will be disabled. "+
+		"Directory to load Discovery Service Definitions from. If not set, only service definitions managed through the API are used. "+
 			"If the directory contains JSON files that can't be parsed as service definition, the node will fail to start.")
 	flagSet.StringSlice("discovery.server.definition_ids", defs.Server.DefinitionIDs,
 		"IDs of the Discovery Service Definitions for which to act as server. "+
 			"If an ID does not map to a loaded service definition, the node will fail to start.")
+	flagSet.Duration("discovery.server.recheck_interval", defs.Server.RecheckInterval,
+		"Interval at which the server verifies registered Verifiable Presentations again (signatures, revocation and service definition), "+
+			"removing presentations that are no longer valid. Set to 0 to disable. "+
+			"Specified as Golang duration (e.g. 1m, 1h30m).")
 	flagSet.Duration("discovery.client.refresh_interval", defs.Client.RefreshInterval,
 		"Interval at which the client synchronizes with the Discovery Server; "+
 			"refreshing Verifiable Presentations of local DIDs and loading changes, updating the local copy. "+
@@ -40,3 +50,188 @@ func FlagSet() *pflag.FlagSet {
 			"Specified as Golang duration (e.g. 1m, 1h30m).")
 	return flagSet
 }
+
+// Cmd contains sub-commands for the remote client
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "discovery",
+		Short: "discovery commands",
+	}
+	definitionCmds := &cobra.Command{
+		Use:   "definition",
+		Short: "service definition commands",
+	}
+	definitionCmds.AddCommand(listDefinitions())
+	definitionCmds.AddCommand(putDefinition())
+	definitionCmds.AddCommand(deleteDefinition())
+	cmd.AddCommand(definitionCmds)
+	moderationCmds := &cobra.Command{
+		Use:   "moderation",
+		Short: "Discovery Server moderation commands",
+	}
+	moderationCmds.AddCommand(removePresentations())
+	moderationCmds.AddCommand(blockSubject())
+	moderationCmds.AddCommand(unblockSubject())
+	moderationCmds.AddCommand(listBlocks())
+	cmd.AddCommand(moderationCmds)
+	return cmd
+}
+
+func listDefinitions() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the Discovery Service definitions known to the node.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			services, err := httpClient(clientConfig).GetServices()
+			if err != nil {
+				return fmt.Errorf("unable to list service definitions: %w", err)
+			}
+			resultJSON, _ := json.MarshalIndent(services, "", "  ")
+			cmd.Println(string(resultJSON))
+			return nil
+		},
+	}
+}
+
+func putDefinition() *cobra.Command {
+	var server bool
+	result := &cobra.Command{
+		Use:   "put [file]",
+		Short: "Adds or updates a Discovery Service definition.",
+		Long: "Adds or updates the Discovery Service definition in the given JSON file, while the node is running. " +
+			"Presentations that no longer fulfill an updated definition are removed. " +
+			"Definitions loaded from the definitions directory can't be changed.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read service definition file: %w", err)
+			}
+			var definition map[string]interface{}
+			if err = json.Unmarshal(data, &definition); err != nil {
+				return fmt.Errorf("unable to parse service definition file: %w", err)
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			stored, err := httpClient(clientConfig).PutServiceDefinition(definition, server)
+			if err != nil {
+				return fmt.Errorf("unable to store service definition: %w", err)
+			}
+			resultJSON, _ := json.MarshalIndent(stored, "", "  ")
+			cmd.Println(string(resultJSON))
+			return nil
+		},
+	}
+	result.Flags().BoolVar(&server, "server", false, "Act as Discovery Server for the service.")
+	return result
+}
+
+func deleteDefinition() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [ID]",
+		Short: "Removes a Discovery Service definition.",
+		Long: "Removes the Discovery Service definition with the given ID, including its presentations and activated DIDs. " +
+			"Definitions loaded from the definitions directory can't be removed.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).DeleteServiceDefinition(args[0]); err != nil {
+				return fmt.Errorf("unable to delete service definition: %w", err)
+			}
+			cmd.Println("Service definition deleted")
+			return nil
+		},
+	}
+}
+
+func removePresentations() *cobra.Command {
+	var reason string
+	result := &cobra.Command{
+		Use:   "remove [service ID] [subject]",
+		Short: "Removes presentations from a Discovery Service.",
+		Long: "Removes the presentations of the given subject from the Discovery Service, for which the node acts as server. " +
+			"The subject is either a DID or the ID of a presentation. The subject can register again, unless it is blocked.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).RemovePresentations(args[0], args[1], reason); err != nil {
+				return fmt.Errorf("unable to remove presentations: %w", err)
+			}
+			cmd.Println("Presentations removed")
+			return nil
+		},
+	}
+	addReasonFlag(result, &reason)
+	return result
+}
+
+func blockSubject() *cobra.Command {
+	var reason string
+	result := &cobra.Command{
+		Use:   "block [service ID] [subject]",
+		Short: "Blocks a subject from registering on a Discovery Service.",
+		Long: "Removes the presentations of the given subject from the Discovery Service, for which the node acts as server, " +
+			"and rejects new registrations of the subject until it is unblocked. The subject is either a DID or the ID of a presentation.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).BlockSubject(args[0], args[1], reason); err != nil {
+				return fmt.Errorf("unable to block subject: %w", err)
+			}
+			cmd.Println("Subject blocked")
+			return nil
+		},
+	}
+	addReasonFlag(result, &reason)
+	return result
+}
+
+func unblockSubject() *cobra.Command {
+	var reason string
+	result := &cobra.Command{
+		Use:   "unblock [service ID] [subject]",
+		Short: "Lifts the block of a subject on a Discovery Service.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).UnblockSubject(args[0], args[1], reason); err != nil {
+				return fmt.Errorf("unable to unblock subject: %w", err)
+			}
+			cmd.Println("Subject unblocked")
+			return nil
+		},
+	}
+	addReasonFlag(result, &reason)
+	return result
+}
+
+func listBlocks() *cobra.Command {
+	return &cobra.Command{
+		Use:   "blocks [service ID]",
+		Short: "Lists the subjects that are blocked on a Discovery Service.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			blocks, err := httpClient(clientConfig).GetBlocks(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to list blocked subjects: %w", err)
+			}
+			resultJSON, _ := json.MarshalIndent(blocks, "", "  ")
+			cmd.Println(string(resultJSON))
+			return nil
+		},
+	}
+}
+
+func addReasonFlag(cmd *cobra.Command, reason *string) {
+	cmd.Flags().StringVar(reason, "reason", "", "Reason for the moderation action, which is recorded in the audit log.")
+	_ = cmd.MarkFlagRequired("reason")
+}
+
+// httpClient creates a remote client
+func httpClient(config core.ClientConfig) v1.HTTPClient {
+	return v1.HTTPClient{
+		ClientConfig: config,
+	}
+}
@@ -19,7 +19,17 @@
 package cmd
 
 import (
+	"bytes"
+	"github.com/nuts-foundation/nuts-node/core"
+	v1 "github.com/nuts-foundation/nuts-node/discovery/api/v1"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
 	"testing"
 )
 
@@ -27,3 +37,152 @@ func TestFlagSet(t *testing.T) {
 	flagset := FlagSet()
 	assert.NotNil(t, flagset)
 }
+
+func TestCmd_ListDefinitions(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		cmd := Cmd()
+		handler := &http2.Handler{StatusCode: http.StatusOK, ResponseData: []v1.ServiceDefinition{{ID: "usecase"}}}
+		s := httptest.NewServer(handler)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		defer s.Close()
+		output := new(bytes.Buffer)
+		cmd.SetOut(output)
+
+		cmd.SetArgs([]string{"definition", "list"})
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, output.String(), `"id": "usecase"`)
+	})
+	t.Run("it handles an http error", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.SetArgs([]string{"definition", "list"})
+		assert.EqualError(t, cmd.Execute(), "unable to list service definitions: Get \"http:///internal/discovery/v1\": http: no Host in request URL")
+	})
+}
+
+func TestCmd_PutDefinition(t *testing.T) {
+	definitionFile := path.Join(t.TempDir(), "definition.json")
+	require.NoError(t, os.WriteFile(definitionFile, []byte(`{"id": "usecase"}`), 0644))
+	t.Run("ok", func(t *testing.T) {
+		cmd := Cmd()
+		handler := &http2.Handler{StatusCode: http.StatusOK, ResponseData: v1.ServiceDefinition{ID: "usecase"}}
+		s := httptest.NewServer(handler)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		defer s.Close()
+
+		cmd.SetArgs([]string{"definition", "put", definitionFile, "--server"})
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "/internal/discovery/v1/usecase", handler.Request.URL.Path)
+		assert.JSONEq(t, `{"definition":{"id":"usecase"},"server":true}`, string(handler.RequestData))
+	})
+	t.Run("file does not exist", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.SetArgs([]string{"definition", "put", path.Join(t.TempDir(), "missing.json")})
+		assert.ErrorContains(t, cmd.Execute(), "unable to read service definition file")
+	})
+	t.Run("file is not JSON", func(t *testing.T) {
+		invalidFile := path.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(invalidFile, []byte(`not JSON`), 0644))
+		cmd := Cmd()
+		cmd.SetArgs([]string{"definition", "put", invalidFile})
+		assert.ErrorContains(t, cmd.Execute(), "unable to parse service definition file")
+	})
+	t.Run("it handles an http error", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.SetArgs([]string{"definition", "put", definitionFile})
+		assert.EqualError(t, cmd.Execute(), "unable to store service definition: Put \"http:///internal/discovery/v1/usecase\": http: no Host in request URL")
+	})
+}
+
+func TestCmd_DeleteDefinition(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		cmd := Cmd()
+		handler := &http2.Handler{StatusCode: http.StatusNoContent, ResponseData: ""}
+		s := httptest.NewServer(handler)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		defer s.Close()
+
+		cmd.SetArgs([]string{"definition", "delete", "usecase"})
+		err := cmd.Execute()
+
+		assert.NoError(t, err)
+	})
+	t.Run("it handles an http error", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.SetArgs([]string{"definition", "delete", "usecase"})
+		assert.EqualError(t, cmd.Execute(), "unable to delete service definition: Delete \"http:///internal/discovery/v1/usecase\": http: no Host in request URL")
+	})
+}
+
+func TestCmd_Moderation(t *testing.T) {
+	setup := func(t *testing.T, handler *http2.Handler) *cobra.Command {
+		cmd := Cmd()
+		s := httptest.NewServer(handler)
+		t.Cleanup(s.Close)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		return cmd
+	}
+	t.Run("remove", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusNoContent}
+		cmd := setup(t, handler)
+		output := new(bytes.Buffer)
+		cmd.SetOut(output)
+
+		cmd.SetArgs([]string{"moderation", "remove", "usecase", "did:web:example.com", "--reason", "spam"})
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "/internal/discovery/v1/usecase/moderation/remove", handler.Request.URL.Path)
+		assert.JSONEq(t, `{"subject":"did:web:example.com","reason":"spam"}`, string(handler.RequestData))
+		assert.Contains(t, output.String(), "Presentations removed")
+	})
+	t.Run("block", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusNoContent}
+		cmd := setup(t, handler)
+
+		cmd.SetArgs([]string{"moderation", "block", "usecase", "did:web:example.com", "--reason", "spam"})
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "/internal/discovery/v1/usecase/moderation/block", handler.Request.URL.Path)
+	})
+	t.Run("unblock", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusNoContent}
+		cmd := setup(t, handler)
+
+		cmd.SetArgs([]string{"moderation", "unblock", "usecase", "did:web:example.com", "--reason", "resolved"})
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "/internal/discovery/v1/usecase/moderation/unblock", handler.Request.URL.Path)
+	})
+	t.Run("blocks", func(t *testing.T) {
+		handler := &http2.Handler{StatusCode: http.StatusOK, ResponseData: []v1.Block{{Subject: "did:web:example.com", Reason: "spam"}}}
+		cmd := setup(t, handler)
+		output := new(bytes.Buffer)
+		cmd.SetOut(output)
+
+		cmd.SetArgs([]string{"moderation", "blocks", "usecase"})
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, output.String(), `"subject": "did:web:example.com"`)
+	})
+	t.Run("reason is required", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.SetArgs([]string{"moderation", "block", "usecase", "did:web:example.com"})
+		assert.EqualError(t, cmd.Execute(), `required flag(s) "reason" not set`)
+	})
+	t.Run("it handles an http error", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.SetArgs([]string{"moderation", "block", "usecase", "did:web:example.com", "--reason", "spam"})
+		assert.EqualError(t, cmd.Execute(), "unable to block subject: Post \"http:///internal/discovery/v1/usecase/moderation/block\": http: no Host in request URL")
+	})
+}
@@ -36,6 +36,9 @@ type ServiceDefinitionsConfig struct {
 type ServerConfig struct {
 	// DefinitionIDs specifies which use case lists the server serves.
 	DefinitionIDs []string `koanf:"definition_ids"`
+	// RecheckInterval specifies how often the server verifies the registered presentations again,
+	// removing those that became invalid (e.g. because a credential was revoked).
+	RecheckInterval time.Duration `koanf:"recheck_interval"`
 }
 
 // ClientConfig holds the config for the client
@@ -47,7 +50,9 @@ type ClientConfig struct {
 // DefaultConfig returns the default configuration.
 func DefaultConfig() Config {
 	return Config{
-		Server: ServerConfig{},
+		Server: ServerConfig{
+			RecheckInterval: time.Hour,
+		},
 		Client: ClientConfig{
 			RefreshInterval: 10 * time.Minute,
 		},
@@ -25,4 +25,5 @@ import (
 
 func TestDefaultConfig(t *testing.T) {
 	assert.NotEmpty(t, DefaultConfig().Client.RefreshInterval)
+	assert.NotEmpty(t, DefaultConfig().Server.RecheckInterval)
 }
@@ -25,6 +25,10 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
 	"github.com/santhosh-tekuri/jsonschema"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 )
 
 //go:embed *.json
@@ -69,3 +73,57 @@ func ParseServiceDefinition(data []byte) (*ServiceDefinition, error) {
 	}
 	return &definition, nil
 }
+
+// serviceDefinitions holds a set of service definitions, which can be changed while the node is running.
+// It is safe for concurrent use.
+type serviceDefinitions struct {
+	mux         sync.RWMutex
+	definitions map[string]ServiceDefinition
+}
+
+func newServiceDefinitions(definitions map[string]ServiceDefinition) *serviceDefinitions {
+	result := &serviceDefinitions{definitions: make(map[string]ServiceDefinition)}
+	maps.Copy(result.definitions, definitions)
+	return result
+}
+
+// get returns the service definition with the given ID, and whether it exists.
+func (s *serviceDefinitions) get(serviceID string) (ServiceDefinition, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	definition, exists := s.definitions[serviceID]
+	return definition, exists
+}
+
+// all returns all service definitions, ordered by ID.
+func (s *serviceDefinitions) all() []ServiceDefinition {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	result := make([]ServiceDefinition, 0, len(s.definitions))
+	for _, definition := range s.definitions {
+		result = append(result, definition)
+	}
+	slices.SortFunc(result, func(a, b ServiceDefinition) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+// asMap returns a copy of the service definitions, indexed by ID.
+func (s *serviceDefinitions) asMap() map[string]ServiceDefinition {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return maps.Clone(s.definitions)
+}
+
+func (s *serviceDefinitions) put(definition ServiceDefinition) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.definitions[definition.ID] = definition
+}
+
+func (s *serviceDefinitions) remove(serviceID string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.definitions, serviceID)
+}
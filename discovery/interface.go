@@ -26,6 +26,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Tag is value that references a point in the list.
@@ -88,13 +89,59 @@ var ErrPresentationAlreadyExists = errors.New("presentation already exists")
 // ErrPresentationRegistrationFailed indicates registration of a presentation on a remote Discovery Service failed.
 var ErrPresentationRegistrationFailed = errors.New("registration of Verifiable Presentation on remote Discovery Service failed")
 
+// ErrInvalidServiceDefinition is returned when a service definition that is added or updated at runtime is invalid.
+var ErrInvalidServiceDefinition = errors.New("invalid service definition")
+
+// ErrServiceDefinitionReadOnly is returned when a service definition that is loaded from the definitions directory is added, updated or removed at runtime.
+var ErrServiceDefinitionReadOnly = errors.New("service definition is loaded from the definitions directory and can't be changed")
+
+// ErrPresentationNotFound is returned when a presentation of a subject can't be found on a Discovery Service.
+var ErrPresentationNotFound = errors.New("presentation not found")
+
+// ErrNotBlocked is returned when a subject that isn't blocked on a Discovery Service is unblocked.
+var ErrNotBlocked = errors.New("subject is not blocked")
+
 // Server defines the API for Discovery Servers.
 type Server interface {
 	// Register registers a presentation on the given Discovery Service.
 	// If the presentation is not valid, or it does not conform to the Service ServiceDefinition, it returns an error.
 	Register(serviceID string, presentation vc.VerifiablePresentation) error
 	// Get retrieves the presentations for the given service, starting at the given timestamp.
-	Get(serviceID string, startAt *Tag) ([]vc.VerifiablePresentation, *Tag, error)
+	// The boolean indicates whether the complete list of presentations is returned, instead of only the ones added since the given timestamp.
+	// This is the case if no timestamp is given, or if it can't be decoded, e.g. because presentations were removed from the service.
+	// The client should then replace its copy of the list.
+	Get(serviceID string, startAt *Tag) ([]vc.VerifiablePresentation, *Tag, bool, error)
+}
+
+// Moderator defines the API for moderating the presentations of Discovery Services for which the node is server.
+// The subject of a moderation action is a DID or the ID of a presentation.
+// Moderation actions are audited, with the actor taken from the given context.
+// Removed presentations are removed by clients on their next update.
+// All functions return ErrServerModeDisabled if the node isn't server for the given service.
+type Moderator interface {
+	// RemovePresentations removes the presentations of the given subject from the Discovery Service.
+	// The subject may register again afterwards, unless it's blocked.
+	// It returns ErrPresentationNotFound if there are no presentations of the subject.
+	RemovePresentations(ctx context.Context, serviceID string, subject string, reason string) error
+	// Block removes the presentations of the given subject from the Discovery Service, and prevents it from registering again.
+	Block(ctx context.Context, serviceID string, subject string, reason string) error
+	// Unblock allows the given subject to register on the Discovery Service again.
+	// It returns ErrNotBlocked if the subject isn't blocked.
+	Unblock(ctx context.Context, serviceID string, subject string, reason string) error
+	// Blocks returns the subjects that are blocked on the Discovery Service.
+	Blocks(serviceID string) ([]Block, error)
+}
+
+// Block is a subject (DID or presentation ID) that is blocked from registering on a Discovery Service.
+type Block struct {
+	// Subject is the blocked DID or presentation ID.
+	Subject string `json:"subject"`
+	// Reason is the reason given for blocking the subject.
+	Reason string `json:"reason"`
+	// Actor is the user or service that blocked the subject.
+	Actor string `json:"actor"`
+	// CreatedAt is the time the subject was blocked.
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // Client defines the API for Discovery Clients.
@@ -123,6 +170,22 @@ type Client interface {
 	GetServiceActivation(ctx context.Context, serviceID string, subjectDID did.DID) (bool, *vc.VerifiablePresentation, error)
 }
 
+// DefinitionManager defines the API for managing service definitions while the node is running.
+// Service definitions loaded from the definitions directory can't be changed through this API.
+type DefinitionManager interface {
+	// PutServiceDefinition adds or updates the given service definition (JSON), which is validated against the service definition JSON schema.
+	// If server is true, the node acts as Discovery Server for the service.
+	// When an existing service definition is updated, presentations that no longer fulfill it are removed,
+	// and activated DIDs are registered again on the Discovery Service.
+	// If the role or (for clients) the endpoint changes, all presentations of the service are removed.
+	// It returns ErrInvalidServiceDefinition if the service definition is invalid.
+	PutServiceDefinition(data []byte, server bool) (*ServiceDefinition, error)
+	// RemoveServiceDefinition removes the service definition with the given ID, including its presentations and activated DIDs.
+	// Presentations registered on a remote Discovery Server aren't retracted: they're removed by the server when they expire.
+	// It returns ErrServiceNotFound if the service definition doesn't exist.
+	RemoveServiceDefinition(serviceID string) error
+}
+
 // SearchResult is a single result of a search operation.
 type SearchResult struct {
 	// Presentation is the Verifiable Presentation that was matched.
@@ -42,13 +42,14 @@ func (m *MockServer) EXPECT() *MockServerMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockServer) Get(serviceID string, startAt *Tag) ([]vc.VerifiablePresentation, *Tag, error) {
+func (m *MockServer) Get(serviceID string, startAt *Tag) ([]vc.VerifiablePresentation, *Tag, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", serviceID, startAt)
 	ret0, _ := ret[0].([]vc.VerifiablePresentation)
 	ret1, _ := ret[1].(*Tag)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // Get indicates an expected call of Get.
@@ -71,6 +72,86 @@ func (mr *MockServerMockRecorder) Register(serviceID, presentation any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockServer)(nil).Register), serviceID, presentation)
 }
 
+// MockModerator is a mock of Moderator interface.
+type MockModerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockModeratorMockRecorder
+}
+
+// MockModeratorMockRecorder is the mock recorder for MockModerator.
+type MockModeratorMockRecorder struct {
+	mock *MockModerator
+}
+
+// NewMockModerator creates a new mock instance.
+func NewMockModerator(ctrl *gomock.Controller) *MockModerator {
+	mock := &MockModerator{ctrl: ctrl}
+	mock.recorder = &MockModeratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerator) EXPECT() *MockModeratorMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockModerator) Block(ctx context.Context, serviceID, subject, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, serviceID, subject, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockModeratorMockRecorder) Block(ctx, serviceID, subject, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockModerator)(nil).Block), ctx, serviceID, subject, reason)
+}
+
+// Blocks mocks base method.
+func (m *MockModerator) Blocks(serviceID string) ([]Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Blocks", serviceID)
+	ret0, _ := ret[0].([]Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Blocks indicates an expected call of Blocks.
+func (mr *MockModeratorMockRecorder) Blocks(serviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blocks", reflect.TypeOf((*MockModerator)(nil).Blocks), serviceID)
+}
+
+// RemovePresentations mocks base method.
+func (m *MockModerator) RemovePresentations(ctx context.Context, serviceID, subject, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePresentations", ctx, serviceID, subject, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePresentations indicates an expected call of RemovePresentations.
+func (mr *MockModeratorMockRecorder) RemovePresentations(ctx, serviceID, subject, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePresentations", reflect.TypeOf((*MockModerator)(nil).RemovePresentations), ctx, serviceID, subject, reason)
+}
+
+// Unblock mocks base method.
+func (m *MockModerator) Unblock(ctx context.Context, serviceID, subject, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unblock", ctx, serviceID, subject, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unblock indicates an expected call of Unblock.
+func (mr *MockModeratorMockRecorder) Unblock(ctx, serviceID, subject, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unblock", reflect.TypeOf((*MockModerator)(nil).Unblock), ctx, serviceID, subject, reason)
+}
+
 // MockClient is a mock of Client interface.
 type MockClient struct {
 	ctrl     *gomock.Controller
@@ -166,3 +247,55 @@ func (mr *MockClientMockRecorder) Services() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Services", reflect.TypeOf((*MockClient)(nil).Services))
 }
+
+// MockDefinitionManager is a mock of DefinitionManager interface.
+type MockDefinitionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockDefinitionManagerMockRecorder
+}
+
+// MockDefinitionManagerMockRecorder is the mock recorder for MockDefinitionManager.
+type MockDefinitionManagerMockRecorder struct {
+	mock *MockDefinitionManager
+}
+
+// NewMockDefinitionManager creates a new mock instance.
+func NewMockDefinitionManager(ctrl *gomock.Controller) *MockDefinitionManager {
+	mock := &MockDefinitionManager{ctrl: ctrl}
+	mock.recorder = &MockDefinitionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDefinitionManager) EXPECT() *MockDefinitionManagerMockRecorder {
+	return m.recorder
+}
+
+// PutServiceDefinition mocks base method.
+func (m *MockDefinitionManager) PutServiceDefinition(data []byte, server bool) (*ServiceDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutServiceDefinition", data, server)
+	ret0, _ := ret[0].(*ServiceDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutServiceDefinition indicates an expected call of PutServiceDefinition.
+func (mr *MockDefinitionManagerMockRecorder) PutServiceDefinition(data, server any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutServiceDefinition", reflect.TypeOf((*MockDefinitionManager)(nil).PutServiceDefinition), data, server)
+}
+
+// RemoveServiceDefinition mocks base method.
+func (m *MockDefinitionManager) RemoveServiceDefinition(serviceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveServiceDefinition", serviceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveServiceDefinition indicates an expected call of RemoveServiceDefinition.
+func (mr *MockDefinitionManagerMockRecorder) RemoveServiceDefinition(serviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveServiceDefinition", reflect.TypeOf((*MockDefinitionManager)(nil).RemoveServiceDefinition), serviceID)
+}
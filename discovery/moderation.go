@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/discovery/log"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// RemovePresentations is a Discovery Server function that removes the presentations of a subject from a Discovery Service.
+// See interface.go for more information.
+func (m *Module) RemovePresentations(ctx context.Context, serviceID string, subject string, reason string) error {
+	if _, isServer := m.serverDefinitions.get(serviceID); !isServer {
+		return ErrServerModeDisabled
+	}
+	removed, err := m.store.removeFromServer(serviceID, subject)
+	if err != nil {
+		return fmt.Errorf("unable to remove presentations: %w", err)
+	}
+	if removed == 0 {
+		return ErrPresentationNotFound
+	}
+	auditRemoval(ctx, serviceID, subject, reason)
+	return nil
+}
+
+// Block is a Discovery Server function that blocks a subject from registering on a Discovery Service.
+// See interface.go for more information.
+func (m *Module) Block(ctx context.Context, serviceID string, subject string, reason string) error {
+	if _, isServer := m.serverDefinitions.get(serviceID); !isServer {
+		return ErrServerModeDisabled
+	}
+	var actor string
+	if info := audit.InfoFromContext(ctx); info != nil {
+		actor = info.Actor
+	}
+	removed, err := m.store.block(blockRecord{
+		ServiceID: serviceID,
+		Subject:   subject,
+		Reason:    reason,
+		Actor:     actor,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to block subject: %w", err)
+	}
+	audit.Log(ctx, log.Logger().WithField("discoveryService", serviceID), audit.DiscoverySubjectBlockedEvent).
+		Infof("Blocked subject on Discovery Service (subject=%s, reason=%s)", subject, reason)
+	if removed > 0 {
+		auditRemoval(ctx, serviceID, subject, reason)
+	}
+	return nil
+}
+
+// Unblock is a Discovery Server function that allows a blocked subject to register on a Discovery Service again.
+// See interface.go for more information.
+func (m *Module) Unblock(ctx context.Context, serviceID string, subject string, reason string) error {
+	if _, isServer := m.serverDefinitions.get(serviceID); !isServer {
+		return ErrServerModeDisabled
+	}
+	unblocked, err := m.store.unblock(serviceID, subject)
+	if err != nil {
+		return fmt.Errorf("unable to unblock subject: %w", err)
+	}
+	if !unblocked {
+		return ErrNotBlocked
+	}
+	audit.Log(ctx, log.Logger().WithField("discoveryService", serviceID), audit.DiscoverySubjectUnblockedEvent).
+		Infof("Unblocked subject on Discovery Service (subject=%s, reason=%s)", subject, reason)
+	return nil
+}
+
+// Blocks is a Discovery Server function that returns the subjects that are blocked on a Discovery Service.
+// See interface.go for more information.
+func (m *Module) Blocks(serviceID string) ([]Block, error) {
+	if _, isServer := m.serverDefinitions.get(serviceID); !isServer {
+		return nil, ErrServerModeDisabled
+	}
+	rows, err := m.store.getBlocks(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Block, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, Block{
+			Subject:   row.Subject,
+			Reason:    row.Reason,
+			Actor:     row.Actor,
+			CreatedAt: time.Unix(row.CreatedAt, 0),
+		})
+	}
+	return result, nil
+}
+
+// recheck periodically verifies the presentations registered on the Discovery Services the node is server for (see recheckPresentations).
+func (m *Module) recheck() {
+	ticker := time.NewTicker(m.config.Server.RecheckInterval)
+	defer ticker.Stop()
+	ctx := audit.Context(m.ctx, "app", ModuleName, "RecheckPresentations")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.recheckPresentations(ctx); err != nil {
+				log.Logger().WithError(err).Errorf("Failed to verify Verifiable Presentations registered on Discovery Server")
+			}
+		}
+	}
+}
+
+// recheckPresentations verifies the presentations registered on the Discovery Services the node is server for again,
+// using the checks of the registration that still apply after it: fulfilling the service definition, signatures and revocation status.
+// Like at registration, issuer trust is determined by the service definition, not the node's trust store.
+// Only presentations that are definitively invalid are removed (see isDefinitelyInvalid),
+// e.g. because a credential was revoked or an issuer's DID was deactivated.
+// Other failures (e.g. a DID that can't be resolved due to a network failure) are logged, and checked again in the next run.
+func (m *Module) recheckPresentations(ctx context.Context) error {
+	var result error
+	now := time.Now()
+	for _, definition := range m.serverDefinitions.all() {
+		presentations, err := m.store.getAll(definition.ID)
+		if err != nil {
+			result = errors.Join(result, err)
+			continue
+		}
+		var invalid []string
+		reasons := make(map[string]error)
+		for _, presentation := range presentations {
+			// Retractions don't contain credentials, and expired presentations are pruned anyway.
+			if presentation.IsType(retractionPresentationType) || !presentation.JWT().Expiration().After(now) {
+				continue
+			}
+			presentationID := presentation.ID.String()
+			err := m.validateRegistration(definition, presentation)
+			if err == nil {
+				_, err = m.vcrInstance.Verifier().VerifyVP(presentation, true, true, nil)
+				if err != nil && !isDefinitelyInvalid(err) {
+					log.Logger().WithError(err).Warnf("Couldn't verify presentation registered on Discovery Service, will retry (service=%s, id=%s)", definition.ID, presentationID)
+					continue
+				}
+			}
+			if err != nil {
+				invalid = append(invalid, presentationID)
+				reasons[presentationID] = err
+			}
+		}
+		if len(invalid) == 0 {
+			continue
+		}
+		// Remove all invalid presentations at once, so the tag prefix changes (and clients reload the complete list) only once.
+		removed, err := m.store.removePresentationsFromServer(definition.ID, invalid)
+		if err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to remove invalid presentations (service=%s): %w", definition.ID, err))
+			continue
+		}
+		for _, presentationID := range removed {
+			auditRemoval(ctx, definition.ID, presentationID, fmt.Sprintf("presentation is no longer valid: %s", reasons[presentationID]))
+		}
+	}
+	return result
+}
+
+// isDefinitelyInvalid returns whether the presentation verification error means the presentation won't become valid again:
+// a credential was revoked or suspended, or the DID of the holder or an issuer was deactivated.
+func isDefinitelyInvalid(err error) bool {
+	return errors.Is(err, types.ErrRevoked) ||
+		errors.Is(err, types.ErrSuspended) ||
+		errors.Is(err, resolver.ErrDeactivated)
+}
+
+func auditRemoval(ctx context.Context, serviceID string, subject string, reason string) {
+	audit.Log(ctx, log.Logger().WithField("discoveryService", serviceID), audit.DiscoveryPresentationRemovedEvent).
+		Infof("Removed presentations from Discovery Service (subject=%s, reason=%s)", subject, reason)
+}
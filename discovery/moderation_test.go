@@ -0,0 +1,289 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestModule_RemovePresentations(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	ctx := audit.TestContext()
+
+	t.Run("ok", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+		auditLogs := audit.CaptureLogs(t)
+
+		err := m.RemovePresentations(ctx, testServiceID, aliceDID.String(), "spam")
+
+		require.NoError(t, err)
+		exists, err := m.store.exists(testServiceID, aliceDID.String(), vpAlice.ID.String())
+		require.NoError(t, err)
+		assert.False(t, exists)
+		auditLogs.AssertContains(t, ModuleName, audit.DiscoveryPresentationRemovedEvent, audit.TestActor,
+			"Removed presentations from Discovery Service (subject="+aliceDID.String()+", reason=spam)")
+	})
+	t.Run("no presentations of subject", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		err := m.RemovePresentations(ctx, testServiceID, aliceDID.String(), "spam")
+
+		assert.ErrorIs(t, err, ErrPresentationNotFound)
+	})
+	t.Run("not a server", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		err := m.RemovePresentations(ctx, "other", aliceDID.String(), "spam")
+
+		assert.ErrorIs(t, err, ErrServerModeDisabled)
+	})
+}
+
+func TestModule_Block(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	ctx := audit.TestContext()
+
+	t.Run("DID", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		presentationVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+		auditLogs := audit.CaptureLogs(t)
+
+		err := m.Block(ctx, testServiceID, aliceDID.String(), "spam")
+
+		require.NoError(t, err)
+		exists, err := m.store.exists(testServiceID, aliceDID.String(), vpAlice.ID.String())
+		require.NoError(t, err)
+		assert.False(t, exists)
+		auditLogs.AssertContains(t, ModuleName, audit.DiscoverySubjectBlockedEvent, audit.TestActor,
+			"Blocked subject on Discovery Service (subject="+aliceDID.String()+", reason=spam)")
+		auditLogs.AssertContains(t, ModuleName, audit.DiscoveryPresentationRemovedEvent, audit.TestActor,
+			"Removed presentations from Discovery Service (subject="+aliceDID.String()+", reason=spam)")
+		t.Run("listed", func(t *testing.T) {
+			blocks, err := m.Blocks(testServiceID)
+
+			require.NoError(t, err)
+			require.Len(t, blocks, 1)
+			assert.Equal(t, aliceDID.String(), blocks[0].Subject)
+			assert.Equal(t, "spam", blocks[0].Reason)
+			assert.Equal(t, audit.TestActor, blocks[0].Actor)
+			assert.False(t, blocks[0].CreatedAt.IsZero())
+		})
+		t.Run("can't register again", func(t *testing.T) {
+			err := m.Register(testServiceID, vpAlice)
+
+			assert.ErrorIs(t, err, ErrInvalidPresentation)
+			assert.ErrorIs(t, err, errPresentationBlocked)
+		})
+	})
+	t.Run("presentation", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		presentationVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Times(2)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+
+		err := m.Block(ctx, testServiceID, vpAlice.ID.String(), "replayed")
+
+		require.NoError(t, err)
+		t.Run("can't register presentation again", func(t *testing.T) {
+			err := m.Register(testServiceID, vpAlice)
+
+			assert.ErrorIs(t, err, errPresentationBlocked)
+		})
+		t.Run("subject can register a new presentation", func(t *testing.T) {
+			newVP := createPresentationCustom(aliceDID, func(claims map[string]interface{}, _ *vc.VerifiablePresentation) {
+				claims[jwt.AudienceKey] = []string{testServiceID}
+			}, vcAlice)
+
+			err := m.Register(testServiceID, newVP)
+
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("not a server", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		err := m.Block(ctx, "other", aliceDID.String(), "spam")
+		assert.ErrorIs(t, err, ErrServerModeDisabled)
+		_, err = m.Blocks("other")
+		assert.ErrorIs(t, err, ErrServerModeDisabled)
+	})
+}
+
+func TestModule_Unblock(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	ctx := audit.TestContext()
+
+	t.Run("ok", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		presentationVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil)
+		require.NoError(t, m.Block(ctx, testServiceID, aliceDID.String(), "spam"))
+		auditLogs := audit.CaptureLogs(t)
+
+		err := m.Unblock(ctx, testServiceID, aliceDID.String(), "resolved")
+
+		require.NoError(t, err)
+		auditLogs.AssertContains(t, ModuleName, audit.DiscoverySubjectUnblockedEvent, audit.TestActor,
+			"Unblocked subject on Discovery Service (subject="+aliceDID.String()+", reason=resolved)")
+		blocks, err := m.Blocks(testServiceID)
+		require.NoError(t, err)
+		assert.Empty(t, blocks)
+		assert.NoError(t, m.Register(testServiceID, vpAlice))
+	})
+	t.Run("not blocked", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		err := m.Unblock(ctx, testServiceID, aliceDID.String(), "resolved")
+
+		assert.ErrorIs(t, err, ErrNotBlocked)
+	})
+	t.Run("not a server", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		err := m.Unblock(ctx, "other", aliceDID.String(), "resolved")
+
+		assert.ErrorIs(t, err, ErrServerModeDisabled)
+	})
+}
+
+func TestModule_recheckPresentations(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	ctx := audit.TestContext()
+
+	isPresentation := func(expected vc.VerifiablePresentation) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			return x.(vc.VerifiablePresentation).ID.String() == expected.ID.String()
+		})
+	}
+	t.Run("invalid presentation is removed", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+		require.NoError(t, m.store.add(testServiceID, vpBob, ""))
+		_, tag, _, err := m.Get(testServiceID, nil)
+		require.NoError(t, err)
+		presentationVerifier.EXPECT().VerifyVP(isPresentation(vpAlice), true, true, nil).Return(nil, types.ErrRevoked)
+		presentationVerifier.EXPECT().VerifyVP(isPresentation(vpBob), true, true, nil)
+		auditLogs := audit.CaptureLogs(t)
+
+		err = m.recheckPresentations(ctx)
+
+		require.NoError(t, err)
+		presentations, _, complete, err := m.Get(testServiceID, tag)
+		require.NoError(t, err)
+		assert.True(t, complete, "clients should load the complete list")
+		assert.Equal(t, []vc.VerifiablePresentation{vpBob}, presentations)
+		auditLogs.AssertContains(t, ModuleName, audit.DiscoveryPresentationRemovedEvent, audit.TestActor,
+			"Removed presentations from Discovery Service (subject="+vpAlice.ID.String()+", reason=presentation is no longer valid: credential is revoked)")
+	})
+	t.Run("suspended credential and deactivated DID", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+		require.NoError(t, m.store.add(testServiceID, vpBob, ""))
+		presentationVerifier.EXPECT().VerifyVP(isPresentation(vpAlice), true, true, nil).Return(nil, fmt.Errorf("invalid VC: %w", types.ErrSuspended))
+		presentationVerifier.EXPECT().VerifyVP(isPresentation(vpBob), true, true, nil).Return(nil, fmt.Errorf("could not validate issuer: %w", resolver.ErrDeactivated))
+
+		err := m.recheckPresentations(ctx)
+
+		require.NoError(t, err)
+		presentations, err := m.store.getAll(testServiceID)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+	})
+	t.Run("presentation of issuer not in trust store is kept, like at registration", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		presentationVerifier.EXPECT().VerifyVP(isPresentation(vpAlice), true, true, nil).Times(2)
+		require.NoError(t, m.Register(testServiceID, vpAlice))
+
+		err := m.recheckPresentations(ctx)
+
+		require.NoError(t, err)
+		presentations, err := m.store.getAll(testServiceID)
+		require.NoError(t, err)
+		assert.Len(t, presentations, 1)
+	})
+	t.Run("presentation is kept if verification fails temporarily", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+		_, tag, _, err := m.Get(testServiceID, nil)
+		require.NoError(t, err)
+		presentationVerifier.EXPECT().VerifyVP(isPresentation(vpAlice), true, true, nil).Return(nil, errors.New("could not validate issuer: connection refused"))
+
+		err = m.recheckPresentations(ctx)
+
+		require.NoError(t, err)
+		presentations, _, complete, err := m.Get(testServiceID, tag)
+		require.NoError(t, err)
+		assert.False(t, complete, "tag prefix should not change")
+		assert.Empty(t, presentations)
+		all, err := m.store.getAll(testServiceID)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+	t.Run("presentation no longer fulfills service definition", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+		definition, _ := m.serverDefinitions.get(testServiceID)
+		otherIssuerPattern := "did:other:*"
+		definition.PresentationDefinition.InputDescriptors[0].Constraints.Fields[0].Filter = &pe.Filter{
+			Type:    "string",
+			Pattern: &otherIssuerPattern,
+		}
+		m.serverDefinitions.put(definition)
+
+		err := m.recheckPresentations(ctx)
+
+		require.NoError(t, err)
+		presentations, err := m.store.getAll(testServiceID)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+	})
+	t.Run("retractions aren't verified again", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		vpAliceRetract := createPresentationCustom(aliceDID, func(claims map[string]interface{}, vp *vc.VerifiablePresentation) {
+			vp.Type = append(vp.Type, retractionPresentationType)
+			claims["retract_jti"] = vpAlice.ID.String()
+			claims[jwt.AudienceKey] = []string{testServiceID}
+		})
+		require.NoError(t, m.store.add(testServiceID, vpAliceRetract, ""))
+
+		err := m.recheckPresentations(ctx)
+
+		require.NoError(t, err)
+		presentations, err := m.store.getAll(testServiceID)
+		require.NoError(t, err)
+		assert.Len(t, presentations, 1)
+	})
+}
@@ -58,6 +58,7 @@ var (
 	errRetractionReferencesUnknownPresentation = errors.New("retraction presentation refers to a non-existing presentation")
 	errRetractionContainsCredentials           = errors.New("retraction presentation must not contain credentials")
 	errInvalidRetractionJTIClaim               = errors.New("invalid/missing 'retract_jti' claim for retraction presentation")
+	errPresentationBlocked                     = errors.New("presentation or its subject is blocked on the Discovery Service")
 )
 
 var _ core.Injectable = &Module{}
@@ -65,6 +66,8 @@ var _ core.Runnable = &Module{}
 var _ core.Configurable = &Module{}
 var _ Server = &Module{}
 var _ Client = &Module{}
+var _ DefinitionManager = &Module{}
+var _ Moderator = &Module{}
 
 var retractionPresentationType = ssi.MustParseURI("RetractedVerifiablePresentation")
 
@@ -87,47 +90,54 @@ type Module struct {
 	storageInstance     storage.Engine
 	store               *sqlStore
 	registrationManager clientRegistrationManager
-	serverDefinitions   map[string]ServiceDefinition
-	allDefinitions      map[string]ServiceDefinition
-	vcrInstance         vcr.VCR
-	documentOwner       management.DocumentOwner
-	clientUpdater       *clientUpdater
-	ctx                 context.Context
-	cancel              context.CancelFunc
-	routines            *sync.WaitGroup
+	serverDefinitions   *serviceDefinitions
+	allDefinitions      *serviceDefinitions
+	// directoryDefinitions contains the service definitions loaded from the definitions directory.
+	// They can't be changed at runtime.
+	directoryDefinitions map[string]ServiceDefinition
+	// definitionsMux serializes changes to service definitions at runtime.
+	definitionsMux sync.Mutex
+	vcrInstance    vcr.VCR
+	documentOwner  management.DocumentOwner
+	clientUpdater  *clientUpdater
+	ctx            context.Context
+	cancel         context.CancelFunc
+	routines       *sync.WaitGroup
 }
 
 func (m *Module) Configure(serverConfig core.ServerConfig) error {
-	if m.config.Definitions.Directory == "" {
-		return nil
-	}
-	var err error
-	m.allDefinitions, err = loadDefinitions(m.config.Definitions.Directory)
-	if err != nil {
-		return err
-	}
-	if len(m.config.Server.DefinitionIDs) > 0 {
+	m.httpClient = client.New(serverConfig.Strictmode, serverConfig.HTTPClient.Timeout, nil)
+	m.directoryDefinitions = make(map[string]ServiceDefinition)
+	serverDefinitions := make(map[string]ServiceDefinition)
+	if m.config.Definitions.Directory != "" {
+		var err error
+		m.directoryDefinitions, err = loadDefinitions(m.config.Definitions.Directory)
+		if err != nil {
+			return err
+		}
 		// Get the definitions that are enabled for this server
-		serverDefinitions := make(map[string]ServiceDefinition)
 		for _, definitionID := range m.config.Server.DefinitionIDs {
-			if definition, exists := m.allDefinitions[definitionID]; !exists {
+			if definition, exists := m.directoryDefinitions[definitionID]; !exists {
 				return fmt.Errorf("service definition '%s' not found", definitionID)
 			} else {
 				serverDefinitions[definitionID] = definition
 			}
 		}
-		m.serverDefinitions = serverDefinitions
 	}
-	m.httpClient = client.New(serverConfig.Strictmode, serverConfig.HTTPClient.Timeout, nil)
+	m.allDefinitions = newServiceDefinitions(m.directoryDefinitions)
+	m.serverDefinitions = newServiceDefinitions(serverDefinitions)
 	return nil
 }
 
 func (m *Module) Start() error {
 	var err error
-	m.store, err = newSQLStore(m.storageInstance.GetSQLDatabase(), m.allDefinitions, m.serverDefinitions)
+	m.store, err = newSQLStore(m.storageInstance.GetSQLDatabase(), m.allDefinitions.asMap(), m.serverDefinitions.asMap())
 	if err != nil {
 		return err
 	}
+	if err = m.loadManagedDefinitions(); err != nil {
+		return err
+	}
 	m.clientUpdater = newClientUpdater(m.allDefinitions, m.store, m.verifyRegistration, m.httpClient)
 	m.registrationManager = newRegistrationManager(m.allDefinitions, m.store, m.httpClient, m.vcrInstance)
 	if m.config.Client.RefreshInterval > 0 {
@@ -137,6 +147,13 @@ func (m *Module) Start() error {
 			m.update()
 		}()
 	}
+	if m.config.Server.RecheckInterval > 0 {
+		m.routines.Add(1)
+		go func() {
+			defer m.routines.Done()
+			m.recheck()
+		}()
+	}
 	return nil
 }
 
@@ -158,13 +175,21 @@ func (m *Module) Config() interface{} {
 // See interface.go for more information.
 func (m *Module) Register(serviceID string, presentation vc.VerifiablePresentation) error {
 	// First, simple sanity checks
-	definition, isServer := m.serverDefinitions[serviceID]
+	definition, isServer := m.serverDefinitions.get(serviceID)
 	if !isServer {
 		return ErrServerModeDisabled
 	}
 	if err := m.verifyRegistration(definition, presentation); err != nil {
 		return err
 	}
+	signerDID, _ := credential.PresentationSigner(presentation) // checked before
+	blocked, err := m.store.isBlocked(definition.ID, signerDID.String(), presentation.ID.String())
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errors.Join(ErrInvalidPresentation, errPresentationBlocked)
+	}
 	return m.store.add(definition.ID, presentation, "")
 }
 
@@ -263,9 +288,9 @@ func (m *Module) validateRetraction(serviceID string, presentation vc.Verifiable
 
 // Get is a Discovery Server function that retrieves the presentations for the given service, starting at the given tag.
 // See interface.go for more information.
-func (m *Module) Get(serviceID string, tag *Tag) ([]vc.VerifiablePresentation, *Tag, error) {
-	if _, exists := m.serverDefinitions[serviceID]; !exists {
-		return nil, nil, ErrServerModeDisabled
+func (m *Module) Get(serviceID string, tag *Tag) ([]vc.VerifiablePresentation, *Tag, bool, error) {
+	if _, exists := m.serverDefinitions.get(serviceID); !exists {
+		return nil, nil, false, ErrServerModeDisabled
 	}
 	return m.store.get(serviceID, tag)
 }
@@ -286,7 +311,8 @@ func (m *Module) ActivateServiceForDID(ctx context.Context, serviceID string, su
 		log.Logger().WithError(err).Warnf("Presentation registration failed, will be retried later (did=%s,service=%s)", subjectDID, serviceID)
 	} else if err == nil {
 		log.Logger().Infof("Successfully activated service for DID (did=%s,service=%s)", subjectDID, serviceID)
-		_ = m.clientUpdater.updateService(ctx, m.allDefinitions[serviceID])
+		definition, _ := m.allDefinitions.get(serviceID)
+		_ = m.clientUpdater.updateService(ctx, definition)
 	}
 	return err
 }
@@ -299,11 +325,7 @@ func (m *Module) DeactivateServiceForDID(ctx context.Context, serviceID string,
 }
 
 func (m *Module) Services() []ServiceDefinition {
-	result := make([]ServiceDefinition, 0, len(m.allDefinitions))
-	for _, definition := range m.allDefinitions {
-		result = append(result, definition)
-	}
-	return result
+	return m.allDefinitions.all()
 }
 
 // GetServiceActivation is a Discovery Client function that retrieves the activation status of a service for a DID.
@@ -356,10 +378,143 @@ func loadDefinitions(directory string) (map[string]ServiceDefinition, error) {
 	return result, nil
 }
 
+// loadManagedDefinitions loads the service definitions that are managed at runtime (see PutServiceDefinition) from the store.
+// It's called on startup and on each client refresh, so definitions added, updated or removed by other nodes sharing the database are applied:
+// managed definitions that are no longer in the store are removed.
+// If a service definition with the same ID was loaded from the definitions directory, that one takes precedence.
+func (m *Module) loadManagedDefinitions() error {
+	m.definitionsMux.Lock()
+	defer m.definitionsMux.Unlock()
+	services, err := m.store.getManagedServices()
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]bool)
+	for _, service := range services {
+		if _, exists := m.directoryDefinitions[service.ID]; exists {
+			log.Logger().Warnf("Service definition managed at runtime is ignored, since it's also loaded from the definitions directory (service=%s)", service.ID)
+			continue
+		}
+		// keep the current definition if the stored one can't be parsed
+		stored[service.ID] = true
+		definition, err := ParseServiceDefinition([]byte(*service.Definition))
+		if err != nil {
+			log.Logger().WithError(err).Errorf("Unable to parse stored service definition, ignoring it (service=%s)", service.ID)
+			continue
+		}
+		m.allDefinitions.put(*definition)
+		if service.IsServer {
+			m.serverDefinitions.put(*definition)
+		} else {
+			m.serverDefinitions.remove(definition.ID)
+		}
+	}
+	for _, definition := range m.allDefinitions.all() {
+		if _, exists := m.directoryDefinitions[definition.ID]; exists || stored[definition.ID] {
+			continue
+		}
+		m.serverDefinitions.remove(definition.ID)
+		m.allDefinitions.remove(definition.ID)
+		log.Logger().Infof("Service definition was removed from the store, unloaded it (service=%s)", definition.ID)
+	}
+	return nil
+}
+
+// PutServiceDefinition adds or updates a service definition at runtime.
+// See interface.go for more information.
+func (m *Module) PutServiceDefinition(data []byte, server bool) (*ServiceDefinition, error) {
+	definition, err := ParseServiceDefinition(data)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidServiceDefinition, err)
+	}
+	if _, exists := m.directoryDefinitions[definition.ID]; exists {
+		return nil, ErrServiceDefinitionReadOnly
+	}
+	m.definitionsMux.Lock()
+	defer m.definitionsMux.Unlock()
+	previous, isUpdate := m.allDefinitions.get(definition.ID)
+	_, wasServer := m.serverDefinitions.get(definition.ID)
+	if err = m.store.putService(*definition, data, server); err != nil {
+		return nil, fmt.Errorf("unable to store service definition: %w", err)
+	}
+	m.allDefinitions.put(*definition)
+	if server {
+		m.serverDefinitions.put(*definition)
+	} else {
+		m.serverDefinitions.remove(definition.ID)
+	}
+	if isUpdate {
+		if err = m.reconcilePresentations(previous, wasServer, *definition, server); err != nil {
+			return nil, fmt.Errorf("unable to apply updated service definition to presentations: %w", err)
+		}
+	}
+	log.Logger().Infof("Stored service definition (service=%s, server=%t)", definition.ID, server)
+	return definition, nil
+}
+
+// reconcilePresentations makes sure the stored presentations of a service match its updated definition.
+// If the role of the node or the Discovery Server changed, the presentations came from another list, so they're all removed.
+// Otherwise, only presentations that no longer fulfill the updated definition are removed.
+// If the node is server for the service, the removal changes the tag prefix, so clients load the complete list and see the removal.
+// In both cases, DIDs activated on the service are registered again on the next refresh.
+func (m *Module) reconcilePresentations(previous ServiceDefinition, wasServer bool, updated ServiceDefinition, isServer bool) error {
+	if wasServer != isServer || (!isServer && previous.Endpoint != updated.Endpoint) {
+		if err := m.store.resetService(updated.ID); err != nil {
+			return err
+		}
+	} else {
+		presentations, err := m.store.getAll(updated.ID)
+		if err != nil {
+			return err
+		}
+		var invalid []string
+		for _, presentation := range presentations {
+			if presentation.IsType(retractionPresentationType) {
+				continue
+			}
+			if err := m.validateRegistration(updated, presentation); err != nil {
+				log.Logger().WithError(err).Infof("Removing presentation that doesn't fulfill the updated service definition (service=%s, id=%s)", updated.ID, presentation.ID)
+				if isServer {
+					invalid = append(invalid, presentation.ID.String())
+				} else if err := m.store.removePresentation(updated.ID, presentation.ID.String()); err != nil {
+					return err
+				}
+			}
+		}
+		// remove all at once, so the tag prefix changes only once
+		if len(invalid) > 0 {
+			if _, err := m.store.removePresentationsFromServer(updated.ID, invalid); err != nil {
+				return err
+			}
+		}
+	}
+	return m.store.refreshRegistrations(updated.ID)
+}
+
+// RemoveServiceDefinition removes a service definition at runtime.
+// See interface.go for more information.
+func (m *Module) RemoveServiceDefinition(serviceID string) error {
+	if _, exists := m.directoryDefinitions[serviceID]; exists {
+		return ErrServiceDefinitionReadOnly
+	}
+	m.definitionsMux.Lock()
+	defer m.definitionsMux.Unlock()
+	if _, exists := m.allDefinitions.get(serviceID); !exists {
+		return ErrServiceNotFound
+	}
+	if err := m.store.removeService(serviceID); err != nil {
+		return fmt.Errorf("unable to remove service definition: %w", err)
+	}
+	m.serverDefinitions.remove(serviceID)
+	m.allDefinitions.remove(serviceID)
+	log.Logger().Infof("Removed service definition (service=%s)", serviceID)
+	return nil
+}
+
 // Search is a Discovery Client function that searches for presentations which credential(s) match the given query.
 // See interface.go for more information.
 func (m *Module) Search(serviceID string, query map[string]string) ([]SearchResult, error) {
-	service, exists := m.allDefinitions[serviceID]
+	service, exists := m.allDefinitions.get(serviceID)
 	if !exists {
 		return nil, ErrServiceNotFound
 	}
@@ -398,8 +553,13 @@ func (m *Module) update() {
 	defer ticker.Stop()
 	ctx := audit.Context(m.ctx, "app", ModuleName, "RefreshDiscoveryClient")
 	do := func() {
-		// Refresh registrations first, to make sure we have (our own) latest presentations when we load them from the Discovery Service
-		err := m.registrationManager.refresh(ctx, time.Now())
+		// Load the service definitions managed at runtime first, since they might have been changed through another node
+		err := m.loadManagedDefinitions()
+		if err != nil {
+			log.Logger().WithError(err).Errorf("Failed to load service definitions managed at runtime")
+		}
+		// Then refresh registrations, to make sure we have (our own) latest presentations when we load them from the Discovery Service
+		err = m.registrationManager.refresh(ctx, time.Now())
 		if err != nil {
 			log.Logger().WithError(err).Errorf("Failed to refresh own Verifiable Presentations on Discovery Service")
 		}
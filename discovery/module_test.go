@@ -22,6 +22,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/core"
@@ -64,7 +65,7 @@ func Test_Module_Register(t *testing.T) {
 		err := m.Register(testServiceID, vpAlice)
 		require.EqualError(t, err, "presentation is invalid for registration\npresentation verification failed: failed")
 
-		_, tag, err := m.Get(testServiceID, nil)
+		_, tag, _, err := m.Get(testServiceID, nil)
 		require.NoError(t, err)
 		expectedTag := tagForTimestamp(t, m.store, testServiceID, 0)
 		assert.Equal(t, expectedTag, *tag)
@@ -80,10 +81,10 @@ func Test_Module_Register(t *testing.T) {
 	})
 	t.Run("valid for too long", func(t *testing.T) {
 		m, _, _ := setupModule(t, storageEngine, func(module *Module) {
-			def := module.allDefinitions[testServiceID]
+			def, _ := module.allDefinitions.get(testServiceID)
 			def.PresentationMaxValidity = 1
-			module.allDefinitions[testServiceID] = def
-			module.serverDefinitions[testServiceID] = def
+			module.allDefinitions.put(def)
+			module.serverDefinitions.put(def)
 		})
 		err := m.Register(testServiceID, vpAlice)
 		assert.EqualError(t, err, "presentation is invalid for registration\npresentation is valid for too long (max 1s)")
@@ -120,7 +121,7 @@ func Test_Module_Register(t *testing.T) {
 			err := m.Register(testServiceID, vpAlice)
 			require.NoError(t, err)
 
-			_, tag, err := m.Get(testServiceID, nil)
+			_, tag, _, err := m.Get(testServiceID, nil)
 			require.NoError(t, err)
 			assert.Equal(t, "1", string(*tag)[tagPrefixLength:])
 		})
@@ -147,7 +148,7 @@ func Test_Module_Register(t *testing.T) {
 			err := m.Register(testServiceID, otherVP)
 			require.ErrorContains(t, err, "presentation does not fulfill Presentation ServiceDefinition")
 
-			_, tag, _ := m.Get(testServiceID, nil)
+			_, tag, _, _ := m.Get(testServiceID, nil)
 			assert.Equal(t, "0", string(*tag)[tagPrefixLength:])
 		})
 	})
@@ -218,21 +219,39 @@ func Test_Module_Get(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		m, _, _ := setupModule(t, storageEngine)
 		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
-		presentations, tag, err := m.Get(testServiceID, nil)
+		presentations, tag, complete, err := m.Get(testServiceID, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, []vc.VerifiablePresentation{vpAlice}, presentations)
 		assert.Equal(t, "1", string(*tag)[tagPrefixLength:])
+		assert.True(t, complete)
 	})
 	t.Run("ok - retrieve delta", func(t *testing.T) {
 		m, _, _ := setupModule(t, storageEngine)
 		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
-		presentations, _, err := m.Get(testServiceID, nil)
+		_, tag, _, err := m.Get(testServiceID, nil)
 		require.NoError(t, err)
-		require.Len(t, presentations, 1)
+		require.NoError(t, m.store.add(testServiceID, vpBob, ""))
+
+		presentations, _, complete, err := m.Get(testServiceID, tag)
+
+		require.NoError(t, err)
+		assert.Equal(t, []vc.VerifiablePresentation{vpBob}, presentations)
+		assert.False(t, complete)
+	})
+	t.Run("ok - tag can't be decoded", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		require.NoError(t, m.store.add(testServiceID, vpAlice, ""))
+		tag := Tag("other1")
+
+		presentations, _, complete, err := m.Get(testServiceID, &tag)
+
+		require.NoError(t, err)
+		assert.Len(t, presentations, 1)
+		assert.True(t, complete)
 	})
 	t.Run("not a server for this service ID", func(t *testing.T) {
 		m, _, _ := setupModule(t, storageEngine)
-		_, _, err := m.Get("other", nil)
+		_, _, _, err := m.Get("other", nil)
 		assert.ErrorIs(t, err, ErrServerModeDisabled)
 	})
 }
@@ -248,12 +267,13 @@ func setupModule(t *testing.T, storageInstance storage.Engine, visitors ...func(
 	m.config = DefaultConfig()
 	require.NoError(t, m.Configure(core.TestServerConfig()))
 	httpClient := client.NewMockHTTPClient(ctrl)
-	httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", nil).AnyTimes()
+	httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", false, nil).AnyTimes()
 	m.httpClient = httpClient
-	m.allDefinitions = testDefinitions()
-	m.serverDefinitions = map[string]ServiceDefinition{
-		testServiceID: m.allDefinitions[testServiceID],
-	}
+	m.directoryDefinitions = testDefinitions()
+	m.allDefinitions = newServiceDefinitions(m.directoryDefinitions)
+	m.serverDefinitions = newServiceDefinitions(map[string]ServiceDefinition{
+		testServiceID: m.directoryDefinitions[testServiceID],
+	})
 	for _, visitor := range visitors {
 		visitor(m)
 	}
@@ -340,7 +360,7 @@ func TestModule_update(t *testing.T) {
 			// overwrite httpClient mock for custom behavior assertions (we want to know how often HttpClient.Get() was called)
 			httpClient := client.NewMockHTTPClient(gomock.NewController(t))
 			// Get() should be called at least twice (times the number of Service Definitions), once for the initial run on startup, then again after the refresh interval
-			httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", nil).MinTimes(2 * len(module.allDefinitions))
+			httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", false, nil).MinTimes(2 * len(module.allDefinitions.all()))
 			module.httpClient = httpClient
 		})
 		time.Sleep(10 * time.Millisecond)
@@ -352,7 +372,7 @@ func TestModule_update(t *testing.T) {
 			// overwrite httpClient mock for custom behavior assertions (we want to know how often HttpClient.Get() was called)
 			httpClient := client.NewMockHTTPClient(gomock.NewController(t))
 			// update causes call to HttpClient.Get(), once for each Service Definition
-			httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", nil).Times(len(module.allDefinitions))
+			httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", false, nil).Times(len(module.allDefinitions.all()))
 			module.httpClient = httpClient
 		})
 	})
@@ -366,7 +386,7 @@ func TestModule_ActivateServiceForDID(t *testing.T) {
 			// overwrite httpClient mock for custom behavior assertions (we want to know how often HttpClient.Get() was called)
 			httpClient := client.NewMockHTTPClient(gomock.NewController(t))
 			httpClient.EXPECT().Register(gomock.Any(), gomock.Any(), vpAlice).Return(nil)
-			httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", nil)
+			httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, "", false, nil)
 			module.httpClient = httpClient
 			// disable auto-refresh job to have deterministic assertions
 			module.config.Client.RefreshInterval = 0
@@ -412,12 +432,248 @@ func TestModule_Services(t *testing.T) {
 	require.NoError(t, storageEngine.Start())
 	t.Run("ok", func(t *testing.T) {
 		services := (&Module{
-			allDefinitions: testDefinitions(),
+			allDefinitions: newServiceDefinitions(testDefinitions()),
 		}).Services()
 		assert.Len(t, services, 2)
 	})
 }
 
+func TestModule_PutServiceDefinition(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	const serviceID = "managed"
+	vpAliceManaged := createPresentationCustom(aliceDID, func(claims map[string]interface{}, _ *vc.VerifiablePresentation) {
+		claims[jwt.AudienceKey] = []string{serviceID}
+	}, vcAlice)
+
+	t.Run("add as server", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		definition, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), true)
+
+		require.NoError(t, err)
+		assert.Equal(t, serviceID, definition.ID)
+		assert.Len(t, m.Services(), 3)
+		_, _, _, err = m.Get(serviceID, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("add as client", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		_, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), false)
+
+		require.NoError(t, err)
+		_, _, _, err = m.Get(serviceID, nil)
+		assert.ErrorIs(t, err, ErrServerModeDisabled)
+		_, err = m.Search(serviceID, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("loaded on startup", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		_, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), true)
+		require.NoError(t, err)
+		m.allDefinitions = newServiceDefinitions(m.directoryDefinitions)
+		m.serverDefinitions = newServiceDefinitions(nil)
+
+		require.NoError(t, m.loadManagedDefinitions())
+
+		_, exists := m.allDefinitions.get(serviceID)
+		assert.True(t, exists)
+		_, exists = m.serverDefinitions.get(serviceID)
+		assert.True(t, exists)
+	})
+	t.Run("changes through another node are loaded", func(t *testing.T) {
+		disableRefresh := func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		}
+		m, _, _ := setupModule(t, storageEngine, disableRefresh)
+		other, _, _ := setupModule(t, storageEngine, disableRefresh)
+
+		t.Run("added", func(t *testing.T) {
+			_, err := other.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), true)
+			require.NoError(t, err)
+
+			require.NoError(t, m.loadManagedDefinitions())
+
+			_, exists := m.allDefinitions.get(serviceID)
+			assert.True(t, exists)
+			_, exists = m.serverDefinitions.get(serviceID)
+			assert.True(t, exists)
+		})
+		t.Run("updated", func(t *testing.T) {
+			_, err := other.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/other", "did:example:*"), false)
+			require.NoError(t, err)
+
+			require.NoError(t, m.loadManagedDefinitions())
+
+			definition, _ := m.allDefinitions.get(serviceID)
+			assert.Equal(t, "http://example.com/other", definition.Endpoint)
+			_, exists := m.serverDefinitions.get(serviceID)
+			assert.False(t, exists)
+		})
+		t.Run("removed", func(t *testing.T) {
+			require.NoError(t, other.RemoveServiceDefinition(serviceID))
+
+			require.NoError(t, m.loadManagedDefinitions())
+
+			_, exists := m.allDefinitions.get(serviceID)
+			assert.False(t, exists)
+			assert.Len(t, m.Services(), 2)
+		})
+	})
+	t.Run("update removes presentations that no longer fulfill the definition", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine, func(module *Module) {
+			// disable automatic refresh, which would register the DID again
+			module.config.Client.RefreshInterval = 0
+		})
+		presentationVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		_, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), true)
+		require.NoError(t, err)
+		require.NoError(t, m.Register(serviceID, vpAliceManaged))
+		nextRefresh := time.Now().Add(time.Hour)
+		require.NoError(t, m.store.updatePresentationRefreshTime(serviceID, aliceDID, &nextRefresh))
+		_, clientTag, _, err := m.Get(serviceID, nil)
+		require.NoError(t, err)
+
+		_, err = m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:other:*"), true)
+
+		require.NoError(t, err)
+		presentations, _, complete, err := m.Get(serviceID, clientTag)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+		assert.True(t, complete, "clients should load the complete list to see the removal")
+		serviceIDs, _, err := m.store.getPresentationsToBeRefreshed(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []string{serviceID}, serviceIDs, "activated DID should be registered again")
+	})
+	t.Run("update keeps presentations that fulfill the definition", func(t *testing.T) {
+		m, presentationVerifier, _ := setupModule(t, storageEngine)
+		presentationVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		_, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), true)
+		require.NoError(t, err)
+		require.NoError(t, m.Register(serviceID, vpAliceManaged))
+
+		_, err = m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:auth*"), true)
+
+		require.NoError(t, err)
+		presentations, _, _, err := m.Get(serviceID, nil)
+		require.NoError(t, err)
+		assert.Len(t, presentations, 1)
+	})
+	t.Run("changing role removes all presentations", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		_, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), false)
+		require.NoError(t, err)
+		require.NoError(t, m.store.add(serviceID, vpAliceManaged, "taggy"))
+
+		_, err = m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), true)
+
+		require.NoError(t, err)
+		presentations, tag, _, err := m.Get(serviceID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+		assert.NotEqual(t, Tag("taggy"), *tag)
+	})
+	t.Run("changing endpoint (client) removes all presentations", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		_, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), false)
+		require.NoError(t, err)
+		require.NoError(t, m.store.add(serviceID, vpAliceManaged, "taggy"))
+
+		_, err = m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/other", "did:example:*"), false)
+
+		require.NoError(t, err)
+		presentations, err := m.store.getAll(serviceID)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+		tag, err := m.store.getTag(serviceID)
+		require.NoError(t, err)
+		assert.Empty(t, tag)
+	})
+	t.Run("invalid definition", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		_, err := m.PutServiceDefinition([]byte(`{"id": "managed"}`), false)
+
+		assert.ErrorIs(t, err, ErrInvalidServiceDefinition)
+	})
+	t.Run("definition from definitions directory can't be changed", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		_, err := m.PutServiceDefinition(testDefinitionJSON(testServiceID, "http://example.com/usecase", "did:example:*"), false)
+
+		assert.ErrorIs(t, err, ErrServiceDefinitionReadOnly)
+	})
+}
+
+func TestModule_RemoveServiceDefinition(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	const serviceID = "managed"
+
+	t.Run("ok", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+		_, err := m.PutServiceDefinition(testDefinitionJSON(serviceID, "http://example.com/managed", "did:example:*"), true)
+		require.NoError(t, err)
+		now := time.Now()
+		require.NoError(t, m.store.updatePresentationRefreshTime(serviceID, aliceDID, &now))
+
+		err = m.RemoveServiceDefinition(serviceID)
+
+		require.NoError(t, err)
+		assert.Len(t, m.Services(), 2)
+		_, err = m.Search(serviceID, nil)
+		assert.ErrorIs(t, err, ErrServiceNotFound)
+		_, _, _, err = m.Get(serviceID, nil)
+		assert.ErrorIs(t, err, ErrServerModeDisabled)
+		activated, _, err := m.GetServiceActivation(context.Background(), serviceID, aliceDID)
+		require.NoError(t, err)
+		assert.False(t, activated)
+	})
+	t.Run("not found", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		err := m.RemoveServiceDefinition(serviceID)
+
+		assert.ErrorIs(t, err, ErrServiceNotFound)
+	})
+	t.Run("definition from definitions directory can't be removed", func(t *testing.T) {
+		m, _, _ := setupModule(t, storageEngine)
+
+		err := m.RemoveServiceDefinition(testServiceID)
+
+		assert.ErrorIs(t, err, ErrServiceDefinitionReadOnly)
+	})
+}
+
+// testDefinitionJSON returns a service definition, which requires a credential issued by a DID matching the given pattern.
+func testDefinitionJSON(serviceID string, endpoint string, issuerPattern string) []byte {
+	return []byte(fmt.Sprintf(`{
+  "id": "%s",
+  "endpoint": "%s",
+  "presentation_max_validity": 86400,
+  "presentation_definition": {
+    "id": "managed_pd",
+    "input_descriptors": [
+      {
+        "id": "1",
+        "constraints": {
+          "fields": [
+            {
+              "path": ["$.issuer"],
+              "filter": {
+                "type": "string",
+                "pattern": "%s"
+              }
+            }
+          ]
+        }
+      }
+    ]
+  }
+}`, serviceID, endpoint, issuerPattern))
+}
+
 func TestModule_GetServiceActivation(t *testing.T) {
 	storageEngine := storage.NewTestStorageEngine(t)
 	require.NoError(t, storageEngine.Start())
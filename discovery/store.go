@@ -44,6 +44,11 @@ type serviceRecord struct {
 	ID        string `gorm:"primaryKey"`
 	LastTag   Tag
 	TagPrefix string
+	// Definition contains the service definition (JSON) if the service is managed at runtime.
+	// It is nil for services loaded from the definitions directory.
+	Definition *string
+	// IsServer indicates whether the node is server for the (managed) service.
+	IsServer bool
 }
 
 func (s serviceRecord) TableName() string {
@@ -119,6 +124,25 @@ func (l presentationRefreshRecord) TableName() string {
 	return "discovery_presentation_refresh"
 }
 
+// blockRecord is a subject (DID or presentation ID) that is blocked from registering on a Discovery Service.
+type blockRecord struct {
+	// ServiceID refers to the entry record in discovery_service
+	ServiceID string `gorm:"primaryKey"`
+	// Subject is the blocked DID or presentation ID.
+	Subject string `gorm:"primaryKey"`
+	// Reason is the reason given for blocking the subject.
+	Reason string
+	// Actor is the user or service that blocked the subject.
+	Actor string
+	// CreatedAt is the timestamp (seconds since Unix epoch) when the subject was blocked.
+	CreatedAt int64
+}
+
+// TableName returns the table name for this DTO.
+func (b blockRecord) TableName() string {
+	return "discovery_block"
+}
+
 type sqlStore struct {
 	db        *gorm.DB
 	writeLock sync.Mutex
@@ -251,30 +275,34 @@ func createPresentationRecord(serviceID string, timestamp *Timestamp, presentati
 // get returns all presentations, registered on the given service, starting after the given tag.
 // It also returns the latest tag of the returned presentations.
 // This tag can then be used next time to only retrieve presentations that were added after that tag.
-func (s *sqlStore) get(serviceID string, tag *Tag) ([]vc.VerifiablePresentation, *Tag, error) {
+// If the given tag is nil or can't be decoded (e.g. because the tag prefix changed), all presentations are returned,
+// which is indicated by the returned boolean.
+func (s *sqlStore) get(serviceID string, tag *Tag) ([]vc.VerifiablePresentation, *Tag, bool, error) {
 	var service serviceRecord
 	if err := s.db.Find(&service, "id = ?", serviceID).Error; err != nil {
-		return nil, nil, fmt.Errorf("query service '%s': %w", serviceID, err)
+		return nil, nil, false, fmt.Errorf("query service '%s': %w", serviceID, err)
 	}
 	var startAfter uint64
+	complete := true
 	if tag != nil {
 		// Decode tag
 		lamportTimestamp := tag.Timestamp(service.TagPrefix)
 		if lamportTimestamp != nil {
 			startAfter = uint64(*lamportTimestamp)
+			complete = false
 		}
 	}
 
 	var rows []presentationRecord
 	err := s.db.Order("lamport_timestamp ASC").Find(&rows, "service_id = ? AND lamport_timestamp > ?", serviceID, startAfter).Error
 	if err != nil {
-		return nil, nil, fmt.Errorf("query service '%s': %w", serviceID, err)
+		return nil, nil, false, fmt.Errorf("query service '%s': %w", serviceID, err)
 	}
 	presentations := make([]vc.VerifiablePresentation, 0, len(rows))
 	for _, row := range rows {
 		presentation, err := vc.ParseVerifiablePresentation(row.PresentationRaw)
 		if err != nil {
-			return nil, nil, fmt.Errorf("parse presentation '%s' of service '%s': %w", row.PresentationID, serviceID, err)
+			return nil, nil, false, fmt.Errorf("parse presentation '%s' of service '%s': %w", row.PresentationID, serviceID, err)
 		}
 		presentations = append(presentations, *presentation)
 	}
@@ -283,7 +311,25 @@ func (s *sqlStore) get(serviceID string, tag *Tag) ([]vc.VerifiablePresentation,
 		// Make sure we don't return an empty string for the tag, instead return tag indicating the beginning of the list.
 		lastTag = Timestamp(0).Tag(service.TagPrefix)
 	}
-	return presentations, &lastTag, nil
+	return presentations, &lastTag, complete, nil
+}
+
+// getAll returns all presentations registered on the given service, including those that expired but weren't pruned yet.
+// In contrast to get, it also returns presentations loaded by a client (which don't have a timestamp).
+func (s *sqlStore) getAll(serviceID string) ([]vc.VerifiablePresentation, error) {
+	var rows []presentationRecord
+	if err := s.db.Find(&rows, "service_id = ?", serviceID).Error; err != nil {
+		return nil, fmt.Errorf("query service '%s': %w", serviceID, err)
+	}
+	result := make([]vc.VerifiablePresentation, 0, len(rows))
+	for _, row := range rows {
+		presentation, err := vc.ParseVerifiablePresentation(row.PresentationRaw)
+		if err != nil {
+			return nil, fmt.Errorf("parse presentation '%s' of service '%s': %w", row.PresentationID, serviceID, err)
+		}
+		result = append(result, *presentation)
+	}
+	return result, nil
 }
 
 // search searches for presentations, registered on the given service, matching the given query.
@@ -477,6 +523,205 @@ func (s *sqlStore) getTag(serviceID string) (Tag, error) {
 	return service.LastTag, nil
 }
 
+// getManagedServices returns the services of which the definition is managed at runtime (see putService).
+func (s *sqlStore) getManagedServices() ([]serviceRecord, error) {
+	var rows []serviceRecord
+	if err := s.db.Find(&rows, "definition IS NOT NULL").Error; err != nil {
+		return nil, fmt.Errorf("query managed services: %w", err)
+	}
+	return rows, nil
+}
+
+// putService creates or updates a service of which the definition is managed at runtime.
+// If the node is server for the service, it makes sure the tag prefix is set.
+func (s *sqlStore) putService(definition ServiceDefinition, data []byte, server bool) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var service serviceRecord
+		if err := tx.Find(&service, "id = ?", definition.ID).Error; err != nil {
+			return err
+		}
+		service.ID = definition.ID
+		definitionJSON := string(data)
+		service.Definition = &definitionJSON
+		service.IsServer = server
+		if server && service.TagPrefix == "" {
+			service.TagPrefix = generatePrefix()
+		}
+		return tx.Save(service).Error
+	})
+}
+
+// resetService removes all presentations of the given service and clears its tag,
+// causing a client to load all presentations from the Discovery Server again.
+func (s *sqlStore) resetService(serviceID string) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&presentationRecord{}, "service_id = ?", serviceID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&serviceRecord{}).Where("id = ?", serviceID).Update("last_tag", nil).Error
+	})
+}
+
+// removePresentation removes the presentation with the given ID from the given service.
+func (s *sqlStore) removePresentation(serviceID string, presentationID string) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	return s.db.Delete(&presentationRecord{}, "service_id = ? AND presentation_id = ?", serviceID, presentationID).Error
+}
+
+// refreshRegistrations causes all activated DIDs of the given service to be registered again on the next refresh.
+func (s *sqlStore) refreshRegistrations(serviceID string) error {
+	// Use the same value as an activation that has not succeeded yet, since a value of 0 means 'not activated'.
+	asSoonAsPossible := time.Time{}.Unix()
+	return s.db.Model(&presentationRefreshRecord{}).
+		Where("service_id = ?", serviceID).
+		Update("next_refresh", asSoonAsPossible).Error
+}
+
+// removeService removes the given service.
+// Its presentations and the DIDs registered on it are removed as well.
+func (s *sqlStore) removeService(serviceID string) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	return s.db.Delete(&serviceRecord{}, "id = ?", serviceID).Error
+}
+
+// removeFromServer removes the presentations of the given subject (DID or presentation ID) from a service the node is server for.
+// It returns the number of removed presentations.
+// Since clients only load presentations added since their last update, the tag prefix is changed (see rotateTagPrefix)
+// to have clients load the complete list, so they see the removal.
+func (s *sqlStore) removeFromServer(serviceID string, subject string) (int, error) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	var removed int
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		removed, err = removeSubject(tx, serviceID, subject)
+		return err
+	})
+	return removed, err
+}
+
+// removePresentationsFromServer removes the presentations with the given IDs from a service the node is server for.
+// It returns the IDs of the presentations that were removed (presentations that don't exist anymore are skipped).
+// Like removeFromServer, it changes the tag prefix if any presentations were removed, but only once.
+func (s *sqlStore) removePresentationsFromServer(serviceID string, presentationIDs []string) ([]string, error) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	var removed []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		removed = nil
+		for _, presentationID := range presentationIDs {
+			result := tx.Where("service_id = ? AND presentation_id = ?", serviceID, presentationID).Delete(&presentationRecord{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				removed = append(removed, presentationID)
+			}
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+		return rotateTagPrefix(tx, serviceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// block blocks the subject of the given record from registering on the service, and removes its presentations.
+// If the subject is already blocked, the reason and actor are updated.
+// It returns the number of removed presentations.
+func (s *sqlStore) block(record blockRecord) (int, error) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	var removed int
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&record).Error; err != nil {
+			return err
+		}
+		var err error
+		removed, err = removeSubject(tx, record.ServiceID, record.Subject)
+		return err
+	})
+	return removed, err
+}
+
+// unblock allows the given subject to register on the service again.
+// It returns false if the subject wasn't blocked.
+func (s *sqlStore) unblock(serviceID string, subject string) (bool, error) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	result := s.db.Delete(&blockRecord{}, "service_id = ? AND subject = ?", serviceID, subject)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// getBlocks returns the subjects that are blocked on the given service, oldest first.
+func (s *sqlStore) getBlocks(serviceID string) ([]blockRecord, error) {
+	var rows []blockRecord
+	if err := s.db.Order("created_at ASC").Find(&rows, "service_id = ?", serviceID).Error; err != nil {
+		return nil, fmt.Errorf("query blocks of service '%s': %w", serviceID, err)
+	}
+	return rows, nil
+}
+
+// isBlocked checks whether any of the given subjects (DIDs or presentation IDs) is blocked on the given service.
+func (s *sqlStore) isBlocked(serviceID string, subjects ...string) (bool, error) {
+	var count int64
+	if err := s.db.Model(&blockRecord{}).Where("service_id = ? AND subject IN ?", serviceID, subjects).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check block: %w", err)
+	}
+	return count > 0, nil
+}
+
+// removeSubject removes the presentations of the given subject (DID or presentation ID) from the service,
+// and changes the tag prefix if any presentations were removed.
+func removeSubject(tx *gorm.DB, serviceID string, subject string) (int, error) {
+	result := tx.Where("service_id = ? AND (credential_subject_id = ? OR presentation_id = ?)", serviceID, subject, subject).
+		Delete(&presentationRecord{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, nil
+	}
+	return int(result.RowsAffected), rotateTagPrefix(tx, serviceID)
+}
+
+// rotateTagPrefix changes the tag prefix of a service the node is server for.
+// Tags issued with the previous prefix can't be decoded anymore, so clients presenting them get the complete list.
+// The last tag is encoded with the new prefix, so the lamport timestamps of the presentations remain valid.
+func rotateTagPrefix(tx *gorm.DB, serviceID string) error {
+	var service serviceRecord
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&service, "id = ?", serviceID).Error; err != nil {
+		return err
+	}
+	timestamp := Timestamp(0)
+	if !service.LastTag.Empty() {
+		if ts := service.LastTag.Timestamp(service.TagPrefix); ts != nil {
+			timestamp = *ts
+		}
+	}
+	newPrefix := generatePrefix()
+	for newPrefix == service.TagPrefix {
+		newPrefix = generatePrefix()
+	}
+	updates := map[string]interface{}{"tag_prefix": newPrefix}
+	if !service.LastTag.Empty() {
+		updates["last_tag"] = string(timestamp.Tag(newPrefix))
+	}
+	return tx.Model(&serviceRecord{}).Where("id = ?", serviceID).Updates(updates).Error
+}
+
 // indexJSONObject indexes a JSON object, resulting in a slice of JSON paths and corresponding string values.
 // It only traverses JSON objects and only adds string values to the result.
 func indexJSONObject(target map[string]interface{}, jsonPaths []string, stringValues []string, currentPath string) ([]string, []string) {
@@ -134,7 +134,7 @@ func Test_sqlStore_get(t *testing.T) {
 
 	t.Run("empty list, empty tag", func(t *testing.T) {
 		m := setupStore(t, storageEngine.GetSQLDatabase())
-		presentations, tag, err := m.get(testServiceID, nil)
+		presentations, tag, _, err := m.get(testServiceID, nil)
 		assert.NoError(t, err)
 		assert.Empty(t, presentations)
 		expectedTag := tagForTimestamp(t, m, testServiceID, 0)
@@ -143,7 +143,7 @@ func Test_sqlStore_get(t *testing.T) {
 	t.Run("1 entry, empty tag", func(t *testing.T) {
 		m := setupStore(t, storageEngine.GetSQLDatabase())
 		require.NoError(t, m.add(testServiceID, vpAlice, ""))
-		presentations, tag, err := m.get(testServiceID, nil)
+		presentations, tag, _, err := m.get(testServiceID, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, []vc.VerifiablePresentation{vpAlice}, presentations)
 		expectedTag := tagForTimestamp(t, m, testServiceID, 1)
@@ -153,7 +153,7 @@ func Test_sqlStore_get(t *testing.T) {
 		m := setupStore(t, storageEngine.GetSQLDatabase())
 		require.NoError(t, m.add(testServiceID, vpAlice, ""))
 		require.NoError(t, m.add(testServiceID, vpBob, ""))
-		presentations, tag, err := m.get(testServiceID, nil)
+		presentations, tag, _, err := m.get(testServiceID, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, []vc.VerifiablePresentation{vpAlice, vpBob}, presentations)
 		expectedTS := tagForTimestamp(t, m, testServiceID, 2)
@@ -164,7 +164,7 @@ func Test_sqlStore_get(t *testing.T) {
 		require.NoError(t, m.add(testServiceID, vpAlice, ""))
 		require.NoError(t, m.add(testServiceID, vpBob, ""))
 		ts := tagForTimestamp(t, m, testServiceID, 1)
-		presentations, tag, err := m.get(testServiceID, &ts)
+		presentations, tag, _, err := m.get(testServiceID, &ts)
 		assert.NoError(t, err)
 		assert.Equal(t, []vc.VerifiablePresentation{vpBob}, presentations)
 		expectedTS := tagForTimestamp(t, m, testServiceID, 2)
@@ -175,7 +175,7 @@ func Test_sqlStore_get(t *testing.T) {
 		require.NoError(t, m.add(testServiceID, vpAlice, ""))
 		require.NoError(t, m.add(testServiceID, vpBob, ""))
 		expectedTag := tagForTimestamp(t, m, testServiceID, 2)
-		presentations, tag, err := m.get(testServiceID, &expectedTag)
+		presentations, tag, _, err := m.get(testServiceID, &expectedTag)
 		assert.NoError(t, err)
 		assert.Equal(t, []vc.VerifiablePresentation{}, presentations)
 		assert.Equal(t, expectedTag, *tag)
@@ -427,6 +427,265 @@ func Test_sqlStore_getPresentationRefreshTime(t *testing.T) {
 	})
 }
 
+func Test_sqlStore_managedServices(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	definition := testDefinitions()["other"]
+	definition.ID = "managed"
+
+	t.Run("put and get", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.putService(definition, []byte(`{"id":"managed"}`), false))
+
+		services, err := c.getManagedServices()
+
+		require.NoError(t, err)
+		require.Len(t, services, 1)
+		assert.Equal(t, "managed", services[0].ID)
+		assert.Equal(t, `{"id":"managed"}`, *services[0].Definition)
+		assert.False(t, services[0].IsServer)
+		assert.Empty(t, services[0].TagPrefix)
+	})
+	t.Run("update to server generates tag prefix once", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.putService(definition, []byte(`{}`), true))
+		services, err := c.getManagedServices()
+		require.NoError(t, err)
+		require.Len(t, services, 1)
+		tagPrefix := services[0].TagPrefix
+		assert.Len(t, tagPrefix, tagPrefixLength)
+
+		require.NoError(t, c.putService(definition, []byte(`{}`), true))
+
+		services, err = c.getManagedServices()
+		require.NoError(t, err)
+		assert.True(t, services[0].IsServer)
+		assert.Equal(t, tagPrefix, services[0].TagPrefix)
+	})
+	t.Run("services from definitions directory aren't returned", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+
+		services, err := c.getManagedServices()
+
+		require.NoError(t, err)
+		assert.Empty(t, services)
+	})
+	t.Run("reset", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpAlice, "taggy"))
+
+		require.NoError(t, c.resetService(testServiceID))
+
+		presentations, err := c.getAll(testServiceID)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+		tag, err := c.getTag(testServiceID)
+		require.NoError(t, err)
+		assert.Empty(t, tag)
+	})
+	t.Run("remove presentation", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpAlice, "taggy"))
+		require.NoError(t, c.add(testServiceID, vpBob, "taggy"))
+
+		require.NoError(t, c.removePresentation(testServiceID, vpAlice.ID.String()))
+
+		presentations, err := c.getAll(testServiceID)
+		require.NoError(t, err)
+		require.Len(t, presentations, 1)
+		assert.Equal(t, vpBob.ID.String(), presentations[0].ID.String())
+	})
+	t.Run("refresh registrations", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		nextRefresh := time.Now().Add(time.Hour)
+		require.NoError(t, c.updatePresentationRefreshTime(testServiceID, aliceDID, &nextRefresh))
+		require.NoError(t, c.updatePresentationRefreshTime("other", aliceDID, &nextRefresh))
+
+		require.NoError(t, c.refreshRegistrations(testServiceID))
+
+		serviceIDs, dids, err := c.getPresentationsToBeRefreshed(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []string{testServiceID}, serviceIDs)
+		assert.Equal(t, []did.DID{aliceDID}, dids)
+		ts, err := c.getPresentationRefreshTime(testServiceID, aliceDID)
+		require.NoError(t, err)
+		assert.NotNil(t, ts, "DID should still be activated")
+	})
+	t.Run("remove service", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.putService(definition, []byte(`{}`), true))
+		require.NoError(t, c.add(definition.ID, vpAlice, ""))
+		now := time.Now()
+		require.NoError(t, c.updatePresentationRefreshTime(definition.ID, aliceDID, &now))
+
+		require.NoError(t, c.removeService(definition.ID))
+
+		services, err := c.getManagedServices()
+		require.NoError(t, err)
+		assert.Empty(t, services)
+		presentations, err := c.getAll(definition.ID)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+		ts, err := c.getPresentationRefreshTime(definition.ID, aliceDID)
+		require.NoError(t, err)
+		assert.Nil(t, ts)
+	})
+}
+
+func Test_sqlStore_removeFromServer(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+
+	t.Run("remove by DID", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpAlice, ""))
+		require.NoError(t, c.add(testServiceID, vpBob, ""))
+		_, oldTag, _, err := c.get(testServiceID, nil)
+		require.NoError(t, err)
+
+		removed, err := c.removeFromServer(testServiceID, aliceDID.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		t.Run("tag prefix changed, so clients load complete list", func(t *testing.T) {
+			presentations, newTag, complete, err := c.get(testServiceID, oldTag)
+			require.NoError(t, err)
+			assert.True(t, complete)
+			assert.Equal(t, []vc.VerifiablePresentation{vpBob}, presentations)
+			assert.NotEqual(t, *oldTag, *newTag)
+			assert.Equal(t, string(*oldTag)[tagPrefixLength:], string(*newTag)[tagPrefixLength:], "timestamp should be retained")
+			t.Run("new tag can be decoded", func(t *testing.T) {
+				presentations, _, complete, err := c.get(testServiceID, newTag)
+				require.NoError(t, err)
+				assert.False(t, complete)
+				assert.Empty(t, presentations)
+			})
+		})
+	})
+	t.Run("remove by presentation ID", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpAlice, ""))
+
+		removed, err := c.removeFromServer(testServiceID, vpAlice.ID.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+	})
+	t.Run("nothing to remove", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpBob, ""))
+		_, oldTag, _, err := c.get(testServiceID, nil)
+		require.NoError(t, err)
+
+		removed, err := c.removeFromServer(testServiceID, aliceDID.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, removed)
+		_, newTag, _, err := c.get(testServiceID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, *oldTag, *newTag, "tag prefix should not change")
+	})
+}
+
+func Test_sqlStore_removePresentationsFromServer(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpAlice, ""))
+		require.NoError(t, c.add(testServiceID, vpBob, ""))
+		_, oldTag, _, err := c.get(testServiceID, nil)
+		require.NoError(t, err)
+
+		removed, err := c.removePresentationsFromServer(testServiceID, []string{vpAlice.ID.String(), vpBob.ID.String(), "unknown"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{vpAlice.ID.String(), vpBob.ID.String()}, removed)
+		presentations, newTag, complete, err := c.get(testServiceID, oldTag)
+		require.NoError(t, err)
+		assert.True(t, complete)
+		assert.Empty(t, presentations)
+		assert.Equal(t, string(*oldTag)[tagPrefixLength:], string(*newTag)[tagPrefixLength:], "timestamp should be retained")
+	})
+	t.Run("nothing to remove", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpBob, ""))
+		_, oldTag, _, err := c.get(testServiceID, nil)
+		require.NoError(t, err)
+
+		removed, err := c.removePresentationsFromServer(testServiceID, []string{vpAlice.ID.String()})
+
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+		_, newTag, _, err := c.get(testServiceID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, *oldTag, *newTag, "tag prefix should not change")
+	})
+}
+
+func Test_sqlStore_moderation(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+
+	t.Run("block and unblock", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.add(testServiceID, vpAlice, ""))
+
+		removed, err := c.block(blockRecord{ServiceID: testServiceID, Subject: aliceDID.String(), Reason: "spam", Actor: "admin", CreatedAt: 1000})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		blocked, err := c.isBlocked(testServiceID, aliceDID.String(), "other")
+		require.NoError(t, err)
+		assert.True(t, blocked)
+		blocked, err = c.isBlocked("other", aliceDID.String())
+		require.NoError(t, err)
+		assert.False(t, blocked)
+		blocks, err := c.getBlocks(testServiceID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, "spam", blocks[0].Reason)
+		assert.Equal(t, "admin", blocks[0].Actor)
+		assert.Equal(t, int64(1000), blocks[0].CreatedAt)
+
+		t.Run("block again updates reason", func(t *testing.T) {
+			removed, err := c.block(blockRecord{ServiceID: testServiceID, Subject: aliceDID.String(), Reason: "more spam", Actor: "admin", CreatedAt: 2000})
+
+			require.NoError(t, err)
+			assert.Equal(t, 0, removed)
+			blocks, err := c.getBlocks(testServiceID)
+			require.NoError(t, err)
+			require.Len(t, blocks, 1)
+			assert.Equal(t, "more spam", blocks[0].Reason)
+		})
+		t.Run("unblock", func(t *testing.T) {
+			unblocked, err := c.unblock(testServiceID, aliceDID.String())
+			require.NoError(t, err)
+			assert.True(t, unblocked)
+
+			blocked, err := c.isBlocked(testServiceID, aliceDID.String())
+			require.NoError(t, err)
+			assert.False(t, blocked)
+			unblocked, err = c.unblock(testServiceID, aliceDID.String())
+			require.NoError(t, err)
+			assert.False(t, unblocked)
+		})
+	})
+}
+
 func setupStore(t *testing.T, db *gorm.DB) *sqlStore {
 	resetStore(t, db)
 	defs := testDefinitions()
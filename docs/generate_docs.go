@@ -47,7 +47,7 @@ func (sl stringSlice) contains(s string) bool {
 }
 
 // serverCommands lists the commands that use the server config. The options server commands are only printed once, because the list is quite long.
-var serverCommands stringSlice = []string{"nuts config", "nuts server", "nuts crypto fs2vault", "nuts crypto fs2external", "nuts http gen-token"}
+var serverCommands stringSlice = []string{"nuts config", "nuts server", "nuts crypto fs2vault", "nuts crypto fs2external", "nuts crypto migrate", "nuts http gen-token"}
 
 func generateDocs() {
 	system := cmd.CreateSystem(func() {})
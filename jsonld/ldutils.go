@@ -151,9 +151,16 @@ const SchemaOrgContext = "https://schema.org"
 // W3cVcContext contains the w3c VerifiableCredential type context
 const W3cVcContext = "https://www.w3.org/2018/credentials/v1"
 
+// W3cVcContextV2 contains the w3c VerifiableCredential type context of the VC data model v2, which also defines the Bitstring Status List terms.
+const W3cVcContextV2 = "https://www.w3.org/ns/credentials/v2"
+
 // W3cStatusList2021Context contains the StatusList2021 related context
 const W3cStatusList2021Context = "https://w3id.org/vc/status-list/2021/v1"
 
+// BitstringStatusListContext contains the terms of the W3C Bitstring Status List (https://www.w3.org/TR/vc-bitstring-status-list/).
+// The W3C defines these terms in the VC data model v2 context, which can't be combined with the v1 context (W3cVcContext).
+const BitstringStatusListContext = W3cVcContextV2
+
 // Jws2020Context contains the JsonWebToken2020 Proof type context
 const Jws2020Context = "https://w3c-ccg.github.io/lds-jws2020/contexts/lds-jws2020-v1.json"
 
@@ -165,6 +172,7 @@ func DefaultContextConfig() ContextsConfig {
 			"https://nuts.nl/credentials/v1": "assets/contexts/nuts.ldjson",
 			W3cVcContext:                     "assets/contexts/w3c-credentials-v1.ldjson",
 			W3cStatusList2021Context:         "assets/contexts/w3c-statuslist2021.ldjson",
+			W3cVcContextV2:                   "assets/contexts/w3c-credentials-v2.ldjson",
 			Jws2020Context:                   "assets/contexts/lds-jws2020-v1.ldjson",
 			SchemaOrgContext:                 "assets/contexts/schema-org-v13.ldjson",
 		},
@@ -173,7 +181,7 @@ func DefaultContextConfig() ContextsConfig {
 
 // DefaultAllowList returns the default allow list for external contexts
 func DefaultAllowList() []string {
-	return []string{SchemaOrgContext, W3cVcContext, Jws2020Context, W3cStatusList2021Context}
+	return []string{SchemaOrgContext, W3cVcContext, Jws2020Context, W3cStatusList2021Context, W3cVcContextV2}
 }
 
 // NewContextLoader creates a new JSON-LD context loader with the embedded FS as first loader.
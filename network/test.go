@@ -44,6 +44,7 @@ func NewTestNetworkInstance(t *testing.T) *Network {
 	ctrl := gomock.NewController(t)
 	pkiMock := pki.NewMockValidator(ctrl)
 	pkiMock.EXPECT().SetVerifyPeerCertificateFunc(gomock.Any()).AnyTimes()
+	pkiMock.EXPECT().SetOCSPStapleFunc(gomock.Any()).AnyTimes()
 	newInstance := NewNetworkInstance(
 		config,
 		didResolver,
@@ -145,6 +145,9 @@ func newServerTLSConfig(config Config) (*tls.Config, error) {
 	tlsConfig.Certificates = []tls.Certificate{
 		*config.serverCert,
 	}
+	if err = config.pkiValidator.SetOCSPStapleFunc(tlsConfig); err != nil {
+		return nil, err
+	}
 	return tlsConfig, nil
 }
 
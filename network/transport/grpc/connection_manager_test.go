@@ -568,6 +568,7 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 
 	t.Run("ok - gRPC server bound, TLS enabled", func(t *testing.T) {
 		pkiMock.EXPECT().SetVerifyPeerCertificateFunc(gomock.Any()).Times(2)
+		pkiMock.EXPECT().SetOCSPStapleFunc(gomock.Any())
 		pkiMock.EXPECT().SubscribeDenied(gomock.Any())
 		cfg, err := NewConfig(
 			fmt.Sprintf("127.0.0.1:%d",
@@ -633,6 +634,7 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 				return nil
 			}
 		}).Times(2) // on inbound and outbound TLS config
+		pkiMock.EXPECT().SetOCSPStapleFunc(gomock.Any())
 		pkiMock.EXPECT().SubscribeDenied(gomock.Any())
 
 		cfg, err := NewConfig(fmt.Sprintf("localhost:%d", test.FreeTCPPort()), "peerID", WithTLS(serverCert, &core.TrustStore{CertPool: x509.NewCertPool()}, pkiMock))
@@ -657,6 +659,21 @@ func Test_grpcConnectionManager_Start(t *testing.T) {
 
 		assert.EqualError(t, err, "custom error")
 	})
+
+	t.Run("error - OCSP stapling", func(t *testing.T) {
+		cfg, err := NewConfig(fmt.Sprintf("localhost:%d", test.FreeTCPPort()), "peerID", WithTLS(serverCert, &core.TrustStore{CertPool: x509.NewCertPool()}, pkiMock))
+		pkiMock.EXPECT().SetVerifyPeerCertificateFunc(gomock.Any()).Times(2)
+		pkiMock.EXPECT().SubscribeDenied(gomock.Any())
+		cm, err := NewGRPCConnectionManager(cfg, nil, *nodeDID, nil, &TestProtocol{})
+		require.NoError(t, err)
+
+		pkiMock.EXPECT().SetOCSPStapleFunc(gomock.Any()).Return(errors.New("custom error"))
+
+		defer cm.Stop()
+		err = cm.Start()
+
+		assert.EqualError(t, err, "custom error")
+	})
 }
 
 func Test_grpcConnectionManager_Stop(t *testing.T) {
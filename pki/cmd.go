@@ -31,6 +31,9 @@ func FlagSet() *pflag.FlagSet {
 	// Flags for denylist features
 	flagSet.Int("pki.maxupdatefailhours", defs.MaxUpdateFailHours, "Maximum number of hours that a denylist update can fail")
 	flagSet.Bool("pki.softfail", defs.Softfail, "Do not reject certificates if their revocation status cannot be established when softfail is true")
+	flagSet.String("pki.revocationpolicy", defs.RevocationPolicy, "Specifies how the revocation status of certificates is checked. "+
+		"'crl-only' only uses CRLs, 'prefer-ocsp' uses OCSP if the certificate specifies an OCSP responder and falls back to CRLs, "+
+		"'both' checks both CRLs and OCSP.")
 	flagSet.String("pki.denylist.trustedsigner", defs.Denylist.TrustedSigner, "Ed25519 public key (in PEM format) of the trusted signer for denylists")
 	flagSet.String("pki.denylist.url", defs.Denylist.URL, "URL of PKI denylist (set to empty string to disable)")
 
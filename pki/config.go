@@ -19,6 +19,16 @@
 
 package pki
 
+const (
+	// RevocationPolicyCRLOnly checks the revocation status of certificates using CRLs only.
+	RevocationPolicyCRLOnly = "crl-only"
+	// RevocationPolicyPreferOCSP checks the revocation status of certificates using OCSP if the certificate specifies an OCSP responder,
+	// and falls back to CRLs if it doesn't or if the OCSP responder can't provide a status.
+	RevocationPolicyPreferOCSP = "prefer-ocsp"
+	// RevocationPolicyBoth checks the revocation status of certificates using both CRLs and OCSP (if the certificate specifies an OCSP responder).
+	RevocationPolicyBoth = "both"
+)
+
 func DefaultConfig() Config {
 	return Config{
 		Denylist: DenylistConfig{
@@ -27,6 +37,7 @@ func DefaultConfig() Config {
 		},
 		MaxUpdateFailHours: 4,
 		Softfail:           true,
+		RevocationPolicy:   RevocationPolicyCRLOnly,
 	}
 }
 
@@ -40,6 +51,9 @@ type Config struct {
 
 	// Softfail still accepts connections if the revocation status of a certificate cannot be reliably established if set to true
 	Softfail bool `koanf:"softfail"`
+
+	// RevocationPolicy specifies how the revocation status of certificates is checked: crl-only, prefer-ocsp or both
+	RevocationPolicy string `koanf:"revocationpolicy"`
 }
 
 // DenylistConfig specifies the config structure for the crl/certificate blacklist module
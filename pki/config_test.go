@@ -29,6 +29,7 @@ func Test_DefaultConfig(t *testing.T) {
 
 	assert.Equal(t, 4, cfg.MaxUpdateFailHours)
 	assert.True(t, cfg.Softfail)
+	assert.Equal(t, RevocationPolicyCRLOnly, cfg.RevocationPolicy)
 	require.NotNil(t, cfg.Denylist)
 	assert.NotEmpty(t, cfg.Denylist.TrustedSigner)
 	assert.NotEmpty(t, cfg.Denylist.URL)
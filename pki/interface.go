@@ -35,6 +35,9 @@ var (
 	// ErrDenylistMissing occurs when the denylist cannot be downloaded
 	ErrDenylistMissing = errors.New("denylist cannot be retrieved")
 
+	// ErrOCSPUnavailable occurs when no (valid) OCSP response can be obtained for a certificate
+	ErrOCSPUnavailable = errors.New("ocsp response is unavailable")
+
 	// ErrCertBanned means the certificate was banned by a denylist rather than revoked by a CRL
 	ErrCertBanned = errors.New("certificate is banned")
 )
@@ -60,7 +63,8 @@ type Denylist interface {
 type Validator interface {
 	// Validate returns an error if any of the certificates in the chain has been revoked, or if the request cannot be processed.
 	// ErrCertRevoked and ErrCertUntrusted indicate that at least one of the certificates is revoked, or signed by a CA that is not in the truststore.
-	// ErrCRLMissing and ErrCRLExpired signal that at least one of the certificates cannot be validated reliably.
+	// ErrCRLMissing, ErrCRLExpired and ErrOCSPUnavailable signal that at least one of the certificates cannot be validated reliably.
+	// Whether CRLs, OCSP or both are used is determined by the configured revocation policy.
 	// If the certificate was revoked on an expired CRL, it wil return ErrCertRevoked.
 	// Ignoring all errors except ErrCertRevoked changes the behavior from hard-fail to soft-fail. Without a truststore, the Validator is a noop if set to soft-fail
 	// The certificate chain is expected to be sorted leaf to root.
@@ -69,6 +73,10 @@ type Validator interface {
 	// SetVerifyPeerCertificateFunc sets config.ValidatePeerCertificate to use Validate.
 	SetVerifyPeerCertificateFunc(config *tls.Config) error
 
+	// SetOCSPStapleFunc sets config.GetCertificate to staple the OCSP response of the (server) certificate in config.Certificates.
+	// It does nothing if the revocation policy doesn't use OCSP.
+	SetOCSPStapleFunc(config *tls.Config) error
+
 	// AddTruststore adds all CAs to the truststore for validation of CRL signatures. It also adds all CRL Distribution Endpoints found in the chain.
 	// CRL Distribution Points encountered during operation, such as on end user certificates, are only added to the monitored CRLs if their issuer is in the truststore.
 	AddTruststore(chain []*x509.Certificate) error
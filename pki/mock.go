@@ -147,6 +147,20 @@ func (mr *MockValidatorMockRecorder) AddTruststore(chain any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTruststore", reflect.TypeOf((*MockValidator)(nil).AddTruststore), chain)
 }
 
+// SetOCSPStapleFunc mocks base method.
+func (m *MockValidator) SetOCSPStapleFunc(config *tls.Config) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOCSPStapleFunc", config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOCSPStapleFunc indicates an expected call of SetOCSPStapleFunc.
+func (mr *MockValidatorMockRecorder) SetOCSPStapleFunc(config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOCSPStapleFunc", reflect.TypeOf((*MockValidator)(nil).SetOCSPStapleFunc), config)
+}
+
 // SetVerifyPeerCertificateFunc mocks base method.
 func (m *MockValidator) SetVerifyPeerCertificateFunc(config *tls.Config) error {
 	m.ctrl.T.Helper()
@@ -239,6 +253,20 @@ func (mr *MockProviderMockRecorder) CreateTLSConfig(cfg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTLSConfig", reflect.TypeOf((*MockProvider)(nil).CreateTLSConfig), cfg)
 }
 
+// SetOCSPStapleFunc mocks base method.
+func (m *MockProvider) SetOCSPStapleFunc(config *tls.Config) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOCSPStapleFunc", config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOCSPStapleFunc indicates an expected call of SetOCSPStapleFunc.
+func (mr *MockProviderMockRecorder) SetOCSPStapleFunc(config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOCSPStapleFunc", reflect.TypeOf((*MockProvider)(nil).SetOCSPStapleFunc), config)
+}
+
 // SetVerifyPeerCertificateFunc mocks base method.
 func (m *MockProvider) SetVerifyPeerCertificateFunc(config *tls.Config) error {
 	m.ctrl.T.Helper()
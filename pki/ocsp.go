@@ -0,0 +1,346 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pki
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ocsp"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ocspMaxCacheAge is the maximum time an OCSP response is cached, also if it specifies a later (or no) NextUpdate.
+// Equal to the CRL update interval, so OCSP revocations are picked up at least as fast as CRL revocations.
+const ocspMaxCacheAge = syncInterval
+
+// ocspMaxResponseSize limits the size of OCSP responses that are read from responders.
+const ocspMaxResponseSize = 1024 * 1024
+
+// ocspStapleRetryInterval is the time after which fetching the OCSP response to staple is retried, if it failed.
+const ocspStapleRetryInterval = time.Minute
+
+// errNoOCSPResponder is returned when a certificate does not specify an OCSP responder.
+var errNoOCSPResponder = errors.New("certificate does not specify an OCSP responder")
+
+// ocspResponse is a cached OCSP response for a single certificate.
+type ocspResponse struct {
+	// status is the certificate status (ocsp.Good or ocsp.Revoked). Responses with status ocsp.Unknown are not cached.
+	status int
+
+	// expiry is the time after which the response must no longer be used.
+	expiry time.Time
+}
+
+// ocspCacheKey returns the key for the OCSP response cache of the given certificate.
+func ocspCacheKey(cert *x509.Certificate) string {
+	return cert.Issuer.String() + "/" + cert.SerialNumber.String()
+}
+
+// validateOCSP checks the revocation status of the certificate using OCSP.
+// Cached (or stapled) responses are used when available, otherwise the OCSP responders of the certificate are queried in order.
+// It returns ErrCertRevoked if the certificate is revoked, errNoOCSPResponder if the certificate doesn't specify an OCSP responder,
+// ErrCertUntrusted if the issuer is not in the truststore, and ErrOCSPUnavailable if no responder gives a definitive status.
+func (v *validator) validateOCSP(cert *x509.Certificate) error {
+	if len(cert.OCSPServer) == 0 {
+		return errNoOCSPResponder
+	}
+	if cached, ok := v.getOCSPResponse(cert); ok {
+		return ocspStatusError(cached.status)
+	}
+	issuer, ok := v.getCert(cert.Issuer.String())
+	if !ok {
+		return ErrCertUntrusted
+	}
+	for _, responder := range cert.OCSPServer {
+		response, err := v.requestOCSP(responder, cert, issuer)
+		if err != nil {
+			logger().WithError(err).
+				WithField("Subject", cert.Subject.String()).
+				WithField("S/N", cert.SerialNumber.String()).
+				WithField("responder", responder).
+				Warn("OCSP request failed")
+			continue
+		}
+		if response.Status == ocsp.Unknown {
+			logger().
+				WithField("Subject", cert.Subject.String()).
+				WithField("S/N", cert.SerialNumber.String()).
+				WithField("responder", responder).
+				Debug("OCSP responder returned status unknown")
+			continue
+		}
+		v.storeOCSPResponse(cert, response)
+		return ocspStatusError(response.Status)
+	}
+	return ErrOCSPUnavailable
+}
+
+// requestOCSP sends an OCSP request for the certificate to the responder, and verifies the response.
+func (v *validator) requestOCSP(responder string, cert *x509.Certificate, issuer *x509.Certificate) (*ocsp.Response, error) {
+	data, err := v.fetchOCSP(responder, cert, issuer)
+	if err != nil {
+		return nil, err
+	}
+	return parseOCSPResponse(data, cert, issuer)
+}
+
+// fetchOCSP sends an OCSP request for the certificate to the responder, and returns the (unverified) response.
+func (v *validator) fetchOCSP(responder string, cert *x509.Certificate, issuer *x509.Certificate) ([]byte, error) {
+	request, err := ocsp.CreateRequest(cert, issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, fmt.Errorf("create OCSP request: %w", err)
+	}
+	httpResponse, err := v.httpClient.Post(responder, "application/ocsp-request", bytes.NewReader(request))
+	if err != nil {
+		return nil, fmt.Errorf("sending OCSP request: %w", err)
+	}
+	defer func() {
+		if err = httpResponse.Body.Close(); err != nil {
+			logger().Warn(err)
+		}
+	}()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder returned status code %d", httpResponse.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, ocspMaxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading OCSP response: %w", err)
+	}
+	return data, nil
+}
+
+// parseOCSPResponse parses the OCSP response for the certificate, and verifies its signature and validity period.
+func parseOCSPResponse(data []byte, cert *x509.Certificate, issuer *x509.Certificate) (*ocsp.Response, error) {
+	response, err := ocsp.ParseResponseForCert(data, cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("parse OCSP response: %w", err)
+	}
+	now := nowFunc()
+	if now.Before(response.ThisUpdate) {
+		return nil, errors.New("OCSP response is not yet valid")
+	}
+	if !response.NextUpdate.IsZero() && !now.Before(response.NextUpdate) {
+		return nil, errors.New("OCSP response has expired")
+	}
+	return response, nil
+}
+
+// ocspStatusError converts an OCSP certificate status to the error returned by validateOCSP.
+func ocspStatusError(status int) error {
+	switch status {
+	case ocsp.Good:
+		return nil
+	case ocsp.Revoked:
+		return ErrCertRevoked
+	default:
+		return ErrOCSPUnavailable
+	}
+}
+
+// getOCSPResponse returns the cached OCSP response for the certificate, or false if there is none or it has expired.
+func (v *validator) getOCSPResponse(cert *x509.Certificate) (*ocspResponse, bool) {
+	value, ok := v.ocspResponses.Load(ocspCacheKey(cert))
+	if !ok {
+		return nil, false
+	}
+	response := value.(*ocspResponse)
+	if !nowFunc().Before(response.expiry) {
+		return nil, false
+	}
+	return response, true
+}
+
+// storeOCSPResponse caches the OCSP response for the certificate until it expires (see ocspExpiry).
+func (v *validator) storeOCSPResponse(cert *x509.Certificate, response *ocsp.Response) {
+	v.ocspResponses.Store(ocspCacheKey(cert), &ocspResponse{
+		status: response.Status,
+		expiry: ocspExpiry(response),
+	})
+}
+
+// ocspExpiry returns the time after which the OCSP response must no longer be used: its NextUpdate, or at most ocspMaxCacheAge from now.
+func ocspExpiry(response *ocsp.Response) time.Time {
+	expiry := nowFunc().Add(ocspMaxCacheAge)
+	if !response.NextUpdate.IsZero() && response.NextUpdate.Before(expiry) {
+		expiry = response.NextUpdate
+	}
+	return expiry
+}
+
+// pruneOCSPResponses removes expired OCSP responses from the cache.
+func (v *validator) pruneOCSPResponses() {
+	now := nowFunc()
+	v.ocspResponses.Range(func(key, value any) bool {
+		if !now.Before(value.(*ocspResponse).expiry) {
+			v.ocspResponses.Delete(key)
+		}
+		return true
+	})
+}
+
+// ocspEnabled returns true if the revocation policy uses OCSP.
+func (v *validator) ocspEnabled() bool {
+	return v.revocationPolicy == RevocationPolicyPreferOCSP || v.revocationPolicy == RevocationPolicyBoth
+}
+
+// setVerifyConnectionFunc sets config.VerifyConnection to use Validate, after processing the OCSP response stapled by the peer (if any).
+// VerifyConnection is used instead of VerifyPeerCertificate, since the stapled OCSP response is not available to the latter.
+func (v *validator) setVerifyConnectionFunc(config *tls.Config) {
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		for _, chain := range state.VerifiedChains {
+			if len(state.OCSPResponse) > 0 {
+				v.processStapledOCSPResponse(state.OCSPResponse, chain)
+			}
+			if err := v.Validate(chain); err != nil {
+				return &tls.CertificateVerificationError{
+					UnverifiedCertificates: chain,
+					Err:                    err,
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// processStapledOCSPResponse caches the stapled OCSP response for the leaf certificate of the chain if it is valid.
+// Invalid stapled responses are ignored, in which case the OCSP responder is queried during validation.
+func (v *validator) processStapledOCSPResponse(data []byte, chain []*x509.Certificate) {
+	if len(chain) < 2 {
+		return
+	}
+	leaf, issuer := chain[0], chain[1]
+	response, err := parseOCSPResponse(data, leaf, issuer)
+	if err != nil {
+		logger().WithError(err).
+			WithField("Subject", leaf.Subject.String()).
+			WithField("S/N", leaf.SerialNumber.String()).
+			Warn("Ignoring invalid stapled OCSP response")
+		return
+	}
+	if response.Status == ocsp.Unknown {
+		return
+	}
+	v.storeOCSPResponse(leaf, response)
+}
+
+// SetOCSPStapleFunc sets config.GetCertificate to staple the OCSP response of the first certificate in config.Certificates, if the revocation policy uses OCSP.
+// The OCSP response is requested from the OCSP responders of the certificate when it is first needed, and cached until it expires.
+// If no (definitive) OCSP response can be obtained, the certificate is presented without OCSP response.
+func (v *validator) SetOCSPStapleFunc(config *tls.Config) error {
+	if !v.ocspEnabled() || len(config.Certificates) == 0 {
+		return nil
+	}
+	stapler, err := v.newOCSPStapler(config.Certificates[0])
+	if err != nil {
+		return err
+	}
+	config.GetCertificate = func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		certificate := stapler.certificate
+		certificate.OCSPStaple = stapler.staple()
+		return &certificate, nil
+	}
+	return nil
+}
+
+// ocspStapler keeps the OCSP response to staple to a (server) certificate.
+type ocspStapler struct {
+	validator   *validator
+	certificate tls.Certificate
+	leaf        *x509.Certificate
+	// issuer is the issuer from the certificate chain. If the chain doesn't contain it, it's looked up in the truststore.
+	issuer *x509.Certificate
+
+	mux      sync.Mutex
+	response []byte
+	// expiry is the time after which the response must be fetched again.
+	expiry time.Time
+}
+
+func (v *validator) newOCSPStapler(certificate tls.Certificate) (*ocspStapler, error) {
+	if len(certificate.Certificate) == 0 {
+		return nil, errors.New("certificate to staple OCSP response to is empty")
+	}
+	leaf := certificate.Leaf
+	if leaf == nil {
+		var err error
+		if leaf, err = x509.ParseCertificate(certificate.Certificate[0]); err != nil {
+			return nil, fmt.Errorf("parse certificate to staple OCSP response to: %w", err)
+		}
+	}
+	stapler := &ocspStapler{
+		validator:   v,
+		certificate: certificate,
+		leaf:        leaf,
+	}
+	if len(certificate.Certificate) > 1 {
+		issuer, err := x509.ParseCertificate(certificate.Certificate[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse issuer of certificate to staple OCSP response to: %w", err)
+		}
+		stapler.issuer = issuer
+	}
+	return stapler, nil
+}
+
+// staple returns the OCSP response to staple, or nil if there is none. It fetches a new response if the current one expired.
+func (s *ocspStapler) staple() []byte {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if nowFunc().Before(s.expiry) {
+		return s.response
+	}
+	s.response = nil
+	s.expiry = nowFunc().Add(ocspStapleRetryInterval)
+	issuer := s.issuer
+	if issuer == nil {
+		issuer, _ = s.validator.getCert(s.leaf.Issuer.String())
+	}
+	if issuer == nil || len(s.leaf.OCSPServer) == 0 {
+		return nil
+	}
+	for _, responder := range s.leaf.OCSPServer {
+		data, err := s.validator.fetchOCSP(responder, s.leaf, issuer)
+		var response *ocsp.Response
+		if err == nil {
+			response, err = parseOCSPResponse(data, s.leaf, issuer)
+		}
+		if err != nil {
+			logger().WithError(err).
+				WithField("Subject", s.leaf.Subject.String()).
+				WithField("S/N", s.leaf.SerialNumber.String()).
+				WithField("responder", responder).
+				Warn("Failed to fetch OCSP response to staple")
+			continue
+		}
+		if response.Status == ocsp.Unknown {
+			continue
+		}
+		s.response = data
+		s.expiry = ocspExpiry(response)
+		return s.response
+	}
+	return nil
+}
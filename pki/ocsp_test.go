@@ -0,0 +1,408 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+// testOCSPResponder is a local OCSP responder for a test CA.
+type testOCSPResponder struct {
+	ca       *x509.Certificate
+	caKey    crypto.Signer
+	server   *httptest.Server
+	statuses map[string]int
+	requests atomic.Int32
+}
+
+func newTestOCSPResponder(t *testing.T) *testOCSPResponder {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "OCSP Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, _ := x509.ParseCertificate(caDER)
+	responder := &testOCSPResponder{
+		ca:       ca,
+		caKey:    caKey,
+		statuses: map[string]int{},
+	}
+	responder.server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		responder.requests.Add(1)
+		data, _ := io.ReadAll(request.Body)
+		ocspRequest, err := ocsp.ParseRequest(data)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		status, ok := responder.statuses[ocspRequest.SerialNumber.String()]
+		if !ok {
+			status = ocsp.Unknown
+		}
+		_, _ = writer.Write(responder.response(t, ocspRequest.SerialNumber, status))
+	}))
+	t.Cleanup(responder.server.Close)
+	return responder
+}
+
+// response creates a signed OCSP response for the given serial number and status.
+func (r *testOCSPResponder) response(t *testing.T, serialNumber *big.Int, status int) []byte {
+	now := time.Now()
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: serialNumber,
+		ThisUpdate:   now.Add(-time.Minute),
+		NextUpdate:   now.Add(10 * time.Minute),
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = now.Add(-time.Minute)
+	}
+	response, err := ocsp.CreateResponse(r.ca, r.ca, template, r.caKey)
+	require.NoError(t, err)
+	return response
+}
+
+// issue creates a certificate issued by the test CA, with the responder as OCSP server and the given CRL distribution points.
+func (r *testOCSPResponder) issue(t *testing.T, serialNumber int64, status int, crlDistributionPoints ...string) *x509.Certificate {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serialNumber),
+		Subject:               pkix.Name{CommonName: "OCSP Test Leaf"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		OCSPServer:            []string{r.server.URL},
+		CRLDistributionPoints: crlDistributionPoints,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, r.ca, key.Public(), r.caKey)
+	require.NoError(t, err)
+	cert, _ := x509.ParseCertificate(certDER)
+	r.statuses[cert.SerialNumber.String()] = status
+	return cert
+}
+
+func newOCSPTestValidator(t *testing.T, responder *testOCSPResponder, policy string) *validator {
+	config := TestConfig(t)
+	config.RevocationPolicy = policy
+	config.Softfail = false
+	val, err := newValidatorWithHTTPClient(config, &http.Client{Timeout: time.Second})
+	require.NoError(t, err)
+	val.truststore.Store(responder.ca.Subject.String(), responder.ca)
+	return val
+}
+
+func TestValidator_validateOCSP(t *testing.T) {
+	responder := newTestOCSPResponder(t)
+
+	t.Run("good", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 100, ocsp.Good)
+
+		assert.NoError(t, val.validateOCSP(cert))
+	})
+	t.Run("revoked", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 101, ocsp.Revoked)
+
+		assert.ErrorIs(t, val.validateOCSP(cert), ErrCertRevoked)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 102, ocsp.Unknown)
+
+		assert.ErrorIs(t, val.validateOCSP(cert), ErrOCSPUnavailable)
+		_, cached := val.getOCSPResponse(cert)
+		assert.False(t, cached)
+	})
+	t.Run("responses are cached", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 103, ocsp.Good)
+		before := responder.requests.Load()
+
+		require.NoError(t, val.validateOCSP(cert))
+		require.NoError(t, val.validateOCSP(cert))
+
+		assert.Equal(t, before+1, responder.requests.Load())
+	})
+	t.Run("cached response expires", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 104, ocsp.Good)
+		require.NoError(t, val.validateOCSP(cert))
+
+		nowFunc = func() time.Time { return time.Now().Add(11 * time.Minute) }
+		defer func() { nowFunc = time.Now }()
+
+		_, cached := val.getOCSPResponse(cert)
+		assert.False(t, cached)
+		val.pruneOCSPResponses()
+		_, stored := val.ocspResponses.Load(ocspCacheKey(cert))
+		assert.False(t, stored)
+	})
+	t.Run("no OCSP responder", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 105, ocsp.Good)
+		cert.OCSPServer = nil
+
+		assert.ErrorIs(t, val.validateOCSP(cert), errNoOCSPResponder)
+	})
+	t.Run("issuer not in truststore", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		val.truststore.Delete(responder.ca.Subject.String())
+		cert := responder.issue(t, 106, ocsp.Good)
+
+		assert.ErrorIs(t, val.validateOCSP(cert), ErrCertUntrusted)
+	})
+	t.Run("responder unavailable", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 107, ocsp.Good)
+		cert.OCSPServer = []string{"http://127.0.0.1:1"}
+
+		assert.ErrorIs(t, val.validateOCSP(cert), ErrOCSPUnavailable)
+	})
+	t.Run("response signed by other CA", func(t *testing.T) {
+		otherResponder := newTestOCSPResponder(t)
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 108, ocsp.Good)
+		otherResponder.statuses[cert.SerialNumber.String()] = ocsp.Good
+		cert.OCSPServer = []string{otherResponder.server.URL}
+
+		assert.ErrorIs(t, val.validateOCSP(cert), ErrOCSPUnavailable)
+	})
+}
+
+func TestValidator_Validate_revocationPolicy(t *testing.T) {
+	responder := newTestOCSPResponder(t)
+	// CRL can't be downloaded, so any CRL check fails with ErrCRLMissing
+	const crlEndpoint = "http://127.0.0.1:1/ca.crl"
+
+	t.Run("crl-only", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyCRLOnly)
+		cert := responder.issue(t, 200, ocsp.Revoked)
+		before := responder.requests.Load()
+
+		// revoked according to OCSP, but OCSP is not used
+		assert.NoError(t, val.Validate([]*x509.Certificate{cert}))
+		assert.Equal(t, before, responder.requests.Load())
+	})
+	t.Run("prefer-ocsp", func(t *testing.T) {
+		t.Run("good, CRL is not checked", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+			cert := responder.issue(t, 210, ocsp.Good, crlEndpoint)
+
+			assert.NoError(t, val.Validate([]*x509.Certificate{cert}))
+		})
+		t.Run("revoked", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+			cert := responder.issue(t, 211, ocsp.Revoked, crlEndpoint)
+
+			assert.ErrorIs(t, val.Validate([]*x509.Certificate{cert}), ErrCertRevoked)
+		})
+		t.Run("unknown, falls back to CRL", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+			cert := responder.issue(t, 212, ocsp.Unknown, crlEndpoint)
+
+			assert.ErrorIs(t, val.Validate([]*x509.Certificate{cert}), ErrCRLMissing)
+		})
+		t.Run("softfail", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+			val.softfail = true
+			cert := responder.issue(t, 213, ocsp.Unknown, crlEndpoint)
+
+			assert.NoError(t, val.Validate([]*x509.Certificate{cert}))
+		})
+	})
+	t.Run("both", func(t *testing.T) {
+		t.Run("good, CRL is checked", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyBoth)
+			cert := responder.issue(t, 220, ocsp.Good, crlEndpoint)
+
+			assert.ErrorIs(t, val.Validate([]*x509.Certificate{cert}), ErrCRLMissing)
+		})
+		t.Run("good, no CRL", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyBoth)
+			cert := responder.issue(t, 221, ocsp.Good)
+
+			assert.NoError(t, val.Validate([]*x509.Certificate{cert}))
+		})
+		t.Run("revoked takes precedence over missing CRL", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyBoth)
+			cert := responder.issue(t, 222, ocsp.Revoked, crlEndpoint)
+
+			assert.ErrorIs(t, val.Validate([]*x509.Certificate{cert}), ErrCertRevoked)
+		})
+		t.Run("OCSP unavailable", func(t *testing.T) {
+			val := newOCSPTestValidator(t, responder, RevocationPolicyBoth)
+			cert := responder.issue(t, 223, ocsp.Unknown)
+
+			assert.ErrorIs(t, val.Validate([]*x509.Certificate{cert}), ErrOCSPUnavailable)
+		})
+	})
+}
+
+func TestValidator_setVerifyConnectionFunc(t *testing.T) {
+	responder := newTestOCSPResponder(t)
+
+	t.Run("stapled response is used", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 300, ocsp.Good)
+		// stapled response says revoked, responder says good
+		staple := responder.response(t, cert.SerialNumber, ocsp.Revoked)
+		config := &tls.Config{}
+		val.setVerifyConnectionFunc(config)
+		before := responder.requests.Load()
+
+		err := config.VerifyConnection(tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert, responder.ca}},
+			OCSPResponse:   staple,
+		})
+
+		assert.ErrorIs(t, err, ErrCertRevoked)
+		assert.Equal(t, before, responder.requests.Load())
+	})
+	t.Run("invalid stapled response is ignored", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 301, ocsp.Good)
+		config := &tls.Config{}
+		val.setVerifyConnectionFunc(config)
+
+		err := config.VerifyConnection(tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert, responder.ca}},
+			OCSPResponse:   []byte("not an OCSP response"),
+		})
+
+		assert.NoError(t, err)
+	})
+	t.Run("no stapled response", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 302, ocsp.Revoked)
+		config := &tls.Config{}
+		val.setVerifyConnectionFunc(config)
+
+		err := config.VerifyConnection(tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert, responder.ca}},
+		})
+
+		assert.ErrorIs(t, err, ErrCertRevoked)
+	})
+}
+
+func TestValidator_SetOCSPStapleFunc(t *testing.T) {
+	responder := newTestOCSPResponder(t)
+	certificate := func(cert *x509.Certificate, chain ...*x509.Certificate) tls.Certificate {
+		result := tls.Certificate{Certificate: [][]byte{cert.Raw}}
+		for _, issuer := range chain {
+			result.Certificate = append(result.Certificate, issuer.Raw)
+		}
+		return result
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 400, ocsp.Good)
+		config := &tls.Config{Certificates: []tls.Certificate{certificate(cert, responder.ca)}}
+		require.NoError(t, val.SetOCSPStapleFunc(config))
+		before := responder.requests.Load()
+
+		result, err := config.GetCertificate(nil)
+
+		require.NoError(t, err)
+		response, err := ocsp.ParseResponseForCert(result.OCSPStaple, cert, responder.ca)
+		require.NoError(t, err)
+		assert.Equal(t, ocsp.Good, response.Status)
+		t.Run("response is cached", func(t *testing.T) {
+			result, err := config.GetCertificate(nil)
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.OCSPStaple)
+			assert.Equal(t, before+1, responder.requests.Load())
+		})
+		t.Run("expired response is fetched again", func(t *testing.T) {
+			nowFunc = func() time.Time { return time.Now().Add(11 * time.Minute) }
+			defer func() { nowFunc = time.Now }()
+
+			_, err := config.GetCertificate(nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, before+2, responder.requests.Load())
+		})
+	})
+	t.Run("issuer from truststore", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyBoth)
+		cert := responder.issue(t, 401, ocsp.Good)
+		config := &tls.Config{Certificates: []tls.Certificate{certificate(cert)}}
+		require.NoError(t, val.SetOCSPStapleFunc(config))
+
+		result, err := config.GetCertificate(nil)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.OCSPStaple)
+	})
+	t.Run("status unknown is not stapled", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		cert := responder.issue(t, 402, ocsp.Unknown)
+		config := &tls.Config{Certificates: []tls.Certificate{certificate(cert, responder.ca)}}
+		require.NoError(t, val.SetOCSPStapleFunc(config))
+
+		result, err := config.GetCertificate(nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.OCSPStaple)
+		assert.Equal(t, cert.Raw, result.Certificate[0])
+	})
+	t.Run("not set if revocation policy doesn't use OCSP", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyCRLOnly)
+		cert := responder.issue(t, 403, ocsp.Good)
+		config := &tls.Config{Certificates: []tls.Certificate{certificate(cert, responder.ca)}}
+
+		require.NoError(t, val.SetOCSPStapleFunc(config))
+
+		assert.Nil(t, config.GetCertificate)
+	})
+	t.Run("invalid certificate", func(t *testing.T) {
+		val := newOCSPTestValidator(t, responder, RevocationPolicyPreferOCSP)
+		config := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{[]byte("not a certificate")}}}}
+
+		err := val.SetOCSPStapleFunc(config)
+
+		assert.ErrorContains(t, err, "parse certificate to staple OCSP response to")
+	})
+}
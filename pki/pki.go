@@ -79,6 +79,7 @@ func (p *PKI) Shutdown() error {
 // CreateTLSConfig creates a tls.Config based on the given core.TLSConfig for outbound connections to other Nuts nodes.
 // It registers the CA certificates in the trust store in the validator which will start fetching their CRLs.
 // It finally registers a VerifyPeerCertificateFunc in the tls.Config which will validate the peer certificate against the validator.
+// If the revocation policy uses OCSP, a VerifyConnection func is registered instead, so OCSP responses stapled by the peer are used.
 // If TLS is not enabled, it returns nil (and no error).
 func (p *PKI) CreateTLSConfig(cfg core.TLSConfig) (*tls.Config, error) {
 	tlsConfig, trustStore, err := cfg.Load()
@@ -93,7 +94,11 @@ func (p *PKI) CreateTLSConfig(cfg core.TLSConfig) (*tls.Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	_ = p.SetVerifyPeerCertificateFunc(tlsConfig) // no error can occur
+	if p.ocspEnabled() {
+		p.setVerifyConnectionFunc(tlsConfig)
+	} else {
+		_ = p.SetVerifyPeerCertificateFunc(tlsConfig) // no error can occur
+	}
 	return tlsConfig, nil
 }
 
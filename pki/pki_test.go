@@ -69,6 +69,14 @@ func TestPKI_Configure(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+	t.Run("invalid revocation policy", func(t *testing.T) {
+		e := New()
+		e.config.RevocationPolicy = "ocsp-only"
+
+		err := e.Configure(core.ServerConfig{})
+
+		assert.EqualError(t, err, "invalid revocation policy: ocsp-only")
+	})
 }
 
 func TestPKI_Runnable(t *testing.T) {
@@ -179,6 +187,22 @@ func TestPKI_CreateTLSConfig(t *testing.T) {
 		_, ok := e.truststore.Load("CN=Intermediate A CA")
 		assert.True(t, ok)
 	})
+	t.Run("TLS enabled with OCSP", func(t *testing.T) {
+		e := New()
+		e.config.RevocationPolicy = RevocationPolicyPreferOCSP
+		require.NoError(t, e.Configure(core.ServerConfig{}))
+		cfg := core.NewServerConfig().TLS
+		cfg.TrustStoreFile = "test/truststore.pem"
+		cfg.CertFile = "test/A-valid.pem"
+		cfg.CertKeyFile = "test/A-valid.pem"
+
+		tlsConfig, err := e.CreateTLSConfig(cfg)
+
+		require.NoError(t, err)
+		require.NotNil(t, tlsConfig)
+		assert.NotNil(t, tlsConfig.VerifyConnection)
+		assert.Nil(t, tlsConfig.VerifyPeerCertificate)
+	})
 	t.Run("TLS disabled", func(t *testing.T) {
 		e := New()
 		require.NoError(t, e.Configure(core.ServerConfig{}))
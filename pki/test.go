@@ -29,6 +29,7 @@ func TestConfig(t *testing.T) Config {
 		Denylist:           DenylistConfig{},
 		MaxUpdateFailHours: 4,
 		Softfail:           true,
+		RevocationPolicy:   RevocationPolicyCRLOnly,
 	}
 }
 
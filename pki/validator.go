@@ -62,6 +62,12 @@ type validator struct {
 
 	// softfail only rejects certificates that have been revoked or denied
 	softfail bool
+
+	// revocationPolicy specifies whether CRLs, OCSP or both are used to check the revocation status of certificates
+	revocationPolicy string
+
+	// ocspResponses maps ocspCacheKey() of certificates to their cached *ocspResponse
+	ocspResponses sync.Map
 }
 
 type revocationList struct {
@@ -93,6 +99,14 @@ func newValidator(config Config) (*validator, error) {
 
 // NewValidatorWithHTTPClient returns a new instance with a pre-configured HTTP client
 func newValidatorWithHTTPClient(config Config, client *http.Client) (*validator, error) {
+	switch config.RevocationPolicy {
+	case "":
+		config.RevocationPolicy = RevocationPolicyCRLOnly
+	case RevocationPolicyCRLOnly, RevocationPolicyPreferOCSP, RevocationPolicyBoth:
+	default:
+		return nil, fmt.Errorf("invalid revocation policy: %s", config.RevocationPolicy)
+	}
+
 	// Create the new denylist with the config
 	denylist, err := NewDenylist(config.Denylist)
 	if err != nil {
@@ -105,6 +119,7 @@ func newValidatorWithHTTPClient(config Config, client *http.Client) (*validator,
 		denylist:           denylist,
 		maxUpdateFailHours: config.MaxUpdateFailHours,
 		softfail:           config.Softfail,
+		revocationPolicy:   config.RevocationPolicy,
 	}, nil
 }
 
@@ -136,7 +151,7 @@ func (v *validator) Validate(chain []*x509.Certificate) error {
 			errOut := fmt.Errorf("%w: subject=%s, S/N=%s, issuer=%s", err, cert.Subject.String(), cert.SerialNumber.String(), cert.Issuer.String())
 			if v.softfail && !(errors.Is(err, ErrCertRevoked) || errors.Is(err, ErrCertBanned)) {
 				// Accept the certificate even if it cannot be properly validated
-				logger().WithError(errOut).Error("Certificate CRL check softfail bypass. Might be unsafe, find cause of failure!")
+				logger().WithError(errOut).Error("Certificate revocation check softfail bypass. Might be unsafe, find cause of failure!")
 				continue
 			}
 			return errOut
@@ -172,7 +187,35 @@ func (v *validator) validateCert(cert *x509.Certificate) error {
 		}
 	}
 
-	// validate the cert against the CRLs
+	switch v.revocationPolicy {
+	case RevocationPolicyPreferOCSP:
+		err := v.validateOCSP(cert)
+		if errors.Is(err, errNoOCSPResponder) || errors.Is(err, ErrOCSPUnavailable) {
+			// fall back to CRLs
+			return v.validateCRL(cert)
+		}
+		return err
+	case RevocationPolicyBoth:
+		crlErr := v.validateCRL(cert)
+		if errors.Is(crlErr, ErrCertRevoked) {
+			return crlErr
+		}
+		ocspErr := v.validateOCSP(cert)
+		if errors.Is(ocspErr, errNoOCSPResponder) {
+			ocspErr = nil
+		}
+		// revocation takes precedence over CRL or OCSP failures
+		if crlErr != nil && !errors.Is(ocspErr, ErrCertRevoked) {
+			return crlErr
+		}
+		return ocspErr
+	default:
+		return v.validateCRL(cert)
+	}
+}
+
+// validateCRL checks the revocation status of the certificate against the CRLs of its CRL distribution points.
+func (v *validator) validateCRL(cert *x509.Certificate) error {
 	for _, endpoint := range cert.CRLDistributionPoints {
 		crl, ok := v.getCRL(endpoint)
 
@@ -303,6 +346,9 @@ func (v *validator) sync() {
 	// Use a WaitGroup to track when background goroutines are complete
 	wg := &sync.WaitGroup{}
 
+	// remove OCSP responses that can no longer be used
+	v.pruneOCSPResponses()
+
 	// maximum time between updates
 	maxDelay := time.Duration(v.maxUpdateFailHours) * time.Hour
 
@@ -37,6 +37,9 @@ type AuthorizedRequest struct {
 	// ClientId contains the client ID of the client that requested the resource (DID).
 	ClientId string `json:"client_id"`
 
+	// InputDescriptorConstraintIdMap maps the ID field of a Presentation Definition input descriptor constraint to the value provided in the VPs for the constraint.
+	InputDescriptorConstraintIdMap map[string]interface{} `json:"input_descriptor_constraint_id_map,omitempty"`
+
 	// PresentationSubmission contains a JSON object that maps requirements from the Presentation Definition to the verifiable presentations that were used to request an access token.
 	// Specified at https://identity.foundation/presentation-exchange/spec/v2.0.0/
 	// A JSON schema is available at https://identity.foundation/presentation-exchange/#json-schema
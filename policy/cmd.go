@@ -25,7 +25,7 @@ import (
 // FlagSet contains flags relevant for JSON-LD
 func FlagSet() *pflag.FlagSet {
 	flagSet := pflag.NewFlagSet("policy", pflag.ContinueOnError)
-	flagSet.String("policy.directory", "", "Directory to read policy files from. Policy files are JSON files that contain a scope to PresentationDefinition mapping. Mutual exclusive with policy.address.")
+	flagSet.String("policy.directory", "", "Directory to read policy files from. Policy files are JSON files that contain a scope to PresentationDefinition mapping, and optional <scope>.cel files containing a CEL authorization policy for that scope. Changes to the files are loaded without restarting. Mutual exclusive with policy.address.")
 	flagSet.String("policy.address", "", "The address of a remote policy server. Mutual exclusive with policy.directory.")
 	return flagSet
 }
@@ -20,7 +20,7 @@ package policy
 
 type Config struct {
 	// Directory is the directory where the policy files are stored
-	// policy files include a scope to presentation definition mapping
+	// policy files include a scope to presentation definition mapping and CEL authorization policies per scope
 	Directory string `koanf:"directory"`
 	// Address is the address of the policy server
 	Address string `koanf:"address"`
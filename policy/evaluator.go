@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2023 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/cel-go/cel"
+	"github.com/nuts-foundation/nuts-node/policy/api/v1/client"
+	"time"
+)
+
+// errNotBoolean is returned when a policy does not evaluate to a boolean
+var errNotBoolean = errors.New("policy must evaluate to a boolean")
+
+// policyEnvironment declares the variables that are available to authorization policies:
+//   - request: the resource request, with the keys audience, client_id, scope, request_method and request_url
+//   - constraints: the input descriptor constraint ID map of the access token, mapping constraint field IDs to their values
+//   - now: the time of evaluation
+func policyEnvironment() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("request", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("constraints", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("now", cel.TimestampType),
+	)
+}
+
+// authorizationPolicy is a compiled CEL expression that decides whether a request is authorized.
+type authorizationPolicy struct {
+	program cel.Program
+}
+
+// compilePolicy parses and type-checks the given CEL expression. The expression must evaluate to a boolean.
+func compilePolicy(expression string) (*authorizationPolicy, error) {
+	env, err := policyEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, errNotBoolean
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &authorizationPolicy{program: program}, nil
+}
+
+// evaluate evaluates the policy against the given request.
+func (p authorizationPolicy) evaluate(ctx context.Context, requestInfo client.AuthorizedRequest) (bool, error) {
+	constraints := requestInfo.InputDescriptorConstraintIdMap
+	if constraints == nil {
+		constraints = map[string]interface{}{}
+	}
+	result, _, err := p.program.ContextEval(ctx, map[string]interface{}{
+		"request": map[string]string{
+			"audience":       requestInfo.Audience,
+			"client_id":      requestInfo.ClientId,
+			"scope":          requestInfo.Scope,
+			"request_method": requestInfo.RequestMethod,
+			"request_url":    requestInfo.RequestUrl,
+		},
+		"constraints": constraints,
+		"now":         time.Now(),
+	})
+	if err != nil {
+		return false, err
+	}
+	authorized, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%w (got %s)", errNotBoolean, result.Type().TypeName())
+	}
+	return authorized, nil
+}
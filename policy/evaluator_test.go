@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2023 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/policy/api/v1/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilePolicy(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		policy, err := compilePolicy(`request.client_id == "did:web:example.com"`)
+
+		require.NoError(t, err)
+		assert.NotNil(t, policy)
+	})
+	t.Run("err - syntax error", func(t *testing.T) {
+		_, err := compilePolicy(`request.client_id ==`)
+
+		assert.ErrorContains(t, err, "Syntax error")
+	})
+	t.Run("err - undeclared variable", func(t *testing.T) {
+		_, err := compilePolicy(`token.scope == "test"`)
+
+		assert.ErrorContains(t, err, "undeclared reference to 'token'")
+	})
+	t.Run("err - doesn't evaluate to a boolean", func(t *testing.T) {
+		_, err := compilePolicy(`request.client_id`)
+
+		assert.ErrorIs(t, err, errNotBoolean)
+	})
+}
+
+func TestAuthorizationPolicy_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	request := client.AuthorizedRequest{
+		Audience:      "did:web:example.com:verifier",
+		ClientId:      "did:web:example.com:client",
+		Scope:         "test",
+		RequestMethod: "GET",
+		RequestUrl:    "https://example.com/resource",
+		InputDescriptorConstraintIdMap: map[string]interface{}{
+			"organization_name": "Caresoft",
+			"roles":             []interface{}{"nurse", "physician"},
+		},
+	}
+
+	t.Run("ok - request fields", func(t *testing.T) {
+		policy, err := compilePolicy(`request.audience == "did:web:example.com:verifier" &&
+			request.client_id == "did:web:example.com:client" &&
+			request.scope == "test" &&
+			request.request_url.startsWith("https://example.com/")`)
+		require.NoError(t, err)
+
+		authorized, err := policy.evaluate(ctx, request)
+
+		require.NoError(t, err)
+		assert.True(t, authorized)
+	})
+	t.Run("ok - constraints", func(t *testing.T) {
+		policy, err := compilePolicy(`constraints.organization_name == "Caresoft" && "physician" in constraints.roles`)
+		require.NoError(t, err)
+
+		authorized, err := policy.evaluate(ctx, request)
+
+		require.NoError(t, err)
+		assert.True(t, authorized)
+	})
+	t.Run("ok - now", func(t *testing.T) {
+		policy, err := compilePolicy(`now > timestamp("2020-01-01T00:00:00Z")`)
+		require.NoError(t, err)
+
+		authorized, err := policy.evaluate(ctx, request)
+
+		require.NoError(t, err)
+		assert.True(t, authorized)
+	})
+	t.Run("err - dynamic value is not a boolean", func(t *testing.T) {
+		policy, err := compilePolicy(`constraints.organization_name`)
+		require.NoError(t, err)
+
+		authorized, err := policy.evaluate(ctx, request)
+
+		assert.ErrorIs(t, err, errNotBoolean)
+		assert.False(t, authorized)
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/policy/api/v1/client"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"strings"
 )
 
 // ModuleName is the name of the policy module
@@ -34,10 +35,32 @@ var ErrNotFound = errors.New("not found")
 // PDPBackend is the interface for the policy backend
 // Both the remote and local policy backend implement this interface
 type PDPBackend interface {
-	// PresentationDefinition returns the PresentationDefinition for the given scope
-	// scopes are space delimited. It's up to the backend to decide how to handle this
-	PresentationDefinition(ctx context.Context, authorizer did.DID, scope string) (*pe.PresentationDefinition, error)
+	// PresentationDefinitions returns the PresentationDefinitions for the given scope, each with the scope it grants when fulfilled.
+	// scopes are space delimited. It's up to the backend to decide how to handle this.
+	// The PresentationDefinitions are evaluated separately, so only the scopes of which the PresentationDefinition is fulfilled are granted.
+	// Unsupported scopes are left out. ErrNotFound is returned if none of the requested scopes are supported.
+	PresentationDefinitions(ctx context.Context, authorizer did.DID, scope string) (ScopedPresentationDefinitions, error)
 
 	// Authorized returns true if the policy backends authorizes the given request information fall within the policy definition
 	Authorized(ctx context.Context, requestInfo client.AuthorizedRequest) (bool, error)
 }
+
+// ScopedPresentationDefinition is a PresentationDefinition that must be fulfilled to be granted the scope.
+type ScopedPresentationDefinition struct {
+	// Scope is granted when the PresentationDefinition is fulfilled. It may contain multiple space delimited scopes.
+	Scope string
+	// PresentationDefinition is the PresentationDefinition for the scope.
+	PresentationDefinition pe.PresentationDefinition
+}
+
+// ScopedPresentationDefinitions is a list of PresentationDefinitions, each for a different scope.
+type ScopedPresentationDefinitions []ScopedPresentationDefinition
+
+// Scope returns the space delimited scopes of the PresentationDefinitions.
+func (s ScopedPresentationDefinitions) Scope() string {
+	var scopes []string
+	for _, definition := range s {
+		scopes = append(scopes, definition.Scope)
+	}
+	return strings.Join(scopes, " ")
+}
@@ -22,37 +22,94 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/fsnotify/fsnotify"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/policy/api/v1/client"
+	"github.com/nuts-foundation/nuts-node/policy/log"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
 	"io"
 	"os"
+	"path"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
+// policyFileExtension is the file extension of authorization policy files.
+// The file name without extension is the scope the policy applies to.
+const policyFileExtension = ".cel"
+
 // localPDP is a backend for presentation definitions
 // It loads a file with the mapping from oauth scope to presentation definition.
-// It allows access when the requester can present a submission according to the Presentation Definition. It does not do any additional authorization checks.
+// It allows access when the requester can present a submission according to the Presentation Definition.
+// Additional authorization checks can be configured per scope through CEL (Common Expression Language) policy files.
+// Files in the directory are watched, changes are loaded without restarting the node.
 type localPDP struct {
+	// directory is the directory the mapping and policy files are loaded from
+	directory string
 	// mapping holds the oauth scope to presentation definition mapping
 	mapping map[string]validatingPresentationDefinition
+	// policies holds the oauth scope to authorization policy mapping
+	policies map[string]*authorizationPolicy
+	// mux guards mapping and policies, which are replaced on reload
+	mux     sync.RWMutex
+	watcher *fsnotify.Watcher
+	// routines is used to wait for the watcher goroutine to finish on shutdown
+	routines sync.WaitGroup
 }
 
-func (b *localPDP) PresentationDefinition(_ context.Context, _ did.DID, scope string) (*pe.PresentationDefinition, error) {
-	mapping, ok := b.mapping[scope]
-	if !ok {
+// PresentationDefinitions returns the PresentationDefinition of each of the given space delimited scopes.
+// Scopes without a mapping are ignored, so they are never granted.
+func (b *localPDP) PresentationDefinitions(_ context.Context, _ did.DID, scope string) (ScopedPresentationDefinitions, error) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	var result ScopedPresentationDefinitions
+	for _, requested := range strings.Fields(scope) {
+		if slices.ContainsFunc(result, func(definition ScopedPresentationDefinition) bool {
+			return definition.Scope == requested
+		}) {
+			continue
+		}
+		mapping, ok := b.mapping[requested]
+		if !ok {
+			continue
+		}
+		result = append(result, ScopedPresentationDefinition{Scope: requested, PresentationDefinition: pe.PresentationDefinition(mapping)})
+	}
+	if len(result) == 0 {
 		return nil, ErrNotFound
 	}
-	result := pe.PresentationDefinition(mapping)
-	return &result, nil
+	return result, nil
 }
 
-func (b *localPDP) Authorized(_ context.Context, _ client.AuthorizedRequest) (bool, error) {
+// Authorized evaluates the authorization policies of all scopes in the request.
+// Access is granted if all policies evaluate to true. Scopes without a policy don't restrict access.
+func (b *localPDP) Authorized(ctx context.Context, requestInfo client.AuthorizedRequest) (bool, error) {
+	b.mux.RLock()
+	policies := b.policies
+	b.mux.RUnlock()
+
+	for _, scope := range strings.Fields(requestInfo.Scope) {
+		policy, ok := policies[scope]
+		if !ok {
+			continue
+		}
+		authorized, err := policy.evaluate(ctx, requestInfo)
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate policy for scope %s: %w", scope, err)
+		}
+		if !authorized {
+			log.Logger().Debugf("Request denied by policy (scope=%s, client_id=%s)", scope, requestInfo.ClientId)
+			return false, nil
+		}
+	}
 	return true, nil
 }
 
-// loadFromDirectory traverses all .json files in the given directory and loads them
+// loadFromDirectory traverses all .json and .cel files in the given directory and loads them.
+// The loaded mapping and policies replace the current ones, if all files could be loaded.
 func (s *localPDP) loadFromDirectory(directory string) error {
 	// open the directory
 	dir, err := os.Open(directory)
@@ -68,42 +125,148 @@ func (s *localPDP) loadFromDirectory(directory string) error {
 	}
 
 	// load all the files
+	mapping := make(map[string]validatingPresentationDefinition)
+	policies := make(map[string]*authorizationPolicy)
 	for _, file := range files {
 		if file.IsDir() {
 			continue
 		}
-		if !strings.HasSuffix(file.Name(), ".json") {
-			continue
-		}
-		err := s.loadFromFile(fmt.Sprintf("%s/%s", directory, file.Name()))
-		if err != nil {
-			return err
+		filename := path.Join(directory, file.Name())
+		switch {
+		case strings.HasSuffix(file.Name(), ".json"):
+			fileMapping, err := readMappingFile(filename)
+			if err != nil {
+				return err
+			}
+			for scope, definition := range fileMapping {
+				mapping[scope] = definition
+			}
+		case strings.HasSuffix(file.Name(), policyFileExtension):
+			policy, err := readPolicyFile(filename)
+			if err != nil {
+				return err
+			}
+			policies[strings.TrimSuffix(file.Name(), policyFileExtension)] = policy
 		}
 	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.directory = directory
+	s.mapping = mapping
+	s.policies = policies
 	return nil
 }
 
 // LoadFromFile loads the mapping from the given file
 func (s *localPDP) loadFromFile(filename string) error {
+	result, err := readMappingFile(filename)
+	if err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.mapping = result
+	return nil
+}
+
+// watch starts watching the policy directory for changes, reloading all files when a file changes.
+// If reloading fails, the previously loaded mapping and policies stay active.
+func (s *localPDP) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(s.directory); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch policy directory: %w", err)
+	}
+	s.watcher = watcher
+	s.routines.Add(1)
+	go func() {
+		defer s.routines.Done()
+		s.handleEvents(watcher)
+	}()
+	return nil
+}
+
+func (s *localPDP) handleEvents(watcher *fsnotify.Watcher) {
+	// editors and deployment tools often write a file in several steps, so changes are debounced
+	const debounce = 100 * time.Millisecond
+	var reload <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if !strings.HasSuffix(event.Name, ".json") && !strings.HasSuffix(event.Name, policyFileExtension) {
+				continue
+			}
+			reload = time.After(debounce)
+		case <-reload:
+			reload = nil
+			if err := s.loadFromDirectory(s.directory); err != nil {
+				log.Logger().WithError(err).Error("Failed to reload policy files, keeping previous policies")
+			} else {
+				log.Logger().Info("Reloaded policy files")
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Logger().WithError(err).Warn("Error while watching policy directory")
+		}
+	}
+}
+
+// close stops watching the policy directory.
+func (s *localPDP) close() error {
+	if s.watcher == nil {
+		return nil
+	}
+	err := s.watcher.Close()
+	s.routines.Wait()
+	s.watcher = nil
+	return err
+}
+
+// readMappingFile reads the scope to presentation definition mapping from the given file
+func readMappingFile(filename string) (map[string]validatingPresentationDefinition, error) {
 	// read the bytes from the file
 	reader, err := os.Open(filename)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer reader.Close()
 	bytes, err := io.ReadAll(reader)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// unmarshal the bytes into the mapping
 	result := make(map[string]validatingPresentationDefinition)
 	err = json.Unmarshal(bytes, &result)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal Presentation Exchange mapping file %s: %w", filename, err)
+		return nil, fmt.Errorf("failed to unmarshal Presentation Exchange mapping file %s: %w", filename, err)
 	}
-	s.mapping = result
-	return nil
+	return result, nil
+}
+
+// readPolicyFile reads and compiles the authorization policy from the given file
+func readPolicyFile(filename string) (*authorizationPolicy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	result, err := compilePolicy(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile policy file %s: %w", filename, err)
+	}
+	return result, nil
 }
 
 // validatingPresentationDefinition is an alias for PresentationDefinition that validates the JSON on unmarshal.
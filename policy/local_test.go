@@ -20,9 +20,13 @@ package policy
 
 import (
 	"context"
+	"os"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/policy/api/v1/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -59,7 +63,16 @@ func TestStore_ByScope(t *testing.T) {
 	t.Run("err - not found", func(t *testing.T) {
 		store := localPDP{}
 
-		_, err := store.PresentationDefinition(context.Background(), did.DID{}, "eOverdracht-overdrachtsbericht2")
+		_, err := store.PresentationDefinitions(context.Background(), did.DID{}, "eOverdracht-overdrachtsbericht2")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("err - none of the scopes found", func(t *testing.T) {
+		store := localPDP{}
+		err := store.loadFromDirectory("test")
+		require.NoError(t, err)
+
+		_, err = store.PresentationDefinitions(context.Background(), did.DID{}, "unknown other")
 
 		assert.Equal(t, ErrNotFound, err)
 	})
@@ -69,9 +82,178 @@ func TestStore_ByScope(t *testing.T) {
 		err := store.loadFromFile("test/definition_mapping.json")
 		require.NoError(t, err)
 
-		result, err := store.PresentationDefinition(context.Background(), did.DID{}, "eOverdracht-overdrachtsbericht")
+		result, err := store.PresentationDefinitions(context.Background(), did.DID{}, "eOverdracht-overdrachtsbericht")
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "eOverdracht-overdrachtsbericht", result[0].Scope)
+		assert.Equal(t, "pd_any_care_organization", result[0].PresentationDefinition.Id)
+	})
+	t.Run("a presentation definition per scope", func(t *testing.T) {
+		store := localPDP{}
+		err := store.loadFromDirectory("test")
+		require.NoError(t, err)
+
+		result, err := store.PresentationDefinitions(context.Background(), did.DID{}, "eOverdracht-overdrachtsbericht homemonitoring")
+
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "pd_any_care_organization", result[0].PresentationDefinition.Id)
+		assert.Equal(t, "pd_homemonitoring", result[1].PresentationDefinition.Id)
+		assert.Equal(t, "eOverdracht-overdrachtsbericht homemonitoring", result.Scope())
+		merged, err := result.Merge()
+		require.NoError(t, err)
+		assert.Equal(t, "pd_any_care_organization+pd_homemonitoring", merged.Id)
+		assert.Len(t, merged.InputDescriptors, 3)
+		assert.Len(t, merged.SubmissionRequirements, 2)
+	})
+	t.Run("unknown and duplicate scopes are left out", func(t *testing.T) {
+		store := localPDP{}
+		err := store.loadFromDirectory("test")
+		require.NoError(t, err)
+
+		result, err := store.PresentationDefinitions(context.Background(), did.DID{}, "unknown homemonitoring homemonitoring")
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "homemonitoring", result[0].Scope)
+		assert.Equal(t, "pd_homemonitoring", result[0].PresentationDefinition.Id)
+	})
+}
+
+func TestLocalPDP_LoadFromDirectory(t *testing.T) {
+	t.Run("loads mappings and policies", func(t *testing.T) {
+		pdp := localPDP{}
+
+		err := pdp.loadFromDirectory("test")
+
+		require.NoError(t, err)
+		assert.Len(t, pdp.mapping, 2)
+		assert.Len(t, pdp.policies, 1)
+		assert.NotNil(t, pdp.policies["eOverdracht-overdrachtsbericht"])
+	})
+	t.Run("returns an error if a policy is invalid", func(t *testing.T) {
+		pdp := localPDP{}
+
+		err := pdp.loadFromDirectory("test/invalid_policy")
+
+		assert.ErrorContains(t, err, "failed to compile policy file test/invalid_policy/invalid.cel")
+	})
+}
+
+func TestLocalPDP_Authorized(t *testing.T) {
+	ctx := context.Background()
+	pdp := localPDP{}
+	require.NoError(t, pdp.loadFromDirectory("test"))
+	request := client.AuthorizedRequest{
+		Scope:                          "eOverdracht-overdrachtsbericht",
+		RequestMethod:                  "GET",
+		InputDescriptorConstraintIdMap: map[string]interface{}{"organization_city": "Caretown"},
+	}
+
+	t.Run("ok - allowed by policy", func(t *testing.T) {
+		authorized, err := pdp.Authorized(ctx, request)
 
 		require.NoError(t, err)
-		assert.NotNil(t, result)
+		assert.True(t, authorized)
+	})
+	t.Run("ok - denied by policy", func(t *testing.T) {
+		request := request
+		request.RequestMethod = "DELETE"
+
+		authorized, err := pdp.Authorized(ctx, request)
+
+		require.NoError(t, err)
+		assert.False(t, authorized)
+	})
+	t.Run("ok - denied if constraint is missing", func(t *testing.T) {
+		request := request
+		request.InputDescriptorConstraintIdMap = nil
+
+		authorized, err := pdp.Authorized(ctx, request)
+
+		require.NoError(t, err)
+		assert.False(t, authorized)
+	})
+	t.Run("ok - denied if one of the scopes is denied", func(t *testing.T) {
+		request := request
+		request.Scope = "other eOverdracht-overdrachtsbericht"
+		request.RequestMethod = "POST"
+
+		authorized, err := pdp.Authorized(ctx, request)
+
+		require.NoError(t, err)
+		assert.False(t, authorized)
+	})
+	t.Run("ok - scope without policy", func(t *testing.T) {
+		request := request
+		request.Scope = "other"
+		request.RequestMethod = "POST"
+
+		authorized, err := pdp.Authorized(ctx, request)
+
+		require.NoError(t, err)
+		assert.True(t, authorized)
+	})
+	t.Run("err - evaluation fails", func(t *testing.T) {
+		policy, err := compilePolicy(`constraints["unknown"] == "value"`)
+		require.NoError(t, err)
+		pdp := localPDP{policies: map[string]*authorizationPolicy{"test": policy}}
+
+		authorized, err := pdp.Authorized(ctx, client.AuthorizedRequest{Scope: "test"})
+
+		assert.ErrorContains(t, err, "failed to evaluate policy for scope test: no such key: unknown")
+		assert.False(t, authorized)
+	})
+}
+
+func TestLocalPDP_Watch(t *testing.T) {
+	ctx := context.Background()
+	directory := t.TempDir()
+	policyFile := path.Join(directory, "test.cel")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`request.request_method == "GET"`), 0644))
+	pdp := localPDP{}
+	require.NoError(t, pdp.loadFromDirectory(directory))
+	require.NoError(t, pdp.watch())
+	defer pdp.close()
+	request := client.AuthorizedRequest{Scope: "test", RequestMethod: "POST"}
+	authorized, err := pdp.Authorized(ctx, request)
+	require.NoError(t, err)
+	require.False(t, authorized)
+
+	t.Run("reloads changed policy", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(policyFile, []byte(`request.request_method in ["GET", "POST"]`), 0644))
+
+		assert.Eventually(t, func() bool {
+			authorized, _ := pdp.Authorized(ctx, request)
+			return authorized
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("keeps previous policy if changed policy is invalid", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(policyFile, []byte(`request.request_method ==`), 0644))
+		time.Sleep(300 * time.Millisecond)
+
+		authorized, err := pdp.Authorized(ctx, request)
+
+		require.NoError(t, err)
+		assert.True(t, authorized)
+	})
+	t.Run("removing the policy lifts the restriction", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(policyFile, []byte(`false`), 0644))
+		require.Eventually(t, func() bool {
+			authorized, _ := pdp.Authorized(ctx, request)
+			return !authorized
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, os.Remove(policyFile))
+
+		assert.Eventually(t, func() bool {
+			authorized, _ := pdp.Authorized(ctx, request)
+			return authorized
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("close stops watching", func(t *testing.T) {
+		assert.NoError(t, pdp.close())
+		assert.NoError(t, pdp.close())
 	})
 }
@@ -0,0 +1,212 @@
+/*
+ * Copyright (C) 2023 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Merge combines the PresentationDefinitions into a single PresentationDefinition, that is fulfilled if all of them are fulfilled.
+// It is used to tell the client which credentials to present for all scopes at once (see mergePresentationDefinitions).
+func (s ScopedPresentationDefinitions) Merge() (*pe.PresentationDefinition, error) {
+	var scopes []string
+	var definitions []pe.PresentationDefinition
+	for _, definition := range s {
+		scopes = append(scopes, definition.Scope)
+		definitions = append(definitions, definition.PresentationDefinition)
+	}
+	if len(definitions) == 0 {
+		return nil, ErrNotFound
+	}
+	result, err := mergePresentationDefinitions(scopes, definitions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to combine presentation definitions of scopes (%s): %w", s.Scope(), err)
+	}
+	return result, nil
+}
+
+// mergePresentationDefinitions combines the PresentationDefinitions of the given scopes into a single PresentationDefinition,
+// that is fulfilled if all the given PresentationDefinitions are fulfilled.
+//   - input descriptors are combined. Input descriptors with the same ID must be equal, they are only included once.
+//   - if any of the definitions has submission requirements, the groups of each definition are prefixed with the scope to keep them apart.
+//     Definitions without submission requirements get a submission requirement that requires all of their input descriptors.
+//   - formats are intersected, since the presentation must conform to all definitions.
+func mergePresentationDefinitions(scopes []string, definitions []pe.PresentationDefinition) (*pe.PresentationDefinition, error) {
+	if len(definitions) == 1 {
+		result := definitions[0]
+		return &result, nil
+	}
+	withSubmissionRequirements := slices.ContainsFunc(definitions, func(definition pe.PresentationDefinition) bool {
+		return len(definition.SubmissionRequirements) > 0
+	})
+	result := pe.PresentationDefinition{
+		InputDescriptors: []*pe.InputDescriptor{},
+	}
+	var ids, names, purposes []string
+	for i, definition := range definitions {
+		scope := scopes[i]
+		ids = append(ids, definition.Id)
+		if definition.Name != "" {
+			names = append(names, definition.Name)
+		}
+		if definition.Purpose != nil && *definition.Purpose != "" {
+			purposes = append(purposes, *definition.Purpose)
+		}
+		if definition.Frame != nil {
+			if result.Frame != nil {
+				return nil, errors.New("multiple definitions specify a frame")
+			}
+			result.Frame = definition.Frame
+		}
+		if definition.Format != nil {
+			if result.Format == nil {
+				result.Format = definition.Format
+			} else {
+				format, err := intersectFormats(*result.Format, *definition.Format)
+				if err != nil {
+					return nil, err
+				}
+				result.Format = format
+			}
+		}
+
+		for _, inputDescriptor := range definition.InputDescriptors {
+			inputDescriptor := *inputDescriptor
+			if withSubmissionRequirements {
+				inputDescriptor.Group = prefixGroups(scope, inputDescriptor.Group)
+				if len(definition.SubmissionRequirements) == 0 {
+					inputDescriptor.Group = append(inputDescriptor.Group, scope)
+				}
+			}
+			if err := addInputDescriptor(&result, inputDescriptor); err != nil {
+				return nil, err
+			}
+		}
+		if withSubmissionRequirements {
+			if len(definition.SubmissionRequirements) == 0 {
+				result.SubmissionRequirements = append(result.SubmissionRequirements, &pe.SubmissionRequirement{
+					Name: scope,
+					Rule: "all",
+					From: scope,
+				})
+			}
+			for _, submissionRequirement := range definition.SubmissionRequirements {
+				result.SubmissionRequirements = append(result.SubmissionRequirements, prefixSubmissionRequirement(scope, *submissionRequirement))
+			}
+		}
+	}
+	result.Id = strings.Join(ids, "+")
+	result.Name = strings.Join(names, ", ")
+	if len(purposes) > 0 {
+		purpose := strings.Join(purposes, " ")
+		result.Purpose = &purpose
+	}
+	return &result, nil
+}
+
+// addInputDescriptor adds the input descriptor to the definition.
+// If the definition already contains an input descriptor with the same ID, their groups are combined.
+func addInputDescriptor(definition *pe.PresentationDefinition, inputDescriptor pe.InputDescriptor) error {
+	for _, existing := range definition.InputDescriptors {
+		if existing.Id != inputDescriptor.Id {
+			continue
+		}
+		left, right := *existing, inputDescriptor
+		left.Group, right.Group = nil, nil
+		if !reflect.DeepEqual(left, right) {
+			return fmt.Errorf("input descriptors with ID '%s' differ", inputDescriptor.Id)
+		}
+		for _, group := range inputDescriptor.Group {
+			if !slices.Contains(existing.Group, group) {
+				existing.Group = append(slices.Clip(existing.Group), group)
+			}
+		}
+		return nil
+	}
+	definition.InputDescriptors = append(definition.InputDescriptors, &inputDescriptor)
+	return nil
+}
+
+// intersectFormats returns the claim formats (and their algorithms/proof types) that are supported by both designations.
+func intersectFormats(left pe.PresentationDefinitionClaimFormatDesignations, right pe.PresentationDefinitionClaimFormatDesignations) (*pe.PresentationDefinitionClaimFormatDesignations, error) {
+	result := pe.PresentationDefinitionClaimFormatDesignations{}
+	for format, leftProperties := range left {
+		rightProperties, ok := right[format]
+		if !ok {
+			continue
+		}
+		properties := map[string][]string{}
+		supported := true
+		for name, values := range leftProperties {
+			if _, ok := rightProperties[name]; !ok {
+				properties[name] = values
+				continue
+			}
+			var shared []string
+			for _, value := range values {
+				if slices.Contains(rightProperties[name], value) {
+					shared = append(shared, value)
+				}
+			}
+			if len(shared) == 0 {
+				supported = false
+				break
+			}
+			properties[name] = shared
+		}
+		if !supported {
+			continue
+		}
+		for name, values := range rightProperties {
+			if _, ok := leftProperties[name]; !ok {
+				properties[name] = values
+			}
+		}
+		result[format] = properties
+	}
+	if len(result) == 0 {
+		return nil, errors.New("definitions don't share a supported format")
+	}
+	return &result, nil
+}
+
+func prefixGroups(scope string, groups []string) []string {
+	result := make([]string, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, scope+"/"+group)
+	}
+	return result
+}
+
+func prefixSubmissionRequirement(scope string, submissionRequirement pe.SubmissionRequirement) *pe.SubmissionRequirement {
+	if submissionRequirement.From != "" {
+		submissionRequirement.From = scope + "/" + submissionRequirement.From
+	}
+	var nested []*pe.SubmissionRequirement
+	for _, current := range submissionRequirement.FromNested {
+		nested = append(nested, prefixSubmissionRequirement(scope, *current))
+	}
+	submissionRequirement.FromNested = nested
+	return &submissionRequirement
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (C) 2023 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePresentationDefinitions(t *testing.T) {
+	organization := &pe.InputDescriptor{Id: "organization", Constraints: &pe.Constraints{Fields: []pe.Field{{Path: []string{"$.type"}}}}}
+	employee := &pe.InputDescriptor{Id: "employee", Constraints: &pe.Constraints{Fields: []pe.Field{{Path: []string{"$.credentialSubject.role"}}}}}
+	patient := &pe.InputDescriptor{Id: "patient", Group: []string{"A"}, Constraints: &pe.Constraints{Fields: []pe.Field{{Path: []string{"$.credentialSubject.patient"}}}}}
+
+	t.Run("single definition is returned as-is", func(t *testing.T) {
+		definition := pe.PresentationDefinition{Id: "1", InputDescriptors: []*pe.InputDescriptor{organization}}
+
+		result, err := mergePresentationDefinitions([]string{"a"}, []pe.PresentationDefinition{definition})
+
+		require.NoError(t, err)
+		assert.Equal(t, definition, *result)
+	})
+	t.Run("input descriptors are combined", func(t *testing.T) {
+		purpose := "Employee access"
+		definitions := []pe.PresentationDefinition{
+			{Id: "1", Name: "Organization", InputDescriptors: []*pe.InputDescriptor{organization}},
+			{Id: "2", Name: "Employee", Purpose: &purpose, InputDescriptors: []*pe.InputDescriptor{organization, employee}},
+		}
+
+		result, err := mergePresentationDefinitions([]string{"a", "b"}, definitions)
+
+		require.NoError(t, err)
+		assert.Equal(t, "1+2", result.Id)
+		assert.Equal(t, "Organization, Employee", result.Name)
+		assert.Equal(t, "Employee access", *result.Purpose)
+		require.Len(t, result.InputDescriptors, 2)
+		assert.Equal(t, "organization", result.InputDescriptors[0].Id)
+		assert.Equal(t, "employee", result.InputDescriptors[1].Id)
+		assert.Empty(t, result.SubmissionRequirements)
+	})
+	t.Run("submission requirements are combined", func(t *testing.T) {
+		definitions := []pe.PresentationDefinition{
+			{Id: "1", InputDescriptors: []*pe.InputDescriptor{organization}},
+			{
+				Id:               "2",
+				InputDescriptors: []*pe.InputDescriptor{organization, patient},
+				SubmissionRequirements: []*pe.SubmissionRequirement{
+					{Rule: "pick", FromNested: []*pe.SubmissionRequirement{{Rule: "all", From: "A"}}},
+				},
+			},
+		}
+
+		result, err := mergePresentationDefinitions([]string{"a", "b"}, definitions)
+
+		require.NoError(t, err)
+		require.Len(t, result.InputDescriptors, 2)
+		assert.Equal(t, []string{"a"}, result.InputDescriptors[0].Group)
+		assert.Equal(t, []string{"b/A"}, result.InputDescriptors[1].Group)
+		require.Len(t, result.SubmissionRequirements, 2)
+		assert.Equal(t, pe.SubmissionRequirement{Name: "a", Rule: "all", From: "a"}, *result.SubmissionRequirements[0])
+		assert.Equal(t, "b/A", result.SubmissionRequirements[1].FromNested[0].From)
+		t.Run("source definitions are not modified", func(t *testing.T) {
+			assert.Empty(t, organization.Group)
+			assert.Equal(t, []string{"A"}, patient.Group)
+			assert.Equal(t, "A", definitions[1].SubmissionRequirements[0].FromNested[0].From)
+		})
+	})
+	t.Run("formats are intersected", func(t *testing.T) {
+		definitions := []pe.PresentationDefinition{
+			{Id: "1", Format: &pe.PresentationDefinitionClaimFormatDesignations{
+				"ldp_vc": {"proof_type": {"JsonWebSignature2020", "Ed25519Signature2018"}},
+				"jwt_vc": {"alg": {"ES256"}},
+			}},
+			{Id: "2"},
+			{Id: "3", Format: &pe.PresentationDefinitionClaimFormatDesignations{
+				"ldp_vc": {"proof_type": {"JsonWebSignature2020"}},
+				"jwt_vp": {"alg": {"ES256"}},
+			}},
+		}
+
+		result, err := mergePresentationDefinitions([]string{"a", "b", "c"}, definitions)
+
+		require.NoError(t, err)
+		assert.Equal(t, pe.PresentationDefinitionClaimFormatDesignations{
+			"ldp_vc": {"proof_type": {"JsonWebSignature2020"}},
+		}, *result.Format)
+	})
+	t.Run("err - no shared format", func(t *testing.T) {
+		definitions := []pe.PresentationDefinition{
+			{Id: "1", Format: &pe.PresentationDefinitionClaimFormatDesignations{"jwt_vc": {"alg": {"ES256"}}}},
+			{Id: "2", Format: &pe.PresentationDefinitionClaimFormatDesignations{"jwt_vc": {"alg": {"EdDSA"}}}},
+		}
+
+		_, err := mergePresentationDefinitions([]string{"a", "b"}, definitions)
+
+		assert.EqualError(t, err, "definitions don't share a supported format")
+	})
+	t.Run("err - conflicting input descriptors", func(t *testing.T) {
+		otherOrganization := *employee
+		otherOrganization.Id = organization.Id
+		definitions := []pe.PresentationDefinition{
+			{Id: "1", InputDescriptors: []*pe.InputDescriptor{organization}},
+			{Id: "2", InputDescriptors: []*pe.InputDescriptor{&otherOrganization}},
+		}
+
+		_, err := mergePresentationDefinitions([]string{"a", "b"}, definitions)
+
+		assert.EqualError(t, err, "input descriptors with ID 'organization' differ")
+	})
+	t.Run("err - multiple frames", func(t *testing.T) {
+		definitions := []pe.PresentationDefinition{
+			{Id: "1", Frame: &pe.Frame{}},
+			{Id: "2", Frame: &pe.Frame{}},
+		}
+
+		_, err := mergePresentationDefinitions([]string{"a", "b"}, definitions)
+
+		assert.EqualError(t, err, "multiple definitions specify a frame")
+	})
+}
+
+func TestScopedPresentationDefinitions_Merge(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		definitions := ScopedPresentationDefinitions{
+			{Scope: "a", PresentationDefinition: pe.PresentationDefinition{Id: "1"}},
+			{Scope: "b", PresentationDefinition: pe.PresentationDefinition{Id: "2"}},
+		}
+
+		result, err := definitions.Merge()
+
+		require.NoError(t, err)
+		assert.Equal(t, "1+2", result.Id)
+	})
+	t.Run("err - no definitions", func(t *testing.T) {
+		_, err := ScopedPresentationDefinitions{}.Merge()
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("err - definitions can't be combined", func(t *testing.T) {
+		definitions := ScopedPresentationDefinitions{
+			{Scope: "a", PresentationDefinition: pe.PresentationDefinition{Id: "1", Frame: &pe.Frame{}}},
+			{Scope: "b", PresentationDefinition: pe.PresentationDefinition{Id: "2", Frame: &pe.Frame{}}},
+		}
+
+		_, err := definitions.Merge()
+
+		assert.EqualError(t, err, "unable to combine presentation definitions of scopes (a b): multiple definitions specify a frame")
+	})
+}
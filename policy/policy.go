@@ -26,7 +26,6 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/pki"
 	"github.com/nuts-foundation/nuts-node/policy/api/v1/client"
-	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"net/url"
 	"time"
 )
@@ -80,15 +79,31 @@ func (b *Router) Configure(config core.ServerConfig) error {
 	return nil
 }
 
+// Start starts watching the policy directory for changes, if the local policy backend is used.
+func (b *Router) Start() error {
+	if local, ok := b.backend.(*localPDP); ok {
+		return local.watch()
+	}
+	return nil
+}
+
+// Shutdown stops watching the policy directory.
+func (b *Router) Shutdown() error {
+	if local, ok := b.backend.(*localPDP); ok {
+		return local.close()
+	}
+	return nil
+}
+
 func (b *Router) Config() interface{} {
 	return &b.config
 }
 
-func (b *Router) PresentationDefinition(ctx context.Context, authorizer did.DID, scope string) (*pe.PresentationDefinition, error) {
+func (b *Router) PresentationDefinitions(ctx context.Context, authorizer did.DID, scope string) (ScopedPresentationDefinitions, error) {
 	if b.backend == nil {
 		return nil, errors.New("no policy backend configured")
 	}
-	return b.backend.PresentationDefinition(ctx, authorizer, scope)
+	return b.backend.PresentationDefinitions(ctx, authorizer, scope)
 }
 
 func (b *Router) Authorized(ctx context.Context, requestInfo client.AuthorizedRequest) (bool, error) {
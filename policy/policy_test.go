@@ -92,6 +92,27 @@ func TestRouter_Configure(t *testing.T) {
 	})
 }
 
+func TestRouter_Start(t *testing.T) {
+	t.Run("local backend watches directory", func(t *testing.T) {
+		router := Router{}
+		router.config.Directory = "test"
+		require.NoError(t, router.Configure(core.ServerConfig{}))
+
+		err := router.Start()
+
+		require.NoError(t, err)
+		assert.NotNil(t, router.backend.(*localPDP).watcher)
+		assert.NoError(t, router.Shutdown())
+		assert.Nil(t, router.backend.(*localPDP).watcher)
+	})
+	t.Run("remote backend", func(t *testing.T) {
+		router := Router{backend: &remote{}}
+
+		assert.NoError(t, router.Start())
+		assert.NoError(t, router.Shutdown())
+	})
+}
+
 func TestRouter_Name(t *testing.T) {
 	router := Router{}
 
@@ -124,17 +145,18 @@ func TestRouterForwarding(t *testing.T) {
 		})
 	})
 
-	t.Run("PresentationDefinition", func(t *testing.T) {
+	t.Run("PresentationDefinitions", func(t *testing.T) {
 		t.Run("ok", func(t *testing.T) {
-			router.backend.(*MockPDPBackend).EXPECT().PresentationDefinition(ctx, testDID, "test").Return(&presentationDefinition, nil)
+			definitions := ScopedPresentationDefinitions{{Scope: "test", PresentationDefinition: presentationDefinition}}
+			router.backend.(*MockPDPBackend).EXPECT().PresentationDefinitions(ctx, testDID, "test").Return(definitions, nil)
 
-			result, err := router.PresentationDefinition(ctx, testDID, "test")
+			result, err := router.PresentationDefinitions(ctx, testDID, "test")
 
 			require.NoError(t, err)
-			assert.Equal(t, presentationDefinition, *result)
+			assert.Equal(t, definitions, result)
 		})
 		t.Run("no backend configured", func(t *testing.T) {
-			result, err := (&Router{}).PresentationDefinition(ctx, testDID, "test")
+			result, err := (&Router{}).PresentationDefinitions(ctx, testDID, "test")
 
 			require.EqualError(t, err, "no policy backend configured")
 			assert.Nil(t, result)
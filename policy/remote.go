@@ -22,7 +22,6 @@ import (
 	"context"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/policy/api/v1/client"
-	"github.com/nuts-foundation/nuts-node/vcr/pe"
 )
 
 type remote struct {
@@ -30,8 +29,14 @@ type remote struct {
 	client  client.HTTPClient
 }
 
-func (b remote) PresentationDefinition(ctx context.Context, authorizer did.DID, scope string) (*pe.PresentationDefinition, error) {
-	return b.client.PresentationDefinition(ctx, b.address, authorizer, scope)
+// PresentationDefinitions retrieves the PresentationDefinition from the remote policy server.
+// The remote server decides on the combination of scopes, so all requested scopes are granted when it is fulfilled.
+func (b remote) PresentationDefinitions(ctx context.Context, authorizer did.DID, scope string) (ScopedPresentationDefinitions, error) {
+	result, err := b.client.PresentationDefinition(ctx, b.address, authorizer, scope)
+	if err != nil {
+		return nil, err
+	}
+	return ScopedPresentationDefinitions{{Scope: scope, PresentationDefinition: *result}}, nil
 }
 
 func (b remote) Authorized(ctx context.Context, requestInfo client.AuthorizedRequest) (bool, error) {
@@ -42,5 +42,8 @@ func FlagSet() *pflag.FlagSet {
 		"If not set it, defaults to a SQLite database stored inside the configured data directory. "+
 		"Note: using SQLite is not recommended in production environments. "+
 		"If using SQLite anyways, remember to enable foreign keys ('_foreign_keys=on') and the write-ahead-log ('_journal_mode=WAL').")
+	flagSet.String("storage.session.type", defs.Session.Type, "Type of the session database, which holds OAuth2 flows, nonces and access tokens. "+
+		"Supported values are 'memory' (default), 'redis' (uses storage.redis) and 'sql' (uses storage.sql). "+
+		"Use 'redis' or 'sql' when running multiple nodes behind a load balancer, so sessions are shared between them.")
 	return flagSet
 }
@@ -20,14 +20,19 @@ package storage
 
 // Config specifies config for the storage engine.
 type Config struct {
-	BBolt BBoltConfig `koanf:"bbolt"`
-	Redis RedisConfig `koanf:"redis"`
-	SQL   SQLConfig   `koanf:"sql"`
+	BBolt   BBoltConfig   `koanf:"bbolt"`
+	Redis   RedisConfig   `koanf:"redis"`
+	SQL     SQLConfig     `koanf:"sql"`
+	Session SessionConfig `koanf:"session"`
 }
 
 // DefaultConfig returns the default configuration for the module.
 func DefaultConfig() Config {
-	return Config{}
+	return Config{
+		Session: SessionConfig{
+			Type: SessionDatabaseTypeMemory,
+		},
+	}
 }
 
 // SQLConfig specifies config for the SQL storage engine.
@@ -35,3 +40,18 @@ type SQLConfig struct {
 	// ConnectionString is the connection string for the SQL database.
 	ConnectionString string `koanf:"connection"`
 }
+
+const (
+	// SessionDatabaseTypeMemory specifies the session database is kept in memory, which is not shared between nodes.
+	SessionDatabaseTypeMemory = "memory"
+	// SessionDatabaseTypeRedis specifies the session database is stored in the configured Redis database.
+	SessionDatabaseTypeRedis = "redis"
+	// SessionDatabaseTypeSQL specifies the session database is stored in the SQL database.
+	SessionDatabaseTypeSQL = "sql"
+)
+
+// SessionConfig specifies config for the session database.
+type SessionConfig struct {
+	// Type specifies the type of session database to use: memory, redis or sql.
+	Type string `koanf:"type"`
+}
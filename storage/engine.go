@@ -150,6 +150,37 @@ func (e *engine) Configure(config core.ServerConfig) error {
 		return fmt.Errorf("failed to initialize SQL database: %w", err)
 	}
 
+	if err := e.initSessionDatabase(); err != nil {
+		return fmt.Errorf("failed to initialize session database: %w", err)
+	}
+
+	return nil
+}
+
+// initSessionDatabase replaces the default in-memory session database if another type is configured.
+// Redis and SQL session databases can be shared between nodes, which is required when running multiple nodes behind a load balancer.
+func (e *engine) initSessionDatabase() error {
+	var sessionDB SessionDatabase
+	switch e.config.Session.Type {
+	case "", SessionDatabaseTypeMemory:
+		return nil
+	case SessionDatabaseTypeRedis:
+		if !e.config.Redis.isConfigured() {
+			return errors.New("session database type 'redis' requires Redis to be configured")
+		}
+		redisSessionDB, err := NewRedisSessionDatabase(e.config.Redis)
+		if err != nil {
+			return fmt.Errorf("unable to configure Redis session database: %w", err)
+		}
+		sessionDB = redisSessionDB
+	case SessionDatabaseTypeSQL:
+		sessionDB = NewSQLSessionDatabase(e.sqlDB)
+	default:
+		return fmt.Errorf("unsupported session database type: %s", e.config.Session.Type)
+	}
+	log.Logger().Infof("Using %s session database", e.config.Session.Type)
+	e.sessionDatabase.close()
+	e.sessionDatabase = sessionDB
 	return nil
 }
 
@@ -170,7 +201,7 @@ func (e *engine) GetSQLDatabase() *gorm.DB {
 
 // initSQLDatabase initializes the SQL database connection.
 // If the connection string is not configured, it defaults to a SQLite database, stored in the node's data directory.
-// Note: only SQLite is supported for now
+// Supported databases are SQLite, PostgreSQL and MySQL. The schema migrations are run on startup.
 func (e *engine) initSQLDatabase() error {
 	connectionString := e.config.SQL.ConnectionString
 	if len(connectionString) == 0 {
@@ -192,6 +223,11 @@ func (e *engine) initSQLDatabase() error {
 				return postgres.New(postgres.Config{Conn: sqlDB})
 			},
 		},
+		"postgresql": {
+			connector: func(sqlDB *sql.DB) gorm.Dialector {
+				return postgres.New(postgres.Config{Conn: sqlDB})
+			},
+		},
 		"mysql": {
 			connector: func(sqlDB *sql.DB) gorm.Dialector {
 				return mysql.New(mysql.Config{Conn: sqlDB})
@@ -205,17 +241,17 @@ func (e *engine) initSQLDatabase() error {
 			break
 		}
 	}
+	// Parse connection string, so credentials can be redacted from errors
+	connectionURL, err := url.Parse(connectionString)
+	if err != nil {
+		return errors.New("invalid SQL database connection string")
+	}
 	if adapter == nil {
-		return fmt.Errorf("unsupported SQL database connection: %s", connectionString)
+		return fmt.Errorf("unsupported SQL database connection: %s", connectionURL.Redacted())
 	}
 
 	// Open connection and migrate
-	var err error
-	connectionURL, err := url.Parse(connectionString)
-	if err != nil {
-		return err
-	}
-	dbMigrator := dbmate.New(connectionURL)
+	dbMigrator := newSQLMigrator(connectionURL)
 	migratorDriver, err := dbMigrator.Driver()
 	if err != nil {
 		return err
@@ -225,14 +261,8 @@ func (e *engine) initSQLDatabase() error {
 		return err
 	}
 	log.Logger().Debug("Running database migrations...")
-
-	// we need the connectionString with adapter specific prefix here
-	dbMigrator.FS = sqlMigrationsFS
-	dbMigrator.MigrationsDir = []string{"sql_migrations"}
-	dbMigrator.AutoDumpSchema = false
-	dbMigrator.Log = sqlMigrationLogger{}
 	if err = dbMigrator.CreateAndMigrate(); err != nil {
-		return fmt.Errorf("failed to migrate database: %w on %s", err, connectionString)
+		return fmt.Errorf("failed to migrate database: %w on %s", err, connectionURL.Redacted())
 	}
 
 	e.sqlDB, err = gorm.Open(adapter.connector(sqlDB), &gorm.Config{
@@ -248,6 +278,17 @@ func (e *engine) initSQLDatabase() error {
 	return nil
 }
 
+// newSQLMigrator creates a dbmate instance that runs the embedded schema migrations on the given database.
+func newSQLMigrator(connectionURL *url.URL) *dbmate.DB {
+	// we need the connectionString with adapter specific prefix here
+	dbMigrator := dbmate.New(connectionURL)
+	dbMigrator.FS = sqlMigrationsFS
+	dbMigrator.MigrationsDir = []string{"sql_migrations"}
+	dbMigrator.AutoDumpSchema = false
+	dbMigrator.Log = sqlMigrationLogger{}
+	return dbMigrator
+}
+
 func sqliteConnectionString(datadir string) string {
 	return "sqlite:file:" + path.Join(datadir, "sqlite.db?_journal_mode=WAL&_foreign_keys=on")
 }
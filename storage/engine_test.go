@@ -20,6 +20,7 @@ package storage
 
 import (
 	"errors"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/test/io"
@@ -157,3 +158,49 @@ func Test_engine_sqlDatabase(t *testing.T) {
 		assert.Equal(t, len(sqlFiles), count)
 	})
 }
+
+func Test_engine_sessionDatabase(t *testing.T) {
+	t.Run("defaults to in-memory", func(t *testing.T) {
+		e := NewTestStorageEngine(t)
+
+		assert.IsType(t, &InMemorySessionDatabase{}, e.GetSessionDatabase())
+	})
+	t.Run("redis", func(t *testing.T) {
+		redis := miniredis.RunT(t)
+		e := New().(*engine)
+		e.config.Redis = RedisConfig{Address: redis.Addr()}
+		e.config.Session.Type = SessionDatabaseTypeRedis
+		require.NoError(t, e.Configure(core.ServerConfig{Datadir: io.TestDirectory(t)}))
+		t.Cleanup(func() {
+			_ = e.Shutdown()
+		})
+
+		assert.IsType(t, &RedisSessionDatabase{}, e.GetSessionDatabase())
+	})
+	t.Run("redis, but Redis is not configured", func(t *testing.T) {
+		e := New().(*engine)
+		e.config.Session.Type = SessionDatabaseTypeRedis
+
+		err := e.Configure(core.ServerConfig{Datadir: io.TestDirectory(t)})
+
+		assert.EqualError(t, err, "failed to initialize session database: session database type 'redis' requires Redis to be configured")
+	})
+	t.Run("sql", func(t *testing.T) {
+		e := New().(*engine)
+		e.config.Session.Type = SessionDatabaseTypeSQL
+		require.NoError(t, e.Configure(core.ServerConfig{Datadir: io.TestDirectory(t)}))
+		t.Cleanup(func() {
+			_ = e.Shutdown()
+		})
+
+		assert.IsType(t, &SQLSessionDatabase{}, e.GetSessionDatabase())
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		e := New().(*engine)
+		e.config.Session.Type = "other"
+
+		err := e.Configure(core.ServerConfig{Datadir: io.TestDirectory(t)})
+
+		assert.EqualError(t, err, "failed to initialize session database: unsupported session database type: other")
+	})
+}
@@ -69,6 +69,9 @@ type database interface {
 
 var ErrNotFound = errors.New("not found")
 
+// ErrExists is returned by SessionStore.PutIfAbsent when the key already exists.
+var ErrExists = errors.New("already exists")
+
 // SessionDatabase is a non-persistent database that holds session data on a KV basis.
 // Keys could be access tokens, nonce's, authorization codes, etc.
 // All entries are stored with a TTL, so they will be removed automatically.
@@ -92,6 +95,14 @@ type SessionStore interface {
 	// Get returns the value for the given key.
 	// Returns ErrNotFound if the key does not exist.
 	Get(key string, target interface{}) error
+	// GetAndDelete returns the value for the given key and deletes it, as a single operation.
+	// If multiple callers try to get the same key concurrently, only one of them gets the value.
+	// Returns ErrNotFound if the key does not exist.
+	GetAndDelete(key string, target interface{}) error
 	// Put stores the given value for the given key.
 	Put(key string, value interface{}) error
+	// PutIfAbsent stores the given value for the given key, if the key does not exist yet, as a single operation.
+	// If multiple callers try to store the same key concurrently, only one of them succeeds.
+	// Returns ErrExists if the key already exists.
+	PutIfAbsent(key string, value interface{}) error
 }
@@ -89,6 +89,9 @@ func Test_redisDatabase_createStore(t *testing.T) {
 		})
 
 		// Setup client-side TLS config
+		t.Cleanup(func() {
+			redisTLSModifier = func(conf *tls.Config) {}
+		})
 		redisTLSModifier = func(conf *tls.Config) {
 			conf.InsecureSkipVerify = true
 		}
@@ -187,6 +190,9 @@ func Test_redisDatabase_createStore(t *testing.T) {
 			})
 
 			// Setup client-side TLS config
+			t.Cleanup(func() {
+				redisTLSModifier = func(conf *tls.Config) {}
+			})
 			redisTLSModifier = func(conf *tls.Config) {
 				conf.InsecureSkipVerify = true
 			}
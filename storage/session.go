@@ -147,6 +147,23 @@ func (i InMemorySessionStore) Get(key string, target interface{}) error {
 	return json.Unmarshal([]byte(entry.Value), target)
 }
 
+func (i InMemorySessionStore) GetAndDelete(key string, target interface{}) error {
+	i.db.mux.Lock()
+	defer i.db.mux.Unlock()
+
+	fullKey := i.getFullKey(key)
+	entry, ok := i.db.entries[fullKey]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(i.db.entries, fullKey)
+	if entry.Expiry.Before(time.Now()) {
+		return ErrNotFound
+	}
+
+	return json.Unmarshal([]byte(entry.Value), target)
+}
+
 func (i InMemorySessionStore) Put(key string, value interface{}) error {
 	i.db.mux.Lock()
 	defer i.db.mux.Unlock()
@@ -164,6 +181,25 @@ func (i InMemorySessionStore) Put(key string, value interface{}) error {
 	return nil
 }
 
+func (i InMemorySessionStore) PutIfAbsent(key string, value interface{}) error {
+	i.db.mux.Lock()
+	defer i.db.mux.Unlock()
+
+	fullKey := i.getFullKey(key)
+	if entry, ok := i.db.entries[fullKey]; ok && !entry.Expiry.Before(time.Now()) {
+		return ErrExists
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	i.db.entries[fullKey] = expiringEntry{
+		Value:  string(bytes),
+		Expiry: time.Now().Add(i.ttl),
+	}
+	return nil
+}
+
 func (i InMemorySessionStore) getFullKey(key string) string {
 	return strings.Join(append(i.prefixes, key), "/")
 }
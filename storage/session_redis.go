@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/storage/log"
+	"github.com/redis/go-redis/v9"
+	"strings"
+	"time"
+)
+
+var _ SessionDatabase = (*RedisSessionDatabase)(nil)
+var _ SessionStore = (*RedisSessionStore)(nil)
+
+// redisSessionKeyPrefix is the prefix (after the optional database name) of all keys stored by the RedisSessionDatabase.
+const redisSessionKeyPrefix = "session"
+
+// RedisSessionDatabase is a SessionDatabase that stores session data in Redis.
+// It can be shared by multiple nodes, so sessions survive requests being routed to different nodes.
+// Expiry of entries is handled by Redis itself.
+type RedisSessionDatabase struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisSessionDatabase creates a new RedisSessionDatabase using the given Redis configuration.
+func NewRedisSessionDatabase(config RedisConfig) (*RedisSessionDatabase, error) {
+	redisDB, err := createRedisDatabase(config)
+	if err != nil {
+		return nil, err
+	}
+	var client redis.UniversalClient
+	if redisDB.sentinelOptions != nil {
+		client = redis.NewFailoverClient(redisDB.sentinelOptions)
+	} else {
+		client = redis.NewClient(redisDB.options)
+	}
+	var prefixParts []string
+	if len(config.Database) > 0 {
+		prefixParts = append(prefixParts, config.Database)
+	}
+	prefixParts = append(prefixParts, redisSessionKeyPrefix)
+	return &RedisSessionDatabase{
+		client: client,
+		prefix: strings.ToLower(strings.Join(prefixParts, "_")),
+	}, nil
+}
+
+func (r *RedisSessionDatabase) GetStore(ttl time.Duration, keys ...string) SessionStore {
+	return RedisSessionStore{
+		ttl:      ttl,
+		prefixes: append([]string{r.prefix}, keys...),
+		client:   r.client,
+	}
+}
+
+func (r *RedisSessionDatabase) close() {
+	if err := r.client.Close(); err != nil {
+		log.Logger().WithError(err).Error("Failed to close Redis session database")
+	}
+}
+
+// RedisSessionStore is a SessionStore backed by Redis.
+type RedisSessionStore struct {
+	ttl      time.Duration
+	prefixes []string
+	client   redis.UniversalClient
+}
+
+func (r RedisSessionStore) Delete(key string) error {
+	if err := r.client.Del(context.Background(), r.getFullKey(key)).Err(); err != nil {
+		return fmt.Errorf("unable to delete session value: %w", err)
+	}
+	return nil
+}
+
+func (r RedisSessionStore) Exists(key string) bool {
+	count, err := r.client.Exists(context.Background(), r.getFullKey(key)).Result()
+	if err != nil {
+		log.Logger().WithError(err).Error("Failed to check existence of session value")
+		return false
+	}
+	return count > 0
+}
+
+func (r RedisSessionStore) Get(key string, target interface{}) error {
+	value, err := r.client.Get(context.Background(), r.getFullKey(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read session value: %w", err)
+	}
+	return json.Unmarshal([]byte(value), target)
+}
+
+func (r RedisSessionStore) GetAndDelete(key string, target interface{}) error {
+	value, err := r.client.GetDel(context.Background(), r.getFullKey(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read session value: %w", err)
+	}
+	return json.Unmarshal([]byte(value), target)
+}
+
+func (r RedisSessionStore) Put(key string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err = r.client.Set(context.Background(), r.getFullKey(key), bytes, r.ttl).Err(); err != nil {
+		return fmt.Errorf("unable to store session value: %w", err)
+	}
+	return nil
+}
+
+func (r RedisSessionStore) PutIfAbsent(key string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	stored, err := r.client.SetNX(context.Background(), r.getFullKey(key), bytes, r.ttl).Result()
+	if err != nil {
+		return fmt.Errorf("unable to store session value: %w", err)
+	}
+	if !stored {
+		return ErrExists
+	}
+	return nil
+}
+
+func (r RedisSessionStore) getFullKey(key string) string {
+	return strings.Join(r.prefixes, "/") + "/" + key
+}
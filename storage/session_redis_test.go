@@ -0,0 +1,158 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package storage
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRedisSessionStore(t *testing.T) {
+	redis := miniredis.RunT(t)
+	db, err := NewRedisSessionDatabase(RedisConfig{Address: redis.Addr(), Database: "db"})
+	require.NoError(t, err)
+	t.Cleanup(db.close)
+	store := db.GetStore(time.Minute, "prefix")
+
+	t.Run("Put", func(t *testing.T) {
+		t.Run("value is stored with TTL", func(t *testing.T) {
+			err := store.Put("put", testStruct{Field1: "value"})
+
+			require.NoError(t, err)
+			actual, err := redis.Get("db_session/prefix/put")
+			require.NoError(t, err)
+			assert.Equal(t, `{"field1":"value"}`, actual)
+			assert.Equal(t, time.Minute, redis.TTL("db_session/prefix/put"))
+		})
+		t.Run("value is not JSON", func(t *testing.T) {
+			err := store.Put("put", make(chan int))
+
+			assert.Error(t, err)
+		})
+	})
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			_ = store.Put("get", testStruct{Field1: "value"})
+			var actual testStruct
+
+			err := store.Get("get", &actual)
+
+			require.NoError(t, err)
+			assert.Equal(t, "value", actual.Field1)
+		})
+		t.Run("value is not found", func(t *testing.T) {
+			var actual string
+
+			err := store.Get("unknown", &actual)
+
+			assert.Equal(t, ErrNotFound, err)
+		})
+		t.Run("value is expired", func(t *testing.T) {
+			_ = store.Put("expired", "value")
+			redis.FastForward(time.Hour)
+			var actual string
+
+			err := store.Get("expired", &actual)
+
+			assert.Equal(t, ErrNotFound, err)
+		})
+	})
+	t.Run("PutIfAbsent", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			err := store.PutIfAbsent("putifabsent", "first")
+
+			require.NoError(t, err)
+			var actual string
+			require.NoError(t, store.Get("putifabsent", &actual))
+			assert.Equal(t, "first", actual)
+		})
+		t.Run("value exists", func(t *testing.T) {
+			err := store.PutIfAbsent("putifabsent", "second")
+
+			assert.Equal(t, ErrExists, err)
+			var actual string
+			require.NoError(t, store.Get("putifabsent", &actual))
+			assert.Equal(t, "first", actual)
+		})
+		t.Run("value is not JSON", func(t *testing.T) {
+			err := store.PutIfAbsent("putifabsent-invalid", make(chan int))
+
+			assert.Error(t, err)
+		})
+	})
+	t.Run("GetAndDelete", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			_ = store.Put("getdel", testStruct{Field1: "value"})
+			var actual testStruct
+
+			err := store.GetAndDelete("getdel", &actual)
+
+			require.NoError(t, err)
+			assert.Equal(t, "value", actual.Field1)
+			assert.False(t, store.Exists("getdel"))
+			assert.Equal(t, ErrNotFound, store.GetAndDelete("getdel", &actual))
+		})
+		t.Run("value is not found", func(t *testing.T) {
+			var actual string
+
+			err := store.GetAndDelete("unknown", &actual)
+
+			assert.Equal(t, ErrNotFound, err)
+		})
+	})
+	t.Run("Exists", func(t *testing.T) {
+		_ = store.Put("exists", "value")
+
+		assert.True(t, store.Exists("exists"))
+		assert.False(t, store.Exists("unknown"))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		_ = store.Put("delete", "value")
+
+		err := store.Delete("delete")
+
+		require.NoError(t, err)
+		assert.False(t, store.Exists("delete"))
+	})
+	t.Run("stores are shared between database instances", func(t *testing.T) {
+		other, err := NewRedisSessionDatabase(RedisConfig{Address: redis.Addr(), Database: "db"})
+		require.NoError(t, err)
+		defer other.close()
+		_ = store.Put("shared", "value")
+		var actual string
+
+		err = other.GetStore(time.Minute, "prefix").Get("shared", &actual)
+
+		require.NoError(t, err)
+		assert.Equal(t, "value", actual)
+	})
+	t.Run("connection error", func(t *testing.T) {
+		redis.SetError("failure")
+		defer redis.SetError("")
+		var actual string
+
+		assert.Error(t, store.Put("error", "value"))
+		assert.ErrorContains(t, store.Get("error", &actual), "unable to read session value")
+		assert.Error(t, store.Delete("error"))
+		assert.False(t, store.Exists("error"))
+	})
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/nuts-foundation/nuts-node/storage/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ SessionDatabase = (*SQLSessionDatabase)(nil)
+var _ SessionStore = (*SQLSessionStore)(nil)
+
+type sqlSessionEntry struct {
+	StoreKey   string `gorm:"primaryKey"`
+	StoreValue string
+	Expires    int64
+}
+
+func (s sqlSessionEntry) TableName() string {
+	return "session_store"
+}
+
+// SQLSessionDatabase is a SessionDatabase that stores session data in the SQL database.
+// It can be shared by multiple nodes, so sessions survive requests being routed to different nodes.
+// Expired entries are ignored when read and periodically pruned.
+type SQLSessionDatabase struct {
+	db       *gorm.DB
+	done     chan struct{}
+	routines sync.WaitGroup
+}
+
+// NewSQLSessionDatabase creates a new SQLSessionDatabase using the given SQL database.
+func NewSQLSessionDatabase(db *gorm.DB) *SQLSessionDatabase {
+	result := &SQLSessionDatabase{
+		db:   db,
+		done: make(chan struct{}, 10),
+	}
+	result.startPruning(sessionStorePruneInterval)
+	return result
+}
+
+func (s *SQLSessionDatabase) GetStore(ttl time.Duration, keys ...string) SessionStore {
+	return SQLSessionStore{
+		ttl:      ttl,
+		prefixes: keys,
+		db:       s.db,
+	}
+}
+
+func (s *SQLSessionDatabase) close() {
+	// Signal pruner to stop and wait for it to finish
+	s.done <- struct{}{}
+	s.routines.Wait()
+}
+
+func (s *SQLSessionDatabase) startPruning(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	s.routines.Add(1)
+	go func() {
+		defer s.routines.Done()
+		for {
+			select {
+			case <-s.done:
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				valsPruned, err := s.prune()
+				if err != nil {
+					log.Logger().WithError(err).Error("Failed to prune expired session variables")
+				} else if valsPruned > 0 {
+					log.Logger().Debugf("Pruned %d expired session variables", valsPruned)
+				}
+			}
+		}
+	}()
+}
+
+func (s *SQLSessionDatabase) prune() (int64, error) {
+	result := s.db.Where("expires <= ?", time.Now().Unix()).Delete(&sqlSessionEntry{})
+	return result.RowsAffected, result.Error
+}
+
+// SQLSessionStore is a SessionStore backed by the SQL database.
+type SQLSessionStore struct {
+	ttl      time.Duration
+	prefixes []string
+	db       *gorm.DB
+}
+
+func (s SQLSessionStore) Delete(key string) error {
+	return s.db.Where("store_key = ?", s.getFullKey(key)).Delete(&sqlSessionEntry{}).Error
+}
+
+func (s SQLSessionStore) Exists(key string) bool {
+	var count int64
+	err := s.db.Model(&sqlSessionEntry{}).
+		Where("store_key = ? AND expires > ?", s.getFullKey(key), time.Now().Unix()).
+		Count(&count).Error
+	if err != nil {
+		log.Logger().WithError(err).Error("Failed to check existence of session value")
+		return false
+	}
+	return count > 0
+}
+
+func (s SQLSessionStore) Get(key string, target interface{}) error {
+	var entry sqlSessionEntry
+	err := s.db.Where("store_key = ? AND expires > ?", s.getFullKey(key), time.Now().Unix()).
+		First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(entry.StoreValue), target)
+}
+
+func (s SQLSessionStore) GetAndDelete(key string, target interface{}) error {
+	fullKey := s.getFullKey(key)
+	var entry sqlSessionEntry
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("store_key = ? AND expires > ?", fullKey, time.Now().Unix()).First(&entry).Error; err != nil {
+			return err
+		}
+		// Only the caller that actually deletes the entry gets the value, other concurrent callers get ErrNotFound.
+		result := tx.Where("store_key = ?", fullKey).Delete(&sqlSessionEntry{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(entry.StoreValue), target)
+}
+
+func (s SQLSessionStore) Put(key string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	entry := sqlSessionEntry{
+		StoreKey:   s.getFullKey(key),
+		StoreValue: string(bytes),
+		Expires:    time.Now().Add(s.ttl).Unix(),
+	}
+	return s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entry).Error
+}
+
+func (s SQLSessionStore) PutIfAbsent(key string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fullKey := s.getFullKey(key)
+	// An expired entry that hasn't been pruned yet must not prevent the value from being stored
+	if err = s.db.Where("store_key = ? AND expires <= ?", fullKey, time.Now().Unix()).Delete(&sqlSessionEntry{}).Error; err != nil {
+		return err
+	}
+	entry := sqlSessionEntry{
+		StoreKey:   fullKey,
+		StoreValue: string(bytes),
+		Expires:    time.Now().Add(s.ttl).Unix(),
+	}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrExists
+	}
+	return nil
+}
+
+func (s SQLSessionStore) getFullKey(key string) string {
+	return strings.Join(append(s.prefixes, key), "/")
+}
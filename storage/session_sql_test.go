@@ -0,0 +1,192 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package storage
+
+import (
+	"github.com/nuts-foundation/nuts-node/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLSessionStore(t *testing.T) {
+	db := NewSQLSessionDatabase(NewTestStorageEngine(t).GetSQLDatabase())
+	t.Cleanup(db.close)
+	store := db.GetStore(time.Minute, "prefix")
+
+	t.Run("Put", func(t *testing.T) {
+		t.Run("value is stored", func(t *testing.T) {
+			err := store.Put("put", testStruct{Field1: "value"})
+
+			require.NoError(t, err)
+			var entry sqlSessionEntry
+			require.NoError(t, db.db.Find(&entry, "store_key = ?", "prefix/put").Error)
+			assert.Equal(t, `{"field1":"value"}`, entry.StoreValue)
+			assert.Greater(t, entry.Expires, time.Now().Unix())
+		})
+		t.Run("existing value is overwritten", func(t *testing.T) {
+			require.NoError(t, store.Put("overwrite", "first"))
+			require.NoError(t, store.Put("overwrite", "second"))
+			var actual string
+
+			require.NoError(t, store.Get("overwrite", &actual))
+
+			assert.Equal(t, "second", actual)
+		})
+		t.Run("value is not JSON", func(t *testing.T) {
+			err := store.Put("put", make(chan int))
+
+			assert.Error(t, err)
+		})
+	})
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			_ = store.Put("get", testStruct{Field1: "value"})
+			var actual testStruct
+
+			err := store.Get("get", &actual)
+
+			require.NoError(t, err)
+			assert.Equal(t, "value", actual.Field1)
+		})
+		t.Run("value is not found", func(t *testing.T) {
+			var actual string
+
+			err := store.Get("unknown", &actual)
+
+			assert.Equal(t, ErrNotFound, err)
+		})
+		t.Run("value is expired", func(t *testing.T) {
+			_ = db.GetStore(-time.Minute, "prefix").Put("expired", "value")
+			var actual string
+
+			err := store.Get("expired", &actual)
+
+			assert.Equal(t, ErrNotFound, err)
+			assert.False(t, store.Exists("expired"))
+		})
+	})
+	t.Run("PutIfAbsent", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			err := store.PutIfAbsent("putifabsent", "first")
+
+			require.NoError(t, err)
+			var actual string
+			require.NoError(t, store.Get("putifabsent", &actual))
+			assert.Equal(t, "first", actual)
+		})
+		t.Run("value exists", func(t *testing.T) {
+			err := store.PutIfAbsent("putifabsent", "second")
+
+			assert.Equal(t, ErrExists, err)
+			var actual string
+			require.NoError(t, store.Get("putifabsent", &actual))
+			assert.Equal(t, "first", actual)
+		})
+		t.Run("value is not JSON", func(t *testing.T) {
+			err := store.PutIfAbsent("putifabsent-invalid", make(chan int))
+
+			assert.Error(t, err)
+		})
+		t.Run("expired value is replaced", func(t *testing.T) {
+			_ = db.GetStore(-time.Minute, "prefix").Put("putifabsent-expired", "first")
+
+			err := store.PutIfAbsent("putifabsent-expired", "second")
+
+			require.NoError(t, err)
+			var actual string
+			require.NoError(t, store.Get("putifabsent-expired", &actual))
+			assert.Equal(t, "second", actual)
+		})
+	})
+	t.Run("GetAndDelete", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			_ = store.Put("getdel", testStruct{Field1: "value"})
+			var actual testStruct
+
+			err := store.GetAndDelete("getdel", &actual)
+
+			require.NoError(t, err)
+			assert.Equal(t, "value", actual.Field1)
+			assert.False(t, store.Exists("getdel"))
+			assert.Equal(t, ErrNotFound, store.GetAndDelete("getdel", &actual))
+		})
+		t.Run("value is not found", func(t *testing.T) {
+			var actual string
+
+			err := store.GetAndDelete("unknown", &actual)
+
+			assert.Equal(t, ErrNotFound, err)
+		})
+		t.Run("value is expired", func(t *testing.T) {
+			_ = db.GetStore(-time.Minute, "prefix").Put("getdel-expired", "value")
+			var actual string
+
+			err := store.GetAndDelete("getdel-expired", &actual)
+
+			assert.Equal(t, ErrNotFound, err)
+		})
+	})
+	t.Run("Exists", func(t *testing.T) {
+		_ = store.Put("exists", "value")
+
+		assert.True(t, store.Exists("exists"))
+		assert.False(t, store.Exists("unknown"))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		_ = store.Put("delete", "value")
+
+		err := store.Delete("delete")
+
+		require.NoError(t, err)
+		assert.False(t, store.Exists("delete"))
+	})
+}
+
+func TestSQLSessionDatabase_prune(t *testing.T) {
+	sqlDB := NewTestStorageEngine(t).GetSQLDatabase()
+	t.Run("prunes expired entries", func(t *testing.T) {
+		db := NewSQLSessionDatabase(sqlDB)
+		defer db.close()
+		_ = db.GetStore(-time.Minute).Put("key1", "value")
+		_ = db.GetStore(time.Minute).Put("key2", "value")
+
+		count, err := db.prune()
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		// Second round to assert there's nothing to prune now
+		count, err = db.prune()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("automatic", func(t *testing.T) {
+		db := &SQLSessionDatabase{db: sqlDB, done: make(chan struct{}, 10)}
+		db.startPruning(10 * time.Millisecond)
+		defer db.close()
+		_ = db.GetStore(-time.Minute).Put("key3", "value")
+
+		test.WaitFor(t, func() (bool, error) {
+			var count int64
+			err := sqlDB.Model(&sqlSessionEntry{}).Where("store_key = ?", "key3").Count(&count).Error
+			return count == 0, err
+		}, time.Second, "time-out waiting for entry to be pruned")
+	})
+}
@@ -179,6 +179,75 @@ func TestInMemorySessionStore_Delete(t *testing.T) {
 	})
 }
 
+func TestInMemorySessionStore_PutIfAbsent(t *testing.T) {
+	db := createDatabase(t)
+	store := db.GetStore(time.Minute, "prefix").(InMemorySessionStore)
+
+	t.Run("value is stored", func(t *testing.T) {
+		err := store.PutIfAbsent(t.Name(), "value")
+
+		require.NoError(t, err)
+		assert.True(t, store.Exists(t.Name()))
+	})
+	t.Run("value exists", func(t *testing.T) {
+		_ = store.Put(t.Name(), "first")
+
+		err := store.PutIfAbsent(t.Name(), "second")
+
+		assert.Equal(t, ErrExists, err)
+		var actual string
+		require.NoError(t, store.Get(t.Name(), &actual))
+		assert.Equal(t, "first", actual)
+	})
+	t.Run("expired value is replaced", func(t *testing.T) {
+		_ = db.GetStore(-time.Minute, "prefix").Put(t.Name(), "first")
+
+		err := store.PutIfAbsent(t.Name(), "second")
+
+		require.NoError(t, err)
+		var actual string
+		require.NoError(t, store.Get(t.Name(), &actual))
+		assert.Equal(t, "second", actual)
+	})
+	t.Run("value is not JSON", func(t *testing.T) {
+		err := store.PutIfAbsent(t.Name(), make(chan int))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestInMemorySessionStore_GetAndDelete(t *testing.T) {
+	db := createDatabase(t)
+	store := db.GetStore(time.Minute, "prefix").(InMemorySessionStore)
+
+	t.Run("value is retrieved and deleted", func(t *testing.T) {
+		_ = store.Put(t.Name(), "value")
+		var actual string
+
+		err := store.GetAndDelete(t.Name(), &actual)
+
+		require.NoError(t, err)
+		assert.Equal(t, "value", actual)
+		assert.False(t, store.Exists(t.Name()))
+	})
+	t.Run("value is not found", func(t *testing.T) {
+		var actual string
+
+		err := store.GetAndDelete(t.Name(), &actual)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("value is expired", func(t *testing.T) {
+		_ = db.GetStore(-time.Minute, "prefix").Put(t.Name(), "value")
+		var actual string
+
+		err := store.GetAndDelete(t.Name(), &actual)
+
+		assert.Equal(t, ErrNotFound, err)
+		assert.False(t, store.Exists(t.Name()))
+	})
+}
+
 func TestInMemorySessionDatabase_Close(t *testing.T) {
 	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
 
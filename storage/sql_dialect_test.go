@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mysql"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// sqlTestDialect describes a SQL database the storage engine supports, for running tests against.
+type sqlTestDialect struct {
+	name string
+	// connectionString returns the connection string to a database of the dialect.
+	// The test is skipped if no database is available.
+	connectionString func(t *testing.T) string
+}
+
+// sqlTestDialects contains all supported SQL dialects.
+// PostgreSQL and MySQL are started as container, which requires Docker.
+// Alternatively, an existing database can be used by setting NUTS_TEST_SQL_POSTGRES or NUTS_TEST_SQL_MYSQL to its connection string.
+var sqlTestDialects = []sqlTestDialect{
+	{
+		name: "sqlite",
+		connectionString: func(t *testing.T) string {
+			return sqliteConnectionString(io.TestDirectory(t))
+		},
+	},
+	{
+		name: "postgres",
+		connectionString: func(t *testing.T) string {
+			if connectionString := os.Getenv("NUTS_TEST_SQL_POSTGRES"); connectionString != "" {
+				return connectionString
+			}
+			skipIfNoContainers(t)
+			ctx := context.Background()
+			container, err := postgres.RunContainer(ctx,
+				testcontainers.WithImage("postgres:16-alpine"),
+				postgres.WithDatabase("nuts"),
+				postgres.WithUsername("nuts"),
+				postgres.WithPassword("nuts"),
+				testcontainers.WithWaitStrategy(wait.ForLog("database system is ready to accept connections").
+					WithOccurrence(2).
+					WithStartupTimeout(time.Minute)),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = container.Terminate(ctx)
+			})
+			connectionString, err := container.ConnectionString(ctx, "sslmode=disable")
+			require.NoError(t, err)
+			return connectionString
+		},
+	},
+	{
+		name: "mysql",
+		connectionString: func(t *testing.T) string {
+			if connectionString := os.Getenv("NUTS_TEST_SQL_MYSQL"); connectionString != "" {
+				return connectionString
+			}
+			skipIfNoContainers(t)
+			ctx := context.Background()
+			container, err := mysql.RunContainer(ctx,
+				testcontainers.WithImage("mysql:8"),
+				mysql.WithDatabase("nuts"),
+				mysql.WithUsername("root"),
+				mysql.WithPassword("nuts"),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = container.Terminate(ctx)
+			})
+			host, err := container.Host(ctx)
+			require.NoError(t, err)
+			port, err := container.MappedPort(ctx, "3306/tcp")
+			require.NoError(t, err)
+			return fmt.Sprintf("mysql://root:nuts@%s:%s/nuts", host, port.Port())
+		},
+	},
+}
+
+func skipIfNoContainers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping database container in short mode")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+}
+
+func Test_engine_sqlDialects(t *testing.T) {
+	// count .sql files in sql_migration directory
+	files, err := os.ReadDir("sql_migrations")
+	require.NoError(t, err)
+	var migrationCount int
+	for _, curr := range files {
+		if strings.HasSuffix(curr.Name(), ".sql") {
+			migrationCount++
+		}
+	}
+
+	for _, dialect := range sqlTestDialects {
+		dialect := dialect
+		t.Run(dialect.name, func(t *testing.T) {
+			connectionString := dialect.connectionString(t)
+			configure := func(t *testing.T) *engine {
+				e := New().(*engine)
+				e.config.SQL.ConnectionString = connectionString
+				require.NoError(t, e.Configure(core.ServerConfig{Datadir: io.TestDirectory(t)}))
+				t.Cleanup(func() {
+					_ = e.Shutdown()
+				})
+				return e
+			}
+			e := configure(t)
+
+			t.Run("runs migrations", func(t *testing.T) {
+				var count int64
+				require.NoError(t, e.GetSQLDatabase().Table("schema_migrations").Count(&count).Error)
+				assert.Equal(t, int64(migrationCount), count)
+			})
+			t.Run("nothing to migrate (already migrated)", func(t *testing.T) {
+				configure(t)
+			})
+			t.Run("foreign keys cascade on delete", func(t *testing.T) {
+				db := e.GetSQLDatabase()
+				subject := did.MustParseDID("did:web:example.com:iam:" + dialect.name)
+				AddDIDtoSQLDB(t, db, subject)
+				require.NoError(t, db.Exec("INSERT INTO vdr_didweb_verificationmethod (id, did, data) VALUES (?, ?, ?)", subject.String()+"#0", subject.String(), "{}").Error)
+
+				require.NoError(t, db.Exec("DELETE FROM vdr_didweb WHERE did = ?", subject.String()).Error)
+
+				var count int64
+				require.NoError(t, db.Table("vdr_didweb_verificationmethod").Where("did = ?", subject.String()).Count(&count).Error)
+				assert.Equal(t, int64(0), count)
+			})
+			t.Run("session database", func(t *testing.T) {
+				sessionDB := NewSQLSessionDatabase(e.GetSQLDatabase())
+				defer sessionDB.close()
+				store := sessionDB.GetStore(time.Minute, dialect.name)
+				require.NoError(t, store.Put("key", "first"))
+				require.NoError(t, store.Put("key", "second"))
+
+				var actual string
+				require.NoError(t, store.Get("key", &actual))
+				assert.Equal(t, "second", actual)
+				require.NoError(t, store.Delete("key"))
+				assert.False(t, store.Exists("key"))
+			})
+			t.Run("rollback all migrations", func(t *testing.T) {
+				connectionURL, err := url.Parse(connectionString)
+				require.NoError(t, err)
+				migrator := newSQLMigrator(connectionURL)
+
+				for i := 0; i < migrationCount; i++ {
+					require.NoError(t, migrator.Rollback())
+				}
+
+				assert.False(t, e.GetSQLDatabase().Migrator().HasTable("discovery_service"))
+				assert.False(t, e.GetSQLDatabase().Migrator().HasTable("vdr_didweb"))
+				assert.False(t, e.GetSQLDatabase().Migrator().HasTable("session_store"))
+			})
+		})
+	}
+}
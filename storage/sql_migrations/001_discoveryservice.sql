@@ -60,8 +60,8 @@ create table discovery_credential_prop
 );
 
 -- migrate:down
-drop table discovery_service;
-drop table discovery_presentation;
-drop table discovery_credential;
 drop table discovery_credential_prop;
+drop table discovery_credential;
+drop table discovery_presentation;
+drop table discovery_service;
 
@@ -22,5 +22,5 @@ create table vdr_didweb_verificationmethod
 );
 
 -- migrate:down
-drop table vdr_didweb;
-drop table vdr_didweb_verificationmethod;
\ No newline at end of file
+drop table vdr_didweb_verificationmethod;
+drop table vdr_didweb;
\ No newline at end of file
@@ -37,5 +37,5 @@ create table status_list_status
 );
 
 -- migrate:down
-drop table status_list_credential;
 drop table status_list_status;
+drop table status_list_credential;
@@ -0,0 +1,7 @@
+-- migrate:up
+-- retire_at: moment (seconds since UNIX epoch) at which a rotated verification method is removed from the DID document.
+--      NULL means the verification method is active.
+alter table vdr_didweb_verificationmethod add column retire_at integer;
+
+-- migrate:down
+alter table vdr_didweb_verificationmethod drop column retire_at;
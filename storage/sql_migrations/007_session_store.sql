@@ -0,0 +1,16 @@
+-- migrate:up
+-- session_store contains session data (e.g. OAuth2 flows, nonces, access tokens) shared by all nodes using the same database.
+create table session_store
+(
+    -- store_key is the full key of the entry, consisting of the store prefixes and the key itself.
+    store_key   varchar(500) not null primary key,
+    -- store_value is the JSON encoded value.
+    store_value text         not null,
+    -- expires is the unix timestamp (seconds) after which the entry is no longer valid.
+    expires     integer      not null
+);
+-- index for the expires column, used by prune()
+create index idx_session_store_expires on session_store (expires);
+
+-- migrate:down
+drop table session_store;
@@ -0,0 +1,27 @@
+-- migrate:up
+-- audit_event contains the persistent, hash-chained audit trail.
+create table audit_event
+(
+    -- sequence is the position of the event in the audit trail, starting at 1.
+    sequence      bigint       not null primary key,
+    -- timestamp is the unix timestamp (milliseconds) of the event.
+    timestamp     bigint       not null,
+    actor         varchar(255) not null,
+    operation     varchar(255) not null,
+    event         varchar(255) not null,
+    module        varchar(255) not null,
+    message       text         not null,
+    -- fields contains the other fields of the event, JSON encoded.
+    fields        text         not null,
+    -- previous_hash is the hash of the previous event, empty for the first event.
+    previous_hash varchar(64)  not null,
+    -- hash is the hex-encoded SHA-256 hash over the event and previous_hash.
+    hash          varchar(64)  not null
+);
+-- indexes for querying events
+create index idx_audit_event_actor on audit_event (actor);
+create index idx_audit_event_operation on audit_event (operation);
+create index idx_audit_event_timestamp on audit_event (timestamp);
+
+-- migrate:down
+drop table audit_event;
@@ -0,0 +1,7 @@
+-- migrate:up
+-- list_type: the type of status list the status list credential publishes, either 'StatusList2021' or 'BitstringStatusList'.
+--      Existing status list credentials are StatusList2021 lists.
+alter table status_list_credential add column list_type varchar(50) not null default 'StatusList2021';
+
+-- migrate:down
+alter table status_list_credential drop column list_type;
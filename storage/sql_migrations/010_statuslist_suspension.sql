@@ -0,0 +1,7 @@
+-- migrate:up
+-- status_purpose: the purpose of the status list credential, either 'revocation' or 'suspension'.
+--      Existing status list credentials are revocation lists.
+alter table status_list_credential add column status_purpose varchar(20) not null default 'revocation';
+
+-- migrate:down
+alter table status_list_credential drop column status_purpose;
@@ -0,0 +1,10 @@
+-- migrate:up
+-- definition: the service definition (JSON) of services that are managed at runtime (through the API).
+--      It is null for services of which the definition is loaded from the definitions directory.
+alter table discovery_service add column definition text null;
+-- is_server: whether the node acts as server for a managed service.
+alter table discovery_service add column is_server boolean not null default false;
+
+-- migrate:down
+alter table discovery_service drop column definition;
+alter table discovery_service drop column is_server;
@@ -0,0 +1,20 @@
+-- migrate:up
+-- discovery_block contains the DIDs and presentations that are blocked from registering on a Discovery Service the node is server for.
+create table discovery_block
+(
+    -- service_id is the ID of the Discovery Service the subject is blocked on.
+    service_id varchar(200) not null,
+    -- subject is the blocked DID or presentation ID (the 'id' of the Verifiable Presentation).
+    subject    varchar(500) not null,
+    -- reason is the reason given by the actor for blocking the subject.
+    reason     text         not null,
+    -- actor is the user or service that blocked the subject.
+    actor      varchar(255) not null,
+    -- created_at is the timestamp (seconds since Unix epoch) when the subject was blocked.
+    created_at integer      not null,
+    primary key (service_id, subject),
+    constraint fk_discovery_block_service foreign key (service_id) references discovery_service (id) on delete cascade
+);
+
+-- migrate:down
+drop table discovery_block;
@@ -51,6 +51,15 @@ type CredentialRequest = openid4vci.CredentialRequest
 // CredentialResponse is the response of the OpenID4VCI credential request endpoint
 type CredentialResponse = openid4vci.CredentialResponse
 
+// BatchCredentialRequest is the request to the OpenID4VCI batch credential request endpoint
+type BatchCredentialRequest = openid4vci.BatchCredentialRequest
+
+// BatchCredentialResponse is the response of the OpenID4VCI batch credential request endpoint
+type BatchCredentialResponse = openid4vci.BatchCredentialResponse
+
+// DeferredCredentialRequest is the request to the OpenID4VCI deferred credential endpoint
+type DeferredCredentialRequest = openid4vci.DeferredCredentialRequest
+
 // OAuth2ClientMetadata is the metadata of the OAuth2 client
 type OAuth2ClientMetadata = openid4vci.OAuth2ClientMetadata
 
@@ -14,6 +14,26 @@ import (
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 )
 
+// HandleAuthorizeRequestParams defines parameters for HandleAuthorizeRequest.
+type HandleAuthorizeRequestParams struct {
+	// ClientId Identifier of the wallet, which must be the DID that signed the request object.
+	ClientId string `form:"client_id" json:"client_id"`
+
+	// Request The request object, a JWT containing the authorization request parameters.
+	Request string `form:"request" json:"request"`
+}
+
+// RequestBatchCredentialParams defines parameters for RequestBatchCredential.
+type RequestBatchCredentialParams struct {
+	Authorization *string `json:"Authorization,omitempty"`
+}
+
+// HandleAuthorizationResponseParams defines parameters for HandleAuthorizationResponse.
+type HandleAuthorizationResponseParams struct {
+	Code  string `form:"code" json:"code"`
+	State string `form:"state" json:"state"`
+}
+
 // RequestCredentialParams defines parameters for RequestCredential.
 type RequestCredentialParams struct {
 	Authorization *string `json:"Authorization,omitempty"`
@@ -25,15 +45,39 @@ type HandleCredentialOfferParams struct {
 	CredentialOffer string `form:"credential_offer" json:"credential_offer"`
 }
 
+// RequestDeferredCredentialParams defines parameters for RequestDeferredCredential.
+type RequestDeferredCredentialParams struct {
+	Authorization *string `json:"Authorization,omitempty"`
+}
+
 // RequestAccessTokenFormdataBody defines parameters for RequestAccessToken.
 type RequestAccessTokenFormdataBody struct {
-	GrantType         string `form:"grant_type" json:"grant_type"`
-	PreAuthorizedCode string `form:"pre-authorized_code" json:"pre-authorized_code"`
+	// ClientId Required for the authorization_code grant, must be equal to the client_id of the authorization request.
+	ClientId *string `form:"client_id,omitempty" json:"client_id,omitempty"`
+
+	// Code Required for the authorization_code grant.
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// CodeVerifier PKCE code verifier, required for the authorization_code grant.
+	CodeVerifier *string `form:"code_verifier,omitempty" json:"code_verifier,omitempty"`
+	GrantType    string  `form:"grant_type" json:"grant_type"`
+
+	// PreAuthorizedCode Required for the pre-authorized code grant.
+	PreAuthorizedCode *string `form:"pre-authorized_code,omitempty" json:"pre-authorized_code,omitempty"`
+
+	// RedirectUri Required for the authorization_code grant, must be equal to the redirect_uri of the authorization request.
+	RedirectUri *string `form:"redirect_uri,omitempty" json:"redirect_uri,omitempty"`
 }
 
+// RequestBatchCredentialJSONRequestBody defines body for RequestBatchCredential for application/json ContentType.
+type RequestBatchCredentialJSONRequestBody = BatchCredentialRequest
+
 // RequestCredentialJSONRequestBody defines body for RequestCredential for application/json ContentType.
 type RequestCredentialJSONRequestBody = CredentialRequest
 
+// RequestDeferredCredentialJSONRequestBody defines body for RequestDeferredCredential for application/json ContentType.
+type RequestDeferredCredentialJSONRequestBody = DeferredCredentialRequest
+
 // RequestAccessTokenFormdataRequestBody defines body for RequestAccessToken for application/x-www-form-urlencoded ContentType.
 type RequestAccessTokenFormdataRequestBody RequestAccessTokenFormdataBody
 
@@ -51,12 +95,24 @@ type ServerInterface interface {
 	// Get the OAuth2 Client Metadata
 	// (GET /n2n/identity/{did}/.well-known/openid-credential-wallet)
 	GetOAuth2ClientMetadata(ctx echo.Context, did string) error
+	// Used by the wallet to start the authorization code flow
+	// (GET /n2n/identity/{did}/authorize)
+	HandleAuthorizeRequest(ctx echo.Context, did string, params HandleAuthorizeRequestParams) error
+	// Used by the wallet to request multiple credentials at once
+	// (POST /n2n/identity/{did}/openid4vci/batch_credential)
+	RequestBatchCredential(ctx echo.Context, did string, params RequestBatchCredentialParams) error
+	// Used by the issuer to redirect to the wallet after authorization
+	// (GET /n2n/identity/{did}/openid4vci/callback)
+	HandleAuthorizationResponse(ctx echo.Context, did string, params HandleAuthorizationResponseParams) error
 	// Used by the wallet to request credentials
 	// (POST /n2n/identity/{did}/openid4vci/credential)
 	RequestCredential(ctx echo.Context, did string, params RequestCredentialParams) error
 	// Used by the issuer to offer credentials to the wallet
 	// (GET /n2n/identity/{did}/openid4vci/credential_offer)
 	HandleCredentialOffer(ctx echo.Context, did string, params HandleCredentialOfferParams) error
+	// Used by the wallet to retrieve a credential of which issuance was deferred
+	// (POST /n2n/identity/{did}/openid4vci/deferred_credential)
+	RequestDeferredCredential(ctx echo.Context, did string, params RequestDeferredCredentialParams) error
 	// Used by the wallet to request an access token
 	// (POST /n2n/identity/{did}/token)
 	RequestAccessToken(ctx echo.Context, did string) error
@@ -131,6 +187,106 @@ func (w *ServerInterfaceWrapper) GetOAuth2ClientMetadata(ctx echo.Context) error
 	return err
 }
 
+// HandleAuthorizeRequest converts echo context to params.
+func (w *ServerInterfaceWrapper) HandleAuthorizeRequest(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HandleAuthorizeRequestParams
+	// ------------- Required query parameter "client_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "client_id", ctx.QueryParams(), &params.ClientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	// ------------- Required query parameter "request" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "request", ctx.QueryParams(), &params.Request)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter request: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.HandleAuthorizeRequest(ctx, did, params)
+	return err
+}
+
+// RequestBatchCredential converts echo context to params.
+func (w *ServerInterfaceWrapper) RequestBatchCredential(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RequestBatchCredentialParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Authorization" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Authorization")]; found {
+		var Authorization string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Authorization, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Authorization", runtime.ParamLocationHeader, valueList[0], &Authorization)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Authorization: %s", err))
+		}
+
+		params.Authorization = &Authorization
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RequestBatchCredential(ctx, did, params)
+	return err
+}
+
+// HandleAuthorizationResponse converts echo context to params.
+func (w *ServerInterfaceWrapper) HandleAuthorizationResponse(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HandleAuthorizationResponseParams
+	// ------------- Required query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "code", ctx.QueryParams(), &params.Code)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter code: %s", err))
+	}
+
+	// ------------- Required query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "state", ctx.QueryParams(), &params.State)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter state: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.HandleAuthorizationResponse(ctx, did, params)
+	return err
+}
+
 // RequestCredential converts echo context to params.
 func (w *ServerInterfaceWrapper) RequestCredential(ctx echo.Context) error {
 	var err error
@@ -192,6 +348,42 @@ func (w *ServerInterfaceWrapper) HandleCredentialOffer(ctx echo.Context) error {
 	return err
 }
 
+// RequestDeferredCredential converts echo context to params.
+func (w *ServerInterfaceWrapper) RequestDeferredCredential(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RequestDeferredCredentialParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Authorization" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Authorization")]; found {
+		var Authorization string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Authorization, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Authorization", runtime.ParamLocationHeader, valueList[0], &Authorization)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Authorization: %s", err))
+		}
+
+		params.Authorization = &Authorization
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RequestDeferredCredential(ctx, did, params)
+	return err
+}
+
 // RequestAccessToken converts echo context to params.
 func (w *ServerInterfaceWrapper) RequestAccessToken(ctx echo.Context) error {
 	var err error
@@ -240,8 +432,12 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/n2n/identity/:did/.well-known/openid-credential-issuer", wrapper.GetOpenID4VCIIssuerMetadata)
 	router.HEAD(baseURL+"/n2n/identity/:did/.well-known/openid-credential-issuer", wrapper.GetOpenID4VCIIssuerMetadataHeaders)
 	router.GET(baseURL+"/n2n/identity/:did/.well-known/openid-credential-wallet", wrapper.GetOAuth2ClientMetadata)
+	router.GET(baseURL+"/n2n/identity/:did/authorize", wrapper.HandleAuthorizeRequest)
+	router.POST(baseURL+"/n2n/identity/:did/openid4vci/batch_credential", wrapper.RequestBatchCredential)
+	router.GET(baseURL+"/n2n/identity/:did/openid4vci/callback", wrapper.HandleAuthorizationResponse)
 	router.POST(baseURL+"/n2n/identity/:did/openid4vci/credential", wrapper.RequestCredential)
 	router.GET(baseURL+"/n2n/identity/:did/openid4vci/credential_offer", wrapper.HandleCredentialOffer)
+	router.POST(baseURL+"/n2n/identity/:did/openid4vci/deferred_credential", wrapper.RequestDeferredCredential)
 	router.POST(baseURL+"/n2n/identity/:did/token", wrapper.RequestAccessToken)
 
 }
@@ -348,6 +544,129 @@ func (response GetOAuth2ClientMetadata404JSONResponse) VisitGetOAuth2ClientMetad
 	return json.NewEncoder(w).Encode(response)
 }
 
+type HandleAuthorizeRequestRequestObject struct {
+	Did    string `json:"did"`
+	Params HandleAuthorizeRequestParams
+}
+
+type HandleAuthorizeRequestResponseObject interface {
+	VisitHandleAuthorizeRequestResponse(w http.ResponseWriter) error
+}
+
+type HandleAuthorizeRequest302ResponseHeaders struct {
+	Location string
+}
+
+type HandleAuthorizeRequest302Response struct {
+	Headers HandleAuthorizeRequest302ResponseHeaders
+}
+
+func (response HandleAuthorizeRequest302Response) VisitHandleAuthorizeRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(302)
+	return nil
+}
+
+type HandleAuthorizeRequest400JSONResponse ErrorResponse
+
+func (response HandleAuthorizeRequest400JSONResponse) VisitHandleAuthorizeRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HandleAuthorizeRequest404JSONResponse ErrorResponse
+
+func (response HandleAuthorizeRequest404JSONResponse) VisitHandleAuthorizeRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestBatchCredentialRequestObject struct {
+	Did    string `json:"did"`
+	Params RequestBatchCredentialParams
+	Body   *RequestBatchCredentialJSONRequestBody
+}
+
+type RequestBatchCredentialResponseObject interface {
+	VisitRequestBatchCredentialResponse(w http.ResponseWriter) error
+}
+
+type RequestBatchCredential200JSONResponse BatchCredentialResponse
+
+func (response RequestBatchCredential200JSONResponse) VisitRequestBatchCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestBatchCredential400JSONResponse ErrorResponse
+
+func (response RequestBatchCredential400JSONResponse) VisitRequestBatchCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestBatchCredential401JSONResponse ErrorResponse
+
+func (response RequestBatchCredential401JSONResponse) VisitRequestBatchCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestBatchCredential404JSONResponse ErrorResponse
+
+func (response RequestBatchCredential404JSONResponse) VisitRequestBatchCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HandleAuthorizationResponseRequestObject struct {
+	Did    string `json:"did"`
+	Params HandleAuthorizationResponseParams
+}
+
+type HandleAuthorizationResponseResponseObject interface {
+	VisitHandleAuthorizationResponseResponse(w http.ResponseWriter) error
+}
+
+type HandleAuthorizationResponse200JSONResponse CredentialOfferResponse
+
+func (response HandleAuthorizationResponse200JSONResponse) VisitHandleAuthorizationResponseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HandleAuthorizationResponse400JSONResponse ErrorResponse
+
+func (response HandleAuthorizationResponse400JSONResponse) VisitHandleAuthorizationResponseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HandleAuthorizationResponse404JSONResponse ErrorResponse
+
+func (response HandleAuthorizationResponse404JSONResponse) VisitHandleAuthorizationResponseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type RequestCredentialRequestObject struct {
 	Did    string `json:"did"`
 	Params RequestCredentialParams
@@ -439,6 +758,52 @@ func (response HandleCredentialOffer404JSONResponse) VisitHandleCredentialOfferR
 	return json.NewEncoder(w).Encode(response)
 }
 
+type RequestDeferredCredentialRequestObject struct {
+	Did    string `json:"did"`
+	Params RequestDeferredCredentialParams
+	Body   *RequestDeferredCredentialJSONRequestBody
+}
+
+type RequestDeferredCredentialResponseObject interface {
+	VisitRequestDeferredCredentialResponse(w http.ResponseWriter) error
+}
+
+type RequestDeferredCredential200JSONResponse CredentialResponse
+
+func (response RequestDeferredCredential200JSONResponse) VisitRequestDeferredCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeferredCredential400JSONResponse ErrorResponse
+
+func (response RequestDeferredCredential400JSONResponse) VisitRequestDeferredCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeferredCredential401JSONResponse ErrorResponse
+
+func (response RequestDeferredCredential401JSONResponse) VisitRequestDeferredCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeferredCredential404JSONResponse ErrorResponse
+
+func (response RequestDeferredCredential404JSONResponse) VisitRequestDeferredCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type RequestAccessTokenRequestObject struct {
 	Did  string `json:"did"`
 	Body *RequestAccessTokenFormdataRequestBody
@@ -489,12 +854,24 @@ type StrictServerInterface interface {
 	// Get the OAuth2 Client Metadata
 	// (GET /n2n/identity/{did}/.well-known/openid-credential-wallet)
 	GetOAuth2ClientMetadata(ctx context.Context, request GetOAuth2ClientMetadataRequestObject) (GetOAuth2ClientMetadataResponseObject, error)
+	// Used by the wallet to start the authorization code flow
+	// (GET /n2n/identity/{did}/authorize)
+	HandleAuthorizeRequest(ctx context.Context, request HandleAuthorizeRequestRequestObject) (HandleAuthorizeRequestResponseObject, error)
+	// Used by the wallet to request multiple credentials at once
+	// (POST /n2n/identity/{did}/openid4vci/batch_credential)
+	RequestBatchCredential(ctx context.Context, request RequestBatchCredentialRequestObject) (RequestBatchCredentialResponseObject, error)
+	// Used by the issuer to redirect to the wallet after authorization
+	// (GET /n2n/identity/{did}/openid4vci/callback)
+	HandleAuthorizationResponse(ctx context.Context, request HandleAuthorizationResponseRequestObject) (HandleAuthorizationResponseResponseObject, error)
 	// Used by the wallet to request credentials
 	// (POST /n2n/identity/{did}/openid4vci/credential)
 	RequestCredential(ctx context.Context, request RequestCredentialRequestObject) (RequestCredentialResponseObject, error)
 	// Used by the issuer to offer credentials to the wallet
 	// (GET /n2n/identity/{did}/openid4vci/credential_offer)
 	HandleCredentialOffer(ctx context.Context, request HandleCredentialOfferRequestObject) (HandleCredentialOfferResponseObject, error)
+	// Used by the wallet to retrieve a credential of which issuance was deferred
+	// (POST /n2n/identity/{did}/openid4vci/deferred_credential)
+	RequestDeferredCredential(ctx context.Context, request RequestDeferredCredentialRequestObject) (RequestDeferredCredentialResponseObject, error)
 	// Used by the wallet to request an access token
 	// (POST /n2n/identity/{did}/token)
 	RequestAccessToken(ctx context.Context, request RequestAccessTokenRequestObject) (RequestAccessTokenResponseObject, error)
@@ -612,6 +989,90 @@ func (sh *strictHandler) GetOAuth2ClientMetadata(ctx echo.Context, did string) e
 	return nil
 }
 
+// HandleAuthorizeRequest operation middleware
+func (sh *strictHandler) HandleAuthorizeRequest(ctx echo.Context, did string, params HandleAuthorizeRequestParams) error {
+	var request HandleAuthorizeRequestRequestObject
+
+	request.Did = did
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.HandleAuthorizeRequest(ctx.Request().Context(), request.(HandleAuthorizeRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "HandleAuthorizeRequest")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(HandleAuthorizeRequestResponseObject); ok {
+		return validResponse.VisitHandleAuthorizeRequestResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RequestBatchCredential operation middleware
+func (sh *strictHandler) RequestBatchCredential(ctx echo.Context, did string, params RequestBatchCredentialParams) error {
+	var request RequestBatchCredentialRequestObject
+
+	request.Did = did
+	request.Params = params
+
+	var body RequestBatchCredentialJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestBatchCredential(ctx.Request().Context(), request.(RequestBatchCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestBatchCredential")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RequestBatchCredentialResponseObject); ok {
+		return validResponse.VisitRequestBatchCredentialResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// HandleAuthorizationResponse operation middleware
+func (sh *strictHandler) HandleAuthorizationResponse(ctx echo.Context, did string, params HandleAuthorizationResponseParams) error {
+	var request HandleAuthorizationResponseRequestObject
+
+	request.Did = did
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.HandleAuthorizationResponse(ctx.Request().Context(), request.(HandleAuthorizationResponseRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "HandleAuthorizationResponse")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(HandleAuthorizationResponseResponseObject); ok {
+		return validResponse.VisitHandleAuthorizationResponseResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // RequestCredential operation middleware
 func (sh *strictHandler) RequestCredential(ctx echo.Context, did string, params RequestCredentialParams) error {
 	var request RequestCredentialRequestObject
@@ -670,6 +1131,38 @@ func (sh *strictHandler) HandleCredentialOffer(ctx echo.Context, did string, par
 	return nil
 }
 
+// RequestDeferredCredential operation middleware
+func (sh *strictHandler) RequestDeferredCredential(ctx echo.Context, did string, params RequestDeferredCredentialParams) error {
+	var request RequestDeferredCredentialRequestObject
+
+	request.Did = did
+	request.Params = params
+
+	var body RequestDeferredCredentialJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestDeferredCredential(ctx.Request().Context(), request.(RequestDeferredCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestDeferredCredential")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RequestDeferredCredentialResponseObject); ok {
+		return validResponse.VisitRequestDeferredCredentialResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // RequestAccessToken operation middleware
 func (sh *strictHandler) RequestAccessToken(ctx echo.Context, did string) error {
 	var request RequestAccessTokenRequestObject
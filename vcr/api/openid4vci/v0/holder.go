@@ -55,9 +55,22 @@ func (w Wrapper) HandleCredentialOffer(ctx context.Context, request HandleCreden
 		// Note: error responses on the Credential Offer Endpoint are not specified in the OpenID4VCI spec.
 		return nil, core.InvalidInputError("unable to unmarshal credential_offer: %w", err)
 	}
-	err = wallet.HandleCredentialOffer(ctx, offer)
+	status, err := wallet.HandleCredentialOffer(ctx, offer)
 	if err != nil {
 		return nil, err
 	}
-	return HandleCredentialOffer200JSONResponse{Status: openid4vci.CredentialOfferStatusReceived}, nil
+	return HandleCredentialOffer200JSONResponse{Status: status}, nil
+}
+
+// HandleAuthorizationResponse handles the authorization response of an authorization code flow for the given DID.
+func (w Wrapper) HandleAuthorizationResponse(ctx context.Context, request HandleAuthorizationResponseRequestObject) (HandleAuthorizationResponseResponseObject, error) {
+	wallet, err := w.getHolderHandler(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	err = wallet.HandleAuthorizationResponse(ctx, request.Params.Code, request.Params.State)
+	if err != nil {
+		return nil, err
+	}
+	return HandleAuthorizationResponse200JSONResponse{Status: openid4vci.CredentialOfferStatusReceived}, nil
 }
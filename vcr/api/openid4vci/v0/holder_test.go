@@ -74,7 +74,7 @@ func TestWrapper_HandleCredentialOffer(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		wallet := holder.NewMockOpenIDHandler(ctrl)
-		wallet.EXPECT().HandleCredentialOffer(gomock.Any(), gomock.Any())
+		wallet.EXPECT().HandleCredentialOffer(gomock.Any(), gomock.Any()).Return(openid4vci.CredentialOfferStatusReceived, nil)
 		documentOwner := management.NewMockDocumentOwner(ctrl)
 		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
 		service := vcr.NewMockVCR(ctrl)
@@ -125,3 +125,39 @@ func TestWrapper_HandleCredentialOffer(t *testing.T) {
 		require.EqualError(t, err, "invalid_request - DID is not owned by this node")
 	})
 }
+
+func TestWrapper_HandleAuthorizationResponse(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		wallet := holder.NewMockOpenIDHandler(ctrl)
+		wallet.EXPECT().HandleAuthorizationResponse(gomock.Any(), "code", "state")
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDHolder(gomock.Any(), holderDID).Return(wallet, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+		response, err := api.HandleAuthorizationResponse(context.Background(), HandleAuthorizationResponseRequestObject{
+			Did: holderDID.String(),
+			Params: HandleAuthorizationResponseParams{
+				Code:  "code",
+				State: "state",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "credential_received", string(response.(HandleAuthorizationResponse200JSONResponse).Status))
+	})
+	t.Run("unknown tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(false, nil)
+		api := Wrapper{DocumentOwner: documentOwner}
+
+		_, err := api.HandleAuthorizationResponse(context.Background(), HandleAuthorizationResponseRequestObject{
+			Did: holderDID.String(),
+		})
+
+		require.EqualError(t, err, "invalid_request - DID is not owned by this node")
+	})
+}
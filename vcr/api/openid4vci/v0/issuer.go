@@ -20,10 +20,8 @@ package v0
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	"net/http"
@@ -78,38 +76,68 @@ func (w Wrapper) RequestCredential(ctx context.Context, request RequestCredentia
 	if err != nil {
 		return nil, err
 	}
+	accessToken, err := accessTokenFromHeader(request.Params.Authorization)
+	if err != nil {
+		return nil, err
+	}
+	credentialResponse, err := issuer.HandleCredentialRequest(ctx, *request.Body, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return RequestCredential200JSONResponse(*credentialResponse), nil
+}
 
-	if request.Params.Authorization == nil {
-		return nil, openid4vci.Error{
+// RequestBatchCredential requests multiple credentials at once from the given DID.
+func (w Wrapper) RequestBatchCredential(ctx context.Context, request RequestBatchCredentialRequestObject) (RequestBatchCredentialResponseObject, error) {
+	issuer, err := w.getIssuerHandler(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := accessTokenFromHeader(request.Params.Authorization)
+	if err != nil {
+		return nil, err
+	}
+	batchResponse, err := issuer.HandleBatchCredentialRequest(ctx, *request.Body, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return RequestBatchCredential200JSONResponse(*batchResponse), nil
+}
+
+// RequestDeferredCredential requests a credential of which issuance was deferred from the given DID.
+func (w Wrapper) RequestDeferredCredential(ctx context.Context, request RequestDeferredCredentialRequestObject) (RequestDeferredCredentialResponseObject, error) {
+	issuer, err := w.getIssuerHandler(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := accessTokenFromHeader(request.Params.Authorization)
+	if err != nil {
+		return nil, err
+	}
+	credentialResponse, err := issuer.HandleDeferredCredentialRequest(ctx, *request.Body, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return RequestDeferredCredential200JSONResponse(*credentialResponse), nil
+}
+
+// accessTokenFromHeader returns the bearer token from the given Authorization header.
+func accessTokenFromHeader(authHeader *string) (string, error) {
+	if authHeader == nil {
+		return "", openid4vci.Error{
 			Err:        errors.New("missing authorization header"),
 			Code:       openid4vci.InvalidToken,
 			StatusCode: http.StatusUnauthorized,
 		}
 	}
-	authHeader := *request.Params.Authorization
-	if len(authHeader) < 7 || strings.ToLower(authHeader[:7]) != "bearer " {
-		return nil, openid4vci.Error{
+	if len(*authHeader) < 7 || strings.ToLower((*authHeader)[:7]) != "bearer " {
+		return "", openid4vci.Error{
 			Err:        errors.New("invalid authorization header"),
 			Code:       openid4vci.InvalidToken,
 			StatusCode: http.StatusUnauthorized,
 		}
 	}
-	accessToken := authHeader[7:]
-	credentialRequest := *request.Body
-	credential, err := issuer.HandleCredentialRequest(ctx, credentialRequest, accessToken)
-	if err != nil {
-		return nil, err
-	}
-	credentialJSON, _ := credential.MarshalJSON()
-	credentialMap := make(map[string]interface{})
-	err = json.Unmarshal(credentialJSON, &credentialMap)
-	if err != nil {
-		return nil, err
-	}
-	return RequestCredential200JSONResponse(CredentialResponse{
-		Credential: &credentialMap,
-		Format:     vc.JSONLDCredentialProofFormat,
-	}), nil
+	return (*authHeader)[7:], nil
 }
 
 // RequestAccessToken requests an OAuth2 access token from the given DID.
@@ -119,14 +147,25 @@ func (w Wrapper) RequestAccessToken(ctx context.Context, request RequestAccessTo
 		return nil, err
 	}
 
-	if request.Body.GrantType != openid4vci.PreAuthorizedCodeGrant {
+	var accessToken, cNonce string
+	switch request.Body.GrantType {
+	case openid4vci.PreAuthorizedCodeGrant:
+		if request.Body.PreAuthorizedCode == nil {
+			return nil, missingParameterError("pre-authorized_code")
+		}
+		accessToken, cNonce, err = issuerHandler.HandleAccessTokenRequest(ctx, *request.Body.PreAuthorizedCode)
+	case openid4vci.AuthorizationCodeGrant:
+		if request.Body.Code == nil || request.Body.CodeVerifier == nil || request.Body.RedirectUri == nil || request.Body.ClientId == nil {
+			return nil, missingParameterError("code, code_verifier, redirect_uri or client_id")
+		}
+		accessToken, cNonce, err = issuerHandler.HandleAuthorizationCodeTokenRequest(ctx, *request.Body.Code, *request.Body.CodeVerifier, *request.Body.RedirectUri, *request.Body.ClientId)
+	default:
 		return nil, openid4vci.Error{
 			Err:        fmt.Errorf("unsupported grant type: %s", request.Body.GrantType),
 			Code:       openid4vci.UnsupportedGrantType,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
-	accessToken, cNonce, err := issuerHandler.HandleAccessTokenRequest(ctx, request.Body.PreAuthorizedCode)
 	if err != nil {
 		return nil, err
 	}
@@ -138,3 +177,32 @@ func (w Wrapper) RequestAccessToken(ctx context.Context, request RequestAccessTo
 		TokenType:   "bearer",
 	}), nil
 }
+
+// HandleAuthorizeRequest handles an OAuth2 authorization request for the authorization code flow, for the given issuer DID.
+func (w Wrapper) HandleAuthorizeRequest(ctx context.Context, request HandleAuthorizeRequestRequestObject) (HandleAuthorizeRequestResponseObject, error) {
+	issuerHandler, err := w.getIssuerHandler(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	authorizationRequest := openid4vci.AuthorizationRequest{
+		ClientID: request.Params.ClientId,
+		Request:  request.Params.Request,
+	}
+	redirectURL, err := issuerHandler.HandleAuthorizeRequest(ctx, authorizationRequest)
+	if err != nil {
+		return nil, err
+	}
+	return HandleAuthorizeRequest302Response{
+		Headers: HandleAuthorizeRequest302ResponseHeaders{
+			Location: redirectURL,
+		},
+	}, nil
+}
+
+func missingParameterError(parameters string) error {
+	return openid4vci.Error{
+		Err:        fmt.Errorf("missing parameter(s): %s", parameters),
+		Code:       openid4vci.InvalidRequest,
+		StatusCode: http.StatusBadRequest,
+	}
+}
@@ -97,6 +97,7 @@ func TestWrapper_GetOIDCProviderMetadata(t *testing.T) {
 }
 
 func TestWrapper_RequestAccessToken(t *testing.T) {
+	code := "code"
 	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
@@ -110,7 +111,7 @@ func TestWrapper_RequestAccessToken(t *testing.T) {
 		response, err := api.RequestAccessToken(context.Background(), RequestAccessTokenRequestObject{
 			Did: issuerDID.String(),
 			Body: &RequestAccessTokenFormdataRequestBody{
-				GrantType: "urn:ietf:params:oauth:grant-type:pre-authorized_code", PreAuthorizedCode: "code",
+				GrantType: "urn:ietf:params:oauth:grant-type:pre-authorized_code", PreAuthorizedCode: &code,
 			},
 		})
 
@@ -118,6 +119,58 @@ func TestWrapper_RequestAccessToken(t *testing.T) {
 		assert.Equal(t, "access-token", response.(RequestAccessToken200JSONResponse).AccessToken)
 		assert.Equal(t, "c_nonce", *response.(RequestAccessToken200JSONResponse).CNonce)
 	})
+	t.Run("ok - authorization code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+		oidcIssuer.EXPECT().HandleAuthorizationCodeTokenRequest(gomock.Any(), "code", "verifier", "https://example.com/callback", "did:nuts:holder").Return("access-token", "c_nonce", nil)
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+		verifier := "verifier"
+		redirectURI := "https://example.com/callback"
+		clientID := "did:nuts:holder"
+
+		response, err := api.RequestAccessToken(context.Background(), RequestAccessTokenRequestObject{
+			Did: issuerDID.String(),
+			Body: &RequestAccessTokenFormdataRequestBody{
+				GrantType:    "authorization_code",
+				Code:         &code,
+				CodeVerifier: &verifier,
+				RedirectUri:  &redirectURI,
+				ClientId:     &clientID,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", response.(RequestAccessToken200JSONResponse).AccessToken)
+		assert.Equal(t, "c_nonce", *response.(RequestAccessToken200JSONResponse).CNonce)
+	})
+	t.Run("missing parameters", func(t *testing.T) {
+		for _, grantType := range []string{"urn:ietf:params:oauth:grant-type:pre-authorized_code", "authorization_code"} {
+			t.Run(grantType, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+				documentOwner := management.NewMockDocumentOwner(ctrl)
+				documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+				service := vcr.NewMockVCR(ctrl)
+				service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+				api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+				response, err := api.RequestAccessToken(context.Background(), RequestAccessTokenRequestObject{
+					Did:  issuerDID.String(),
+					Body: &RequestAccessTokenFormdataRequestBody{GrantType: grantType},
+				})
+
+				var protocolError openid4vci.Error
+				require.ErrorAs(t, err, &protocolError)
+				assert.Equal(t, openid4vci.InvalidRequest, protocolError.Code)
+				assert.Equal(t, http.StatusBadRequest, protocolError.StatusCode)
+				require.Nil(t, response)
+			})
+		}
+	})
 	t.Run("unknown tenant", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		documentOwner := management.NewMockDocumentOwner(ctrl)
@@ -154,11 +207,76 @@ func TestWrapper_RequestAccessToken(t *testing.T) {
 	})
 }
 
+func TestWrapper_HandleAuthorizeRequest(t *testing.T) {
+	params := HandleAuthorizeRequestParams{
+		ClientId: "did:nuts:holder",
+		Request:  "request-object",
+	}
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+		oidcIssuer.EXPECT().HandleAuthorizeRequest(gomock.Any(), openid4vci.AuthorizationRequest{
+			ClientID: "did:nuts:holder",
+			Request:  "request-object",
+		}).Return("https://example.com/callback?code=code&state=state", nil)
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+		response, err := api.HandleAuthorizeRequest(context.Background(), HandleAuthorizeRequestRequestObject{
+			Did:    issuerDID.String(),
+			Params: params,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/callback?code=code&state=state", response.(HandleAuthorizeRequest302Response).Headers.Location)
+	})
+	t.Run("unknown tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(false, nil)
+		api := Wrapper{DocumentOwner: documentOwner}
+
+		_, err := api.HandleAuthorizeRequest(context.Background(), HandleAuthorizeRequestRequestObject{
+			Did:    issuerDID.String(),
+			Params: params,
+		})
+
+		require.EqualError(t, err, "invalid_request - DID is not owned by this node")
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+		oidcIssuer.EXPECT().HandleAuthorizeRequest(gomock.Any(), gomock.Any()).Return("", openid4vci.Error{
+			Code:       openid4vci.InvalidGrant,
+			StatusCode: http.StatusBadRequest,
+		})
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+		response, err := api.HandleAuthorizeRequest(context.Background(), HandleAuthorizeRequestRequestObject{
+			Did:    issuerDID.String(),
+			Params: params,
+		})
+
+		require.EqualError(t, err, "invalid_grant")
+		assert.Nil(t, response)
+	})
+}
+
 func TestWrapper_RequestCredential(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
-		oidcIssuer.EXPECT().HandleCredentialRequest(gomock.Any(), gomock.Any(), "access-token").Return(&vc.VerifiableCredential{}, nil)
+		oidcIssuer.EXPECT().HandleCredentialRequest(gomock.Any(), gomock.Any(), "access-token").Return(&openid4vci.CredentialResponse{
+			Format:     vc.JSONLDCredentialProofFormat,
+			Credential: &map[string]interface{}{},
+		}, nil)
 		documentOwner := management.NewMockDocumentOwner(ctrl)
 		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
 		service := vcr.NewMockVCR(ctrl)
@@ -241,3 +359,103 @@ func TestWrapper_RequestCredential(t *testing.T) {
 		assert.Nil(t, response)
 	})
 }
+
+func TestWrapper_RequestBatchCredential(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+		oidcIssuer.EXPECT().HandleBatchCredentialRequest(gomock.Any(), gomock.Any(), "access-token").Return(&openid4vci.BatchCredentialResponse{
+			CredentialResponses: []openid4vci.CredentialResponse{{Format: vc.JSONLDCredentialProofFormat}},
+		}, nil)
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+		authz := "Bearer access-token"
+		response, err := api.RequestBatchCredential(context.Background(), RequestBatchCredentialRequestObject{
+			Did: issuerDID.String(),
+			Params: RequestBatchCredentialParams{
+				Authorization: &authz,
+			},
+			Body: &RequestBatchCredentialJSONRequestBody{
+				CredentialRequests: []openid4vci.CredentialRequest{{Format: "ldp_vc"}},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, response.(RequestBatchCredential200JSONResponse).CredentialResponses, 1)
+	})
+	t.Run("error - no authorization header", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+		response, err := api.RequestBatchCredential(context.Background(), RequestBatchCredentialRequestObject{
+			Did: issuerDID.String(),
+		})
+
+		var protocolError openid4vci.Error
+		require.ErrorAs(t, err, &protocolError)
+		assert.EqualError(t, protocolError, "invalid_token - missing authorization header")
+		assert.Nil(t, response)
+	})
+}
+
+func TestWrapper_RequestDeferredCredential(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+		oidcIssuer.EXPECT().HandleDeferredCredentialRequest(gomock.Any(), openid4vci.DeferredCredentialRequest{TransactionID: "tx-id"}, "access-token").Return(&openid4vci.CredentialResponse{
+			Format:     vc.JSONLDCredentialProofFormat,
+			Credential: &map[string]interface{}{},
+		}, nil)
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+		authz := "Bearer access-token"
+		response, err := api.RequestDeferredCredential(context.Background(), RequestDeferredCredentialRequestObject{
+			Did: issuerDID.String(),
+			Params: RequestDeferredCredentialParams{
+				Authorization: &authz,
+			},
+			Body: &RequestDeferredCredentialJSONRequestBody{TransactionID: "tx-id"},
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, response.(RequestDeferredCredential200JSONResponse).Credential)
+	})
+	t.Run("issuance pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		oidcIssuer := issuer.NewMockOpenIDHandler(ctrl)
+		oidcIssuer.EXPECT().HandleDeferredCredentialRequest(gomock.Any(), gomock.Any(), "access-token").Return(nil, openid4vci.Error{
+			Code:       openid4vci.IssuancePending,
+			StatusCode: http.StatusBadRequest,
+		})
+		documentOwner := management.NewMockDocumentOwner(ctrl)
+		documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(true, nil)
+		service := vcr.NewMockVCR(ctrl)
+		service.EXPECT().GetOpenIDIssuer(gomock.Any(), issuerDID).Return(oidcIssuer, nil)
+		api := Wrapper{VCR: service, DocumentOwner: documentOwner}
+
+		authz := "Bearer access-token"
+		response, err := api.RequestDeferredCredential(context.Background(), RequestDeferredCredentialRequestObject{
+			Did: issuerDID.String(),
+			Params: RequestDeferredCredentialParams{
+				Authorization: &authz,
+			},
+			Body: &RequestDeferredCredentialJSONRequestBody{TransactionID: "tx-id"},
+		})
+
+		require.EqualError(t, err, "issuance_pending")
+		assert.Nil(t, response)
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	vcrTypes "github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -40,6 +41,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/vcr"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	"github.com/nuts-foundation/nuts-node/vcr/statuslist2021"
 )
 
 var clockFn = func() time.Time {
@@ -74,14 +76,23 @@ func (w *Wrapper) Routes(router core.EchoRouter) {
 
 // ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
 func (w *Wrapper) ResolveStatusCode(err error) int {
+	var openidErr openid4vci.Error
+	if errors.As(err, &openidErr) && openidErr.StatusCode != 0 {
+		return openidErr.StatusCode
+	}
 	return core.ResolveStatusCode(err, map[error]int{
-		vcrTypes.ErrNotFound:        http.StatusNotFound,
-		resolver.ErrServiceNotFound: http.StatusPreconditionFailed,
-		vcrTypes.ErrRevoked:         http.StatusConflict,
-		resolver.ErrNotFound:        http.StatusBadRequest,
-		resolver.ErrKeyNotFound:     http.StatusBadRequest,
-		did.ErrInvalidDID:           http.StatusBadRequest,
-		vcrTypes.ErrStatusNotFound:  http.StatusBadRequest,
+		vcrTypes.ErrNotFound:                http.StatusNotFound,
+		holder.ErrCredentialOfferNotFound:   http.StatusNotFound,
+		issuer.ErrDeferredIssuanceNotFound:  http.StatusNotFound,
+		issuer.ErrDeferredIssuanceCompleted: http.StatusConflict,
+		resolver.ErrServiceNotFound:         http.StatusPreconditionFailed,
+		vcrTypes.ErrRevoked:                 http.StatusConflict,
+		vcrTypes.ErrSuspended:               http.StatusConflict,
+		vcrTypes.ErrNotSuspended:            http.StatusConflict,
+		resolver.ErrNotFound:                http.StatusBadRequest,
+		resolver.ErrKeyNotFound:             http.StatusBadRequest,
+		did.ErrInvalidDID:                   http.StatusBadRequest,
+		vcrTypes.ErrStatusNotFound:          http.StatusBadRequest,
 	})
 }
 
@@ -148,7 +159,7 @@ func parseCredentialOptions(request IssueVCRequestObject) (*issuer.CredentialOpt
 	// Valid CredentialOptions:
 	// All: Format
 	// did:nuts: PublishToNetwork, Visibility
-	// did:web: WithStatusList2021Revocation
+	// did:web: WithStatusList2021Revocation, WithStatusListSuspension, StatusListType
 	switch issuerDID.Method {
 	case "nuts":
 		options.Publish = true
@@ -179,11 +190,35 @@ func parseCredentialOptions(request IssueVCRequestObject) (*issuer.CredentialOpt
 		if request.Body.WithStatusList2021Revocation != nil {
 			return nil, core.InvalidInputError("illegal option 'withStatusList2021Revocation' requested for issuer's DID method: %s", issuerDID.Method)
 		}
+		if request.Body.WithStatusListSuspension != nil {
+			return nil, core.InvalidInputError("illegal option 'withStatusListSuspension' requested for issuer's DID method: %s", issuerDID.Method)
+		}
+		if request.Body.StatusListType != nil {
+			return nil, core.InvalidInputError("illegal option 'statusListType' requested for issuer's DID method: %s", issuerDID.Method)
+		}
 	case "web":
 		// check if statusList2021Entry should be added
 		if request.Body.WithStatusList2021Revocation != nil {
 			options.WithStatusListRevocation = *request.Body.WithStatusList2021Revocation
 		}
+		// check if a statusListEntry for suspension should be added
+		if request.Body.WithStatusListSuspension != nil {
+			options.WithStatusListSuspension = *request.Body.WithStatusListSuspension
+		}
+		// select the type of status list the entries are added to
+		if request.Body.StatusListType != nil {
+			if !options.WithStatusListRevocation && !options.WithStatusListSuspension {
+				return nil, core.InvalidInputError("statusListType is only allowed when withStatusList2021Revocation or withStatusListSuspension is true")
+			}
+			switch *request.Body.StatusListType {
+			case StatusList2021:
+				options.StatusListType = statuslist2021.StatusList2021Type
+			case BitstringStatusList:
+				options.StatusListType = statuslist2021.BitstringStatusListType
+			default:
+				return nil, core.InvalidInputError("invalid value for statusListType")
+			}
+		}
 		// non expiring credential MUST set a value for withStatusList2021Revocation
 		if request.Body.ExpirationDate == nil && request.Body.WithStatusList2021Revocation == nil {
 			return nil, core.InvalidInputError("withStatusList2021Revocation MUST be provided for credentials without expirationDate")
@@ -222,6 +257,32 @@ func (w Wrapper) RevokeVC(ctx context.Context, request RevokeVCRequestObject) (R
 	return RevokeVC204Response{}, nil
 }
 
+// SuspendVC handles the API request for suspending a credential.
+func (w Wrapper) SuspendVC(ctx context.Context, request SuspendVCRequestObject) (SuspendVCResponseObject, error) {
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+
+	if err = w.VCR.Issuer().Suspend(ctx, *credentialID); err != nil {
+		return nil, err
+	}
+	return SuspendVC204Response{}, nil
+}
+
+// UnsuspendVC handles the API request for lifting the suspension of a credential.
+func (w Wrapper) UnsuspendVC(ctx context.Context, request UnsuspendVCRequestObject) (UnsuspendVCResponseObject, error) {
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+
+	if err = w.VCR.Issuer().Unsuspend(ctx, *credentialID); err != nil {
+		return nil, err
+	}
+	return UnsuspendVC204Response{}, nil
+}
+
 // SearchIssuedVCs handles the API request for searching for issued VCs
 func (w *Wrapper) SearchIssuedVCs(ctx context.Context, request SearchIssuedVCsRequestObject) (SearchIssuedVCsResponseObject, error) {
 	issuerDID, err := did.ParseDID(request.Params.Issuer)
@@ -404,6 +465,119 @@ func (w *Wrapper) GetCredentialsInWallet(ctx context.Context, request GetCredent
 	return GetCredentialsInWallet200JSONResponse(credentials), nil
 }
 
+// RemoveCredentialFromWallet handles API request to remove a credential from a holder's wallet.
+func (w *Wrapper) RemoveCredentialFromWallet(ctx context.Context, request RemoveCredentialFromWalletRequestObject) (RemoveCredentialFromWalletResponseObject, error) {
+	holderDID, err := did.ParseDID(request.Did)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid holder DID: %w", err)
+	}
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+	err = w.VCR.Wallet().Remove(ctx, *holderDID, *credentialID)
+	if err != nil {
+		return nil, err
+	}
+	return RemoveCredentialFromWallet204Response{}, nil
+}
+
+// ListCredentialOffers handles API request to list the OpenID4VCI credential offers awaiting the holder's consent.
+func (w *Wrapper) ListCredentialOffers(ctx context.Context, request ListCredentialOffersRequestObject) (ListCredentialOffersResponseObject, error) {
+	openidHolder, err := w.getOpenIDHolder(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	offers, err := openidHolder.ListCredentialOffers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ListCredentialOffers200JSONResponse(offers), nil
+}
+
+// AcceptCredentialOffer handles API request to accept an OpenID4VCI credential offer, which retrieves the offered credential into the holder's wallet.
+func (w *Wrapper) AcceptCredentialOffer(ctx context.Context, request AcceptCredentialOfferRequestObject) (AcceptCredentialOfferResponseObject, error) {
+	openidHolder, err := w.getOpenIDHolder(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	if err = openidHolder.AcceptCredentialOffer(ctx, request.Id); err != nil {
+		return nil, err
+	}
+	return AcceptCredentialOffer204Response{}, nil
+}
+
+// RejectCredentialOffer handles API request to reject an OpenID4VCI credential offer.
+func (w *Wrapper) RejectCredentialOffer(ctx context.Context, request RejectCredentialOfferRequestObject) (RejectCredentialOfferResponseObject, error) {
+	openidHolder, err := w.getOpenIDHolder(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	if err = openidHolder.RejectCredentialOffer(ctx, request.Id); err != nil {
+		return nil, err
+	}
+	return RejectCredentialOffer204Response{}, nil
+}
+
+func (w *Wrapper) getOpenIDHolder(ctx context.Context, holderDID string) (holder.OpenIDHandler, error) {
+	if !w.VCR.OpenID4VCIEnabled() {
+		return nil, core.NotFoundError("openid4vci is disabled")
+	}
+	parsedDID, err := did.ParseDID(holderDID)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid holder DID: %w", err)
+	}
+	return w.VCR.GetOpenIDHolder(ctx, *parsedDID)
+}
+
+// ListDeferredIssuances handles API request to list the deferred OpenID4VCI credential issuances of the issuer.
+func (w *Wrapper) ListDeferredIssuances(ctx context.Context, request ListDeferredIssuancesRequestObject) (ListDeferredIssuancesResponseObject, error) {
+	openidIssuer, err := w.getOpenIDIssuer(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	issuances, err := openidIssuer.ListDeferredIssuances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ListDeferredIssuances200JSONResponse(issuances), nil
+}
+
+// CompleteDeferredIssuance handles API request to complete a deferred OpenID4VCI credential issuance, which allows the wallet to retrieve the credential.
+func (w *Wrapper) CompleteDeferredIssuance(ctx context.Context, request CompleteDeferredIssuanceRequestObject) (CompleteDeferredIssuanceResponseObject, error) {
+	openidIssuer, err := w.getOpenIDIssuer(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	if err = openidIssuer.CompleteDeferredIssuance(ctx, request.Id); err != nil {
+		return nil, err
+	}
+	return CompleteDeferredIssuance204Response{}, nil
+}
+
+// DenyDeferredIssuance handles API request to deny a deferred OpenID4VCI credential issuance.
+func (w *Wrapper) DenyDeferredIssuance(ctx context.Context, request DenyDeferredIssuanceRequestObject) (DenyDeferredIssuanceResponseObject, error) {
+	openidIssuer, err := w.getOpenIDIssuer(ctx, request.Did)
+	if err != nil {
+		return nil, err
+	}
+	if err = openidIssuer.DenyDeferredIssuance(ctx, request.Id); err != nil {
+		return nil, err
+	}
+	return DenyDeferredIssuance204Response{}, nil
+}
+
+func (w *Wrapper) getOpenIDIssuer(ctx context.Context, issuerDID string) (issuer.OpenIDHandler, error) {
+	if !w.VCR.OpenID4VCIEnabled() {
+		return nil, core.NotFoundError("openid4vci is disabled")
+	}
+	parsedDID, err := did.ParseDID(issuerDID)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid issuer DID: %w", err)
+	}
+	return w.VCR.GetOpenIDIssuer(ctx, *parsedDID)
+}
+
 // TrustIssuer handles API request to start trusting an issuer of a Verifiable Credential.
 func (w *Wrapper) TrustIssuer(ctx context.Context, request TrustIssuerRequestObject) (TrustIssuerResponseObject, error) {
 	if err := changeTrust(*request.Body, w.VCR.Trust); err != nil {
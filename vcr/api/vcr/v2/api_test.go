@@ -38,7 +38,10 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	"github.com/nuts-foundation/nuts-node/vcr/statuslist2021"
+	vcrTypes "github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -281,6 +284,23 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.EqualError(t, err, "illegal option 'withStatusList2021Revocation' requested for issuer's DID method: nuts")
 				assert.Nil(t, response)
 			})
+			t.Run("err - WithStatusListSuspension provided", func(t *testing.T) {
+				testContext := newMockContext(t)
+
+				publishValue := false
+				request := IssueVCRequest{
+					Issuer:                   expectedRequestedVC.Issuer.String(),
+					Type:                     expectedRequestedVC.Type[0].String(),
+					CredentialSubject:        expectedRequestedVC.CredentialSubject,
+					PublishToNetwork:         &publishValue,
+					WithStatusListSuspension: &publishValue,
+				}
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "illegal option 'withStatusListSuspension' requested for issuer's DID method: nuts")
+				assert.Nil(t, response)
+			})
 
 		})
 		t.Run("did:web", func(t *testing.T) {
@@ -310,6 +330,82 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
 			})
+			t.Run("ok with BitstringStatusList", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				statusListType := BitstringStatusList
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					Type:                         expectedRequestedVC.Type[0].String(),
+					WithStatusList2021Revocation: &withRevocation,
+					StatusListType:               &statusListType,
+				}
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					WithStatusListRevocation: true,
+					StatusListType:           statuslist2021.BitstringStatusListType,
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
+			t.Run("ok with suspension", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				withSuspension := true
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					Type:                         expectedRequestedVC.Type[0].String(),
+					WithStatusList2021Revocation: &withRevocation,
+					WithStatusListSuspension:     &withSuspension,
+				}
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					WithStatusListRevocation: true,
+					WithStatusListSuspension: true,
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
+			t.Run("err - statusListType without WithStatusList2021Revocation", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := false
+				statusListType := BitstringStatusList
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					Type:                         expectedRequestedVC.Type[0].String(),
+					WithStatusList2021Revocation: &withRevocation,
+					StatusListType:               &statusListType,
+				}
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "statusListType is only allowed when withStatusList2021Revocation or withStatusListSuspension is true")
+				assert.Nil(t, response)
+			})
+			t.Run("err - invalid statusListType", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				statusListType := IssueVCRequestStatusListType("RevocationList2020")
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					Type:                         expectedRequestedVC.Type[0].String(),
+					WithStatusList2021Revocation: &withRevocation,
+					StatusListType:               &statusListType,
+				}
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "invalid value for statusListType")
+				assert.Nil(t, response)
+			})
 			t.Run("ok - without WithStatusList2021Revocation, with ExpirationDate", func(t *testing.T) {
 				testContext := newMockContext(t)
 
@@ -661,6 +757,72 @@ func TestWrapper_RevokeVC(t *testing.T) {
 	})
 }
 
+func TestWrapper_SuspendVC(t *testing.T) {
+	credentialID := "did:web:example.com:iam:123#abc"
+	credentialURI := ssi.MustParseURI(credentialID)
+
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Suspend(gomock.Any(), credentialURI).Return(nil)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: credentialID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SuspendVC204Response{}, response)
+	})
+	t.Run("vcr returns an error", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Suspend(gomock.Any(), credentialURI).Return(vcrTypes.ErrSuspended)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: credentialID})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, vcrTypes.ErrSuspended)
+		assert.Equal(t, http.StatusConflict, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("invalid credential id format", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: "%%"})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid credential id: parse \"%%\": invalid URL escape \"%%\"")
+	})
+}
+
+func TestWrapper_UnsuspendVC(t *testing.T) {
+	credentialID := "did:web:example.com:iam:123#abc"
+	credentialURI := ssi.MustParseURI(credentialID)
+
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Unsuspend(gomock.Any(), credentialURI).Return(nil)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: credentialID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, UnsuspendVC204Response{}, response)
+	})
+	t.Run("vcr returns an error", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Unsuspend(gomock.Any(), credentialURI).Return(vcrTypes.ErrNotSuspended)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: credentialID})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, vcrTypes.ErrNotSuspended)
+		assert.Equal(t, http.StatusConflict, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("invalid credential id format", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: "%%"})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid credential id: parse \"%%\": invalid URL escape \"%%\"")
+	})
+}
+
 // parsedTimeStr returns the original (truncated) time and an RFC3339 string with an extra round of formatting/parsing
 func parsedTimeStr(t time.Time) (time.Time, string) {
 	formatted := t.Format(time.RFC3339)
@@ -728,6 +890,308 @@ func TestWrapper_GetCredentialsInWallet(t *testing.T) {
 	})
 }
 
+func TestWrapper_RemoveCredentialFromWallet(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockWallet.EXPECT().Remove(testContext.requestCtx, holderDID, *testVC.ID).Return(nil)
+
+		response, err := testContext.client.RemoveCredentialFromWallet(testContext.requestCtx, RemoveCredentialFromWalletRequestObject{
+			Did: holderDID.String(),
+			Id:  testVC.ID.String(),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, RemoveCredentialFromWallet204Response{}, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockWallet.EXPECT().Remove(testContext.requestCtx, holderDID, *testVC.ID).Return(vcrTypes.ErrNotFound)
+
+		response, err := testContext.client.RemoveCredentialFromWallet(testContext.requestCtx, RemoveCredentialFromWalletRequestObject{
+			Did: holderDID.String(),
+			Id:  testVC.ID.String(),
+		})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, vcrTypes.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("invalid DID", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.RemoveCredentialFromWallet(testContext.requestCtx, RemoveCredentialFromWalletRequestObject{
+			Did: "%%",
+			Id:  testVC.ID.String(),
+		})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid holder DID: invalid DID")
+	})
+	t.Run("invalid credential ID", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.RemoveCredentialFromWallet(testContext.requestCtx, RemoveCredentialFromWalletRequestObject{
+			Did: holderDID.String(),
+			Id:  "%%",
+		})
+
+		assert.Empty(t, response)
+		assert.ErrorContains(t, err, "invalid credential id")
+	})
+}
+
+func TestWrapper_ListCredentialOffers(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidHolder := holder.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDHolder(testContext.requestCtx, holderDID).Return(openidHolder, nil)
+		offers := []holder.PendingCredentialOffer{{ID: "1"}}
+		openidHolder.EXPECT().ListCredentialOffers(testContext.requestCtx).Return(offers, nil)
+
+		response, err := testContext.client.ListCredentialOffers(testContext.requestCtx, ListCredentialOffersRequestObject{
+			Did: holderDID.String(),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ListCredentialOffers200JSONResponse(offers), response)
+	})
+	t.Run("OpenID4VCI disabled", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(false)
+
+		response, err := testContext.client.ListCredentialOffers(testContext.requestCtx, ListCredentialOffersRequestObject{
+			Did: holderDID.String(),
+		})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "openid4vci is disabled")
+	})
+	t.Run("invalid DID", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+
+		response, err := testContext.client.ListCredentialOffers(testContext.requestCtx, ListCredentialOffersRequestObject{
+			Did: "%%",
+		})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid holder DID: invalid DID")
+	})
+	t.Run("DID not owned by this node", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDHolder(testContext.requestCtx, holderDID).Return(nil, openid4vci.Error{
+			Err:        errors.New("DID is not owned by this node"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusNotFound,
+		})
+
+		response, err := testContext.client.ListCredentialOffers(testContext.requestCtx, ListCredentialOffersRequestObject{
+			Did: holderDID.String(),
+		})
+
+		assert.Empty(t, response)
+		assert.Equal(t, http.StatusNotFound, testContext.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_AcceptCredentialOffer(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidHolder := holder.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDHolder(testContext.requestCtx, holderDID).Return(openidHolder, nil)
+		openidHolder.EXPECT().AcceptCredentialOffer(testContext.requestCtx, "1").Return(nil)
+
+		response, err := testContext.client.AcceptCredentialOffer(testContext.requestCtx, AcceptCredentialOfferRequestObject{
+			Did: holderDID.String(),
+			Id:  "1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, AcceptCredentialOffer204Response{}, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidHolder := holder.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDHolder(testContext.requestCtx, holderDID).Return(openidHolder, nil)
+		openidHolder.EXPECT().AcceptCredentialOffer(testContext.requestCtx, "1").Return(holder.ErrCredentialOfferNotFound)
+
+		response, err := testContext.client.AcceptCredentialOffer(testContext.requestCtx, AcceptCredentialOfferRequestObject{
+			Did: holderDID.String(),
+			Id:  "1",
+		})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, holder.ErrCredentialOfferNotFound)
+		assert.Equal(t, http.StatusNotFound, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("retrieving credential fails", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidHolder := holder.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDHolder(testContext.requestCtx, holderDID).Return(openidHolder, nil)
+		openidHolder.EXPECT().AcceptCredentialOffer(testContext.requestCtx, "1").Return(openid4vci.Error{
+			Err:        errors.New("failed"),
+			Code:       openid4vci.ServerError,
+			StatusCode: http.StatusBadGateway,
+		})
+
+		_, err := testContext.client.AcceptCredentialOffer(testContext.requestCtx, AcceptCredentialOfferRequestObject{
+			Did: holderDID.String(),
+			Id:  "1",
+		})
+
+		assert.EqualError(t, err, "server_error - failed")
+		assert.Equal(t, http.StatusBadGateway, testContext.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_RejectCredentialOffer(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidHolder := holder.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDHolder(testContext.requestCtx, holderDID).Return(openidHolder, nil)
+		openidHolder.EXPECT().RejectCredentialOffer(testContext.requestCtx, "1").Return(nil)
+
+		response, err := testContext.client.RejectCredentialOffer(testContext.requestCtx, RejectCredentialOfferRequestObject{
+			Did: holderDID.String(),
+			Id:  "1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, RejectCredentialOffer204Response{}, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidHolder := holder.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDHolder(testContext.requestCtx, holderDID).Return(openidHolder, nil)
+		openidHolder.EXPECT().RejectCredentialOffer(testContext.requestCtx, "1").Return(holder.ErrCredentialOfferNotFound)
+
+		response, err := testContext.client.RejectCredentialOffer(testContext.requestCtx, RejectCredentialOfferRequestObject{
+			Did: holderDID.String(),
+			Id:  "1",
+		})
+
+		assert.Empty(t, response)
+		assert.Equal(t, http.StatusNotFound, testContext.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_ListDeferredIssuances(t *testing.T) {
+	issuerDID := did.MustParseDID("did:web:example.com:iam:456")
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidIssuer := issuer.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDIssuer(testContext.requestCtx, issuerDID).Return(openidIssuer, nil)
+		issuances := []issuer.DeferredIssuance{{TransactionID: "1", Status: issuer.DeferredIssuanceStatusPending}}
+		openidIssuer.EXPECT().ListDeferredIssuances(testContext.requestCtx).Return(issuances, nil)
+
+		response, err := testContext.client.ListDeferredIssuances(testContext.requestCtx, ListDeferredIssuancesRequestObject{
+			Did: issuerDID.String(),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ListDeferredIssuances200JSONResponse(issuances), response)
+	})
+	t.Run("OpenID4VCI disabled", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(false)
+
+		response, err := testContext.client.ListDeferredIssuances(testContext.requestCtx, ListDeferredIssuancesRequestObject{
+			Did: issuerDID.String(),
+		})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "openid4vci is disabled")
+	})
+	t.Run("invalid DID", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+
+		response, err := testContext.client.ListDeferredIssuances(testContext.requestCtx, ListDeferredIssuancesRequestObject{
+			Did: "%%",
+		})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid issuer DID: invalid DID")
+	})
+}
+
+func TestWrapper_CompleteDeferredIssuance(t *testing.T) {
+	issuerDID := did.MustParseDID("did:web:example.com:iam:456")
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidIssuer := issuer.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDIssuer(testContext.requestCtx, issuerDID).Return(openidIssuer, nil)
+		openidIssuer.EXPECT().CompleteDeferredIssuance(testContext.requestCtx, "1").Return(nil)
+
+		response, err := testContext.client.CompleteDeferredIssuance(testContext.requestCtx, CompleteDeferredIssuanceRequestObject{
+			Did: issuerDID.String(),
+			Id:  "1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, CompleteDeferredIssuance204Response{}, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidIssuer := issuer.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDIssuer(testContext.requestCtx, issuerDID).Return(openidIssuer, nil)
+		openidIssuer.EXPECT().CompleteDeferredIssuance(testContext.requestCtx, "1").Return(issuer.ErrDeferredIssuanceNotFound)
+
+		response, err := testContext.client.CompleteDeferredIssuance(testContext.requestCtx, CompleteDeferredIssuanceRequestObject{
+			Did: issuerDID.String(),
+			Id:  "1",
+		})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, issuer.ErrDeferredIssuanceNotFound)
+		assert.Equal(t, http.StatusNotFound, testContext.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_DenyDeferredIssuance(t *testing.T) {
+	issuerDID := did.MustParseDID("did:web:example.com:iam:456")
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidIssuer := issuer.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDIssuer(testContext.requestCtx, issuerDID).Return(openidIssuer, nil)
+		openidIssuer.EXPECT().DenyDeferredIssuance(testContext.requestCtx, "1").Return(nil)
+
+		response, err := testContext.client.DenyDeferredIssuance(testContext.requestCtx, DenyDeferredIssuanceRequestObject{
+			Did: issuerDID.String(),
+			Id:  "1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, DenyDeferredIssuance204Response{}, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		testContext := newMockContext(t)
+		openidIssuer := issuer.NewMockOpenIDHandler(testContext.ctrl)
+		testContext.vcr.EXPECT().OpenID4VCIEnabled().Return(true)
+		testContext.vcr.EXPECT().GetOpenIDIssuer(testContext.requestCtx, issuerDID).Return(openidIssuer, nil)
+		openidIssuer.EXPECT().DenyDeferredIssuance(testContext.requestCtx, "1").Return(issuer.ErrDeferredIssuanceNotFound)
+
+		response, err := testContext.client.DenyDeferredIssuance(testContext.requestCtx, DenyDeferredIssuanceRequestObject{
+			Did: issuerDID.String(),
+			Id:  "1",
+		})
+
+		assert.Empty(t, response)
+		assert.Equal(t, http.StatusNotFound, testContext.client.ResolveStatusCode(err))
+	})
+}
+
 func TestWrapper_CreateVP(t *testing.T) {
 	issuerURI := ssi.MustParseURI("did:nuts:123")
 	credentialType := ssi.MustParseURI("ExampleType")
@@ -108,6 +108,30 @@ func (hb HTTPClient) IssueVC(request IssueVCRequest) (*VerifiableCredential, err
 	return response.JSON200, err
 }
 
+// Suspend suspends the issued Verifiable Credential with the given ID
+func (hb HTTPClient) Suspend(credentialID string) error {
+	ctx := context.Background()
+
+	response, err := hb.client().SuspendVC(ctx, credentialID)
+	if err != nil {
+		return err
+	}
+
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
+// Unsuspend lifts the suspension of the issued Verifiable Credential with the given ID
+func (hb HTTPClient) Unsuspend(credentialID string) error {
+	ctx := context.Background()
+
+	response, err := hb.client().UnsuspendVC(ctx, credentialID)
+	if err != nil {
+		return err
+	}
+
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
 func handleTrustedResponse(response *http.Response, err error) ([]string, error) {
 	if err != nil {
 		return nil, err
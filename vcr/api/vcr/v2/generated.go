@@ -39,8 +39,15 @@ const (
 
 // Defines values for IssueVCRequestFormat.
 const (
-	JwtVc IssueVCRequestFormat = "jwt_vc"
-	LdpVc IssueVCRequestFormat = "ldp_vc"
+	JwtVc   IssueVCRequestFormat = "jwt_vc"
+	LdpVc   IssueVCRequestFormat = "ldp_vc"
+	VcSdJwt IssueVCRequestFormat = "vc+sd-jwt"
+)
+
+// Defines values for IssueVCRequestStatusListType.
+const (
+	BitstringStatusList IssueVCRequestStatusListType = "BitstringStatusList"
+	StatusList2021      IssueVCRequestStatusListType = "StatusList2021"
 )
 
 // Defines values for IssueVCRequestVisibility.
@@ -111,7 +118,7 @@ type IssueVCRequest struct {
 	// ExpirationDate RFC3339 time string until when the credential is valid.
 	ExpirationDate *string `json:"expirationDate,omitempty"`
 
-	// Format Proof format for the credential (ldp_vc for JSON-LD or jwt_vc for JWT). If not set, it defaults to JSON-LD.
+	// Format Proof format for the credential (ldp_vc for JSON-LD, jwt_vc for JWT or vc+sd-jwt for SD-JWT VC). If not set, it defaults to JSON-LD.
 	Format *IssueVCRequestFormat `json:"format,omitempty"`
 
 	// Issuer DID according to Nuts specification.
@@ -123,6 +130,13 @@ type IssueVCRequest struct {
 	// Note: a not published credential can still be publicly revoked.
 	PublishToNetwork *bool `json:"publishToNetwork,omitempty"`
 
+	// StatusListType Type of the status list the credentialStatus added by withStatusList2021Revocation or withStatusListSuspension refers to.
+	// 'StatusList2021' adds a StatusList2021Entry (https://www.w3.org/TR/2023/WD-vc-status-list-20230427/),
+	// 'BitstringStatusList' adds a BitstringStatusListEntry (https://www.w3.org/TR/vc-bitstring-status-list/).
+	// Since the W3C only defines its terms for the VC data model v2, the credential then is a VC data model v2 credential: it gets the https://www.w3.org/ns/credentials/v2 context instead of the v1 context, and validFrom/validUntil instead of issuanceDate/expirationDate. It can't be combined with the v1 context or the jwt_vc format.
+	// If not set, it defaults to StatusList2021. Only valid if withStatusList2021Revocation or withStatusListSuspension is true.
+	StatusListType *IssueVCRequestStatusListType `json:"statusListType,omitempty"`
+
 	// Type Type definition for the credential.
 	Type string `json:"type"`
 
@@ -131,17 +145,29 @@ type IssueVCRequest struct {
 	Visibility *IssueVCRequestVisibility `json:"visibility,omitempty"`
 
 	// WithStatusList2021Revocation Add a credentialStatus with statusPurpose 'revocation' to the issued credential. This allows a credential to
-	// be revoked using the referenced StatusList2021Credential. StatusPurpose 'suspension' is not supported (yet).
+	// be revoked using the referenced StatusList2021Credential. Use withStatusListSuspension for statusPurpose 'suspension'.
 	// See https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
 	//
 	// Credentials with a short lifespan (expiry) are preferred over adding a credentialStatus.
 	// A credentialStatus can only be added if publishToNetwork is false, and the issuer is not a did:nuts.
 	WithStatusList2021Revocation *bool `json:"withStatusList2021Revocation,omitempty"`
+
+	// WithStatusListSuspension Add a credentialStatus with statusPurpose 'suspension' to the issued credential. This allows a credential to
+	// be suspended (and unsuspended) using the referenced status list credential.
+	// Only valid for did:web issuers.
+	WithStatusListSuspension *bool `json:"withStatusListSuspension,omitempty"`
 }
 
-// IssueVCRequestFormat Proof format for the credential (ldp_vc for JSON-LD or jwt_vc for JWT). If not set, it defaults to JSON-LD.
+// IssueVCRequestFormat Proof format for the credential (ldp_vc for JSON-LD, jwt_vc for JWT or vc+sd-jwt for SD-JWT VC). If not set, it defaults to JSON-LD.
 type IssueVCRequestFormat string
 
+// IssueVCRequestStatusListType Type of the status list the credentialStatus added by withStatusList2021Revocation or withStatusListSuspension refers to.
+// 'StatusList2021' adds a StatusList2021Entry (https://www.w3.org/TR/2023/WD-vc-status-list-20230427/),
+// 'BitstringStatusList' adds a BitstringStatusListEntry (https://www.w3.org/TR/vc-bitstring-status-list/).
+// Since the W3C only defines its terms for the VC data model v2, the credential then is a VC data model v2 credential: it gets the https://www.w3.org/ns/credentials/v2 context instead of the v1 context, and validFrom/validUntil instead of issuanceDate/expirationDate. It can't be combined with the v1 context or the jwt_vc format.
+// If not set, it defaults to StatusList2021. Only valid if withStatusList2021Revocation or withStatusListSuspension is true.
+type IssueVCRequestStatusListType string
+
 // IssueVCRequestVisibility When publishToNetwork is true, the credential can be published publicly or privately to the holder.
 // This field is mandatory if publishToNetwork is true to prevent accidents. It defaults to "private".
 type IssueVCRequestVisibility string
@@ -333,6 +359,15 @@ type ClientInterface interface {
 
 	CreateVP(ctx context.Context, body CreateVPJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListCredentialOffers request
+	ListCredentialOffers(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcceptCredentialOffer request
+	AcceptCredentialOffer(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RejectCredentialOffer request
+	RejectCredentialOffer(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetCredentialsInWallet request
 	GetCredentialsInWallet(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -341,6 +376,9 @@ type ClientInterface interface {
 
 	LoadVC(ctx context.Context, did string, body LoadVCJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// RemoveCredentialFromWallet request
+	RemoveCredentialFromWallet(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// IssueVCWithBody request with any body
 	IssueVCWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -352,6 +390,21 @@ type ClientInterface interface {
 	// RevokeVC request
 	RevokeVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// UnsuspendVC request
+	UnsuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SuspendVC request
+	SuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListDeferredIssuances request
+	ListDeferredIssuances(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CompleteDeferredIssuance request
+	CompleteDeferredIssuance(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DenyDeferredIssuance request
+	DenyDeferredIssuance(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// SearchVCsWithBody request with any body
 	SearchVCsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -411,6 +464,42 @@ func (c *Client) CreateVP(ctx context.Context, body CreateVPJSONRequestBody, req
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListCredentialOffers(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCredentialOffersRequest(c.Server, did)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcceptCredentialOffer(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptCredentialOfferRequest(c.Server, did, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RejectCredentialOffer(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRejectCredentialOfferRequest(c.Server, did, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetCredentialsInWallet(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetCredentialsInWalletRequest(c.Server, did)
 	if err != nil {
@@ -447,6 +536,18 @@ func (c *Client) LoadVC(ctx context.Context, did string, body LoadVCJSONRequestB
 	return c.Client.Do(req)
 }
 
+func (c *Client) RemoveCredentialFromWallet(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveCredentialFromWalletRequest(c.Server, did, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) IssueVCWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewIssueVCRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -495,6 +596,66 @@ func (c *Client) RevokeVC(ctx context.Context, id string, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
+func (c *Client) UnsuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnsuspendVCRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSuspendVCRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListDeferredIssuances(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDeferredIssuancesRequest(c.Server, did)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CompleteDeferredIssuance(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCompleteDeferredIssuanceRequest(c.Server, did, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DenyDeferredIssuance(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDenyDeferredIssuanceRequest(c.Server, did, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) SearchVCsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSearchVCsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -691,6 +852,122 @@ func NewCreateVPRequestWithBody(server string, contentType string, body io.Reade
 	return req, nil
 }
 
+// NewListCredentialOffersRequest generates requests for ListCredentialOffers
+func NewListCredentialOffersRequest(server string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/holder/%s/offer", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAcceptCredentialOfferRequest generates requests for AcceptCredentialOffer
+func NewAcceptCredentialOfferRequest(server string, did string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/holder/%s/offer/%s/accept", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRejectCredentialOfferRequest generates requests for RejectCredentialOffer
+func NewRejectCredentialOfferRequest(server string, did string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/holder/%s/offer/%s/reject", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetCredentialsInWalletRequest generates requests for GetCredentialsInWallet
 func NewGetCredentialsInWalletRequest(server string, did string) (*http.Request, error) {
 	var err error
@@ -772,6 +1049,47 @@ func NewLoadVCRequestWithBody(server string, did string, contentType string, bod
 	return req, nil
 }
 
+// NewRemoveCredentialFromWalletRequest generates requests for RemoveCredentialFromWallet
+func NewRemoveCredentialFromWalletRequest(server string, did string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/holder/%s/vc/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewIssueVCRequest calls the generic IssueVC builder with application/json body
 func NewIssueVCRequest(server string, body IssueVCJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -919,19 +1237,203 @@ func NewRevokeVCRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewSearchVCsRequest calls the generic SearchVCs builder with application/json body
-func NewSearchVCsRequest(server string, body SearchVCsJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+// NewUnsuspendVCRequest generates requests for UnsuspendVC
+func NewUnsuspendVCRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewSearchVCsRequestWithBody(server, "application/json", bodyReader)
-}
 
-// NewSearchVCsRequestWithBody generates requests for SearchVCs with any type of body
-func NewSearchVCsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/vc/%s/suspension", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSuspendVCRequest generates requests for SuspendVC
+func NewSuspendVCRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/vc/%s/suspension", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListDeferredIssuancesRequest generates requests for ListDeferredIssuances
+func NewListDeferredIssuancesRequest(server string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/%s/deferred", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCompleteDeferredIssuanceRequest generates requests for CompleteDeferredIssuance
+func NewCompleteDeferredIssuanceRequest(server string, did string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/%s/deferred/%s/complete", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDenyDeferredIssuanceRequest generates requests for DenyDeferredIssuance
+func NewDenyDeferredIssuanceRequest(server string, did string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "did", runtime.ParamLocationPath, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/%s/deferred/%s/deny", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSearchVCsRequest calls the generic SearchVCs builder with application/json body
+func NewSearchVCsRequest(server string, body SearchVCsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSearchVCsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSearchVCsRequestWithBody generates requests for SearchVCs with any type of body
+func NewSearchVCsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1269,6 +1771,15 @@ type ClientWithResponsesInterface interface {
 
 	CreateVPWithResponse(ctx context.Context, body CreateVPJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateVPResponse, error)
 
+	// ListCredentialOffersWithResponse request
+	ListCredentialOffersWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*ListCredentialOffersResponse, error)
+
+	// AcceptCredentialOfferWithResponse request
+	AcceptCredentialOfferWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*AcceptCredentialOfferResponse, error)
+
+	// RejectCredentialOfferWithResponse request
+	RejectCredentialOfferWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*RejectCredentialOfferResponse, error)
+
 	// GetCredentialsInWalletWithResponse request
 	GetCredentialsInWalletWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*GetCredentialsInWalletResponse, error)
 
@@ -1277,6 +1788,9 @@ type ClientWithResponsesInterface interface {
 
 	LoadVCWithResponse(ctx context.Context, did string, body LoadVCJSONRequestBody, reqEditors ...RequestEditorFn) (*LoadVCResponse, error)
 
+	// RemoveCredentialFromWalletWithResponse request
+	RemoveCredentialFromWalletWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*RemoveCredentialFromWalletResponse, error)
+
 	// IssueVCWithBodyWithResponse request with any body
 	IssueVCWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueVCResponse, error)
 
@@ -1288,6 +1802,21 @@ type ClientWithResponsesInterface interface {
 	// RevokeVCWithResponse request
 	RevokeVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RevokeVCResponse, error)
 
+	// UnsuspendVCWithResponse request
+	UnsuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnsuspendVCResponse, error)
+
+	// SuspendVCWithResponse request
+	SuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SuspendVCResponse, error)
+
+	// ListDeferredIssuancesWithResponse request
+	ListDeferredIssuancesWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*ListDeferredIssuancesResponse, error)
+
+	// CompleteDeferredIssuanceWithResponse request
+	CompleteDeferredIssuanceWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*CompleteDeferredIssuanceResponse, error)
+
+	// DenyDeferredIssuanceWithResponse request
+	DenyDeferredIssuanceWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*DenyDeferredIssuanceResponse, error)
+
 	// SearchVCsWithBodyWithResponse request with any body
 	SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error)
 
@@ -1355,10 +1884,10 @@ func (r CreateVPResponse) StatusCode() int {
 	return 0
 }
 
-type GetCredentialsInWalletResponse struct {
+type ListCredentialOffersResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *[]VerifiableCredential
+	JSON200                       *[]PendingCredentialOffer
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1372,7 +1901,7 @@ type GetCredentialsInWalletResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCredentialsInWalletResponse) Status() string {
+func (r ListCredentialOffersResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1380,14 +1909,14 @@ func (r GetCredentialsInWalletResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCredentialsInWalletResponse) StatusCode() int {
+func (r ListCredentialOffersResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type LoadVCResponse struct {
+type AcceptCredentialOfferResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
 	ApplicationproblemJSONDefault *struct {
@@ -1403,7 +1932,7 @@ type LoadVCResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r LoadVCResponse) Status() string {
+func (r AcceptCredentialOfferResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1411,17 +1940,16 @@ func (r LoadVCResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r LoadVCResponse) StatusCode() int {
+func (r AcceptCredentialOfferResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type IssueVCResponse struct {
+type RejectCredentialOfferResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *VerifiableCredential
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1435,7 +1963,7 @@ type IssueVCResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r IssueVCResponse) Status() string {
+func (r RejectCredentialOfferResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1443,17 +1971,17 @@ func (r IssueVCResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r IssueVCResponse) StatusCode() int {
+func (r RejectCredentialOfferResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type SearchIssuedVCsResponse struct {
+type GetCredentialsInWalletResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *SearchVCResults
+	JSON200                       *[]VerifiableCredential
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1467,7 +1995,7 @@ type SearchIssuedVCsResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r SearchIssuedVCsResponse) Status() string {
+func (r GetCredentialsInWalletResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1475,17 +2003,16 @@ func (r SearchIssuedVCsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r SearchIssuedVCsResponse) StatusCode() int {
+func (r GetCredentialsInWalletResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type RevokeVCResponse struct {
+type LoadVCResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *Revocation
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1499,7 +2026,7 @@ type RevokeVCResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r RevokeVCResponse) Status() string {
+func (r LoadVCResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1507,17 +2034,16 @@ func (r RevokeVCResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r RevokeVCResponse) StatusCode() int {
+func (r LoadVCResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type SearchVCsResponse struct {
+type RemoveCredentialFromWalletResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *SearchVCResults
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1531,7 +2057,7 @@ type SearchVCsResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r SearchVCsResponse) Status() string {
+func (r RemoveCredentialFromWalletResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1539,14 +2065,14 @@ func (r SearchVCsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r SearchVCsResponse) StatusCode() int {
+func (r RemoveCredentialFromWalletResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ResolveVCResponse struct {
+type IssueVCResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
 	JSON200                       *VerifiableCredential
@@ -1563,7 +2089,7 @@ type ResolveVCResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r ResolveVCResponse) Status() string {
+func (r IssueVCResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1571,16 +2097,17 @@ func (r ResolveVCResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ResolveVCResponse) StatusCode() int {
+func (r IssueVCResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type UntrustIssuerResponse struct {
+type SearchIssuedVCsResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
+	JSON200                       *SearchVCResults
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1594,7 +2121,7 @@ type UntrustIssuerResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r UntrustIssuerResponse) Status() string {
+func (r SearchIssuedVCsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1602,16 +2129,17 @@ func (r UntrustIssuerResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r UntrustIssuerResponse) StatusCode() int {
+func (r SearchIssuedVCsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type TrustIssuerResponse struct {
+type RevokeVCResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
+	JSON200                       *Revocation
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1625,7 +2153,7 @@ type TrustIssuerResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r TrustIssuerResponse) Status() string {
+func (r RevokeVCResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1633,17 +2161,16 @@ func (r TrustIssuerResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r TrustIssuerResponse) StatusCode() int {
+func (r RevokeVCResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type VerifyVCResponse struct {
+type UnsuspendVCResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *VCVerificationResult
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1657,7 +2184,7 @@ type VerifyVCResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r VerifyVCResponse) Status() string {
+func (r UnsuspendVCResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1665,17 +2192,16 @@ func (r VerifyVCResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r VerifyVCResponse) StatusCode() int {
+func (r UnsuspendVCResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type VerifyVPResponse struct {
+type SuspendVCResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *VPVerificationResult
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1689,7 +2215,7 @@ type VerifyVPResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r VerifyVPResponse) Status() string {
+func (r SuspendVCResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1697,17 +2223,17 @@ func (r VerifyVPResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r VerifyVPResponse) StatusCode() int {
+func (r SuspendVCResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ListTrustedResponse struct {
+type ListDeferredIssuancesResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *[]string
+	JSON200                       *[]DeferredIssuance
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1721,7 +2247,7 @@ type ListTrustedResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r ListTrustedResponse) Status() string {
+func (r ListDeferredIssuancesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1729,17 +2255,16 @@ func (r ListTrustedResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListTrustedResponse) StatusCode() int {
+func (r ListDeferredIssuancesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ListUntrustedResponse struct {
+type CompleteDeferredIssuanceResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
-	JSON200                       *[]string
 	ApplicationproblemJSONDefault *struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1753,7 +2278,7 @@ type ListUntrustedResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r ListUntrustedResponse) Status() string {
+func (r CompleteDeferredIssuanceResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1761,380 +2286,585 @@ func (r ListUntrustedResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListUntrustedResponse) StatusCode() int {
+func (r CompleteDeferredIssuanceResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-// CreateVPWithBodyWithResponse request with arbitrary body returning *CreateVPResponse
-func (c *ClientWithResponses) CreateVPWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateVPResponse, error) {
-	rsp, err := c.CreateVPWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseCreateVPResponse(rsp)
-}
+type DenyDeferredIssuanceResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
 
-func (c *ClientWithResponses) CreateVPWithResponse(ctx context.Context, body CreateVPJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateVPResponse, error) {
-	rsp, err := c.CreateVP(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
-	return ParseCreateVPResponse(rsp)
 }
 
-// GetCredentialsInWalletWithResponse request returning *GetCredentialsInWalletResponse
-func (c *ClientWithResponses) GetCredentialsInWalletWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*GetCredentialsInWalletResponse, error) {
-	rsp, err := c.GetCredentialsInWallet(ctx, did, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r DenyDeferredIssuanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseGetCredentialsInWalletResponse(rsp)
+	return http.StatusText(0)
 }
 
-// LoadVCWithBodyWithResponse request with arbitrary body returning *LoadVCResponse
-func (c *ClientWithResponses) LoadVCWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoadVCResponse, error) {
-	rsp, err := c.LoadVCWithBody(ctx, did, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r DenyDeferredIssuanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParseLoadVCResponse(rsp)
+	return 0
 }
 
-func (c *ClientWithResponses) LoadVCWithResponse(ctx context.Context, did string, body LoadVCJSONRequestBody, reqEditors ...RequestEditorFn) (*LoadVCResponse, error) {
-	rsp, err := c.LoadVC(ctx, did, body, reqEditors...)
-	if err != nil {
-		return nil, err
+type SearchVCsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *SearchVCResults
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
-	return ParseLoadVCResponse(rsp)
 }
 
-// IssueVCWithBodyWithResponse request with arbitrary body returning *IssueVCResponse
-func (c *ClientWithResponses) IssueVCWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueVCResponse, error) {
-	rsp, err := c.IssueVCWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r SearchVCsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseIssueVCResponse(rsp)
+	return http.StatusText(0)
 }
 
-func (c *ClientWithResponses) IssueVCWithResponse(ctx context.Context, body IssueVCJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueVCResponse, error) {
-	rsp, err := c.IssueVC(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchVCsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParseIssueVCResponse(rsp)
+	return 0
 }
 
-// SearchIssuedVCsWithResponse request returning *SearchIssuedVCsResponse
-func (c *ClientWithResponses) SearchIssuedVCsWithResponse(ctx context.Context, params *SearchIssuedVCsParams, reqEditors ...RequestEditorFn) (*SearchIssuedVCsResponse, error) {
-	rsp, err := c.SearchIssuedVCs(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+type ResolveVCResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *VerifiableCredential
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
-	return ParseSearchIssuedVCsResponse(rsp)
 }
 
-// RevokeVCWithResponse request returning *RevokeVCResponse
-func (c *ClientWithResponses) RevokeVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RevokeVCResponse, error) {
-	rsp, err := c.RevokeVC(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r ResolveVCResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseRevokeVCResponse(rsp)
+	return http.StatusText(0)
 }
 
-// SearchVCsWithBodyWithResponse request with arbitrary body returning *SearchVCsResponse
-func (c *ClientWithResponses) SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
-	rsp, err := c.SearchVCsWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResolveVCResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParseSearchVCsResponse(rsp)
+	return 0
 }
 
-func (c *ClientWithResponses) SearchVCsWithResponse(ctx context.Context, body SearchVCsJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
-	rsp, err := c.SearchVCs(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+type UntrustIssuerResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
-	return ParseSearchVCsResponse(rsp)
 }
 
-// ResolveVCWithResponse request returning *ResolveVCResponse
-func (c *ClientWithResponses) ResolveVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*ResolveVCResponse, error) {
-	rsp, err := c.ResolveVC(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r UntrustIssuerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseResolveVCResponse(rsp)
+	return http.StatusText(0)
 }
 
-// UntrustIssuerWithBodyWithResponse request with arbitrary body returning *UntrustIssuerResponse
-func (c *ClientWithResponses) UntrustIssuerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UntrustIssuerResponse, error) {
-	rsp, err := c.UntrustIssuerWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r UntrustIssuerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParseUntrustIssuerResponse(rsp)
+	return 0
 }
 
-func (c *ClientWithResponses) UntrustIssuerWithResponse(ctx context.Context, body UntrustIssuerJSONRequestBody, reqEditors ...RequestEditorFn) (*UntrustIssuerResponse, error) {
-	rsp, err := c.UntrustIssuer(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+type TrustIssuerResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
-	return ParseUntrustIssuerResponse(rsp)
 }
 
-// TrustIssuerWithBodyWithResponse request with arbitrary body returning *TrustIssuerResponse
-func (c *ClientWithResponses) TrustIssuerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TrustIssuerResponse, error) {
-	rsp, err := c.TrustIssuerWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r TrustIssuerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseTrustIssuerResponse(rsp)
+	return http.StatusText(0)
 }
 
-func (c *ClientWithResponses) TrustIssuerWithResponse(ctx context.Context, body TrustIssuerJSONRequestBody, reqEditors ...RequestEditorFn) (*TrustIssuerResponse, error) {
-	rsp, err := c.TrustIssuer(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r TrustIssuerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParseTrustIssuerResponse(rsp)
+	return 0
 }
 
-// VerifyVCWithBodyWithResponse request with arbitrary body returning *VerifyVCResponse
-func (c *ClientWithResponses) VerifyVCWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyVCResponse, error) {
-	rsp, err := c.VerifyVCWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+type VerifyVCResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *VCVerificationResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
-	return ParseVerifyVCResponse(rsp)
 }
 
-func (c *ClientWithResponses) VerifyVCWithResponse(ctx context.Context, body VerifyVCJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyVCResponse, error) {
-	rsp, err := c.VerifyVC(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r VerifyVCResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseVerifyVCResponse(rsp)
+	return http.StatusText(0)
 }
 
-// VerifyVPWithBodyWithResponse request with arbitrary body returning *VerifyVPResponse
-func (c *ClientWithResponses) VerifyVPWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyVPResponse, error) {
-	rsp, err := c.VerifyVPWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r VerifyVCResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParseVerifyVPResponse(rsp)
+	return 0
 }
 
-func (c *ClientWithResponses) VerifyVPWithResponse(ctx context.Context, body VerifyVPJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyVPResponse, error) {
-	rsp, err := c.VerifyVP(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+type VerifyVPResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *VPVerificationResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
-	return ParseVerifyVPResponse(rsp)
 }
 
-// ListTrustedWithResponse request returning *ListTrustedResponse
-func (c *ClientWithResponses) ListTrustedWithResponse(ctx context.Context, credentialType string, reqEditors ...RequestEditorFn) (*ListTrustedResponse, error) {
-	rsp, err := c.ListTrusted(ctx, credentialType, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r VerifyVPResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseListTrustedResponse(rsp)
+	return http.StatusText(0)
 }
 
-// ListUntrustedWithResponse request returning *ListUntrustedResponse
-func (c *ClientWithResponses) ListUntrustedWithResponse(ctx context.Context, credentialType string, reqEditors ...RequestEditorFn) (*ListUntrustedResponse, error) {
-	rsp, err := c.ListUntrusted(ctx, credentialType, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r VerifyVPResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParseListUntrustedResponse(rsp)
+	return 0
 }
 
-// ParseCreateVPResponse parses an HTTP response from a CreateVPWithResponse call
-func ParseCreateVPResponse(rsp *http.Response) (*CreateVPResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
+type ListTrustedResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]string
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
+}
 
-	response := &CreateVPResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+// Status returns HTTPResponse.Status
+func (r ListTrustedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
+	return http.StatusText(0)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest VerifiablePresentation
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTrustedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest struct {
-			// Detail A human-readable explanation specific to this occurrence of the problem.
-			Detail string `json:"detail"`
+type ListUntrustedResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]string
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
 
-			// Status HTTP statuscode
-			Status float32 `json:"status"`
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
 
-			// Title A short, human-readable summary of the problem type.
-			Title string `json:"title"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.ApplicationproblemJSONDefault = &dest
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
 
+// Status returns HTTPResponse.Status
+func (r ListUntrustedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
+	return http.StatusText(0)
+}
 
-	return response, nil
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListUntrustedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-// ParseGetCredentialsInWalletResponse parses an HTTP response from a GetCredentialsInWalletWithResponse call
-func ParseGetCredentialsInWalletResponse(rsp *http.Response) (*GetCredentialsInWalletResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// CreateVPWithBodyWithResponse request with arbitrary body returning *CreateVPResponse
+func (c *ClientWithResponses) CreateVPWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateVPResponse, error) {
+	rsp, err := c.CreateVPWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
+	return ParseCreateVPResponse(rsp)
+}
 
-	response := &GetCredentialsInWalletResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+func (c *ClientWithResponses) CreateVPWithResponse(ctx context.Context, body CreateVPJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateVPResponse, error) {
+	rsp, err := c.CreateVP(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseCreateVPResponse(rsp)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []VerifiableCredential
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest struct {
-			// Detail A human-readable explanation specific to this occurrence of the problem.
-			Detail string `json:"detail"`
-
-			// Status HTTP statuscode
-			Status float32 `json:"status"`
-
-			// Title A short, human-readable summary of the problem type.
-			Title string `json:"title"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.ApplicationproblemJSONDefault = &dest
-
+// ListCredentialOffersWithResponse request returning *ListCredentialOffersResponse
+func (c *ClientWithResponses) ListCredentialOffersWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*ListCredentialOffersResponse, error) {
+	rsp, err := c.ListCredentialOffers(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-
-	return response, nil
+	return ParseListCredentialOffersResponse(rsp)
 }
 
-// ParseLoadVCResponse parses an HTTP response from a LoadVCWithResponse call
-func ParseLoadVCResponse(rsp *http.Response) (*LoadVCResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// AcceptCredentialOfferWithResponse request returning *AcceptCredentialOfferResponse
+func (c *ClientWithResponses) AcceptCredentialOfferWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*AcceptCredentialOfferResponse, error) {
+	rsp, err := c.AcceptCredentialOffer(ctx, did, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
+	return ParseAcceptCredentialOfferResponse(rsp)
+}
 
-	response := &LoadVCResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+// RejectCredentialOfferWithResponse request returning *RejectCredentialOfferResponse
+func (c *ClientWithResponses) RejectCredentialOfferWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*RejectCredentialOfferResponse, error) {
+	rsp, err := c.RejectCredentialOffer(ctx, did, id, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseRejectCredentialOfferResponse(rsp)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest struct {
-			// Detail A human-readable explanation specific to this occurrence of the problem.
-			Detail string `json:"detail"`
-
-			// Status HTTP statuscode
-			Status float32 `json:"status"`
-
-			// Title A short, human-readable summary of the problem type.
-			Title string `json:"title"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.ApplicationproblemJSONDefault = &dest
-
+// GetCredentialsInWalletWithResponse request returning *GetCredentialsInWalletResponse
+func (c *ClientWithResponses) GetCredentialsInWalletWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*GetCredentialsInWalletResponse, error) {
+	rsp, err := c.GetCredentialsInWallet(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-
-	return response, nil
+	return ParseGetCredentialsInWalletResponse(rsp)
 }
 
-// ParseIssueVCResponse parses an HTTP response from a IssueVCWithResponse call
-func ParseIssueVCResponse(rsp *http.Response) (*IssueVCResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// LoadVCWithBodyWithResponse request with arbitrary body returning *LoadVCResponse
+func (c *ClientWithResponses) LoadVCWithBodyWithResponse(ctx context.Context, did string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoadVCResponse, error) {
+	rsp, err := c.LoadVCWithBody(ctx, did, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
+	return ParseLoadVCResponse(rsp)
+}
 
-	response := &IssueVCResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+func (c *ClientWithResponses) LoadVCWithResponse(ctx context.Context, did string, body LoadVCJSONRequestBody, reqEditors ...RequestEditorFn) (*LoadVCResponse, error) {
+	rsp, err := c.LoadVC(ctx, did, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseLoadVCResponse(rsp)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest VerifiableCredential
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest struct {
-			// Detail A human-readable explanation specific to this occurrence of the problem.
-			Detail string `json:"detail"`
-
-			// Status HTTP statuscode
-			Status float32 `json:"status"`
+// RemoveCredentialFromWalletWithResponse request returning *RemoveCredentialFromWalletResponse
+func (c *ClientWithResponses) RemoveCredentialFromWalletWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*RemoveCredentialFromWalletResponse, error) {
+	rsp, err := c.RemoveCredentialFromWallet(ctx, did, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveCredentialFromWalletResponse(rsp)
+}
 
-			// Title A short, human-readable summary of the problem type.
-			Title string `json:"title"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.ApplicationproblemJSONDefault = &dest
+// IssueVCWithBodyWithResponse request with arbitrary body returning *IssueVCResponse
+func (c *ClientWithResponses) IssueVCWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueVCResponse, error) {
+	rsp, err := c.IssueVCWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueVCResponse(rsp)
+}
 
+func (c *ClientWithResponses) IssueVCWithResponse(ctx context.Context, body IssueVCJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueVCResponse, error) {
+	rsp, err := c.IssueVC(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseIssueVCResponse(rsp)
+}
 
-	return response, nil
+// SearchIssuedVCsWithResponse request returning *SearchIssuedVCsResponse
+func (c *ClientWithResponses) SearchIssuedVCsWithResponse(ctx context.Context, params *SearchIssuedVCsParams, reqEditors ...RequestEditorFn) (*SearchIssuedVCsResponse, error) {
+	rsp, err := c.SearchIssuedVCs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchIssuedVCsResponse(rsp)
 }
 
-// ParseSearchIssuedVCsResponse parses an HTTP response from a SearchIssuedVCsWithResponse call
-func ParseSearchIssuedVCsResponse(rsp *http.Response) (*SearchIssuedVCsResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// RevokeVCWithResponse request returning *RevokeVCResponse
+func (c *ClientWithResponses) RevokeVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RevokeVCResponse, error) {
+	rsp, err := c.RevokeVC(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
+	return ParseRevokeVCResponse(rsp)
+}
 
-	response := &SearchIssuedVCsResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+// UnsuspendVCWithResponse request returning *UnsuspendVCResponse
+func (c *ClientWithResponses) UnsuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnsuspendVCResponse, error) {
+	rsp, err := c.UnsuspendVC(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseUnsuspendVCResponse(rsp)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest SearchVCResults
+// SuspendVCWithResponse request returning *SuspendVCResponse
+func (c *ClientWithResponses) SuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SuspendVCResponse, error) {
+	rsp, err := c.SuspendVC(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSuspendVCResponse(rsp)
+}
+
+// ListDeferredIssuancesWithResponse request returning *ListDeferredIssuancesResponse
+func (c *ClientWithResponses) ListDeferredIssuancesWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*ListDeferredIssuancesResponse, error) {
+	rsp, err := c.ListDeferredIssuances(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDeferredIssuancesResponse(rsp)
+}
+
+// CompleteDeferredIssuanceWithResponse request returning *CompleteDeferredIssuanceResponse
+func (c *ClientWithResponses) CompleteDeferredIssuanceWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*CompleteDeferredIssuanceResponse, error) {
+	rsp, err := c.CompleteDeferredIssuance(ctx, did, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCompleteDeferredIssuanceResponse(rsp)
+}
+
+// DenyDeferredIssuanceWithResponse request returning *DenyDeferredIssuanceResponse
+func (c *ClientWithResponses) DenyDeferredIssuanceWithResponse(ctx context.Context, did string, id string, reqEditors ...RequestEditorFn) (*DenyDeferredIssuanceResponse, error) {
+	rsp, err := c.DenyDeferredIssuance(ctx, did, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDenyDeferredIssuanceResponse(rsp)
+}
+
+// SearchVCsWithBodyWithResponse request with arbitrary body returning *SearchVCsResponse
+func (c *ClientWithResponses) SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
+	rsp, err := c.SearchVCsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchVCsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SearchVCsWithResponse(ctx context.Context, body SearchVCsJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
+	rsp, err := c.SearchVCs(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchVCsResponse(rsp)
+}
+
+// ResolveVCWithResponse request returning *ResolveVCResponse
+func (c *ClientWithResponses) ResolveVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*ResolveVCResponse, error) {
+	rsp, err := c.ResolveVC(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResolveVCResponse(rsp)
+}
+
+// UntrustIssuerWithBodyWithResponse request with arbitrary body returning *UntrustIssuerResponse
+func (c *ClientWithResponses) UntrustIssuerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UntrustIssuerResponse, error) {
+	rsp, err := c.UntrustIssuerWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUntrustIssuerResponse(rsp)
+}
+
+func (c *ClientWithResponses) UntrustIssuerWithResponse(ctx context.Context, body UntrustIssuerJSONRequestBody, reqEditors ...RequestEditorFn) (*UntrustIssuerResponse, error) {
+	rsp, err := c.UntrustIssuer(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUntrustIssuerResponse(rsp)
+}
+
+// TrustIssuerWithBodyWithResponse request with arbitrary body returning *TrustIssuerResponse
+func (c *ClientWithResponses) TrustIssuerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TrustIssuerResponse, error) {
+	rsp, err := c.TrustIssuerWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrustIssuerResponse(rsp)
+}
+
+func (c *ClientWithResponses) TrustIssuerWithResponse(ctx context.Context, body TrustIssuerJSONRequestBody, reqEditors ...RequestEditorFn) (*TrustIssuerResponse, error) {
+	rsp, err := c.TrustIssuer(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrustIssuerResponse(rsp)
+}
+
+// VerifyVCWithBodyWithResponse request with arbitrary body returning *VerifyVCResponse
+func (c *ClientWithResponses) VerifyVCWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyVCResponse, error) {
+	rsp, err := c.VerifyVCWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyVCResponse(rsp)
+}
+
+func (c *ClientWithResponses) VerifyVCWithResponse(ctx context.Context, body VerifyVCJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyVCResponse, error) {
+	rsp, err := c.VerifyVC(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyVCResponse(rsp)
+}
+
+// VerifyVPWithBodyWithResponse request with arbitrary body returning *VerifyVPResponse
+func (c *ClientWithResponses) VerifyVPWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyVPResponse, error) {
+	rsp, err := c.VerifyVPWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyVPResponse(rsp)
+}
+
+func (c *ClientWithResponses) VerifyVPWithResponse(ctx context.Context, body VerifyVPJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyVPResponse, error) {
+	rsp, err := c.VerifyVP(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyVPResponse(rsp)
+}
+
+// ListTrustedWithResponse request returning *ListTrustedResponse
+func (c *ClientWithResponses) ListTrustedWithResponse(ctx context.Context, credentialType string, reqEditors ...RequestEditorFn) (*ListTrustedResponse, error) {
+	rsp, err := c.ListTrusted(ctx, credentialType, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTrustedResponse(rsp)
+}
+
+// ListUntrustedWithResponse request returning *ListUntrustedResponse
+func (c *ClientWithResponses) ListUntrustedWithResponse(ctx context.Context, credentialType string, reqEditors ...RequestEditorFn) (*ListUntrustedResponse, error) {
+	rsp, err := c.ListUntrusted(ctx, credentialType, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListUntrustedResponse(rsp)
+}
+
+// ParseCreateVPResponse parses an HTTP response from a CreateVPWithResponse call
+func ParseCreateVPResponse(rsp *http.Response) (*CreateVPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateVPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VerifiablePresentation
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2161,22 +2891,22 @@ func ParseSearchIssuedVCsResponse(rsp *http.Response) (*SearchIssuedVCsResponse,
 	return response, nil
 }
 
-// ParseRevokeVCResponse parses an HTTP response from a RevokeVCWithResponse call
-func ParseRevokeVCResponse(rsp *http.Response) (*RevokeVCResponse, error) {
+// ParseListCredentialOffersResponse parses an HTTP response from a ListCredentialOffersWithResponse call
+func ParseListCredentialOffersResponse(rsp *http.Response) (*ListCredentialOffersResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RevokeVCResponse{
+	response := &ListCredentialOffersResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Revocation
+		var dest []PendingCredentialOffer
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2203,27 +2933,55 @@ func ParseRevokeVCResponse(rsp *http.Response) (*RevokeVCResponse, error) {
 	return response, nil
 }
 
-// ParseSearchVCsResponse parses an HTTP response from a SearchVCsWithResponse call
-func ParseSearchVCsResponse(rsp *http.Response) (*SearchVCsResponse, error) {
+// ParseAcceptCredentialOfferResponse parses an HTTP response from a AcceptCredentialOfferWithResponse call
+func ParseAcceptCredentialOfferResponse(rsp *http.Response) (*AcceptCredentialOfferResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SearchVCsResponse{
+	response := &AcceptCredentialOfferResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest SearchVCResults
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
 
+// ParseRejectCredentialOfferResponse parses an HTTP response from a RejectCredentialOfferWithResponse call
+func ParseRejectCredentialOfferResponse(rsp *http.Response) (*RejectCredentialOfferResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RejectCredentialOfferResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -2245,22 +3003,22 @@ func ParseSearchVCsResponse(rsp *http.Response) (*SearchVCsResponse, error) {
 	return response, nil
 }
 
-// ParseResolveVCResponse parses an HTTP response from a ResolveVCWithResponse call
-func ParseResolveVCResponse(rsp *http.Response) (*ResolveVCResponse, error) {
+// ParseGetCredentialsInWalletResponse parses an HTTP response from a GetCredentialsInWalletWithResponse call
+func ParseGetCredentialsInWalletResponse(rsp *http.Response) (*GetCredentialsInWalletResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ResolveVCResponse{
+	response := &GetCredentialsInWalletResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest VerifiableCredential
+		var dest []VerifiableCredential
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2287,15 +3045,15 @@ func ParseResolveVCResponse(rsp *http.Response) (*ResolveVCResponse, error) {
 	return response, nil
 }
 
-// ParseUntrustIssuerResponse parses an HTTP response from a UntrustIssuerWithResponse call
-func ParseUntrustIssuerResponse(rsp *http.Response) (*UntrustIssuerResponse, error) {
+// ParseLoadVCResponse parses an HTTP response from a LoadVCWithResponse call
+func ParseLoadVCResponse(rsp *http.Response) (*LoadVCResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &UntrustIssuerResponse{
+	response := &LoadVCResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -2322,15 +3080,15 @@ func ParseUntrustIssuerResponse(rsp *http.Response) (*UntrustIssuerResponse, err
 	return response, nil
 }
 
-// ParseTrustIssuerResponse parses an HTTP response from a TrustIssuerWithResponse call
-func ParseTrustIssuerResponse(rsp *http.Response) (*TrustIssuerResponse, error) {
+// ParseRemoveCredentialFromWalletResponse parses an HTTP response from a RemoveCredentialFromWalletWithResponse call
+func ParseRemoveCredentialFromWalletResponse(rsp *http.Response) (*RemoveCredentialFromWalletResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TrustIssuerResponse{
+	response := &RemoveCredentialFromWalletResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -2357,22 +3115,22 @@ func ParseTrustIssuerResponse(rsp *http.Response) (*TrustIssuerResponse, error)
 	return response, nil
 }
 
-// ParseVerifyVCResponse parses an HTTP response from a VerifyVCWithResponse call
-func ParseVerifyVCResponse(rsp *http.Response) (*VerifyVCResponse, error) {
+// ParseIssueVCResponse parses an HTTP response from a IssueVCWithResponse call
+func ParseIssueVCResponse(rsp *http.Response) (*IssueVCResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &VerifyVCResponse{
+	response := &IssueVCResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest VCVerificationResult
+		var dest VerifiableCredential
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2399,22 +3157,22 @@ func ParseVerifyVCResponse(rsp *http.Response) (*VerifyVCResponse, error) {
 	return response, nil
 }
 
-// ParseVerifyVPResponse parses an HTTP response from a VerifyVPWithResponse call
-func ParseVerifyVPResponse(rsp *http.Response) (*VerifyVPResponse, error) {
+// ParseSearchIssuedVCsResponse parses an HTTP response from a SearchIssuedVCsWithResponse call
+func ParseSearchIssuedVCsResponse(rsp *http.Response) (*SearchIssuedVCsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &VerifyVPResponse{
+	response := &SearchIssuedVCsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest VPVerificationResult
+		var dest SearchVCResults
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2441,22 +3199,22 @@ func ParseVerifyVPResponse(rsp *http.Response) (*VerifyVPResponse, error) {
 	return response, nil
 }
 
-// ParseListTrustedResponse parses an HTTP response from a ListTrustedWithResponse call
-func ParseListTrustedResponse(rsp *http.Response) (*ListTrustedResponse, error) {
+// ParseRevokeVCResponse parses an HTTP response from a RevokeVCWithResponse call
+func ParseRevokeVCResponse(rsp *http.Response) (*RevokeVCResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListTrustedResponse{
+	response := &RevokeVCResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []string
+		var dest Revocation
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2483,27 +3241,20 @@ func ParseListTrustedResponse(rsp *http.Response) (*ListTrustedResponse, error)
 	return response, nil
 }
 
-// ParseListUntrustedResponse parses an HTTP response from a ListUntrustedWithResponse call
-func ParseListUntrustedResponse(rsp *http.Response) (*ListUntrustedResponse, error) {
+// ParseUnsuspendVCResponse parses an HTTP response from a UnsuspendVCWithResponse call
+func ParseUnsuspendVCResponse(rsp *http.Response) (*UnsuspendVCResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListUntrustedResponse{
+	response := &UnsuspendVCResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []string
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -2525,26 +3276,522 @@ func ParseListUntrustedResponse(rsp *http.Response) (*ListUntrustedResponse, err
 	return response, nil
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Create a new Verifiable Presentation for a set of Verifiable Credentials.
-	// (POST /internal/vcr/v2/holder/vp)
-	CreateVP(ctx echo.Context) error
-	// List all Verifiable Credentials in the holder's wallet.
-	// (GET /internal/vcr/v2/holder/{did}/vc)
-	GetCredentialsInWallet(ctx echo.Context, did string) error
-	// Load a VerifiableCredential into the holders wallet.
-	// (POST /internal/vcr/v2/holder/{did}/vc)
-	LoadVC(ctx echo.Context, did string) error
-	// Issues a new Verifiable Credential
-	// (POST /internal/vcr/v2/issuer/vc)
-	IssueVC(ctx echo.Context) error
-	// Searches for verifiable credentials issued by this node which matches the search params
-	// (GET /internal/vcr/v2/issuer/vc/search)
-	SearchIssuedVCs(ctx echo.Context, params SearchIssuedVCsParams) error
-	// Revoke an issued credential
+// ParseSuspendVCResponse parses an HTTP response from a SuspendVCWithResponse call
+func ParseSuspendVCResponse(rsp *http.Response) (*SuspendVCResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SuspendVCResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListDeferredIssuancesResponse parses an HTTP response from a ListDeferredIssuancesWithResponse call
+func ParseListDeferredIssuancesResponse(rsp *http.Response) (*ListDeferredIssuancesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDeferredIssuancesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []DeferredIssuance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCompleteDeferredIssuanceResponse parses an HTTP response from a CompleteDeferredIssuanceWithResponse call
+func ParseCompleteDeferredIssuanceResponse(rsp *http.Response) (*CompleteDeferredIssuanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CompleteDeferredIssuanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDenyDeferredIssuanceResponse parses an HTTP response from a DenyDeferredIssuanceWithResponse call
+func ParseDenyDeferredIssuanceResponse(rsp *http.Response) (*DenyDeferredIssuanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DenyDeferredIssuanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSearchVCsResponse parses an HTTP response from a SearchVCsWithResponse call
+func ParseSearchVCsResponse(rsp *http.Response) (*SearchVCsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchVCsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchVCResults
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResolveVCResponse parses an HTTP response from a ResolveVCWithResponse call
+func ParseResolveVCResponse(rsp *http.Response) (*ResolveVCResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResolveVCResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VerifiableCredential
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUntrustIssuerResponse parses an HTTP response from a UntrustIssuerWithResponse call
+func ParseUntrustIssuerResponse(rsp *http.Response) (*UntrustIssuerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UntrustIssuerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTrustIssuerResponse parses an HTTP response from a TrustIssuerWithResponse call
+func ParseTrustIssuerResponse(rsp *http.Response) (*TrustIssuerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TrustIssuerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseVerifyVCResponse parses an HTTP response from a VerifyVCWithResponse call
+func ParseVerifyVCResponse(rsp *http.Response) (*VerifyVCResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VerifyVCResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VCVerificationResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseVerifyVPResponse parses an HTTP response from a VerifyVPWithResponse call
+func ParseVerifyVPResponse(rsp *http.Response) (*VerifyVPResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VerifyVPResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VPVerificationResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTrustedResponse parses an HTTP response from a ListTrustedWithResponse call
+func ParseListTrustedResponse(rsp *http.Response) (*ListTrustedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTrustedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListUntrustedResponse parses an HTTP response from a ListUntrustedWithResponse call
+func ParseListUntrustedResponse(rsp *http.Response) (*ListUntrustedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListUntrustedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Create a new Verifiable Presentation for a set of Verifiable Credentials.
+	// (POST /internal/vcr/v2/holder/vp)
+	CreateVP(ctx echo.Context) error
+	// List the credential offers awaiting the holder's consent.
+	// (GET /internal/vcr/v2/holder/{did}/offer)
+	ListCredentialOffers(ctx echo.Context, did string) error
+	// Accept a credential offer, which retrieves the offered credential into the holder's wallet.
+	// (POST /internal/vcr/v2/holder/{did}/offer/{id}/accept)
+	AcceptCredentialOffer(ctx echo.Context, did string, id string) error
+	// Reject a credential offer, which discards it.
+	// (POST /internal/vcr/v2/holder/{did}/offer/{id}/reject)
+	RejectCredentialOffer(ctx echo.Context, did string, id string) error
+	// List all Verifiable Credentials in the holder's wallet.
+	// (GET /internal/vcr/v2/holder/{did}/vc)
+	GetCredentialsInWallet(ctx echo.Context, did string) error
+	// Load a VerifiableCredential into the holders wallet.
+	// (POST /internal/vcr/v2/holder/{did}/vc)
+	LoadVC(ctx echo.Context, did string) error
+	// Remove a VerifiableCredential from the holders wallet.
+	// (DELETE /internal/vcr/v2/holder/{did}/vc/{id})
+	RemoveCredentialFromWallet(ctx echo.Context, did string, id string) error
+	// Issues a new Verifiable Credential
+	// (POST /internal/vcr/v2/issuer/vc)
+	IssueVC(ctx echo.Context) error
+	// Searches for verifiable credentials issued by this node which matches the search params
+	// (GET /internal/vcr/v2/issuer/vc/search)
+	SearchIssuedVCs(ctx echo.Context, params SearchIssuedVCsParams) error
+	// Revoke an issued credential
 	// (DELETE /internal/vcr/v2/issuer/vc/{id})
 	RevokeVC(ctx echo.Context, id string) error
+	// Unsuspend an issued credential
+	// (DELETE /internal/vcr/v2/issuer/vc/{id}/suspension)
+	UnsuspendVC(ctx echo.Context, id string) error
+	// Suspend an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/suspension)
+	SuspendVC(ctx echo.Context, id string) error
+	// List the deferred OpenID4VCI credential issuances of the issuer.
+	// (GET /internal/vcr/v2/issuer/{did}/deferred)
+	ListDeferredIssuances(ctx echo.Context, did string) error
+	// Complete a deferred credential issuance, allowing the wallet to retrieve the credential.
+	// (POST /internal/vcr/v2/issuer/{did}/deferred/{id}/complete)
+	CompleteDeferredIssuance(ctx echo.Context, did string, id string) error
+	// Deny a deferred credential issuance, which discards the issuance.
+	// (POST /internal/vcr/v2/issuer/{did}/deferred/{id}/deny)
+	DenyDeferredIssuance(ctx echo.Context, did string, id string) error
 	// Searches for verifiable credentials that could be used for different use-cases.
 	// (POST /internal/vcr/v2/search)
 	SearchVCs(ctx echo.Context) error
@@ -2571,293 +3818,854 @@ type ServerInterface interface {
 	ListUntrusted(ctx echo.Context, credentialType string) error
 }
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// CreateVP converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateVP(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateVP(ctx)
+	return err
+}
+
+// ListCredentialOffers converts echo context to params.
+func (w *ServerInterfaceWrapper) ListCredentialOffers(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListCredentialOffers(ctx, did)
+	return err
+}
+
+// AcceptCredentialOffer converts echo context to params.
+func (w *ServerInterfaceWrapper) AcceptCredentialOffer(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.AcceptCredentialOffer(ctx, did, id)
+	return err
+}
+
+// RejectCredentialOffer converts echo context to params.
+func (w *ServerInterfaceWrapper) RejectCredentialOffer(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RejectCredentialOffer(ctx, did, id)
+	return err
+}
+
+// GetCredentialsInWallet converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCredentialsInWallet(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCredentialsInWallet(ctx, did)
+	return err
+}
+
+// LoadVC converts echo context to params.
+func (w *ServerInterfaceWrapper) LoadVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.LoadVC(ctx, did)
+	return err
+}
+
+// RemoveCredentialFromWallet converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveCredentialFromWallet(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemoveCredentialFromWallet(ctx, did, id)
+	return err
+}
+
+// IssueVC converts echo context to params.
+func (w *ServerInterfaceWrapper) IssueVC(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.IssueVC(ctx)
+	return err
+}
+
+// SearchIssuedVCs converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchIssuedVCs(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchIssuedVCsParams
+	// ------------- Required query parameter "credentialType" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "credentialType", ctx.QueryParams(), &params.CredentialType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialType: %s", err))
+	}
+
+	// ------------- Required query parameter "issuer" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "issuer", ctx.QueryParams(), &params.Issuer)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter issuer: %s", err))
+	}
+
+	// ------------- Optional query parameter "subject" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subject", ctx.QueryParams(), &params.Subject)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subject: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SearchIssuedVCs(ctx, params)
+	return err
+}
+
+// RevokeVC converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeVC(ctx, id)
+	return err
+}
+
+// UnsuspendVC converts echo context to params.
+func (w *ServerInterfaceWrapper) UnsuspendVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UnsuspendVC(ctx, id)
+	return err
+}
+
+// SuspendVC converts echo context to params.
+func (w *ServerInterfaceWrapper) SuspendVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SuspendVC(ctx, id)
+	return err
+}
+
+// ListDeferredIssuances converts echo context to params.
+func (w *ServerInterfaceWrapper) ListDeferredIssuances(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListDeferredIssuances(ctx, did)
+	return err
+}
+
+// CompleteDeferredIssuance converts echo context to params.
+func (w *ServerInterfaceWrapper) CompleteDeferredIssuance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CompleteDeferredIssuance(ctx, did, id)
+	return err
+}
+
+// DenyDeferredIssuance converts echo context to params.
+func (w *ServerInterfaceWrapper) DenyDeferredIssuance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DenyDeferredIssuance(ctx, did, id)
+	return err
+}
+
+// SearchVCs converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchVCs(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SearchVCs(ctx)
+	return err
+}
+
+// ResolveVC converts echo context to params.
+func (w *ServerInterfaceWrapper) ResolveVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ResolveVC(ctx, id)
+	return err
+}
+
+// UntrustIssuer converts echo context to params.
+func (w *ServerInterfaceWrapper) UntrustIssuer(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UntrustIssuer(ctx)
+	return err
 }
 
-// CreateVP converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateVP(ctx echo.Context) error {
+// TrustIssuer converts echo context to params.
+func (w *ServerInterfaceWrapper) TrustIssuer(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateVP(ctx)
+	err = w.Handler.TrustIssuer(ctx)
 	return err
 }
 
-// GetCredentialsInWallet converts echo context to params.
-func (w *ServerInterfaceWrapper) GetCredentialsInWallet(ctx echo.Context) error {
+// VerifyVC converts echo context to params.
+func (w *ServerInterfaceWrapper) VerifyVC(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "did" -------------
-	var did string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
-	}
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.VerifyVC(ctx)
+	return err
+}
+
+// VerifyVP converts echo context to params.
+func (w *ServerInterfaceWrapper) VerifyVP(ctx echo.Context) error {
+	var err error
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetCredentialsInWallet(ctx, did)
+	err = w.Handler.VerifyVP(ctx)
 	return err
 }
 
-// LoadVC converts echo context to params.
-func (w *ServerInterfaceWrapper) LoadVC(ctx echo.Context) error {
+// ListTrusted converts echo context to params.
+func (w *ServerInterfaceWrapper) ListTrusted(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "did" -------------
-	var did string
+	// ------------- Path parameter "credentialType" -------------
+	var credentialType string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, ctx.Param("did"), &did)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "credentialType", runtime.ParamLocationPath, ctx.Param("credentialType"), &credentialType)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialType: %s", err))
 	}
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.LoadVC(ctx, did)
+	err = w.Handler.ListTrusted(ctx, credentialType)
 	return err
 }
 
-// IssueVC converts echo context to params.
-func (w *ServerInterfaceWrapper) IssueVC(ctx echo.Context) error {
+// ListUntrusted converts echo context to params.
+func (w *ServerInterfaceWrapper) ListUntrusted(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "credentialType" -------------
+	var credentialType string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "credentialType", runtime.ParamLocationPath, ctx.Param("credentialType"), &credentialType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialType: %s", err))
+	}
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.IssueVC(ctx)
+	err = w.Handler.ListUntrusted(ctx, credentialType)
 	return err
 }
 
-// SearchIssuedVCs converts echo context to params.
-func (w *ServerInterfaceWrapper) SearchIssuedVCs(ctx echo.Context) error {
-	var err error
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params SearchIssuedVCsParams
-	// ------------- Required query parameter "credentialType" -------------
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
 
-	err = runtime.BindQueryParameter("form", true, true, "credentialType", ctx.QueryParams(), &params.CredentialType)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialType: %s", err))
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
 	}
 
-	// ------------- Required query parameter "issuer" -------------
+	router.POST(baseURL+"/internal/vcr/v2/holder/vp", wrapper.CreateVP)
+	router.GET(baseURL+"/internal/vcr/v2/holder/:did/offer", wrapper.ListCredentialOffers)
+	router.POST(baseURL+"/internal/vcr/v2/holder/:did/offer/:id/accept", wrapper.AcceptCredentialOffer)
+	router.POST(baseURL+"/internal/vcr/v2/holder/:did/offer/:id/reject", wrapper.RejectCredentialOffer)
+	router.GET(baseURL+"/internal/vcr/v2/holder/:did/vc", wrapper.GetCredentialsInWallet)
+	router.POST(baseURL+"/internal/vcr/v2/holder/:did/vc", wrapper.LoadVC)
+	router.DELETE(baseURL+"/internal/vcr/v2/holder/:did/vc/:id", wrapper.RemoveCredentialFromWallet)
+	router.POST(baseURL+"/internal/vcr/v2/issuer/vc", wrapper.IssueVC)
+	router.GET(baseURL+"/internal/vcr/v2/issuer/vc/search", wrapper.SearchIssuedVCs)
+	router.DELETE(baseURL+"/internal/vcr/v2/issuer/vc/:id", wrapper.RevokeVC)
+	router.DELETE(baseURL+"/internal/vcr/v2/issuer/vc/:id/suspension", wrapper.UnsuspendVC)
+	router.PUT(baseURL+"/internal/vcr/v2/issuer/vc/:id/suspension", wrapper.SuspendVC)
+	router.GET(baseURL+"/internal/vcr/v2/issuer/:did/deferred", wrapper.ListDeferredIssuances)
+	router.POST(baseURL+"/internal/vcr/v2/issuer/:did/deferred/:id/complete", wrapper.CompleteDeferredIssuance)
+	router.POST(baseURL+"/internal/vcr/v2/issuer/:did/deferred/:id/deny", wrapper.DenyDeferredIssuance)
+	router.POST(baseURL+"/internal/vcr/v2/search", wrapper.SearchVCs)
+	router.GET(baseURL+"/internal/vcr/v2/vc/:id", wrapper.ResolveVC)
+	router.DELETE(baseURL+"/internal/vcr/v2/verifier/trust", wrapper.UntrustIssuer)
+	router.POST(baseURL+"/internal/vcr/v2/verifier/trust", wrapper.TrustIssuer)
+	router.POST(baseURL+"/internal/vcr/v2/verifier/vc", wrapper.VerifyVC)
+	router.POST(baseURL+"/internal/vcr/v2/verifier/vp", wrapper.VerifyVP)
+	router.GET(baseURL+"/internal/vcr/v2/verifier/:credentialType/trusted", wrapper.ListTrusted)
+	router.GET(baseURL+"/internal/vcr/v2/verifier/:credentialType/untrusted", wrapper.ListUntrusted)
 
-	err = runtime.BindQueryParameter("form", true, true, "issuer", ctx.QueryParams(), &params.Issuer)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter issuer: %s", err))
+}
+
+type CreateVPRequestObject struct {
+	Body *CreateVPJSONRequestBody
+}
+
+type CreateVPResponseObject interface {
+	VisitCreateVPResponse(w http.ResponseWriter) error
+}
+
+type CreateVP200JSONResponse VerifiablePresentation
+
+func (response CreateVP200JSONResponse) VisitCreateVPResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVPdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response CreateVPdefaultApplicationProblemPlusJSONResponse) VisitCreateVPResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListCredentialOffersRequestObject struct {
+	Did string `json:"did"`
+}
+
+type ListCredentialOffersResponseObject interface {
+	VisitListCredentialOffersResponse(w http.ResponseWriter) error
+}
+
+type ListCredentialOffers200JSONResponse []PendingCredentialOffer
+
+func (response ListCredentialOffers200JSONResponse) VisitListCredentialOffersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCredentialOffersdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response ListCredentialOffersdefaultApplicationProblemPlusJSONResponse) VisitListCredentialOffersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AcceptCredentialOfferRequestObject struct {
+	Did string `json:"did"`
+	Id  string `json:"id"`
+}
+
+type AcceptCredentialOfferResponseObject interface {
+	VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error
+}
+
+type AcceptCredentialOffer204Response struct {
+}
+
+func (response AcceptCredentialOffer204Response) VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AcceptCredentialOfferdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response AcceptCredentialOfferdefaultApplicationProblemPlusJSONResponse) VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RejectCredentialOfferRequestObject struct {
+	Did string `json:"did"`
+	Id  string `json:"id"`
+}
+
+type RejectCredentialOfferResponseObject interface {
+	VisitRejectCredentialOfferResponse(w http.ResponseWriter) error
+}
+
+type RejectCredentialOffer204Response struct {
+}
+
+func (response RejectCredentialOffer204Response) VisitRejectCredentialOfferResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RejectCredentialOfferdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
+	StatusCode int
+}
+
+func (response RejectCredentialOfferdefaultApplicationProblemPlusJSONResponse) VisitRejectCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCredentialsInWalletRequestObject struct {
+	Did string `json:"did"`
+}
 
-	// ------------- Optional query parameter "subject" -------------
+type GetCredentialsInWalletResponseObject interface {
+	VisitGetCredentialsInWalletResponse(w http.ResponseWriter) error
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "subject", ctx.QueryParams(), &params.Subject)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subject: %s", err))
-	}
+type GetCredentialsInWallet200JSONResponse []VerifiableCredential
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.SearchIssuedVCs(ctx, params)
-	return err
+func (response GetCredentialsInWallet200JSONResponse) VisitGetCredentialsInWalletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// RevokeVC converts echo context to params.
-func (w *ServerInterfaceWrapper) RevokeVC(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id string
+type GetCredentialsInWalletdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
+	StatusCode int
+}
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+func (response GetCredentialsInWalletdefaultApplicationProblemPlusJSONResponse) VisitGetCredentialsInWalletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.RevokeVC(ctx, id)
-	return err
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-// SearchVCs converts echo context to params.
-func (w *ServerInterfaceWrapper) SearchVCs(ctx echo.Context) error {
-	var err error
+type LoadVCRequestObject struct {
+	Did  string `json:"did"`
+	Body *LoadVCJSONRequestBody
+}
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+type LoadVCResponseObject interface {
+	VisitLoadVCResponse(w http.ResponseWriter) error
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.SearchVCs(ctx)
-	return err
+type LoadVC204Response struct {
 }
 
-// ResolveVC converts echo context to params.
-func (w *ServerInterfaceWrapper) ResolveVC(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id string
+func (response LoadVC204Response) VisitLoadVCResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
-	}
+type LoadVCdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ResolveVC(ctx, id)
-	return err
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
 }
 
-// UntrustIssuer converts echo context to params.
-func (w *ServerInterfaceWrapper) UntrustIssuer(ctx echo.Context) error {
-	var err error
+func (response LoadVCdefaultApplicationProblemPlusJSONResponse) VisitLoadVCResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+	return json.NewEncoder(w).Encode(response.Body)
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.UntrustIssuer(ctx)
-	return err
+type RemoveCredentialFromWalletRequestObject struct {
+	Did string `json:"did"`
+	Id  string `json:"id"`
 }
 
-// TrustIssuer converts echo context to params.
-func (w *ServerInterfaceWrapper) TrustIssuer(ctx echo.Context) error {
-	var err error
+type RemoveCredentialFromWalletResponseObject interface {
+	VisitRemoveCredentialFromWalletResponse(w http.ResponseWriter) error
+}
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+type RemoveCredentialFromWallet204Response struct {
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.TrustIssuer(ctx)
-	return err
+func (response RemoveCredentialFromWallet204Response) VisitRemoveCredentialFromWalletResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-// VerifyVC converts echo context to params.
-func (w *ServerInterfaceWrapper) VerifyVC(ctx echo.Context) error {
-	var err error
+type RemoveCredentialFromWalletdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.VerifyVC(ctx)
-	return err
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
 }
 
-// VerifyVP converts echo context to params.
-func (w *ServerInterfaceWrapper) VerifyVP(ctx echo.Context) error {
-	var err error
+func (response RemoveCredentialFromWalletdefaultApplicationProblemPlusJSONResponse) VisitRemoveCredentialFromWalletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+	return json.NewEncoder(w).Encode(response.Body)
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.VerifyVP(ctx)
-	return err
+type IssueVCRequestObject struct {
+	Body *IssueVCJSONRequestBody
 }
 
-// ListTrusted converts echo context to params.
-func (w *ServerInterfaceWrapper) ListTrusted(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "credentialType" -------------
-	var credentialType string
+type IssueVCResponseObject interface {
+	VisitIssueVCResponse(w http.ResponseWriter) error
+}
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "credentialType", runtime.ParamLocationPath, ctx.Param("credentialType"), &credentialType)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialType: %s", err))
-	}
+type IssueVC200JSONResponse VerifiableCredential
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+func (response IssueVC200JSONResponse) VisitIssueVCResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListTrusted(ctx, credentialType)
-	return err
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ListUntrusted converts echo context to params.
-func (w *ServerInterfaceWrapper) ListUntrusted(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "credentialType" -------------
-	var credentialType string
+type IssueVCdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "credentialType", runtime.ParamLocationPath, ctx.Param("credentialType"), &credentialType)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialType: %s", err))
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
+	StatusCode int
+}
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+func (response IssueVCdefaultApplicationProblemPlusJSONResponse) VisitIssueVCResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListUntrusted(ctx, credentialType)
-	return err
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+type SearchIssuedVCsRequestObject struct {
+	Params SearchIssuedVCsParams
 }
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+type SearchIssuedVCsResponseObject interface {
+	VisitSearchIssuedVCsResponse(w http.ResponseWriter) error
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+type SearchIssuedVCs200JSONResponse SearchVCResults
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+func (response SearchIssuedVCs200JSONResponse) VisitSearchIssuedVCsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchIssuedVCsdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
+	StatusCode int
+}
 
-	router.POST(baseURL+"/internal/vcr/v2/holder/vp", wrapper.CreateVP)
-	router.GET(baseURL+"/internal/vcr/v2/holder/:did/vc", wrapper.GetCredentialsInWallet)
-	router.POST(baseURL+"/internal/vcr/v2/holder/:did/vc", wrapper.LoadVC)
-	router.POST(baseURL+"/internal/vcr/v2/issuer/vc", wrapper.IssueVC)
-	router.GET(baseURL+"/internal/vcr/v2/issuer/vc/search", wrapper.SearchIssuedVCs)
-	router.DELETE(baseURL+"/internal/vcr/v2/issuer/vc/:id", wrapper.RevokeVC)
-	router.POST(baseURL+"/internal/vcr/v2/search", wrapper.SearchVCs)
-	router.GET(baseURL+"/internal/vcr/v2/vc/:id", wrapper.ResolveVC)
-	router.DELETE(baseURL+"/internal/vcr/v2/verifier/trust", wrapper.UntrustIssuer)
-	router.POST(baseURL+"/internal/vcr/v2/verifier/trust", wrapper.TrustIssuer)
-	router.POST(baseURL+"/internal/vcr/v2/verifier/vc", wrapper.VerifyVC)
-	router.POST(baseURL+"/internal/vcr/v2/verifier/vp", wrapper.VerifyVP)
-	router.GET(baseURL+"/internal/vcr/v2/verifier/:credentialType/trusted", wrapper.ListTrusted)
-	router.GET(baseURL+"/internal/vcr/v2/verifier/:credentialType/untrusted", wrapper.ListUntrusted)
+func (response SearchIssuedVCsdefaultApplicationProblemPlusJSONResponse) VisitSearchIssuedVCsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateVPRequestObject struct {
-	Body *CreateVPJSONRequestBody
+type RevokeVCRequestObject struct {
+	Id string `json:"id"`
 }
 
-type CreateVPResponseObject interface {
-	VisitCreateVPResponse(w http.ResponseWriter) error
+type RevokeVCResponseObject interface {
+	VisitRevokeVCResponse(w http.ResponseWriter) error
 }
 
-type CreateVP200JSONResponse VerifiablePresentation
+type RevokeVC200JSONResponse Revocation
 
-func (response CreateVP200JSONResponse) VisitCreateVPResponse(w http.ResponseWriter) error {
+func (response RevokeVC200JSONResponse) VisitRevokeVCResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateVPdefaultApplicationProblemPlusJSONResponse struct {
+type RevokeVC204Response struct {
+}
+
+func (response RevokeVC204Response) VisitRevokeVCResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeVCdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -2871,31 +4679,30 @@ type CreateVPdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response CreateVPdefaultApplicationProblemPlusJSONResponse) VisitCreateVPResponse(w http.ResponseWriter) error {
+func (response RevokeVCdefaultApplicationProblemPlusJSONResponse) VisitRevokeVCResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetCredentialsInWalletRequestObject struct {
-	Did string `json:"did"`
+type UnsuspendVCRequestObject struct {
+	Id string `json:"id"`
 }
 
-type GetCredentialsInWalletResponseObject interface {
-	VisitGetCredentialsInWalletResponse(w http.ResponseWriter) error
+type UnsuspendVCResponseObject interface {
+	VisitUnsuspendVCResponse(w http.ResponseWriter) error
 }
 
-type GetCredentialsInWallet200JSONResponse []VerifiableCredential
-
-func (response GetCredentialsInWallet200JSONResponse) VisitGetCredentialsInWalletResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type UnsuspendVC204Response struct {
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (response UnsuspendVC204Response) VisitUnsuspendVCResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-type GetCredentialsInWalletdefaultApplicationProblemPlusJSONResponse struct {
+type UnsuspendVCdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -2909,31 +4716,30 @@ type GetCredentialsInWalletdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response GetCredentialsInWalletdefaultApplicationProblemPlusJSONResponse) VisitGetCredentialsInWalletResponse(w http.ResponseWriter) error {
+func (response UnsuspendVCdefaultApplicationProblemPlusJSONResponse) VisitUnsuspendVCResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type LoadVCRequestObject struct {
-	Did  string `json:"did"`
-	Body *LoadVCJSONRequestBody
+type SuspendVCRequestObject struct {
+	Id string `json:"id"`
 }
 
-type LoadVCResponseObject interface {
-	VisitLoadVCResponse(w http.ResponseWriter) error
+type SuspendVCResponseObject interface {
+	VisitSuspendVCResponse(w http.ResponseWriter) error
 }
 
-type LoadVC204Response struct {
+type SuspendVC204Response struct {
 }
 
-func (response LoadVC204Response) VisitLoadVCResponse(w http.ResponseWriter) error {
+func (response SuspendVC204Response) VisitSuspendVCResponse(w http.ResponseWriter) error {
 	w.WriteHeader(204)
 	return nil
 }
 
-type LoadVCdefaultApplicationProblemPlusJSONResponse struct {
+type SuspendVCdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -2947,31 +4753,31 @@ type LoadVCdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response LoadVCdefaultApplicationProblemPlusJSONResponse) VisitLoadVCResponse(w http.ResponseWriter) error {
+func (response SuspendVCdefaultApplicationProblemPlusJSONResponse) VisitSuspendVCResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type IssueVCRequestObject struct {
-	Body *IssueVCJSONRequestBody
+type ListDeferredIssuancesRequestObject struct {
+	Did string `json:"did"`
 }
 
-type IssueVCResponseObject interface {
-	VisitIssueVCResponse(w http.ResponseWriter) error
+type ListDeferredIssuancesResponseObject interface {
+	VisitListDeferredIssuancesResponse(w http.ResponseWriter) error
 }
 
-type IssueVC200JSONResponse VerifiableCredential
+type ListDeferredIssuances200JSONResponse []DeferredIssuance
 
-func (response IssueVC200JSONResponse) VisitIssueVCResponse(w http.ResponseWriter) error {
+func (response ListDeferredIssuances200JSONResponse) VisitListDeferredIssuancesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type IssueVCdefaultApplicationProblemPlusJSONResponse struct {
+type ListDeferredIssuancesdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -2985,31 +4791,31 @@ type IssueVCdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response IssueVCdefaultApplicationProblemPlusJSONResponse) VisitIssueVCResponse(w http.ResponseWriter) error {
+func (response ListDeferredIssuancesdefaultApplicationProblemPlusJSONResponse) VisitListDeferredIssuancesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type SearchIssuedVCsRequestObject struct {
-	Params SearchIssuedVCsParams
+type CompleteDeferredIssuanceRequestObject struct {
+	Did string `json:"did"`
+	Id  string `json:"id"`
 }
 
-type SearchIssuedVCsResponseObject interface {
-	VisitSearchIssuedVCsResponse(w http.ResponseWriter) error
+type CompleteDeferredIssuanceResponseObject interface {
+	VisitCompleteDeferredIssuanceResponse(w http.ResponseWriter) error
 }
 
-type SearchIssuedVCs200JSONResponse SearchVCResults
-
-func (response SearchIssuedVCs200JSONResponse) VisitSearchIssuedVCsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type CompleteDeferredIssuance204Response struct {
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (response CompleteDeferredIssuance204Response) VisitCompleteDeferredIssuanceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-type SearchIssuedVCsdefaultApplicationProblemPlusJSONResponse struct {
+type CompleteDeferredIssuancedefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -3023,39 +4829,31 @@ type SearchIssuedVCsdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response SearchIssuedVCsdefaultApplicationProblemPlusJSONResponse) VisitSearchIssuedVCsResponse(w http.ResponseWriter) error {
+func (response CompleteDeferredIssuancedefaultApplicationProblemPlusJSONResponse) VisitCompleteDeferredIssuanceResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type RevokeVCRequestObject struct {
-	Id string `json:"id"`
-}
-
-type RevokeVCResponseObject interface {
-	VisitRevokeVCResponse(w http.ResponseWriter) error
+type DenyDeferredIssuanceRequestObject struct {
+	Did string `json:"did"`
+	Id  string `json:"id"`
 }
 
-type RevokeVC200JSONResponse Revocation
-
-func (response RevokeVC200JSONResponse) VisitRevokeVCResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+type DenyDeferredIssuanceResponseObject interface {
+	VisitDenyDeferredIssuanceResponse(w http.ResponseWriter) error
 }
 
-type RevokeVC204Response struct {
+type DenyDeferredIssuance204Response struct {
 }
 
-func (response RevokeVC204Response) VisitRevokeVCResponse(w http.ResponseWriter) error {
+func (response DenyDeferredIssuance204Response) VisitDenyDeferredIssuanceResponse(w http.ResponseWriter) error {
 	w.WriteHeader(204)
 	return nil
 }
 
-type RevokeVCdefaultApplicationProblemPlusJSONResponse struct {
+type DenyDeferredIssuancedefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -3069,7 +4867,7 @@ type RevokeVCdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response RevokeVCdefaultApplicationProblemPlusJSONResponse) VisitRevokeVCResponse(w http.ResponseWriter) error {
+func (response DenyDeferredIssuancedefaultApplicationProblemPlusJSONResponse) VisitDenyDeferredIssuanceResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
@@ -3383,12 +5181,24 @@ type StrictServerInterface interface {
 	// Create a new Verifiable Presentation for a set of Verifiable Credentials.
 	// (POST /internal/vcr/v2/holder/vp)
 	CreateVP(ctx context.Context, request CreateVPRequestObject) (CreateVPResponseObject, error)
+	// List the credential offers awaiting the holder's consent.
+	// (GET /internal/vcr/v2/holder/{did}/offer)
+	ListCredentialOffers(ctx context.Context, request ListCredentialOffersRequestObject) (ListCredentialOffersResponseObject, error)
+	// Accept a credential offer, which retrieves the offered credential into the holder's wallet.
+	// (POST /internal/vcr/v2/holder/{did}/offer/{id}/accept)
+	AcceptCredentialOffer(ctx context.Context, request AcceptCredentialOfferRequestObject) (AcceptCredentialOfferResponseObject, error)
+	// Reject a credential offer, which discards it.
+	// (POST /internal/vcr/v2/holder/{did}/offer/{id}/reject)
+	RejectCredentialOffer(ctx context.Context, request RejectCredentialOfferRequestObject) (RejectCredentialOfferResponseObject, error)
 	// List all Verifiable Credentials in the holder's wallet.
 	// (GET /internal/vcr/v2/holder/{did}/vc)
 	GetCredentialsInWallet(ctx context.Context, request GetCredentialsInWalletRequestObject) (GetCredentialsInWalletResponseObject, error)
 	// Load a VerifiableCredential into the holders wallet.
 	// (POST /internal/vcr/v2/holder/{did}/vc)
 	LoadVC(ctx context.Context, request LoadVCRequestObject) (LoadVCResponseObject, error)
+	// Remove a VerifiableCredential from the holders wallet.
+	// (DELETE /internal/vcr/v2/holder/{did}/vc/{id})
+	RemoveCredentialFromWallet(ctx context.Context, request RemoveCredentialFromWalletRequestObject) (RemoveCredentialFromWalletResponseObject, error)
 	// Issues a new Verifiable Credential
 	// (POST /internal/vcr/v2/issuer/vc)
 	IssueVC(ctx context.Context, request IssueVCRequestObject) (IssueVCResponseObject, error)
@@ -3398,6 +5208,21 @@ type StrictServerInterface interface {
 	// Revoke an issued credential
 	// (DELETE /internal/vcr/v2/issuer/vc/{id})
 	RevokeVC(ctx context.Context, request RevokeVCRequestObject) (RevokeVCResponseObject, error)
+	// Unsuspend an issued credential
+	// (DELETE /internal/vcr/v2/issuer/vc/{id}/suspension)
+	UnsuspendVC(ctx context.Context, request UnsuspendVCRequestObject) (UnsuspendVCResponseObject, error)
+	// Suspend an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/suspension)
+	SuspendVC(ctx context.Context, request SuspendVCRequestObject) (SuspendVCResponseObject, error)
+	// List the deferred OpenID4VCI credential issuances of the issuer.
+	// (GET /internal/vcr/v2/issuer/{did}/deferred)
+	ListDeferredIssuances(ctx context.Context, request ListDeferredIssuancesRequestObject) (ListDeferredIssuancesResponseObject, error)
+	// Complete a deferred credential issuance, allowing the wallet to retrieve the credential.
+	// (POST /internal/vcr/v2/issuer/{did}/deferred/{id}/complete)
+	CompleteDeferredIssuance(ctx context.Context, request CompleteDeferredIssuanceRequestObject) (CompleteDeferredIssuanceResponseObject, error)
+	// Deny a deferred credential issuance, which discards the issuance.
+	// (POST /internal/vcr/v2/issuer/{did}/deferred/{id}/deny)
+	DenyDeferredIssuance(ctx context.Context, request DenyDeferredIssuanceRequestObject) (DenyDeferredIssuanceResponseObject, error)
 	// Searches for verifiable credentials that could be used for different use-cases.
 	// (POST /internal/vcr/v2/search)
 	SearchVCs(ctx context.Context, request SearchVCsRequestObject) (SearchVCsResponseObject, error)
@@ -3465,6 +5290,83 @@ func (sh *strictHandler) CreateVP(ctx echo.Context) error {
 	return nil
 }
 
+// ListCredentialOffers operation middleware
+func (sh *strictHandler) ListCredentialOffers(ctx echo.Context, did string) error {
+	var request ListCredentialOffersRequestObject
+
+	request.Did = did
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCredentialOffers(ctx.Request().Context(), request.(ListCredentialOffersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCredentialOffers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListCredentialOffersResponseObject); ok {
+		return validResponse.VisitListCredentialOffersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// AcceptCredentialOffer operation middleware
+func (sh *strictHandler) AcceptCredentialOffer(ctx echo.Context, did string, id string) error {
+	var request AcceptCredentialOfferRequestObject
+
+	request.Did = did
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AcceptCredentialOffer(ctx.Request().Context(), request.(AcceptCredentialOfferRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AcceptCredentialOffer")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(AcceptCredentialOfferResponseObject); ok {
+		return validResponse.VisitAcceptCredentialOfferResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RejectCredentialOffer operation middleware
+func (sh *strictHandler) RejectCredentialOffer(ctx echo.Context, did string, id string) error {
+	var request RejectCredentialOfferRequestObject
+
+	request.Did = did
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RejectCredentialOffer(ctx.Request().Context(), request.(RejectCredentialOfferRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RejectCredentialOffer")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RejectCredentialOfferResponseObject); ok {
+		return validResponse.VisitRejectCredentialOfferResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetCredentialsInWallet operation middleware
 func (sh *strictHandler) GetCredentialsInWallet(ctx echo.Context, did string) error {
 	var request GetCredentialsInWalletRequestObject
@@ -3521,6 +5423,32 @@ func (sh *strictHandler) LoadVC(ctx echo.Context, did string) error {
 	return nil
 }
 
+// RemoveCredentialFromWallet operation middleware
+func (sh *strictHandler) RemoveCredentialFromWallet(ctx echo.Context, did string, id string) error {
+	var request RemoveCredentialFromWalletRequestObject
+
+	request.Did = did
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveCredentialFromWallet(ctx.Request().Context(), request.(RemoveCredentialFromWalletRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveCredentialFromWallet")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RemoveCredentialFromWalletResponseObject); ok {
+		return validResponse.VisitRemoveCredentialFromWalletResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // IssueVC operation middleware
 func (sh *strictHandler) IssueVC(ctx echo.Context) error {
 	var request IssueVCRequestObject
@@ -3600,6 +5528,133 @@ func (sh *strictHandler) RevokeVC(ctx echo.Context, id string) error {
 	return nil
 }
 
+// UnsuspendVC operation middleware
+func (sh *strictHandler) UnsuspendVC(ctx echo.Context, id string) error {
+	var request UnsuspendVCRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UnsuspendVC(ctx.Request().Context(), request.(UnsuspendVCRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnsuspendVC")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UnsuspendVCResponseObject); ok {
+		return validResponse.VisitUnsuspendVCResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SuspendVC operation middleware
+func (sh *strictHandler) SuspendVC(ctx echo.Context, id string) error {
+	var request SuspendVCRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SuspendVC(ctx.Request().Context(), request.(SuspendVCRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SuspendVC")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SuspendVCResponseObject); ok {
+		return validResponse.VisitSuspendVCResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListDeferredIssuances operation middleware
+func (sh *strictHandler) ListDeferredIssuances(ctx echo.Context, did string) error {
+	var request ListDeferredIssuancesRequestObject
+
+	request.Did = did
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDeferredIssuances(ctx.Request().Context(), request.(ListDeferredIssuancesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDeferredIssuances")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListDeferredIssuancesResponseObject); ok {
+		return validResponse.VisitListDeferredIssuancesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CompleteDeferredIssuance operation middleware
+func (sh *strictHandler) CompleteDeferredIssuance(ctx echo.Context, did string, id string) error {
+	var request CompleteDeferredIssuanceRequestObject
+
+	request.Did = did
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CompleteDeferredIssuance(ctx.Request().Context(), request.(CompleteDeferredIssuanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CompleteDeferredIssuance")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CompleteDeferredIssuanceResponseObject); ok {
+		return validResponse.VisitCompleteDeferredIssuanceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DenyDeferredIssuance operation middleware
+func (sh *strictHandler) DenyDeferredIssuance(ctx echo.Context, did string, id string) error {
+	var request DenyDeferredIssuanceRequestObject
+
+	request.Did = did
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DenyDeferredIssuance(ctx.Request().Context(), request.(DenyDeferredIssuanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DenyDeferredIssuance")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DenyDeferredIssuanceResponseObject); ok {
+		return validResponse.VisitDenyDeferredIssuanceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // SearchVCs operation middleware
 func (sh *strictHandler) SearchVCs(ctx echo.Context) error {
 	var request SearchVCsRequestObject
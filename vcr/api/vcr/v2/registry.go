@@ -40,7 +40,7 @@ func (w *Wrapper) ResolveVC(ctx context.Context, request ResolveVCRequestObject)
 	}
 	result, err := w.VCR.Resolve(*vcID, nil)
 	if result != nil {
-		// When err != nil credential is untrusted or revoked, credential is still returned.
+		// When err != nil credential is untrusted, revoked or suspended, credential is still returned.
 		// This API call must return the VC regardless its status: https://github.com/nuts-foundation/nuts-node/issues/1221
 		return ResolveVC200JSONResponse(*result), nil
 	}
@@ -23,6 +23,8 @@ import (
 	"encoding/json"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/holder"
+	"github.com/nuts-foundation/nuts-node/vcr/issuer"
 )
 
 // VerifiableCredential is an alias to use from within the API
@@ -37,6 +39,12 @@ type Revocation = credential.Revocation
 // VerifiablePresentation is an alias to use from within the API
 type VerifiablePresentation = vc.VerifiablePresentation
 
+// PendingCredentialOffer is an alias to use from within the API
+type PendingCredentialOffer = holder.PendingCredentialOffer
+
+// DeferredIssuance is an alias to use from within the API
+type DeferredIssuance = issuer.DeferredIssuance
+
 var _ json.Marshaler = (*IssueVC200JSONResponse)(nil)
 var _ json.Marshaler = (*ResolveVC200JSONResponse)(nil)
 var _ json.Marshaler = (*CreateVP200JSONResponse)(nil)
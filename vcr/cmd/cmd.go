@@ -37,9 +37,18 @@ import (
 func FlagSet() *pflag.FlagSet {
 	defs := vcr.DefaultConfig()
 	flagSet := pflag.NewFlagSet("vcr", pflag.ContinueOnError)
+	flagSet.StringSlice("vcr.openid4vci.consent.autoacceptissuers", defs.OpenID4VCI.Consent.AutoAcceptIssuers, "DIDs of issuers whose credential offers are accepted without the holder's consent.")
+	flagSet.StringSlice("vcr.openid4vci.consent.autoaccepttypes", defs.OpenID4VCI.Consent.AutoAcceptTypes, "Credential types of which offers are accepted without the holder's consent.")
+	flagSet.Duration("vcr.openid4vci.consent.expiry", defs.OpenID4VCI.Consent.Expiry, "Time after which credential offers that weren't accepted or rejected by the holder are discarded.")
+	flagSet.Int("vcr.openid4vci.consent.maxpending", defs.OpenID4VCI.Consent.MaxPending, "Maximum number of credential offers per holder awaiting the holder's consent. Further offers are refused until pending offers are answered or expired. Not limited if 0.")
+	flagSet.Bool("vcr.openid4vci.consent.required", defs.OpenID4VCI.Consent.Required, "Require the holder to accept incoming OpenID4VCI credential offers (through the API) before the credential is retrieved, unless auto-accepted by issuer or credential type.")
+	flagSet.Duration("vcr.openid4vci.deferred.interval", defs.OpenID4VCI.Deferred.Interval, "Interval at which the holder tries to retrieve credentials of which issuance was deferred by the issuer, e.g. 1m. Disabled if 0.")
+	flagSet.StringSlice("vcr.openid4vci.deferred.types", defs.OpenID4VCI.Deferred.Types, "Credential types of which issuance over OpenID4VCI is deferred, until it's completed or denied through the issuer API.")
 	flagSet.String("vcr.openid4vci.definitionsdir", defs.OpenID4VCI.DefinitionsDIR, "Directory with the additional credential definitions the node could issue (experimental, may change without notice).")
 	flagSet.Bool("vcr.openid4vci.enabled", defs.OpenID4VCI.Enabled, "Enable issuing and receiving credentials over OpenID4VCI.")
 	flagSet.Duration("vcr.openid4vci.timeout", time.Second*30, "Time-out for OpenID4VCI HTTP client operations.")
+	flagSet.String("vcr.wallet.sweep.action", defs.Wallet.Sweep.Action, "Action on expired or revoked wallet credentials: 'flag' excludes them from presentations, 'remove' deletes them from the wallet.")
+	flagSet.Duration("vcr.wallet.sweep.interval", defs.Wallet.Sweep.Interval, "Interval at which wallet credentials are checked for expiry and revocation, e.g. 1h. Disabled if 0.")
 	return flagSet
 }
 
@@ -54,6 +63,8 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(listTrustedCmd())
 	cmd.AddCommand(listUntrustedCmd())
 	cmd.AddCommand(issueVC())
+	cmd.AddCommand(suspendCmd())
+	cmd.AddCommand(unsuspendCmd())
 	return cmd
 }
 
@@ -187,6 +198,47 @@ func issueVC() *cobra.Command {
 	return result
 }
 
+func suspendCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "suspend [id]",
+		Short: "Suspends an issued Verifiable Credential",
+		Long: "Suspends an issued Verifiable Credential by setting its suspension bit on the status list. " +
+			"Only credentials issued with a credentialStatus with statusPurpose 'suspension' can be suspended. " +
+			"The suspension can be lifted using 'unsuspend'.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			credentialID := args[0]
+
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).Suspend(credentialID); err != nil {
+				return fmt.Errorf("unable to suspend credential: %v", err)
+			}
+
+			cmd.Println(fmt.Sprintf("%s is now suspended", credentialID))
+			return nil
+		},
+	}
+}
+
+func unsuspendCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unsuspend [id]",
+		Short: "Lifts the suspension of an issued Verifiable Credential",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			credentialID := args[0]
+
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			if err := httpClient(clientConfig).Unsuspend(credentialID); err != nil {
+				return fmt.Errorf("unable to unsuspend credential: %v", err)
+			}
+
+			cmd.Println(fmt.Sprintf("%s is no longer suspended", credentialID))
+			return nil
+		},
+	}
+}
+
 // httpClient creates a remote client
 func httpClient(config core.ClientConfig) api.HTTPClient {
 	return api.HTTPClient{
@@ -258,6 +258,63 @@ func TestCmd_Issue(t *testing.T) {
 	})
 }
 
+func TestCmd_Suspend(t *testing.T) {
+	const credentialID = "did:web:example.com:iam:123#1"
+
+	buf := new(bytes.Buffer)
+
+	// Setup new VCR commands with output to a bytes buffer
+	newCmd := func(t *testing.T) *cobra.Command {
+		t.Helper()
+		buf.Reset()
+		command := Cmd()
+		command.SetOut(buf)
+		return command
+	}
+
+	cmds := map[string]string{
+		"suspend":   "is now suspended",
+		"unsuspend": "is no longer suspended",
+	}
+
+	for c, expectedOutput := range cmds {
+		t.Run(c, func(t *testing.T) {
+			t.Run("ok", func(t *testing.T) {
+				cmd := newCmd(t)
+				_ = setupServer(t, http.StatusNoContent, nil)
+				cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+				cmd.SetArgs([]string{c, credentialID})
+				err := cmd.Execute()
+
+				require.NoError(t, err)
+				assert.Contains(t, buf.String(), credentialID)
+				assert.Contains(t, buf.String(), expectedOutput)
+			})
+
+			t.Run("error - server error", func(t *testing.T) {
+				cmd := newCmd(t)
+				_ = setupServer(t, http.StatusConflict, nil)
+				cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+				cmd.SetArgs([]string{c, credentialID})
+				err := cmd.Execute()
+
+				assert.ErrorContains(t, err, "server returned HTTP 409")
+			})
+
+			t.Run("error - not enough args", func(t *testing.T) {
+				cmd := newCmd(t)
+
+				cmd.SetArgs([]string{c})
+				err := cmd.Execute()
+
+				assert.Error(t, err)
+			})
+		})
+	}
+}
+
 func setupServer(t *testing.T, statusCode int, responseData interface{}) *http2.Handler {
 	handler := &http2.Handler{StatusCode: statusCode, ResponseData: responseData}
 	s := httptest.NewServer(handler)
@@ -31,12 +31,46 @@ const ModuleName = "VCR"
 type Config struct {
 	// OpenID4VCI holds the config for the OpenID4VCI credential issuer and wallet
 	OpenID4VCI openid4vci.Config `koanf:"openid4vci"`
+	// Wallet holds the config for the wallet of the node's own DIDs
+	Wallet WalletConfig `koanf:"wallet"`
 }
 
+// WalletConfig holds the config for the wallet
+type WalletConfig struct {
+	// Sweep holds the config for the background routine that checks wallet credentials for expiry and revocation.
+	Sweep WalletSweepConfig `koanf:"sweep"`
+}
+
+// WalletSweepConfig holds the config for the wallet sweeper
+type WalletSweepConfig struct {
+	// Interval specifies how often wallet credentials are checked. If 0, the sweeper is disabled.
+	Interval time.Duration `koanf:"interval"`
+	// Action specifies what to do with expired or revoked credentials: flag (default) or remove.
+	Action string `koanf:"action"`
+}
+
+const (
+	// WalletSweepActionFlag flags expired or revoked credentials, which excludes them from presentation submissions.
+	WalletSweepActionFlag = "flag"
+	// WalletSweepActionRemove removes expired or revoked credentials from the wallet.
+	WalletSweepActionRemove = "remove"
+)
+
 // DefaultConfig returns a fresh Config filled with default values
 func DefaultConfig() Config {
-	return Config{OpenID4VCI: openid4vci.Config{
-		Enabled: true,
-		Timeout: 5 * time.Second,
-	}}
+	return Config{
+		OpenID4VCI: openid4vci.Config{
+			Enabled: true,
+			Timeout: 5 * time.Second,
+			Consent: openid4vci.ConsentConfig{
+				Expiry:     15 * time.Minute,
+				MaxPending: 100,
+			},
+		},
+		Wallet: WalletConfig{
+			Sweep: WalletSweepConfig{
+				Action: WalletSweepActionFlag,
+			},
+		},
+	}
 }
@@ -78,7 +78,7 @@ func (d defaultCredentialValidator) Validate(credential vc.VerifiableCredential)
 		return fmt.Errorf("%w: type 'VerifiableCredential' is required", errValidation)
 	}
 
-	if !credential.ContainsContext(vc.VCContextV1URI()) {
+	if !credential.ContainsContext(vc.VCContextV1URI()) && !credential.ContainsContext(statuslist2021.VCContextV2URI) {
 		return fmt.Errorf("%w: default context is required", errValidation)
 	}
 
@@ -127,16 +127,21 @@ func validateCredentialStatus(credential vc.VerifiableCredential) error {
 			return errors.New("credentialStatus.type is required")
 		}
 
-		// only accept StatusList2021Entry for now
-		if credentialStatus.Type != statuslist2021.EntryType {
+		// only accept StatusList2021Entry and BitstringStatusListEntry for now
+		switch credentialStatus.Type {
+		case statuslist2021.EntryType:
+			if !credential.ContainsContext(statuslist2021.ContextURI) {
+				return errors.New("StatusList2021 context is required")
+			}
+		case statuslist2021.BitstringEntryType:
+			if !credential.ContainsContext(statuslist2021.BitstringContextURI) {
+				return errors.New("BitstringStatusList context is required")
+			}
+		default:
 			continue
 		}
 		// TODO: AllFieldsDefined validator should be sufficient?
 
-		if !credential.ContainsContext(statuslist2021.ContextURI) {
-			return errors.New("StatusList2021 context is required")
-		}
-
 		// unmarshal as StatusList2021Entry
 		var cs statuslist2021.Entry
 		if err = json.Unmarshal(credentialStatus.Raw(), &cs); err != nil {
@@ -145,22 +150,22 @@ func validateCredentialStatus(credential vc.VerifiableCredential) error {
 
 		// 'id' MUST NOT be the URL for the status list
 		if cs.ID == cs.StatusListCredential {
-			return errors.New("StatusList2021Entry.id is the same as the StatusList2021Entry.statusListCredential")
+			return fmt.Errorf("%s.id is the same as the %s.statusListCredential", cs.Type, cs.Type)
 		}
 
 		// StatusPurpose must contain a purpose
 		if cs.StatusPurpose == "" {
-			return errors.New("StatusList2021Entry.statusPurpose is required")
+			return fmt.Errorf("%s.statusPurpose is required", cs.Type)
 		}
 
 		// statusListIndex must be a non-negative number
 		if n, err := strconv.Atoi(cs.StatusListIndex); err != nil || n < 0 {
-			return errors.New("invalid StatusList2021Entry.statusListIndex")
+			return fmt.Errorf("invalid %s.statusListIndex", cs.Type)
 		}
 
 		// 'statusListCredential' must be a URL
 		if _, err = url.ParseRequestURI(cs.StatusListCredential); err != nil {
-			return fmt.Errorf("parse StatusList2021Entry.statusListCredential URL: %w", err)
+			return fmt.Errorf("parse %s.statusListCredential URL: %w", cs.Type, err)
 		}
 	}
 
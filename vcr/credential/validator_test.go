@@ -430,6 +430,15 @@ func TestDefaultCredentialValidator(t *testing.T) {
 		assert.EqualError(t, err, "validation failed: 'proof' is required for JSON-LD credentials")
 	})
 
+	t.Run("ok - VC data model v2 context", func(t *testing.T) {
+		v := test.ValidNutsOrganizationCredential(t)
+		v.Context = []ssi.URI{statuslist2021.VCContextV2URI, ssi.MustParseURI(NutsV1Context)}
+
+		err := validator.Validate(v)
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("failed - missing default context", func(t *testing.T) {
 		v := test.ValidNutsOrganizationCredential(t)
 		v.Context = []ssi.URI{ssi.MustParseURI(NutsV1Context)}
@@ -555,4 +564,33 @@ func Test_validateCredentialStatus(t *testing.T) {
 			assert.EqualError(t, err, "parse StatusList2021Entry.statusListCredential URL: parse \"not a URL\": invalid URI for request")
 		})
 	})
+	t.Run(statuslist2021.BitstringEntryType, func(t *testing.T) {
+		makeValidCSEntry := func() vc.VerifiableCredential {
+			return vc.VerifiableCredential{
+				Context: []ssi.URI{ssi.MustParseURI(jsonld.BitstringStatusListContext)},
+				CredentialStatus: []any{&statuslist2021.Entry{
+					ID:                   "https://example-com/credentials/status/3#94567",
+					Type:                 statuslist2021.BitstringEntryType,
+					StatusPurpose:        "revocation",
+					StatusListIndex:      "94567",
+					StatusListCredential: "https://example-com/credentials/status/3",
+				}},
+			}
+		}
+		t.Run("ok", func(t *testing.T) {
+			assert.NoError(t, validateCredentialStatus(makeValidCSEntry()))
+		})
+		t.Run("error - missing context", func(t *testing.T) {
+			cred := makeValidCSEntry()
+			cred.Context = []ssi.URI{vc.VCContextV1URI(), statuslist2021.ContextURI}
+			err := validateCredentialStatus(cred)
+			assert.EqualError(t, err, "BitstringStatusList context is required")
+		})
+		t.Run("error - missing statusPurpose", func(t *testing.T) {
+			cred := makeValidCSEntry()
+			cred.CredentialStatus[0].(*statuslist2021.Entry).StatusPurpose = ""
+			err := validateCredentialStatus(cred)
+			assert.EqualError(t, err, "BitstringStatusListEntry.statusPurpose is required")
+		})
+	})
 }
@@ -46,9 +46,11 @@ type Wallet interface {
 	// BuildPresentation builds and signs a Verifiable Presentation using the given Verifiable Credentials.
 	// The assertion key used for signing it is taken from signerDID's DID document.
 	// If signerDID is not provided, it will be derived from the credentials credentialSubject.id fields. But only if all provided credentials have the same (singular) credentialSubject.id field.
+	// SD-JWT VCs are presented with a key binding JWT, signed by the credential subject's assertion key.
 	BuildPresentation(ctx context.Context, credentials []vc.VerifiableCredential, options PresentationOptions, signerDID *did.DID, validateVC bool) (*vc.VerifiablePresentation, error)
 
 	// BuildSubmission builds a Verifiable Presentation based on the given presentation definition.
+	// SD-JWT VCs only disclose the claims required by input descriptors that specify limit_disclosure.
 	BuildSubmission(ctx context.Context, walletDID did.DID, presentationDefinition pe.PresentationDefinition, acceptedFormats map[string]map[string][]string, params BuildParams) (*vc.VerifiablePresentation, *pe.PresentationSubmission, error)
 
 	// List returns all credentials in the wallet for the given holder.
@@ -56,8 +58,19 @@ type Wallet interface {
 
 	// Put adds the given credentials to the wallet. It is an all-or-nothing operation:
 	// if one of them fails, none of the credentials are added.
+	// SD-JWT VCs are checked to match their SD-JWT.
 	Put(ctx context.Context, credentials ...vc.VerifiableCredential) error
 
+	// Remove removes the credential with the given ID from the wallet of the given holder.
+	// It returns types.ErrNotFound if the wallet does not contain the credential.
+	Remove(ctx context.Context, holderDID did.DID, credentialID ssi.URI) error
+
+	// Sweep checks the credentials in the wallet of the given holder for expiry and revocation.
+	// Expired or revoked credentials are removed if remove is true, otherwise they are flagged.
+	// Flagged credentials remain in the wallet (and are returned by List), but are not used by BuildSubmission.
+	// It returns the number of credentials that were flagged or removed.
+	Sweep(ctx context.Context, holderDID did.DID, remove bool) (int, error)
+
 	// IsEmpty returns true if the wallet contains no credentials at all (for all holder DIDs).
 	IsEmpty() (bool, error)
 }
@@ -26,12 +26,15 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	vcrTypes "github.com/nuts-foundation/nuts-node/vcr/types"
@@ -44,14 +47,49 @@ type OpenIDHandler interface {
 	Metadata() openid4vci.OAuth2ClientMetadata
 	// HandleCredentialOffer handles a credential offer from an issuer.
 	// It will try to retrieve the offered credential and store it.
-	HandleCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer) error
+	// If the holder's consent is required, the offer is queued instead and CredentialOfferStatusPending is returned.
+	HandleCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer) (openid4vci.CredentialOfferStatus, error)
+	// RequestCredential requests a credential matching the given credential definition from the given issuer, without a credential offer
+	// (wallet-initiated flow). It starts the authorization code flow, the issuer only returns credentials it issued to the wallet.
+	RequestCredential(ctx context.Context, credentialIssuer string, credentialDefinition openid4vci.CredentialDefinition) error
+	// HandleAuthorizationResponse handles the authorization response of an authorization code flow started by HandleCredentialOffer or RequestCredential.
+	// It exchanges the authorization code for an access token, and retrieves and stores the offered credential.
+	HandleAuthorizationResponse(ctx context.Context, code string, state string) error
+	// ListCredentialOffers returns the credential offers that await the holder's consent.
+	// Offers that expired are discarded and not returned.
+	ListCredentialOffers(ctx context.Context) ([]PendingCredentialOffer, error)
+	// AcceptCredentialOffer accepts a pending credential offer: the offered credential is retrieved and stored.
+	// It returns ErrCredentialOfferNotFound if the offer doesn't exist or expired.
+	AcceptCredentialOffer(ctx context.Context, id string) error
+	// RejectCredentialOffer rejects a pending credential offer, which discards it.
+	// It returns ErrCredentialOfferNotFound if the offer doesn't exist or expired.
+	RejectCredentialOffer(ctx context.Context, id string) error
+	// RetrieveDeferredCredentials tries to retrieve the credentials of which issuance was deferred by the issuer, and stores them.
+	// Credentials of which issuance is still pending are retried on the next invocation.
+	RetrieveDeferredCredentials(ctx context.Context) error
+}
+
+// authorizationSessionTTL is the maximum time between sending the authorization request and receiving the authorization response.
+const authorizationSessionTTL = time.Minute
+
+// authorizationSession contains the state of an authorization code flow, which is needed to handle the authorization response.
+type authorizationSession struct {
+	WalletDID            string                          `json:"wallet_did"`
+	CredentialIssuer     string                          `json:"credential_issuer"`
+	CredentialDefinition openid4vci.CredentialDefinition `json:"credential_definition"`
+	CodeVerifier         string                          `json:"code_verifier"`
+	RedirectURI          string                          `json:"redirect_uri"`
+	// ExpectedIssuer is the DID that must have issued the credential, if any (see storeCredential).
+	ExpectedIssuer string `json:"expected_issuer,omitempty"`
 }
 
 var nowFunc = time.Now
 var _ OpenIDHandler = (*openidHandler)(nil)
 
 // NewOpenIDHandler creates an OpenIDHandler that tries to retrieve offered credentials, to store it in the given credential store.
-func NewOpenIDHandler(did did.DID, identifier string, httpClient core.HTTPRequestDoer, credentialStore vcrTypes.Writer, signer crypto.JWTSigner, resolver resolver.KeyResolver) OpenIDHandler {
+// Offers that require the holder's consent (see openid4vci.ConsentConfig) are queued in the given offer store.
+func NewOpenIDHandler(did did.DID, identifier string, httpClient core.HTTPRequestDoer, credentialStore vcrTypes.Writer, signer crypto.JWTSigner, resolver resolver.KeyResolver, sessionDatabase storage.SessionDatabase,
+	offerStore stoabs.KVStore, consent openid4vci.ConsentConfig) OpenIDHandler {
 	return &openidHandler{
 		did:                 did,
 		identifier:          identifier,
@@ -60,6 +98,9 @@ func NewOpenIDHandler(did did.DID, identifier string, httpClient core.HTTPReques
 		resolver:            resolver,
 		httpClient:          httpClient,
 		issuerClientCreator: openid4vci.NewIssuerAPIClient,
+		sessionDatabase:     sessionDatabase,
+		offerStore:          offerStore,
+		consent:             consent,
 	}
 }
 
@@ -72,6 +113,13 @@ type openidHandler struct {
 	issuerClientCreator func(ctx context.Context, httpClient core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error)
 	httpClient          core.HTTPRequestDoer
 	jsonldReader        jsonld.Reader
+	sessionDatabase     storage.SessionDatabase
+	offerStore          stoabs.KVStore
+	consent             openid4vci.ConsentConfig
+}
+
+func (h *openidHandler) authorizationSessionStore() storage.SessionStore {
+	return h.sessionDatabase.GetStore(authorizationSessionTTL, "openid4vci", "wallet", "authorization")
 }
 
 func (h *openidHandler) Metadata() openid4vci.OAuth2ClientMetadata {
@@ -83,7 +131,30 @@ func (h *openidHandler) Metadata() openid4vci.OAuth2ClientMetadata {
 // HandleCredentialOffer handles a credential offer from an issuer.
 // Error responses on the Credential Offer Endpoint are not defined in the OpenID4VCI spec,
 // so these are inferred of whatever makes sense.
-func (h *openidHandler) HandleCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer) error {
+func (h *openidHandler) HandleCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer) (openid4vci.CredentialOfferStatus, error) {
+	if err := validateCredentialOffer(offer); err != nil {
+		return "", err
+	}
+	// If the holder's consent is not required, any issuer is accepted.
+	var expectedIssuer string
+	if h.consent.Required {
+		autoAccepted, allowedIssuer := h.isAutoAccepted(offer)
+		if !autoAccepted {
+			if err := h.queueCredentialOffer(ctx, offer); err != nil {
+				return "", err
+			}
+			return openid4vci.CredentialOfferStatusPending, nil
+		}
+		expectedIssuer = allowedIssuer
+	}
+	if err := h.acceptCredentialOffer(ctx, offer, expectedIssuer); err != nil {
+		return "", err
+	}
+	return openid4vci.CredentialOfferStatusReceived, nil
+}
+
+// validateCredentialOffer checks whether the credential offer can be handled by this wallet.
+func validateCredentialOffer(offer openid4vci.CredentialOffer) error {
 	// TODO: This check is too simplistic, there can be multiple credential offers,
 	//       but the issuer should only request the one it's interested in.
 	//       See https://github.com/nuts-foundation/nuts-node/issues/2049
@@ -110,15 +181,23 @@ func (h *openidHandler) HandleCredentialOffer(ctx context.Context, offer openid4
 		}
 	}
 
-	preAuthorizedCode := getPreAuthorizedCodeFromOffer(offer)
-	if preAuthorizedCode == "" {
+	_, supportsAuthorizationCode := offer.Grants[openid4vci.AuthorizationCodeGrant].(map[string]interface{})
+	if getPreAuthorizedCodeFromOffer(offer) == "" && !supportsAuthorizationCode {
 		return openid4vci.Error{
-			Err:        errors.New("couldn't find (valid) pre-authorized code grant in credential offer"),
+			Err:        errors.New("couldn't find (valid) pre-authorized code or authorization code grant in credential offer"),
 			Code:       openid4vci.InvalidGrant,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
+	return nil
+}
 
+// acceptCredentialOffer retrieves the offered credential and stores it, using the pre-authorized code flow or authorization code flow.
+// The credential offer must have been validated using validateCredentialOffer.
+// If expectedIssuer is set, the received credential must be issued by that DID.
+func (h *openidHandler) acceptCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer, expectedIssuer string) error {
+	offeredCredential := offer.Credentials[0]
+	preAuthorizedCode := getPreAuthorizedCodeFromOffer(offer)
 	issuerClient, err := h.issuerClientCreator(ctx, h.httpClient, offer.CredentialIssuer)
 	if err != nil {
 		return openid4vci.Error{
@@ -128,9 +207,155 @@ func (h *openidHandler) HandleCredentialOffer(ctx context.Context, offer openid4
 		}
 	}
 
-	accessTokenResponse, err := issuerClient.RequestAccessToken(openid4vci.PreAuthorizedCodeGrant, map[string]string{
-		"pre-authorized_code": preAuthorizedCode,
+	// The pre-authorized code flow is preferred, since it doesn't require a roundtrip to the authorization endpoint.
+	if preAuthorizedCode != "" {
+		return h.receiveCredential(ctx, issuerClient, offeredCredential.CredentialDefinition, expectedIssuer, openid4vci.PreAuthorizedCodeGrant, map[string]string{
+			"pre-authorized_code": preAuthorizedCode,
+		})
+	}
+	authorizationCodeGrant, _ := offer.Grants[openid4vci.AuthorizationCodeGrant].(map[string]interface{})
+	issuerState, _ := authorizationCodeGrant["issuer_state"].(string)
+	return h.requestAuthorization(ctx, issuerClient, *offeredCredential.CredentialDefinition, expectedIssuer, issuerState)
+}
+
+func (h *openidHandler) RequestCredential(ctx context.Context, credentialIssuer string, credentialDefinition openid4vci.CredentialDefinition) error {
+	issuerClient, err := h.issuerClientCreator(ctx, h.httpClient, credentialIssuer)
+	if err != nil {
+		return openid4vci.Error{
+			Err:        fmt.Errorf("unable to create issuer client: %w", err),
+			Code:       openid4vci.ServerError,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	return h.requestAuthorization(ctx, issuerClient, credentialDefinition, "", "")
+}
+
+func (h *openidHandler) HandleAuthorizationResponse(ctx context.Context, code string, state string) error {
+	if code == "" || state == "" {
+		return openid4vci.Error{
+			Err:        errors.New("missing code or state"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	store := h.authorizationSessionStore()
+	var session authorizationSession
+	if err := store.Get(state, &session); err != nil {
+		return openid4vci.Error{
+			Err:        errors.New("unknown or expired state"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// state is to be used just once
+	if err := store.Delete(state); err != nil {
+		log.Logger().WithError(err).Error("Failed to delete OpenID4VCI authorization session")
+	}
+	if session.WalletDID != h.did.String() {
+		return openid4vci.Error{
+			Err:        errors.New("state was not issued for this wallet"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	issuerClient, err := h.issuerClientCreator(ctx, h.httpClient, session.CredentialIssuer)
+	if err != nil {
+		return openid4vci.Error{
+			Err:        fmt.Errorf("unable to create issuer client: %w", err),
+			Code:       openid4vci.ServerError,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	return h.receiveCredential(ctx, issuerClient, &session.CredentialDefinition, session.ExpectedIssuer, openid4vci.AuthorizationCodeGrant, map[string]string{
+		oauth.CodeParam:         code,
+		oauth.CodeVerifierParam: session.CodeVerifier,
+		oauth.RedirectURIParam:  session.RedirectURI,
+		oauth.ClientIDParam:     h.did.String(),
+	})
+}
+
+// requestAuthorization starts the authorization code flow by sending an authorization request (with PKCE) to the issuer.
+// The issuer redirects to the wallet's redirect URI, where the authorization code is exchanged for the credential (see HandleAuthorizationResponse).
+func (h *openidHandler) requestAuthorization(ctx context.Context, issuerClient openid4vci.IssuerAPIClient, credentialDefinition openid4vci.CredentialDefinition, expectedIssuer string, issuerState string) error {
+	pkceParams := oauth.GeneratePKCEParams()
+	state := crypto.GenerateNonce()
+	redirectURI := core.JoinURLPaths(h.identifier, "/openid4vci/callback")
+	credentialIssuer := issuerClient.Metadata().CredentialIssuer
+	store := h.authorizationSessionStore()
+	err := store.Put(state, authorizationSession{
+		WalletDID:            h.did.String(),
+		CredentialIssuer:     credentialIssuer,
+		CredentialDefinition: credentialDefinition,
+		CodeVerifier:         pkceParams.Verifier,
+		RedirectURI:          redirectURI,
+		ExpectedIssuer:       expectedIssuer,
 	})
+	if err != nil {
+		return fmt.Errorf("unable to store OpenID4VCI authorization session: %w", err)
+	}
+
+	request, err := h.signAuthorizationRequest(ctx, credentialIssuer, map[string]interface{}{
+		oauth.ResponseTypeParam:        oauth.CodeResponseType,
+		oauth.RedirectURIParam:         redirectURI,
+		oauth.StateParam:               state,
+		oauth.CodeChallengeParam:       pkceParams.Challenge,
+		oauth.CodeChallengeMethodParam: pkceParams.ChallengeMethod,
+	}, credentialDefinition, issuerState)
+	if err != nil {
+		_ = store.Delete(state)
+		return err
+	}
+	params := map[string]string{
+		oauth.ClientIDParam: h.did.String(),
+		oauth.RequestParam:  request,
+	}
+	if err = issuerClient.RequestAuthorization(ctx, params); err != nil {
+		_ = store.Delete(state)
+		return openid4vci.Error{
+			Err:        fmt.Errorf("unable to request authorization: %w", err),
+			Code:       openid4vci.ServerError,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	return nil
+}
+
+// signAuthorizationRequest creates a request object (JAR, RFC9101) containing the given authorization request parameters,
+// signed with the wallet's assertion key. This authenticates the wallet to the issuer.
+// If the flow was initiated by a credential offer, the request contains its issuer_state.
+// Otherwise, the credential is requested using authorization_details.
+func (h *openidHandler) signAuthorizationRequest(ctx context.Context, credentialIssuer string, params map[string]interface{}, credentialDefinition openid4vci.CredentialDefinition, issuerState string) (string, error) {
+	keyID, _, err := h.resolver.ResolveKey(h.did, nil, resolver.AssertionMethod)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve key for signing authorization request: %w", err)
+	}
+	now := nowFunc()
+	params[jwt.IssuerKey] = h.did.String()
+	params[jwt.AudienceKey] = credentialIssuer
+	params[jwt.IssuedAtKey] = now.Unix()
+	params[jwt.ExpirationKey] = now.Add(authorizationSessionTTL).Unix()
+	params[oauth.ClientIDParam] = h.did.String()
+	if issuerState != "" {
+		params["issuer_state"] = issuerState
+	} else {
+		params["authorization_details"] = []openid4vci.AuthorizationDetails{{
+			Type:                 openid4vci.AuthorizationDetailsTypeOpenIDCredential,
+			Format:               vc.JSONLDCredentialProofFormat,
+			CredentialDefinition: &credentialDefinition,
+		}}
+	}
+	request, err := h.signer.SignJWT(ctx, params, nil, keyID.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to sign authorization request: %w", err)
+	}
+	return request, nil
+}
+
+// receiveCredential exchanges the given grant for an access token, which is used to retrieve the credential from the issuer.
+// The credential is then validated against the credential definition and expected issuer (if set), and stored.
+func (h *openidHandler) receiveCredential(ctx context.Context, issuerClient openid4vci.IssuerAPIClient, credentialDefinition *openid4vci.CredentialDefinition, expectedIssuer string, grantType string, grantParams map[string]string) error {
+	accessTokenResponse, err := issuerClient.RequestAccessToken(grantType, grantParams)
 	if err != nil {
 		return openid4vci.Error{
 			Err:        fmt.Errorf("unable to request access token: %w", err),
@@ -156,7 +381,11 @@ func (h *openidHandler) HandleCredentialOffer(ctx context.Context, offer openid4
 	}
 
 	retrieveCtx := audit.Context(ctx, "app-openid4vci", "VCR/OpenID4VCI", "RetrieveCredential")
-	credential, err := h.retrieveCredential(retrieveCtx, issuerClient, offeredCredential.CredentialDefinition, accessTokenResponse)
+	credential, err := h.retrieveCredential(retrieveCtx, issuerClient, credentialDefinition, accessTokenResponse)
+	var deferredErr openid4vci.DeferredIssuanceError
+	if errors.As(err, &deferredErr) {
+		return h.deferCredential(ctx, issuerClient.Metadata().CredentialIssuer, *credentialDefinition, expectedIssuer, deferredErr.TransactionID, accessTokenResponse.AccessToken)
+	}
 	if err != nil {
 		return openid4vci.Error{
 			Err:        fmt.Errorf("unable to retrieve credential: %w", err),
@@ -164,17 +393,32 @@ func (h *openidHandler) HandleCredentialOffer(ctx context.Context, offer openid4
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
-	if err = openid4vci.ValidateDefinitionWithCredential(*credential, *offeredCredential.CredentialDefinition); err != nil {
+	return h.storeCredential(*credential, *credentialDefinition, expectedIssuer)
+}
+
+// storeCredential validates the received credential against the credential definition, and stores it.
+// If expectedIssuer is set, the credential must be issued by that DID. This is the case when the offer was accepted
+// without the holder's consent because its issuer is allow-listed: the offer's credential_issuer is not authenticated,
+// so the issuer can only be trusted when it signed the credential.
+func (h *openidHandler) storeCredential(credential vc.VerifiableCredential, credentialDefinition openid4vci.CredentialDefinition, expectedIssuer string) error {
+	if err := openid4vci.ValidateDefinitionWithCredential(credential, credentialDefinition); err != nil {
 		return openid4vci.Error{
 			Err:        fmt.Errorf("received credential does not match offer: %w", err),
 			Code:       openid4vci.InvalidRequest,
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
+	if expectedIssuer != "" && credential.Issuer.String() != expectedIssuer {
+		return openid4vci.Error{
+			Err:        fmt.Errorf("received credential is not issued by the auto-accepted issuer (expected=%s, actual=%s)", expectedIssuer, credential.Issuer.String()),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
 	log.Logger().
 		WithField("credentialID", credential.ID).
 		Infof("Received VC over OpenID4VCI")
-	err = h.credentialStore.StoreCredential(*credential, nil)
+	err := h.credentialStore.StoreCredential(credential, nil)
 	if err != nil {
 		return fmt.Errorf("unable to store credential: %w", err)
 	}
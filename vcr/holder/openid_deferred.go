@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package holder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+)
+
+// deferredCredentialTTL is the time after which the holder stops trying to retrieve a credential of which issuance was deferred.
+const deferredCredentialTTL = 7 * 24 * time.Hour
+
+// deferredCredential is a credential of which issuance was deferred by the issuer, which is retrieved later on.
+type deferredCredential struct {
+	TransactionID        string                          `json:"transaction_id"`
+	CredentialIssuer     string                          `json:"credential_issuer"`
+	CredentialDefinition openid4vci.CredentialDefinition `json:"credential_definition"`
+	AccessToken          string                          `json:"access_token"`
+	ExpiresAt            time.Time                       `json:"expires_at"`
+	// ExpectedIssuer is the DID that must have issued the credential, if any (see storeCredential).
+	ExpectedIssuer string `json:"expected_issuer,omitempty"`
+}
+
+// deferredShelf returns the name of the shelf in the offer store that holds the holder's deferred credentials.
+func (h *openidHandler) deferredShelf() string {
+	return "deferred/" + h.did.String()
+}
+
+// deferCredential stores the deferred credential issuance, so the credential can be retrieved later (see RetrieveDeferredCredentials).
+func (h *openidHandler) deferCredential(ctx context.Context, credentialIssuer string, credentialDefinition openid4vci.CredentialDefinition, expectedIssuer string, transactionID string, accessToken string) error {
+	data, _ := json.Marshal(deferredCredential{
+		TransactionID:        transactionID,
+		CredentialIssuer:     credentialIssuer,
+		CredentialDefinition: credentialDefinition,
+		AccessToken:          accessToken,
+		ExpiresAt:            nowFunc().Add(deferredCredentialTTL),
+		ExpectedIssuer:       expectedIssuer,
+	})
+	err := h.offerStore.WriteShelf(ctx, h.deferredShelf(), func(writer stoabs.Writer) error {
+		return writer.Put(stoabs.BytesKey(transactionID), data)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to store deferred credential: %w", err)
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, h.did.String()).
+		Infof("Issuer deferred issuance of credential offered over OpenID4VCI (issuer=%s, transaction_id=%s)", credentialIssuer, transactionID)
+	return nil
+}
+
+func (h *openidHandler) RetrieveDeferredCredentials(ctx context.Context) error {
+	var deferredCredentials []deferredCredential
+	err := h.offerStore.ReadShelf(ctx, h.deferredShelf(), func(reader stoabs.Reader) error {
+		return reader.Iterate(func(key stoabs.Key, value []byte) error {
+			var current deferredCredential
+			if err := json.Unmarshal(value, &current); err != nil {
+				return fmt.Errorf("unable to unmarshal deferred credential %s: %w", string(key.Bytes()), err)
+			}
+			deferredCredentials = append(deferredCredentials, current)
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list deferred credentials: %w", err)
+	}
+	for _, current := range deferredCredentials {
+		if h.retrieveDeferredCredential(ctx, current) {
+			err := h.offerStore.WriteShelf(ctx, h.deferredShelf(), func(writer stoabs.Writer) error {
+				return writer.Delete(stoabs.BytesKey(current.TransactionID))
+			})
+			if err != nil {
+				return fmt.Errorf("unable to delete deferred credential: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// retrieveDeferredCredential tries to retrieve and store the deferred credential.
+// It returns true if the deferred credential is done with (retrieved, expired or denied by the issuer), meaning it can be deleted.
+func (h *openidHandler) retrieveDeferredCredential(ctx context.Context, deferred deferredCredential) bool {
+	logger := log.Logger().
+		WithField(core.LogFieldDID, h.did.String()).
+		WithField("transaction_id", deferred.TransactionID)
+	if !nowFunc().Before(deferred.ExpiresAt) {
+		logger.Warnf("Discarding deferred OpenID4VCI credential, it wasn't issued in time (issuer=%s)", deferred.CredentialIssuer)
+		return true
+	}
+	issuerClient, err := h.issuerClientCreator(ctx, h.httpClient, deferred.CredentialIssuer)
+	if err != nil {
+		logger.WithError(err).Warnf("Unable to create issuer client to retrieve deferred OpenID4VCI credential (issuer=%s)", deferred.CredentialIssuer)
+		return false
+	}
+	credential, err := issuerClient.RequestDeferredCredential(ctx, deferred.TransactionID, deferred.AccessToken)
+	var protocolErr openid4vci.Error
+	if errors.As(err, &protocolErr) {
+		if protocolErr.Code == openid4vci.IssuancePending {
+			return false
+		}
+		// The issuer denied issuance, or doesn't know the transaction (anymore)
+		logger.WithError(err).Warnf("Issuer refused to issue deferred OpenID4VCI credential (issuer=%s)", deferred.CredentialIssuer)
+		return true
+	} else if err != nil {
+		logger.WithError(err).Warnf("Unable to retrieve deferred OpenID4VCI credential (issuer=%s)", deferred.CredentialIssuer)
+		return false
+	}
+	// The credential can only be retrieved once, so it can't be retried if it can't be stored.
+	if err = h.storeCredential(*credential, deferred.CredentialDefinition, deferred.ExpectedIssuer); err != nil {
+		logger.WithError(err).Errorf("Unable to store deferred OpenID4VCI credential (issuer=%s)", deferred.CredentialIssuer)
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package holder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_openidHandler_DeferredCredentials(t *testing.T) {
+	credentialOffer := openid4vci.CredentialOffer{
+		CredentialIssuer: issuerDID.String(),
+		Credentials:      offeredCredential(),
+		Grants: map[string]interface{}{
+			"urn:ietf:params:oauth:grant-type:pre-authorized_code": map[string]interface{}{
+				"pre-authorized_code": "code",
+			},
+		},
+	}
+	issuedCredential := &vc.VerifiableCredential{
+		Context: []ssi.URI{ssi.MustParseURI("https://www.w3.org/2018/credentials/v1"), ssi.MustParseURI("http://example.org/credentials/V1")},
+		Type:    []ssi.URI{ssi.MustParseURI("VerifiableCredential"), ssi.MustParseURI("HumanCredential")},
+		Issuer:  issuerDID.URI(),
+	}
+	nonce := "nonsens"
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nowFunc = func() time.Time {
+		return now
+	}
+	t.Cleanup(func() {
+		nowFunc = time.Now
+	})
+	// deferIssuance handles the credential offer, of which the issuer defers issuance
+	deferIssuance := func(t *testing.T) (*openidHandler, *openid4vci.MockIssuerAPIClient, *types.MockWriter) {
+		ctrl := gomock.NewController(t)
+		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+		issuerAPIClient.EXPECT().Metadata().Return(openid4vci.CredentialIssuerMetadata{CredentialIssuer: issuerDID.String()}).AnyTimes()
+		issuerAPIClient.EXPECT().RequestAccessToken(gomock.Any(), gomock.Any()).Return(&oauth.TokenResponse{AccessToken: "access-token", CNonce: &nonce}, nil)
+		issuerAPIClient.EXPECT().RequestCredential(gomock.Any(), gomock.Any(), "access-token").
+			Return(nil, fmt.Errorf("get credential request failed: %w", openid4vci.DeferredIssuanceError{TransactionID: "tx-id"}))
+		jwtSigner := crypto.NewMockJWTSigner(ctrl)
+		jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), "key-id").Return("signed-jwt", nil)
+		keyResolver := resolver.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.NutsSigningKeyType).Return(ssi.MustParseURI("key-id"), nil, nil)
+		credentialStore := types.NewMockWriter(ctrl)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, credentialStore, jwtSigner, keyResolver, nil, newTestOfferStore(t), openid4vci.ConsentConfig{}).(*openidHandler)
+		w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error) {
+			assert.Equal(t, issuerDID.String(), credentialIssuerIdentifier)
+			return issuerAPIClient, nil
+		}
+
+		status, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+
+		require.NoError(t, err)
+		require.Equal(t, openid4vci.CredentialOfferStatusReceived, status)
+		return w, issuerAPIClient, credentialStore
+	}
+	countDeferred := func(t *testing.T, w *openidHandler) int {
+		count := 0
+		err := w.offerStore.ReadShelf(context.Background(), w.deferredShelf(), func(reader stoabs.Reader) error {
+			return reader.Iterate(func(_ stoabs.Key, _ []byte) error {
+				count++
+				return nil
+			}, stoabs.BytesKey{})
+		})
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("issuance is deferred", func(t *testing.T) {
+		w, _, _ := deferIssuance(t)
+
+		assert.Equal(t, 1, countDeferred(t, w))
+	})
+	t.Run("credential is retrieved", func(t *testing.T) {
+		w, issuerAPIClient, credentialStore := deferIssuance(t)
+		issuerAPIClient.EXPECT().RequestDeferredCredential(gomock.Any(), "tx-id", "access-token").Return(issuedCredential, nil)
+		credentialStore.EXPECT().StoreCredential(*issuedCredential, nil).Return(nil)
+
+		err := w.RetrieveDeferredCredentials(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, countDeferred(t, w))
+	})
+	t.Run("issuance still pending", func(t *testing.T) {
+		w, issuerAPIClient, _ := deferIssuance(t)
+		issuerAPIClient.EXPECT().RequestDeferredCredential(gomock.Any(), "tx-id", "access-token").
+			Return(nil, fmt.Errorf("deferred credential request failed: %w", openid4vci.Error{Code: openid4vci.IssuancePending}))
+
+		err := w.RetrieveDeferredCredentials(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, countDeferred(t, w))
+	})
+	t.Run("issuance denied", func(t *testing.T) {
+		w, issuerAPIClient, _ := deferIssuance(t)
+		issuerAPIClient.EXPECT().RequestDeferredCredential(gomock.Any(), "tx-id", "access-token").
+			Return(nil, openid4vci.Error{Code: openid4vci.InvalidTransactionID})
+
+		err := w.RetrieveDeferredCredentials(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, countDeferred(t, w))
+	})
+	t.Run("issuer unreachable", func(t *testing.T) {
+		w, issuerAPIClient, _ := deferIssuance(t)
+		issuerAPIClient.EXPECT().RequestDeferredCredential(gomock.Any(), "tx-id", "access-token").
+			Return(nil, errors.New("connection refused"))
+
+		err := w.RetrieveDeferredCredentials(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, countDeferred(t, w))
+	})
+	t.Run("received credential does not match offer", func(t *testing.T) {
+		w, issuerAPIClient, _ := deferIssuance(t)
+		otherCredential := *issuedCredential
+		otherCredential.Type = []ssi.URI{ssi.MustParseURI("VerifiableCredential"), ssi.MustParseURI("OtherCredential")}
+		issuerAPIClient.EXPECT().RequestDeferredCredential(gomock.Any(), "tx-id", "access-token").Return(&otherCredential, nil)
+
+		err := w.RetrieveDeferredCredentials(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, countDeferred(t, w))
+	})
+	t.Run("expired", func(t *testing.T) {
+		w, _, _ := deferIssuance(t)
+		nowFunc = func() time.Time {
+			return now.Add(deferredCredentialTTL)
+		}
+		t.Cleanup(func() {
+			nowFunc = func() time.Time {
+				return now
+			}
+		})
+
+		err := w.RetrieveDeferredCredentials(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, countDeferred(t, w))
+	})
+}
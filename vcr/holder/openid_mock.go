@@ -40,20 +40,64 @@ func (m *MockOpenIDHandler) EXPECT() *MockOpenIDHandlerMockRecorder {
 	return m.recorder
 }
 
-// HandleCredentialOffer mocks base method.
-func (m *MockOpenIDHandler) HandleCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer) error {
+// AcceptCredentialOffer mocks base method.
+func (m *MockOpenIDHandler) AcceptCredentialOffer(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleCredentialOffer", ctx, offer)
+	ret := m.ctrl.Call(m, "AcceptCredentialOffer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptCredentialOffer indicates an expected call of AcceptCredentialOffer.
+func (mr *MockOpenIDHandlerMockRecorder) AcceptCredentialOffer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptCredentialOffer", reflect.TypeOf((*MockOpenIDHandler)(nil).AcceptCredentialOffer), ctx, id)
+}
+
+// HandleAuthorizationResponse mocks base method.
+func (m *MockOpenIDHandler) HandleAuthorizationResponse(ctx context.Context, code, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleAuthorizationResponse", ctx, code, state)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// HandleAuthorizationResponse indicates an expected call of HandleAuthorizationResponse.
+func (mr *MockOpenIDHandlerMockRecorder) HandleAuthorizationResponse(ctx, code, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAuthorizationResponse", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleAuthorizationResponse), ctx, code, state)
+}
+
+// HandleCredentialOffer mocks base method.
+func (m *MockOpenIDHandler) HandleCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer) (openid4vci.CredentialOfferStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCredentialOffer", ctx, offer)
+	ret0, _ := ret[0].(openid4vci.CredentialOfferStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // HandleCredentialOffer indicates an expected call of HandleCredentialOffer.
 func (mr *MockOpenIDHandlerMockRecorder) HandleCredentialOffer(ctx, offer any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCredentialOffer", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleCredentialOffer), ctx, offer)
 }
 
+// ListCredentialOffers mocks base method.
+func (m *MockOpenIDHandler) ListCredentialOffers(ctx context.Context) ([]PendingCredentialOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCredentialOffers", ctx)
+	ret0, _ := ret[0].([]PendingCredentialOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCredentialOffers indicates an expected call of ListCredentialOffers.
+func (mr *MockOpenIDHandlerMockRecorder) ListCredentialOffers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCredentialOffers", reflect.TypeOf((*MockOpenIDHandler)(nil).ListCredentialOffers), ctx)
+}
+
 // Metadata mocks base method.
 func (m *MockOpenIDHandler) Metadata() openid4vci.OAuth2ClientMetadata {
 	m.ctrl.T.Helper()
@@ -67,3 +111,45 @@ func (mr *MockOpenIDHandlerMockRecorder) Metadata() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockOpenIDHandler)(nil).Metadata))
 }
+
+// RejectCredentialOffer mocks base method.
+func (m *MockOpenIDHandler) RejectCredentialOffer(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectCredentialOffer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectCredentialOffer indicates an expected call of RejectCredentialOffer.
+func (mr *MockOpenIDHandlerMockRecorder) RejectCredentialOffer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectCredentialOffer", reflect.TypeOf((*MockOpenIDHandler)(nil).RejectCredentialOffer), ctx, id)
+}
+
+// RequestCredential mocks base method.
+func (m *MockOpenIDHandler) RequestCredential(ctx context.Context, credentialIssuer string, credentialDefinition openid4vci.CredentialDefinition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCredential", ctx, credentialIssuer, credentialDefinition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestCredential indicates an expected call of RequestCredential.
+func (mr *MockOpenIDHandlerMockRecorder) RequestCredential(ctx, credentialIssuer, credentialDefinition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCredential", reflect.TypeOf((*MockOpenIDHandler)(nil).RequestCredential), ctx, credentialIssuer, credentialDefinition)
+}
+
+// RetrieveDeferredCredentials mocks base method.
+func (m *MockOpenIDHandler) RetrieveDeferredCredentials(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveDeferredCredentials", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetrieveDeferredCredentials indicates an expected call of RetrieveDeferredCredentials.
+func (mr *MockOpenIDHandlerMockRecorder) RetrieveDeferredCredentials(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveDeferredCredentials", reflect.TypeOf((*MockOpenIDHandler)(nil).RetrieveDeferredCredentials), ctx)
+}
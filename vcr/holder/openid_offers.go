@@ -0,0 +1,243 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package holder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+)
+
+// ErrCredentialOfferNotFound is returned when a pending credential offer can't be found, e.g. because it expired.
+var ErrCredentialOfferNotFound = errors.New("credential offer not found")
+
+// errTooManyCredentialOffers is returned when a credential offer can't be queued, because too many offers await the holder's consent.
+var errTooManyCredentialOffers = errors.New("too many pending credential offers")
+
+// PendingCredentialOffer is a credential offer received over OpenID4VCI that awaits the holder's consent.
+type PendingCredentialOffer struct {
+	// ID identifies the pending offer, it is used to accept or reject it.
+	ID string `json:"id"`
+	// Offer is the credential offer as received from the issuer.
+	Offer openid4vci.CredentialOffer `json:"offer"`
+	// ReceivedAt is the time the credential offer was received.
+	ReceivedAt time.Time `json:"receivedAt"`
+	// ExpiresAt is the time after which the credential offer is discarded if it wasn't accepted or rejected.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (o PendingCredentialOffer) expired() bool {
+	return !nowFunc().Before(o.ExpiresAt)
+}
+
+func (h *openidHandler) ListCredentialOffers(ctx context.Context) ([]PendingCredentialOffer, error) {
+	result := make([]PendingCredentialOffer, 0)
+	var expired []stoabs.Key
+	err := h.offerStore.ReadShelf(ctx, h.did.String(), func(reader stoabs.Reader) error {
+		return reader.Iterate(func(key stoabs.Key, value []byte) error {
+			var offer PendingCredentialOffer
+			if err := json.Unmarshal(value, &offer); err != nil {
+				return fmt.Errorf("unable to unmarshal credential offer %s: %w", string(key.Bytes()), err)
+			}
+			if offer.expired() {
+				expired = append(expired, key)
+			} else {
+				result = append(result, offer)
+			}
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list credential offers: %w", err)
+	}
+	if len(expired) > 0 {
+		err = h.offerStore.WriteShelf(ctx, h.did.String(), func(writer stoabs.Writer) error {
+			for _, key := range expired {
+				if err := writer.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Logger().WithError(err).Warn("Failed to discard expired OpenID4VCI credential offers")
+		}
+	}
+	slices.SortFunc(result, func(a, b PendingCredentialOffer) int {
+		return a.ReceivedAt.Compare(b.ReceivedAt)
+	})
+	return result, nil
+}
+
+func (h *openidHandler) AcceptCredentialOffer(ctx context.Context, id string) error {
+	offer, err := h.takeCredentialOffer(ctx, id)
+	if err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, h.did.String()).
+		Infof("Accepted OpenID4VCI credential offer (id=%s, issuer=%s)", id, offer.Offer.CredentialIssuer)
+	// The holder consented, so the credential is accepted from any issuer.
+	return h.acceptCredentialOffer(ctx, offer.Offer, "")
+}
+
+func (h *openidHandler) RejectCredentialOffer(ctx context.Context, id string) error {
+	offer, err := h.takeCredentialOffer(ctx, id)
+	if err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, h.did.String()).
+		Infof("Rejected OpenID4VCI credential offer (id=%s, issuer=%s)", id, offer.Offer.CredentialIssuer)
+	return nil
+}
+
+// queueCredentialOffer stores the credential offer, so the holder can accept or reject it later.
+// Since credential offers are unauthenticated, expired offers are discarded and the number of pending offers
+// is limited to consent.MaxPending, so the store can't be flooded.
+func (h *openidHandler) queueCredentialOffer(ctx context.Context, offer openid4vci.CredentialOffer) error {
+	now := nowFunc()
+	pendingOffer := PendingCredentialOffer{
+		ID:         uuid.NewString(),
+		Offer:      offer,
+		ReceivedAt: now,
+		ExpiresAt:  now.Add(h.consent.Expiry),
+	}
+	data, _ := json.Marshal(pendingOffer)
+	err := h.offerStore.Write(ctx, func(tx stoabs.WriteTx) error {
+		writer := tx.GetShelfWriter(h.did.String())
+		var pending int
+		var expired []stoabs.Key
+		err := writer.Iterate(func(key stoabs.Key, value []byte) error {
+			var current PendingCredentialOffer
+			if err := json.Unmarshal(value, &current); err != nil || current.expired() {
+				expired = append(expired, key)
+			} else {
+				pending++
+			}
+			return nil
+		}, stoabs.BytesKey{})
+		if err != nil {
+			return err
+		}
+		for _, key := range expired {
+			if err := writer.Delete(key); err != nil {
+				return err
+			}
+		}
+		if h.consent.MaxPending > 0 && pending >= h.consent.MaxPending {
+			return errTooManyCredentialOffers
+		}
+		return writer.Put(stoabs.BytesKey(pendingOffer.ID), data)
+	}, stoabs.WithWriteLock())
+	if errors.Is(err, errTooManyCredentialOffers) {
+		log.Logger().
+			WithField(core.LogFieldDID, h.did.String()).
+			Warnf("Refused OpenID4VCI credential offer, too many offers await holder consent (issuer=%s)", offer.CredentialIssuer)
+		return openid4vci.Error{
+			Err:        err,
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusTooManyRequests,
+		}
+	} else if err != nil {
+		return fmt.Errorf("unable to store credential offer: %w", err)
+	}
+	log.Logger().
+		WithField(core.LogFieldDID, h.did.String()).
+		Infof("Received OpenID4VCI credential offer, awaiting holder consent (id=%s, issuer=%s)", pendingOffer.ID, offer.CredentialIssuer)
+	return nil
+}
+
+// takeCredentialOffer removes the pending credential offer from the store and returns it.
+// Removing it makes sure an offer can only be accepted or rejected once.
+func (h *openidHandler) takeCredentialOffer(ctx context.Context, id string) (*PendingCredentialOffer, error) {
+	var result PendingCredentialOffer
+	err := h.offerStore.WriteShelf(ctx, h.did.String(), func(writer stoabs.Writer) error {
+		key := stoabs.BytesKey(id)
+		data, err := writer.Get(key)
+		if errors.Is(err, stoabs.ErrKeyNotFound) {
+			return ErrCredentialOfferNotFound
+		} else if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(data, &result); err != nil {
+			return fmt.Errorf("unable to unmarshal credential offer %s: %w", id, err)
+		}
+		return writer.Delete(key)
+	})
+	if errors.Is(err, ErrCredentialOfferNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to retrieve credential offer: %w", err)
+	}
+	if result.expired() {
+		return nil, ErrCredentialOfferNotFound
+	}
+	return &result, nil
+}
+
+// isAutoAccepted returns whether the credential offer is accepted without the holder's consent,
+// because one of the offered credential types or its issuer is configured to be accepted automatically.
+// The offered types are enforced when the credential is received (it must match the credential definition),
+// but the offer's credential_issuer isn't authenticated. So if the offer is accepted because of its issuer,
+// that issuer DID is returned: the received credential must be issued by it.
+func (h *openidHandler) isAutoAccepted(offer openid4vci.CredentialOffer) (bool, string) {
+	for _, credentialType := range offer.Credentials[0].CredentialDefinition.Type {
+		if slices.Contains(h.consent.AutoAcceptTypes, credentialType.String()) {
+			return true, ""
+		}
+	}
+	issuerDID := issuerDIDFromIdentifier(offer.CredentialIssuer)
+	if issuerDID != "" && slices.Contains(h.consent.AutoAcceptIssuers, issuerDID) {
+		return true, issuerDID
+	}
+	return false, ""
+}
+
+// issuerDIDFromIdentifier derives the issuer's DID from its Credential Issuer Identifier (see openid4vci.CreateIdentifier),
+// which ends with the URL-encoded DID. It returns an empty string if the identifier doesn't end with a DID.
+func issuerDIDFromIdentifier(identifier string) string {
+	parsedURL, err := url.Parse(identifier)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.TrimSuffix(parsedURL.EscapedPath(), "/"), "/")
+	lastSegment, err := url.PathUnescape(segments[len(segments)-1])
+	if err != nil {
+		return ""
+	}
+	issuerDID, err := did.ParseDID(lastSegment)
+	if err != nil {
+		return ""
+	}
+	return issuerDID.String()
+}
@@ -0,0 +1,342 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package holder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_openidHandler_CredentialOfferConsent(t *testing.T) {
+	credentialOffer := openid4vci.CredentialOffer{
+		CredentialIssuer: "https://issuer.example.com/n2n/identity/did:nuts:issuer",
+		Credentials:      offeredCredential(),
+		Grants: map[string]interface{}{
+			"urn:ietf:params:oauth:grant-type:pre-authorized_code": map[string]interface{}{
+				"pre-authorized_code": "code",
+			},
+		},
+	}
+	consent := openid4vci.ConsentConfig{
+		Required: true,
+		Expiry:   time.Minute,
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nowFunc = func() time.Time {
+		return now
+	}
+	t.Cleanup(func() {
+		nowFunc = time.Now
+	})
+	createHandler := func(t *testing.T, offerStore stoabs.KVStore) *openidHandler {
+		return NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, offerStore, consent).(*openidHandler)
+	}
+	queueOffer := func(t *testing.T, w *openidHandler) PendingCredentialOffer {
+		status, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+		require.NoError(t, err)
+		require.Equal(t, openid4vci.CredentialOfferStatusPending, status)
+		offers, err := w.ListCredentialOffers(context.Background())
+		require.NoError(t, err)
+		require.Len(t, offers, 1)
+		return offers[0]
+	}
+
+	t.Run("offer is queued", func(t *testing.T) {
+		w := createHandler(t, newTestOfferStore(t))
+
+		offer := queueOffer(t, w)
+
+		assert.NotEmpty(t, offer.ID)
+		assert.Equal(t, credentialOffer.CredentialIssuer, offer.Offer.CredentialIssuer)
+		assert.Equal(t, now, offer.ReceivedAt.UTC())
+		assert.Equal(t, now.Add(time.Minute), offer.ExpiresAt.UTC())
+		t.Run("offers are kept per holder", func(t *testing.T) {
+			other := NewOpenIDHandler(did.MustParseDID("did:nuts:other"), "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, w.offerStore, consent)
+
+			offers, err := other.ListCredentialOffers(context.Background())
+
+			require.NoError(t, err)
+			assert.Empty(t, offers)
+		})
+	})
+	t.Run("invalid offer is not queued", func(t *testing.T) {
+		w := createHandler(t, newTestOfferStore(t))
+
+		_, err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{})
+
+		require.EqualError(t, err, "invalid_request - there must be exactly 1 credential in credential offer")
+		offers, err := w.ListCredentialOffers(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, offers)
+	})
+	t.Run("accept", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+		issuerAPIClient.EXPECT().RequestAccessToken("urn:ietf:params:oauth:grant-type:pre-authorized_code", map[string]string{
+			"pre-authorized_code": "code",
+		}).Return(nil, errors.New("failed"))
+		w := createHandler(t, newTestOfferStore(t))
+		w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error) {
+			assert.Equal(t, credentialOffer.CredentialIssuer, credentialIssuerIdentifier)
+			return issuerAPIClient, nil
+		}
+		offer := queueOffer(t, w)
+
+		err := w.AcceptCredentialOffer(audit.TestContext(), offer.ID)
+
+		// the credential is retrieved using the offered grant
+		require.EqualError(t, err, "invalid_token - unable to request access token: failed")
+		t.Run("offer can only be accepted once", func(t *testing.T) {
+			err := w.AcceptCredentialOffer(audit.TestContext(), offer.ID)
+
+			assert.ErrorIs(t, err, ErrCredentialOfferNotFound)
+		})
+	})
+	t.Run("reject", func(t *testing.T) {
+		w := createHandler(t, newTestOfferStore(t))
+		offer := queueOffer(t, w)
+
+		err := w.RejectCredentialOffer(audit.TestContext(), offer.ID)
+
+		require.NoError(t, err)
+		offers, err := w.ListCredentialOffers(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, offers)
+		t.Run("offer can't be accepted after rejection", func(t *testing.T) {
+			err := w.AcceptCredentialOffer(audit.TestContext(), offer.ID)
+
+			assert.ErrorIs(t, err, ErrCredentialOfferNotFound)
+		})
+	})
+	t.Run("unknown offer", func(t *testing.T) {
+		w := createHandler(t, newTestOfferStore(t))
+
+		assert.ErrorIs(t, w.AcceptCredentialOffer(audit.TestContext(), "unknown"), ErrCredentialOfferNotFound)
+		assert.ErrorIs(t, w.RejectCredentialOffer(audit.TestContext(), "unknown"), ErrCredentialOfferNotFound)
+	})
+	t.Run("expired offers are discarded", func(t *testing.T) {
+		w := createHandler(t, newTestOfferStore(t))
+		offer := queueOffer(t, w)
+		nowFunc = func() time.Time {
+			return now.Add(time.Minute)
+		}
+		defer func() {
+			nowFunc = func() time.Time {
+				return now
+			}
+		}()
+
+		offers, err := w.ListCredentialOffers(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, offers)
+		assert.ErrorIs(t, w.AcceptCredentialOffer(audit.TestContext(), offer.ID), ErrCredentialOfferNotFound)
+	})
+	t.Run("number of pending offers is limited", func(t *testing.T) {
+		w := createHandler(t, newTestOfferStore(t))
+		w.consent.MaxPending = 2
+		for i := 0; i < 2; i++ {
+			_, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+			require.NoError(t, err)
+		}
+
+		_, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+
+		assert.EqualError(t, err, "invalid_request - too many pending credential offers")
+		var protocolError openid4vci.Error
+		require.ErrorAs(t, err, &protocolError)
+		assert.Equal(t, http.StatusTooManyRequests, protocolError.StatusCode)
+		t.Run("expired offers are discarded when an offer is received", func(t *testing.T) {
+			nowFunc = func() time.Time {
+				return now.Add(time.Minute)
+			}
+			defer func() {
+				nowFunc = func() time.Time {
+					return now
+				}
+			}()
+
+			_, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+
+			require.NoError(t, err)
+			var stored int
+			err = w.offerStore.ReadShelf(context.Background(), holderDID.String(), func(reader stoabs.Reader) error {
+				return reader.Iterate(func(_ stoabs.Key, _ []byte) error {
+					stored++
+					return nil
+				}, stoabs.BytesKey{})
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, stored)
+		})
+	})
+}
+
+func Test_openidHandler_isAutoAccepted(t *testing.T) {
+	offer := openid4vci.CredentialOffer{
+		CredentialIssuer: "https://issuer.example.com/n2n/identity/did:web:example.com%253A8080:iam:issuer",
+		Credentials:      offeredCredential(),
+	}
+	t.Run("no rules", func(t *testing.T) {
+		w := openidHandler{}
+
+		accepted, _ := w.isAutoAccepted(offer)
+
+		assert.False(t, accepted)
+	})
+	t.Run("issuer", func(t *testing.T) {
+		w := openidHandler{consent: openid4vci.ConsentConfig{AutoAcceptIssuers: []string{"did:web:example.com%3A8080:iam:issuer"}}}
+
+		accepted, expectedIssuer := w.isAutoAccepted(offer)
+
+		assert.True(t, accepted)
+		assert.Equal(t, "did:web:example.com%3A8080:iam:issuer", expectedIssuer)
+	})
+	t.Run("other issuer", func(t *testing.T) {
+		w := openidHandler{consent: openid4vci.ConsentConfig{AutoAcceptIssuers: []string{"did:web:example.com:iam:other"}}}
+
+		accepted, _ := w.isAutoAccepted(offer)
+
+		assert.False(t, accepted)
+	})
+	t.Run("credential type", func(t *testing.T) {
+		w := openidHandler{consent: openid4vci.ConsentConfig{AutoAcceptTypes: []string{"HumanCredential"}}}
+
+		accepted, expectedIssuer := w.isAutoAccepted(offer)
+
+		assert.True(t, accepted)
+		assert.Empty(t, expectedIssuer)
+	})
+	t.Run("other credential type", func(t *testing.T) {
+		w := openidHandler{consent: openid4vci.ConsentConfig{AutoAcceptTypes: []string{"OtherCredential"}}}
+
+		accepted, _ := w.isAutoAccepted(offer)
+
+		assert.False(t, accepted)
+	})
+}
+
+func Test_openidHandler_AutoAcceptedIssuer(t *testing.T) {
+	trustedIssuer := did.MustParseDID("did:web:trusted.example.com")
+	consent := openid4vci.ConsentConfig{
+		Required:          true,
+		AutoAcceptIssuers: []string{trustedIssuer.String()},
+	}
+	nonce := "nonce"
+	// receive sets up a wallet that receives a credential issued by the given DID, from the issuer in the given offer.
+	receive := func(t *testing.T, offer openid4vci.CredentialOffer, credentialIssuer did.DID) (*openidHandler, *types.MockWriter) {
+		ctrl := gomock.NewController(t)
+		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+		issuerAPIClient.EXPECT().Metadata().Return(openid4vci.CredentialIssuerMetadata{CredentialIssuer: offer.CredentialIssuer}).AnyTimes()
+		issuerAPIClient.EXPECT().RequestAccessToken(openid4vci.PreAuthorizedCodeGrant, gomock.Any()).Return(&oauth.TokenResponse{
+			AccessToken: "access-token",
+			CNonce:      &nonce,
+		}, nil)
+		issuerAPIClient.EXPECT().RequestCredential(gomock.Any(), gomock.Any(), "access-token").Return(&vc.VerifiableCredential{
+			Context: []ssi.URI{ssi.MustParseURI("https://www.w3.org/2018/credentials/v1"), ssi.MustParseURI("http://example.org/credentials/V1")},
+			Type:    []ssi.URI{ssi.MustParseURI("VerifiableCredential"), ssi.MustParseURI("HumanCredential")},
+			Issuer:  credentialIssuer.URI(),
+		}, nil)
+		jwtSigner := crypto.NewMockJWTSigner(ctrl)
+		jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), "key-id").Return("signed-jwt", nil)
+		keyResolver := resolver.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.NutsSigningKeyType).Return(ssi.MustParseURI("key-id"), nil, nil)
+		credentialStore := types.NewMockWriter(ctrl)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, credentialStore, jwtSigner, keyResolver, nil, newTestOfferStore(t), consent).(*openidHandler)
+		w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, _ string) (openid4vci.IssuerAPIClient, error) {
+			return issuerAPIClient, nil
+		}
+		return w, credentialStore
+	}
+	createOffer := func(credentialIssuer string) openid4vci.CredentialOffer {
+		return openid4vci.CredentialOffer{
+			CredentialIssuer: credentialIssuer,
+			Credentials:      offeredCredential(),
+			Grants: map[string]interface{}{
+				openid4vci.PreAuthorizedCodeGrant: map[string]interface{}{
+					"pre-authorized_code": "code",
+				},
+			},
+		}
+	}
+
+	t.Run("credential issued by allow-listed issuer is stored", func(t *testing.T) {
+		offer := createOffer("https://trusted.example.com/n2n/identity/did:web:trusted.example.com")
+		w, credentialStore := receive(t, offer, trustedIssuer)
+		credentialStore.EXPECT().StoreCredential(gomock.Any(), nil).Return(nil)
+
+		status, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+
+		require.NoError(t, err)
+		assert.Equal(t, openid4vci.CredentialOfferStatusReceived, status)
+	})
+	t.Run("credential_issuer URL spoofing an allow-listed issuer is rejected", func(t *testing.T) {
+		// the offer isn't authenticated: anyone can put the DID of an allow-listed issuer at the end of the credential_issuer URL
+		offer := createOffer("https://attacker.example.com/x/did:web:trusted.example.com")
+		w, _ := receive(t, offer, did.MustParseDID("did:web:attacker.example.com"))
+		// no StoreCredential call expected
+
+		_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+
+		require.EqualError(t, err, "invalid_request - received credential is not issued by the auto-accepted issuer (expected=did:web:trusted.example.com, actual=did:web:attacker.example.com)")
+	})
+}
+
+func Test_issuerDIDFromIdentifier(t *testing.T) {
+	testCases := []struct {
+		identifier string
+		expected   string
+	}{
+		{"https://example.com/n2n/identity/did:nuts:issuer", "did:nuts:issuer"},
+		{"https://example.com/n2n/identity/did:nuts:issuer/", "did:nuts:issuer"},
+		{"https://example.com/n2n/identity/did:web:example.com%253A8080", "did:web:example.com%3A8080"},
+		{"https://example.com/issuer", ""},
+		{"did:nuts:issuer", ""},
+		{"", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.identifier, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, issuerDIDFromIdentifier(testCase.identifier))
+		})
+	}
+}
+
+func newTestOfferStore(t *testing.T) stoabs.KVStore {
+	store, err := storage.NewTestStorageEngine(t).GetProvider("test").GetKVStore("credential-offers", storage.PersistentStorageClass)
+	require.NoError(t, err)
+	return store
+}
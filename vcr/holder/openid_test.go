@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -43,12 +44,12 @@ var holderDID = did.MustParseDID("did:nuts:holder")
 var issuerDID = did.MustParseDID("did:nuts:issuer")
 
 func TestNewOIDCWallet(t *testing.T) {
-	w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil)
+	w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{})
 	assert.NotNil(t, w)
 }
 
 func Test_wallet_Metadata(t *testing.T) {
-	w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil)
+	w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{})
 
 	metadata := w.Metadata()
 
@@ -104,23 +105,24 @@ func Test_wallet_HandleCredentialOffer(t *testing.T) {
 			return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 		}
 
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, credentialStore, jwtSigner, keyResolver).(*openidHandler)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, credentialStore, jwtSigner, keyResolver, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
 		w.issuerClientCreator = func(_ context.Context, httpClient core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error) {
 			return issuerAPIClient, nil
 		}
 
 		credentialStore.EXPECT().StoreCredential(gomock.Any(), nil).Return(nil)
 
-		err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+		status, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
 
 		require.NoError(t, err)
+		assert.Equal(t, openid4vci.CredentialOfferStatusReceived, status)
 	})
 	t.Run("pre-authorized code grant", func(t *testing.T) {
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil).(*openidHandler)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
 		t.Run("no grants", func(t *testing.T) {
 			offer := openid4vci.CredentialOffer{Credentials: offeredCredential()}
-			err := w.HandleCredentialOffer(audit.TestContext(), offer)
-			require.EqualError(t, err, "invalid_grant - couldn't find (valid) pre-authorized code grant in credential offer")
+			_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+			require.EqualError(t, err, "invalid_grant - couldn't find (valid) pre-authorized code or authorization code grant in credential offer")
 		})
 		t.Run("no pre-authorized grant", func(t *testing.T) {
 			offer := openid4vci.CredentialOffer{
@@ -129,8 +131,8 @@ func Test_wallet_HandleCredentialOffer(t *testing.T) {
 					"some-other-grant": nil,
 				},
 			}
-			err := w.HandleCredentialOffer(audit.TestContext(), offer)
-			require.EqualError(t, err, "invalid_grant - couldn't find (valid) pre-authorized code grant in credential offer")
+			_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+			require.EqualError(t, err, "invalid_grant - couldn't find (valid) pre-authorized code or authorization code grant in credential offer")
 		})
 		t.Run("invalid pre-authorized grant", func(t *testing.T) {
 			offer := openid4vci.CredentialOffer{
@@ -141,12 +143,129 @@ func Test_wallet_HandleCredentialOffer(t *testing.T) {
 					},
 				},
 			}
-			err := w.HandleCredentialOffer(audit.TestContext(), offer)
-			require.EqualError(t, err, "invalid_grant - couldn't find (valid) pre-authorized code grant in credential offer")
+			_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+			require.EqualError(t, err, "invalid_grant - couldn't find (valid) pre-authorized code or authorization code grant in credential offer")
+		})
+	})
+	t.Run("authorization code grant", func(t *testing.T) {
+		offer := openid4vci.CredentialOffer{
+			CredentialIssuer: issuerDID.String(),
+			Credentials:      offeredCredential(),
+			Grants: map[string]interface{}{
+				"authorization_code": map[string]interface{}{
+					"issuer_state": "issuer-state",
+				},
+			},
+		}
+		t.Run("ok", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+			issuerAPIClient.EXPECT().Metadata().Return(metadata)
+			var authorizationParams map[string]string
+			issuerAPIClient.EXPECT().RequestAuthorization(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, params map[string]string) error {
+				authorizationParams = params
+				return nil
+			})
+			var requestObject map[string]interface{}
+			jwtSigner := crypto.NewMockJWTSigner(ctrl)
+			jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), nil, "key-id").DoAndReturn(func(_ context.Context, claims map[string]interface{}, _ map[string]interface{}, _ interface{}) (string, error) {
+				requestObject = claims
+				return "signed-request", nil
+			})
+			keyResolver := resolver.NewMockKeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.AssertionMethod).Return(ssi.MustParseURI("key-id"), nil, nil)
+			sessionDatabase := storage.NewTestInMemorySessionDatabase(t)
+			w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, jwtSigner, keyResolver, sessionDatabase, nil, openid4vci.ConsentConfig{}).(*openidHandler)
+			w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, _ string) (openid4vci.IssuerAPIClient, error) {
+				return issuerAPIClient, nil
+			}
+
+			_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"client_id": holderDID.String(), "request": "signed-request"}, authorizationParams)
+			assert.Equal(t, holderDID.String(), requestObject["iss"])
+			assert.Equal(t, issuerDID.String(), requestObject["aud"])
+			assert.Equal(t, "code", requestObject["response_type"])
+			assert.Equal(t, holderDID.String(), requestObject["client_id"])
+			assert.Equal(t, "https://holder.example.com/openid4vci/callback", requestObject["redirect_uri"])
+			assert.Equal(t, "issuer-state", requestObject["issuer_state"])
+			assert.Nil(t, requestObject["authorization_details"])
+			assert.Equal(t, "S256", requestObject["code_challenge_method"])
+			state, _ := requestObject["state"].(string)
+			require.NotEmpty(t, state)
+			var session authorizationSession
+			require.NoError(t, w.authorizationSessionStore().Get(state, &session))
+			assert.Equal(t, issuerDID.String(), session.CredentialIssuer)
+			assert.Equal(t, *offeredCredential()[0].CredentialDefinition, session.CredentialDefinition)
+			assert.True(t, oauth.ValidatePKCEParams(requestObject["code_challenge"].(string), "S256", session.CodeVerifier))
+		})
+		t.Run("pre-authorized code grant is preferred", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+			issuerAPIClient.EXPECT().RequestAccessToken(openid4vci.PreAuthorizedCodeGrant, gomock.Any()).Return(nil, errors.New("request failed"))
+			w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
+			w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, _ string) (openid4vci.IssuerAPIClient, error) {
+				return issuerAPIClient, nil
+			}
+			offer := openid4vci.CredentialOffer{
+				CredentialIssuer: issuerDID.String(),
+				Credentials:      offeredCredential(),
+				Grants: map[string]interface{}{
+					"authorization_code": map[string]interface{}{},
+					"urn:ietf:params:oauth:grant-type:pre-authorized_code": map[string]interface{}{
+						"pre-authorized_code": "code",
+					},
+				},
+			}
+
+			_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+
+			require.EqualError(t, err, "invalid_token - unable to request access token: request failed")
+		})
+		t.Run("error - authorization request fails", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+			issuerAPIClient.EXPECT().Metadata().Return(metadata)
+			issuerAPIClient.EXPECT().RequestAuthorization(gomock.Any(), gomock.Any()).Return(errors.New("request failed"))
+			var state string
+			jwtSigner := crypto.NewMockJWTSigner(ctrl)
+			jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), nil, "key-id").DoAndReturn(func(_ context.Context, claims map[string]interface{}, _ map[string]interface{}, _ interface{}) (string, error) {
+				state = claims["state"].(string)
+				return "signed-request", nil
+			})
+			keyResolver := resolver.NewMockKeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.AssertionMethod).Return(ssi.MustParseURI("key-id"), nil, nil)
+			sessionDatabase := storage.NewTestInMemorySessionDatabase(t)
+			w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, jwtSigner, keyResolver, sessionDatabase, nil, openid4vci.ConsentConfig{}).(*openidHandler)
+			w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, _ string) (openid4vci.IssuerAPIClient, error) {
+				return issuerAPIClient, nil
+			}
+
+			_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+
+			require.EqualError(t, err, "server_error - unable to request authorization: request failed")
+			assert.False(t, w.authorizationSessionStore().Exists(state))
+		})
+		t.Run("error - signing authorization request fails", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+			issuerAPIClient.EXPECT().Metadata().Return(metadata)
+			keyResolver := resolver.NewMockKeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.AssertionMethod).Return(ssi.URI{}, nil, resolver.ErrKeyNotFound)
+			w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, keyResolver, storage.NewTestInMemorySessionDatabase(t), nil, openid4vci.ConsentConfig{}).(*openidHandler)
+			w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, _ string) (openid4vci.IssuerAPIClient, error) {
+				return issuerAPIClient, nil
+			}
+
+			_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
+
+			require.ErrorIs(t, err, resolver.ErrKeyNotFound)
+			require.ErrorContains(t, err, "failed to resolve key for signing authorization request")
 		})
 	})
 	t.Run("error - too many credentials in offer", func(t *testing.T) {
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{})
 
 		offer := openid4vci.CredentialOffer{
 			Credentials: []openid4vci.OfferedCredential{
@@ -154,22 +273,22 @@ func Test_wallet_HandleCredentialOffer(t *testing.T) {
 				offeredCredential()[0],
 			},
 		}
-		err := w.HandleCredentialOffer(audit.TestContext(), offer).(openid4vci.Error)
+		_, err := w.HandleCredentialOffer(audit.TestContext(), offer)
 
 		assert.EqualError(t, err, "invalid_request - there must be exactly 1 credential in credential offer")
-		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, err.(openid4vci.Error).StatusCode)
 	})
 	t.Run("error - access token request fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
 		issuerAPIClient.EXPECT().RequestAccessToken(gomock.Any(), gomock.Any()).Return(nil, errors.New("request failed"))
 
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil).(*openidHandler)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
 		w.issuerClientCreator = func(_ context.Context, httpClient core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error) {
 			return issuerAPIClient, nil
 		}
 
-		err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+		_, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
 
 		require.EqualError(t, err, "invalid_token - unable to request access token: request failed")
 	})
@@ -178,12 +297,12 @@ func Test_wallet_HandleCredentialOffer(t *testing.T) {
 		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
 		issuerAPIClient.EXPECT().RequestAccessToken(gomock.Any(), gomock.Any()).Return(&oauth.TokenResponse{}, nil)
 
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil).(*openidHandler)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
 		w.issuerClientCreator = func(_ context.Context, httpClient core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error) {
 			return issuerAPIClient, nil
 		}
 
-		err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+		_, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
 
 		require.EqualError(t, err, "invalid_token - access_token is missing")
 	})
@@ -192,27 +311,27 @@ func Test_wallet_HandleCredentialOffer(t *testing.T) {
 		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
 		issuerAPIClient.EXPECT().RequestAccessToken(gomock.Any(), gomock.Any()).Return(&oauth.TokenResponse{AccessToken: "foo"}, nil)
 
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil).(*openidHandler)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
 		w.issuerClientCreator = func(_ context.Context, httpClient core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error) {
 			return issuerAPIClient, nil
 		}
 
-		err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+		_, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
 
 		require.EqualError(t, err, "invalid_token - c_nonce is missing")
 	})
 	t.Run("error - no credentials in offer", func(t *testing.T) {
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{})
 
-		err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{}).(openid4vci.Error)
+		_, err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{})
 
 		assert.EqualError(t, err, "invalid_request - there must be exactly 1 credential in credential offer")
-		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, err.(openid4vci.Error).StatusCode)
 	})
 	t.Run("error - can't issuer client (metadata can't be loaded)", func(t *testing.T) {
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{})
 
-		err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{
+		_, err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{
 			CredentialIssuer: "http://localhost:87632",
 			Credentials:      offeredCredential(),
 			Grants: map[string]interface{}{
@@ -240,34 +359,158 @@ func Test_wallet_HandleCredentialOffer(t *testing.T) {
 		keyResolver := resolver.NewMockKeyResolver(ctrl)
 		keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.NutsSigningKeyType)
 
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, jwtSigner, keyResolver).(*openidHandler)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, jwtSigner, keyResolver, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
 		w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, _ string) (openid4vci.IssuerAPIClient, error) {
 			return issuerAPIClient, nil
 		}
 
-		err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
+		_, err := w.HandleCredentialOffer(audit.TestContext(), credentialOffer)
 
 		require.EqualError(t, err, "invalid_request - received credential does not match offer: credential does not match credential_definition: type mismatch")
 	})
 	t.Run("error - unsupported format", func(t *testing.T) {
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{})
 
-		err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{
+		_, err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{
 			Credentials: []openid4vci.OfferedCredential{{Format: "not supported"}},
-		}).(openid4vci.Error)
+		})
 
 		assert.EqualError(t, err, "unsupported_credential_type - credential offer: unsupported format 'not supported'")
-		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, err.(openid4vci.Error).StatusCode)
 	})
 	t.Run("error - credentialSubject not allowed in offer", func(t *testing.T) {
-		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{})
 		credentials := offeredCredential()
 		credentials[0].CredentialDefinition.CredentialSubject = new(map[string]interface{})
 
-		err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{Credentials: credentials}).(openid4vci.Error)
+		_, err := w.HandleCredentialOffer(audit.TestContext(), openid4vci.CredentialOffer{Credentials: credentials})
 
 		assert.EqualError(t, err, "invalid_request - credential offer: invalid credential_definition: credentialSubject not allowed in offer")
-		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, err.(openid4vci.Error).StatusCode)
+	})
+}
+
+func Test_wallet_RequestCredential(t *testing.T) {
+	metadata := openid4vci.CredentialIssuerMetadata{
+		CredentialIssuer:   issuerDID.String(),
+		CredentialEndpoint: "credential-endpoint",
+	}
+	definition := *offeredCredential()[0].CredentialDefinition
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+		issuerAPIClient.EXPECT().Metadata().Return(metadata)
+		issuerAPIClient.EXPECT().RequestAuthorization(gomock.Any(), map[string]string{"client_id": holderDID.String(), "request": "signed-request"}).Return(nil)
+		var requestObject map[string]interface{}
+		jwtSigner := crypto.NewMockJWTSigner(ctrl)
+		jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), nil, "key-id").DoAndReturn(func(_ context.Context, claims map[string]interface{}, _ map[string]interface{}, _ interface{}) (string, error) {
+			requestObject = claims
+			return "signed-request", nil
+		})
+		keyResolver := resolver.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.AssertionMethod).Return(ssi.MustParseURI("key-id"), nil, nil)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, jwtSigner, keyResolver, storage.NewTestInMemorySessionDatabase(t), nil, openid4vci.ConsentConfig{}).(*openidHandler)
+		w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, credentialIssuer string) (openid4vci.IssuerAPIClient, error) {
+			assert.Equal(t, issuerDID.String(), credentialIssuer)
+			return issuerAPIClient, nil
+		}
+
+		err := w.RequestCredential(audit.TestContext(), issuerDID.String(), definition)
+
+		require.NoError(t, err)
+		assert.Nil(t, requestObject["issuer_state"])
+		assert.Equal(t, []openid4vci.AuthorizationDetails{{
+			Type:                 "openid_credential",
+			Format:               "ldp_vc",
+			CredentialDefinition: &definition,
+		}}, requestObject["authorization_details"])
+		var session authorizationSession
+		require.NoError(t, w.authorizationSessionStore().Get(requestObject["state"].(string), &session))
+		assert.Equal(t, definition, session.CredentialDefinition)
+	})
+	t.Run("error - can't create issuer client", func(t *testing.T) {
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, nil, nil, openid4vci.ConsentConfig{}).(*openidHandler)
+		w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, _ string) (openid4vci.IssuerAPIClient, error) {
+			return nil, errors.New("failed")
+		}
+
+		err := w.RequestCredential(audit.TestContext(), issuerDID.String(), definition)
+
+		require.EqualError(t, err, "server_error - unable to create issuer client: failed")
+	})
+}
+
+func Test_wallet_HandleAuthorizationResponse(t *testing.T) {
+	metadata := openid4vci.CredentialIssuerMetadata{
+		CredentialIssuer:   issuerDID.String(),
+		CredentialEndpoint: "credential-endpoint",
+	}
+	nonce := "nonsens"
+	session := authorizationSession{
+		WalletDID:            holderDID.String(),
+		CredentialIssuer:     issuerDID.String(),
+		CredentialDefinition: *offeredCredential()[0].CredentialDefinition,
+		CodeVerifier:         "verifier",
+		RedirectURI:          "https://holder.example.com/openid4vci/callback",
+	}
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		issuerAPIClient := openid4vci.NewMockIssuerAPIClient(ctrl)
+		issuerAPIClient.EXPECT().Metadata().Return(metadata)
+		issuerAPIClient.EXPECT().RequestAccessToken("authorization_code", map[string]string{
+			"code":          "auth-code",
+			"code_verifier": "verifier",
+			"redirect_uri":  "https://holder.example.com/openid4vci/callback",
+			"client_id":     holderDID.String(),
+		}).Return(&oauth.TokenResponse{AccessToken: "access-token", CNonce: &nonce}, nil)
+		issuerAPIClient.EXPECT().RequestCredential(gomock.Any(), gomock.Any(), "access-token").
+			Return(&vc.VerifiableCredential{
+				Context: []ssi.URI{ssi.MustParseURI("https://www.w3.org/2018/credentials/v1"), ssi.MustParseURI("http://example.org/credentials/V1")},
+				Type:    []ssi.URI{ssi.MustParseURI("VerifiableCredential"), ssi.MustParseURI("HumanCredential")},
+				Issuer:  issuerDID.URI()}, nil)
+		credentialStore := types.NewMockWriter(ctrl)
+		credentialStore.EXPECT().StoreCredential(gomock.Any(), nil).Return(nil)
+		jwtSigner := crypto.NewMockJWTSigner(ctrl)
+		jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), "key-id").Return("signed-jwt", nil)
+		keyResolver := resolver.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveKey(holderDID, nil, resolver.NutsSigningKeyType).Return(ssi.MustParseURI("key-id"), nil, nil)
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, credentialStore, jwtSigner, keyResolver, storage.NewTestInMemorySessionDatabase(t), nil, openid4vci.ConsentConfig{}).(*openidHandler)
+		w.issuerClientCreator = func(_ context.Context, _ core.HTTPRequestDoer, credentialIssuerIdentifier string) (openid4vci.IssuerAPIClient, error) {
+			assert.Equal(t, issuerDID.String(), credentialIssuerIdentifier)
+			return issuerAPIClient, nil
+		}
+		require.NoError(t, w.authorizationSessionStore().Put("state", session))
+
+		err := w.HandleAuthorizationResponse(audit.TestContext(), "auth-code", "state")
+
+		require.NoError(t, err)
+		t.Run("state can only be used once", func(t *testing.T) {
+			err := w.HandleAuthorizationResponse(audit.TestContext(), "auth-code", "state")
+
+			require.EqualError(t, err, "invalid_request - unknown or expired state")
+		})
+	})
+	t.Run("error - missing code", func(t *testing.T) {
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, storage.NewTestInMemorySessionDatabase(t), nil, openid4vci.ConsentConfig{})
+
+		err := w.HandleAuthorizationResponse(audit.TestContext(), "", "state")
+
+		require.EqualError(t, err, "invalid_request - missing code or state")
+	})
+	t.Run("error - unknown state", func(t *testing.T) {
+		w := NewOpenIDHandler(holderDID, "https://holder.example.com", &http.Client{}, nil, nil, nil, storage.NewTestInMemorySessionDatabase(t), nil, openid4vci.ConsentConfig{})
+
+		err := w.HandleAuthorizationResponse(audit.TestContext(), "auth-code", "state")
+
+		require.EqualError(t, err, "invalid_request - unknown or expired state")
+	})
+	t.Run("error - state issued for other wallet", func(t *testing.T) {
+		w := NewOpenIDHandler(did.MustParseDID("did:nuts:other"), "https://holder.example.com", &http.Client{}, nil, nil, nil, storage.NewTestInMemorySessionDatabase(t), nil, openid4vci.ConsentConfig{}).(*openidHandler)
+		require.NoError(t, w.authorizationSessionStore().Put("state", session))
+
+		err := w.HandleAuthorizationResponse(audit.TestContext(), "auth-code", "state")
+
+		require.EqualError(t, err, "invalid_request - state was not issued for this wallet")
 	})
 }
 
@@ -38,8 +38,10 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"strings"
@@ -48,6 +50,9 @@ import (
 
 const statsShelf = "stats"
 
+// flaggedShelf contains the credentials that were found to be expired or revoked by Sweep, keyed by holder DID and credential ID (see flaggedKey).
+const flaggedShelf = "flagged"
+
 // ErrNoCredentials is returned when no matching credentials are found in the wallet based on a PresentationDefinition
 var ErrNoCredentials = errors.New("no matching credentials")
 
@@ -87,6 +92,11 @@ func (h wallet) BuildSubmission(ctx context.Context, walletDID did.DID, presenta
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to retrieve wallet credentials: %w", err)
 	}
+	// don't present credentials that were found to be expired or revoked
+	credentials, err = h.withoutFlagged(ctx, walletDID, credentials)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve wallet credentials: %w", err)
+	}
 
 	// match against the wallet's credentials
 	// if there's a match, create a VP and call the token endpoint
@@ -116,6 +126,16 @@ func (h wallet) BuildSubmission(ctx context.Context, walletDID did.DID, presenta
 	if signInstructions.Empty() {
 		return nil, nil, ErrNoCredentials
 	}
+	// only disclose the claims of SD-JWT VCs that are needed to satisfy the presentation definition
+	for i, signInstruction := range signInstructions {
+		for j, mapping := range signInstruction.Mappings {
+			limited, err := presentationDefinition.LimitDisclosure(mapping.Id, signInstruction.VerifiableCredentials[j])
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to limit disclosure of credential (id=%s): %w", signInstruction.VerifiableCredentials[j].ID, err)
+			}
+			signInstructions[i].VerifiableCredentials[j] = *limited
+		}
+	}
 
 	// todo: support multiple wallets
 	vp, err := h.BuildPresentation(ctx, signInstructions[0].VerifiableCredentials, PresentationOptions{
@@ -160,6 +180,10 @@ func (h wallet) BuildPresentation(ctx context.Context, credentials []vc.Verifiab
 			}
 		}
 	}
+	credentials, err = h.addKeyBindings(ctx, credentials, options.ProofOptions)
+	if err != nil {
+		return nil, err
+	}
 
 	switch options.Format {
 	case JWTPresentationFormat:
@@ -173,6 +197,55 @@ func (h wallet) BuildPresentation(ctx context.Context, credentials []vc.Verifiab
 	}
 }
 
+// addKeyBindings returns the credentials with a key binding JWT added to each SD-JWT VC, proving the credential subject presents it.
+// The key binding JWT is signed with the assertion key of the credential subject, and contains the domain and nonce (or challenge) of the presentation.
+func (h wallet) addKeyBindings(ctx context.Context, credentials []vc.VerifiableCredential, options proof.ProofOptions) ([]vc.VerifiableCredential, error) {
+	var audience, nonce string
+	if options.Domain != nil {
+		audience = *options.Domain
+	}
+	if options.Nonce != nil {
+		nonce = *options.Nonce
+	} else if options.Challenge != nil {
+		nonce = *options.Challenge
+	}
+	result := make([]vc.VerifiableCredential, len(credentials))
+	for i, current := range credentials {
+		result[i] = current
+		if !sdjwt.IsCredential(current) {
+			continue
+		}
+		sdJWT, err := sdjwt.FromCredential(current)
+		if err != nil {
+			return nil, core.InvalidInputError("invalid SD-JWT VC (id=%s): %w", current.ID, err)
+		}
+		subjectDID, err := current.SubjectDID()
+		if err != nil {
+			return nil, core.InvalidInputError("invalid SD-JWT VC (id=%s): %w", current.ID, err)
+		}
+		kid, _, err := h.keyResolver.ResolveKey(*subjectDID, nil, resolver.NutsSigningKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve assertion key for signing key binding JWT (did=%s): %w", *subjectDID, err)
+		}
+		key, err := h.keyStore.Resolve(ctx, kid.String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve assertion key from key store for signing key binding JWT (did=%s): %w", *subjectDID, err)
+		}
+		sdJWT, err = sdJWT.WithKeyBinding(ctx, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return h.keyStore.SignJWT(ctx, claims, headers, key)
+		}, audience, nonce)
+		if err != nil {
+			return nil, err
+		}
+		bound, err := sdjwt.ToCredential(*sdJWT)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *bound
+	}
+	return result, nil
+}
+
 // buildJWTPresentation builds a JWT presentation according to https://www.w3.org/TR/vc-data-model/#json-web-token
 func (h wallet) buildJWTPresentation(ctx context.Context, subjectDID did.DID, credentials []vc.VerifiableCredential, options PresentationOptions, key crypto.Key) (*vc.VerifiablePresentation, error) {
 	headers := map[string]interface{}{
@@ -259,6 +332,11 @@ func (h wallet) Put(ctx context.Context, credentials ...vc.VerifiableCredential)
 			if err != nil {
 				return fmt.Errorf("unable to resolve subject DID from VC %s: %w", curr.ID, err)
 			}
+			if sdjwt.IsCredential(curr) {
+				if _, err = sdjwt.FromCredential(curr); err != nil {
+					return fmt.Errorf("invalid SD-JWT VC %s: %w", curr.ID, err)
+				}
+			}
 			walletKey := stoabs.BytesKey(curr.ID.String())
 			// First check if the VC doesn't already exist; otherwise stats will be incorrect
 			walletShelf := tx.GetShelfWriter(subjectDID.String())
@@ -310,6 +388,146 @@ func (h wallet) List(ctx context.Context, holderDID did.DID) ([]vc.VerifiableCre
 	return result, nil
 }
 
+func (h wallet) Remove(ctx context.Context, holderDID did.DID, credentialID ssi.URI) error {
+	err := h.walletStore.Write(ctx, func(tx stoabs.WriteTx) error {
+		walletKey := stoabs.BytesKey(credentialID.String())
+		walletShelf := tx.GetShelfWriter(holderDID.String())
+		_, err := walletShelf.Get(walletKey)
+		if errors.Is(err, stoabs.ErrKeyNotFound) {
+			return types.ErrNotFound
+		} else if err != nil {
+			return err
+		}
+		if err = walletShelf.Delete(walletKey); err != nil {
+			return err
+		}
+		if err = tx.GetShelfWriter(flaggedShelf).Delete(flaggedKey(holderDID, credentialID)); err != nil {
+			return err
+		}
+		// Update stats
+		stats := tx.GetShelfWriter(statsShelf)
+		currentCount, err := h.readCredentialCount(stats)
+		if err != nil {
+			return fmt.Errorf("unable to read wallet credential count: %w", err)
+		}
+		if currentCount > 0 {
+			currentCount--
+		}
+		return stats.Put(credentialCountStatsKey, binary.BigEndian.AppendUint32([]byte{}, currentCount))
+	}, stoabs.WithWriteLock()) // lock required for stats consistency
+	if errors.Is(err, types.ErrNotFound) {
+		return err
+	} else if err != nil {
+		return fmt.Errorf("unable to remove credential %s: %w", credentialID, err)
+	}
+	return nil
+}
+
+// flaggedCredential is stored in the flagged shelf for credentials that were found to be expired or revoked.
+type flaggedCredential struct {
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+func (h wallet) Sweep(ctx context.Context, holderDID did.DID, remove bool) (int, error) {
+	credentials, err := h.List(ctx, holderDID)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, cred := range credentials {
+		if cred.ID == nil {
+			continue
+		}
+		reason := h.invalidReason(cred)
+		if reason == "" {
+			// credential might have been flagged before (e.g. a suspended status list entry), but is valid now
+			if err = h.walletStore.WriteShelf(ctx, flaggedShelf, func(writer stoabs.Writer) error {
+				return writer.Delete(flaggedKey(holderDID, *cred.ID))
+			}); err != nil {
+				return count, fmt.Errorf("unable to unflag credential %s: %w", cred.ID, err)
+			}
+			continue
+		}
+		if remove {
+			err = h.Remove(ctx, holderDID, *cred.ID)
+			if errors.Is(err, types.ErrNotFound) {
+				// removed in the meantime
+				continue
+			}
+		} else {
+			err = h.flag(ctx, holderDID, *cred.ID, reason)
+		}
+		if err != nil {
+			return count, err
+		}
+		log.Logger().
+			WithField(core.LogFieldCredentialID, cred.ID.String()).
+			WithField(core.LogFieldDID, holderDID.String()).
+			Infof("Wallet credential is %s (removed=%v)", reason, remove)
+		count++
+	}
+	return count, nil
+}
+
+// invalidReason returns "revoked" or "expired" if the credential is revoked or expired, or an empty string otherwise.
+// Other verification failures (e.g. an unreachable status list) are not considered a reason to flag or remove a credential.
+func (h wallet) invalidReason(cred vc.VerifiableCredential) string {
+	err := h.verifier.Verify(cred, true, false, nil)
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, types.ErrRevoked) {
+		return "revoked"
+	}
+	if errors.Is(err, types.ErrCredentialNotValidAtTime) && cred.ExpirationDate != nil && cred.ExpirationDate.Before(time.Now()) {
+		return "expired"
+	}
+	log.Logger().
+		WithError(err).
+		WithField(core.LogFieldCredentialID, cred.ID.String()).
+		Debug("Wallet credential verification failed, ignoring")
+	return ""
+}
+
+func (h wallet) flag(ctx context.Context, holderDID did.DID, credentialID ssi.URI, reason string) error {
+	data, _ := json.Marshal(flaggedCredential{Reason: reason, Time: time.Now()})
+	err := h.walletStore.WriteShelf(ctx, flaggedShelf, func(writer stoabs.Writer) error {
+		return writer.Put(flaggedKey(holderDID, credentialID), data)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to flag credential %s: %w", credentialID, err)
+	}
+	return nil
+}
+
+// withoutFlagged returns the given credentials of the holder, except those that have been flagged by Sweep.
+func (h wallet) withoutFlagged(ctx context.Context, holderDID did.DID, credentials []vc.VerifiableCredential) ([]vc.VerifiableCredential, error) {
+	var result []vc.VerifiableCredential
+	err := h.walletStore.ReadShelf(ctx, flaggedShelf, func(reader stoabs.Reader) error {
+		for _, cred := range credentials {
+			if cred.ID != nil {
+				_, err := reader.Get(flaggedKey(holderDID, *cred.ID))
+				if err == nil {
+					continue
+				} else if !errors.Is(err, stoabs.ErrKeyNotFound) {
+					return err
+				}
+			}
+			result = append(result, cred)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// flaggedKey returns the key of a credential in the flagged shelf.
+// Flags are kept per holder, since the same credential might be held by multiple holders.
+// The holder DID is followed by a slash, which can't occur in a DID, so the key is unambiguous.
+func flaggedKey(holderDID did.DID, credentialID ssi.URI) stoabs.BytesKey {
+	return stoabs.BytesKey(holderDID.String() + "/" + credentialID.String())
+}
+
 func (h wallet) Diagnostics() []core.DiagnosticResult {
 	ctx := context.Background()
 	var count uint32
@@ -20,6 +20,7 @@ package holder
 
 import (
 	"context"
+	crypt "crypto"
 	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
@@ -30,7 +31,9 @@ import (
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -358,6 +361,58 @@ func TestWallet_BuildSubmission(t *testing.T) {
 		require.NotNil(t, submission)
 
 	})
+	t.Run("ok - SD-JWT VC with limited disclosure and key binding", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		keyResolver := resolver.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveKey(vdr.TestDIDA, nil, resolver.NutsSigningKeyType).Return(ssi.MustParseURI(key.KID()), key.Public(), nil).Times(2)
+		presentationDefinition := pe.PresentationDefinition{InputDescriptors: []*pe.InputDescriptor{{
+			Id: "1",
+			Constraints: &pe.Constraints{
+				LimitDisclosure: pe.DirectiveRequired,
+				Fields:          []pe.Field{{Path: []string{"$.credentialSubject.company.name"}}},
+			},
+		}}}
+
+		w := New(keyResolver, keyStore, nil, jsonldManager, store)
+		credential := createSDJWTCredential(t, vdr.TestDIDA)
+		// the credential subject's context isn't carried over, matching uses JSON paths
+		require.Equal(t, []ssi.URI{vc.VCContextV1URI()}, credential.Context)
+		err := w.Put(context.Background(), credential)
+		require.NoError(t, err)
+
+		vp, submission, err := w.BuildSubmission(ctx, vdr.TestDIDA, presentationDefinition, vpFormats, BuildParams{Audience: verifierDID.String(), Expires: time.Now().Add(time.Second), Nonce: "nonce"})
+
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+		assert.Equal(t, sdjwt.Format, submission.DescriptorMap[0].Format)
+		require.Len(t, vp.VerifiableCredential, 1)
+		presented := vp.VerifiableCredential[0]
+		assert.Equal(t, map[string]interface{}{"name": "De beste zorg"}, presented.CredentialSubject[0].(map[string]interface{})["company"])
+		sdJWT, err := sdjwt.FromCredential(presented)
+		require.NoError(t, err)
+		token, err := sdJWT.VerifyKeyBinding(func(_ string) (crypt.PublicKey, error) {
+			return key.Public(), nil
+		}, verifierDID.String(), "nonce", time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []string{verifierDID.String()}, token.Audience())
+	})
+	t.Run("error - only flagged credentials", func(t *testing.T) {
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+
+		w := New(nil, keyStore, nil, jsonldManager, store).(*wallet)
+		err := w.Put(context.Background(), credentials...)
+		require.NoError(t, err)
+		require.NoError(t, w.flag(ctx, walletDID, *credentials[0].ID, "revoked"))
+
+		vp, submission, err := w.BuildSubmission(ctx, walletDID, presentationDefinition, vpFormats, BuildParams{Audience: verifierDID.String(), Expires: time.Now().Add(time.Second), Nonce: ""})
+
+		assert.Equal(t, ErrNoCredentials, err)
+		assert.Nil(t, vp)
+		assert.Nil(t, submission)
+	})
 	t.Run("error - no matching credentials", func(t *testing.T) {
 		storageEngine := storage.NewTestStorageEngine(t)
 		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
@@ -453,6 +508,190 @@ func Test_wallet_Put(t *testing.T) {
 	})
 }
 
+func Test_wallet_Put_SDJWT(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		sut := New(nil, nil, nil, nil, store)
+		expected := createSDJWTCredential(t, vdr.TestDIDA)
+
+		err := sut.Put(context.Background(), expected)
+		require.NoError(t, err)
+
+		list, err := sut.List(context.Background(), vdr.TestDIDA)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.True(t, sdjwt.IsCredential(list[0]))
+		_, err = sdjwt.FromCredential(list[0])
+		assert.NoError(t, err)
+	})
+	t.Run("credential does not match SD-JWT", func(t *testing.T) {
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		sut := New(nil, nil, nil, nil, store)
+		tampered := createSDJWTCredential(t, vdr.TestDIDA)
+		tampered.CredentialSubject = []interface{}{map[string]interface{}{"id": vdr.TestDIDA.String(), "company": "Other"}}
+
+		err := sut.Put(context.Background(), tampered)
+
+		assert.ErrorContains(t, err, "credential does not match its SD-JWT")
+	})
+}
+
+func Test_wallet_Remove(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		sut := New(nil, nil, nil, nil, store).(*wallet)
+		removed := createCredential(vdr.TestMethodDIDA.String())
+		kept := createCredential(vdr.TestMethodDIDA.String())
+		require.NoError(t, sut.Put(context.Background(), removed, kept))
+		require.NoError(t, sut.flag(context.Background(), vdr.TestDIDA, *removed.ID, "revoked"))
+		// the same credential, flagged for another holder
+		require.NoError(t, sut.flag(context.Background(), vdr.TestDIDB, *removed.ID, "revoked"))
+
+		err := sut.Remove(context.Background(), vdr.TestDIDA, *removed.ID)
+
+		require.NoError(t, err)
+		list, err := sut.List(context.Background(), vdr.TestDIDA)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, kept.ID.String(), list[0].ID.String())
+		assert.Equal(t, 1, sut.Diagnostics()[0].Result(), "removing a credential should decrement total number of credentials")
+		t.Run("flag is removed", func(t *testing.T) {
+			err := store.ReadShelf(context.Background(), flaggedShelf, func(reader stoabs.Reader) error {
+				_, err := reader.Get(flaggedKey(vdr.TestDIDA, *removed.ID))
+				return err
+			})
+			assert.ErrorIs(t, err, stoabs.ErrKeyNotFound)
+		})
+		t.Run("flag of other holder is kept", func(t *testing.T) {
+			err := store.ReadShelf(context.Background(), flaggedShelf, func(reader stoabs.Reader) error {
+				_, err := reader.Get(flaggedKey(vdr.TestDIDB, *removed.ID))
+				return err
+			})
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("not found", func(t *testing.T) {
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		sut := New(nil, nil, nil, nil, store)
+		cred := createCredential(vdr.TestMethodDIDA.String())
+		require.NoError(t, sut.Put(context.Background(), cred))
+
+		t.Run("unknown credential", func(t *testing.T) {
+			err := sut.Remove(context.Background(), vdr.TestDIDA, ssi.MustParseURI("did:example:123#1"))
+
+			assert.ErrorIs(t, err, types.ErrNotFound)
+		})
+		t.Run("other holder", func(t *testing.T) {
+			err := sut.Remove(context.Background(), vdr.TestDIDB, *cred.ID)
+
+			assert.ErrorIs(t, err, types.ErrNotFound)
+			assert.Equal(t, 1, sut.Diagnostics()[0].Result())
+		})
+	})
+}
+
+func Test_wallet_Sweep(t *testing.T) {
+	expired := createCredential(vdr.TestMethodDIDA.String())
+	expirationDate := time.Now().Add(-time.Hour)
+	expired.ExpirationDate = &expirationDate
+	revoked := createCredential(vdr.TestMethodDIDA.String())
+	valid := createCredential(vdr.TestMethodDIDA.String())
+	unverifiable := createCredential(vdr.TestMethodDIDA.String())
+	setup := func(t *testing.T) *wallet {
+		ctrl := gomock.NewController(t)
+		mockVerifier := verifier.NewMockVerifier(ctrl)
+		mockVerifier.EXPECT().Verify(gomock.Any(), true, false, nil).DoAndReturn(func(cred vc.VerifiableCredential, _ bool, _ bool, _ *time.Time) error {
+			switch cred.ID.String() {
+			case expired.ID.String():
+				return types.ErrCredentialNotValidAtTime
+			case revoked.ID.String():
+				return types.ErrRevoked
+			case unverifiable.ID.String():
+				return errors.New("status list unavailable")
+			}
+			return nil
+		}).AnyTimes()
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		sut := New(nil, nil, mockVerifier, nil, store).(*wallet)
+		require.NoError(t, sut.Put(context.Background(), expired, revoked, valid, unverifiable))
+		return sut
+	}
+	flagged := func(t *testing.T, sut *wallet) []string {
+		var result []string
+		err := sut.walletStore.ReadShelf(context.Background(), flaggedShelf, func(reader stoabs.Reader) error {
+			return reader.Iterate(func(key stoabs.Key, value []byte) error {
+				result = append(result, string(key.Bytes()))
+				return nil
+			}, stoabs.BytesKey{})
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		sut := setup(t)
+
+		count, err := sut.Sweep(context.Background(), vdr.TestDIDA, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.ElementsMatch(t, []string{string(flaggedKey(vdr.TestDIDA, *expired.ID)), string(flaggedKey(vdr.TestDIDA, *revoked.ID))}, flagged(t, sut))
+		list, _ := sut.List(context.Background(), vdr.TestDIDA)
+		assert.Len(t, list, 4, "flagged credentials should remain in the wallet")
+	})
+	t.Run("remove", func(t *testing.T) {
+		sut := setup(t)
+
+		count, err := sut.Sweep(context.Background(), vdr.TestDIDA, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Empty(t, flagged(t, sut))
+		list, _ := sut.List(context.Background(), vdr.TestDIDA)
+		require.Len(t, list, 2)
+		assert.ElementsMatch(t, []string{valid.ID.String(), unverifiable.ID.String()}, []string{list[0].ID.String(), list[1].ID.String()})
+		assert.Equal(t, 2, sut.Diagnostics()[0].Result())
+	})
+	t.Run("previously flagged credential is valid again", func(t *testing.T) {
+		sut := setup(t)
+		require.NoError(t, sut.flag(context.Background(), vdr.TestDIDA, *valid.ID, "revoked"))
+
+		_, err := sut.Sweep(context.Background(), vdr.TestDIDA, false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, flagged(t, sut), string(flaggedKey(vdr.TestDIDA, *valid.ID)))
+	})
+	t.Run("not valid yet is not flagged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockVerifier := verifier.NewMockVerifier(ctrl)
+		mockVerifier.EXPECT().Verify(gomock.Any(), true, false, nil).Return(types.ErrCredentialNotValidAtTime)
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		sut := New(nil, nil, mockVerifier, nil, store).(*wallet)
+		require.NoError(t, sut.Put(context.Background(), valid))
+
+		count, err := sut.Sweep(context.Background(), vdr.TestDIDA, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("empty wallet", func(t *testing.T) {
+		storageEngine := storage.NewTestStorageEngine(t)
+		store, _ := storageEngine.GetProvider("test").GetKVStore("credentials", storage.PersistentStorageClass)
+		sut := New(nil, nil, nil, nil, store)
+
+		count, err := sut.Sweep(context.Background(), vdr.TestDIDA, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
+
 func Test_wallet_List(t *testing.T) {
 	t.Run("invalid credential returns error", func(t *testing.T) {
 		storageEngine := storage.NewTestStorageEngine(t)
@@ -526,6 +765,23 @@ func Test_wallet_Diagnostics(t *testing.T) {
 	})
 }
 
+// createSDJWTCredential issues an SD-JWT VC (with the same claims as createCredential) for the given subject.
+func createSDJWTCredential(t *testing.T, subjectDID did.DID) vc.VerifiableCredential {
+	keyStore := crypto.NewMemoryCryptoInstance()
+	issuerKey, err := keyStore.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc("did:example:issuer#1"))
+	require.NoError(t, err)
+	template := createCredential(subjectDID.String() + "#1")
+	id := ssi.MustParseURI("did:example:issuer#" + uuid.NewString())
+	template.ID = &id
+	template.Issuer = ssi.MustParseURI("did:example:issuer")
+	template.Proof = nil
+	result, err := sdjwt.IssueCredential(audit.TestContext(), template, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		return keyStore.SignJWT(ctx, claims, headers, issuerKey)
+	})
+	require.NoError(t, err)
+	return *result
+}
+
 func createCredential(keyID string) vc.VerifiableCredential {
 	testCredentialJSON := `
 {
@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/statuslist2021"
 )
 
 // Publisher publishes new credentials and revocations to a channel. Used by a credential issuer.
@@ -47,12 +48,27 @@ type keyResolver interface {
 // Issuer is a role in the network for a party who issues credentials about a subject to a holder.
 type Issuer interface {
 	// Issue issues a credential by signing an unsigned credential.
+	// If the credential is offered over OpenID4VCI and its issuance is deferred, it isn't signed and stored yet:
+	// the unsigned credential is returned, which is signed when the deferred issuance is completed.
 	Issue(ctx context.Context, template vc.VerifiableCredential, options CredentialOptions) (*vc.VerifiableCredential, error)
+	// SignDeferred signs and stores the unsigned credential of a deferred OpenID4VCI issuance that is completed.
+	SignDeferred(ctx context.Context, unsignedCredential vc.VerifiableCredential) (*vc.VerifiableCredential, error)
 	// Revoke credential with credentialID.
 	// It returns types.ErrNotFound if the credential is not issued by this node, or types.ErrRevoked if already revoked.
 	// The revocation will be published to the network by the issuers Publisher if issuer by did:nuts.
 	Revoke(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error)
-	// StatusList returns the StatusList2021Credential tracking status list revocations for this issuer at /iam/issuerID/status/page.
+	// Suspend credential with credentialID by setting its 'suspension' entry on the status list. Suspension can be lifted using Unsuspend.
+	// It returns types.ErrNotFound if the credential is not issued by this node, types.ErrStatusNotFound if the credential has no 'suspension' entry,
+	// or types.ErrSuspended if already suspended.
+	Suspend(ctx context.Context, credentialID ssi.URI) error
+	// Unsuspend credential with credentialID by clearing its 'suspension' entry on the status list.
+	// It returns types.ErrNotFound if the credential is not issued by this node, types.ErrStatusNotFound if the credential has no 'suspension' entry,
+	// or types.ErrNotSuspended if the credential is not suspended.
+	Unsuspend(ctx context.Context, credentialID ssi.URI) error
+	// RevocationStatus returns whether the given credential, issued by this node, is revoked or suspended.
+	// It checks both revocations published on the network and the credential's status list entries.
+	RevocationStatus(ctx context.Context, credential vc.VerifiableCredential) (revoked bool, suspended bool, err error)
+	// StatusList returns the StatusList2021Credential or BitstringStatusListCredential tracking status list revocations or suspensions for this issuer at /iam/issuerID/status/page.
 	// Returns types.ErrNotFound when no credential statuses have been published using the issuer and page combination.
 	StatusList(ctx context.Context, issuer did.DID, page int) (*vc.VerifiableCredential, error)
 	CredentialSearcher
@@ -90,7 +106,7 @@ type CredentialSearcher interface {
 // CredentialOptions specifies options for issuing a credential.
 type CredentialOptions struct {
 	// Format specifies the proof format for the issued credential. If not set, it defaults to JSON-LD.
-	// Valid options are: ldp_vc or jwt_vc
+	// Valid options are: ldp_vc, jwt_vc or vc+sd-jwt
 	Format string
 	// Publish param indicates if the credential should be published to the network.
 	Publish bool
@@ -98,4 +114,9 @@ type CredentialOptions struct {
 	Public bool
 	// WithStatusListRevocation adds a 'revocation' entry to the credential. Requires Publish to be False.
 	WithStatusListRevocation bool
+	// WithStatusListSuspension adds a 'suspension' entry to the credential, allowing it to be suspended. Requires Publish to be False.
+	WithStatusListSuspension bool
+	// StatusListType specifies the type of status list the 'revocation' and 'suspension' entries are published on.
+	// Valid options are: StatusList2021 or BitstringStatusList. If not set, it defaults to StatusList2021.
+	StatusListType statuslist2021.StatusListType
 }
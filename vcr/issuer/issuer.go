@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/statuslist2021"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -94,49 +95,52 @@ type issuer struct {
 // Issue creates a new credential, signs, stores it.
 // If publish is true, it publishes the credential to the network using the configured Publisher
 // Use the public flag to pass the visibility settings to the Publisher.
+// If the credential is offered over OpenID4VCI and its issuance is deferred, the unsigned credential is returned (see SignDeferred).
 func (i issuer) Issue(ctx context.Context, template vc.VerifiableCredential, options CredentialOptions) (*vc.VerifiableCredential, error) {
 	// Until further notice we don't support publishing JWT VCs, since they're not officially supported by Nuts yet.
 	if options.Publish && options.Format == vc.JWTCredentialProofFormat {
 		return nil, errors.New("publishing VC JWTs is not supported")
 	}
-
-	createdVC, err := i.buildAndSignVC(ctx, template, options)
-	if err != nil {
-		return nil, err
+	if options.Publish && options.Format == sdjwt.Format {
+		return nil, errors.New("publishing SD-JWT VCs is not supported")
 	}
 
-	// Sanity check: all provided fields must be defined by the context: otherwise they're not protected by the signature
-	err = credential.AllFieldsDefinedValidator{
-		DocumentLoader: i.jsonldManager.DocumentLoader(),
-	}.Validate(*createdVC)
+	unsignedVC, err := i.buildVC(ctx, template, options)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate the VC using the type-specific validator
-	validator := credential.FindValidator(*createdVC)
-	if err := validator.Validate(*createdVC); err != nil {
-		return nil, err
-	}
-
-	// Trust credential before storing/publishing, otherwise it might self-issued credentials might not be trusted,
-	// if AddTrust() fails for whatever reason.
-	// Only 1 allowed for now, but looping over all types (VerifiableCredential is excluded by ExtractTypes()) is future-proof.
-	for _, credentialType := range credential.ExtractTypes(*createdVC) {
-		// MustParseURI is safe since it came from vc.Type, which contains URIs
-		if err := i.trustConfig.AddTrust(ssi.MustParseURI(credentialType), createdVC.Issuer); err != nil {
-			return nil, fmt.Errorf("failed to trust issuer when issuing VC (did=%s,type=%s): %w", createdVC.Issuer, credentialType, err)
+	// Try to issue over OpenID4VCI if it's enabled and if the credential is not public
+	// (public credentials are always published on the network).
+	publishOverOpenID4VCI := options.Publish && !options.Public && i.openidHandlerFn != nil
+	// If issuance over OpenID4VCI is deferred for the credential type, the unsigned credential is offered.
+	// It's only signed and stored when the deferred issuance is completed, so nothing is left behind if it's denied.
+	if publishOverOpenID4VCI && (options.Format == "" || options.Format == vc.JSONLDCredentialProofFormat) {
+		deferred, err := i.deferUsingOpenID4VCI(ctx, *unsignedVC)
+		if err != nil {
+			log.Logger().
+				WithField(core.LogFieldCredentialID, unsignedVC.ID.String()).
+				WithError(err).
+				Warnf("Couldn't offer deferred credential over OpenID4VCI, fallback to publish over Nuts network")
+			publishOverOpenID4VCI = false
+		} else if deferred {
+			log.Logger().
+				WithField(core.LogFieldCredentialID, unsignedVC.ID.String()).
+				Info("Offered credential over OpenID4VCI, issuance is deferred until it's completed")
+			return unsignedVC, nil
 		}
 	}
 
-	if err = i.store.StoreCredential(*createdVC); err != nil {
-		return nil, fmt.Errorf("unable to store the issued credential: %w", err)
+	createdVC, err := i.signVC(ctx, *unsignedVC, options.Format)
+	if err != nil {
+		return nil, err
+	}
+	if err = i.validateAndStore(*createdVC, options.Format); err != nil {
+		return nil, err
 	}
 
 	if options.Publish {
-		// Try to issue over OpenID4VCI if it's enabled and if the credential is not public
-		// (public credentials are always published on the network).
-		if i.openidHandlerFn != nil && !options.Public {
+		if publishOverOpenID4VCI {
 			success, err := i.issueUsingOpenID4VCI(ctx, *createdVC)
 			if err != nil {
 				// An error occurred, but it's not because the wallet/issuer doesn't support OpenID4VCI.
@@ -189,7 +193,101 @@ func (i issuer) issueUsingOpenID4VCI(ctx context.Context, credential vc.Verifiab
 	return true, i.vcrStore.StoreCredential(credential, nil)
 }
 
+// deferUsingOpenID4VCI offers the unsigned credential over OpenID4VCI, if its issuance is deferred by the OpenID4VCI issuer.
+// It returns whether the credential was offered. If no error is returned and bool is false,
+// issuance of the credential isn't deferred or the wallet does not support OpenID4VCI.
+func (i issuer) deferUsingOpenID4VCI(ctx context.Context, unsignedCredential vc.VerifiableCredential) (bool, error) {
+	subjectID, err := unsignedCredential.SubjectDID()
+	if err != nil {
+		return false, err
+	}
+	walletIdentifier, err := i.walletResolver.Resolve(*subjectID)
+	if err != nil {
+		return false, fmt.Errorf("unable to discover wallet identifier: %w", err)
+	}
+	if walletIdentifier == "" {
+		// Wallet not configured for OpenID4VCI
+		return false, nil
+	}
+	issuerDID, _ := did.ParseDID(unsignedCredential.Issuer.String()) // can't fail, already created
+	openidIssuer, err := i.openidHandlerFn(ctx, *issuerDID)
+	if err != nil {
+		return false, fmt.Errorf("unable to discover issuer identifier: %w", err)
+	}
+	if !openidIssuer.IsDeferred(unsignedCredential) {
+		return false, nil
+	}
+	// The credential is validated when it's signed, but invalid credentials are better rejected before they're offered.
+	if err = (credential.AllFieldsDefinedValidator{DocumentLoader: i.jsonldManager.DocumentLoader()}).Validate(unsignedCredential); err != nil {
+		return false, err
+	}
+	err = openidIssuer.OfferCredential(ctx, unsignedCredential, walletIdentifier)
+	if err != nil {
+		return false, fmt.Errorf("unable to offer the credential over OpenID4VCI to (wallet: %s): %w", walletIdentifier, err)
+	}
+	return true, nil
+}
+
+func (i issuer) SignDeferred(ctx context.Context, unsignedCredential vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+	createdVC, err := i.signVC(ctx, unsignedCredential, vc.JSONLDCredentialProofFormat)
+	if err != nil {
+		return nil, err
+	}
+	if err = i.validateAndStore(*createdVC, vc.JSONLDCredentialProofFormat); err != nil {
+		return nil, err
+	}
+	// Like credentials issued over OpenID4VCI directly, it needs to be in the general VCR store.
+	if err = i.vcrStore.StoreCredential(*createdVC, nil); err != nil {
+		return nil, err
+	}
+	return createdVC, nil
+}
+
+// validateAndStore validates the signed credential, trusts its issuer and stores it as issued credential.
+func (i issuer) validateAndStore(createdVC vc.VerifiableCredential, format string) error {
+	// Sanity check: all provided fields must be defined by the context: otherwise they're not protected by the signature.
+	// SD-JWT VCs are not JSON-LD documents: the SD-JWT signature protects all claims.
+	if format != sdjwt.Format {
+		err := credential.AllFieldsDefinedValidator{
+			DocumentLoader: i.jsonldManager.DocumentLoader(),
+		}.Validate(createdVC)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Validate the VC using the type-specific validator
+	validator := credential.FindValidator(createdVC)
+	if err := validator.Validate(createdVC); err != nil {
+		return err
+	}
+
+	// Trust credential before storing/publishing, otherwise it might self-issued credentials might not be trusted,
+	// if AddTrust() fails for whatever reason.
+	// Only 1 allowed for now, but looping over all types (VerifiableCredential is excluded by ExtractTypes()) is future-proof.
+	for _, credentialType := range credential.ExtractTypes(createdVC) {
+		// MustParseURI is safe since it came from vc.Type, which contains URIs
+		if err := i.trustConfig.AddTrust(ssi.MustParseURI(credentialType), createdVC.Issuer); err != nil {
+			return fmt.Errorf("failed to trust issuer when issuing VC (did=%s,type=%s): %w", createdVC.Issuer, credentialType, err)
+		}
+	}
+
+	if err := i.store.StoreCredential(createdVC); err != nil {
+		return fmt.Errorf("unable to store the issued credential: %w", err)
+	}
+	return nil
+}
+
 func (i issuer) buildAndSignVC(ctx context.Context, template vc.VerifiableCredential, options CredentialOptions) (*vc.VerifiableCredential, error) {
+	unsignedCredential, err := i.buildVC(ctx, template, options)
+	if err != nil {
+		return nil, err
+	}
+	return i.signVC(ctx, *unsignedCredential, options.Format)
+}
+
+// buildVC builds the unsigned credential from the template, with an ID, issuance date and the requested credential statuses.
+func (i issuer) buildVC(ctx context.Context, template vc.VerifiableCredential, options CredentialOptions) (*vc.VerifiableCredential, error) {
 	if len(template.Type) != 1 {
 		return nil, core.InvalidInputError("can only issue credential with 1 type")
 	}
@@ -199,16 +297,6 @@ func (i issuer) buildAndSignVC(ctx context.Context, template vc.VerifiableCreden
 		return nil, fmt.Errorf("failed to parse issuer: %w", err)
 	}
 
-	key, err := i.keyResolver.ResolveAssertionKey(ctx, *issuerDID)
-	if err != nil {
-		const errString = "failed to sign credential: could not resolve an assertionKey for issuer: %w"
-		// Differentiate between a DID document not found and some other error:
-		if resolver.IsFunctionalResolveError(err) {
-			return nil, core.InvalidInputError(errString, err)
-		}
-		return nil, fmt.Errorf(errString, err)
-	}
-
 	credentialID := ssi.MustParseURI(fmt.Sprintf("%s#%s", issuerDID.String(), uuid.New().String()))
 	unsignedCredential := vc.VerifiableCredential{
 		Context:           template.Context,
@@ -218,39 +306,108 @@ func (i issuer) buildAndSignVC(ctx context.Context, template vc.VerifiableCreden
 		Issuer:            template.Issuer,
 		ExpirationDate:    template.ExpirationDate,
 		IssuanceDate:      template.IssuanceDate,
+		ValidFrom:         template.ValidFrom,
+		ValidUntil:        template.ValidUntil,
 		//CredentialStatus:  template.CredentialStatus, // not allowed for now since it requires API changes to be able to determine what status to revoke.
 	}
+	var statusPurposes []statuslist2021.StatusPurpose
 	if options.WithStatusListRevocation {
+		statusPurposes = append(statusPurposes, statuslist2021.StatusPurposeRevocation)
+	}
+	if options.WithStatusListSuspension {
+		statusPurposes = append(statusPurposes, statuslist2021.StatusPurposeSuspension)
+	}
+	statusListType := options.StatusListType
+	if statusListType == "" {
+		statusListType = statuslist2021.StatusList2021Type
+	}
+	// The Bitstring Status List terms are only defined by the W3C VC data model v2 context, which can't be combined with the v1 context.
+	// JSON-LD credentials with a Bitstring Status List entry are therefore VC data model v2 credentials.
+	// SD-JWT VCs aren't JSON-LD documents, so they keep using the v1 data model.
+	if len(statusPurposes) > 0 && statusListType == statuslist2021.BitstringStatusListType && options.Format != sdjwt.Format {
+		if options.Format == vc.JWTCredentialProofFormat {
+			return nil, core.InvalidInputError("Bitstring Status List is not supported for JWT credentials")
+		}
+		if unsignedCredential.ContainsContext(vc.VCContextV1URI()) {
+			return nil, core.InvalidInputError("Bitstring Status List requires the VC data model v2 context, which can't be combined with context '%s'", vc.VCContextV1URI())
+		}
+		if !unsignedCredential.ContainsContext(statuslist2021.VCContextV2URI) {
+			// the base context must come first
+			unsignedCredential.Context = append([]ssi.URI{statuslist2021.VCContextV2URI}, unsignedCredential.Context...)
+		}
+	}
+	if len(statusPurposes) > 0 {
 		// add credential status
-		credentialStatusEntry, err := i.statusListStore.Create(ctx, *issuerDID, statuslist2021.StatusPurposeRevocation)
-		if err != nil {
-			return nil, err
+		for _, statusPurpose := range statusPurposes {
+			credentialStatusEntry, err := i.statusListStore.Create(ctx, *issuerDID, statusPurpose, statusListType)
+			if err != nil {
+				return nil, err
+			}
+			unsignedCredential.CredentialStatus = append(unsignedCredential.CredentialStatus, credentialStatusEntry)
 		}
-		unsignedCredential.CredentialStatus = append(unsignedCredential.CredentialStatus, credentialStatusEntry)
 
-		// add status list context
-		if !unsignedCredential.ContainsContext(statusList2021ContextURI) {
-			unsignedCredential.Context = append(unsignedCredential.Context, statusList2021ContextURI)
+		// add status list context (SD-JWT VCs aren't JSON-LD documents)
+		contextURI := statusListContextURI(statusListType)
+		if options.Format != sdjwt.Format && !unsignedCredential.ContainsContext(contextURI) {
+			unsignedCredential.Context = append(unsignedCredential.Context, contextURI)
 		}
 	}
-	if unsignedCredential.IssuanceDate == nil {
-		issuanceDate := TimeFunc()
-		unsignedCredential.IssuanceDate = &issuanceDate
-	}
-	if !unsignedCredential.ContainsContext(vc.VCContextV1URI()) {
-		unsignedCredential.Context = append(unsignedCredential.Context, vc.VCContextV1URI())
+	if unsignedCredential.ContainsContext(statuslist2021.VCContextV2URI) {
+		// VC data model v2 replaces issuanceDate and expirationDate with validFrom and validUntil
+		if unsignedCredential.ValidFrom == nil {
+			unsignedCredential.ValidFrom = unsignedCredential.IssuanceDate
+		}
+		if unsignedCredential.ValidUntil == nil {
+			unsignedCredential.ValidUntil = unsignedCredential.ExpirationDate
+		}
+		unsignedCredential.IssuanceDate = nil
+		unsignedCredential.ExpirationDate = nil
+		if unsignedCredential.ValidFrom == nil {
+			validFrom := TimeFunc()
+			unsignedCredential.ValidFrom = &validFrom
+		}
+	} else {
+		if unsignedCredential.IssuanceDate == nil {
+			issuanceDate := TimeFunc()
+			unsignedCredential.IssuanceDate = &issuanceDate
+		}
+		if !unsignedCredential.ContainsContext(vc.VCContextV1URI()) {
+			unsignedCredential.Context = append(unsignedCredential.Context, vc.VCContextV1URI())
+		}
 	}
 
 	defaultType := vc.VerifiableCredentialTypeV1URI()
 	if !unsignedCredential.IsType(defaultType) {
 		unsignedCredential.Type = append(unsignedCredential.Type, defaultType)
 	}
+	return &unsignedCredential, nil
+}
 
-	switch options.Format {
+// signVC signs the unsigned credential in the given format, using the assertion key of its issuer.
+func (i issuer) signVC(ctx context.Context, unsignedCredential vc.VerifiableCredential, format string) (*vc.VerifiableCredential, error) {
+	issuerDID, err := did.ParseDID(unsignedCredential.Issuer.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse issuer: %w", err)
+	}
+	key, err := i.keyResolver.ResolveAssertionKey(ctx, *issuerDID)
+	if err != nil {
+		const errString = "failed to sign credential: could not resolve an assertionKey for issuer: %w"
+		// Differentiate between a DID document not found and some other error:
+		if resolver.IsFunctionalResolveError(err) {
+			return nil, core.InvalidInputError(errString, err)
+		}
+		return nil, fmt.Errorf(errString, err)
+	}
+
+	switch format {
 	case vc.JWTCredentialProofFormat:
 		return vc.CreateJWTVerifiableCredential(ctx, unsignedCredential, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
 			return i.keyStore.SignJWT(ctx, claims, headers, key)
 		})
+	case sdjwt.Format:
+		return sdjwt.IssueCredential(ctx, unsignedCredential, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return i.keyStore.SignJWT(ctx, claims, headers, key)
+		})
 	case "":
 		fallthrough
 	case vc.JSONLDCredentialProofFormat:
@@ -265,7 +422,12 @@ func (i issuer) buildJSONLDCredential(ctx context.Context, unsignedCredential vc
 	b, _ := json.Marshal(unsignedCredential)
 	_ = json.Unmarshal(b, &credentialAsMap)
 
-	proofOptions := proof.ProofOptions{Created: *unsignedCredential.IssuanceDate}
+	created := unsignedCredential.IssuanceDate
+	if created == nil {
+		// VC data model v2 credential
+		created = unsignedCredential.ValidFrom
+	}
+	proofOptions := proof.ProofOptions{Created: *created}
 
 	webSig := signature.JSONWebSignature2020{ContextLoader: i.jsonldManager.DocumentLoader(), Signer: i.keyStore}
 	signingResult, err := proof.NewLDProof(proofOptions).Sign(ctx, credentialAsMap, webSig, key)
@@ -325,33 +487,105 @@ func (i issuer) revokeDIDNuts(ctx context.Context, credentialID ssi.URI) (*crede
 
 // revokeStatusList revokes a credential through its credential status
 func (i issuer) revokeStatusList(ctx context.Context, credentialID ssi.URI) error {
-	cred, err := i.store.GetCredential(credentialID)
+	slEntry, err := i.statusListEntry(credentialID, statuslist2021.StatusPurposeRevocation)
 	if err != nil {
 		return err
 	}
+	return i.statusListStore.Revoke(ctx, credentialID, *slEntry)
+}
 
-	statuses, err := cred.CredentialStatuses()
+func (i issuer) Suspend(ctx context.Context, credentialID ssi.URI) error {
+	slEntry, err := i.statusListEntry(credentialID, statuslist2021.StatusPurposeSuspension)
+	if err != nil {
+		return err
+	}
+	if err = i.statusListStore.Suspend(ctx, credentialID, *slEntry); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credentialID).
+		Info("Verifiable Credential suspended")
+	return nil
+}
+
+func (i issuer) Unsuspend(ctx context.Context, credentialID ssi.URI) error {
+	slEntry, err := i.statusListEntry(credentialID, statuslist2021.StatusPurposeSuspension)
 	if err != nil {
 		return err
 	}
+	if err = i.statusListStore.Unsuspend(ctx, *slEntry); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credentialID).
+		Info("Verifiable Credential unsuspended")
+	return nil
+}
 
-	// find the correct credentialStatus and revoke it on the relevant statuslist
+func (i issuer) RevocationStatus(ctx context.Context, credential vc.VerifiableCredential) (bool, bool, error) {
+	if credential.ID != nil {
+		revoked, err := i.isRevoked(*credential.ID)
+		if err != nil || revoked {
+			return revoked, false, err
+		}
+	}
+
+	statuses, err := credential.CredentialStatuses()
+	if err != nil {
+		return false, false, err
+	}
+	var revoked, suspended bool
 	for _, status := range statuses {
-		if status.Type == statuslist2021.EntryType {
+		if status.Type != statuslist2021.EntryType && status.Type != statuslist2021.BitstringEntryType {
+			continue
+		}
+		var slEntry statuslist2021.Entry
+		if err = json.Unmarshal(status.Raw(), &slEntry); err != nil {
+			return false, false, err
+		}
+		isSet, err := i.statusListStore.IsSet(ctx, slEntry)
+		if err != nil {
+			return false, false, err
+		}
+		switch statuslist2021.StatusPurpose(slEntry.StatusPurpose) {
+		case statuslist2021.StatusPurposeRevocation:
+			revoked = revoked || isSet
+		case statuslist2021.StatusPurposeSuspension:
+			suspended = suspended || isSet
+		}
+	}
+	return revoked, suspended, nil
+}
+
+// statusListEntry returns the status list credentialStatus with the given purpose of the issued credential with credentialID.
+// It returns types.ErrStatusNotFound if the credential has no such credentialStatus.
+func (i issuer) statusListEntry(credentialID ssi.URI, statusPurpose statuslist2021.StatusPurpose) (*statuslist2021.Entry, error) {
+	cred, err := i.store.GetCredential(credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := cred.CredentialStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	// find the credentialStatus with the correct purpose
+	for _, status := range statuses {
+		if status.Type == statuslist2021.EntryType || status.Type == statuslist2021.BitstringEntryType {
 			var slEntry statuslist2021.Entry
 			err = json.Unmarshal(status.Raw(), &slEntry)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			// TODO: make sure it is the correct entry when we allow other purposes, or VC issuance that include other credential statuses
-			if slEntry.StatusPurpose != statuslist2021.StatusPurposeRevocation {
+			if slEntry.StatusPurpose != string(statusPurpose) {
 				continue
 			}
-			return i.statusListStore.Revoke(ctx, credentialID, slEntry)
+			return &slEntry, nil
 		}
 	}
 
-	return types.ErrStatusNotFound
+	return nil, types.ErrStatusNotFound
 }
 
 func (i issuer) buildRevocation(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error) {
@@ -427,6 +661,15 @@ const statusListValidity = 24 * time.Hour // TODO: make configurable
 
 var statusList2021ContextURI = ssi.MustParseURI(jsonld.W3cStatusList2021Context)
 var statusList2021CredentialTypeURI = ssi.MustParseURI(statuslist2021.CredentialType)
+var bitstringStatusListCredentialTypeURI = ssi.MustParseURI(statuslist2021.BitstringCredentialType)
+
+// statusListContextURI returns the JSON-LD context that defines the terms of the given status list type.
+func statusListContextURI(statusListType statuslist2021.StatusListType) ssi.URI {
+	if statusListType == statuslist2021.BitstringStatusListType {
+		return statuslist2021.BitstringContextURI
+	}
+	return statusList2021ContextURI
+}
 
 func (i issuer) StatusList(ctx context.Context, issuerDID did.DID, page int) (*vc.VerifiableCredential, error) {
 	// todo: get cached credential if available
@@ -437,7 +680,11 @@ func (i issuer) StatusList(ctx context.Context, issuerDID did.DID, page int) (*v
 		return nil, err
 	}
 
-	// create statuslist credential template
+	// create statuslist credential template, its type depends on the type of status list published on the page
+	credentialType := statusList2021CredentialTypeURI
+	if credSubject.Type == statuslist2021.BitstringCredentialSubjectType {
+		credentialType = bitstringStatusListCredentialTypeURI
+	}
 	iss := TimeFunc()
 	exp := iss.Add(statusListValidity)
 	template := vc.VerifiableCredential{
@@ -447,13 +694,17 @@ func (i issuer) StatusList(ctx context.Context, issuerDID did.DID, page int) (*v
 		},
 		Type: []ssi.URI{
 			// vc.VerifiableCredentialTypeV1URI(), // automatically added
-			statusList2021CredentialTypeURI,
+			credentialType,
 		},
 		CredentialSubject: []any{credSubject},
 		Issuer:            issuerDID.URI(),
 		IssuanceDate:      &iss,
 		ExpirationDate:    &exp,
 	}
+	if credentialType == bitstringStatusListCredentialTypeURI {
+		// BitstringStatusListCredentials are VC data model v2 credentials, validFrom and validUntil are set by buildVC
+		template.Context = []ssi.URI{statuslist2021.BitstringContextURI}
+	}
 
 	// build and sign the VC.
 	// All content is validated and these credentials should not be in the issuer store, so don't use i.Issue()
@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/statuslist2021"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
@@ -35,6 +36,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/require"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -78,7 +80,7 @@ func Test_issuer_buildAndSignVC(t *testing.T) {
 		}},
 	}
 	keyStore := crypto.NewMemoryCryptoInstance()
-	signingKey, err := keyStore.New(ctx, func(key crypt.PublicKey) (string, error) {
+	signingKey, err := keyStore.New(ctx, crypto.ECP256Key, func(key crypt.PublicKey) (string, error) {
 		return kid, nil
 	})
 	require.NoError(t, err)
@@ -147,6 +149,40 @@ func Test_issuer_buildAndSignVC(t *testing.T) {
 			assert.Equal(t, result.ID.String(), result.JWT().JwtID())
 		})
 	})
+	t.Run("SD-JWT", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolverMock := NewMockkeyResolver(ctrl)
+			keyResolverMock.EXPECT().ResolveAssertionKey(ctx, gomock.Any()).Return(signingKey, nil)
+			sut := issuer{keyResolver: keyResolverMock, keyStore: keyStore}
+			sdTemplate := template
+			sdTemplate.CredentialSubject = []interface{}{map[string]interface{}{
+				"id":   subjectDID,
+				"name": "Hospital",
+			}}
+
+			result, err := sut.buildAndSignVC(ctx, sdTemplate, CredentialOptions{Format: sdjwt.Format})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, sdjwt.IsCredential(*result))
+			assert.Contains(t, result.Type, credentialType, "expected vc to be of right type")
+			// SD-JWT VCs aren't JSON-LD documents, so the contexts of the template are not carried over
+			assert.Equal(t, []ssi.URI{vc.VCContextV1URI()}, result.Context)
+			assert.Equal(t, template.IssuanceDate.Local(), result.IssuanceDate.Local())
+			assert.Equal(t, template.ExpirationDate.Local(), result.ExpirationDate.Local())
+			assert.Equal(t, template.Issuer, result.Issuer)
+			assert.Equal(t, sdTemplate.CredentialSubject, result.CredentialSubject)
+			// Assert SD-JWT
+			sdJWT, err := sdjwt.FromCredential(*result)
+			require.NoError(t, err)
+			require.Len(t, sdJWT.Disclosures, 1)
+			assert.Equal(t, "name", sdJWT.Disclosures[0].Name)
+			kid, _, err := crypto.JWTKidAlg(sdJWT.IssuerJWT)
+			require.NoError(t, err)
+			assert.Equal(t, kid, signingKey.KID())
+		})
+	})
 	t.Run("credentialStatus", func(t *testing.T) {
 		t.Run("ok", func(t *testing.T) {
 			issuerDID := did.MustParseDID("did:web:example.com:iam:123")
@@ -171,11 +207,92 @@ func Test_issuer_buildAndSignVC(t *testing.T) {
 			require.Len(t, statuses, 1)
 			assert.Equal(t, statuslist2021.EntryType, statuses[0].Type)
 		})
-		t.Run("error - did:nuts", func(t *testing.T) {
+		t.Run("ok - BitstringStatusList", func(t *testing.T) {
+			issuerDID := did.MustParseDID("did:web:example.com:iam:123")
+			slTemplate := template
+			slTemplate.Issuer = issuerDID.URI() // does not overwrite template
+			// schema.org redefines terms protected by the VC data model v2 context
+			slTemplate.Context = nil
+
 			ctrl := gomock.NewController(t)
 			keyResolverMock := NewMockkeyResolver(ctrl)
 			keyResolverMock.EXPECT().ResolveAssertionKey(ctx, gomock.Any()).Return(signingKey, nil)
 			jsonldManager := jsonld.NewTestJSONLDManager(t)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, statusListStore: NewTestStatusListStore(t, issuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithStatusListRevocation: true, StatusListType: statuslist2021.BitstringStatusListType})
+
+			// only check fields relevant to credential status
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			// VC data model v2 credential
+			assert.Equal(t, statuslist2021.BitstringContextURI, result.Context[0])
+			assert.NotContains(t, result.Context, vc.VCContextV1URI())
+			assert.Nil(t, result.IssuanceDate)
+			assert.Nil(t, result.ExpirationDate)
+			assert.Equal(t, template.IssuanceDate.Local(), result.ValidFrom.Local())
+			assert.Equal(t, template.ExpirationDate.Local(), result.ValidUntil.Local())
+
+			statuses, err := result.CredentialStatuses()
+			require.NoError(t, err)
+			require.Len(t, statuses, 1)
+			assert.Equal(t, statuslist2021.BitstringEntryType, statuses[0].Type)
+		})
+		t.Run("error - BitstringStatusList with VC data model v1 context", func(t *testing.T) {
+			issuerDID := did.MustParseDID("did:web:example.com:iam:123")
+			slTemplate := template
+			slTemplate.Issuer = issuerDID.URI() // does not overwrite template
+			slTemplate.Context = []ssi.URI{vc.VCContextV1URI()}
+			sut := issuer{statusListStore: NewTestStatusListStore(t, issuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithStatusListRevocation: true, StatusListType: statuslist2021.BitstringStatusListType})
+
+			assert.EqualError(t, err, "Bitstring Status List requires the VC data model v2 context, which can't be combined with context 'https://www.w3.org/2018/credentials/v1'")
+			assert.Nil(t, result)
+		})
+		t.Run("error - BitstringStatusList for JWT credential", func(t *testing.T) {
+			issuerDID := did.MustParseDID("did:web:example.com:iam:123")
+			slTemplate := template
+			slTemplate.Issuer = issuerDID.URI() // does not overwrite template
+			sut := issuer{statusListStore: NewTestStatusListStore(t, issuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{
+				Format:                   vc.JWTCredentialProofFormat,
+				WithStatusListRevocation: true,
+				StatusListType:           statuslist2021.BitstringStatusListType,
+			})
+
+			assert.EqualError(t, err, "Bitstring Status List is not supported for JWT credentials")
+			assert.Nil(t, result)
+		})
+		t.Run("ok - revocation and suspension", func(t *testing.T) {
+			issuerDID := did.MustParseDID("did:web:example.com:iam:123")
+			slTemplate := template
+			slTemplate.Issuer = issuerDID.URI() // does not overwrite template
+
+			ctrl := gomock.NewController(t)
+			keyResolverMock := NewMockkeyResolver(ctrl)
+			keyResolverMock.EXPECT().ResolveAssertionKey(ctx, gomock.Any()).Return(signingKey, nil)
+			jsonldManager := jsonld.NewTestJSONLDManager(t)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, statusListStore: NewTestStatusListStore(t, issuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithStatusListRevocation: true, WithStatusListSuspension: true})
+
+			// only check fields relevant to credential status
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			var entries []statuslist2021.Entry
+			b, _ := json.Marshal(result.CredentialStatus)
+			require.NoError(t, json.Unmarshal(b, &entries))
+			require.Len(t, entries, 2)
+			assert.Equal(t, statuslist2021.StatusPurposeRevocation, entries[0].StatusPurpose)
+			assert.Equal(t, statuslist2021.StatusPurposeSuspension, entries[1].StatusPurpose)
+			assert.NotEqual(t, entries[0].StatusListCredential, entries[1].StatusListCredential)
+		})
+		t.Run("error - did:nuts", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolverMock := NewMockkeyResolver(ctrl)
+			jsonldManager := jsonld.NewTestJSONLDManager(t)
 			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, statusListStore: NewTestStatusListStore(t)}
 
 			result, err := sut.buildAndSignVC(ctx, template, CredentialOptions{WithStatusListRevocation: true})
@@ -328,6 +445,36 @@ func Test_issuer_Issue(t *testing.T) {
 		assert.True(t, trustConfig.IsTrusted(credentialType, result.Issuer))
 	})
 
+	t.Run("ok - BitstringStatusList revocation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webIssuerDID := did.MustParseDID("did:web:example.com:iam:123")
+		webTemplate := template
+		webTemplate.Issuer = webIssuerDID.URI()
+		trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.config"))
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(ctx, gomock.Any()).Return(crypto.NewTestKey(webIssuerDID.String()+"#abc"), nil)
+		mockStore := NewMockStore(ctrl)
+		mockStore.EXPECT().StoreCredential(gomock.Any())
+		sut := issuer{
+			keyResolver: keyResolverMock, store: mockStore,
+			jsonldManager: jsonldManager, trustConfig: trustConfig,
+			keyStore:        crypto.NewMemoryCryptoInstance(),
+			statusListStore: NewTestStatusListStore(t, webIssuerDID),
+		}
+
+		result, err := sut.Issue(ctx, webTemplate, CredentialOptions{
+			WithStatusListRevocation: true,
+			StatusListType:           statuslist2021.BitstringStatusListType,
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, result.Context, statuslist2021.BitstringContextURI)
+		statuses, err := result.CredentialStatuses()
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, statuslist2021.BitstringEntryType, statuses[0].Type)
+	})
+
 	t.Run("publishing JWT VCs is disallowed", func(t *testing.T) {
 		sut := issuer{}
 
@@ -339,6 +486,17 @@ func Test_issuer_Issue(t *testing.T) {
 		require.EqualError(t, err, "publishing VC JWTs is not supported")
 		assert.Nil(t, result)
 	})
+	t.Run("publishing SD-JWT VCs is disallowed", func(t *testing.T) {
+		sut := issuer{}
+
+		result, err := sut.Issue(ctx, template, CredentialOptions{
+			Publish: true,
+			Public:  true,
+			Format:  sdjwt.Format,
+		})
+		require.EqualError(t, err, "publishing SD-JWT VCs is not supported")
+		assert.Nil(t, result)
+	})
 
 	t.Run("OpenID4VCI", func(t *testing.T) {
 		const walletIdentifier = "http://example.com/wallet"
@@ -347,8 +505,9 @@ func Test_issuer_Issue(t *testing.T) {
 			publisher := NewMockPublisher(ctrl)
 			publisher.EXPECT().PublishCredential(gomock.Any(), gomock.Any(), gomock.Any())
 			walletResolver := openid4vci.NewMockIdentifierResolver(ctrl)
-			walletResolver.EXPECT().Resolve(gomock.Any()).Return(walletIdentifier, nil)
+			walletResolver.EXPECT().Resolve(gomock.Any()).AnyTimes().Return(walletIdentifier, nil)
 			openidHandler := NewMockOpenIDHandler(ctrl)
+			openidHandler.EXPECT().IsDeferred(gomock.Any()).Return(false)
 			openidHandler.EXPECT().OfferCredential(gomock.Any(), gomock.Any(), walletIdentifier).Return(errors.New("failed"))
 			keyResolver := NewMockkeyResolver(ctrl)
 			keyResolver.EXPECT().ResolveAssertionKey(ctx, gomock.Any()).Return(crypto.NewTestKey(issuerKeyID), nil)
@@ -436,6 +595,7 @@ func Test_issuer_Issue(t *testing.T) {
 			walletResolver := openid4vci.NewMockIdentifierResolver(ctrl)
 			walletResolver.EXPECT().Resolve(holderDID).AnyTimes().Return(walletIdentifier, nil)
 			openidIssuer := NewMockOpenIDHandler(ctrl)
+			openidIssuer.EXPECT().IsDeferred(gomock.Any()).Return(false)
 			openidIssuer.EXPECT().OfferCredential(gomock.Any(), gomock.Any(), walletIdentifier)
 			vcrStore := vcr.NewMockWriter(ctrl)
 			vcrStore.EXPECT().StoreCredential(gomock.Any(), gomock.Any())
@@ -467,6 +627,67 @@ func Test_issuer_Issue(t *testing.T) {
 			require.NoError(t, err)
 			assert.NotNil(t, result)
 		})
+		t.Run("ok - deferred issuance over OpenID4VCI is offered unsigned", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			walletResolver := openid4vci.NewMockIdentifierResolver(ctrl)
+			walletResolver.EXPECT().Resolve(holderDID).AnyTimes().Return(walletIdentifier, nil)
+			openidIssuer := NewMockOpenIDHandler(ctrl)
+			openidIssuer.EXPECT().IsDeferred(gomock.Any()).Return(true)
+			openidIssuer.EXPECT().OfferCredential(gomock.Any(), gomock.Any(), walletIdentifier)
+			sut := issuer{
+				jsonldManager:  jsonldManager,
+				trustConfig:    trust.NewConfig(path.Join(io.TestDirectory(t), "trust.config")),
+				keyStore:       crypto.NewMemoryCryptoInstance(),
+				walletResolver: walletResolver,
+				openidHandlerFn: func(ctx context.Context, id did.DID) (OpenIDHandler, error) {
+					return openidIssuer, nil
+				},
+			}
+
+			result, err := sut.Issue(ctx, template, CredentialOptions{
+				Publish: true,
+				Public:  false,
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Empty(t, result.Proof)
+		})
+		t.Run("deferred issuance over OpenID4VCI fails - fallback to network", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			walletResolver := openid4vci.NewMockIdentifierResolver(ctrl)
+			walletResolver.EXPECT().Resolve(holderDID).AnyTimes().Return(walletIdentifier, nil)
+			openidIssuer := NewMockOpenIDHandler(ctrl)
+			openidIssuer.EXPECT().IsDeferred(gomock.Any()).Return(true)
+			openidIssuer.EXPECT().OfferCredential(gomock.Any(), gomock.Any(), walletIdentifier).Return(errors.New("failed"))
+			publisher := NewMockPublisher(ctrl)
+			publisher.EXPECT().PublishCredential(gomock.Any(), gomock.Any(), gomock.Any())
+			keyResolver := NewMockkeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveAssertionKey(ctx, gomock.Any()).Return(crypto.NewTestKey(issuerKeyID), nil)
+			store := NewMockStore(ctrl)
+			store.EXPECT().StoreCredential(gomock.Any())
+			sut := issuer{
+				keyResolver:    keyResolver,
+				store:          store,
+				jsonldManager:  jsonldManager,
+				trustConfig:    trust.NewConfig(path.Join(io.TestDirectory(t), "trust.config")),
+				keyStore:       crypto.NewMemoryCryptoInstance(),
+				walletResolver: walletResolver,
+				openidHandlerFn: func(ctx context.Context, id did.DID) (OpenIDHandler, error) {
+					return openidIssuer, nil
+				},
+				networkPublisher: publisher,
+			}
+
+			result, err := sut.Issue(ctx, template, CredentialOptions{
+				Publish: true,
+				Public:  false,
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.NotEmpty(t, result.Proof)
+		})
 	})
 
 	t.Run("error - from used services", func(t *testing.T) {
@@ -802,7 +1023,7 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		status := NewTestStatusListStore(t, issuerDID)
-		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation)
+		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
 		require.NoError(t, err)
 		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
 		sut := issuer{
@@ -817,7 +1038,7 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 	})
 	t.Run("error - double revocation", func(t *testing.T) {
 		status := NewTestStatusListStore(t, issuerDID)
-		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation)
+		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
 		require.NoError(t, err)
 		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
 		sut := issuer{
@@ -831,6 +1052,21 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 
 		assert.ErrorIs(t, err, vcr.ErrRevoked)
 	})
+	t.Run("ok - BitstringStatusList", func(t *testing.T) {
+		status := NewTestStatusListStore(t, issuerDID)
+		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.BitstringStatusListType)
+		require.NoError(t, err)
+		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
+		sut := issuer{
+			store:           issuerStore,
+			statusListStore: status,
+		}
+
+		revCred, err := sut.Revoke(context.Background(), credentialID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, revCred)
+	})
 	t.Run("error - credential not found", func(t *testing.T) {
 		store := NewMockStore(gomock.NewController(t))
 		store.EXPECT().GetCredential(gomock.Any()).Return(nil, vcr.ErrNotFound)
@@ -843,7 +1079,7 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 	})
 	t.Run("error - statuslist credential not found", func(t *testing.T) {
 		status := NewTestStatusListStore(t, issuerDID)
-		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation)
+		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
 		require.NoError(t, err)
 		entry.StatusListCredential = "unknown status list"
 		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
@@ -871,6 +1107,169 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 	})
 }
 
+func TestIssuer_Suspend(t *testing.T) {
+	issuerDID := did.MustParseDID("did:web:example.com:iam:123")
+	storeWithCred := func(c *gomock.Controller, entries ...any) (*MockStore, ssi.URI) {
+		credentialID := ssi.MustParseURI(issuerDID.String() + "#identifier")
+		cred := &vc.VerifiableCredential{
+			ID:               &credentialID,
+			Issuer:           ssi.MustParseURI(issuerDID.String()),
+			CredentialStatus: entries,
+		}
+		store := NewMockStore(c)
+		store.EXPECT().GetCredential(*cred.ID).Return(cred, nil).MinTimes(1)
+		return store, credentialID
+	}
+	newSUT := func(t *testing.T) (issuer, ssi.URI) {
+		status := NewTestStatusListStore(t, issuerDID)
+		revocationEntry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
+		require.NoError(t, err)
+		suspensionEntry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeSuspension, statuslist2021.StatusList2021Type)
+		require.NoError(t, err)
+		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *revocationEntry, *suspensionEntry)
+		return issuer{
+			store:           issuerStore,
+			statusListStore: status,
+		}, credentialID
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		sut, credentialID := newSUT(t)
+
+		err := sut.Suspend(context.Background(), credentialID)
+
+		assert.NoError(t, err)
+		t.Run("suspension is listed on the suspension status list", func(t *testing.T) {
+			credSubject, err := sut.statusListStore.CredentialSubject(context.Background(), issuerDID, 2)
+			require.NoError(t, err)
+			assert.Equal(t, statuslist2021.StatusPurposeSuspension, credSubject.StatusPurpose)
+			revocations, err := sut.statusListStore.CredentialSubject(context.Background(), issuerDID, 1)
+			require.NoError(t, err)
+			assert.NotEqual(t, credSubject.EncodedList, revocations.EncodedList)
+		})
+	})
+	t.Run("ok - unsuspend", func(t *testing.T) {
+		sut, credentialID := newSUT(t)
+		require.NoError(t, sut.Suspend(context.Background(), credentialID))
+
+		err := sut.Unsuspend(context.Background(), credentialID)
+
+		assert.NoError(t, err)
+		t.Run("can be suspended again", func(t *testing.T) {
+			assert.NoError(t, sut.Suspend(context.Background(), credentialID))
+		})
+	})
+	t.Run("error - double suspension", func(t *testing.T) {
+		sut, credentialID := newSUT(t)
+		require.NoError(t, sut.Suspend(context.Background(), credentialID))
+
+		err := sut.Suspend(context.Background(), credentialID)
+
+		assert.ErrorIs(t, err, vcr.ErrSuspended)
+	})
+	t.Run("error - unsuspend without suspension", func(t *testing.T) {
+		sut, credentialID := newSUT(t)
+
+		err := sut.Unsuspend(context.Background(), credentialID)
+
+		assert.ErrorIs(t, err, vcr.ErrNotSuspended)
+	})
+	t.Run("error - credential not found", func(t *testing.T) {
+		store := NewMockStore(gomock.NewController(t))
+		store.EXPECT().GetCredential(gomock.Any()).Return(nil, vcr.ErrNotFound).Times(2)
+		sut := issuer{store: store}
+
+		assert.ErrorIs(t, sut.Suspend(context.Background(), ssi.MustParseURI("did:web:example.com:iam#not-found")), vcr.ErrNotFound)
+		assert.ErrorIs(t, sut.Unsuspend(context.Background(), ssi.MustParseURI("did:web:example.com:iam#not-found")), vcr.ErrNotFound)
+	})
+	t.Run("error - no suspension credential status", func(t *testing.T) {
+		status := NewTestStatusListStore(t, issuerDID)
+		entry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
+		require.NoError(t, err)
+		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
+		sut := issuer{store: issuerStore, statusListStore: status}
+
+		assert.ErrorIs(t, sut.Suspend(context.Background(), credentialID), vcr.ErrStatusNotFound)
+		assert.ErrorIs(t, sut.Unsuspend(context.Background(), credentialID), vcr.ErrStatusNotFound)
+	})
+}
+
+func TestIssuer_RevocationStatus(t *testing.T) {
+	issuerDID := did.MustParseDID("did:web:example.com:iam:123")
+	credentialID := ssi.MustParseURI(issuerDID.String() + "#identifier")
+	newSUT := func(t *testing.T) (issuer, vc.VerifiableCredential) {
+		status := NewTestStatusListStore(t, issuerDID)
+		revocationEntry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
+		require.NoError(t, err)
+		suspensionEntry, err := status.Create(context.Background(), issuerDID, statuslist2021.StatusPurposeSuspension, statuslist2021.BitstringStatusListType)
+		require.NoError(t, err)
+		cred := vc.VerifiableCredential{
+			ID:               &credentialID,
+			Issuer:           issuerDID.URI(),
+			CredentialStatus: []any{*revocationEntry, *suspensionEntry},
+		}
+		store := NewMockStore(gomock.NewController(t))
+		store.EXPECT().GetCredential(credentialID).Return(&cred, nil).AnyTimes()
+		store.EXPECT().GetRevocation(credentialID).Return(nil, vcr.ErrNotFound).AnyTimes()
+		return issuer{store: store, statusListStore: status}, cred
+	}
+
+	t.Run("ok - not revoked or suspended", func(t *testing.T) {
+		sut, cred := newSUT(t)
+
+		revoked, suspended, err := sut.RevocationStatus(context.Background(), cred)
+
+		require.NoError(t, err)
+		assert.False(t, revoked)
+		assert.False(t, suspended)
+	})
+	t.Run("ok - revoked on status list", func(t *testing.T) {
+		sut, cred := newSUT(t)
+		require.NoError(t, sut.revokeStatusList(context.Background(), credentialID))
+
+		revoked, suspended, err := sut.RevocationStatus(context.Background(), cred)
+
+		require.NoError(t, err)
+		assert.True(t, revoked)
+		assert.False(t, suspended)
+	})
+	t.Run("ok - suspended on status list", func(t *testing.T) {
+		sut, cred := newSUT(t)
+		require.NoError(t, sut.Suspend(context.Background(), credentialID))
+
+		revoked, suspended, err := sut.RevocationStatus(context.Background(), cred)
+
+		require.NoError(t, err)
+		assert.False(t, revoked)
+		assert.True(t, suspended)
+	})
+	t.Run("ok - revoked on the network", func(t *testing.T) {
+		store := NewMockStore(gomock.NewController(t))
+		store.EXPECT().GetRevocation(credentialID).Return(&credential.Revocation{}, nil)
+		sut := issuer{store: store}
+
+		revoked, suspended, err := sut.RevocationStatus(context.Background(), vc.VerifiableCredential{ID: &credentialID})
+
+		require.NoError(t, err)
+		assert.True(t, revoked)
+		assert.False(t, suspended)
+	})
+	t.Run("error - status list of other issuer", func(t *testing.T) {
+		sut, cred := newSUT(t)
+		entry := statuslist2021.Entry{
+			Type:                 statuslist2021.EntryType,
+			StatusPurpose:        string(statuslist2021.StatusPurposeRevocation),
+			StatusListIndex:      "1",
+			StatusListCredential: "https://example.com/other/statuslist/1",
+		}
+		cred.CredentialStatus = []any{entry}
+
+		_, _, err := sut.RevocationStatus(context.Background(), cred)
+
+		assert.ErrorIs(t, err, vcr.ErrNotFound)
+	})
+}
+
 func TestIssuer_isRevoked(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -926,7 +1325,7 @@ func TestIssuer_StatusList(t *testing.T) {
 	ctx := audit.TestContext()
 	const kid = "did:web:example.com:iam:123#abc"
 	keyStore := crypto.NewMemoryCryptoInstance()
-	signingKey, err := keyStore.New(ctx, func(key crypt.PublicKey) (string, error) {
+	signingKey, err := keyStore.New(ctx, crypto.ECP256Key, func(key crypt.PublicKey) (string, error) {
 		return kid, nil
 	})
 	require.NoError(t, err)
@@ -944,7 +1343,7 @@ func TestIssuer_StatusList(t *testing.T) {
 			trustConfig:     trustConfig,
 			statusListStore: NewTestStatusListStore(t, issuerDID),
 		}
-		_, err = sut.statusListStore.Create(ctx, issuerDID, statuslist2021.StatusPurposeRevocation)
+		_, err = sut.statusListStore.Create(ctx, issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
 		require.NoError(t, err)
 
 		result, err := sut.StatusList(ctx, issuerDID, 1)
@@ -976,6 +1375,45 @@ func TestIssuer_StatusList(t *testing.T) {
 		verif := verifier.NewVerifier(vStoreMock, vDIDResolverMock, vKeyResolverMock, jsonldManager, trustConfig, nil)
 		assert.NoError(t, verif.Verify(*result, true, true, nil))
 	})
+	t.Run("ok - BitstringStatusList", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolverMock := NewMockkeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveAssertionKey(ctx, gomock.Any()).Return(signingKey, nil)
+		sut := issuer{
+			keyResolver:     keyResolverMock,
+			jsonldManager:   jsonldManager,
+			keyStore:        keyStore,
+			trustConfig:     trustConfig,
+			statusListStore: NewTestStatusListStore(t, issuerDID),
+		}
+		_, err = sut.statusListStore.Create(ctx, issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.BitstringStatusListType)
+		require.NoError(t, err)
+
+		result, err := sut.StatusList(ctx, issuerDID, 1)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, statuslist2021.BitstringContextURI, result.Context[0])
+		assert.NotContains(t, result.Context, vc.VCContextV1URI())
+		assert.NotNil(t, result.ValidFrom)
+		assert.NotNil(t, result.ValidUntil)
+		assert.True(t, result.IsType(ssi.MustParseURI(statuslist2021.BitstringCredentialType)))
+		var subjects []statuslist2021.CredentialSubject
+		require.NoError(t, result.UnmarshalCredentialSubject(&subjects))
+		require.Len(t, subjects, 1)
+		assert.Equal(t, statuslist2021.BitstringCredentialSubjectType, subjects[0].Type)
+		assert.True(t, strings.HasPrefix(subjects[0].EncodedList, "u"))
+
+		// verify credential
+		vStoreMock := verifier.NewMockStore(ctrl)
+		vStoreMock.EXPECT().GetRevocations(gomock.Any()).Return(nil, verifier.ErrNotFound)
+		vDIDResolverMock := resolver.NewMockDIDResolver(ctrl)
+		vDIDResolverMock.EXPECT().Resolve(gomock.Any(), gomock.Any())
+		vKeyResolverMock := resolver.NewMockKeyResolver(ctrl)
+		vKeyResolverMock.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(signingKey.Public(), nil)
+		verif := verifier.NewVerifier(vStoreMock, vDIDResolverMock, vKeyResolverMock, jsonldManager, trustConfig, nil)
+		assert.NoError(t, verif.Verify(*result, true, true, nil))
+	})
 	t.Run("error - unknown status list credential", func(t *testing.T) {
 		sut := issuer{statusListStore: NewTestStatusListStore(t, issuerDID)}
 
@@ -995,7 +1433,7 @@ func TestIssuer_StatusList(t *testing.T) {
 			trustConfig:     trustConfig,
 			statusListStore: NewTestStatusListStore(t, issuerDID),
 		}
-		_, err = sut.statusListStore.Create(ctx, issuerDID, statuslist2021.StatusPurposeRevocation)
+		_, err = sut.statusListStore.Create(ctx, issuerDID, statuslist2021.StatusPurposeRevocation, statuslist2021.StatusList2021Type)
 		require.NoError(t, err)
 
 		result, err := sut.StatusList(ctx, issuerDID, 1)
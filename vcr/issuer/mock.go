@@ -149,6 +149,22 @@ func (mr *MockIssuerMockRecorder) Issue(ctx, template, options any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockIssuer)(nil).Issue), ctx, template, options)
 }
 
+// RevocationStatus mocks base method.
+func (m *MockIssuer) RevocationStatus(ctx context.Context, credential vc.VerifiableCredential) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevocationStatus", ctx, credential)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RevocationStatus indicates an expected call of RevocationStatus.
+func (mr *MockIssuerMockRecorder) RevocationStatus(ctx, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevocationStatus", reflect.TypeOf((*MockIssuer)(nil).RevocationStatus), ctx, credential)
+}
+
 // Revoke mocks base method.
 func (m *MockIssuer) Revoke(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error) {
 	m.ctrl.T.Helper()
@@ -179,6 +195,21 @@ func (mr *MockIssuerMockRecorder) SearchCredential(credentialType, issuer, subje
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCredential", reflect.TypeOf((*MockIssuer)(nil).SearchCredential), credentialType, issuer, subject)
 }
 
+// SignDeferred mocks base method.
+func (m *MockIssuer) SignDeferred(ctx context.Context, unsignedCredential vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignDeferred", ctx, unsignedCredential)
+	ret0, _ := ret[0].(*vc.VerifiableCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignDeferred indicates an expected call of SignDeferred.
+func (mr *MockIssuerMockRecorder) SignDeferred(ctx, unsignedCredential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignDeferred", reflect.TypeOf((*MockIssuer)(nil).SignDeferred), ctx, unsignedCredential)
+}
+
 // StatusList mocks base method.
 func (m *MockIssuer) StatusList(ctx context.Context, issuer did.DID, page int) (*vc.VerifiableCredential, error) {
 	m.ctrl.T.Helper()
@@ -194,6 +225,34 @@ func (mr *MockIssuerMockRecorder) StatusList(ctx, issuer, page any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusList", reflect.TypeOf((*MockIssuer)(nil).StatusList), ctx, issuer, page)
 }
 
+// Suspend mocks base method.
+func (m *MockIssuer) Suspend(ctx context.Context, credentialID ssi.URI) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, credentialID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockIssuerMockRecorder) Suspend(ctx, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockIssuer)(nil).Suspend), ctx, credentialID)
+}
+
+// Unsuspend mocks base method.
+func (m *MockIssuer) Unsuspend(ctx context.Context, credentialID ssi.URI) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, credentialID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockIssuerMockRecorder) Unsuspend(ctx, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockIssuer)(nil).Unsuspend), ctx, credentialID)
+}
+
 // MockStore is a mock of Store interface.
 type MockStore struct {
 	ctrl     *gomock.Controller
@@ -27,9 +27,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/jwx/v2/jwt"
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/storage"
@@ -39,8 +42,10 @@ import (
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 )
 
@@ -58,11 +63,23 @@ type Flow struct {
 	Credentials []vc.VerifiableCredential `json:"credentials"`
 }
 
+// grant returns the grant of the given type, or nil if the flow doesn't have such a grant.
+func (f *Flow) grant(grantType string) *Grant {
+	for i := range f.Grants {
+		if f.Grants[i].Type == grantType {
+			return &f.Grants[i]
+		}
+	}
+	return nil
+}
+
 // Grant is a grant that has been issued for an OAuth2 state.
 type Grant struct {
 	// Type is the type of grant, e.g. "urn:ietf:params:oauth:grant-type:pre-authorized_code".
 	Type string `json:"type"`
 	// Params is a map of parameters for the grant, e.g. "pre-authorized_code" for type "urn:ietf:params:oauth:grant-type:pre-authorized_code".
+	// For the "authorization_code" type it contains the "issuer_state" sent in the offer, and after the wallet's authorization request,
+	// the parameters of that request (client_id, redirect_uri and PKCE code challenge) that need to be verified in the token request.
 	Params map[string]interface{} `json:"params"`
 }
 
@@ -72,9 +89,14 @@ var _ OpenIDHandler = (*openidHandler)(nil)
 const TokenTTL = 15 * time.Minute
 
 const preAuthCodeRefType = "preauthcode"
+const issuerStateRefType = "issuer_state"
+const authCodeRefType = "authcode"
 const accessTokenRefType = "accesstoken"
 const cNonceRefType = "c_nonce"
 
+const issuerStateParam = "issuer_state"
+const authorizationDetailsParam = "authorization_details"
+
 // OpenIDHandler defines the interface for handling OpenID4VCI issuer operations.
 type OpenIDHandler interface {
 	// ProviderMetadata returns the OpenID Connect provider metadata.
@@ -82,24 +104,62 @@ type OpenIDHandler interface {
 	// HandleAccessTokenRequest handles an OAuth2 access token request for the given issuer and pre-authorized code.
 	// It returns the access token and a c_nonce.
 	HandleAccessTokenRequest(ctx context.Context, preAuthorizedCode string) (string, string, error)
+	// HandleAuthorizeRequest handles an OAuth2 authorization request for the authorization code flow.
+	// The request must be a request object signed by the wallet's DID (client_id), and contain a PKCE code challenge.
+	// It contains either the issuer_state from a credential offer, or authorization_details requesting credentials
+	// that have been issued to the wallet.
+	// It returns the URL the wallet must be redirected to, containing the authorization code.
+	HandleAuthorizeRequest(ctx context.Context, request openid4vci.AuthorizationRequest) (string, error)
+	// HandleAuthorizationCodeTokenRequest handles an OAuth2 access token request for the given authorization code.
+	// The code verifier, redirect URI and client ID must match the ones of the authorization request.
+	// It returns the access token and a c_nonce.
+	HandleAuthorizationCodeTokenRequest(ctx context.Context, code string, codeVerifier string, redirectURI string, clientID string) (string, string, error)
 	// Metadata returns the OpenID4VCI credential issuer metadata for the given issuer.
 	Metadata() openid4vci.CredentialIssuerMetadata
 	// OfferCredential sends a credential offer to the specified wallet. It derives the issuer from the credential.
 	OfferCredential(ctx context.Context, credential vc.VerifiableCredential, walletIdentifier string) error
 	// HandleCredentialRequest requests a credential from the given issuer.
-	HandleCredentialRequest(ctx context.Context, request openid4vci.CredentialRequest, accessToken string) (*vc.VerifiableCredential, error)
+	// If issuance of the credential is deferred, the response contains a transaction ID instead of the credential.
+	HandleCredentialRequest(ctx context.Context, request openid4vci.CredentialRequest, accessToken string) (*openid4vci.CredentialResponse, error)
+	// HandleBatchCredentialRequest requests multiple credentials from the given issuer at once.
+	// Every credential request is served with a distinct credential of the flow, so a credential can't be requested twice in a batch.
+	// If one of the credential requests is invalid, the whole batch fails.
+	HandleBatchCredentialRequest(ctx context.Context, request openid4vci.BatchCredentialRequest, accessToken string) (*openid4vci.BatchCredentialResponse, error)
+	// HandleDeferredCredentialRequest handles a request for a credential of which issuance was deferred.
+	// It returns an error with code issuance_pending if the issuance hasn't been completed yet.
+	HandleDeferredCredentialRequest(ctx context.Context, request openid4vci.DeferredCredentialRequest, accessToken string) (*openid4vci.CredentialResponse, error)
+	// ListDeferredIssuances returns the deferred credential issuances of the issuer that haven't been retrieved by the wallet yet.
+	ListDeferredIssuances(ctx context.Context) ([]DeferredIssuance, error)
+	// CompleteDeferredIssuance approves the deferred credential issuance with the given transaction ID,
+	// after which the wallet can retrieve the credential. Credentials offered by the issuer are signed and stored at this point.
+	CompleteDeferredIssuance(ctx context.Context, transactionID string) error
+	// DenyDeferredIssuance denies the deferred credential issuance with the given transaction ID.
+	// The wallet won't be able to retrieve the credential. Since it's only signed when the issuance is completed, nothing needs to be revoked.
+	// It returns ErrDeferredIssuanceCompleted if the issuance was already completed.
+	DenyDeferredIssuance(ctx context.Context, transactionID string) error
+	// IsDeferred returns whether issuance of the credential is deferred, because it's of one of the configured deferred types.
+	IsDeferred(credential vc.VerifiableCredential) bool
 }
 
 // NewOpenIDHandler creates a new OpenIDHandler instance. The identifier is the Credential Issuer Identifier, e.g. https://example.com/issuer/
-func NewOpenIDHandler(issuerDID did.DID, issuerIdentifierURL string, definitionsDIR string, httpClient core.HTTPRequestDoer, keyResolver resolver.KeyResolver, sessionDatabase storage.SessionDatabase) (OpenIDHandler, error) {
+// Issuance of credentials of one of the given deferredTypes is deferred until it's completed through CompleteDeferredIssuance.
+// Deferred issuances are kept in the given deferredStore.
+// The credentialStore is used to find issued credentials requested by wallets in wallet-initiated flows.
+// The credentialIssuer signs the credentials of completed deferred issuances, and checks whether issued credentials are revoked.
+func NewOpenIDHandler(issuerDID did.DID, issuerIdentifierURL string, definitionsDIR string, httpClient core.HTTPRequestDoer, keyResolver resolver.KeyResolver, credentialStore Store, credentialIssuer Issuer,
+	sessionDatabase storage.SessionDatabase, deferredStore stoabs.KVStore, deferredTypes []string) (OpenIDHandler, error) {
 	i := &openidHandler{
 		issuerIdentifierURL: issuerIdentifierURL,
 		issuerDID:           issuerDID,
 		definitionsDIR:      definitionsDIR,
 		httpClient:          httpClient,
 		keyResolver:         keyResolver,
+		credentialStore:     credentialStore,
+		credentialIssuer:    credentialIssuer,
 		walletClientCreator: openid4vci.NewWalletAPIClient,
 		store:               NewOpenIDMemoryStore(sessionDatabase),
+		deferredStore:       deferredStore,
+		deferredTypes:       deferredTypes,
 	}
 
 	// load the credential definitions. This is done to halt startup procedure if needed.
@@ -112,15 +172,21 @@ type openidHandler struct {
 	definitionsDIR       string
 	credentialsSupported []map[string]interface{}
 	keyResolver          resolver.KeyResolver
+	credentialStore      Store
+	credentialIssuer     Issuer
 	store                OpenIDStore
 	walletClientCreator  func(ctx context.Context, httpClient core.HTTPRequestDoer, walletMetadataURL string) (openid4vci.WalletAPIClient, error)
 	httpClient           core.HTTPRequestDoer
+	deferredStore        stoabs.KVStore
+	deferredTypes        []string
 }
 
 func (i *openidHandler) Metadata() openid4vci.CredentialIssuerMetadata {
 	metadata := openid4vci.CredentialIssuerMetadata{
-		CredentialIssuer:   i.issuerIdentifierURL,
-		CredentialEndpoint: core.JoinURLPaths(i.issuerIdentifierURL, "/openid4vci/credential"),
+		CredentialIssuer:           i.issuerIdentifierURL,
+		CredentialEndpoint:         core.JoinURLPaths(i.issuerIdentifierURL, "/openid4vci/credential"),
+		BatchCredentialEndpoint:    core.JoinURLPaths(i.issuerIdentifierURL, "/openid4vci/batch_credential"),
+		DeferredCredentialEndpoint: core.JoinURLPaths(i.issuerIdentifierURL, "/openid4vci/deferred_credential"),
 	}
 
 	// deepcopy the i.credentialsSupported slice to prevent concurrent access to the slice.
@@ -131,8 +197,12 @@ func (i *openidHandler) Metadata() openid4vci.CredentialIssuerMetadata {
 
 func (i *openidHandler) ProviderMetadata() openid4vci.ProviderMetadata {
 	return openid4vci.ProviderMetadata{
-		Issuer:        i.issuerIdentifierURL,
-		TokenEndpoint: core.JoinURLPaths(i.issuerIdentifierURL, "token"),
+		Issuer:                        i.issuerIdentifierURL,
+		AuthorizationEndpoint:         core.JoinURLPaths(i.issuerIdentifierURL, "authorize"),
+		TokenEndpoint:                 core.JoinURLPaths(i.issuerIdentifierURL, "token"),
+		GrantTypesSupported:           []string{openid4vci.PreAuthorizedCodeGrant, openid4vci.AuthorizationCodeGrant},
+		CodeChallengeMethodsSupported: []string{oauth.PKCEMethodS256},
+		RequireSignedRequestObject:    true,
 		// TODO: Anonymous access (no client_id) is OK as long as PKIoverheid Private is used,
 		// if that requirement is dropped we need to authenticate wallets using client_id.
 		// See https://github.com/nuts-foundation/nuts-node/issues/2032
@@ -159,8 +229,294 @@ func (i *openidHandler) HandleAccessTokenRequest(ctx context.Context, preAuthori
 			StatusCode: http.StatusBadRequest,
 		}
 	}
+	accessToken, cNonce, err := i.issueAccessToken(ctx, flow)
+	if err != nil {
+		return "", "", err
+	}
+
+	// PreAuthorizedCode is to be used just once
+	// See https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#section-4.1.1
+	// "This code MUST be short-lived and single-use."
+	// The flow can't be redeemed using the authorization code flow anymore either.
+	i.deleteReferences(ctx, flow, preAuthCodeRefType, issuerStateRefType)
+	return accessToken, cNonce, nil
+}
+
+func (i *openidHandler) HandleAuthorizeRequest(ctx context.Context, request openid4vci.AuthorizationRequest) (string, error) {
+	// The wallet authenticates by signing the authorization request with a key of its DID (client_id),
+	// the same way it authenticates to the IAM authorization server.
+	if request.Request == "" {
+		return "", openid4vci.Error{
+			Err:        errors.New("authorization request must be a signed request object"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	params, err := oauth.ValidateJARRequest(ctx, i.keyResolver, request.Request, request.ClientID)
+	if err != nil {
+		return "", openid4vci.Error{
+			Err:        fmt.Errorf("invalid request object: %w", err),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// the request object must be intended for this issuer, otherwise another issuer could replay it
+	if audience, _ := params[jwt.AudienceKey].([]string); !slices.Contains(audience, i.issuerIdentifierURL) {
+		return "", openid4vci.Error{
+			Err:        errors.New("request object audience does not match issuer"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if responseType := stringParam(params, oauth.ResponseTypeParam); responseType != oauth.CodeResponseType {
+		return "", openid4vci.Error{
+			Err:        fmt.Errorf("unsupported response_type: %s", responseType),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	redirectURI, err := url.Parse(stringParam(params, oauth.RedirectURIParam))
+	if err != nil || !redirectURI.IsAbs() {
+		return "", openid4vci.Error{
+			Err:        errors.New("invalid redirect_uri"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// PKCE is mandatory, since the authorization code is sent through the redirect
+	codeChallenge := stringParam(params, oauth.CodeChallengeParam)
+	codeChallengeMethod := stringParam(params, oauth.CodeChallengeMethodParam)
+	if codeChallenge == "" || codeChallengeMethod != oauth.PKCEMethodS256 {
+		return "", openid4vci.Error{
+			Err:        errors.New("code_challenge is required and code_challenge_method must be S256"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// If the flow was initiated by a credential offer, the wallet presents the issuer_state from the offer.
+	// Otherwise, the wallet requests the credentials it wants using authorization_details.
+	var flow *Flow
+	issuerState := stringParam(params, issuerStateParam)
+	if issuerState != "" {
+		flow, err = i.issuerInitiatedFlow(ctx, issuerState, request.ClientID)
+	} else {
+		flow, err = i.walletInitiatedFlow(ctx, params[authorizationDetailsParam], request.ClientID)
+	}
+	if err != nil {
+		return "", err
+	}
+	grant := flow.grant(openid4vci.AuthorizationCodeGrant)
+	grant.Params[oauth.ClientIDParam] = request.ClientID
+	grant.Params[oauth.RedirectURIParam] = redirectURI.String()
+	grant.Params[oauth.CodeChallengeParam] = codeChallenge
+	grant.Params[oauth.CodeChallengeMethodParam] = codeChallengeMethod
+	code := crypto.GenerateNonce()
+	if issuerState != "" {
+		err = i.store.Update(ctx, *flow)
+	} else {
+		err = i.store.Store(ctx, *flow)
+	}
+	if err == nil {
+		err = i.store.StoreReference(ctx, flow.ID, authCodeRefType, code)
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to store authorization code: %w", err)
+	}
+	// issuer_state can only be used once, and the flow can't be redeemed using the pre-authorized code anymore.
+	i.deleteReferences(ctx, flow, issuerStateRefType, preAuthCodeRefType)
+
+	query := redirectURI.Query()
+	query.Set(oauth.CodeParam, code)
+	if state := stringParam(params, oauth.StateParam); state != "" {
+		query.Set(oauth.StateParam, state)
+	}
+	redirectURI.RawQuery = query.Encode()
+	return redirectURI.String(), nil
+}
+
+// issuerInitiatedFlow returns the flow of the credential offer with the given issuer_state.
+// The credential must have been offered to the given client.
+func (i *openidHandler) issuerInitiatedFlow(ctx context.Context, issuerState string, clientID string) (*Flow, error) {
+	flow, err := i.store.FindByReference(ctx, issuerStateRefType, issuerState)
+	if err != nil {
+		return nil, err
+	}
+	if flow == nil || flow.IssuerID != i.issuerDID.String() {
+		return nil, openid4vci.Error{
+			Err:        errors.New("unknown issuer_state"),
+			Code:       openid4vci.InvalidGrant,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if clientID != flow.WalletID {
+		return nil, openid4vci.Error{
+			Err:        errors.New("client_id does not match the wallet the credential was offered to"),
+			Code:       openid4vci.InvalidClient,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if flow.grant(openid4vci.AuthorizationCodeGrant) == nil {
+		return nil, openid4vci.Error{
+			Err:        errors.New("credential offer does not allow the authorization code flow"),
+			Code:       openid4vci.InvalidGrant,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return flow, nil
+}
+
+// walletInitiatedFlow creates a new flow for the credentials requested by the wallet through authorization_details.
+// Only credentials that have been issued to the client (the authenticated wallet DID) by this issuer can be requested,
+// for each requested credential the most recently issued, non-revoked and non-expired one is returned.
+func (i *openidHandler) walletInitiatedFlow(ctx context.Context, authorizationDetails interface{}, clientID string) (*Flow, error) {
+	var requested []openid4vci.AuthorizationDetails
+	if data, err := json.Marshal(authorizationDetails); err != nil || json.Unmarshal(data, &requested) != nil || len(requested) == 0 {
+		return nil, openid4vci.Error{
+			Err:        errors.New("missing issuer_state or authorization_details"),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	subject, err := ssi.ParseURI(clientID)
+	if err != nil {
+		return nil, openid4vci.Error{
+			Err:        fmt.Errorf("invalid client_id: %w", err),
+			Code:       openid4vci.InvalidClient,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	flow := Flow{
+		ID:       uuid.NewString(),
+		IssuerID: i.issuerDID.String(),
+		WalletID: clientID,
+		Grants: []Grant{
+			{
+				Type:   openid4vci.AuthorizationCodeGrant,
+				Params: map[string]interface{}{},
+			},
+		},
+	}
+	for _, details := range requested {
+		if details.Type != openid4vci.AuthorizationDetailsTypeOpenIDCredential || details.Format != vc.JSONLDCredentialProofFormat || details.CredentialDefinition == nil {
+			return nil, openid4vci.Error{
+				Err:        errors.New("unsupported authorization_details: only 'openid_credential' with format 'ldp_vc' and a credential_definition is supported"),
+				Code:       openid4vci.InvalidRequest,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		credential, err := i.findIssuedCredential(ctx, *details.CredentialDefinition, *subject)
+		if err != nil {
+			return nil, err
+		}
+		flow.Credentials = append(flow.Credentials, *credential)
+	}
+	return &flow, nil
+}
+
+// findIssuedCredential returns the most recently issued, valid credential matching the credential definition,
+// issued to the given subject by this issuer. Revoked and suspended credentials are skipped.
+func (i *openidHandler) findIssuedCredential(ctx context.Context, definition openid4vci.CredentialDefinition, subject ssi.URI) (*vc.VerifiableCredential, error) {
+	var result *vc.VerifiableCredential
+	for _, credentialType := range definition.Type {
+		if credentialType.String() == vc.VerifiableCredentialType {
+			continue
+		}
+		candidates, err := i.credentialStore.SearchCredential(credentialType, i.issuerDID, &subject)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search issued credentials: %w", err)
+		}
+		for j := range candidates {
+			candidate := candidates[j]
+			if openid4vci.ValidateDefinitionWithCredential(candidate, definition) != nil ||
+				(candidate.ExpirationDate != nil && candidate.ExpirationDate.Before(time.Now())) ||
+				(result != nil && result.IssuanceDate != nil && candidate.IssuanceDate != nil && !candidate.IssuanceDate.After(*result.IssuanceDate)) {
+				continue
+			}
+			revoked, suspended, err := i.credentialIssuer.RevocationStatus(ctx, candidate)
+			if err != nil {
+				log.Logger().WithError(err).
+					WithField(core.LogFieldCredentialID, candidate.ID).
+					Warn("Unable to determine revocation status of issued credential, skipping it")
+				continue
+			}
+			if revoked || suspended {
+				continue
+			}
+			result = &candidate
+		}
+		break
+	}
+	if result == nil {
+		return nil, openid4vci.Error{
+			Err:        errors.New("no credential matching authorization_details has been issued to the wallet"),
+			Code:       openid4vci.AccessDenied,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return result, nil
+}
+
+// stringParam returns the value of the given authorization request parameter, or an empty string if it's not a string.
+func stringParam(params map[string]interface{}, key string) string {
+	value, _ := params[key].(string)
+	return value
+}
+
+func (i *openidHandler) HandleAuthorizationCodeTokenRequest(ctx context.Context, code string, codeVerifier string, redirectURI string, clientID string) (string, string, error) {
+	flow, err := i.store.FindByReference(ctx, authCodeRefType, code)
+	if err != nil {
+		return "", "", err
+	}
+	if flow == nil || flow.IssuerID != i.issuerDID.String() {
+		return "", "", openid4vci.Error{
+			Err:        errors.New("unknown authorization code"),
+			Code:       openid4vci.InvalidGrant,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// The authorization code is to be used just once, also when the request is invalid (e.g. an attacker guessing the code verifier).
+	if err := i.store.DeleteReference(ctx, authCodeRefType, code); err != nil {
+		log.Logger().WithError(err).Error("Failed to delete authorization code")
+	}
+	grant := flow.grant(openid4vci.AuthorizationCodeGrant)
+	if grant == nil {
+		return "", "", openid4vci.Error{
+			Err:        errors.New("unknown authorization code"),
+			Code:       openid4vci.InvalidGrant,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if grant.Params[oauth.ClientIDParam] != clientID {
+		return "", "", openid4vci.Error{
+			Err:        errors.New("client_id does not match authorization request"),
+			Code:       openid4vci.InvalidClient,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if grant.Params[oauth.RedirectURIParam] != redirectURI {
+		return "", "", openid4vci.Error{
+			Err:        errors.New("redirect_uri does not match authorization request"),
+			Code:       openid4vci.InvalidGrant,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	challenge, _ := grant.Params[oauth.CodeChallengeParam].(string)
+	challengeMethod, _ := grant.Params[oauth.CodeChallengeMethodParam].(string)
+	if !oauth.ValidatePKCEParams(challenge, challengeMethod, codeVerifier) {
+		return "", "", openid4vci.Error{
+			Err:        errors.New("code_verifier does not match code_challenge"),
+			Code:       openid4vci.InvalidGrant,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return i.issueAccessToken(ctx, flow)
+}
+
+// issueAccessToken generates and stores an access token and c_nonce for the given flow.
+func (i *openidHandler) issueAccessToken(ctx context.Context, flow *Flow) (string, string, error) {
 	accessToken := crypto.GenerateNonce()
-	err = i.store.StoreReference(ctx, flow.ID, accessTokenRefType, accessToken)
+	err := i.store.StoreReference(ctx, flow.ID, accessTokenRefType, accessToken)
 	if err != nil {
 		return "", "", err
 	}
@@ -169,17 +525,33 @@ func (i *openidHandler) HandleAccessTokenRequest(ctx context.Context, preAuthori
 	if err != nil {
 		return "", "", err
 	}
+	return accessToken, cNonce, nil
+}
 
-	// PreAuthorizedCode is to be used just once
-	// See https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#section-4.1.1
-	// "This code MUST be short-lived and single-use."
-	err = i.store.DeleteReference(ctx, preAuthCodeRefType, preAuthorizedCode)
-	if err != nil {
-		// Extremely unlikely, but if we return an error here the credential issuance flow will fail without a way to retry it.
-		// Just log it, nothing will break (since they'll be pruned after ttl anyway).
-		log.Logger().WithError(err).Error("Failed to delete pre-authorized code")
+// deleteReferences deletes the references of the given types to the flow's grants, so they can't be used anymore.
+// Failures are only logged: if an error would be returned the credential issuance flow would fail without a way to retry it,
+// and nothing will break (since they'll be pruned after ttl anyway).
+func (i *openidHandler) deleteReferences(ctx context.Context, flow *Flow, refTypes ...string) {
+	for _, refType := range refTypes {
+		var grantType, param string
+		switch refType {
+		case preAuthCodeRefType:
+			grantType, param = openid4vci.PreAuthorizedCodeGrant, "pre-authorized_code"
+		case issuerStateRefType:
+			grantType, param = openid4vci.AuthorizationCodeGrant, issuerStateParam
+		}
+		grant := flow.grant(grantType)
+		if grant == nil {
+			continue
+		}
+		reference, _ := grant.Params[param].(string)
+		if reference == "" {
+			continue
+		}
+		if err := i.store.DeleteReference(ctx, refType, reference); err != nil {
+			log.Logger().WithError(err).Errorf("Failed to delete %s", param)
+		}
 	}
-	return accessToken, cNonce, nil
 }
 
 func (i *openidHandler) OfferCredential(ctx context.Context, credential vc.VerifiableCredential, walletIdentifier string) error {
@@ -206,21 +578,43 @@ func (i *openidHandler) OfferCredential(ctx context.Context, credential vc.Verif
 	return nil
 }
 
-func (i *openidHandler) HandleCredentialRequest(ctx context.Context, request openid4vci.CredentialRequest, accessToken string) (*vc.VerifiableCredential, error) {
-	if request.Format != vc.JSONLDCredentialProofFormat {
-		return nil, openid4vci.Error{
-			Err:        fmt.Errorf("credential request: unsupported format '%s'", request.Format),
-			Code:       openid4vci.UnsupportedCredentialType,
-			StatusCode: http.StatusBadRequest,
-		}
+func (i *openidHandler) HandleCredentialRequest(ctx context.Context, request openid4vci.CredentialRequest, accessToken string) (*openid4vci.CredentialResponse, error) {
+	flow, err := i.findFlowByAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
 	}
-	if err := request.CredentialDefinition.Validate(false); err != nil {
+	return i.handleCredentialRequest(ctx, flow, request, accessToken, map[int]bool{})
+}
+
+func (i *openidHandler) HandleBatchCredentialRequest(ctx context.Context, request openid4vci.BatchCredentialRequest, accessToken string) (*openid4vci.BatchCredentialResponse, error) {
+	if len(request.CredentialRequests) == 0 {
 		return nil, openid4vci.Error{
-			Err:        fmt.Errorf("credential request: %w", err),
+			Err:        errors.New("batch credential request: no credential requests"),
 			Code:       openid4vci.InvalidRequest,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
+	flow, err := i.findFlowByAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	result := openid4vci.BatchCredentialResponse{
+		CredentialResponses: make([]openid4vci.CredentialResponse, 0, len(request.CredentialRequests)),
+	}
+	// every credential of the flow can be returned only once in the batch
+	issued := make(map[int]bool)
+	for _, credentialRequest := range request.CredentialRequests {
+		response, err := i.handleCredentialRequest(ctx, flow, credentialRequest, accessToken, issued)
+		if err != nil {
+			return nil, err
+		}
+		result.CredentialResponses = append(result.CredentialResponses, *response)
+	}
+	return &result, nil
+}
+
+// findFlowByAccessToken returns the flow for which the given access token was issued.
+func (i *openidHandler) findFlowByAccessToken(ctx context.Context, accessToken string) (*Flow, error) {
 	flow, err := i.store.FindByReference(ctx, accessTokenRefType, accessToken)
 	if err != nil {
 		return nil, err
@@ -233,9 +627,36 @@ func (i *openidHandler) HandleCredentialRequest(ctx context.Context, request ope
 			StatusCode: http.StatusBadRequest,
 		}
 	}
+	return flow, nil
+}
 
-	credential := flow.Credentials[0] // there's always just one (at least for now)
-	subjectDID, _ := credential.SubjectDID()
+// handleCredentialRequest handles a single credential request for the given flow.
+// The credentials of the flow at the indices in issued are skipped, the index of the returned credential is added to it.
+func (i *openidHandler) handleCredentialRequest(ctx context.Context, flow *Flow, request openid4vci.CredentialRequest, accessToken string, issued map[int]bool) (*openid4vci.CredentialResponse, error) {
+	if request.Format != vc.JSONLDCredentialProofFormat {
+		return nil, openid4vci.Error{
+			Err:        fmt.Errorf("credential request: unsupported format '%s'", request.Format),
+			Code:       openid4vci.UnsupportedCredentialType,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if err := request.CredentialDefinition.Validate(false); err != nil {
+		return nil, openid4vci.Error{
+			Err:        fmt.Errorf("credential request: %w", err),
+			Code:       openid4vci.InvalidRequest,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if err := i.validateProof(ctx, flow, request); err != nil {
+		return nil, err
+	}
+
+	credentialIndex, err := selectCredential(flow, *request.CredentialDefinition, issued)
+	if err != nil {
+		return nil, err
+	}
+	credential := flow.Credentials[credentialIndex]
 
 	// check credential.Issuer against given issuer
 	if credential.Issuer.String() != i.issuerDID.String() {
@@ -245,37 +666,78 @@ func (i *openidHandler) HandleCredentialRequest(ctx context.Context, request ope
 			StatusCode: http.StatusBadRequest,
 		}
 	}
+	issued[credentialIndex] = true
 
-	if err = i.validateProof(ctx, flow, request); err != nil {
-		return nil, err
+	if i.IsDeferred(credential) {
+		transactionID, err := i.deferIssuance(ctx, credential, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		return &openid4vci.CredentialResponse{Format: vc.JSONLDCredentialProofFormat, TransactionID: &transactionID}, nil
 	}
+	return i.credentialResponse(ctx, credential)
+}
 
-	if err = openid4vci.ValidateDefinitionWithCredential(credential, *request.CredentialDefinition); err != nil {
-		return nil, openid4vci.Error{
-			Err:        fmt.Errorf("requested credential does not match offer: %w", err),
+// selectCredential returns the index of the first credential of the flow that matches the requested credential definition,
+// and hasn't been issued yet (indicated by issued). A flow might contain multiple credentials (e.g. in the wallet-initiated flow),
+// which a wallet can retrieve using a batch credential request.
+func selectCredential(flow *Flow, definition openid4vci.CredentialDefinition, issued map[int]bool) (int, error) {
+	var mismatchErr error
+	alreadyIssued := false
+	for index, credential := range flow.Credentials {
+		if err := openid4vci.ValidateDefinitionWithCredential(credential, definition); err != nil {
+			mismatchErr = err
+			continue
+		}
+		if !issued[index] {
+			return index, nil
+		}
+		alreadyIssued = true
+	}
+	if alreadyIssued {
+		return -1, openid4vci.Error{
+			Err:        errors.New("requested credential was already requested in this batch"),
 			Code:       openid4vci.InvalidRequest,
 			StatusCode: http.StatusBadRequest,
 		}
 	}
+	return -1, openid4vci.Error{
+		Err:        fmt.Errorf("requested credential does not match offer: %w", mismatchErr),
+		Code:       openid4vci.InvalidRequest,
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// credentialResponse creates the credential response containing the given credential, which is about to be returned to the wallet.
+func (i *openidHandler) credentialResponse(ctx context.Context, credential vc.VerifiableCredential) (*openid4vci.CredentialResponse, error) {
+	credentialJSON, _ := credential.MarshalJSON()
+	credentialMap := make(map[string]interface{})
+	if err := json.Unmarshal(credentialJSON, &credentialMap); err != nil {
+		return nil, err
+	}
 
 	// Important: since we (for now) create the VC even before the wallet requests it, we don't know if every VC is actually retrieved by the wallet.
 	//            This is a temporary shortcut, since changing that requires a lot of refactoring.
 	//            To make actually retrieved VC traceable, we log it to the audit log.
+	subjectDID, _ := credential.SubjectDID()
 	audit.Log(ctx, log.Logger(), audit.VerifiableCredentialRetrievedEvent).
 		WithField(core.LogFieldCredentialID, credential.ID).
 		WithField(core.LogFieldCredentialIssuer, credential.Issuer.String()).
 		WithField(core.LogFieldCredentialSubject, subjectDID).
 		Info("VC retrieved by wallet over OpenID4VCI")
 
-	return &credential, nil
+	return &openid4vci.CredentialResponse{
+		Credential: &credentialMap,
+		Format:     vc.JSONLDCredentialProofFormat,
+	}, nil
 }
 
 // validateProof validates the proof of the credential request. Aside from checks as specified by the spec,
 // it verifies the proof signature, and whether the signer is the intended wallet.
 // See https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-proof-types
 func (i *openidHandler) validateProof(ctx context.Context, flow *Flow, request openid4vci.CredentialRequest) error {
-	credential := flow.Credentials[0] // there's always just one (at least for now)
-	wallet, _ := credential.SubjectDID()
+	// all credentials of a flow are issued to the same wallet
+	wallet, _ := flow.Credentials[0].SubjectDID()
 
 	// augment invalid_proof errors according to §7.3.2 of openid4vci spec
 	generateProofError := func(err openid4vci.Error) error {
@@ -405,6 +867,11 @@ func (i *openidHandler) createOffer(ctx context.Context, credential vc.Verifiabl
 	grantParams := map[string]interface{}{
 		"pre-authorized_code": preAuthorizedCode,
 	}
+	// The wallet may also choose the authorization code flow, for which it needs to present the issuer_state.
+	issuerState := crypto.GenerateNonce()
+	authorizationCodeGrantParams := map[string]interface{}{
+		issuerStateParam: issuerState,
+	}
 	offer := openid4vci.CredentialOffer{
 		CredentialIssuer: i.issuerIdentifierURL,
 		Credentials: []openid4vci.OfferedCredential{{
@@ -416,6 +883,7 @@ func (i *openidHandler) createOffer(ctx context.Context, credential vc.Verifiabl
 		}},
 		Grants: map[string]interface{}{
 			openid4vci.PreAuthorizedCodeGrant: grantParams,
+			openid4vci.AuthorizationCodeGrant: authorizationCodeGrantParams,
 		},
 	}
 	subjectDID, _ := credential.SubjectDID() // succeeded in previous step, can't fail
@@ -430,12 +898,19 @@ func (i *openidHandler) createOffer(ctx context.Context, credential vc.Verifiabl
 				Type:   openid4vci.PreAuthorizedCodeGrant,
 				Params: grantParams,
 			},
+			{
+				Type:   openid4vci.AuthorizationCodeGrant,
+				Params: map[string]interface{}{issuerStateParam: issuerState},
+			},
 		},
 	}
 	err := i.store.Store(ctx, flow)
 	if err == nil {
 		err = i.store.StoreReference(ctx, flow.ID, preAuthCodeRefType, preAuthorizedCode)
 	}
+	if err == nil {
+		err = i.store.StoreReference(ctx, flow.ID, issuerStateRefType, issuerState)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to store credential offer: %w", err)
 	}
@@ -0,0 +1,308 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package issuer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+)
+
+// DeferredIssuanceTTL is the time after which deferred credential issuances are discarded
+// if they weren't completed and retrieved by the wallet.
+const DeferredIssuanceTTL = 7 * 24 * time.Hour
+
+// ErrDeferredIssuanceNotFound is returned when a deferred credential issuance can't be found, e.g. because it expired.
+var ErrDeferredIssuanceNotFound = errors.New("deferred credential issuance not found")
+
+// ErrDeferredIssuanceCompleted is returned when a deferred credential issuance can't be denied, because it was already completed.
+// The credential is signed by then, so it must be revoked instead.
+var ErrDeferredIssuanceCompleted = errors.New("deferred credential issuance already completed")
+
+// DeferredIssuanceStatus is the status of a deferred credential issuance.
+type DeferredIssuanceStatus string
+
+// DeferredIssuanceStatusPending indicates the deferred credential issuance awaits completion by the issuer.
+const DeferredIssuanceStatusPending DeferredIssuanceStatus = "pending"
+
+// DeferredIssuanceStatusCompleted indicates the deferred credential issuance was completed by the issuer,
+// and the credential can be retrieved by the wallet.
+const DeferredIssuanceStatusCompleted DeferredIssuanceStatus = "completed"
+
+// DeferredIssuance is a credential issuance over OpenID4VCI that was deferred,
+// until it is completed or denied by the issuer.
+type DeferredIssuance struct {
+	// TransactionID identifies the deferred issuance, it is used by the wallet to retrieve the credential.
+	TransactionID string `json:"transactionId"`
+	// Credential is the credential that will be issued to the wallet.
+	// Credentials offered by the issuer are unsigned until the issuance is completed.
+	// Credentials requested by the wallet (in the wallet-initiated flow) were issued before, so they're signed already.
+	Credential vc.VerifiableCredential `json:"credential"`
+	// Status is the status of the deferred issuance.
+	Status DeferredIssuanceStatus `json:"status"`
+	// CreatedAt is the time the wallet requested the credential.
+	CreatedAt time.Time `json:"createdAt"`
+	// ExpiresAt is the time after which the deferred issuance is discarded if the credential wasn't retrieved by the wallet.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (d DeferredIssuance) expired() bool {
+	return !TimeFunc().Before(d.ExpiresAt)
+}
+
+// deferredIssuanceRecord is the stored form of a DeferredIssuance.
+// It includes the access token the wallet used, which it must present again to retrieve the credential.
+type deferredIssuanceRecord struct {
+	DeferredIssuance
+	AccessToken string `json:"accessToken"`
+}
+
+func (i *openidHandler) IsDeferred(credential vc.VerifiableCredential) bool {
+	for _, credentialType := range credential.Type {
+		if slices.Contains(i.deferredTypes, credentialType.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// deferIssuance stores the credential as deferred issuance, and returns the transaction ID the wallet uses to retrieve it.
+func (i *openidHandler) deferIssuance(ctx context.Context, credential vc.VerifiableCredential, accessToken string) (string, error) {
+	now := TimeFunc()
+	record := deferredIssuanceRecord{
+		DeferredIssuance: DeferredIssuance{
+			TransactionID: uuid.NewString(),
+			Credential:    credential,
+			Status:        DeferredIssuanceStatusPending,
+			CreatedAt:     now,
+			ExpiresAt:     now.Add(DeferredIssuanceTTL),
+		},
+		AccessToken: accessToken,
+	}
+	if err := i.writeDeferredIssuance(ctx, record); err != nil {
+		return "", err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credential.ID).
+		Infof("Deferred OpenID4VCI credential issuance (transaction_id=%s)", record.TransactionID)
+	return record.TransactionID, nil
+}
+
+func (i *openidHandler) HandleDeferredCredentialRequest(ctx context.Context, request openid4vci.DeferredCredentialRequest, accessToken string) (*openid4vci.CredentialResponse, error) {
+	record, err := i.readDeferredIssuance(ctx, request.TransactionID)
+	if errors.Is(err, ErrDeferredIssuanceNotFound) {
+		return nil, openid4vci.Error{
+			Err:        fmt.Errorf("unknown transaction_id: %s", request.TransactionID),
+			Code:       openid4vci.InvalidTransactionID,
+			StatusCode: http.StatusBadRequest,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if record.AccessToken != accessToken {
+		log.Logger().Warn("Client tried retrieving deferred credential over OpenID4VCI with unknown OAuth2 access token")
+		return nil, openid4vci.Error{
+			Err:        errors.New("access token does not match the credential request"),
+			Code:       openid4vci.InvalidToken,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if record.Status != DeferredIssuanceStatusCompleted {
+		return nil, openid4vci.Error{
+			Code:       openid4vci.IssuancePending,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	// The credential can be retrieved only once
+	if err = i.deleteDeferredIssuance(ctx, request.TransactionID); err != nil {
+		return nil, err
+	}
+	return i.credentialResponse(ctx, record.Credential)
+}
+
+func (i *openidHandler) ListDeferredIssuances(ctx context.Context) ([]DeferredIssuance, error) {
+	result := make([]DeferredIssuance, 0)
+	var expired []stoabs.Key
+	err := i.deferredStore.ReadShelf(ctx, i.issuerDID.String(), func(reader stoabs.Reader) error {
+		return reader.Iterate(func(key stoabs.Key, value []byte) error {
+			var record deferredIssuanceRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				return fmt.Errorf("unable to unmarshal deferred issuance %s: %w", string(key.Bytes()), err)
+			}
+			if record.expired() {
+				expired = append(expired, key)
+			} else {
+				result = append(result, record.DeferredIssuance)
+			}
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list deferred issuances: %w", err)
+	}
+	if len(expired) > 0 {
+		err = deleteDeferredIssuances(ctx, i.deferredStore, i.issuerDID, expired)
+		if err != nil {
+			log.Logger().WithError(err).Warn("Failed to discard expired OpenID4VCI deferred issuances")
+		}
+	}
+	slices.SortFunc(result, func(a, b DeferredIssuance) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return result, nil
+}
+
+func (i *openidHandler) CompleteDeferredIssuance(ctx context.Context, transactionID string) error {
+	record, err := i.readDeferredIssuance(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	if record.Status == DeferredIssuanceStatusCompleted {
+		return nil
+	}
+	// Credentials offered by the issuer are signed (and stored) only now. If signing fails, the issuance stays pending.
+	if record.Credential.Proof == nil {
+		signedCredential, err := i.credentialIssuer.SignDeferred(ctx, record.Credential)
+		if err != nil {
+			return fmt.Errorf("unable to sign credential of deferred issuance: %w", err)
+		}
+		record.Credential = *signedCredential
+	}
+	record.Status = DeferredIssuanceStatusCompleted
+	if err = i.writeDeferredIssuance(ctx, *record); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, record.Credential.ID).
+		Infof("Completed deferred OpenID4VCI credential issuance (transaction_id=%s)", transactionID)
+	return nil
+}
+
+func (i *openidHandler) DenyDeferredIssuance(ctx context.Context, transactionID string) error {
+	record, err := i.readDeferredIssuance(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	if record.Status == DeferredIssuanceStatusCompleted {
+		return ErrDeferredIssuanceCompleted
+	}
+	if err = i.deleteDeferredIssuance(ctx, transactionID); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, record.Credential.ID).
+		Infof("Denied deferred OpenID4VCI credential issuance (transaction_id=%s)", transactionID)
+	return nil
+}
+
+// readDeferredIssuance returns the deferred issuance with the given transaction ID.
+// It returns ErrDeferredIssuanceNotFound if it doesn't exist or expired.
+func (i *openidHandler) readDeferredIssuance(ctx context.Context, transactionID string) (*deferredIssuanceRecord, error) {
+	var result deferredIssuanceRecord
+	err := i.deferredStore.ReadShelf(ctx, i.issuerDID.String(), func(reader stoabs.Reader) error {
+		data, err := reader.Get(stoabs.BytesKey(transactionID))
+		if errors.Is(err, stoabs.ErrKeyNotFound) {
+			return ErrDeferredIssuanceNotFound
+		} else if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(data, &result); err != nil {
+			return fmt.Errorf("unable to unmarshal deferred issuance %s: %w", transactionID, err)
+		}
+		return nil
+	})
+	if errors.Is(err, ErrDeferredIssuanceNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to retrieve deferred issuance: %w", err)
+	}
+	if result.expired() {
+		return nil, ErrDeferredIssuanceNotFound
+	}
+	return &result, nil
+}
+
+func (i *openidHandler) writeDeferredIssuance(ctx context.Context, record deferredIssuanceRecord) error {
+	data, _ := json.Marshal(record)
+	err := i.deferredStore.WriteShelf(ctx, i.issuerDID.String(), func(writer stoabs.Writer) error {
+		return writer.Put(stoabs.BytesKey(record.TransactionID), data)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to store deferred issuance: %w", err)
+	}
+	return nil
+}
+
+func (i *openidHandler) deleteDeferredIssuance(ctx context.Context, transactionID string) error {
+	err := i.deferredStore.WriteShelf(ctx, i.issuerDID.String(), func(writer stoabs.Writer) error {
+		return writer.Delete(stoabs.BytesKey(transactionID))
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete deferred issuance: %w", err)
+	}
+	return nil
+}
+
+// PruneDeferredIssuances discards the expired deferred issuances of the given issuer from the store.
+// It returns the number of discarded deferred issuances.
+func PruneDeferredIssuances(ctx context.Context, store stoabs.KVStore, issuerDID did.DID) (int, error) {
+	var expired []stoabs.Key
+	err := store.ReadShelf(ctx, issuerDID.String(), func(reader stoabs.Reader) error {
+		return reader.Iterate(func(key stoabs.Key, value []byte) error {
+			var record deferredIssuanceRecord
+			if err := json.Unmarshal(value, &record); err != nil || record.expired() {
+				expired = append(expired, key)
+			}
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to read deferred issuances: %w", err)
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+	if err = deleteDeferredIssuances(ctx, store, issuerDID, expired); err != nil {
+		return 0, fmt.Errorf("unable to delete expired deferred issuances: %w", err)
+	}
+	return len(expired), nil
+}
+
+func deleteDeferredIssuances(ctx context.Context, store stoabs.KVStore, issuerDID did.DID, keys []stoabs.Key) error {
+	return store.WriteShelf(ctx, issuerDID.String(), func(writer stoabs.Writer) error {
+		for _, key := range keys {
+			if err := writer.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
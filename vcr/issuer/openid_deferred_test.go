@@ -0,0 +1,356 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package issuer
+
+import (
+	"context"
+	crypt "crypto"
+	"net/http"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_memoryIssuer_HandleBatchCredentialRequest(t *testing.T) {
+	ctx := audit.TestContext()
+	t.Run("ok", func(t *testing.T) {
+		service, accessToken, request := newTestCredentialRequest(t)
+		// add a second credential of the same type to the flow
+		flow, err := service.store.FindByReference(ctx, accessTokenRefType, accessToken)
+		require.NoError(t, err)
+		otherCredentialID := ssi.MustParseURI(issuerDID.String() + "#2")
+		otherCredential := issuedVC
+		otherCredential.ID = &otherCredentialID
+		flow.Credentials = append(flow.Credentials, otherCredential)
+		require.NoError(t, service.store.Update(ctx, *flow))
+
+		response, err := service.HandleBatchCredentialRequest(ctx, openid4vci.BatchCredentialRequest{
+			CredentialRequests: []openid4vci.CredentialRequest{request, request},
+		}, accessToken)
+
+		require.NoError(t, err)
+		require.Len(t, response.CredentialResponses, 2)
+		for _, credentialResponse := range response.CredentialResponses {
+			require.NotNil(t, credentialResponse.Credential)
+			assert.Equal(t, issuerDID.String(), (*credentialResponse.Credential)["issuer"])
+		}
+		assert.Equal(t, issuedVCID.String(), (*response.CredentialResponses[0].Credential)["id"])
+		assert.Equal(t, otherCredentialID.String(), (*response.CredentialResponses[1].Credential)["id"])
+	})
+	t.Run("same credential requested twice", func(t *testing.T) {
+		service, accessToken, request := newTestCredentialRequest(t)
+
+		response, err := service.HandleBatchCredentialRequest(ctx, openid4vci.BatchCredentialRequest{
+			CredentialRequests: []openid4vci.CredentialRequest{request, request},
+		}, accessToken)
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - requested credential was already requested in this batch")
+		assert.Nil(t, response)
+	})
+	t.Run("no credential requests", func(t *testing.T) {
+		service, accessToken, _ := newTestCredentialRequest(t)
+
+		response, err := service.HandleBatchCredentialRequest(ctx, openid4vci.BatchCredentialRequest{}, accessToken)
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - batch credential request: no credential requests")
+		assert.Nil(t, response)
+	})
+	t.Run("one of the credential requests is invalid", func(t *testing.T) {
+		service, accessToken, request := newTestCredentialRequest(t)
+		invalidRequest := request
+		invalidRequest.Proof = nil
+
+		response, err := service.HandleBatchCredentialRequest(ctx, openid4vci.BatchCredentialRequest{
+			CredentialRequests: []openid4vci.CredentialRequest{request, invalidRequest},
+		}, accessToken)
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_proof - missing proof")
+		assert.Nil(t, response)
+	})
+	t.Run("unknown access token", func(t *testing.T) {
+		service, _, request := newTestCredentialRequest(t)
+
+		response, err := service.HandleBatchCredentialRequest(ctx, openid4vci.BatchCredentialRequest{
+			CredentialRequests: []openid4vci.CredentialRequest{request},
+		}, "other")
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_token - unknown access token")
+		assert.Nil(t, response)
+	})
+}
+
+func Test_memoryIssuer_DeferredIssuance(t *testing.T) {
+	ctx := audit.TestContext()
+	// signedVC is issuedVC (which is unsigned) after signing it on completion
+	signedVC := issuedVC
+	signedVC.Proof = []interface{}{map[string]interface{}{"type": "JsonWebSignature2020"}}
+	// unsignedIssuedVC matches issuedVC, as read back from the deferred issuance store
+	unsignedIssuedVC := gomock.Cond(func(x any) bool {
+		credential := x.(vc.VerifiableCredential)
+		return credential.ID.String() == issuedVCID.String() && credential.Proof == nil
+	})
+	// deferIssuance defers issuance of issuedVC. The credential issuer of the returned handler expects no calls.
+	deferIssuance := func(t *testing.T) (*openidHandler, string, string) {
+		service, accessToken, request := newTestCredentialRequest(t)
+		service.deferredTypes = []string{"HumanCredential"}
+		service.credentialIssuer = NewMockIssuer(gomock.NewController(t))
+		response, err := service.HandleCredentialRequest(ctx, request, accessToken)
+		require.NoError(t, err)
+		require.Nil(t, response.Credential)
+		require.NotNil(t, response.TransactionID)
+		return service, accessToken, *response.TransactionID
+	}
+
+	t.Run("credential request is deferred", func(t *testing.T) {
+		service, _, transactionID := deferIssuance(t)
+
+		issuances, err := service.ListDeferredIssuances(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, issuances, 1)
+		assert.Equal(t, transactionID, issuances[0].TransactionID)
+		assert.Equal(t, DeferredIssuanceStatusPending, issuances[0].Status)
+		assert.Equal(t, issuedVC.Type, issuances[0].Credential.Type)
+		t.Run("deferred issuances are kept per issuer", func(t *testing.T) {
+			other := *service
+			other.issuerDID = holderDID
+
+			issuances, err := other.ListDeferredIssuances(ctx)
+
+			require.NoError(t, err)
+			assert.Empty(t, issuances)
+		})
+	})
+	t.Run("credential of other type is not deferred", func(t *testing.T) {
+		service, accessToken, request := newTestCredentialRequest(t)
+		service.deferredTypes = []string{"OtherCredential"}
+
+		response, err := service.HandleCredentialRequest(ctx, request, accessToken)
+
+		require.NoError(t, err)
+		assert.NotNil(t, response.Credential)
+		assert.Nil(t, response.TransactionID)
+	})
+	t.Run("issuance pending", func(t *testing.T) {
+		service, accessToken, transactionID := deferIssuance(t)
+
+		response, err := service.HandleDeferredCredentialRequest(ctx, openid4vci.DeferredCredentialRequest{TransactionID: transactionID}, accessToken)
+
+		assertProtocolError(t, err, http.StatusBadRequest, "issuance_pending")
+		assert.Nil(t, response)
+	})
+	t.Run("completed", func(t *testing.T) {
+		service, accessToken, transactionID := deferIssuance(t)
+		service.credentialIssuer.(*MockIssuer).EXPECT().SignDeferred(ctx, unsignedIssuedVC).Return(&signedVC, nil)
+
+		err := service.CompleteDeferredIssuance(ctx, transactionID)
+		require.NoError(t, err)
+
+		issuances, err := service.ListDeferredIssuances(ctx)
+		require.NoError(t, err)
+		require.Len(t, issuances, 1)
+		assert.Equal(t, DeferredIssuanceStatusCompleted, issuances[0].Status)
+		assert.NotNil(t, issuances[0].Credential.Proof)
+		t.Run("completing again doesn't sign again", func(t *testing.T) {
+			require.NoError(t, service.CompleteDeferredIssuance(ctx, transactionID))
+		})
+		t.Run("can't be denied after completion", func(t *testing.T) {
+			err := service.DenyDeferredIssuance(ctx, transactionID)
+
+			assert.ErrorIs(t, err, ErrDeferredIssuanceCompleted)
+		})
+		t.Run("wallet retrieves credential", func(t *testing.T) {
+			auditLogs := audit.CaptureLogs(t)
+
+			response, err := service.HandleDeferredCredentialRequest(ctx, openid4vci.DeferredCredentialRequest{TransactionID: transactionID}, accessToken)
+
+			require.NoError(t, err)
+			require.NotNil(t, response.Credential)
+			assert.Equal(t, vc.JSONLDCredentialProofFormat, response.Format)
+			assert.Equal(t, issuerDID.String(), (*response.Credential)["issuer"])
+			assert.NotNil(t, (*response.Credential)["proof"])
+			auditLogs.AssertContains(t, "VCR", "VerifiableCredentialRetrievedEvent", audit.TestActor, "VC retrieved by wallet over OpenID4VCI")
+		})
+		t.Run("credential can only be retrieved once", func(t *testing.T) {
+			response, err := service.HandleDeferredCredentialRequest(ctx, openid4vci.DeferredCredentialRequest{TransactionID: transactionID}, accessToken)
+
+			assertProtocolError(t, err, http.StatusBadRequest, "invalid_transaction_id - unknown transaction_id: "+transactionID)
+			assert.Nil(t, response)
+		})
+	})
+	t.Run("completion fails if credential can't be signed", func(t *testing.T) {
+		service, _, transactionID := deferIssuance(t)
+		service.credentialIssuer.(*MockIssuer).EXPECT().SignDeferred(ctx, unsignedIssuedVC).Return(nil, assert.AnError)
+
+		err := service.CompleteDeferredIssuance(ctx, transactionID)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		issuances, err := service.ListDeferredIssuances(ctx)
+		require.NoError(t, err)
+		require.Len(t, issuances, 1)
+		assert.Equal(t, DeferredIssuanceStatusPending, issuances[0].Status)
+	})
+	t.Run("credential that was signed before is not signed again", func(t *testing.T) {
+		service, _, transactionID := deferIssuance(t)
+		record, err := service.readDeferredIssuance(ctx, transactionID)
+		require.NoError(t, err)
+		record.Credential = signedVC
+		require.NoError(t, service.writeDeferredIssuance(ctx, *record))
+
+		err = service.CompleteDeferredIssuance(ctx, transactionID)
+
+		require.NoError(t, err)
+	})
+	t.Run("denied", func(t *testing.T) {
+		// the credential isn't signed, so nothing is revoked (the credential issuer expects no calls)
+		service, accessToken, transactionID := deferIssuance(t)
+
+		err := service.DenyDeferredIssuance(ctx, transactionID)
+		require.NoError(t, err)
+
+		issuances, err := service.ListDeferredIssuances(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, issuances)
+		response, err := service.HandleDeferredCredentialRequest(ctx, openid4vci.DeferredCredentialRequest{TransactionID: transactionID}, accessToken)
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_transaction_id - unknown transaction_id: "+transactionID)
+		assert.Nil(t, response)
+	})
+	t.Run("access token does not match", func(t *testing.T) {
+		service, _, transactionID := deferIssuance(t)
+		service.credentialIssuer.(*MockIssuer).EXPECT().SignDeferred(ctx, unsignedIssuedVC).Return(&signedVC, nil)
+		require.NoError(t, service.CompleteDeferredIssuance(ctx, transactionID))
+
+		response, err := service.HandleDeferredCredentialRequest(ctx, openid4vci.DeferredCredentialRequest{TransactionID: transactionID}, "other")
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_token - access token does not match the credential request")
+		assert.Nil(t, response)
+	})
+	t.Run("expired", func(t *testing.T) {
+		service, accessToken, transactionID := deferIssuance(t)
+		TimeFunc = func() time.Time {
+			return time.Now().Add(DeferredIssuanceTTL)
+		}
+		t.Cleanup(func() {
+			TimeFunc = time.Now
+		})
+
+		issuances, err := service.ListDeferredIssuances(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, issuances)
+		err = service.CompleteDeferredIssuance(ctx, transactionID)
+		assert.ErrorIs(t, err, ErrDeferredIssuanceNotFound)
+		_, err = service.HandleDeferredCredentialRequest(ctx, openid4vci.DeferredCredentialRequest{TransactionID: transactionID}, accessToken)
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_transaction_id - unknown transaction_id: "+transactionID)
+	})
+	t.Run("unknown transaction ID", func(t *testing.T) {
+		service := requireNewTestHandler(t, nil)
+
+		assert.ErrorIs(t, service.CompleteDeferredIssuance(ctx, "unknown"), ErrDeferredIssuanceNotFound)
+		assert.ErrorIs(t, service.DenyDeferredIssuance(context.Background(), "unknown"), ErrDeferredIssuanceNotFound)
+	})
+}
+
+func TestPruneDeferredIssuances(t *testing.T) {
+	ctx := audit.TestContext()
+	service := requireNewTestHandler(t, nil)
+	now := time.Now()
+	for _, createdAt := range []time.Time{now.Add(-DeferredIssuanceTTL), now} {
+		require.NoError(t, service.writeDeferredIssuance(ctx, deferredIssuanceRecord{
+			DeferredIssuance: DeferredIssuance{
+				TransactionID: createdAt.String(),
+				Credential:    issuedVC,
+				Status:        DeferredIssuanceStatusPending,
+				CreatedAt:     createdAt,
+				ExpiresAt:     createdAt.Add(DeferredIssuanceTTL),
+			},
+		}))
+	}
+
+	pruned, err := PruneDeferredIssuances(ctx, service.deferredStore, issuerDID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	issuances, err := service.ListDeferredIssuances(ctx)
+	require.NoError(t, err)
+	require.Len(t, issuances, 1)
+	assert.Equal(t, now.String(), issuances[0].TransactionID)
+	t.Run("nothing to prune", func(t *testing.T) {
+		pruned, err := PruneDeferredIssuances(ctx, service.deferredStore, issuerDID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, pruned)
+	})
+}
+
+// newTestCredentialRequest creates a handler with an issuance flow for issuedVC,
+// and returns it together with the access token and a valid credential request for that flow.
+func newTestCredentialRequest(t *testing.T) (*openidHandler, string, openid4vci.CredentialRequest) {
+	ctx := audit.TestContext()
+	keyStore := crypto.NewMemoryCryptoInstance()
+	signerKey, _ := keyStore.New(ctx, crypto.ECP256Key, func(key crypt.PublicKey) (string, error) {
+		return keyID, nil
+	})
+	keyResolver := resolver.NewMockKeyResolver(gomock.NewController(t))
+	keyResolver.EXPECT().ResolveKeyByID(keyID, nil, resolver.NutsSigningKeyType).AnyTimes().Return(signerKey.Public(), nil)
+	service := requireNewTestHandler(t, keyResolver)
+	_, err := service.createOffer(ctx, issuedVC, "code")
+	require.NoError(t, err)
+	accessToken, cNonce, err := service.HandleAccessTokenRequest(ctx, "code")
+	require.NoError(t, err)
+
+	headers := map[string]interface{}{
+		"typ": openid4vci.JWTTypeOpenID4VCIProof,
+		"kid": keyID,
+	}
+	claims := map[string]interface{}{
+		"aud":   issuerIdentifier,
+		"iat":   time.Now().Unix(),
+		"nonce": cNonce,
+	}
+	proof, err := keyStore.SignJWT(ctx, claims, headers, keyID)
+	require.NoError(t, err)
+	return service, accessToken, openid4vci.CredentialRequest{
+		Format: vc.JSONLDCredentialProofFormat,
+		CredentialDefinition: &openid4vci.CredentialDefinition{
+			Context: issuedVC.Context,
+			Type:    issuedVC.Type,
+		},
+		Proof: &openid4vci.CredentialRequestProof{
+			Jwt:       proof,
+			ProofType: openid4vci.ProofTypeJWT,
+		},
+	}
+}
+
+func newTestDeferredStore(t *testing.T) stoabs.KVStore {
+	store, err := storage.NewTestStorageEngine(t).GetProvider("test").GetKVStore("deferred-issuances", storage.PersistentStorageClass)
+	require.NoError(t, err)
+	return store
+}
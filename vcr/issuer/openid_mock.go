@@ -41,6 +41,34 @@ func (m *MockOpenIDHandler) EXPECT() *MockOpenIDHandlerMockRecorder {
 	return m.recorder
 }
 
+// CompleteDeferredIssuance mocks base method.
+func (m *MockOpenIDHandler) CompleteDeferredIssuance(ctx context.Context, transactionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDeferredIssuance", ctx, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteDeferredIssuance indicates an expected call of CompleteDeferredIssuance.
+func (mr *MockOpenIDHandlerMockRecorder) CompleteDeferredIssuance(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDeferredIssuance", reflect.TypeOf((*MockOpenIDHandler)(nil).CompleteDeferredIssuance), ctx, transactionID)
+}
+
+// DenyDeferredIssuance mocks base method.
+func (m *MockOpenIDHandler) DenyDeferredIssuance(ctx context.Context, transactionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyDeferredIssuance", ctx, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyDeferredIssuance indicates an expected call of DenyDeferredIssuance.
+func (mr *MockOpenIDHandlerMockRecorder) DenyDeferredIssuance(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyDeferredIssuance", reflect.TypeOf((*MockOpenIDHandler)(nil).DenyDeferredIssuance), ctx, transactionID)
+}
+
 // HandleAccessTokenRequest mocks base method.
 func (m *MockOpenIDHandler) HandleAccessTokenRequest(ctx context.Context, preAuthorizedCode string) (string, string, error) {
 	m.ctrl.T.Helper()
@@ -57,11 +85,57 @@ func (mr *MockOpenIDHandlerMockRecorder) HandleAccessTokenRequest(ctx, preAuthor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAccessTokenRequest", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleAccessTokenRequest), ctx, preAuthorizedCode)
 }
 
+// HandleAuthorizationCodeTokenRequest mocks base method.
+func (m *MockOpenIDHandler) HandleAuthorizationCodeTokenRequest(ctx context.Context, code, codeVerifier, redirectURI, clientID string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleAuthorizationCodeTokenRequest", ctx, code, codeVerifier, redirectURI, clientID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// HandleAuthorizationCodeTokenRequest indicates an expected call of HandleAuthorizationCodeTokenRequest.
+func (mr *MockOpenIDHandlerMockRecorder) HandleAuthorizationCodeTokenRequest(ctx, code, codeVerifier, redirectURI, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAuthorizationCodeTokenRequest", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleAuthorizationCodeTokenRequest), ctx, code, codeVerifier, redirectURI, clientID)
+}
+
+// HandleAuthorizeRequest mocks base method.
+func (m *MockOpenIDHandler) HandleAuthorizeRequest(ctx context.Context, request openid4vci.AuthorizationRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleAuthorizeRequest", ctx, request)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleAuthorizeRequest indicates an expected call of HandleAuthorizeRequest.
+func (mr *MockOpenIDHandlerMockRecorder) HandleAuthorizeRequest(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAuthorizeRequest", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleAuthorizeRequest), ctx, request)
+}
+
+// HandleBatchCredentialRequest mocks base method.
+func (m *MockOpenIDHandler) HandleBatchCredentialRequest(ctx context.Context, request openid4vci.BatchCredentialRequest, accessToken string) (*openid4vci.BatchCredentialResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleBatchCredentialRequest", ctx, request, accessToken)
+	ret0, _ := ret[0].(*openid4vci.BatchCredentialResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleBatchCredentialRequest indicates an expected call of HandleBatchCredentialRequest.
+func (mr *MockOpenIDHandlerMockRecorder) HandleBatchCredentialRequest(ctx, request, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBatchCredentialRequest", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleBatchCredentialRequest), ctx, request, accessToken)
+}
+
 // HandleCredentialRequest mocks base method.
-func (m *MockOpenIDHandler) HandleCredentialRequest(ctx context.Context, request openid4vci.CredentialRequest, accessToken string) (*vc.VerifiableCredential, error) {
+func (m *MockOpenIDHandler) HandleCredentialRequest(ctx context.Context, request openid4vci.CredentialRequest, accessToken string) (*openid4vci.CredentialResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HandleCredentialRequest", ctx, request, accessToken)
-	ret0, _ := ret[0].(*vc.VerifiableCredential)
+	ret0, _ := ret[0].(*openid4vci.CredentialResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -72,6 +146,50 @@ func (mr *MockOpenIDHandlerMockRecorder) HandleCredentialRequest(ctx, request, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCredentialRequest", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleCredentialRequest), ctx, request, accessToken)
 }
 
+// HandleDeferredCredentialRequest mocks base method.
+func (m *MockOpenIDHandler) HandleDeferredCredentialRequest(ctx context.Context, request openid4vci.DeferredCredentialRequest, accessToken string) (*openid4vci.CredentialResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDeferredCredentialRequest", ctx, request, accessToken)
+	ret0, _ := ret[0].(*openid4vci.CredentialResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleDeferredCredentialRequest indicates an expected call of HandleDeferredCredentialRequest.
+func (mr *MockOpenIDHandlerMockRecorder) HandleDeferredCredentialRequest(ctx, request, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeferredCredentialRequest", reflect.TypeOf((*MockOpenIDHandler)(nil).HandleDeferredCredentialRequest), ctx, request, accessToken)
+}
+
+// IsDeferred mocks base method.
+func (m *MockOpenIDHandler) IsDeferred(credential vc.VerifiableCredential) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDeferred", credential)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDeferred indicates an expected call of IsDeferred.
+func (mr *MockOpenIDHandlerMockRecorder) IsDeferred(credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDeferred", reflect.TypeOf((*MockOpenIDHandler)(nil).IsDeferred), credential)
+}
+
+// ListDeferredIssuances mocks base method.
+func (m *MockOpenIDHandler) ListDeferredIssuances(ctx context.Context) ([]DeferredIssuance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeferredIssuances", ctx)
+	ret0, _ := ret[0].([]DeferredIssuance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeferredIssuances indicates an expected call of ListDeferredIssuances.
+func (mr *MockOpenIDHandlerMockRecorder) ListDeferredIssuances(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeferredIssuances", reflect.TypeOf((*MockOpenIDHandler)(nil).ListDeferredIssuances), ctx)
+}
+
 // Metadata mocks base method.
 func (m *MockOpenIDHandler) Metadata() openid4vci.CredentialIssuerMetadata {
 	m.ctrl.T.Helper()
@@ -28,6 +28,9 @@ import (
 type OpenIDStore interface {
 	// Store saves a new Flow in the store.
 	Store(ctx context.Context, flow Flow) error
+	// Update replaces an existing Flow in the store, e.g. to record the parameters of an authorization request.
+	// If the flow does not exist, it returns an error.
+	Update(ctx context.Context, flow Flow) error
 	// StoreReference saves a reference to the given Flow, for looking it up later.
 	// This is used for finding a flow given a secret, e.g. pre-authorized code, authorization code or nonce.
 	// like a database index. The reference must be unique for all flows.
@@ -66,6 +69,14 @@ func (o *openidMemoryStore) Store(_ context.Context, flow Flow) error {
 	return store.Put(flow.ID, flow)
 }
 
+func (o *openidMemoryStore) Update(_ context.Context, flow Flow) error {
+	store := o.sessionDatabase.GetStore(TokenTTL, "openid4vci", "flow")
+	if !store.Exists(flow.ID) {
+		return errors.New("OAuth2 flow with this ID does not exist")
+	}
+	return store.Put(flow.ID, flow)
+}
+
 func (o *openidMemoryStore) StoreReference(_ context.Context, flowID string, refType string, reference string) error {
 	if len(reference) == 0 {
 		return errors.New("invalid reference")
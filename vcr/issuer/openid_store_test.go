@@ -22,6 +22,7 @@ import (
 	"context"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -119,6 +120,33 @@ func Test_memoryStore_Store(t *testing.T) {
 	})
 }
 
+func Test_memoryStore_Update(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ok", func(t *testing.T) {
+		store := createStore(t)
+		flow := Flow{
+			ID: "flow-id",
+		}
+		require.NoError(t, store.Store(ctx, flow))
+		require.NoError(t, store.StoreReference(ctx, flow.ID, refType, ref))
+		flow.WalletID = "wallet"
+
+		err := store.Update(ctx, flow)
+
+		assert.NoError(t, err)
+		actual, err := store.FindByReference(ctx, refType, ref)
+		assert.NoError(t, err)
+		assert.Equal(t, "wallet", actual.WalletID)
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		store := createStore(t)
+
+		err := store.Update(ctx, Flow{ID: "flow-id"})
+
+		assert.EqualError(t, err, "OAuth2 flow with this ID does not exist")
+	})
+}
+
 func createStore(t *testing.T) *openidMemoryStore {
 	storageDatabase := storage.NewTestInMemorySessionDatabase(t)
 	store := NewOpenIDMemoryStore(storageDatabase).(*openidMemoryStore)
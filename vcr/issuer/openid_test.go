@@ -26,6 +26,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/storage"
@@ -35,6 +36,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 )
@@ -47,7 +49,9 @@ var keyID = holderDID.String() + "#1"
 
 const definitionsDIR = "./test/valid"
 
+var issuedVCID = ssi.MustParseURI(issuerDID.String() + "#1")
 var issuedVC = vc.VerifiableCredential{
+	ID:     &issuedVCID,
 	Issuer: issuerDID.URI(),
 	CredentialSubject: []interface{}{
 		map[string]interface{}{
@@ -66,21 +70,21 @@ var issuedVC = vc.VerifiableCredential{
 
 func TestNew(t *testing.T) {
 	t.Run("custom definitions", func(t *testing.T) {
-		iss, err := NewOpenIDHandler(issuerDID, issuerIdentifier, "./test/valid", nil, nil, storage.NewTestInMemorySessionDatabase(t))
+		iss, err := NewOpenIDHandler(issuerDID, issuerIdentifier, "./test/valid", nil, nil, nil, nil, storage.NewTestInMemorySessionDatabase(t), nil, nil)
 
 		require.NoError(t, err)
 		assert.Len(t, iss.(*openidHandler).credentialsSupported, 3)
 	})
 
 	t.Run("error - invalid json", func(t *testing.T) {
-		_, err := NewOpenIDHandler(issuerDID, issuerIdentifier, "./test/invalid", nil, nil, storage.NewTestInMemorySessionDatabase(t))
+		_, err := NewOpenIDHandler(issuerDID, issuerIdentifier, "./test/invalid", nil, nil, nil, nil, storage.NewTestInMemorySessionDatabase(t), nil, nil)
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "failed to parse credential definition from test/invalid/invalid.json: unexpected end of JSON input")
 	})
 
 	t.Run("error - invalid directory", func(t *testing.T) {
-		_, err := NewOpenIDHandler(issuerDID, issuerIdentifier, "./test/non_existing", nil, nil, storage.NewTestInMemorySessionDatabase(t))
+		_, err := NewOpenIDHandler(issuerDID, issuerIdentifier, "./test/non_existing", nil, nil, nil, nil, storage.NewTestInMemorySessionDatabase(t), nil, nil)
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "failed to load credential definitions: lstat ./test/non_existing: no such file or directory")
@@ -95,6 +99,8 @@ func Test_memoryIssuer_Metadata(t *testing.T) {
 
 		assert.Equal(t, "https://example.com/did:nuts:issuer", metadata.CredentialIssuer)
 		assert.Equal(t, "https://example.com/did:nuts:issuer/openid4vci/credential", metadata.CredentialEndpoint)
+		assert.Equal(t, "https://example.com/did:nuts:issuer/openid4vci/batch_credential", metadata.BatchCredentialEndpoint)
+		assert.Equal(t, "https://example.com/did:nuts:issuer/openid4vci/deferred_credential", metadata.DeferredCredentialEndpoint)
 		require.Len(t, metadata.CredentialsSupported, 3)
 		assert.Equal(t, "ldp_vc", metadata.CredentialsSupported[0]["format"])
 		require.Len(t, metadata.CredentialsSupported[0]["cryptographic_binding_methods_supported"], 1)
@@ -110,8 +116,12 @@ func Test_memoryIssuer_ProviderMetadata(t *testing.T) {
 	metadata := requireNewTestHandler(t, nil).ProviderMetadata()
 
 	assert.Equal(t, openid4vci.ProviderMetadata{
-		Issuer:        "https://example.com/did:nuts:issuer",
-		TokenEndpoint: "https://example.com/did:nuts:issuer/token",
+		Issuer:                        "https://example.com/did:nuts:issuer",
+		AuthorizationEndpoint:         "https://example.com/did:nuts:issuer/authorize",
+		TokenEndpoint:                 "https://example.com/did:nuts:issuer/token",
+		GrantTypesSupported:           []string{"urn:ietf:params:oauth:grant-type:pre-authorized_code", "authorization_code"},
+		CodeChallengeMethodsSupported: []string{"S256"},
+		RequireSignedRequestObject:    true,
 		PreAuthorizedGrantAnonymousAccessSupported: true,
 	}, metadata)
 }
@@ -119,7 +129,7 @@ func Test_memoryIssuer_ProviderMetadata(t *testing.T) {
 func Test_memoryIssuer_HandleCredentialRequest(t *testing.T) {
 	keyStore := crypto.NewMemoryCryptoInstance()
 	ctx := audit.TestContext()
-	signerKey, _ := keyStore.New(ctx, func(key crypt.PublicKey) (string, error) {
+	signerKey, _ := keyStore.New(ctx, crypto.ECP256Key, func(key crypt.PublicKey) (string, error) {
 		return keyID, nil
 	})
 	ctrl := gomock.NewController(t)
@@ -176,7 +186,10 @@ func Test_memoryIssuer_HandleCredentialRequest(t *testing.T) {
 
 		require.NoError(t, err)
 		require.NotNil(t, response)
-		assert.Equal(t, issuerDID.URI(), response.Issuer)
+		assert.Equal(t, vc.JSONLDCredentialProofFormat, response.Format)
+		require.NotNil(t, response.Credential)
+		assert.Equal(t, issuerDID.String(), (*response.Credential)["issuer"])
+		assert.Nil(t, response.TransactionID)
 		auditLogs.AssertContains(t, "VCR", "VerifiableCredentialRetrievedEvent", audit.TestActor, "VC retrieved by wallet over OpenID4VCI")
 	})
 	t.Run("unsupported format", func(t *testing.T) {
@@ -398,12 +411,12 @@ func Test_memoryIssuer_HandleAccessTokenRequest(t *testing.T) {
 	})
 	t.Run("pre-authorized code issued by other issuer", func(t *testing.T) {
 		store := storage.NewTestInMemorySessionDatabase(t)
-		service, err := NewOpenIDHandler(issuerDID, issuerIdentifier, definitionsDIR, &http.Client{}, nil, store)
+		service, err := NewOpenIDHandler(issuerDID, issuerIdentifier, definitionsDIR, &http.Client{}, nil, nil, nil, store, nil, nil)
 		require.NoError(t, err)
 		_, err = service.(*openidHandler).createOffer(ctx, issuedVC, "code")
 		require.NoError(t, err)
 
-		otherService, err := NewOpenIDHandler(did.MustParseDID("did:nuts:other"), "http://example.com/other", definitionsDIR, &http.Client{}, nil, store)
+		otherService, err := NewOpenIDHandler(did.MustParseDID("did:nuts:other"), "http://example.com/other", definitionsDIR, &http.Client{}, nil, nil, nil, store, nil, nil)
 		require.NoError(t, err)
 		accessToken, _, err := otherService.HandleAccessTokenRequest(audit.TestContext(), "code")
 
@@ -426,6 +439,329 @@ func Test_memoryIssuer_HandleAccessTokenRequest(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, protocolError.StatusCode)
 		assert.Empty(t, accessToken)
 	})
+	t.Run("pre-authorized code and issuer_state can't be used again", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		_, _, err = service.HandleAccessTokenRequest(audit.TestContext(), "code")
+		require.NoError(t, err)
+
+		_, _, err = service.HandleAccessTokenRequest(audit.TestContext(), "code")
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown pre-authorized code")
+		authorizationRequest := signRequest(authorizationRequestParams(offer, oauth.GeneratePKCEParams()))
+		_, err = service.HandleAuthorizeRequest(audit.TestContext(), authorizationRequest)
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown issuer_state")
+	})
+}
+
+func Test_memoryIssuer_HandleAuthorizeRequest(t *testing.T) {
+	ctx := audit.TestContext()
+	pkceParams := oauth.GeneratePKCEParams()
+	t.Run("ok", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "pre-authorized-code")
+		require.NoError(t, err)
+
+		redirectURL, err := service.HandleAuthorizeRequest(ctx, signRequest(authorizationRequestParams(offer, pkceParams)))
+
+		require.NoError(t, err)
+		parsedURL, err := url.Parse(redirectURL)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/did:nuts:holder/openid4vci/callback", parsedURL.Scheme+"://"+parsedURL.Host+parsedURL.Path)
+		assert.Equal(t, "wallet-state", parsedURL.Query().Get("state"))
+		require.NotEmpty(t, parsedURL.Query().Get("code"))
+		t.Run("issuer_state and pre-authorized code can't be used again", func(t *testing.T) {
+			_, err := service.HandleAuthorizeRequest(ctx, signRequest(authorizationRequestParams(offer, pkceParams)))
+			assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown issuer_state")
+			_, _, err = service.HandleAccessTokenRequest(ctx, "pre-authorized-code")
+			assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown pre-authorized code")
+		})
+	})
+	t.Run("ok - wallet-initiated", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		credentialStore := NewMockStore(gomock.NewController(t))
+		service.credentialStore = credentialStore
+		issuedCredential := func(id string, issuanceDate time.Time) vc.VerifiableCredential {
+			credentialID := ssi.MustParseURI(id)
+			result := issuedVC
+			result.ID = &credentialID
+			result.IssuanceDate = &issuanceDate
+			return result
+		}
+		older := issuedCredential("did:nuts:issuer#older", time.Now().Add(-2*time.Hour))
+		latest := issuedCredential("did:nuts:issuer#latest", time.Now().Add(-time.Hour))
+		revoked := issuedCredential("did:nuts:issuer#revoked", time.Now())
+		suspended := issuedCredential("did:nuts:issuer#suspended", time.Now().Add(time.Minute))
+		unknownStatus := issuedCredential("did:nuts:issuer#unknown", time.Now().Add(2*time.Minute))
+		credentialStore.EXPECT().SearchCredential(ssi.MustParseURI("HumanCredential"), issuerDID, &ssi.URI{URL: holderDID.URI().URL}).
+			Return([]vc.VerifiableCredential{older, latest, revoked, suspended, unknownStatus}, nil)
+		revoker := NewMockIssuer(gomock.NewController(t))
+		service.credentialIssuer = revoker
+		revoker.EXPECT().RevocationStatus(ctx, older).Return(false, false, nil)
+		revoker.EXPECT().RevocationStatus(ctx, latest).Return(false, false, nil)
+		revoker.EXPECT().RevocationStatus(ctx, revoked).Return(true, false, nil)
+		revoker.EXPECT().RevocationStatus(ctx, suspended).Return(false, true, nil)
+		revoker.EXPECT().RevocationStatus(ctx, unknownStatus).Return(false, false, assert.AnError)
+
+		redirectURL, err := service.HandleAuthorizeRequest(ctx, signRequest(authorizationRequestParams(nil, pkceParams)))
+
+		require.NoError(t, err)
+		parsedURL, err := url.Parse(redirectURL)
+		require.NoError(t, err)
+		code := parsedURL.Query().Get("code")
+		require.NotEmpty(t, code)
+		flow, err := service.store.FindByReference(ctx, authCodeRefType, code)
+		require.NoError(t, err)
+		require.NotNil(t, flow)
+		assert.Equal(t, holderDID.String(), flow.WalletID)
+		require.Len(t, flow.Credentials, 1)
+		assert.Equal(t, latest.ID.String(), flow.Credentials[0].ID.String())
+	})
+	t.Run("error - wallet-initiated, no credential issued to the wallet", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		credentialStore := NewMockStore(gomock.NewController(t))
+		service.credentialStore = credentialStore
+		credentialStore.EXPECT().SearchCredential(gomock.Any(), issuerDID, gomock.Any()).Return(nil, nil)
+
+		_, err := service.HandleAuthorizeRequest(ctx, signRequest(authorizationRequestParams(nil, pkceParams)))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "access_denied - no credential matching authorization_details has been issued to the wallet")
+	})
+	t.Run("error - missing issuer_state and authorization_details", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		params := authorizationRequestParams(nil, pkceParams)
+		delete(params, "authorization_details")
+
+		_, err := service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - missing issuer_state or authorization_details")
+	})
+	t.Run("error - unsupported authorization_details", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		params := authorizationRequestParams(nil, pkceParams)
+		params["authorization_details"] = []map[string]interface{}{{"type": "other"}}
+
+		_, err := service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - unsupported authorization_details: only 'openid_credential' with format 'ldp_vc' and a credential_definition is supported")
+	})
+	t.Run("error - not a signed request object", func(t *testing.T) {
+		service, _ := newTestAuthorizeHandler(t)
+
+		_, err := service.HandleAuthorizeRequest(ctx, openid4vci.AuthorizationRequest{ClientID: holderDID.String()})
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - authorization request must be a signed request object")
+	})
+	t.Run("error - client_id is not the signer of the request object", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		params := authorizationRequestParams(offer, pkceParams)
+		params["client_id"] = "did:nuts:other"
+		request := signRequest(params)
+		request.ClientID = "did:nuts:other"
+
+		_, err = service.HandleAuthorizeRequest(ctx, request)
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - invalid request object: invalid_request - client_id does not match signer of authorization request")
+	})
+	t.Run("error - request object intended for other issuer", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		params := authorizationRequestParams(offer, pkceParams)
+		params["aud"] = "https://example.com/other"
+
+		_, err = service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - request object audience does not match issuer")
+	})
+	t.Run("error - unsupported response_type", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		params := authorizationRequestParams(offer, pkceParams)
+		params["response_type"] = "token"
+
+		_, err = service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - unsupported response_type: token")
+	})
+	t.Run("error - invalid redirect_uri", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		params := authorizationRequestParams(offer, pkceParams)
+		params["redirect_uri"] = "/callback"
+
+		_, err = service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - invalid redirect_uri")
+	})
+	t.Run("error - missing PKCE code challenge", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		params := authorizationRequestParams(offer, pkceParams)
+		delete(params, "code_challenge")
+
+		_, err = service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - code_challenge is required and code_challenge_method must be S256")
+	})
+	t.Run("error - plain PKCE code challenge method", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		params := authorizationRequestParams(offer, pkceParams)
+		params["code_challenge_method"] = "plain"
+
+		_, err = service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_request - code_challenge is required and code_challenge_method must be S256")
+	})
+	t.Run("error - unknown issuer_state", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		offer, err := service.createOffer(ctx, issuedVC, "code")
+		require.NoError(t, err)
+		params := authorizationRequestParams(offer, pkceParams)
+		params["issuer_state"] = "unknown"
+
+		_, err = service.HandleAuthorizeRequest(ctx, signRequest(params))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown issuer_state")
+	})
+	t.Run("error - client_id is not the wallet the credential was offered to", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		otherVC := issuedVC
+		otherVC.CredentialSubject = []interface{}{map[string]interface{}{"id": "did:nuts:other"}}
+		offer, err := service.createOffer(ctx, otherVC, "code")
+		require.NoError(t, err)
+
+		_, err = service.HandleAuthorizeRequest(ctx, signRequest(authorizationRequestParams(offer, pkceParams)))
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_client - client_id does not match the wallet the credential was offered to")
+	})
+}
+
+func Test_memoryIssuer_HandleAuthorizationCodeTokenRequest(t *testing.T) {
+	ctx := audit.TestContext()
+	const redirectURI = "https://example.com/did:nuts:holder/openid4vci/callback"
+	authorize := func(t *testing.T, service *openidHandler, signRequest func(map[string]interface{}) openid4vci.AuthorizationRequest, pkceParams oauth.PKCEParams) string {
+		offer, err := service.createOffer(ctx, issuedVC, "pre-authorized-code")
+		require.NoError(t, err)
+		redirectURL, err := service.HandleAuthorizeRequest(ctx, signRequest(authorizationRequestParams(offer, pkceParams)))
+		require.NoError(t, err)
+		parsedURL, _ := url.Parse(redirectURL)
+		return parsedURL.Query().Get("code")
+	}
+	t.Run("ok", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		pkceParams := oauth.GeneratePKCEParams()
+		code := authorize(t, service, signRequest, pkceParams)
+
+		accessToken, cNonce, err := service.HandleAuthorizationCodeTokenRequest(ctx, code, pkceParams.Verifier, redirectURI, holderDID.String())
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, cNonce)
+		flow, err := service.store.FindByReference(ctx, accessTokenRefType, accessToken)
+		require.NoError(t, err)
+		require.NotNil(t, flow)
+		t.Run("code can't be used again", func(t *testing.T) {
+			_, _, err := service.HandleAuthorizationCodeTokenRequest(ctx, code, pkceParams.Verifier, redirectURI, holderDID.String())
+
+			assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown authorization code")
+		})
+	})
+	t.Run("error - unknown code", func(t *testing.T) {
+		service := requireNewTestHandler(t, nil)
+		pkceParams := oauth.GeneratePKCEParams()
+
+		_, _, err := service.HandleAuthorizationCodeTokenRequest(ctx, "unknown", pkceParams.Verifier, redirectURI, holderDID.String())
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown authorization code")
+	})
+	t.Run("error - code verifier does not match", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		code := authorize(t, service, signRequest, oauth.GeneratePKCEParams())
+
+		_, _, err := service.HandleAuthorizationCodeTokenRequest(ctx, code, oauth.GeneratePKCEParams().Verifier, redirectURI, holderDID.String())
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - code_verifier does not match code_challenge")
+		t.Run("code can't be used again", func(t *testing.T) {
+			_, _, err := service.HandleAuthorizationCodeTokenRequest(ctx, code, oauth.GeneratePKCEParams().Verifier, redirectURI, holderDID.String())
+
+			assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - unknown authorization code")
+		})
+	})
+	t.Run("error - redirect_uri does not match", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		pkceParams := oauth.GeneratePKCEParams()
+		code := authorize(t, service, signRequest, pkceParams)
+
+		_, _, err := service.HandleAuthorizationCodeTokenRequest(ctx, code, pkceParams.Verifier, "https://example.com/other", holderDID.String())
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_grant - redirect_uri does not match authorization request")
+	})
+	t.Run("error - client_id does not match", func(t *testing.T) {
+		service, signRequest := newTestAuthorizeHandler(t)
+		pkceParams := oauth.GeneratePKCEParams()
+		code := authorize(t, service, signRequest, pkceParams)
+
+		_, _, err := service.HandleAuthorizationCodeTokenRequest(ctx, code, pkceParams.Verifier, redirectURI, "did:nuts:other")
+
+		assertProtocolError(t, err, http.StatusBadRequest, "invalid_client - client_id does not match authorization request")
+	})
+}
+
+// newTestAuthorizeHandler creates an openidHandler that resolves the holder's key,
+// and a function that creates authorization requests with a request object signed by the holder.
+func newTestAuthorizeHandler(t *testing.T) (*openidHandler, func(params map[string]interface{}) openid4vci.AuthorizationRequest) {
+	ctx := audit.TestContext()
+	keyStore := crypto.NewMemoryCryptoInstance()
+	signerKey, _ := keyStore.New(ctx, crypto.ECP256Key, func(key crypt.PublicKey) (string, error) {
+		return keyID, nil
+	})
+	keyResolver := resolver.NewMockKeyResolver(gomock.NewController(t))
+	keyResolver.EXPECT().ResolveKeyByID(keyID, nil, resolver.AssertionMethod).AnyTimes().Return(signerKey.Public(), nil)
+	signRequest := func(params map[string]interface{}) openid4vci.AuthorizationRequest {
+		request, err := keyStore.SignJWT(ctx, params, nil, keyID)
+		require.NoError(t, err)
+		return openid4vci.AuthorizationRequest{
+			ClientID: holderDID.String(),
+			Request:  request,
+		}
+	}
+	return requireNewTestHandler(t, keyResolver), signRequest
+}
+
+// authorizationRequestParams returns the parameters of a valid authorization request for the given offer.
+// If offer is nil, the request is wallet-initiated, requesting the credential using authorization_details.
+func authorizationRequestParams(offer *openid4vci.CredentialOffer, pkceParams oauth.PKCEParams) map[string]interface{} {
+	params := map[string]interface{}{
+		"iss":                   holderDID.String(),
+		"aud":                   issuerIdentifier,
+		"client_id":             holderDID.String(),
+		"response_type":         "code",
+		"redirect_uri":          "https://example.com/did:nuts:holder/openid4vci/callback",
+		"state":                 "wallet-state",
+		"code_challenge":        pkceParams.Challenge,
+		"code_challenge_method": pkceParams.ChallengeMethod,
+	}
+	if offer != nil {
+		params["issuer_state"] = offer.Grants[openid4vci.AuthorizationCodeGrant].(map[string]interface{})["issuer_state"]
+	} else {
+		params["authorization_details"] = []openid4vci.AuthorizationDetails{{
+			Type:   openid4vci.AuthorizationDetailsTypeOpenIDCredential,
+			Format: vc.JSONLDCredentialProofFormat,
+			CredentialDefinition: &openid4vci.CredentialDefinition{
+				Context: issuedVC.Context,
+				Type:    issuedVC.Type,
+			},
+		}}
+	}
+	return params
 }
 
 func assertProtocolError(t *testing.T, err error, statusCode int, message string) {
@@ -436,7 +772,7 @@ func assertProtocolError(t *testing.T, err error, statusCode int, message string
 }
 
 func requireNewTestHandler(t *testing.T, keyResolver resolver.KeyResolver) *openidHandler {
-	service, err := NewOpenIDHandler(issuerDID, issuerIdentifier, definitionsDIR, &http.Client{}, keyResolver, storage.NewTestInMemorySessionDatabase(t))
+	service, err := NewOpenIDHandler(issuerDID, issuerIdentifier, definitionsDIR, &http.Client{}, keyResolver, nil, nil, storage.NewTestInMemorySessionDatabase(t), newTestDeferredStore(t), nil)
 	require.NoError(t, err)
 	return service.(*openidHandler)
 }
@@ -34,6 +34,9 @@ const (
 	// - the Authorization Server expects a PIN in the pre-authorized flow but the client provides the wrong PIN
 	// - the End-User provides the wrong Pre-Authorized Code or the Pre-Authorized Code has expired
 	InvalidGrant ErrorCode = "invalid_grant"
+	// AccessDenied is returned by the Authorization Endpoint when the wallet requests a credential it isn't authorized for,
+	// e.g. a credential that hasn't been issued to it.
+	AccessDenied ErrorCode = "access_denied"
 	// InvalidToken is returned when (in addition to cases defined by OAuth2):
 	// - Credential Request contains the wrong Access Token or the Access Token is missing
 	InvalidToken ErrorCode = "invalid_token"
@@ -48,6 +51,12 @@ const (
 	// InvalidProof is returned when the Credential Request did not contain a proof,
 	// or proof was invalid, i.e. it was not bound to a Credential Issuer provided nonce
 	InvalidProof ErrorCode = "invalid_proof"
+	// IssuancePending is returned by the Deferred Credential Endpoint when the credential is not yet available.
+	// The client should retry the request later.
+	IssuancePending ErrorCode = "issuance_pending"
+	// InvalidTransactionID is returned by the Deferred Credential Endpoint when the transaction ID is unknown,
+	// the credential was already retrieved or its issuance was denied.
+	InvalidTransactionID ErrorCode = "invalid_transaction_id"
 )
 
 // Error is an error that signals the error was (probably) caused by the client (e.g. bad request),
@@ -42,7 +42,26 @@ type IssuerAPIClient interface {
 	// Metadata returns the Credential Issuer Metadata.
 	Metadata() CredentialIssuerMetadata
 	// RequestCredential requests a credential from the issuer.
+	// If the issuer deferred issuance of the credential, a DeferredIssuanceError is returned containing the transaction ID.
 	RequestCredential(ctx context.Context, request CredentialRequest, accessToken string) (*vc.VerifiableCredential, error)
+	// RequestBatchCredential requests multiple credentials from the issuer at once, using its Batch Credential Endpoint.
+	// The credential responses are returned in the order of the given requests.
+	RequestBatchCredential(ctx context.Context, requests []CredentialRequest, accessToken string) (*BatchCredentialResponse, error)
+	// RequestDeferredCredential retrieves a credential of which issuance was deferred, using the issuer's Deferred Credential Endpoint.
+	// If the credential is not yet available, an Error with code IssuancePending is returned.
+	RequestDeferredCredential(ctx context.Context, transactionID string, accessToken string) (*vc.VerifiableCredential, error)
+}
+
+// DeferredIssuanceError is returned when the issuer deferred issuance of a requested credential.
+// The credential can later be retrieved from the issuer's Deferred Credential Endpoint using the transaction ID.
+type DeferredIssuanceError struct {
+	// TransactionID identifies the deferred issuance at the issuer.
+	TransactionID string
+}
+
+// Error returns the error message.
+func (e DeferredIssuanceError) Error() string {
+	return "credential issuance deferred (transaction_id=" + e.TransactionID + ")"
 }
 
 // NewIssuerAPIClient resolves the Credential Issuer Metadata from the well-known endpoint
@@ -102,6 +121,51 @@ func (h defaultIssuerAPIClient) RequestCredential(ctx context.Context, request C
 	if err != nil {
 		return nil, fmt.Errorf("get credential request failed: %w", err)
 	}
+	if credentialResponse.Credential == nil && credentialResponse.TransactionID != nil {
+		return nil, DeferredIssuanceError{TransactionID: *credentialResponse.TransactionID}
+	}
+	return parseCredentialResponse(credentialResponse)
+}
+
+func (h defaultIssuerAPIClient) RequestBatchCredential(ctx context.Context, requests []CredentialRequest, accessToken string) (*BatchCredentialResponse, error) {
+	if h.metadata.BatchCredentialEndpoint == "" {
+		return nil, errors.New("issuer does not support batch credential requests")
+	}
+	requestBody, _ := json.Marshal(BatchCredentialRequest{CredentialRequests: requests})
+
+	var batchResponse BatchCredentialResponse
+	httpRequest, _ := http.NewRequestWithContext(ctx, "POST", h.metadata.BatchCredentialEndpoint, bytes.NewReader(requestBody))
+	httpRequest.Header.Add("Authorization", "Bearer "+accessToken)
+	httpRequest.Header.Add("Content-Type", "application/json")
+	err := httpDo(h.httpClient, httpRequest, &batchResponse)
+	if err != nil {
+		return nil, fmt.Errorf("batch credential request failed: %w", err)
+	}
+	if len(batchResponse.CredentialResponses) != len(requests) {
+		return nil, fmt.Errorf("batch credential response contains %d responses, expected %d", len(batchResponse.CredentialResponses), len(requests))
+	}
+	return &batchResponse, nil
+}
+
+func (h defaultIssuerAPIClient) RequestDeferredCredential(ctx context.Context, transactionID string, accessToken string) (*vc.VerifiableCredential, error) {
+	if h.metadata.DeferredCredentialEndpoint == "" {
+		return nil, errors.New("issuer does not support deferred credential requests")
+	}
+	requestBody, _ := json.Marshal(DeferredCredentialRequest{TransactionID: transactionID})
+
+	var credentialResponse CredentialResponse
+	httpRequest, _ := http.NewRequestWithContext(ctx, "POST", h.metadata.DeferredCredentialEndpoint, bytes.NewReader(requestBody))
+	httpRequest.Header.Add("Authorization", "Bearer "+accessToken)
+	httpRequest.Header.Add("Content-Type", "application/json")
+	err := httpDo(h.httpClient, httpRequest, &credentialResponse)
+	if err != nil {
+		return nil, fmt.Errorf("deferred credential request failed: %w", err)
+	}
+	return parseCredentialResponse(credentialResponse)
+}
+
+// parseCredentialResponse returns the credential contained in the given credential response.
+func parseCredentialResponse(credentialResponse CredentialResponse) (*vc.VerifiableCredential, error) {
 	// TODO: check format
 	//       See https://github.com/nuts-foundation/nuts-node/issues/2037
 	if credentialResponse.Credential == nil {
@@ -109,7 +173,7 @@ func (h defaultIssuerAPIClient) RequestCredential(ctx context.Context, request C
 	}
 	var credential vc.VerifiableCredential
 	credentialJSON, _ := json.Marshal(*credentialResponse.Credential)
-	err = json.Unmarshal(credentialJSON, &credential)
+	err := json.Unmarshal(credentialJSON, &credential)
 	if err != nil {
 		return nil, fmt.Errorf("unable to unmarshal received credential: %w", err)
 	}
@@ -180,6 +244,12 @@ func httpDo(httpClient core.HTTPRequestDoer, httpRequest *http.Request, result i
 			responseBodyStr = responseBodyStr[:100] + "..."
 		}
 		log.Logger().Debugf("HTTP response body: %s", responseBodyStr)
+		// If the server responded with an OpenID4VCI error, return it so the caller can act on it (e.g. issuance_pending)
+		var errorResponse Error
+		if json.Unmarshal(responseBody, &errorResponse) == nil && errorResponse.Code != "" {
+			errorResponse.StatusCode = httpResponse.StatusCode
+			return fmt.Errorf("unexpected http response code (%s): %d: %w", httpRequest.URL, httpResponse.StatusCode, errorResponse)
+		}
 		return fmt.Errorf("unexpected http response code (%s): %d", httpRequest.URL, httpResponse.StatusCode)
 	}
 	if result != nil {
@@ -194,6 +264,9 @@ func httpDo(httpClient core.HTTPRequestDoer, httpRequest *http.Request, result i
 type OAuth2Client interface {
 	// RequestAccessToken requests an access token from the Authorization Server.
 	RequestAccessToken(grantType string, params map[string]string) (*oauth.TokenResponse, error)
+	// RequestAuthorization sends an authorization request to the Authorization Server's authorization endpoint.
+	// The Authorization Server responds by redirecting to the client's redirect_uri, which is followed.
+	RequestAuthorization(ctx context.Context, params map[string]string) error
 }
 
 var _ OAuth2Client = &httpOAuth2Client{}
@@ -218,3 +291,22 @@ func (c httpOAuth2Client) RequestAccessToken(grantType string, params map[string
 	}
 	return &accessTokenResponse, nil
 }
+
+func (c httpOAuth2Client) RequestAuthorization(ctx context.Context, params map[string]string) error {
+	if len(c.metadata.AuthorizationEndpoint) == 0 {
+		return errors.New("authorization server does not support the authorization code flow (no authorization endpoint)")
+	}
+	authorizationURL, err := url.Parse(c.metadata.AuthorizationEndpoint)
+	if err != nil {
+		return fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authorizationURL.Query()
+	for key, value := range params {
+		query.Add(key, value)
+	}
+	authorizationURL.RawQuery = query.Encode()
+	if err = httpGet(ctx, c.httpClient, authorizationURL.String(), nil); err != nil {
+		return fmt.Errorf("authorization request error: %w", err)
+	}
+	return nil
+}
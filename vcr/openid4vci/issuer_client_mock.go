@@ -70,6 +70,35 @@ func (mr *MockIssuerAPIClientMockRecorder) RequestAccessToken(grantType, params
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAccessToken", reflect.TypeOf((*MockIssuerAPIClient)(nil).RequestAccessToken), grantType, params)
 }
 
+// RequestAuthorization mocks base method.
+func (m *MockIssuerAPIClient) RequestAuthorization(ctx context.Context, params map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAuthorization", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestAuthorization indicates an expected call of RequestAuthorization.
+func (mr *MockIssuerAPIClientMockRecorder) RequestAuthorization(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAuthorization", reflect.TypeOf((*MockIssuerAPIClient)(nil).RequestAuthorization), ctx, params)
+}
+
+// RequestBatchCredential mocks base method.
+func (m *MockIssuerAPIClient) RequestBatchCredential(ctx context.Context, requests []CredentialRequest, accessToken string) (*BatchCredentialResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestBatchCredential", ctx, requests, accessToken)
+	ret0, _ := ret[0].(*BatchCredentialResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestBatchCredential indicates an expected call of RequestBatchCredential.
+func (mr *MockIssuerAPIClientMockRecorder) RequestBatchCredential(ctx, requests, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestBatchCredential", reflect.TypeOf((*MockIssuerAPIClient)(nil).RequestBatchCredential), ctx, requests, accessToken)
+}
+
 // RequestCredential mocks base method.
 func (m *MockIssuerAPIClient) RequestCredential(ctx context.Context, request CredentialRequest, accessToken string) (*vc.VerifiableCredential, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +114,21 @@ func (mr *MockIssuerAPIClientMockRecorder) RequestCredential(ctx, request, acces
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCredential", reflect.TypeOf((*MockIssuerAPIClient)(nil).RequestCredential), ctx, request, accessToken)
 }
 
+// RequestDeferredCredential mocks base method.
+func (m *MockIssuerAPIClient) RequestDeferredCredential(ctx context.Context, transactionID, accessToken string) (*vc.VerifiableCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDeferredCredential", ctx, transactionID, accessToken)
+	ret0, _ := ret[0].(*vc.VerifiableCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDeferredCredential indicates an expected call of RequestDeferredCredential.
+func (mr *MockIssuerAPIClientMockRecorder) RequestDeferredCredential(ctx, transactionID, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDeferredCredential", reflect.TypeOf((*MockIssuerAPIClient)(nil).RequestDeferredCredential), ctx, transactionID, accessToken)
+}
+
 // MockOAuth2Client is a mock of OAuth2Client interface.
 type MockOAuth2Client struct {
 	ctrl     *gomock.Controller
@@ -122,3 +166,17 @@ func (mr *MockOAuth2ClientMockRecorder) RequestAccessToken(grantType, params any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAccessToken", reflect.TypeOf((*MockOAuth2Client)(nil).RequestAccessToken), grantType, params)
 }
+
+// RequestAuthorization mocks base method.
+func (m *MockOAuth2Client) RequestAuthorization(ctx context.Context, params map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAuthorization", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestAuthorization indicates an expected call of RequestAuthorization.
+func (mr *MockOAuth2ClientMockRecorder) RequestAuthorization(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAuthorization", reflect.TypeOf((*MockOAuth2Client)(nil).RequestAuthorization), ctx, params)
+}
@@ -126,6 +126,107 @@ func Test_httpIssuerClient_RequestCredential(t *testing.T) {
 		require.ErrorContains(t, err, "unable to unmarshal received credential: json: cannot unmarshal")
 		require.Nil(t, credential)
 	})
+	t.Run("issuance deferred", func(t *testing.T) {
+		setup := setupClientTest(t)
+		transactionID := "tx-id"
+		setup.credentialHandler = setup.httpPostHandler(CredentialResponse{TransactionID: &transactionID})
+		client, err := NewIssuerAPIClient(ctx, httpClient, setup.issuerMetadata.CredentialIssuer)
+		require.NoError(t, err)
+
+		credential, err := client.RequestCredential(ctx, credentialRequest, "token")
+
+		var deferredErr DeferredIssuanceError
+		require.ErrorAs(t, err, &deferredErr)
+		assert.Equal(t, "tx-id", deferredErr.TransactionID)
+		require.Nil(t, credential)
+	})
+}
+
+func Test_httpIssuerClient_RequestBatchCredential(t *testing.T) {
+	ctx := context.Background()
+	httpClient := &http.Client{}
+	credentialRequest := CredentialRequest{
+		CredentialDefinition: &CredentialDefinition{},
+		Format:               vc.JSONLDCredentialProofFormat,
+	}
+	t.Run("ok", func(t *testing.T) {
+		setup := setupClientTest(t)
+		client, err := NewIssuerAPIClient(ctx, httpClient, setup.issuerMetadata.CredentialIssuer)
+		require.NoError(t, err)
+
+		response, err := client.RequestBatchCredential(ctx, []CredentialRequest{credentialRequest}, "token")
+
+		require.NoError(t, err)
+		require.Len(t, response.CredentialResponses, 1)
+		assert.NotNil(t, response.CredentialResponses[0].Credential)
+		assert.Equal(t, "Bearer token", setup.requests[2].Header.Get("Authorization"))
+	})
+	t.Run("error - number of responses differs from number of requests", func(t *testing.T) {
+		setup := setupClientTest(t)
+		client, err := NewIssuerAPIClient(ctx, httpClient, setup.issuerMetadata.CredentialIssuer)
+		require.NoError(t, err)
+
+		response, err := client.RequestBatchCredential(ctx, []CredentialRequest{credentialRequest, credentialRequest}, "token")
+
+		require.EqualError(t, err, "batch credential response contains 1 responses, expected 2")
+		require.Nil(t, response)
+	})
+	t.Run("error - issuer does not support batch credential requests", func(t *testing.T) {
+		setup := setupClientTest(t)
+		setup.issuerMetadata.BatchCredentialEndpoint = ""
+		client, err := NewIssuerAPIClient(ctx, httpClient, setup.issuerMetadata.CredentialIssuer)
+		require.NoError(t, err)
+
+		response, err := client.RequestBatchCredential(ctx, []CredentialRequest{credentialRequest}, "token")
+
+		require.EqualError(t, err, "issuer does not support batch credential requests")
+		require.Nil(t, response)
+	})
+}
+
+func Test_httpIssuerClient_RequestDeferredCredential(t *testing.T) {
+	ctx := context.Background()
+	httpClient := &http.Client{}
+	t.Run("ok", func(t *testing.T) {
+		setup := setupClientTest(t)
+		client, err := NewIssuerAPIClient(ctx, httpClient, setup.issuerMetadata.CredentialIssuer)
+		require.NoError(t, err)
+
+		credential, err := client.RequestDeferredCredential(ctx, "tx-id", "token")
+
+		require.NoError(t, err)
+		require.NotNil(t, credential)
+		assert.Equal(t, "Bearer token", setup.requests[2].Header.Get("Authorization"))
+	})
+	t.Run("issuance pending", func(t *testing.T) {
+		setup := setupClientTest(t)
+		setup.deferredCredentialHandler = func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte(`{"error":"issuance_pending"}`))
+		}
+		client, err := NewIssuerAPIClient(ctx, httpClient, setup.issuerMetadata.CredentialIssuer)
+		require.NoError(t, err)
+
+		credential, err := client.RequestDeferredCredential(ctx, "tx-id", "token")
+
+		var protocolErr Error
+		require.ErrorAs(t, err, &protocolErr)
+		assert.Equal(t, IssuancePending, protocolErr.Code)
+		assert.Equal(t, http.StatusBadRequest, protocolErr.StatusCode)
+		require.Nil(t, credential)
+	})
+	t.Run("error - issuer does not support deferred credential requests", func(t *testing.T) {
+		setup := setupClientTest(t)
+		setup.issuerMetadata.DeferredCredentialEndpoint = ""
+		client, err := NewIssuerAPIClient(ctx, httpClient, setup.issuerMetadata.CredentialIssuer)
+		require.NoError(t, err)
+
+		credential, err := client.RequestDeferredCredential(ctx, "tx-id", "token")
+
+		require.EqualError(t, err, "issuer does not support deferred credential requests")
+		require.Nil(t, credential)
+	})
 }
 
 func Test_httpOAuth2Client_RequestAccessToken(t *testing.T) {
@@ -158,3 +259,46 @@ func Test_httpOAuth2Client_RequestAccessToken(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+func Test_httpOAuth2Client_RequestAuthorization(t *testing.T) {
+	httpClient := &http.Client{}
+	t.Run("ok", func(t *testing.T) {
+		setup := setupClientTest(t)
+		params := map[string]string{"redirect_uri": setup.callbackURL, "state": "state"}
+
+		err := (&httpOAuth2Client{
+			metadata:   *setup.providerMetadata,
+			httpClient: httpClient,
+		}).RequestAuthorization(context.Background(), params)
+
+		assert.NoError(t, err)
+		require.Len(t, setup.requests, 2)
+		assert.Equal(t, "/issuer/authorize", setup.requests[0].URL.Path)
+		assert.Equal(t, setup.callbackURL, setup.requests[0].URL.Query().Get("redirect_uri"))
+		// the redirect to the client's redirect_uri is followed
+		assert.Equal(t, "/wallet/callback", setup.requests[1].URL.Path)
+		assert.Equal(t, "code", setup.requests[1].URL.Query().Get("code"))
+		assert.Equal(t, "state", setup.requests[1].URL.Query().Get("state"))
+	})
+	t.Run("error - redirect_uri returns error", func(t *testing.T) {
+		setup := setupClientTest(t)
+		setup.callbackHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		params := map[string]string{"redirect_uri": setup.callbackURL, "state": "state"}
+
+		err := (&httpOAuth2Client{
+			metadata:   *setup.providerMetadata,
+			httpClient: httpClient,
+		}).RequestAuthorization(context.Background(), params)
+
+		require.ErrorContains(t, err, "authorization request error")
+	})
+	t.Run("error - no authorization endpoint", func(t *testing.T) {
+		err := (&httpOAuth2Client{
+			httpClient: httpClient,
+		}).RequestAuthorization(context.Background(), nil)
+
+		require.EqualError(t, err, "authorization server does not support the authorization code flow (no authorization endpoint)")
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/test"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 )
@@ -53,9 +54,18 @@ func setupClientTest(t *testing.T) *oidcClientTestContext {
 	clientTest.issuerMetadataHandler = clientTest.httpGetHandler(issuerMetadata)
 	clientTest.providerMetadataHandler = clientTest.httpGetHandler(providerMetadata)
 	clientTest.credentialHandler = clientTest.httpPostHandler(credentialResponse)
+	clientTest.batchCredentialHandler = clientTest.httpPostHandler(BatchCredentialResponse{CredentialResponses: []CredentialResponse{credentialResponse}})
+	clientTest.deferredCredentialHandler = clientTest.httpPostHandler(credentialResponse)
 	clientTest.tokenHandler = clientTest.httpPostHandler(oauth.TokenResponse{AccessToken: "secret"})
 	clientTest.walletMetadataHandler = clientTest.httpGetHandler(walletMetadata)
 	clientTest.credentialOfferHandler = clientTest.httpGetHandler(CredentialOfferResponse{CredentialOfferStatusReceived})
+	clientTest.authorizationHandler = func(writer http.ResponseWriter, request *http.Request) {
+		clientTest.requests = append(clientTest.requests, *request)
+		redirectURL, _ := url.Parse(request.URL.Query().Get(oauth.RedirectURIParam))
+		redirectURL.RawQuery = url.Values{oauth.CodeParam: []string{"code"}, oauth.StateParam: request.URL.Query()[oauth.StateParam]}.Encode()
+		http.Redirect(writer, request, redirectURL.String(), http.StatusFound)
+	}
+	clientTest.callbackHandler = clientTest.httpGetHandler(CredentialOfferResponse{CredentialOfferStatusReceived})
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/issuer"+CredentialIssuerMetadataWellKnownPath, func(writer http.ResponseWriter, request *http.Request) {
@@ -67,9 +77,21 @@ func setupClientTest(t *testing.T) *oidcClientTestContext {
 	mux.HandleFunc("/issuer/credential", func(writer http.ResponseWriter, request *http.Request) {
 		clientTest.credentialHandler(writer, request)
 	})
+	mux.HandleFunc("/issuer/batch_credential", func(writer http.ResponseWriter, request *http.Request) {
+		clientTest.batchCredentialHandler(writer, request)
+	})
+	mux.HandleFunc("/issuer/deferred_credential", func(writer http.ResponseWriter, request *http.Request) {
+		clientTest.deferredCredentialHandler(writer, request)
+	})
 	mux.HandleFunc("/issuer/token", func(writer http.ResponseWriter, request *http.Request) {
 		clientTest.tokenHandler(writer, request)
 	})
+	mux.HandleFunc("/issuer/authorize", func(writer http.ResponseWriter, request *http.Request) {
+		clientTest.authorizationHandler(writer, request)
+	})
+	mux.HandleFunc("/wallet/callback", func(writer http.ResponseWriter, request *http.Request) {
+		clientTest.callbackHandler(writer, request)
+	})
 	mux.HandleFunc("/wallet/metadata", func(writer http.ResponseWriter, request *http.Request) {
 		clientTest.walletMetadataHandler(writer, request)
 	})
@@ -80,11 +102,15 @@ func setupClientTest(t *testing.T) *oidcClientTestContext {
 
 	clientTest.walletMetadata.CredentialOfferEndpoint = serverURL + "/wallet/credential_offer"
 	clientTest.walletMetadataURL = serverURL + "/wallet/metadata"
+	clientTest.callbackURL = serverURL + "/wallet/callback"
 	issuerIdentifier := serverURL + "/issuer"
 	issuerMetadata.CredentialIssuer = issuerIdentifier
 	issuerMetadata.CredentialEndpoint = issuerIdentifier + "/credential"
+	issuerMetadata.BatchCredentialEndpoint = issuerIdentifier + "/batch_credential"
+	issuerMetadata.DeferredCredentialEndpoint = issuerIdentifier + "/deferred_credential"
 	providerMetadata.Issuer = issuerIdentifier
 	providerMetadata.TokenEndpoint = issuerIdentifier + "/token"
+	providerMetadata.AuthorizationEndpoint = issuerIdentifier + "/authorize"
 	return clientTest
 }
 
@@ -123,13 +149,18 @@ type oidcClientTestContext struct {
 	providerMetadata        *ProviderMetadata
 	walletMetadata          *OAuth2ClientMetadata
 	walletMetadataURL       string
+	callbackURL             string
 	issuerMetadataHandler   http.HandlerFunc
 	providerMetadataHandler http.HandlerFunc
-	credentialHandler       http.HandlerFunc
-	credentialOfferHandler  http.HandlerFunc
-	tokenHandler            http.HandlerFunc
-	walletMetadataHandler   http.HandlerFunc
-	requests                []http.Request
+	credentialHandler         http.HandlerFunc
+	batchCredentialHandler    http.HandlerFunc
+	deferredCredentialHandler http.HandlerFunc
+	credentialOfferHandler    http.HandlerFunc
+	tokenHandler              http.HandlerFunc
+	authorizationHandler      http.HandlerFunc
+	callbackHandler           http.HandlerFunc
+	walletMetadataHandler     http.HandlerFunc
+	requests                  []http.Request
 }
 
 func startHTTPServer(t *testing.T, mux *http.ServeMux) string {
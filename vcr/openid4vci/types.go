@@ -22,6 +22,7 @@ package openid4vci
 
 import (
 	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"time"
 )
 
@@ -29,6 +30,10 @@ import (
 // Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-pre-authorized-code-flow
 const PreAuthorizedCodeGrant = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
 
+// AuthorizationCodeGrant is the grant type used for the authorization code flow from the OpenID4VCI specification.
+// Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-authorization-code-flow
+const AuthorizationCodeGrant = oauth.AuthorizationCodeGrantType
+
 // WalletMetadataWellKnownPath defines the well-known path for OpenID4VCI Wallet Metadata.
 // It is NOT specified by the OpenID4VCI specification, we just use it to be consistent with the other well-known paths.
 const WalletMetadataWellKnownPath = "/.well-known/openid-credential-wallet"
@@ -53,6 +58,10 @@ type CredentialOfferStatus string
 // CredentialOfferStatusReceived indicates that the wallet has received the credential.
 const CredentialOfferStatusReceived CredentialOfferStatus = "credential_received"
 
+// CredentialOfferStatusPending indicates that the wallet has queued the credential offer,
+// awaiting the holder's consent before the credential is retrieved.
+const CredentialOfferStatusPending CredentialOfferStatus = "credential_pending"
+
 // CredentialIssuerMetadata defines the OpenID4VCI Credential Issuer Metadata.
 // Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-credential-issuer-metadata
 type CredentialIssuerMetadata struct {
@@ -62,6 +71,12 @@ type CredentialIssuerMetadata struct {
 	// CredentialEndpoint defines where the wallet can send a request to retrieve a credential.
 	CredentialEndpoint string `json:"credential_endpoint"`
 
+	// BatchCredentialEndpoint defines where the wallet can send a request to retrieve multiple credentials at once.
+	BatchCredentialEndpoint string `json:"batch_credential_endpoint,omitempty"`
+
+	// DeferredCredentialEndpoint defines where the wallet can retrieve a credential of which issuance was deferred.
+	DeferredCredentialEndpoint string `json:"deferred_credential_endpoint,omitempty"`
+
 	// CredentialsSupported defines metadata about which credential types the credential issuer can issue.
 	CredentialsSupported []map[string]interface{} `json:"credentials_supported"`
 }
@@ -79,9 +94,22 @@ type ProviderMetadata struct {
 	// Issuer defines the authorization server's identifier, which is a URL that uses the "https" scheme and has no query or fragment components.
 	Issuer string `json:"issuer"`
 
+	// AuthorizationEndpoint defines the URL of the authorization server's authorization endpoint [RFC6749].
+	// It is only required for the authorization code flow.
+	AuthorizationEndpoint string `json:"authorization_endpoint,omitempty"`
+
 	// TokenEndpoint defines the URL of the authorization server's token endpoint [RFC6749].
 	TokenEndpoint string `json:"token_endpoint"`
 
+	// GrantTypesSupported is a list of the OAuth 2.0 grant type values that this authorization server supports.
+	GrantTypesSupported []string `json:"grant_types_supported,omitempty"`
+
+	// CodeChallengeMethodsSupported is a list of the PKCE code challenge methods supported by this authorization server [RFC7636].
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported,omitempty"`
+
+	// RequireSignedRequestObject indicates whether authorization requests must be sent as signed request object (JAR, RFC9101).
+	RequireSignedRequestObject bool `json:"require_signed_request_object,omitempty"`
+
 	// PreAuthorizedGrantAnonymousAccessSupported indicates whether anonymous access (requests without client_id)
 	// for pre-authorized code grant flows.
 	// See https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-oauth-20-authorization-serv
@@ -99,6 +127,34 @@ type CredentialOffer struct {
 	Grants map[string]interface{} `json:"grants"`
 }
 
+// AuthorizationRequest defines the OAuth2 authorization request the wallet sends to start the authorization code flow.
+// Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-authorization-request
+// The wallet authenticates by sending the request parameters as a request object (JAR, RFC9101),
+// signed with an assertionMethod key of the DID in the client_id.
+type AuthorizationRequest struct {
+	// ClientID identifies the wallet.
+	ClientID string
+	// Request is the signed request object (JWT), which contains the authorization request parameters:
+	// response_type, client_id, redirect_uri, state, code_challenge and code_challenge_method,
+	// and either issuer_state (issuer-initiated flow) or authorization_details (wallet-initiated flow).
+	Request string
+}
+
+// AuthorizationDetailsTypeOpenIDCredential is the type of authorization_details entries that request issuance of a credential.
+const AuthorizationDetailsTypeOpenIDCredential = "openid_credential"
+
+// AuthorizationDetails defines an authorization_details entry of an authorization request [RFC9396],
+// which the wallet uses to request a credential when the flow is not initiated by a credential offer.
+// Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-request-issuance-of-a-certa
+type AuthorizationDetails struct {
+	// Type must be "openid_credential".
+	Type string `json:"type"`
+	// Format specifies the credential format.
+	Format string `json:"format"`
+	// CredentialDefinition contains the 'credential_definition' of the requested credential.
+	CredentialDefinition *CredentialDefinition `json:"credential_definition,omitempty"`
+}
+
 // OfferedCredential defines a single entry in the credentials array of a CredentialOffer. We currently do not support 'JSON string' offers.
 // Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-credential-offer-parameters
 // and https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-vc-secured-using-data-integ
@@ -145,6 +201,29 @@ type CredentialResponse struct {
 	Format     string                  `json:"format,omitempty"`
 	Credential *map[string]interface{} `json:"credential,omitempty"`
 	CNonce     *string                 `json:"c_nonce,omitempty"`
+	// TransactionID is set instead of Credential when the issuer deferred issuance of the credential.
+	// The wallet uses it to retrieve the credential from the Deferred Credential Endpoint.
+	TransactionID *string `json:"transaction_id,omitempty"`
+}
+
+// BatchCredentialRequest defines the request sent by the wallet to the issuer to retrieve multiple credentials at once.
+// Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-batch-credential-request
+type BatchCredentialRequest struct {
+	CredentialRequests []CredentialRequest `json:"credential_requests"`
+}
+
+// BatchCredentialResponse defines the response for batch credential requests.
+// The credential responses are in the same order as the credential requests of the BatchCredentialRequest.
+// Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-batch-credential-response
+type BatchCredentialResponse struct {
+	CredentialResponses []CredentialResponse `json:"credential_responses"`
+	CNonce              *string              `json:"c_nonce,omitempty"`
+}
+
+// DeferredCredentialRequest defines the request sent by the wallet to retrieve a credential of which issuance was deferred.
+// Specified by https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-deferred-credential-request
+type DeferredCredentialRequest struct {
+	TransactionID string `json:"transaction_id"`
 }
 
 // Config holds the config for the OpenID4VCI credential issuer and wallet
@@ -155,4 +234,33 @@ type Config struct {
 	Enabled bool `koanf:"enabled"`
 	// Timeout defines the timeout for HTTP client operations
 	Timeout time.Duration `koanf:"timeout"`
+	// Consent holds the config for the holder's consent on incoming credential offers
+	Consent ConsentConfig `koanf:"consent"`
+	// Deferred holds the config for deferred credential issuance
+	Deferred DeferredConfig `koanf:"deferred"`
+}
+
+// ConsentConfig holds the config for the holder's consent on incoming credential offers.
+type ConsentConfig struct {
+	// Required indicates whether incoming credential offers must be accepted by the holder before the credential is retrieved.
+	// If false, all offered credentials are retrieved immediately.
+	Required bool `koanf:"required"`
+	// AutoAcceptIssuers contains the DIDs of issuers whose credential offers are accepted without consent.
+	AutoAcceptIssuers []string `koanf:"autoacceptissuers"`
+	// AutoAcceptTypes contains the credential types of which offers are accepted without consent.
+	AutoAcceptTypes []string `koanf:"autoaccepttypes"`
+	// Expiry is the time after which unanswered credential offers are discarded.
+	Expiry time.Duration `koanf:"expiry"`
+	// MaxPending is the maximum number of unanswered credential offers kept per holder.
+	// Further offers are refused until pending offers are accepted, rejected or expired. If 0, the number isn't limited.
+	MaxPending int `koanf:"maxpending"`
+}
+
+// DeferredConfig holds the config for deferred credential issuance.
+type DeferredConfig struct {
+	// Types contains the credential types of which issuance is deferred, until it's completed or denied through the issuer API.
+	Types []string `koanf:"types"`
+	// Interval specifies how often the holder tries to retrieve credentials of which issuance was deferred by the issuer.
+	// If 0, deferred credentials are not retrieved.
+	Interval time.Duration `koanf:"interval"`
 }
@@ -74,7 +74,7 @@ func (c *defaultWalletAPIClient) OfferCredential(ctx context.Context, offer Cred
 	if err != nil {
 		return fmt.Errorf("offer credential error: %w", err)
 	}
-	if response.Status != CredentialOfferStatusReceived {
+	if response.Status != CredentialOfferStatusReceived && response.Status != CredentialOfferStatusPending {
 		return fmt.Errorf("offer credential error: unexpected status: %s", response.Status)
 	}
 	return nil
@@ -87,6 +87,19 @@ func Test_httpWalletClient_OfferCredential(t *testing.T) {
 		require.Equal(t, []interface{}{}, credentialOffer["credentials"])
 		require.Equal(t, map[string]interface{}{"grant_type": "pre-authorized_code"}, credentialOffer["grants"])
 	})
+	t.Run("ok - offer pending holder consent", func(t *testing.T) {
+		setup := setupClientTest(t)
+		setup.credentialOfferHandler = setup.httpGetHandler(CredentialOfferResponse{CredentialOfferStatusPending})
+		client, err := NewWalletAPIClient(ctx, httpClient, setup.walletMetadataURL)
+		require.NoError(t, err)
+
+		err = client.OfferCredential(ctx, CredentialOffer{
+			CredentialIssuer: setup.issuerMetadata.CredentialIssuer,
+			Credentials:      []OfferedCredential{},
+		})
+
+		require.NoError(t, err)
+	})
 	t.Run("error - invalid response from wallet", func(t *testing.T) {
 		setup := setupClientTest(t)
 		setup.credentialOfferHandler = setup.httpGetHandler(nil)
@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pe
+
+import (
+	"fmt"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+)
+
+// LimitDisclosure returns the credential with only the claims disclosed that are needed to match the constraints of the given input descriptor,
+// if the input descriptor's limit_disclosure is required or preferred.
+// Only SD-JWT VCs support selective disclosure, other credentials (and SD-JWT VCs if disclosure isn't limited) are returned as-is.
+// Disclosures are removed one at a time, as long as the credential still matches the constraints.
+// If disclosures are removed, the key binding JWT (if any) is removed as well, since it is bound to the disclosures.
+func (presentationDefinition PresentationDefinition) LimitDisclosure(inputDescriptorID string, credential vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+	var inputDescriptor *InputDescriptor
+	for _, curr := range presentationDefinition.InputDescriptors {
+		if curr.Id == inputDescriptorID {
+			inputDescriptor = curr
+			break
+		}
+	}
+	if inputDescriptor == nil {
+		return nil, fmt.Errorf("input descriptor not found: %s", inputDescriptorID)
+	}
+	constraints := inputDescriptor.Constraints
+	if constraints == nil || (constraints.LimitDisclosure != DirectiveRequired && constraints.LimitDisclosure != DirectivePreferred) ||
+		!sdjwt.IsCredential(credential) {
+		return &credential, nil
+	}
+	sdJWT, err := sdjwt.FromCredential(credential)
+	if err != nil {
+		return nil, err
+	}
+	if match, _, err := matchConstraint(constraints, credential); err != nil {
+		return nil, err
+	} else if !match {
+		return nil, fmt.Errorf("credential does not match input descriptor: %s", inputDescriptorID)
+	}
+	result := &credential
+	current := *sdJWT
+	for i := 0; i < len(current.Disclosures); {
+		candidate := current.Without(current.Disclosures[i].Digest())
+		candidateCredential, err := sdjwt.ToCredential(candidate)
+		if err != nil {
+			return nil, err
+		}
+		match, _, err := matchConstraint(constraints, *candidateCredential)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			// disclosure isn't needed, removing it might have removed disclosures before it, so start over
+			current = candidate
+			result = candidateCredential
+			i = 0
+		} else {
+			i++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+	vcrTest "github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresentationDefinition_LimitDisclosure(t *testing.T) {
+	credential := sdJWTCredential(t)
+	definition := func(limitDisclosure string) PresentationDefinition {
+		return PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{
+				{
+					Id: "1",
+					Constraints: &Constraints{
+						LimitDisclosure: limitDisclosure,
+						Fields: []Field{
+							{Path: []string{"$.issuer"}},
+							{Path: []string{"$.credentialSubject.organization.name"}},
+						},
+					},
+				},
+			},
+		}
+	}
+	t.Run("required", func(t *testing.T) {
+		result, err := definition(DirectiveRequired).LimitDisclosure("1", credential)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"id":           "did:example:holder",
+			"organization": map[string]interface{}{"name": "Hospital"},
+		}, result.CredentialSubject[0])
+		sdJWT, err := sdjwt.FromCredential(*result)
+		require.NoError(t, err)
+		assert.Len(t, sdJWT.Disclosures, 2)
+	})
+	t.Run("preferred", func(t *testing.T) {
+		result, err := definition(DirectivePreferred).LimitDisclosure("1", credential)
+
+		require.NoError(t, err)
+		assert.NotContains(t, result.CredentialSubject[0], "phone")
+	})
+	t.Run("not limited", func(t *testing.T) {
+		result, err := definition("").LimitDisclosure("1", credential)
+
+		require.NoError(t, err)
+		assert.Equal(t, credential, *result)
+	})
+	t.Run("not an SD-JWT VC", func(t *testing.T) {
+		jsonldCredential := vcrTest.ValidNutsOrganizationCredential(t)
+
+		result, err := definition(DirectiveRequired).LimitDisclosure("1", jsonldCredential)
+
+		require.NoError(t, err)
+		assert.Equal(t, jsonldCredential, *result)
+	})
+	t.Run("credential does not match", func(t *testing.T) {
+		pd := definition(DirectiveRequired)
+		pd.InputDescriptors[0].Constraints.Fields[1].Path = []string{"$.credentialSubject.organization.country"}
+
+		_, err := pd.LimitDisclosure("1", credential)
+
+		assert.EqualError(t, err, "credential does not match input descriptor: 1")
+	})
+	t.Run("unknown input descriptor", func(t *testing.T) {
+		_, err := definition(DirectiveRequired).LimitDisclosure("2", credential)
+
+		assert.EqualError(t, err, "input descriptor not found: 2")
+	})
+}
+
+// sdJWTCredential issues an SD-JWT VC of type NutsOrganizationCredential for did:example:holder.
+func sdJWTCredential(t *testing.T) vc.VerifiableCredential {
+	return sdJWTCredentialWithSubject(t, map[string]interface{}{
+		"id":    "did:example:holder",
+		"phone": "0123456789",
+		"organization": map[string]interface{}{
+			"name": "Hospital",
+			"city": "Amsterdam",
+		},
+	})
+}
+
+// sdJWTCredentialWithSubject issues an SD-JWT VC of type NutsOrganizationCredential with the given credential subject.
+func sdJWTCredentialWithSubject(t *testing.T, subject map[string]interface{}) vc.VerifiableCredential {
+	keyStore := crypto.NewMemoryCryptoInstance()
+	key, err := keyStore.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc("did:example:issuer#1"))
+	require.NoError(t, err)
+	id := ssi.MustParseURI("did:example:issuer#credential-1")
+	issuanceDate := time.Now()
+	template := vc.VerifiableCredential{
+		Context:           []ssi.URI{vc.VCContextV1URI()},
+		ID:                &id,
+		Type:              []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("NutsOrganizationCredential")},
+		Issuer:            ssi.MustParseURI("did:example:issuer"),
+		IssuanceDate:      &issuanceDate,
+		CredentialSubject: []interface{}{subject},
+	}
+	result, err := sdjwt.IssueCredential(audit.TestContext(), template, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		return keyStore.SignJWT(ctx, claims, headers, key)
+	})
+	require.NoError(t, err)
+	return *result
+}
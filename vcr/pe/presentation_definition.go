@@ -25,11 +25,15 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jws"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
+	"math"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/PaesslerAG/jsonpath"
 	"github.com/dlclark/regexp2"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 )
 
 // ErrUnsupportedFilter is returned when a filter uses unsupported features.
@@ -71,17 +75,23 @@ type PresentationContext struct {
 // - pattern, const and enum only on string fields
 // - number, boolean, array and string JSON schema types
 // - Submission Requirements Feature
+// - is_holder and same_subject constraints, where the holder is assumed to be the (single) subject of the credentials the is_holder constraints apply to
 // ErrUnsupportedFilter is returned when a filter uses unsupported features.
 // Other errors can be returned for faulty JSON paths or regex patterns.
 func (presentationDefinition PresentationDefinition) Match(vcs []vc.VerifiableCredential) ([]vc.VerifiableCredential, []InputDescriptorMappingObject, error) {
+	return presentationDefinition.match(vcs, nil)
+}
+
+// match is like Match, but uses holderOf to determine the holder of a credential for is_holder constraints.
+func (presentationDefinition PresentationDefinition) match(vcs []vc.VerifiableCredential, holderOf holderResolver) ([]vc.VerifiableCredential, []InputDescriptorMappingObject, error) {
 	var selectedVCs []vc.VerifiableCredential
 	var descriptorMaps []InputDescriptorMappingObject
 	var err error
 	if len(presentationDefinition.SubmissionRequirements) > 0 {
-		if descriptorMaps, selectedVCs, err = presentationDefinition.matchSubmissionRequirements(vcs); err != nil {
+		if descriptorMaps, selectedVCs, err = presentationDefinition.matchSubmissionRequirements(vcs, holderOf); err != nil {
 			return nil, nil, err
 		}
-	} else if descriptorMaps, selectedVCs, err = presentationDefinition.matchBasic(vcs); err != nil {
+	} else if descriptorMaps, selectedVCs, err = presentationDefinition.matchBasic(vcs, holderOf); err != nil {
 		return nil, nil, err
 	}
 
@@ -117,35 +127,60 @@ func (presentationDefinition PresentationDefinition) ResolveConstraintsFields(cr
 	return result, nil
 }
 
-func (presentationDefinition PresentationDefinition) matchConstraints(vcs []vc.VerifiableCredential) ([]Candidate, error) {
+// matchConstraints matches the VCs against the constraints of each input descriptor.
+// If the presentation definition contains is_holder or same_subject constraints,
+// the VCs are selected such that these constraints are satisfied (preferred constraints only if possible).
+func (presentationDefinition PresentationDefinition) matchConstraints(vcs []vc.VerifiableCredential, holderOf holderResolver) ([]Candidate, error) {
 	var candidates []Candidate
+	options := make([][]*vc.VerifiableCredential, len(presentationDefinition.InputDescriptors))
 
-	for _, inputDescriptor := range presentationDefinition.InputDescriptors {
+	for i, inputDescriptor := range presentationDefinition.InputDescriptors {
 		// we create an empty Candidate. If a VC matches, it'll be attached to the Candidate.
 		// if no VC matches, the Candidate will have an nil VC which is detected later on for SubmissionRequirement rules.
 		match := Candidate{
 			InputDescriptor: *inputDescriptor,
 		}
-		for _, credential := range vcs {
-			isMatch, err := matchCredential(*inputDescriptor, credential)
+		for j := range vcs {
+			credential := &vcs[j]
+			isMatch, err := matchCredential(*inputDescriptor, *credential)
 			if err != nil {
 				return nil, err
 			}
 			// InputDescriptor formats must be a subset of the PresentationDefinition formats, so it must satisfy both.
-			if isMatch && matchFormat(presentationDefinition.Format, credential) && matchFormat(inputDescriptor.Format, credential) {
-				match.VC = &credential
-				break
+			if isMatch && matchFormat(presentationDefinition.Format, *credential) && matchFormat(inputDescriptor.Format, *credential) {
+				if match.VC == nil {
+					match.VC = credential
+				}
+				options[i] = append(options[i], credential)
 			}
 		}
 		candidates = append(candidates, match)
 	}
 
+	subjectConstraints := presentationDefinition.subjectConstraints()
+	if len(subjectConstraints) == 0 {
+		return candidates, nil
+	}
+	// input descriptors may only be left without VC if submission requirements could allow it
+	allowUnselected := len(presentationDefinition.SubmissionRequirements) > 0
+	selection, ok := selectCredentials(subjectConstraints, options, holderOf, true, allowUnselected)
+	if !ok {
+		selection, ok = selectCredentials(subjectConstraints, options, holderOf, false, allowUnselected)
+	}
+	for i := range candidates {
+		if ok {
+			candidates[i].VC = selection[i]
+		} else {
+			// no combination of VCs satisfies the required constraints
+			candidates[i].VC = nil
+		}
+	}
 	return candidates, nil
 }
 
-func (presentationDefinition PresentationDefinition) matchBasic(vcs []vc.VerifiableCredential) ([]InputDescriptorMappingObject, []vc.VerifiableCredential, error) {
+func (presentationDefinition PresentationDefinition) matchBasic(vcs []vc.VerifiableCredential, holderOf holderResolver) ([]InputDescriptorMappingObject, []vc.VerifiableCredential, error) {
 	// do the constraints check
-	candidates, err := presentationDefinition.matchConstraints(vcs)
+	candidates, err := presentationDefinition.matchConstraints(vcs, holderOf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -161,7 +196,7 @@ func (presentationDefinition PresentationDefinition) matchBasic(vcs []vc.Verifia
 		// create the InputDescriptorMappingObject with the relative path
 		mapping := InputDescriptorMappingObject{
 			Id:     candidate.InputDescriptor.Id,
-			Format: candidate.VC.Format(),
+			Format: credentialFormat(*candidate.VC),
 			Path:   fmt.Sprintf("$.verifiableCredential[%d]", index),
 		}
 		descriptors = append(descriptors, mapping)
@@ -172,9 +207,9 @@ func (presentationDefinition PresentationDefinition) matchBasic(vcs []vc.Verifia
 	return descriptors, matchingCredentials, nil
 }
 
-func (presentationDefinition PresentationDefinition) matchSubmissionRequirements(vcs []vc.VerifiableCredential) ([]InputDescriptorMappingObject, []vc.VerifiableCredential, error) {
+func (presentationDefinition PresentationDefinition) matchSubmissionRequirements(vcs []vc.VerifiableCredential, holderOf holderResolver) ([]InputDescriptorMappingObject, []vc.VerifiableCredential, error) {
 	// first we use the constraint matching algorithm to get the matching credentials
-	candidates, err := presentationDefinition.matchConstraints(vcs)
+	candidates, err := presentationDefinition.matchConstraints(vcs, holderOf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -231,7 +266,7 @@ outer:
 			if candidate.VC != nil && vcEqual(uniqueVC, *candidate.VC) {
 				mapping := InputDescriptorMappingObject{
 					Id:     candidate.InputDescriptor.Id,
-					Format: candidate.VC.Format(),
+					Format: credentialFormat(*candidate.VC),
 					Path:   fmt.Sprintf("$.verifiableCredential[%d]", index),
 				}
 				descriptors = append(descriptors, mapping)
@@ -276,7 +311,7 @@ func matchFormat(format *PresentationDefinitionClaimFormatDesignations, credenti
 	}
 
 	asMap := map[string]map[string][]string(*format)
-	switch credential.Format() {
+	switch credentialFormat(credential) {
 	case vc.JSONLDCredentialProofFormat:
 		if entry := asMap[vc.JSONLDCredentialProofFormat]; entry != nil {
 			if proofTypes := entry["proof_type"]; proofTypes != nil {
@@ -301,10 +336,34 @@ func matchFormat(format *PresentationDefinitionClaimFormatDesignations, credenti
 				}
 			}
 		}
+	case sdjwt.Format:
+		// Get signing algorithm used to sign the issuer-signed JWT
+		sdJWT, err := sdjwt.FromCredential(credential)
+		if err != nil {
+			return false
+		}
+		message, _ := jws.ParseString(sdJWT.IssuerJWT) // can't fail, SD-JWT has been parsed before.
+		signingAlgorithm, _ := message.Signatures()[0].ProtectedHeaders().Get(jws.AlgorithmKey)
+		if entry := asMap[sdjwt.Format]; entry != nil {
+			for _, supportedAlgorithm := range entry["sd-jwt_alg_values"] {
+				if signingAlgorithm == jwa.SignatureAlgorithm(supportedAlgorithm) {
+					return true
+				}
+			}
+		}
 	}
 	return false
 }
 
+// credentialFormat returns the format of the credential as used in presentation definitions and submissions.
+// It differs from vc.VerifiableCredential.Format() for SD-JWT VCs, which are represented as JSON-LD credentials.
+func credentialFormat(credential vc.VerifiableCredential) string {
+	if sdjwt.IsCredential(credential) {
+		return sdjwt.Format
+	}
+	return credential.Format()
+}
+
 func matchProofType(proofType string, credential vc.VerifiableCredential) bool {
 	proofs, _ := credential.Proofs()
 	for _, p := range proofs {
@@ -319,6 +378,10 @@ func matchCredential(descriptor InputDescriptor, credential vc.VerifiableCredent
 	// for each constraint in descriptor.constraints:
 	//   a vc must match the constraint
 	if descriptor.Constraints != nil {
+		// only SD-JWT VCs support selective disclosure
+		if descriptor.Constraints.LimitDisclosure == DirectiveRequired && !sdjwt.IsCredential(credential) {
+			return false, nil
+		}
 		matches, _, err := matchConstraint(descriptor.Constraints, credential)
 		return matches, err
 	}
@@ -327,8 +390,9 @@ func matchCredential(descriptor InputDescriptor, credential vc.VerifiableCredent
 
 // matchConstraint matches the constraint against the VC.
 // All Fields need to match according to the Field rules.
-// IsHolder, SameSubject, SubjectIsIssuer, Statuses are not supported for now.
-// LimitDisclosure is not supported for now.
+// IsHolder and SameSubject are evaluated over all input descriptors (see matchConstraints).
+// SubjectIsIssuer, Statuses are not supported for now.
+// LimitDisclosure is evaluated by matchCredential, the disclosures are limited by LimitDisclosure.
 // If the constraint matches, it returns true and a map containing constraint field IDs and matched values.
 func matchConstraint(constraint *Constraints, credential vc.VerifiableCredential) (bool, map[string]interface{}, error) {
 	// jsonpath works on interfaces, so convert the VC to an interface
@@ -392,6 +456,10 @@ func matchField(field Field, credential map[string]interface{}) (bool, interface
 			return false, nil, err
 		}
 		if match {
+			if field.Predicate != nil {
+				// the verifier only wants to know the result of the filter, not the actual value
+				return true, true, nil
+			}
 			return true, value, nil
 		}
 		// if filter at path does not match continue and set optionalInvalid
@@ -420,9 +488,14 @@ func getValueAtPath(path string, vcAsInterface interface{}) (interface{}, error)
 
 // matchFilter matches the value against the filter.
 // A filter is a JSON Schema descriptor (https://json-schema.org/draft/2020-12/json-schema-validation.html#name-a-vocabulary-for-structural)
-// Supported schema types: string, number, boolean, array, enum.
-// Supported schema properties: const, enum, pattern. These only work for strings.
-// Supported go value types: string, float64, int, bool and array.
+// Supported schema types: string, number, integer, boolean, array, object.
+// Supported schema properties:
+//   - strings: const, enum, pattern, minLength, maxLength, format (date and date-time) with formatMinimum, formatMaximum, formatExclusiveMinimum, formatExclusiveMaximum
+//   - numbers: minimum, maximum, exclusiveMinimum, exclusiveMaximum
+//   - arrays: contains
+//
+// If the filter type isn't array, an array value matches if one of its items matches.
+// Supported go value types: string, float64, int, bool, array and object (map[string]interface{}).
 // 'null' values are not supported.
 // It returns an error on unsupported features or when the regex pattern fails.
 func matchFilter(filter Filter, value interface{}) (bool, error) {
@@ -441,26 +514,27 @@ func matchFilter(filter Filter, value interface{}) (bool, error) {
 		return false, nil
 	}
 
-	switch value.(type) {
+	switch typedValue := value.(type) {
 	case string:
-		if filter.Type != "string" {
+		if !filter.allowsType("string") {
 			return false, nil
 		}
+		return matchStringFilter(filter, typedValue)
 	case float64:
-		if filter.Type != "number" {
-			return false, nil
-		}
+		return matchNumberFilter(filter, typedValue), nil
 	case int:
-		if filter.Type != "number" {
-			return false, nil
-		}
+		return matchNumberFilter(filter, float64(typedValue)), nil
 	case bool:
-		if filter.Type != "boolean" {
+		if !filter.allowsType("boolean") {
 			return false, nil
 		}
+		// const only supports strings
+		return filter.Const == nil, nil
 	case []interface{}:
-		values := value.([]interface{})
-		for _, v := range values {
+		if filter.Type == "array" {
+			return matchArrayFilter(filter, typedValue)
+		}
+		for _, v := range typedValue {
 			match, err := matchFilter(filter, v)
 			if err != nil {
 				return false, err
@@ -469,29 +543,138 @@ func matchFilter(filter Filter, value interface{}) (bool, error) {
 				return true, nil
 			}
 		}
+		return false, nil
+	case map[string]interface{}:
+		return filter.allowsType("object"), nil
 	default:
-		// object not supported for now
 		return false, ErrUnsupportedFilter
 	}
+}
 
-	if filter.Const != nil {
-		if value != *filter.Const {
-			return false, nil
+// allowsType returns true if the filter type equals the given type.
+func (f Filter) allowsType(jsonType string) bool {
+	return f.Type == jsonType
+}
+
+func matchStringFilter(filter Filter, value string) (bool, error) {
+	if filter.Const != nil && value != *filter.Const {
+		return false, nil
+	}
+	length := utf8.RuneCountInString(value)
+	if filter.MinLength != nil && length < *filter.MinLength {
+		return false, nil
+	}
+	if filter.MaxLength != nil && length > *filter.MaxLength {
+		return false, nil
+	}
+	if filter.Format != nil {
+		match, err := matchFormatFilter(filter, value)
+		if err != nil || !match {
+			return false, err
 		}
 	}
-
-	if filter.Pattern != nil && filter.Type == "string" {
+	if filter.Pattern != nil {
 		re, err := regexp2.Compile(*filter.Pattern, regexp2.ECMAScript)
 		if err != nil {
 			return false, err
 		}
-		return re.MatchString(value.(string))
+		return re.MatchString(value)
 	}
+	return true, nil
+}
 
-	// if we get here, no pattern, enum or const is requested just the type.
+// matchFormatFilter checks the value against the date or date-time format and its bounds.
+// Other formats are ignored, unless format bounds are specified.
+func matchFormatFilter(filter Filter, value string) (bool, error) {
+	var layout, boundLayout string
+	switch *filter.Format {
+	case "date":
+		layout = time.DateOnly
+		// bounds are allowed to omit leading zeros (e.g. 1999-5-16), as in the Presentation Exchange examples
+		boundLayout = "2006-1-2"
+	case "date-time":
+		layout = time.RFC3339
+		boundLayout = time.RFC3339
+	default:
+		if filter.FormatMinimum != nil || filter.FormatMaximum != nil || filter.FormatExclusiveMinimum != nil || filter.FormatExclusiveMaximum != nil {
+			return false, ErrUnsupportedFilter
+		}
+		return true, nil
+	}
+	actual, err := time.Parse(layout, value)
+	if err != nil {
+		// not formatted according to the format, so no match
+		return false, nil
+	}
+	bounds := []struct {
+		bound *string
+		valid func(cmp int) bool
+	}{
+		{filter.FormatMinimum, func(cmp int) bool { return cmp >= 0 }},
+		{filter.FormatMaximum, func(cmp int) bool { return cmp <= 0 }},
+		{filter.FormatExclusiveMinimum, func(cmp int) bool { return cmp > 0 }},
+		{filter.FormatExclusiveMaximum, func(cmp int) bool { return cmp < 0 }},
+	}
+	for _, curr := range bounds {
+		if curr.bound == nil {
+			continue
+		}
+		bound, err := time.Parse(boundLayout, *curr.bound)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s bound in filter (%s): %w", *filter.Format, *curr.bound, err)
+		}
+		if !curr.valid(actual.Compare(bound)) {
+			return false, nil
+		}
+	}
 	return true, nil
 }
 
+func matchNumberFilter(filter Filter, value float64) bool {
+	switch filter.Type {
+	case "number":
+	case "integer":
+		if value != math.Trunc(value) {
+			return false
+		}
+	default:
+		return false
+	}
+	// const only supports strings
+	if filter.Const != nil {
+		return false
+	}
+	if filter.Minimum != nil && value < *filter.Minimum {
+		return false
+	}
+	if filter.Maximum != nil && value > *filter.Maximum {
+		return false
+	}
+	if filter.ExclusiveMinimum != nil && value <= *filter.ExclusiveMinimum {
+		return false
+	}
+	if filter.ExclusiveMaximum != nil && value >= *filter.ExclusiveMaximum {
+		return false
+	}
+	return true
+}
+
+func matchArrayFilter(filter Filter, values []interface{}) (bool, error) {
+	if filter.Contains == nil {
+		return true, nil
+	}
+	for _, v := range values {
+		match, err := matchFilter(*filter.Contains, v)
+		if err != nil {
+			return false, err
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // deduplicate removes duplicate VCs from the slice.
 // It uses JSON marshalling to determine if two VCs are equal.
 func deduplicate(vcs []vc.VerifiableCredential) []vc.VerifiableCredential {
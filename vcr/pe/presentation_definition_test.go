@@ -45,7 +45,7 @@ var testCredentialString = `
   }
 }`
 
-//go:embed test/*.json
+//go:embed test/*.json test/dif/*.json
 var testFiles embed.FS
 
 type testDefinitions struct {
@@ -441,6 +441,32 @@ txJy6M1-lD7a5HTzanYTWBPAUHDZGyGKXdJw-W_x0IWChBzI8t3kpG253fg6V3tPgHeKXE94fz_QpYfg
 		})
 	})
 
+	t.Run("SD-JWT", func(t *testing.T) {
+		verifiableCredential := sdJWTCredential(t)
+
+		t.Run("alg match", func(t *testing.T) {
+			asMap := map[string]map[string][]string{"vc+sd-jwt": {"sd-jwt_alg_values": {"ES256"}}}
+			asFormat := PresentationDefinitionClaimFormatDesignations(asMap)
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.True(t, match)
+		})
+		t.Run("no alg match", func(t *testing.T) {
+			asMap := map[string]map[string][]string{"vc+sd-jwt": {"sd-jwt_alg_values": {"ES384"}}}
+			asFormat := PresentationDefinitionClaimFormatDesignations(asMap)
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.False(t, match)
+		})
+		t.Run("does not match ldp_vc", func(t *testing.T) {
+			asMap := map[string]map[string][]string{"ldp_vc": {"proof_type": {"SDJWTProof"}}}
+			asFormat := PresentationDefinitionClaimFormatDesignations(asMap)
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.False(t, match)
+		})
+	})
+
 }
 
 func Test_matchCredential(t *testing.T) {
@@ -450,6 +476,21 @@ func Test_matchCredential(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, match)
 	})
+	t.Run("limit_disclosure required", func(t *testing.T) {
+		descriptor := InputDescriptor{Constraints: &Constraints{LimitDisclosure: DirectiveRequired}}
+		t.Run("SD-JWT VC", func(t *testing.T) {
+			match, err := matchCredential(descriptor, sdJWTCredential(t))
+
+			require.NoError(t, err)
+			assert.True(t, match)
+		})
+		t.Run("JSON-LD VC does not support selective disclosure", func(t *testing.T) {
+			match, err := matchCredential(descriptor, vcrTest.ValidNutsOrganizationCredential(t))
+
+			require.NoError(t, err)
+			assert.False(t, match)
+		})
+	})
 }
 
 func Test_matchConstraint(t *testing.T) {
@@ -559,6 +600,15 @@ func Test_matchField(t *testing.T) {
 		assert.Equal(t, stringVal, value)
 		assert.True(t, match)
 	})
+	t.Run("valid match with predicate resolves to filter result", func(t *testing.T) {
+		stringVal := "value"
+		predicate := PredicateRequired
+		match, value, err := matchField(Field{Path: []string{"$.credentialSubject.field"}, Filter: &Filter{Type: "string", Const: &stringVal}, Predicate: &predicate}, testCredentialMap)
+
+		require.NoError(t, err)
+		assert.Equal(t, true, value)
+		assert.True(t, match)
+	})
 	t.Run("match on type", func(t *testing.T) {
 		stringVal := "VerifiableCredential"
 		match, value, err := matchField(Field{Path: []string{"$.type"}, Filter: &Filter{Type: "string", Const: &stringVal}}, testCredentialMap)
@@ -683,6 +733,150 @@ func Test_matchFilter(t *testing.T) {
 	})
 }
 
+func Test_matchFilter_keywords(t *testing.T) {
+	float := func(f float64) *float64 { return &f }
+	integer := func(i int) *int { return &i }
+	str := func(s string) *string { return &s }
+	type testCaseDef struct {
+		name   string
+		filter Filter
+		value  interface{}
+		want   bool
+	}
+	testCases := []testCaseDef{
+		{name: "no type", filter: Filter{}, value: "foo", want: false},
+		{name: "no type with number", filter: Filter{}, value: 1.0, want: false},
+		{name: "object", filter: Filter{Type: "object"}, value: map[string]interface{}{}, want: true},
+		{name: "object with string", filter: Filter{Type: "string"}, value: map[string]interface{}{}, want: false},
+		{name: "integer", filter: Filter{Type: "integer"}, value: 2.0, want: true},
+		{name: "integer with fraction", filter: Filter{Type: "integer"}, value: 2.5, want: false},
+		{name: "minimum", filter: Filter{Type: "number", Minimum: float(2)}, value: 2, want: true},
+		{name: "minimum not met", filter: Filter{Type: "number", Minimum: float(2)}, value: 1.9, want: false},
+		{name: "maximum", filter: Filter{Type: "number", Maximum: float(2)}, value: 2.0, want: true},
+		{name: "maximum exceeded", filter: Filter{Type: "number", Maximum: float(2)}, value: 2.1, want: false},
+		{name: "exclusiveMinimum", filter: Filter{Type: "number", ExclusiveMinimum: float(2)}, value: 2.0, want: false},
+		{name: "exclusiveMaximum", filter: Filter{Type: "number", ExclusiveMaximum: float(2)}, value: 1.0, want: true},
+		{name: "exclusiveMaximum exceeded", filter: Filter{Type: "number", ExclusiveMaximum: float(2)}, value: 2.0, want: false},
+		{name: "minimum on array", filter: Filter{Type: "number", Minimum: float(2)}, value: []interface{}{1.0, 3.0}, want: true},
+		{name: "minimum ignored for strings", filter: Filter{Type: "string", Minimum: float(2)}, value: "1", want: true},
+		{name: "minLength", filter: Filter{Type: "string", MinLength: integer(3)}, value: "abc", want: true},
+		{name: "maxLength counts characters", filter: Filter{Type: "string", MaxLength: integer(1)}, value: "é", want: true},
+		{name: "minLength not met", filter: Filter{Type: "string", MinLength: integer(3)}, value: "ab", want: false},
+		{name: "maxLength exceeded", filter: Filter{Type: "string", MaxLength: integer(3)}, value: "abcd", want: false},
+		{name: "date", filter: Filter{Type: "string", Format: str("date")}, value: "2020-01-01", want: true},
+		{name: "invalid date", filter: Filter{Type: "string", Format: str("date")}, value: "2020-01-01T00:00:00Z", want: false},
+		{name: "formatMinimum date", filter: Filter{Type: "string", Format: str("date"), FormatMinimum: str("2020-01-01")}, value: "2020-01-01", want: true},
+		{name: "formatMaximum date without leading zeros", filter: Filter{Type: "string", Format: str("date"), FormatMaximum: str("1999-5-16")}, value: "1999-05-16", want: true},
+		{name: "formatExclusiveMinimum date", filter: Filter{Type: "string", Format: str("date"), FormatExclusiveMinimum: str("2020-01-01")}, value: "2020-01-01", want: false},
+		{name: "formatMaximum date-time", filter: Filter{Type: "string", Format: str("date-time"), FormatMaximum: str("2020-01-01T00:00:00Z")}, value: "2020-01-01T01:00:00+02:00", want: true},
+		{name: "formatExclusiveMaximum date-time", filter: Filter{Type: "string", Format: str("date-time"), FormatExclusiveMaximum: str("2020-01-01T00:00:00Z")}, value: "2020-01-01T00:00:00Z", want: false},
+		{name: "unknown format is ignored", filter: Filter{Type: "string", Format: str("email")}, value: "foo", want: true},
+		{name: "contains", filter: Filter{Type: "array", Contains: &Filter{Type: "string", Const: str("b")}}, value: []interface{}{"a", "b"}, want: true},
+		{name: "contains no match", filter: Filter{Type: "array", Contains: &Filter{Type: "string", Const: str("c")}}, value: []interface{}{"a", "b"}, want: false},
+		{name: "array without contains", filter: Filter{Type: "array"}, value: []interface{}{}, want: true},
+		{name: "array with non-array", filter: Filter{Type: "array"}, value: "a", want: false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := matchFilter(testCase.filter, testCase.value)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+	t.Run("error - format bound with unsupported format", func(t *testing.T) {
+		match, err := matchFilter(Filter{Type: "string", Format: str("email"), FormatMinimum: str("a")}, "b")
+		assert.False(t, match)
+		assert.Equal(t, ErrUnsupportedFilter, err)
+	})
+	t.Run("error - invalid format bound", func(t *testing.T) {
+		match, err := matchFilter(Filter{Type: "string", Format: str("date"), FormatMinimum: str("yesterday")}, "2020-01-01")
+		assert.False(t, match)
+		assert.ErrorContains(t, err, "invalid date bound in filter (yesterday)")
+	})
+}
+
+// TestPresentationDefinition_Match_DIF tests the presentation definitions of the DIF Presentation Exchange examples (see test/dif).
+func TestPresentationDefinition_Match_DIF(t *testing.T) {
+	// jsonCredential returns a credential with a single credential subject, which is marshalled as object (not as array).
+	jsonCredential := func(subject map[string]interface{}) vc.VerifiableCredential {
+		data, _ := json.Marshal(map[string]interface{}{"type": "VerifiableCredential", "credentialSubject": subject})
+		var result vc.VerifiableCredential
+		_ = json.Unmarshal(data, &result)
+		return result
+	}
+	type testCaseDef struct {
+		name       string
+		file       string
+		credential vc.VerifiableCredential
+		match      bool
+	}
+	testCases := []testCaseDef{
+		{
+			name:       "filter - date of birth before formatMaximum",
+			file:       "pd_filter.json",
+			credential: jsonCredential(map[string]interface{}{"dob": "1990-07-04"}),
+			match:      true,
+		},
+		{
+			name:       "filter - date of birth on formatMaximum",
+			file:       "pd_filter.json",
+			credential: jsonCredential(map[string]interface{}{"dateOfBirth": "1999-05-16"}),
+			match:      true,
+		},
+		{
+			name:       "filter - date of birth after formatMaximum",
+			file:       "pd_filter.json",
+			credential: jsonCredential(map[string]interface{}{"dob": "2001-01-01"}),
+			match:      false,
+		},
+		{
+			name:       "filter - date of birth not a date",
+			file:       "pd_filter.json",
+			credential: jsonCredential(map[string]interface{}{"dob": 1990}),
+			match:      false,
+		},
+		{
+			name:       "predicate - date of birth before formatMaximum",
+			file:       "predicate_example.json",
+			credential: sdJWTCredentialWithSubject(t, map[string]interface{}{"id": "did:example:holder", "dob": "1990-07-04"}),
+			match:      true,
+		},
+		{
+			name:       "predicate - date of birth after formatMaximum",
+			file:       "predicate_example.json",
+			credential: sdJWTCredentialWithSubject(t, map[string]interface{}{"id": "did:example:holder", "dob": "2001-01-01"}),
+			match:      false,
+		},
+		{
+			name:       "predicate - selective disclosure required",
+			file:       "predicate_example.json",
+			credential: jsonCredential(map[string]interface{}{"dob": "1990-07-04"}),
+			match:      false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := testFiles.ReadFile("test/dif/" + testCase.file)
+			require.NoError(t, err)
+			var envelope struct {
+				PresentationDefinition json.RawMessage `json:"presentation_definition"`
+			}
+			require.NoError(t, json.Unmarshal(data, &envelope))
+			definition, err := ParsePresentationDefinition(envelope.PresentationDefinition)
+			require.NoError(t, err)
+
+			credentials, _, err := definition.Match([]vc.VerifiableCredential{testCase.credential})
+
+			require.NoError(t, err)
+			if testCase.match {
+				assert.Len(t, credentials, 1)
+			} else {
+				assert.Empty(t, credentials)
+			}
+		})
+	}
+}
+
 func TestPresentationDefinition_ResolveConstraintsFields(t *testing.T) {
 	subjectDID := did.MustParseDID("did:web:example.com")
 	jwtCredential := vcrTest.JWTNutsOrganizationCredential(t, subjectDID)
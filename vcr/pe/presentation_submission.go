@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"strings"
 )
 
@@ -112,7 +113,7 @@ func (b *PresentationSubmissionBuilder) Build(format string) (PresentationSubmis
 		allVCs = append(allVCs, vcs...)
 	}
 
-	selectedVCs, inputDescriptorMappingObjects, err := b.presentationDefinition.Match(allVCs)
+	selectedVCs, inputDescriptorMappingObjects, err := b.presentationDefinition.match(allVCs, b.holderOf)
 	if err != nil {
 		return presentationSubmission, nil, err
 	}
@@ -130,8 +131,8 @@ func (b *PresentationSubmissionBuilder) Build(format string) (PresentationSubmis
 					signInstructions[i].VerifiableCredentials = append(signInstructions[i].VerifiableCredentials, selectedVCs[j])
 					// remap the path to the correct wallet index
 					mapping := inputDescriptorMappingObjects[j]
-					mapping.Format = selectedVCs[j].Format()
-					mapping.Path = fmt.Sprintf("$.verifiableCredential[%d]", walletCredentialIndex[b.holders[i]])
+					mapping.Format = credentialFormat(selectedVCs[j])
+					mapping.Path = credentialPath(fmt.Sprintf("$.verifiableCredential[%d]", walletCredentialIndex[b.holders[i]]), mapping.Format)
 					signInstructions[i].Mappings = append(signInstructions[i].Mappings, mapping)
 					walletCredentialIndex[b.holders[i]]++
 				}
@@ -153,7 +154,7 @@ func (b *PresentationSubmissionBuilder) Build(format string) (PresentationSubmis
 	// todo the check below actually depends on the format of the credential and not the format of the VP
 	for _, signInstruction := range nonEmptySignInstructions {
 		if len(signInstruction.Mappings) == 1 {
-			signInstruction.Mappings[0].Path = "$.verifiableCredential"
+			signInstruction.Mappings[0].Path = credentialPath("$.verifiableCredential", signInstruction.Mappings[0].Format)
 		}
 	}
 
@@ -184,6 +185,27 @@ func (b *PresentationSubmissionBuilder) Build(format string) (PresentationSubmis
 	return presentationSubmission, nonEmptySignInstructions, nil
 }
 
+// credentialPath returns the path of a credential with the given format, given the path of the credential in the presentation.
+// SD-JWT VCs are mapped to their compact serialization, which is contained by the credential's proof.
+func credentialPath(path string, format string) string {
+	if format == sdjwt.Format {
+		return path + sdjwt.ProofPath
+	}
+	return path
+}
+
+// holderOf returns the holder of the wallet that contains the given credential, or nil if no wallet contains it.
+func (b *PresentationSubmissionBuilder) holderOf(credential vc.VerifiableCredential) *did.DID {
+	for i, walletVCs := range b.wallets {
+		for _, walletVC := range walletVCs {
+			if walletVC.Raw() == credential.Raw() {
+				return &b.holders[i]
+			}
+		}
+	}
+	return nil
+}
+
 // Resolve returns a map where each of the input descriptors is mapped to the corresponding VerifiableCredential.
 // If an input descriptor can't be mapped to a VC, an error is returned.
 // This function is specified by https://identity.foundation/presentation-exchange/#processing-of-submission-entries
@@ -211,7 +233,7 @@ func resolveCredential(path []string, mapping InputDescriptorMappingObject, valu
 	var decodedTargetValue interface{}
 	switch targetValue := targetValueRaw.(type) {
 	case string:
-		// must be JWT VC or VP
+		// must be JWT VC or VP, or SD-JWT VC
 		if mapping.Format == vc.JWTCredentialProofFormat {
 			decodedTargetValue, err = vc.ParseVerifiableCredential(targetValue)
 			if err != nil {
@@ -222,6 +244,11 @@ func resolveCredential(path []string, mapping InputDescriptorMappingObject, valu
 			if err != nil {
 				return nil, fmt.Errorf("invalid JWT presentation at path '%s': %w", fullPathString, err)
 			}
+		} else if mapping.Format == sdjwt.Format {
+			decodedTargetValue, err = sdjwt.ParseCredential(targetValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SD-JWT credential at path '%s': %w", fullPathString, err)
+			}
 		}
 	case map[string]interface{}:
 		// must be JSON-LD
@@ -266,13 +293,13 @@ func (s PresentationSubmission) Validate(envelope Envelope, definition Presentat
 	}
 
 	// Create a new presentation submission: the submission being validated should have the same input descriptor mapping.
-	submissionBuilder := definition.PresentationSubmissionBuilder()
-	for _, presentation := range envelope.Presentations {
-		signer, err := credential.PresentationSigner(presentation)
-		if err != nil {
-			return nil, fmt.Errorf("unable to derive presentation signer: %w", err)
-		}
-		submissionBuilder.AddWallet(*signer, presentation.VerifiableCredential)
+	submissionBuilder, err := definition.envelopeSubmissionBuilder(envelope)
+	if err != nil {
+		return nil, err
+	}
+	// Check is_holder and same_subject constraints on the submitted credentials, so a violation yields a clear error.
+	if err := definition.validateSubjectConstraints(actualCredentials, submissionBuilder.holderOf); err != nil {
+		return nil, err
 	}
 	_, signInstructions, err := submissionBuilder.Build("")
 	if err != nil {
@@ -292,9 +319,66 @@ func (s PresentationSubmission) Validate(envelope Envelope, definition Presentat
 		return nil, fmt.Errorf("expected %d credentials, got %d", len(expectedCredentials), len(actualCredentials))
 	}
 	for inputDescriptorID, expectedCredential := range expectedCredentials {
-		if actualCredentials[inputDescriptorID].Raw() != expectedCredential.Raw() {
+		if !sameCredential(actualCredentials[inputDescriptorID], expectedCredential) {
 			return nil, fmt.Errorf("incorrect mapping for input descriptor: %s", inputDescriptorID)
 		}
 	}
 	return expectedCredentials, nil
 }
+
+// IsFulfilledBy returns true if the credentials in the presentations of the envelope fulfill the Presentation Definition,
+// regardless of the Presentation Submission. It can be used to evaluate a Presentation Definition that is part of a larger one.
+// An error is returned if the Presentation Definition can't be evaluated, e.g. because of an invalid regex pattern.
+func (presentationDefinition PresentationDefinition) IsFulfilledBy(envelope Envelope) (bool, error) {
+	submissionBuilder, err := presentationDefinition.envelopeSubmissionBuilder(envelope)
+	if err != nil {
+		return false, err
+	}
+	_, signInstructions, err := submissionBuilder.Build("")
+	if err != nil {
+		return false, err
+	}
+	return len(signInstructions) > 0, nil
+}
+
+// envelopeSubmissionBuilder returns a PresentationSubmissionBuilder with a wallet for the signer of each presentation in the envelope.
+func (presentationDefinition PresentationDefinition) envelopeSubmissionBuilder(envelope Envelope) (*PresentationSubmissionBuilder, error) {
+	submissionBuilder := presentationDefinition.PresentationSubmissionBuilder()
+	for _, presentation := range envelope.Presentations {
+		signer, err := credential.PresentationSigner(presentation)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive presentation signer: %w", err)
+		}
+		submissionBuilder.AddWallet(*signer, presentation.VerifiableCredential)
+	}
+	return &submissionBuilder, nil
+}
+
+// sameCredential returns true if both credentials are the same. SD-JWT VCs are compared by their compact serialization,
+// since the credential resolved from a presentation submission is parsed from it.
+func sameCredential(a vc.VerifiableCredential, b vc.VerifiableCredential) bool {
+	if sdjwt.IsCredential(a) && sdjwt.IsCredential(b) {
+		sdJWTA, errA := sdjwt.FromCredential(a)
+		sdJWTB, errB := sdjwt.FromCredential(b)
+		return errA == nil && errB == nil && sdJWTA.String() == sdJWTB.String()
+	}
+	return a.Raw() == b.Raw()
+}
+
+// validateSubjectConstraints checks whether the credentials (mapped by input descriptor ID) satisfy the required is_holder and same_subject constraints.
+func (presentationDefinition PresentationDefinition) validateSubjectConstraints(credentials map[string]vc.VerifiableCredential, holderOf holderResolver) error {
+	constraints := presentationDefinition.subjectConstraints()
+	if len(constraints) == 0 {
+		return nil
+	}
+	selection := make([]*vc.VerifiableCredential, len(presentationDefinition.InputDescriptors))
+	for i, inputDescriptor := range presentationDefinition.InputDescriptors {
+		if current, ok := credentials[inputDescriptor.Id]; ok {
+			selection[i] = &current
+		}
+	}
+	if err := checkSubjectConstraints(constraints, selection, holderOf, false); err != nil {
+		return fmt.Errorf("presentation submission violates presentation definition: %w", err)
+	}
+	return nil
+}
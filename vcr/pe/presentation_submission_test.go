@@ -24,6 +24,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/pe/test"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -219,6 +220,18 @@ func TestPresentationSubmissionBuilder_Build(t *testing.T) {
 			assert.Equal(t, "$.verifiableCredential", submission.DescriptorMap[0].Path)
 		})
 	})
+	t.Run("SD-JWT VC", func(t *testing.T) {
+		presentationDefinition := PresentationDefinition{InputDescriptors: []*InputDescriptor{{Id: "1"}}}
+		builder := presentationDefinition.PresentationSubmissionBuilder()
+		builder.AddWallet(holder1, []vc.VerifiableCredential{sdJWTCredential(t)})
+
+		submission, _, err := builder.Build("ldp_vp")
+
+		require.NoError(t, err)
+		require.Len(t, submission.DescriptorMap, 1)
+		assert.Equal(t, "vc+sd-jwt", submission.DescriptorMap[0].Format)
+		assert.Equal(t, "$.verifiableCredential.proof.sdJwt", submission.DescriptorMap[0].Path)
+	})
 }
 
 func TestPresentationSubmission_Resolve(t *testing.T) {
@@ -409,6 +422,56 @@ func TestPresentationSubmission_Resolve(t *testing.T) {
 		require.ErrorContains(t, err, "unable to resolve credential for input descriptor '1': invalid JSON-LD presentation at path")
 		assert.Nil(t, credentials)
 	})
+	t.Run("SD-JWT VC", func(t *testing.T) {
+		sdJWTVC := sdJWTCredential(t)
+		const submissionJSON = `
+{
+  "descriptor_map": [
+    {
+      "format": "vc+sd-jwt",
+      "id": "1",
+      "path": "$.verifiableCredential.proof.sdJwt"
+    }
+  ]
+}
+`
+		var submission PresentationSubmission
+		require.NoError(t, json.Unmarshal([]byte(submissionJSON), &submission))
+		t.Run("ok", func(t *testing.T) {
+			vp := vc.VerifiablePresentation{
+				VerifiableCredential: []vc.VerifiableCredential{sdJWTVC},
+			}
+
+			credentials, err := submission.Resolve(toEnvelope(t, vp))
+
+			require.NoError(t, err)
+			assert.Equal(t, sdJWTVC.ID, credentials["1"].ID)
+			assert.True(t, sdjwt.IsCredential(credentials["1"]))
+		})
+		t.Run("not an SD-JWT VC", func(t *testing.T) {
+			vp := vc.VerifiablePresentation{
+				VerifiableCredential: []vc.VerifiableCredential{vc2},
+			}
+			const submissionJSON = `
+{
+  "descriptor_map": [
+    {
+      "format": "vc+sd-jwt",
+      "id": "1",
+      "path": "$.verifiableCredential.issuer"
+    }
+  ]
+}
+`
+			var submission PresentationSubmission
+			require.NoError(t, json.Unmarshal([]byte(submissionJSON), &submission))
+
+			credentials, err := submission.Resolve(toEnvelope(t, vp))
+
+			assert.EqualError(t, err, "unable to resolve credential for input descriptor '1': invalid SD-JWT credential at path '$.verifiableCredential.issuer': invalid SD-JWT: missing disclosure separator")
+			assert.Nil(t, credentials)
+		})
+	})
 	t.Run("path does not resolve to a VP or VC", func(t *testing.T) {
 		vp := vc.VerifiablePresentation{
 			VerifiableCredential: []vc.VerifiableCredential{vc1},
@@ -702,6 +765,49 @@ func TestPresentationSubmission_Validate(t *testing.T) {
 	})
 }
 
+func TestPresentationDefinition_IsFulfilledBy(t *testing.T) {
+	vcID := ssi.MustParseURI("did:example:123#first-vc")
+	vp := vc.VerifiablePresentation{
+		VerifiableCredential: []vc.VerifiableCredential{
+			credentialToJSONLD(vc.VerifiableCredential{ID: &vcID}),
+		},
+		Proof: []interface{}{
+			proof.LDProof{VerificationMethod: vcID},
+		},
+	}
+	definition := func(pattern string) PresentationDefinition {
+		return PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{
+				{
+					Id: "1",
+					Constraints: &Constraints{
+						Fields: []Field{{Path: []string{"$.id"}, Filter: &Filter{Type: "string", Pattern: &pattern}}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("fulfilled", func(t *testing.T) {
+		fulfilled, err := definition("first-vc").IsFulfilledBy(toEnvelope(t, vp))
+
+		require.NoError(t, err)
+		assert.True(t, fulfilled)
+	})
+	t.Run("not fulfilled", func(t *testing.T) {
+		fulfilled, err := definition("second-vc").IsFulfilledBy(toEnvelope(t, vp))
+
+		require.NoError(t, err)
+		assert.False(t, fulfilled)
+	})
+	t.Run("error - invalid pattern", func(t *testing.T) {
+		fulfilled, err := definition("(").IsFulfilledBy(toEnvelope(t, vp))
+
+		assert.Error(t, err)
+		assert.False(t, fulfilled)
+	})
+}
+
 func toEnvelope(t *testing.T, presentations interface{}) Envelope {
 	vpBytes, _ := json.Marshal(presentations)
 	envelope, err := ParseEnvelope(vpBytes)
@@ -0,0 +1,260 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pe
+
+import (
+	"fmt"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+)
+
+const (
+	// DirectiveRequired indicates a constraint must be satisfied.
+	DirectiveRequired = "required"
+	// DirectivePreferred indicates a constraint should be satisfied, if possible.
+	DirectivePreferred = "preferred"
+)
+
+// holderResolver returns the DID of the holder that presents the given credential, or nil if it's unknown.
+type holderResolver func(credential vc.VerifiableCredential) *did.DID
+
+// subjectConstraint is an is_holder or same_subject constraint of an input descriptor,
+// resolved to the input descriptors (by index) it applies to.
+// The subject of a field is derived from the credentialSubject.id of the credential that matched the input descriptor.
+type subjectConstraint struct {
+	// inputDescriptorID is the ID of the input descriptor that specifies the constraint.
+	inputDescriptorID string
+	// inputDescriptors contains the indices of the input descriptors that contain the constraint's fields.
+	inputDescriptors []int
+	isHolder         bool
+	directive        string
+}
+
+func (c subjectConstraint) name() string {
+	if c.isHolder {
+		return "is_holder"
+	}
+	return "same_subject"
+}
+
+// applies returns whether the constraint must be satisfied: required constraints always, preferred constraints only if includePreferred is true.
+func (c subjectConstraint) applies(includePreferred bool) bool {
+	return c.directive == DirectiveRequired || (includePreferred && c.directive == DirectivePreferred)
+}
+
+// subjectConstraints returns the is_holder and same_subject constraints of all input descriptors.
+// is_holder field IDs refer to fields of the same input descriptor,
+// same_subject field IDs may refer to fields of any input descriptor of the presentation definition.
+func (presentationDefinition PresentationDefinition) subjectConstraints() []subjectConstraint {
+	fieldDescriptors := make(map[string][]int)
+	for i, inputDescriptor := range presentationDefinition.InputDescriptors {
+		if inputDescriptor.Constraints == nil {
+			continue
+		}
+		for _, field := range inputDescriptor.Constraints.Fields {
+			if field.Id != nil {
+				fieldDescriptors[*field.Id] = append(fieldDescriptors[*field.Id], i)
+			}
+		}
+	}
+	var result []subjectConstraint
+	for i, inputDescriptor := range presentationDefinition.InputDescriptors {
+		if inputDescriptor.Constraints == nil {
+			continue
+		}
+		for _, isHolder := range inputDescriptor.Constraints.IsHolder {
+			result = append(result, subjectConstraint{
+				inputDescriptorID: inputDescriptor.Id,
+				inputDescriptors:  []int{i},
+				isHolder:          true,
+				directive:         isHolder.Directive,
+			})
+		}
+		for _, sameSubject := range inputDescriptor.Constraints.SameSubject {
+			var inputDescriptors []int
+			for _, fieldID := range sameSubject.FieldId {
+				inputDescriptors = append(inputDescriptors, fieldDescriptors[fieldID]...)
+			}
+			result = append(result, subjectConstraint{
+				inputDescriptorID: inputDescriptor.Id,
+				inputDescriptors:  inputDescriptors,
+				directive:         sameSubject.Directive,
+			})
+		}
+	}
+	return result
+}
+
+// checkSubjectConstraints checks whether the credentials selected for the input descriptors (by index, nil if none is selected)
+// satisfy the given constraints. Preferred constraints are only checked if includePreferred is true.
+// If holderOf is nil, the holder is unknown, and is_holder constraints require all credentials subject to them to have the same subject,
+// since they will be presented by a single holder.
+func checkSubjectConstraints(constraints []subjectConstraint, selection []*vc.VerifiableCredential, holderOf holderResolver, includePreferred bool) error {
+	var commonHolder *did.DID
+	for _, constraint := range constraints {
+		if !constraint.applies(includePreferred) {
+			continue
+		}
+		var subject *did.DID
+		for _, index := range constraint.inputDescriptors {
+			credential := selection[index]
+			if credential == nil {
+				continue
+			}
+			credentialSubject, err := credential.SubjectDID()
+			if err != nil {
+				return fmt.Errorf("%s constraint of input descriptor '%s' not satisfied: %w", constraint.name(), constraint.inputDescriptorID, err)
+			}
+			if subject != nil && !subject.Equals(*credentialSubject) {
+				return fmt.Errorf("%s constraint of input descriptor '%s' not satisfied: credentials have different subjects", constraint.name(), constraint.inputDescriptorID)
+			}
+			subject = credentialSubject
+			if !constraint.isHolder {
+				continue
+			}
+			holder := commonHolder
+			if holderOf != nil {
+				holder = holderOf(*credential)
+			}
+			if holder == nil {
+				commonHolder = credentialSubject
+			} else if !holder.Equals(*credentialSubject) {
+				return fmt.Errorf("is_holder constraint of input descriptor '%s' not satisfied: credential subject is not the holder", constraint.inputDescriptorID)
+			}
+		}
+	}
+	return nil
+}
+
+// selectCredentials selects a credential from the options for each input descriptor (by index), such that the subject constraints are satisfied.
+// If allowUnselected is true, an input descriptor may be left without credential (e.g. when submission requirements allow picking).
+// It returns false if no such selection exists.
+// The constraints only concern the subjects of the credentials, so instead of trying every combination of credentials
+// (which grows exponentially with the number of input descriptors, and the credentials might come from a remote party),
+// input descriptors that must have the same subject are grouped, and a subject is selected for each group.
+func selectCredentials(constraints []subjectConstraint, options [][]*vc.VerifiableCredential, holderOf holderResolver, includePreferred bool, allowUnselected bool) ([]*vc.VerifiableCredential, bool) {
+	groups := make([]int, len(options))
+	for index := range groups {
+		groups[index] = index
+	}
+	find := func(index int) int {
+		for groups[index] != index {
+			index = groups[index]
+		}
+		return index
+	}
+	constrained := make([]bool, len(options))
+	isHolder := make([]bool, len(options))
+	firstIsHolder := -1
+	for _, constraint := range constraints {
+		if !constraint.applies(includePreferred) {
+			continue
+		}
+		for _, index := range constraint.inputDescriptors {
+			constrained[index] = true
+			groups[find(index)] = find(constraint.inputDescriptors[0])
+			if !constraint.isHolder {
+				continue
+			}
+			isHolder[index] = true
+			// If the holder is unknown, all credentials subject to is_holder must have the same subject: the holder that presents them.
+			if holderOf == nil {
+				if firstIsHolder == -1 {
+					firstIsHolder = index
+				}
+				groups[find(index)] = find(firstIsHolder)
+			}
+		}
+	}
+
+	selection := make([]*vc.VerifiableCredential, len(options))
+	var groupOrder []int
+	groupMembers := make(map[int][]int)
+	for index := range options {
+		if !constrained[index] {
+			// the selected credential doesn't matter for the constraints
+			if len(options[index]) > 0 {
+				selection[index] = options[index][0]
+			}
+			continue
+		}
+		group := find(index)
+		if _, exists := groupMembers[group]; !exists {
+			groupOrder = append(groupOrder, group)
+		}
+		groupMembers[group] = append(groupMembers[group], index)
+	}
+	for _, group := range groupOrder {
+		if !selectSubject(groupMembers[group], options, selection, holderOf, isHolder, allowUnselected) {
+			return nil, false
+		}
+	}
+	if checkSubjectConstraints(constraints, selection, holderOf, includePreferred) != nil {
+		return nil, false
+	}
+	return selection, true
+}
+
+// selectSubject selects credentials with the same subject for the given input descriptors (by index) from the options.
+// Subjects are tried in the order in which they appear in the options.
+// Credentials of input descriptors subject to is_holder (indicated by isHolder) must be presented by their subject, if the holder is known.
+// An input descriptor without credential of the selected subject is left without credential if allowUnselected is true, or if it has no options at all.
+func selectSubject(indices []int, options [][]*vc.VerifiableCredential, selection []*vc.VerifiableCredential, holderOf holderResolver, isHolder []bool, allowUnselected bool) bool {
+	// index the first credential per subject of every input descriptor
+	bySubject := make([]map[string]*vc.VerifiableCredential, len(indices))
+	var subjects []string
+	seen := make(map[string]bool)
+	for i, index := range indices {
+		bySubject[i] = make(map[string]*vc.VerifiableCredential)
+		for _, credential := range options[index] {
+			subject, err := credential.SubjectDID()
+			if err != nil {
+				continue
+			}
+			if isHolder[index] && holderOf != nil {
+				if holder := holderOf(*credential); holder != nil && !holder.Equals(*subject) {
+					continue
+				}
+			}
+			key := subject.String()
+			if _, exists := bySubject[i][key]; !exists {
+				bySubject[i][key] = credential
+			}
+			if !seen[key] {
+				seen[key] = true
+				subjects = append(subjects, key)
+			}
+		}
+	}
+	// the last attempt leaves all input descriptors without credential
+	for _, subject := range append(subjects, "") {
+		complete := true
+		for i, index := range indices {
+			selection[index] = bySubject[i][subject]
+			if selection[index] == nil && !allowUnselected && len(options[index]) > 0 {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return true
+		}
+	}
+	return false
+}
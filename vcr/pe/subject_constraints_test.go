@@ -0,0 +1,281 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pe
+
+import (
+	"fmt"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var aliceDID = did.MustParseDID("did:example:alice")
+var bobDID = did.MustParseDID("did:example:bob")
+
+func subjectCredential(id string, credentialType string, subject did.DID) vc.VerifiableCredential {
+	credentialID := ssi.MustParseURI(id)
+	return credentialToJSONLD(vc.VerifiableCredential{
+		ID:   &credentialID,
+		Type: []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI(credentialType)},
+		CredentialSubject: []interface{}{
+			map[string]interface{}{"id": subject.String()},
+		},
+	})
+}
+
+// subjectConstraintsDefinition returns a presentation definition that requires a NameCredential and an AgeCredential,
+// with the given is_holder/same_subject directives (empty means no constraint).
+func subjectConstraintsDefinition(isHolderDirective string, sameSubjectDirective string) PresentationDefinition {
+	typeField := func(id string, credentialType string) Field {
+		return Field{
+			Id:     &id,
+			Path:   []string{"$.type"},
+			Filter: &Filter{Type: "array", Contains: &Filter{Type: "string", Const: &credentialType}},
+		}
+	}
+	nameDescriptor := &InputDescriptor{
+		Id:          "name",
+		Constraints: &Constraints{Fields: []Field{typeField("name_type", "NameCredential")}},
+	}
+	ageDescriptor := &InputDescriptor{
+		Id:          "age",
+		Constraints: &Constraints{Fields: []Field{typeField("age_type", "AgeCredential")}},
+	}
+	if isHolderDirective != "" {
+		nameDescriptor.Constraints.IsHolder = []*IsHolderItems{{Directive: isHolderDirective, FieldId: []string{"name_type"}}}
+	}
+	if sameSubjectDirective != "" {
+		ageDescriptor.Constraints.SameSubject = []*SameSubjectItems{{Directive: sameSubjectDirective, FieldId: []string{"name_type", "age_type"}}}
+	}
+	return PresentationDefinition{
+		Id:               "subject_constraints",
+		InputDescriptors: []*InputDescriptor{nameDescriptor, ageDescriptor},
+	}
+}
+
+func TestPresentationDefinition_subjectConstraints(t *testing.T) {
+	constraints := subjectConstraintsDefinition(DirectiveRequired, DirectivePreferred).subjectConstraints()
+
+	require.Len(t, constraints, 2)
+	assert.Equal(t, subjectConstraint{inputDescriptorID: "name", inputDescriptors: []int{0}, isHolder: true, directive: DirectiveRequired}, constraints[0])
+	assert.Equal(t, subjectConstraint{inputDescriptorID: "age", inputDescriptors: []int{0, 1}, directive: DirectivePreferred}, constraints[1])
+}
+
+func TestPresentationDefinition_Match_subjectConstraints(t *testing.T) {
+	aliceName := subjectCredential("did:example:issuer#1", "NameCredential", aliceDID)
+	bobName := subjectCredential("did:example:issuer#2", "NameCredential", bobDID)
+	aliceAge := subjectCredential("did:example:issuer#3", "AgeCredential", aliceDID)
+
+	t.Run("same_subject selects credentials of the same subject", func(t *testing.T) {
+		definition := subjectConstraintsDefinition("", DirectiveRequired)
+
+		vcs, mappings, err := definition.Match([]vc.VerifiableCredential{bobName, aliceName, aliceAge})
+
+		require.NoError(t, err)
+		require.Len(t, vcs, 2)
+		assert.Equal(t, aliceName.ID.String(), vcs[0].ID.String())
+		assert.Equal(t, aliceAge.ID.String(), vcs[1].ID.String())
+		assert.Len(t, mappings, 2)
+	})
+	t.Run("required same_subject can't be satisfied", func(t *testing.T) {
+		definition := subjectConstraintsDefinition("", DirectiveRequired)
+
+		vcs, mappings, err := definition.Match([]vc.VerifiableCredential{bobName, aliceAge})
+
+		require.NoError(t, err)
+		assert.Empty(t, vcs)
+		assert.Empty(t, mappings)
+	})
+	t.Run("preferred same_subject can't be satisfied", func(t *testing.T) {
+		definition := subjectConstraintsDefinition("", DirectivePreferred)
+
+		vcs, _, err := definition.Match([]vc.VerifiableCredential{bobName, aliceAge})
+
+		require.NoError(t, err)
+		assert.Len(t, vcs, 2)
+	})
+	t.Run("is_holder and same_subject without known holder", func(t *testing.T) {
+		definition := subjectConstraintsDefinition(DirectiveRequired, DirectiveRequired)
+
+		vcs, _, err := definition.Match([]vc.VerifiableCredential{bobName, aliceName, aliceAge})
+
+		require.NoError(t, err)
+		require.Len(t, vcs, 2)
+		assert.Equal(t, aliceName.ID.String(), vcs[0].ID.String())
+	})
+	t.Run("credential without subject DID", func(t *testing.T) {
+		definition := subjectConstraintsDefinition(DirectiveRequired, "")
+		credential := aliceName
+		credential.CredentialSubject = nil
+
+		vcs, _, err := definition.Match([]vc.VerifiableCredential{credential, aliceAge})
+
+		require.NoError(t, err)
+		assert.Empty(t, vcs)
+	})
+}
+
+func Test_selectCredentials(t *testing.T) {
+	t.Run("many candidates", func(t *testing.T) {
+		// 3 input descriptors that must have the same subject, with 500 candidates each of distinct subjects,
+		// only the last candidates share their subject.
+		const count = 500
+		constraints := []subjectConstraint{{inputDescriptorID: "id", inputDescriptors: []int{0, 1, 2}, directive: DirectiveRequired}}
+		options := make([][]*vc.VerifiableCredential, 3)
+		for i := range options {
+			for j := 0; j < count; j++ {
+				subject := did.MustParseDID(fmt.Sprintf("did:example:%d-%d", i, j))
+				if j == count-1 {
+					subject = aliceDID
+				}
+				credential := subjectCredential(fmt.Sprintf("did:example:issuer#%d-%d", i, j), "NameCredential", subject)
+				options[i] = append(options[i], &credential)
+			}
+		}
+
+		selection, ok := selectCredentials(constraints, options, nil, true, false)
+
+		require.True(t, ok)
+		for _, credential := range selection {
+			subject, _ := credential.SubjectDID()
+			assert.Equal(t, aliceDID, *subject)
+		}
+		t.Run("input descriptors may be left without credential", func(t *testing.T) {
+			options := [][]*vc.VerifiableCredential{options[0][:count-1], options[1], options[2]}
+
+			selection, ok := selectCredentials(constraints, options, nil, true, true)
+
+			require.True(t, ok)
+			assert.Same(t, options[0][0], selection[0])
+			assert.Nil(t, selection[1])
+			assert.Nil(t, selection[2])
+		})
+		t.Run("no common subject", func(t *testing.T) {
+			options := [][]*vc.VerifiableCredential{options[0][:count-1], options[1], options[2]}
+
+			_, ok := selectCredentials(constraints, options, nil, true, false)
+
+			assert.False(t, ok)
+		})
+	})
+	t.Run("credentials of the same subject are interchangeable", func(t *testing.T) {
+		constraints := []subjectConstraint{{inputDescriptorID: "id", inputDescriptors: []int{0, 1}, directive: DirectiveRequired}}
+		var bobCredentials []*vc.VerifiableCredential
+		for j := 0; j < 1000; j++ {
+			credential := subjectCredential(fmt.Sprintf("did:example:issuer#%d", j), "NameCredential", bobDID)
+			bobCredentials = append(bobCredentials, &credential)
+		}
+		aliceCredential := subjectCredential("did:example:issuer#alice", "AgeCredential", aliceDID)
+		options := [][]*vc.VerifiableCredential{bobCredentials, bobCredentials, {&aliceCredential}}
+
+		selection, ok := selectCredentials(constraints, options, nil, true, false)
+
+		require.True(t, ok)
+		assert.Same(t, bobCredentials[0], selection[0])
+		assert.Same(t, bobCredentials[0], selection[1])
+		assert.Same(t, &aliceCredential, selection[2])
+	})
+}
+
+func TestPresentationSubmissionBuilder_Build_isHolder(t *testing.T) {
+	aliceName := subjectCredential("did:example:issuer#1", "NameCredential", aliceDID)
+	bobName := subjectCredential("did:example:issuer#2", "NameCredential", bobDID)
+	bobAge := subjectCredential("did:example:issuer#3", "AgeCredential", bobDID)
+	definition := subjectConstraintsDefinition(DirectiveRequired, "")
+
+	t.Run("selects credential of which the wallet holder is the subject", func(t *testing.T) {
+		builder := definition.PresentationSubmissionBuilder()
+		builder.AddWallet(bobDID, []vc.VerifiableCredential{aliceName, bobName, bobAge})
+
+		_, signInstructions, err := builder.Build("ldp_vp")
+
+		require.NoError(t, err)
+		require.Len(t, signInstructions, 1)
+		assert.Equal(t, bobDID, signInstructions[0].Holder)
+		require.Len(t, signInstructions[0].VerifiableCredentials, 2)
+		assert.Equal(t, bobName.ID.String(), signInstructions[0].VerifiableCredentials[0].ID.String())
+	})
+	t.Run("no wallet holds a credential of its own", func(t *testing.T) {
+		builder := definition.PresentationSubmissionBuilder()
+		builder.AddWallet(bobDID, []vc.VerifiableCredential{aliceName, bobAge})
+
+		_, signInstructions, err := builder.Build("ldp_vp")
+
+		require.NoError(t, err)
+		assert.True(t, signInstructions.Empty())
+	})
+}
+
+func TestPresentationSubmission_Validate_subjectConstraints(t *testing.T) {
+	aliceName := subjectCredential("did:example:issuer#1", "NameCredential", aliceDID)
+	aliceAge := subjectCredential("did:example:issuer#2", "AgeCredential", aliceDID)
+	bobAge := subjectCredential("did:example:issuer#3", "AgeCredential", bobDID)
+	presentation := func(signer did.DID, credentials ...vc.VerifiableCredential) vc.VerifiablePresentation {
+		return vc.VerifiablePresentation{
+			VerifiableCredential: credentials,
+			Proof: []interface{}{
+				proof.LDProof{VerificationMethod: signer.URI()},
+			},
+		}
+	}
+	submission := PresentationSubmission{
+		DescriptorMap: []InputDescriptorMappingObject{
+			{Id: "name", Path: "$.verifiableCredential[0]", Format: "ldp_vc"},
+			{Id: "age", Path: "$.verifiableCredential[1]", Format: "ldp_vc"},
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		definition := subjectConstraintsDefinition(DirectiveRequired, DirectiveRequired)
+
+		credentials, err := submission.Validate(toEnvelope(t, presentation(aliceDID, aliceName, aliceAge)), definition)
+
+		require.NoError(t, err)
+		assert.Len(t, credentials, 2)
+	})
+	t.Run("is_holder violated", func(t *testing.T) {
+		definition := subjectConstraintsDefinition(DirectiveRequired, "")
+
+		credentials, err := submission.Validate(toEnvelope(t, presentation(bobDID, aliceName, aliceAge)), definition)
+
+		assert.EqualError(t, err, "presentation submission violates presentation definition: is_holder constraint of input descriptor 'name' not satisfied: credential subject is not the holder")
+		assert.Nil(t, credentials)
+	})
+	t.Run("same_subject violated", func(t *testing.T) {
+		definition := subjectConstraintsDefinition("", DirectiveRequired)
+
+		credentials, err := submission.Validate(toEnvelope(t, presentation(aliceDID, aliceName, bobAge)), definition)
+
+		assert.EqualError(t, err, "presentation submission violates presentation definition: same_subject constraint of input descriptor 'age' not satisfied: credentials have different subjects")
+		assert.Nil(t, credentials)
+	})
+	t.Run("preferred same_subject violated", func(t *testing.T) {
+		definition := subjectConstraintsDefinition("", DirectivePreferred)
+
+		credentials, err := submission.Validate(toEnvelope(t, presentation(aliceDID, aliceName, bobAge)), definition)
+
+		require.NoError(t, err)
+		assert.Len(t, credentials, 2)
+	})
+}
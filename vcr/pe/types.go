@@ -104,7 +104,7 @@ type Statuses struct {
 	Suspended *StatusDirective `json:"suspended,omitempty"`
 }
 
-// Field describes a constraints field in a presentation definition's input descriptor.  The predicate feature is not implemented
+// Field describes a constraints field in a presentation definition's input descriptor.
 type Field struct {
 	Id             *string  `json:"id,omitempty"`
 	Optional       *bool    `json:"optional,omitempty"`
@@ -113,11 +113,22 @@ type Field struct {
 	Name           *string  `json:"name,omitempty"`
 	IntentToRetain *bool    `json:"intent_to_retain,omitempty"`
 	Filter         *Filter  `json:"filter,omitempty"`
+	// Predicate indicates the verifier only wants to know whether the value matches the filter: required or preferred.
+	// If set, the field resolves to the boolean result of the filter instead of the value itself.
+	Predicate *string `json:"predicate,omitempty"`
 }
 
-// Filter is a JSON Schema (without nesting)
+const (
+	// PredicateRequired indicates the boolean result of the filter must be submitted instead of the value.
+	PredicateRequired = "required"
+	// PredicatePreferred indicates the boolean result of the filter should be submitted instead of the value.
+	PredicatePreferred = "preferred"
+)
+
+// Filter is a JSON Schema, supporting a subset of the keywords.
+// Keywords only apply to values of the matching type, e.g. minimum is ignored for strings.
 type Filter struct {
-	// Type is the type of field: string, number, boolean, array, object
+	// Type is the type of field: string, number, integer, boolean, array, object
 	Type string `json:"type"`
 	// Const is a constant value to match, currently only strings are supported
 	Const *string `json:"const,omitempty"`
@@ -125,6 +136,31 @@ type Filter struct {
 	Enum []string `json:"enum,omitempty"`
 	// Pattern is a pattern to match according to ECMA-262, section 21.2.1
 	Pattern *string `json:"pattern,omitempty"`
+	// MinLength is the minimum length (in characters) of a string
+	MinLength *int `json:"minLength,omitempty"`
+	// MaxLength is the maximum length (in characters) of a string
+	MaxLength *int `json:"maxLength,omitempty"`
+	// Minimum is the inclusive lower bound of a number
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Maximum is the inclusive upper bound of a number
+	Maximum *float64 `json:"maximum,omitempty"`
+	// ExclusiveMinimum is the exclusive lower bound of a number
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	// ExclusiveMaximum is the exclusive upper bound of a number
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	// Format is the format of a string. The date and date-time formats are validated and can be used with the format bounds.
+	// Other formats are ignored.
+	Format *string `json:"format,omitempty"`
+	// FormatMinimum is the inclusive lower bound of a date or date-time string
+	FormatMinimum *string `json:"formatMinimum,omitempty"`
+	// FormatMaximum is the inclusive upper bound of a date or date-time string
+	FormatMaximum *string `json:"formatMaximum,omitempty"`
+	// FormatExclusiveMinimum is the exclusive lower bound of a date or date-time string
+	FormatExclusiveMinimum *string `json:"formatExclusiveMinimum,omitempty"`
+	// FormatExclusiveMaximum is the exclusive upper bound of a date or date-time string
+	FormatExclusiveMaximum *string `json:"formatExclusiveMaximum,omitempty"`
+	// Contains is a filter of which at least one item of an array must match
+	Contains *Filter `json:"contains,omitempty"`
 }
 
 // SubmissionRequirement
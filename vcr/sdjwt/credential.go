@@ -0,0 +1,284 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+)
+
+// Format is the credential format of SD-JWT VCs, as used in presentation definitions, presentation submissions and credential options.
+const Format = "vc+sd-jwt"
+
+// ProofType is the type of the proof that contains the SD-JWT of a credential.
+// SD-JWT VCs are represented as vc.VerifiableCredential containing the disclosed claims, with the SD-JWT as proof.
+// That representation is only a view on the SD-JWT for use within the node: it is derived from it and checked against it when the SD-JWT is extracted.
+// Other parties only get to see the SD-JWT in compact serialization (see ProofPath).
+const ProofType = "SDJWTProof"
+
+// ProofPath is the JSON path of the SD-JWT in compact serialization, relative to the credential.
+// Presentation submissions map SD-JWT VCs to this path, so verifiers get the SD-JWT itself instead of its view.
+const ProofPath = ".proof.sdJwt"
+
+// jwtType is the typ header of SD-JWT VCs.
+const jwtType = "vc+sd-jwt"
+
+const (
+	vctClaim = "vct"
+	// statusClaim contains the credential status entries (e.g. StatusList2021Entry) of the SD-JWT VC.
+	statusClaim = "status"
+)
+
+// registeredClaims are claims that are never selectively disclosable and that are not part of the credential subject.
+var registeredClaims = []string{
+	jwt.IssuerKey, jwt.IssuedAtKey, jwt.NotBeforeKey, jwt.ExpirationKey, jwt.JwtIDKey, jwt.SubjectKey,
+	vctClaim, "cnf", statusClaim,
+}
+
+// Proof is the proof of an SD-JWT VC, containing the SD-JWT in compact serialization.
+type Proof struct {
+	Type  ssi.ProofType `json:"type"`
+	SDJWT string        `json:"sdJwt"`
+}
+
+// IssueCredential creates an SD-JWT VC from the given credential template, and signs it using the given signer.
+// The template must have exactly 1 type next to VerifiableCredential, which becomes the vct claim,
+// and exactly 1 credential subject with an ID, which becomes the sub claim (binding the credential to the subject's keys).
+// All properties of the credential subject (except its ID) become selectively disclosable, recursively for nested objects.
+// The credential status of the template becomes the status claim. Its JSON-LD context is not included, since SD-JWT VCs aren't JSON-LD documents.
+func IssueCredential(ctx context.Context, template vc.VerifiableCredential, signer vc.JWTSigner) (*vc.VerifiableCredential, error) {
+	if template.ID == nil || template.IssuanceDate == nil {
+		return nil, errors.New("SD-JWT VC requires an ID and issuanceDate")
+	}
+	var credentialTypes []string
+	for _, credentialType := range template.Type {
+		if credentialType.String() != vc.VerifiableCredentialType {
+			credentialTypes = append(credentialTypes, credentialType.String())
+		}
+	}
+	if len(credentialTypes) != 1 {
+		return nil, errors.New("SD-JWT VC must have exactly 1 type next to VerifiableCredential")
+	}
+	subjectDID, err := template.SubjectDID()
+	if err != nil {
+		return nil, err
+	}
+	if len(template.CredentialSubject) != 1 {
+		return nil, errors.New("SD-JWT VC must have exactly 1 credentialSubject")
+	}
+	subject, ok := template.CredentialSubject[0].(map[string]interface{})
+	if !ok {
+		// normalize to a JSON object
+		if err = remarshal(template.CredentialSubject[0], &subject); err != nil {
+			return nil, fmt.Errorf("invalid credentialSubject: %w", err)
+		}
+	}
+	claims := make(map[string]interface{})
+	for name, value := range subject {
+		if name != "id" {
+			claims[name] = value
+		}
+	}
+	var disclosures []Disclosure
+	claims, err = makeDisclosable(claims, &disclosures)
+	if err != nil {
+		return nil, err
+	}
+	claims[sdAlgClaim] = hashAlgorithm
+	claims[jwt.IssuerKey] = template.Issuer.String()
+	claims[jwt.SubjectKey] = subjectDID.String()
+	claims[jwt.JwtIDKey] = template.ID.String()
+	claims[jwt.IssuedAtKey] = template.IssuanceDate.Unix()
+	claims[jwt.NotBeforeKey] = template.IssuanceDate.Unix()
+	if template.ExpirationDate != nil {
+		claims[jwt.ExpirationKey] = template.ExpirationDate.Unix()
+	}
+	claims[vctClaim] = credentialTypes[0]
+	if len(template.CredentialStatus) == 1 {
+		claims[statusClaim] = template.CredentialStatus[0]
+	} else if len(template.CredentialStatus) > 1 {
+		claims[statusClaim] = template.CredentialStatus
+	}
+	headers := map[string]interface{}{
+		jws.TypeKey: jwtType,
+	}
+	issuerJWT, err := signer(ctx, claims, headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign SD-JWT: %w", err)
+	}
+	result, err := Parse(SDJWT{IssuerJWT: issuerJWT, Disclosures: disclosures}.String())
+	if err != nil {
+		return nil, err
+	}
+	return ToCredential(*result)
+}
+
+// makeDisclosable replaces the properties of the given object by digests of disclosures, which are added to disclosures.
+// Properties of nested objects are made disclosable as well, so they can be disclosed individually.
+func makeDisclosable(object map[string]interface{}, disclosures *[]Disclosure) (map[string]interface{}, error) {
+	var digests []string
+	for name, value := range object {
+		if nested, ok := value.(map[string]interface{}); ok {
+			var err error
+			if value, err = makeDisclosable(nested, disclosures); err != nil {
+				return nil, err
+			}
+		}
+		disclosure, err := NewDisclosure(name, value)
+		if err != nil {
+			return nil, err
+		}
+		*disclosures = append(*disclosures, *disclosure)
+		digests = append(digests, disclosure.Digest())
+	}
+	// sort digests, so the order of the properties is not revealed
+	sort.Strings(digests)
+	result := map[string]interface{}{}
+	if len(digests) > 0 {
+		result[sdClaim] = digests
+	}
+	return result, nil
+}
+
+// ToCredential converts the SD-JWT VC to a vc.VerifiableCredential, containing its disclosed claims and the SD-JWT as proof.
+func ToCredential(sdJWT SDJWT) (*vc.VerifiableCredential, error) {
+	message, err := jws.ParseString(sdJWT.IssuerJWT)
+	if err != nil {
+		return nil, err
+	}
+	if message.Signatures()[0].ProtectedHeaders().Type() != jwtType {
+		return nil, fmt.Errorf("invalid SD-JWT VC: typ must be %s", jwtType)
+	}
+	claims := sdJWT.Claims()
+	vct, _ := claims[vctClaim].(string)
+	if vct == "" {
+		return nil, fmt.Errorf("invalid SD-JWT VC: missing %s claim", vctClaim)
+	}
+	subject := map[string]interface{}{}
+	if sub, ok := claims[jwt.SubjectKey]; ok {
+		subject["id"] = sub
+	}
+	for name, value := range claims {
+		if !slices.Contains(registeredClaims, name) {
+			subject[name] = value
+		}
+	}
+	credential := map[string]interface{}{
+		"@context":          []string{vc.VCContextV1URI().String()},
+		"id":                claims[jwt.JwtIDKey],
+		"type":              []string{vct, vc.VerifiableCredentialType},
+		"issuer":            claims[jwt.IssuerKey],
+		"credentialSubject": subject,
+		"proof": Proof{
+			Type:  ProofType,
+			SDJWT: sdJWT.String(),
+		},
+	}
+	if issuanceDate := numericDate(claims, jwt.NotBeforeKey, jwt.IssuedAtKey); issuanceDate != nil {
+		credential["issuanceDate"] = issuanceDate.Format(time.RFC3339)
+	}
+	if expirationDate := numericDate(claims, jwt.ExpirationKey); expirationDate != nil {
+		credential["expirationDate"] = expirationDate.Format(time.RFC3339)
+	}
+	if status, ok := claims[statusClaim]; ok {
+		credential["credentialStatus"] = status
+	}
+	data, _ := json.Marshal(credential)
+	return vc.ParseVerifiableCredential(string(data))
+}
+
+// ParseCredential parses an SD-JWT VC in compact serialization, and converts it to a vc.VerifiableCredential (see ToCredential).
+// It does not verify any signature.
+func ParseCredential(raw string) (*vc.VerifiableCredential, error) {
+	sdJWT, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	return ToCredential(*sdJWT)
+}
+
+// IsCredential returns true if the credential is an SD-JWT VC, i.e. it has an SD-JWT proof.
+// It does not check whether the SD-JWT is valid.
+func IsCredential(credential vc.VerifiableCredential) bool {
+	proofs, _ := credential.Proofs()
+	return len(proofs) == 1 && proofs[0].Type == ProofType
+}
+
+// FromCredential returns the SD-JWT of the SD-JWT VC.
+// It returns an error if the credential is not an SD-JWT VC, or if the credential does not exactly match its SD-JWT.
+// It does not verify any signature.
+func FromCredential(credential vc.VerifiableCredential) (*SDJWT, error) {
+	if !IsCredential(credential) {
+		return nil, errors.New("credential is not an SD-JWT VC")
+	}
+	var proofs []Proof
+	if err := credential.UnmarshalProofValue(&proofs); err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT proof: %w", err)
+	}
+	result, err := Parse(proofs[0].SDJWT)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := ToCredential(*result)
+	if err != nil {
+		return nil, err
+	}
+	// The credential must not contain claims other than the ones that are disclosed by the SD-JWT
+	var expectedMap, actualMap map[string]interface{}
+	if err = remarshal(expected, &expectedMap); err != nil {
+		return nil, err
+	}
+	if err = remarshal(credential, &actualMap); err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(expectedMap, actualMap) {
+		return nil, errors.New("credential does not match its SD-JWT")
+	}
+	return result, nil
+}
+
+// numericDate returns the time of the first given claim that is present, or nil if none is present.
+func numericDate(claims map[string]interface{}, names ...string) *time.Time {
+	for _, name := range names {
+		if value, ok := claims[name].(float64); ok {
+			result := time.Unix(int64(value), 0).UTC()
+			return &result
+		}
+	}
+	return nil
+}
+
+func remarshal(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueCredential(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		credential, _ := testCredential(t)
+
+		assert.True(t, IsCredential(*credential))
+		assert.Equal(t, "did:web:example.com:iam:issuer#1", credential.ID.String())
+		assert.Equal(t, "did:web:example.com:iam:issuer", credential.Issuer.String())
+		assert.True(t, credential.IsType(ssi.MustParseURI("NutsOrganizationCredential")))
+		assert.True(t, credential.ContainsContext(vc.VCContextV1URI()))
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *credential.IssuanceDate)
+		assert.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), *credential.ExpirationDate)
+		subjectDID, _ := credential.SubjectDID()
+		assert.Equal(t, "did:web:example.com:iam:holder", subjectDID.String())
+		var subjects []map[string]interface{}
+		require.NoError(t, credential.UnmarshalCredentialSubject(&subjects))
+		assert.Equal(t, map[string]interface{}{"name": "Hospital", "city": "Amsterdam"}, subjects[0]["organization"])
+		t.Run("claims are selectively disclosable", func(t *testing.T) {
+			sdJWT, err := FromCredential(*credential)
+			require.NoError(t, err)
+			assert.Len(t, sdJWT.Disclosures, 3)
+			message, _ := jws.ParseString(sdJWT.IssuerJWT)
+			assert.NotContains(t, string(message.Payload()), "Hospital")
+			assert.NotContains(t, string(message.Payload()), "@context")
+			assert.Equal(t, "vc+sd-jwt", message.Signatures()[0].ProtectedHeaders().Type())
+		})
+	})
+	signer, _ := testHolderKey(t)
+	t.Run("credential status becomes status claim", func(t *testing.T) {
+		template := testTemplate()
+		template.CredentialStatus = []interface{}{map[string]interface{}{
+			"id":                   "https://example.com/statuslist/1#5",
+			"type":                 "StatusList2021Entry",
+			"statusPurpose":        "revocation",
+			"statusListIndex":      "5",
+			"statusListCredential": "https://example.com/statuslist/1",
+		}}
+
+		credential, err := IssueCredential(audit.TestContext(), template, signer)
+
+		require.NoError(t, err)
+		sdJWT, err := FromCredential(*credential)
+		require.NoError(t, err)
+		status := sdJWT.Claims()["status"].(map[string]interface{})
+		assert.Equal(t, "StatusList2021Entry", status["type"])
+		require.Len(t, credential.CredentialStatus, 1)
+		assert.Equal(t, "StatusList2021Entry", credential.CredentialStatus[0].(map[string]interface{})["type"])
+	})
+	t.Run("multiple types", func(t *testing.T) {
+		template := testTemplate()
+		template.Type = append(template.Type, ssi.MustParseURI("OtherCredential"))
+
+		_, err := IssueCredential(audit.TestContext(), template, signer)
+
+		assert.EqualError(t, err, "SD-JWT VC must have exactly 1 type next to VerifiableCredential")
+	})
+	t.Run("no subject ID", func(t *testing.T) {
+		template := testTemplate()
+		template.CredentialSubject = []interface{}{map[string]interface{}{"name": "Hospital"}}
+
+		_, err := IssueCredential(audit.TestContext(), template, signer)
+
+		assert.ErrorContains(t, err, "credential subjects have no ID")
+	})
+}
+
+func TestParseCredential(t *testing.T) {
+	credential, _ := testCredential(t)
+	sdJWT, err := FromCredential(*credential)
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		parsed, err := ParseCredential(sdJWT.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, credential.Raw(), parsed.Raw())
+	})
+	t.Run("invalid SD-JWT", func(t *testing.T) {
+		_, err := ParseCredential("not an SD-JWT")
+
+		assert.EqualError(t, err, "invalid SD-JWT: missing disclosure separator")
+	})
+}
+
+func TestFromCredential(t *testing.T) {
+	credential, _ := testCredential(t)
+	t.Run("ok", func(t *testing.T) {
+		sdJWT, err := FromCredential(*credential)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hospital", sdJWT.Claims()["organization"].(map[string]interface{})["name"])
+	})
+	t.Run("survives JSON roundtrip", func(t *testing.T) {
+		data, _ := json.Marshal(credential)
+		var parsed vc.VerifiableCredential
+		require.NoError(t, json.Unmarshal(data, &parsed))
+
+		_, err := FromCredential(parsed)
+
+		assert.NoError(t, err)
+	})
+	t.Run("credential does not match SD-JWT", func(t *testing.T) {
+		tampered := *credential
+		tampered.CredentialSubject = []interface{}{map[string]interface{}{
+			"id":           "did:web:example.com:iam:holder",
+			"organization": map[string]interface{}{"name": "Other hospital", "city": "Amsterdam"},
+		}}
+
+		_, err := FromCredential(tampered)
+
+		assert.EqualError(t, err, "credential does not match its SD-JWT")
+	})
+	t.Run("not an SD-JWT VC", func(t *testing.T) {
+		_, err := FromCredential(vc.VerifiableCredential{})
+
+		assert.EqualError(t, err, "credential is not an SD-JWT VC")
+	})
+}
+
+func testTemplate() vc.VerifiableCredential {
+	id := ssi.MustParseURI("did:web:example.com:iam:issuer#1")
+	issuanceDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expirationDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	return vc.VerifiableCredential{
+		Context:        []ssi.URI{vc.VCContextV1URI()},
+		ID:             &id,
+		Type:           []ssi.URI{ssi.MustParseURI("NutsOrganizationCredential"), vc.VerifiableCredentialTypeV1URI()},
+		Issuer:         ssi.MustParseURI("did:web:example.com:iam:issuer"),
+		IssuanceDate:   &issuanceDate,
+		ExpirationDate: &expirationDate,
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id": "did:web:example.com:iam:holder",
+			"organization": map[string]interface{}{
+				"name": "Hospital",
+				"city": "Amsterdam",
+			},
+		}},
+	}
+}
+
+// testCredential issues an SD-JWT VC using testTemplate, and returns it with the issuer's key.
+func testCredential(t *testing.T) (*vc.VerifiableCredential, crypto.Key) {
+	keyStore := crypto.NewMemoryCryptoInstance()
+	key, err := keyStore.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc("did:web:example.com:iam:issuer#key-1"))
+	require.NoError(t, err)
+	credential, err := IssueCredential(audit.TestContext(), testTemplate(), func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		return keyStore.SignJWT(ctx, claims, headers, key)
+	})
+	require.NoError(t, err)
+	return credential, key
+}
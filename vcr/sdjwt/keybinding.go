@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/crypto"
+)
+
+// KeyBindingJWTType is the typ header of key binding JWTs.
+const KeyBindingJWTType = "kb+jwt"
+
+// sdHashClaim contains the hash of the SD-JWT (without key binding JWT) the key binding JWT is bound to.
+const sdHashClaim = "sd_hash"
+
+// nonceClaim contains the nonce of the presentation the key binding JWT is created for.
+const nonceClaim = "nonce"
+
+// WithKeyBinding returns a copy of the SD-JWT with a key binding JWT, signed by the holder using the given signer.
+// An existing key binding JWT is replaced.
+func (s SDJWT) WithKeyBinding(ctx context.Context, signer vc.JWTSigner, audience string, nonce string) (*SDJWT, error) {
+	result := s
+	result.KeyBindingJWT = ""
+	claims := map[string]interface{}{
+		jwt.IssuedAtKey: time.Now().Unix(),
+		jwt.AudienceKey: audience,
+		nonceClaim:      nonce,
+		sdHashClaim:     hash(result.withoutKeyBinding()),
+	}
+	headers := map[string]interface{}{
+		jws.TypeKey: KeyBindingJWTType,
+	}
+	token, err := signer(ctx, claims, headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign key binding JWT: %w", err)
+	}
+	result.KeyBindingJWT = token
+	return &result, nil
+}
+
+// VerifyKeyBinding verifies the key binding JWT of the SD-JWT, using the given function to resolve the holder's public key.
+// It checks the signature, typ header and that it is bound to the SD-JWT and its disclosures.
+// The audience and nonce of the key binding JWT must match the given audience and nonce, to prevent it from being replayed.
+func (s SDJWT) VerifyKeyBinding(keyResolver crypto.PublicKeyFunc, audience string, nonce string, at time.Time) (jwt.Token, error) {
+	if s.KeyBindingJWT == "" {
+		return nil, errors.New("SD-JWT has no key binding JWT")
+	}
+	if audience == "" || nonce == "" {
+		return nil, errors.New("audience and nonce are required to verify key binding JWT")
+	}
+	token, err := crypto.ParseJWT(s.KeyBindingJWT, keyResolver, jwt.WithClock(jwt.ClockFunc(func() time.Time {
+		return at
+	})), jwt.WithRequiredClaim(jwt.IssuedAtKey), jwt.WithAudience(audience))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key binding JWT: %w", err)
+	}
+	message, _ := jws.ParseString(s.KeyBindingJWT) // can't fail, parsed above
+	if message.Signatures()[0].ProtectedHeaders().Type() != KeyBindingJWTType {
+		return nil, errors.New("invalid key binding JWT: typ must be " + KeyBindingJWTType)
+	}
+	if actualNonce, _ := token.Get(nonceClaim); actualNonce != nonce {
+		return nil, errors.New("invalid key binding JWT: nonce does not match")
+	}
+	if sdHash, _ := token.Get(sdHashClaim); sdHash != hash(s.withoutKeyBinding()) {
+		return nil, errors.New("invalid key binding JWT: sd_hash does not match")
+	}
+	return token, nil
+}
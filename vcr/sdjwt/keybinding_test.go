@@ -0,0 +1,146 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	crypt "crypto"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDJWT_WithKeyBinding(t *testing.T) {
+	credential, _ := testCredential(t)
+	sdJWT, err := FromCredential(*credential)
+	require.NoError(t, err)
+	signer, publicKey := testHolderKey(t)
+
+	t.Run("ok", func(t *testing.T) {
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "https://example.com", "nonce")
+		require.NoError(t, err)
+
+		parsed, err := Parse(bound.String())
+		require.NoError(t, err)
+		token, err := parsed.VerifyKeyBinding(publicKey, "https://example.com", "nonce", time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://example.com"}, token.Audience())
+		nonce, _ := token.Get("nonce")
+		assert.Equal(t, "nonce", nonce)
+	})
+	t.Run("replaces existing key binding", func(t *testing.T) {
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "https://example.com", "nonce")
+		require.NoError(t, err)
+		limited := bound.Without(bound.Disclosures[0].Digest())
+
+		rebound, err := limited.WithKeyBinding(audit.TestContext(), signer, "https://example.com", "other")
+
+		require.NoError(t, err)
+		_, err = rebound.VerifyKeyBinding(publicKey, "https://example.com", "other", time.Now())
+		assert.NoError(t, err)
+	})
+}
+
+func TestSDJWT_VerifyKeyBinding(t *testing.T) {
+	credential, _ := testCredential(t)
+	sdJWT, err := FromCredential(*credential)
+	require.NoError(t, err)
+	signer, publicKey := testHolderKey(t)
+
+	t.Run("disclosures changed after binding", func(t *testing.T) {
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "https://example.com", "nonce")
+		require.NoError(t, err)
+		// remove a disclosure, but keep the key binding JWT
+		limited := bound.Without(bound.Disclosures[0].Digest())
+		limited.KeyBindingJWT = bound.KeyBindingJWT
+
+		_, err = limited.VerifyKeyBinding(publicKey, "https://example.com", "nonce", time.Now())
+
+		assert.EqualError(t, err, "invalid key binding JWT: sd_hash does not match")
+	})
+	t.Run("other audience", func(t *testing.T) {
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "https://example.com", "nonce")
+		require.NoError(t, err)
+
+		_, err = bound.VerifyKeyBinding(publicKey, "https://other.example.com", "nonce", time.Now())
+
+		assert.ErrorContains(t, err, "invalid key binding JWT: \"aud\" not satisfied")
+	})
+	t.Run("other nonce", func(t *testing.T) {
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "https://example.com", "nonce")
+		require.NoError(t, err)
+
+		_, err = bound.VerifyKeyBinding(publicKey, "https://example.com", "other", time.Now())
+
+		assert.EqualError(t, err, "invalid key binding JWT: nonce does not match")
+	})
+	t.Run("no audience or nonce", func(t *testing.T) {
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "https://example.com", "nonce")
+		require.NoError(t, err)
+
+		_, err = bound.VerifyKeyBinding(publicKey, "", "", time.Now())
+
+		assert.EqualError(t, err, "audience and nonce are required to verify key binding JWT")
+	})
+	t.Run("no key binding", func(t *testing.T) {
+		_, err := sdJWT.VerifyKeyBinding(publicKey, "https://example.com", "nonce", time.Now())
+
+		assert.EqualError(t, err, "SD-JWT has no key binding JWT")
+	})
+	t.Run("signed by other key", func(t *testing.T) {
+		otherSigner, _ := testHolderKey(t)
+		bound, err := otherSigner(audit.TestContext(), map[string]interface{}{}, map[string]interface{}{})
+		require.NoError(t, err)
+		sdJWT := *sdJWT
+		sdJWT.KeyBindingJWT = bound
+
+		_, err = sdJWT.VerifyKeyBinding(publicKey, "https://example.com", "nonce", time.Now())
+
+		assert.ErrorContains(t, err, "invalid key binding JWT")
+	})
+	t.Run("wrong typ", func(t *testing.T) {
+		token, err := signer(audit.TestContext(), map[string]interface{}{"iat": time.Now().Unix(), "aud": "https://example.com"}, map[string]interface{}{"typ": "JWT"})
+		require.NoError(t, err)
+		sdJWT := *sdJWT
+		sdJWT.KeyBindingJWT = token
+
+		_, err = sdJWT.VerifyKeyBinding(publicKey, "https://example.com", "nonce", time.Now())
+
+		assert.EqualError(t, err, "invalid key binding JWT: typ must be kb+jwt")
+	})
+}
+
+// testHolderKey creates a new key and returns a signer for it, and a function that resolves its public key.
+func testHolderKey(t *testing.T) (vc.JWTSigner, crypto.PublicKeyFunc) {
+	keyStore := crypto.NewMemoryCryptoInstance()
+	key, err := keyStore.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc("did:web:example.com:iam:holder#key-1"))
+	require.NoError(t, err)
+	signer := func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		return keyStore.SignJWT(ctx, claims, headers, key)
+	}
+	return signer, func(_ string) (crypt.PublicKey, error) {
+		return key.Public(), nil
+	}
+}
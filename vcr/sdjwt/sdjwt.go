@@ -0,0 +1,308 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+// Package sdjwt implements Selective Disclosure JWTs (https://datatracker.ietf.org/doc/draft-ietf-oauth-selective-disclosure-jwt/)
+// and SD-JWT based Verifiable Credentials (https://datatracker.ietf.org/doc/draft-ietf-oauth-sd-jwt-vc/).
+// Only object properties can be selectively disclosable, array element disclosures are not supported.
+package sdjwt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// separator separates the issuer-signed JWT, disclosures and key binding JWT in the SD-JWT compact serialization.
+const separator = "~"
+
+// sdClaim contains the digests of the selectively disclosable properties of an object.
+const sdClaim = "_sd"
+
+// sdAlgClaim contains the hash algorithm used to calculate the digests of disclosures.
+const sdAlgClaim = "_sd_alg"
+
+// hashAlgorithm is the only supported hash algorithm for disclosure digests.
+const hashAlgorithm = "sha-256"
+
+// saltSize is the size of the random salt of disclosures in bytes, as recommended by the specification.
+const saltSize = 16
+
+// Disclosure is a selectively disclosable object property.
+type Disclosure struct {
+	Salt  string
+	Name  string
+	Value interface{}
+	// encoded contains the base64url encoded disclosure as it was created or parsed, since its digest must be calculated over the original encoding.
+	encoded string
+}
+
+// NewDisclosure creates a disclosure for the given property, using a random salt.
+func NewDisclosure(name string, value interface{}) (*Disclosure, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	result := Disclosure{
+		Salt:  base64.RawURLEncoding.EncodeToString(salt),
+		Name:  name,
+		Value: value,
+	}
+	data, err := json.Marshal([]interface{}{result.Salt, result.Name, result.Value})
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for disclosure '%s': %w", name, err)
+	}
+	result.encoded = base64.RawURLEncoding.EncodeToString(data)
+	return &result, nil
+}
+
+func parseDisclosure(encoded string) (*Disclosure, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid disclosure encoding: %w", err)
+	}
+	var elements []interface{}
+	if err = json.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("invalid disclosure: %w", err)
+	}
+	if len(elements) != 3 {
+		return nil, errors.New("invalid disclosure: only object property disclosures are supported")
+	}
+	salt, ok := elements[0].(string)
+	if !ok {
+		return nil, errors.New("invalid disclosure: salt must be a string")
+	}
+	name, ok := elements[1].(string)
+	if !ok {
+		return nil, errors.New("invalid disclosure: name must be a string")
+	}
+	if name == sdClaim || name == "..." {
+		return nil, fmt.Errorf("invalid disclosure: reserved name '%s'", name)
+	}
+	return &Disclosure{Salt: salt, Name: name, Value: elements[2], encoded: encoded}, nil
+}
+
+// Encoded returns the base64url encoded disclosure.
+func (d Disclosure) Encoded() string {
+	return d.encoded
+}
+
+// Digest returns the digest of the disclosure, which is included in the SD-JWT payload in place of the disclosed property.
+func (d Disclosure) Digest() string {
+	return hash(d.encoded)
+}
+
+// SDJWT is a Selective Disclosure JWT: an issuer-signed JWT, the disclosures for (some of) its selectively disclosable properties,
+// and optionally a key binding JWT signed by the holder.
+type SDJWT struct {
+	// IssuerJWT is the issuer-signed JWT in compact serialization.
+	IssuerJWT string
+	// Disclosures contains the disclosures of the properties that are disclosed.
+	Disclosures []Disclosure
+	// KeyBindingJWT is the key binding JWT in compact serialization. It is empty if the SD-JWT has no key binding.
+	KeyBindingJWT string
+	payload       map[string]interface{}
+}
+
+// Parse parses an SD-JWT in compact serialization (<Issuer-signed JWT>~<Disclosure 1>~...~<Disclosure N>~<optional KB-JWT>).
+// It checks that every disclosure is referenced by the SD-JWT exactly once, but does not verify any signature.
+func Parse(raw string) (*SDJWT, error) {
+	parts := strings.Split(strings.TrimSpace(raw), separator)
+	if len(parts) < 2 {
+		return nil, errors.New("invalid SD-JWT: missing disclosure separator")
+	}
+	message, err := jws.ParseString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT: %w", err)
+	}
+	result := SDJWT{
+		IssuerJWT:     parts[0],
+		KeyBindingJWT: parts[len(parts)-1],
+	}
+	if err = json.Unmarshal(message.Payload(), &result.payload); err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT payload: %w", err)
+	}
+	if alg, ok := result.payload[sdAlgClaim]; ok && alg != hashAlgorithm {
+		return nil, fmt.Errorf("invalid SD-JWT: unsupported %s: %v", sdAlgClaim, alg)
+	}
+	for _, encoded := range parts[1 : len(parts)-1] {
+		if encoded == "" {
+			return nil, errors.New("invalid SD-JWT: empty disclosure")
+		}
+		disclosure, err := parseDisclosure(encoded)
+		if err != nil {
+			return nil, err
+		}
+		result.Disclosures = append(result.Disclosures, *disclosure)
+	}
+	// Each disclosure must be referenced by the payload or another disclosure, and only once.
+	digests := make(map[string]bool)
+	for _, disclosure := range result.Disclosures {
+		if digests[disclosure.Digest()] {
+			return nil, errors.New("invalid SD-JWT: duplicate disclosure")
+		}
+		digests[disclosure.Digest()] = true
+	}
+	referenced, err := result.referencedDigests()
+	if err != nil {
+		return nil, err
+	}
+	for digest := range digests {
+		if !referenced[digest] {
+			return nil, errors.New("invalid SD-JWT: disclosure is not referenced")
+		}
+	}
+	return &result, nil
+}
+
+// String returns the SD-JWT in compact serialization.
+func (s SDJWT) String() string {
+	return s.withoutKeyBinding() + s.KeyBindingJWT
+}
+
+// withoutKeyBinding returns the SD-JWT in compact serialization without the key binding JWT, which is the input for its sd_hash.
+func (s SDJWT) withoutKeyBinding() string {
+	var builder strings.Builder
+	builder.WriteString(s.IssuerJWT)
+	builder.WriteString(separator)
+	for _, disclosure := range s.Disclosures {
+		builder.WriteString(disclosure.encoded)
+		builder.WriteString(separator)
+	}
+	return builder.String()
+}
+
+// Claims returns the claims of the SD-JWT, with the disclosed properties included and the SD-JWT specific claims removed.
+func (s SDJWT) Claims() map[string]interface{} {
+	disclosures := make(map[string]Disclosure)
+	for _, disclosure := range s.Disclosures {
+		disclosures[disclosure.Digest()] = disclosure
+	}
+	result := resolveDisclosures(s.payload, disclosures).(map[string]interface{})
+	delete(result, sdAlgClaim)
+	return result
+}
+
+// Without returns a copy of the SD-JWT without the disclosure with the given digest,
+// and without the disclosures that were only referenced by it (e.g. properties of an undisclosed object).
+// The key binding JWT is removed, since it is bound to the disclosures.
+func (s SDJWT) Without(digest string) SDJWT {
+	result := SDJWT{IssuerJWT: s.IssuerJWT, payload: s.payload}
+	for _, disclosure := range s.Disclosures {
+		if disclosure.Digest() != digest {
+			result.Disclosures = append(result.Disclosures, disclosure)
+		}
+	}
+	// can't fail, the payload and disclosures have been parsed before
+	referenced, _ := result.referencedDigests()
+	disclosures := result.Disclosures
+	result.Disclosures = nil
+	for _, disclosure := range disclosures {
+		if referenced[disclosure.Digest()] {
+			result.Disclosures = append(result.Disclosures, disclosure)
+		}
+	}
+	return result
+}
+
+// referencedDigests returns the digests of the disclosures that are referenced by the payload, or by another referenced disclosure.
+// It returns an error if a digest is referenced more than once.
+func (s SDJWT) referencedDigests() (map[string]bool, error) {
+	disclosures := make(map[string]Disclosure)
+	for _, disclosure := range s.Disclosures {
+		disclosures[disclosure.Digest()] = disclosure
+	}
+	result := make(map[string]bool)
+	var collect func(value interface{}) error
+	collect = func(value interface{}) error {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			digests, _ := typed[sdClaim].([]interface{})
+			for _, curr := range digests {
+				digest, ok := curr.(string)
+				if !ok {
+					return fmt.Errorf("invalid SD-JWT: %s must contain strings", sdClaim)
+				}
+				if result[digest] {
+					return errors.New("invalid SD-JWT: digest is referenced more than once")
+				}
+				result[digest] = true
+				if disclosure, ok := disclosures[digest]; ok {
+					if err := collect(disclosure.Value); err != nil {
+						return err
+					}
+				}
+			}
+			for name, property := range typed {
+				if name == sdClaim {
+					continue
+				}
+				if err := collect(property); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for _, item := range typed {
+				if err := collect(item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return result, collect(s.payload)
+}
+
+// resolveDisclosures returns a copy of the given value with the disclosed properties added to the objects that reference them.
+func resolveDisclosures(value interface{}, disclosures map[string]Disclosure) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for name, property := range typed {
+			if name == sdClaim {
+				continue
+			}
+			result[name] = resolveDisclosures(property, disclosures)
+		}
+		digests, _ := typed[sdClaim].([]interface{})
+		for _, digest := range digests {
+			if disclosure, ok := disclosures[fmt.Sprintf("%v", digest)]; ok {
+				result[disclosure.Name] = resolveDisclosures(disclosure.Value, disclosures)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, item := range typed {
+			result[i] = resolveDisclosures(item, disclosures)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+func hash(input string) string {
+	digest := sha256.Sum256([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
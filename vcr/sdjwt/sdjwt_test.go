@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	credential, _ := testCredential(t)
+	sdJWT, err := FromCredential(*credential)
+	require.NoError(t, err)
+	raw := sdJWT.String()
+
+	t.Run("ok", func(t *testing.T) {
+		result, err := Parse(raw)
+
+		require.NoError(t, err)
+		assert.Equal(t, raw, result.String())
+		assert.Len(t, result.Disclosures, 3)
+		assert.Empty(t, result.KeyBindingJWT)
+	})
+	t.Run("no disclosures", func(t *testing.T) {
+		result, err := Parse(sdJWT.IssuerJWT + "~")
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Disclosures)
+		assert.NotContains(t, result.Claims(), "organization")
+	})
+	t.Run("missing separator", func(t *testing.T) {
+		_, err := Parse(sdJWT.IssuerJWT)
+
+		assert.EqualError(t, err, "invalid SD-JWT: missing disclosure separator")
+	})
+	t.Run("duplicate disclosure", func(t *testing.T) {
+		_, err := Parse(raw + sdJWT.Disclosures[0].Encoded() + "~")
+
+		assert.EqualError(t, err, "invalid SD-JWT: duplicate disclosure")
+	})
+	t.Run("unreferenced disclosure", func(t *testing.T) {
+		disclosure, _ := NewDisclosure("name", "Other hospital")
+
+		_, err := Parse(raw + disclosure.Encoded() + "~")
+
+		assert.EqualError(t, err, "invalid SD-JWT: disclosure is not referenced")
+	})
+	t.Run("array element disclosure", func(t *testing.T) {
+		_, err := Parse(sdJWT.IssuerJWT + "~WyJzYWx0IiwgInZhbHVlIl0~")
+
+		assert.EqualError(t, err, "invalid disclosure: only object property disclosures are supported")
+	})
+}
+
+func TestSDJWT_Without(t *testing.T) {
+	credential, _ := testCredential(t)
+	sdJWT, err := FromCredential(*credential)
+	require.NoError(t, err)
+	disclosures := map[string]Disclosure{}
+	for _, disclosure := range sdJWT.Disclosures {
+		disclosures[disclosure.Name] = disclosure
+	}
+
+	t.Run("nested property", func(t *testing.T) {
+		result := sdJWT.Without(disclosures["city"].Digest())
+
+		assert.Len(t, result.Disclosures, 2)
+		assert.Equal(t, map[string]interface{}{"name": "Hospital"}, result.Claims()["organization"])
+	})
+	t.Run("object removes its properties", func(t *testing.T) {
+		result := sdJWT.Without(disclosures["organization"].Digest())
+
+		assert.Empty(t, result.Disclosures)
+		assert.NotContains(t, result.Claims(), "organization")
+		_, err := Parse(result.String())
+		assert.NoError(t, err)
+	})
+}
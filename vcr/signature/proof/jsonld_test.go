@@ -202,6 +202,26 @@ func TestLDProof_Sign(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("sign and verify a document with each key type", func(t *testing.T) {
+		keyStore := crypto.NewMemoryCryptoInstance()
+		suite := signature.JSONWebSignature2020{ContextLoader: contextLoader, Signer: keyStore}
+		for _, keyType := range crypto.SupportedKeyTypes() {
+			t.Run(string(keyType), func(t *testing.T) {
+				key, err := keyStore.New(audit.TestContext(), keyType, crypto.StringNamingFunc("did:nuts:123#"+string(keyType)))
+				require.NoError(t, err)
+
+				result, err := NewLDProof(ProofOptions{Created: time.Now()}).Sign(audit.TestContext(), document, suite, key)
+				require.NoError(t, err)
+				signedDocument := result.(SignedDocument)
+
+				proofToVerify := LDProof{}
+				require.NoError(t, signedDocument.UnmarshalProofValue(&proofToVerify))
+				err = proofToVerify.Verify(signedDocument.DocumentWithoutProof(), suite, key.Public())
+				assert.NoError(t, err)
+			})
+		}
+	})
+
 	t.Run("it handles a failed document canonicalization", func(t *testing.T) {
 		ldProof := LDProof{}
 
@@ -23,8 +23,12 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"errors"
+	"strings"
 )
 
+// multibaseBase64URL is the multibase prefix of base64url encoding without padding, used for BitstringStatusList encodedList values.
+const multibaseBase64URL = "u"
+
 var ErrIndexNotInBitstring = errors.New("index not in status list")
 
 const defaultBitstringLengthInBytes = 16 * 1024 // *8 = herd privacy of 16kB or 131072 bit
@@ -48,6 +52,23 @@ func (bs *bitstring) bit(statusListIndex int) (bool, error) {
 	return isSet((*bs)[q], r), nil
 }
 
+// value returns the status of size bits starting at the statusListIndex-th entry of that size,
+// or an error if the requested entry is out of bounds. The first bit is the most significant bit of the value.
+func (bs *bitstring) value(statusListIndex int, size int) (int, error) {
+	if size < 1 || statusListIndex < 0 || (statusListIndex+1)*size > len(*bs)*8 {
+		return 0, ErrIndexNotInBitstring
+	}
+	result := 0
+	for i := statusListIndex * size; i < (statusListIndex+1)*size; i++ {
+		set, _ := bs.bit(i) // can't fail, bounds are checked above
+		result <<= 1
+		if set {
+			result |= 1
+		}
+	}
+	return result, nil
+}
+
 // setBit set the value of the bit at statusListIndex to 1, or returns an error when the index is out of bounds.
 func (bs *bitstring) setBit(statusListIndex int, value bool) error {
 	q, r := statusListIndex/8, byte(statusListIndex%8)
@@ -113,3 +134,20 @@ func expand(encodedList string) (bitstring, error) {
 
 	return expanded.Bytes(), nil
 }
+
+// compressMultibase compresses a BitstringStatusList bitstring. It equals compress, but prefixes the result with the multibase base64url prefix.
+func compressMultibase(bitstring []byte) (string, error) {
+	encodedList, err := compress(bitstring)
+	if err != nil {
+		return "", err
+	}
+	return multibaseBase64URL + encodedList, nil
+}
+
+// expandMultibase expands a compressed BitstringStatusList bitstring, which must be multibase base64url encoded.
+func expandMultibase(encodedList string) (bitstring, error) {
+	if !strings.HasPrefix(encodedList, multibaseBase64URL) {
+		return nil, errors.New("encodedList must be multibase base64url encoded")
+	}
+	return expand(strings.TrimPrefix(encodedList, multibaseBase64URL))
+}
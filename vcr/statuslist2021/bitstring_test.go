@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -52,6 +53,33 @@ func TestBitstring_Bit(t *testing.T) {
 	}
 }
 
+func TestBitstring_Value(t *testing.T) {
+	bs := bitstring{33} // 33 => 00100001
+	type test struct {
+		index int
+		size  int
+		value int
+		err   error
+	}
+
+	tt := []test{
+		{2, 1, 1, nil},
+		{0, 2, 0, nil},  // 00
+		{1, 2, 2, nil},  // 10
+		{3, 2, 1, nil},  // 01
+		{1, 4, 1, nil},  // 0001
+		{0, 8, 33, nil}, // 00100001
+		{4, 2, 0, ErrIndexNotInBitstring},
+		{-1, 2, 0, ErrIndexNotInBitstring},
+		{0, 0, 0, ErrIndexNotInBitstring},
+	}
+	for _, tc := range tt {
+		v, err := bs.value(tc.index, tc.size)
+		assert.ErrorIs(t, err, tc.err)
+		assert.Equal(t, tc.value, v)
+	}
+}
+
 func TestBitstring_SetBit(t *testing.T) {
 	t.Run("ok - set value", func(t *testing.T) {
 		bs := bitstring{0}
@@ -123,3 +151,25 @@ func Test_CompressExpand(t *testing.T) {
 		assert.Equal(t, padExpanded, nopadExpanded)
 	})
 }
+
+func Test_CompressExpandMultibase(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		bs := *newBitstring()
+		require.NoError(t, bs.setBit(42, true))
+
+		compressed, err := compressMultibase(bs)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(compressed, "u"))
+		expanded, err := expandMultibase(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, bs, expanded)
+	})
+	t.Run("error - not multibase encoded", func(t *testing.T) {
+		compressed, err := compress(*newBitstring())
+		require.NoError(t, err)
+
+		_, err = expandMultibase(compressed)
+
+		assert.EqualError(t, err, "encodedList must be multibase base64url encoded")
+	})
+}
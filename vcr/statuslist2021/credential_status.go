@@ -54,6 +54,10 @@ type statusList struct {
 	// statusListCredential is the URL (from StatusList2021Entry.statusListCredential) that credential was downloaded from
 	// it should match with credential.ID
 	statusListCredential string
+	// credentialType is the type of the status list credential; StatusList2021Credential or BitstringStatusListCredential
+	credentialType string
+	// entryType is the credentialStatus.type of entries referring to this status list
+	entryType string
 	// statusPurpose is the purpose listed in the StatusList2021Credential.credentialSubject
 	statusPurpose string
 	// expanded StatusList2021 bitstring
@@ -62,7 +66,8 @@ type statusList struct {
 	lastUpdated time.Time
 }
 
-// Verify CredentialStatus returns a types.ErrRevoked when the credentialStatus contains a 'StatusList2021Entry' that can be resolved and lists the credential as 'revoked'
+// Verify CredentialStatus returns a types.ErrRevoked when the credentialStatus contains a 'StatusList2021Entry' or 'BitstringStatusListEntry'
+// that can be resolved and lists the credential as 'revoked', or types.ErrSuspended if it is only listed as 'suspended'.
 // Other credentialStatus type/statusPurpose are ignored. Verification may fail with other non-standardized errors.
 func (cs *CredentialStatus) Verify(credentialToVerify vc.VerifiableCredential) error {
 	if credentialToVerify.CredentialStatus == nil {
@@ -74,11 +79,13 @@ func (cs *CredentialStatus) Verify(credentialToVerify vc.VerifiableCredential) e
 		return err
 	}
 
-	// only check credentialStatus of type StatusList2021Entry with statusPurpose == revocation other types/purposes are ignored
+	// only check credentialStatus of type StatusList2021Entry or BitstringStatusListEntry with statusPurpose == revocation or suspension,
+	// other types/purposes are ignored
 	// returns errors if processing fails -> TODO: hard/soft fail option?
-	// returns types.ErrRevoked if correct type, purpose, and listed.
+	// returns types.ErrRevoked if correct type, purpose, and listed. Revocation takes precedence over suspension.
+	suspended := false
 	for _, status := range statuses {
-		if status.Type != EntryType {
+		if status.Type != EntryType && status.Type != BitstringEntryType {
 			// ignore other credentialStatus.type
 			// TODO: what log level?
 			log.Logger().
@@ -91,12 +98,12 @@ func (cs *CredentialStatus) Verify(credentialToVerify vc.VerifiableCredential) e
 			// cannot happen. already validated in credential.defaultCredentialValidator{}
 			return err
 		}
-		if slEntry.StatusPurpose != "revocation" {
-			// ignore purposes that are not revocation
+		if slEntry.StatusPurpose != StatusPurposeRevocation && slEntry.StatusPurpose != StatusPurposeSuspension {
+			// ignore purposes that are not revocation or suspension
 			// TODO: what log level?
 			log.Logger().
 				WithField("credentialStatus.statusPurpose", slEntry.StatusPurpose).
-				Info("ignoring credentialStatus with purpose other than 'revocation'")
+				Info("ignoring credentialStatus with purpose other than 'revocation' or 'suspension'")
 			continue
 		}
 
@@ -108,6 +115,9 @@ func (cs *CredentialStatus) Verify(credentialToVerify vc.VerifiableCredential) e
 		if sList.statusPurpose != slEntry.StatusPurpose {
 			return fmt.Errorf("StatusList2021Credential.credentialSubject.statusPuspose='%s' does not match vc.credentialStatus.statusPurpose='%s'", sList.statusPurpose, slEntry.StatusPurpose)
 		}
+		if sList.entryType != slEntry.Type {
+			return fmt.Errorf("status list credential of type '%s' does not match vc.credentialStatus.type='%s'", sList.credentialType, slEntry.Type)
+		}
 
 		// check if listed
 		index, err := strconv.Atoi(slEntry.StatusListIndex)
@@ -115,14 +125,28 @@ func (cs *CredentialStatus) Verify(credentialToVerify vc.VerifiableCredential) e
 			// cannot happen. already validated in credential.defaultCredentialValidator{}
 			return err
 		}
-		revoked, err := sList.expanded.bit(index)
+		statusSize, err := slEntry.statusSize()
+		if err != nil {
+			return err
+		}
+		if slEntry.Type == BitstringEntryType && len(sList.expanded)*8 < minBitstringEntries*statusSize {
+			return fmt.Errorf("status list must contain at least %d entries", minBitstringEntries)
+		}
+		status, err := sList.expanded.value(index, statusSize)
 		if err != nil {
 			return err
 		}
-		if revoked {
+		if status != 0 {
+			if slEntry.StatusPurpose == StatusPurposeSuspension {
+				suspended = true
+				continue
+			}
 			return types.ErrRevoked
 		}
 	}
+	if suspended {
+		return types.ErrSuspended
+	}
 	return nil
 }
 
@@ -144,13 +168,19 @@ func (cs *CredentialStatus) update(statusListCredential string) (*statusList, er
 	}
 
 	// make statusList
-	expanded, err := expand(credSubject.EncodedList)
+	expanded, err := expandList(*credSubject)
 	if err != nil {
 		// cant happen, already checked in verifyStatusList2021Credential
 		return nil, err
 	}
+	credentialType, entryType := CredentialType, EntryType
+	if credSubject.Type == BitstringCredentialSubjectType {
+		credentialType, entryType = BitstringCredentialType, BitstringEntryType
+	}
 	sl := statusList{
 		credential:           cred,
+		credentialType:       credentialType,
+		entryType:            entryType,
 		statusListCredential: statusListCredential,
 		statusPurpose:        credSubject.StatusPurpose,
 		expanded:             expanded,
@@ -191,10 +221,10 @@ func (cs *CredentialStatus) download(statusListCredential string) (*vc.Verifiabl
 	return &cred, nil
 }
 
-// verifyStatusList2021Credential checks that the StatusList2021Credential is currently valid
+// verifyStatusList2021Credential checks that the StatusList2021Credential or BitstringStatusListCredential is currently valid
 func (cs *CredentialStatus) verifyStatusList2021Credential(cred vc.VerifiableCredential) (*CredentialSubject, error) {
 	// make sure we have the correct credential type.
-	if len(cred.Type) != 2 || !cred.IsType(credentialTypeURI) {
+	if len(cred.Type) != 2 || !(cred.IsType(credentialTypeURI) || cred.IsType(bitstringCredentialTypeURI)) {
 		return nil, errors.New("incorrect credential types")
 	}
 
@@ -215,7 +245,7 @@ func (cs *CredentialStatus) verifyStatusList2021Credential(cred vc.VerifiableCre
 		return nil, err
 	}
 	credSubject := credSubjects[0] // validators already ensured there is exactly 1 credentialSubject
-	_, err := expand(credSubject.EncodedList)
+	_, err := expandList(credSubject)
 	if err != nil {
 		return nil, fmt.Errorf("credentialSubject.encodedList is invalid: %w", err)
 	}
@@ -226,3 +256,11 @@ func (cs *CredentialStatus) verifyStatusList2021Credential(cred vc.VerifiableCre
 	}
 	return &credSubject, nil
 }
+
+// expandList expands the encodedList of the credential subject, using the encoding of its status list type.
+func expandList(credSubject CredentialSubject) (bitstring, error) {
+	if credSubject.Type == BitstringCredentialSubjectType {
+		return expandMultibase(credSubject.EncodedList)
+	}
+	return expand(credSubject.EncodedList)
+}
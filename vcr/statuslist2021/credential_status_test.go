@@ -67,13 +67,34 @@ func TestCredentialStatus_verify(t *testing.T) {
 		err := cs.Verify(cred)
 		assert.ErrorIs(t, err, types.ErrRevoked)
 	})
-	t.Run("ok - credentialStatus.statusPurpose != 'revocation' is ignored", func(t *testing.T) {
+	t.Run("ok - credentialStatus.statusPurpose other than 'revocation' or 'suspension' is ignored", func(t *testing.T) {
 		cs, entry, _ := testSetup(t, true) // true
-		entry.StatusPurpose = "suspension"
+		entry.StatusPurpose = "refresh"
 		cred := test.ValidNutsOrganizationCredential(t)
 		cred.CredentialStatus = []any{entry}
 		assert.NoError(t, cs.Verify(cred))
 	})
+	t.Run("ok - suspended", func(t *testing.T) {
+		cs, entry := suspensionTestSetup(t, true)
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		err := cs.Verify(cred)
+		assert.ErrorIs(t, err, types.ErrSuspended)
+	})
+	t.Run("ok - not suspended", func(t *testing.T) {
+		cs, entry := suspensionTestSetup(t, false)
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.NoError(t, cs.Verify(cred))
+	})
+	t.Run("ok - revocation takes precedence over suspension", func(t *testing.T) {
+		cs, suspensionEntry := suspensionTestSetup(t, true)
+		_, revocationEntry, _ := testSetup(t, true) // test servers share the same TLS certificate
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{suspensionEntry, revocationEntry}
+		err := cs.Verify(cred)
+		assert.ErrorIs(t, err, types.ErrRevoked)
+	})
 	t.Run("error - cannot get statusList", func(t *testing.T) {
 		cs, entry, _ := testSetup(t, false)
 		cs.client = http.DefaultClient
@@ -117,6 +138,44 @@ func TestCredentialStatus_verify(t *testing.T) {
 	})
 }
 
+func TestCredentialStatus_verify_bitstring(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.NoError(t, cs.Verify(cred))
+	})
+	t.Run("ok - revoked", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, true)
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		err := cs.Verify(cred)
+		assert.ErrorIs(t, err, types.ErrRevoked)
+	})
+	t.Run("error - entry type does not match status list credential", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		entry.Type = EntryType
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.EqualError(t, cs.Verify(cred), "status list credential of type 'BitstringStatusListCredential' does not match vc.credentialStatus.type='StatusList2021Entry'")
+	})
+	t.Run("error - statusSize without statusMessage", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		entry.StatusSize = 2
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.EqualError(t, cs.Verify(cred), "credentialStatus.statusMessage must contain 4 messages")
+	})
+	t.Run("error - status list too short for statusSize", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		entry.StatusSize = 2
+		entry.StatusMessage = []StatusMessage{{"0x0", "valid"}, {"0x1", "revoked"}, {"0x2", "revoked"}, {"0x3", "revoked"}}
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.EqualError(t, cs.Verify(cred), "status list must contain at least 131072 entries")
+	})
+}
+
 func TestCredentialStatus_update(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		cs, _, ts := testSetup(t, false)
@@ -258,6 +317,21 @@ func TestCredentialStatus_verifyStatusList2021Credential(t *testing.T) {
 		assert.EqualError(t, err, "credentialSubject.encodedList is invalid: illegal base64 data at input byte 0")
 		assert.Nil(t, credSubj)
 	})
+	t.Run("ok - BitstringStatusListCredential", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		credSubj, err := credentialStatusNoSignCheck.verifyStatusList2021Credential(cred)
+		assert.NoError(t, err)
+		require.NotNil(t, credSubj)
+		assert.Equal(t, BitstringCredentialSubjectType, credSubj.Type)
+	})
+	t.Run("error - BitstringStatusList encodedList is not multibase encoded", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.CredentialSubject[0].(map[string]any)["encodedList"] = test.ValidStatusList2021Credential(t).CredentialSubject[0].(map[string]any)["encodedList"]
+		credSubj, err := credentialStatusNoSignCheck.verifyStatusList2021Credential(cred)
+
+		assert.EqualError(t, err, "credentialSubject.encodedList is invalid: encodedList must be multibase base64url encoded")
+		assert.Nil(t, credSubj)
+	})
 	t.Run("error -invalid signature", func(t *testing.T) {
 		cred := test.ValidStatusList2021Credential(t)
 		cs := CredentialStatus{verifySignature: func(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error {
@@ -274,8 +348,25 @@ func TestCredentialStatus_verifyStatusList2021Credential(t *testing.T) {
 //   - a StatusList2021Entry pointing to the test server, optionally provide a statusListIndex matching statusList2021Credential.encodedList to simulate revocation
 //   - the test server
 func testSetup(t testing.TB, entryIsRevoked bool) (*CredentialStatus, Entry, *httptest.Server) {
-	// make test server
-	statusList2021Credential := test.ValidStatusList2021Credential(t) // has bit 1 set
+	return testSetupWithCredential(t, test.ValidStatusList2021Credential(t), EntryType, entryIsRevoked)
+}
+
+// bitstringTestSetup is testSetup for a BitstringStatusListCredential and BitstringStatusListEntry
+func bitstringTestSetup(t testing.TB, entryIsRevoked bool) (*CredentialStatus, Entry, *httptest.Server) {
+	return testSetupWithCredential(t, test.ValidBitstringStatusListCredential(t), BitstringEntryType, entryIsRevoked)
+}
+
+// suspensionTestSetup is testSetup for a StatusList2021Credential and StatusList2021Entry with statusPurpose 'suspension'
+func suspensionTestSetup(t testing.TB, entryIsSuspended bool) (*CredentialStatus, Entry) {
+	statusList2021Credential := test.ValidStatusList2021Credential(t)
+	statusList2021Credential.CredentialSubject[0].(map[string]any)["statusPurpose"] = StatusPurposeSuspension
+	cs, entry, _ := testSetupWithCredential(t, statusList2021Credential, EntryType, entryIsSuspended)
+	entry.StatusPurpose = StatusPurposeSuspension
+	return cs, entry
+}
+
+func testSetupWithCredential(t testing.TB, statusList2021Credential vc.VerifiableCredential, entryType string, entryIsRevoked bool) (*CredentialStatus, Entry, *httptest.Server) {
+	// make test server; statusList2021Credential has bit 1 set
 	credBytes, err := json.Marshal(statusList2021Credential)
 	if err != nil {
 		t.Fatal(err)
@@ -297,7 +388,7 @@ func testSetup(t testing.TB, entryIsRevoked bool) (*CredentialStatus, Entry, *ht
 
 	// make StatusList2021Entry
 	slEntry := Entry{
-		Type:                 EntryType,
+		Type:                 entryType,
 		StatusPurpose:        StatusPurposeRevocation,
 		StatusListIndex:      "76248",
 		StatusListCredential: ts.URL,
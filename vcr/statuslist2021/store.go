@@ -37,32 +37,51 @@ import (
 // errNotFound wraps types.ErrNotFound to clarify which credential is not found
 var errNotFound = fmt.Errorf("status list: %w", types.ErrNotFound)
 
-// errUnsupportedPurpose limits current usage to 'revocation'
+// errUnsupportedPurpose is returned when the statusPurpose is not supported by the operation
 var errUnsupportedPurpose = errors.New("status list: purpose not supported")
 
 // errNotFound wraps types.ErrRevoked to clarify the source of the error
 var errRevoked = fmt.Errorf("status list: %w", types.ErrRevoked)
 
+// errSuspended wraps types.ErrSuspended to clarify the source of the error
+var errSuspended = fmt.Errorf("status list: %w", types.ErrSuspended)
+
+// errNotSuspended wraps types.ErrNotSuspended to clarify the source of the error
+var errNotSuspended = fmt.Errorf("status list: %w", types.ErrNotSuspended)
+
 type StatusPurpose string
 
 const (
 	StatusPurposeRevocation = "revocation"
-	statusPurposeSuspension = "suspension" // currently not supported
+	StatusPurposeSuspension = "suspension"
 )
 
 // StatusList2021Issuer keeps track of the number of credentials with a credential status per issuer, and allows revoking
-// them by setting the relevant bit on the StatusList.
+// or suspending them by setting the relevant bit on the StatusList.
 // Individual statuses should be derived from the StatusList2021Credential(s), not inspected here.
+// An issuer can publish both StatusList2021 and BitstringStatusList lists; every page contains a single type of list with a single purpose.
 type StatusList2021Issuer interface {
-	// CredentialSubject creates a CredentialSubject to incorporate in a StatusList2021Credential issued by issuer.
+	// CredentialSubject creates a CredentialSubject to incorporate in a StatusList2021Credential or BitstringStatusListCredential issued by issuer.
+	// The credentialSubject.type indicates the type of status list credential the page belongs to.
 	CredentialSubject(ctx context.Context, issuer did.DID, page int) (*CredentialSubject, error)
-	// Create a StatusList2021Entry that can be added to the credentialStatus of a VC.
+	// Create a StatusList2021Entry or BitstringStatusListEntry (depending on listType) with the given purpose ('revocation' or 'suspension')
+	// that can be added to the credentialStatus of a VC.
 	// The corresponding credential will have a gap in the bitstring if the returned entry does not make it into a credential.
-	Create(ctx context.Context, issuer did.DID, purpose StatusPurpose) (*Entry, error)
-	// Revoke by adding StatusList2021Entry to the list of revocations.
+	Create(ctx context.Context, issuer did.DID, purpose StatusPurpose, listType StatusListType) (*Entry, error)
+	// Revoke by adding StatusList2021Entry or BitstringStatusListEntry to the list of revocations.
 	// The credentialID is only used to allow reverse search of revocations, its issuer is NOT compared to the entry issuer.
 	// Returns types.ErrRevoked if already revoked, or types.ErrNotFound when the entry.StatusListCredential is unknown.
 	Revoke(ctx context.Context, credentialID ssi.URI, entry Entry) error
+	// Suspend by adding a StatusList2021Entry or BitstringStatusListEntry with statusPurpose 'suspension' to the list of suspensions.
+	// The credentialID is only used to allow reverse search of suspensions, its issuer is NOT compared to the entry issuer.
+	// Returns types.ErrSuspended if already suspended, or types.ErrNotFound when the entry.StatusListCredential is unknown.
+	Suspend(ctx context.Context, credentialID ssi.URI, entry Entry) error
+	// Unsuspend removes a StatusList2021Entry or BitstringStatusListEntry with statusPurpose 'suspension' from the list of suspensions.
+	// Returns types.ErrNotSuspended if the entry is not suspended, or types.ErrNotFound when the entry.StatusListCredential is unknown.
+	Unsuspend(ctx context.Context, entry Entry) error
+	// IsSet returns true if the entry is revoked or suspended (depending on its statusPurpose) on its status list.
+	// Returns types.ErrNotFound when the entry.StatusListCredential is unknown.
+	IsSet(ctx context.Context, entry Entry) (bool, error)
 }
 
 func (s statusListCredentialRecord) TableName() string {
@@ -78,9 +97,13 @@ type statusListCredentialRecord struct {
 	Issuer string
 	// Page number corresponding to this SubjectID.
 	Page int
+	// ListType is the type of status list published on this page.
+	ListType StatusListType
+	// StatusPurpose of the status list published on this page, either 'revocation' or 'suspension'.
+	StatusPurpose StatusPurpose
 	// LastIssuedIndex on this page. Range:  0 <= StatusListIndex < statuslist2021.maxBitstringIndex
 	LastIssuedIndex int
-	// Revocations list all revocations for this SubjectID
+	// Revocations list all revocations (or suspensions, depending on StatusPurpose) for this SubjectID
 	Revocations []revocationRecord `gorm:"foreignKey:StatusListCredential;references:SubjectID"`
 }
 
@@ -88,7 +111,8 @@ func (s revocationRecord) TableName() string {
 	return "status_list_status"
 }
 
-// revocationRecord is created when a statusList entry has been revoked.
+// revocationRecord is created when a statusList entry has been revoked or suspended.
+// Suspensions are removed again when the entry is unsuspended.
 type revocationRecord struct {
 	// StatusListCredential is the credentialSubject.ID this revocation belongs to. Example https://example.com/iam/id/statuslist/1
 	StatusListCredential string `gorm:"primaryKey"`
@@ -143,7 +167,11 @@ func (s *sqlStore) CredentialSubject(ctx context.Context, issuer did.DID, page i
 			return nil, err
 		}
 	}
-	encodedList, err := compress(*bitstring)
+	compressFn := compress
+	if statuslist.ListType == BitstringStatusListType {
+		compressFn = compressMultibase
+	}
+	encodedList, err := compressFn(*bitstring)
 	if err != nil {
 		// can't happen
 		return nil, err
@@ -152,16 +180,24 @@ func (s *sqlStore) CredentialSubject(ctx context.Context, issuer did.DID, page i
 	// return credential subject
 	return &CredentialSubject{
 		Id:            statusListCredential,
-		Type:          CredentialSubjectType,
-		StatusPurpose: StatusPurposeRevocation,
+		Type:          string(statuslist.ListType),
+		StatusPurpose: string(statuslist.StatusPurpose),
 		EncodedList:   encodedList,
 	}, nil
 }
 
-func (s *sqlStore) Create(ctx context.Context, issuer did.DID, purpose StatusPurpose) (*Entry, error) {
-	if purpose != StatusPurposeRevocation {
+func (s *sqlStore) Create(ctx context.Context, issuer did.DID, purpose StatusPurpose, listType StatusListType) (*Entry, error) {
+	if purpose != StatusPurposeRevocation && purpose != StatusPurposeSuspension {
 		return nil, errUnsupportedPurpose
 	}
+	entryType := EntryType
+	switch listType {
+	case StatusList2021Type:
+	case BitstringStatusListType:
+		entryType = BitstringEntryType
+	default:
+		return nil, fmt.Errorf("status list: unsupported type: %s", listType)
+	}
 
 	if _, isSQLite := s.db.Config.Dialector.(*sqlite.Dialector); isSQLite {
 		// SQLite does not support SELECT FOR UPDATE and allows only 1 active write transaction at any time,
@@ -177,27 +213,29 @@ func (s *sqlStore) Create(ctx context.Context, issuer did.DID, purpose StatusPur
 	var credentialRecord statusListCredentialRecord
 	for {
 		err := s.DB(ctx).Transaction(func(tx *gorm.DB) error {
-			// lock issuer's last page; iff it exists
+			// lock issuer's last page of the list type and purpose; iff it exists
 			//
 			// SELECT *
 			// FROM status_list_credential
-			// WHERE issuer = 'issuer.String()'
+			// WHERE issuer = 'issuer.String()' AND list_type = 'listType' AND status_purpose = 'purpose'
 			// ORDER BY page DESC
 			// LIMIT 1
 			// FOR UPDATE;
 			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-				Order("page").
-				Last(&credentialRecord, "issuer = ?", issuer.String()).
+				Order("page DESC").
+				First(&credentialRecord, "issuer = ? AND list_type = ? AND status_purpose = ?", issuer.String(), listType, purpose).
 				Error
 			if err != nil {
 				if !errors.Is(err, gorm.ErrRecordNotFound) {
 					return err
 				}
 
-				// first time issuer; prepare to create a new Page / StatusListCredential
+				// first time issuer for this list type and purpose; prepare to create a new Page / StatusListCredential
 				credentialRecord = statusListCredentialRecord{
 					Issuer:          issuer.String(),
-					LastIssuedIndex: maxBitstringIndex, // this will be incremented to move to page 1
+					ListType:        listType,
+					StatusPurpose:   purpose,
+					LastIssuedIndex: maxBitstringIndex, // this will be incremented to move to a new page
 					Page:            0,
 				}
 			}
@@ -208,7 +246,22 @@ func (s *sqlStore) Create(ctx context.Context, issuer did.DID, purpose StatusPur
 			// create new page (statusListCredential) if current is full
 			if credentialRecord.LastIssuedIndex > maxBitstringIndex {
 				credentialRecord.LastIssuedIndex = 0
-				credentialRecord.Page++
+				// pages are numbered per issuer, regardless of list type and purpose, since they share the same URL space.
+				//
+				// SELECT MAX(page)
+				// FROM status_list_credential
+				// WHERE issuer = 'issuer.String()';
+				var lastPage *int
+				if err = tx.Model(&statusListCredentialRecord{}).
+					Select("MAX(page)").
+					Where("issuer = ?", issuer.String()).
+					Scan(&lastPage).Error; err != nil {
+					return err
+				}
+				credentialRecord.Page = 1
+				if lastPage != nil {
+					credentialRecord.Page = *lastPage + 1
+				}
 
 				// set statusListCredential with correct page
 				credentialRecord.SubjectID, err = toStatusListCredential(issuer, credentialRecord.Page)
@@ -219,8 +272,8 @@ func (s *sqlStore) Create(ctx context.Context, issuer did.DID, purpose StatusPur
 				// add new statusListCredential
 				// this is not protected by the SELECT FOR UPDATE clause, so can fail with gorm.ErrDuplicatedKey
 				//
-				// INSERT INTO  status_list_credential (id, issuer, page, last_issued_index)
-				// VALUES ('credentialRecord.ID', 'credentialRecord.Issuer', 'credentialRecord.Page', 0);
+				// INSERT INTO  status_list_credential (id, issuer, page, list_type, status_purpose, last_issued_index)
+				// VALUES ('credentialRecord.ID', 'credentialRecord.Issuer', 'credentialRecord.Page', 'credentialRecord.ListType', 'credentialRecord.StatusPurpose', 0);
 				return tx.Create(credentialRecord).Error
 			}
 
@@ -247,42 +300,70 @@ func (s *sqlStore) Create(ctx context.Context, issuer did.DID, purpose StatusPur
 
 	return &Entry{
 		ID:                   fmt.Sprintf("%s#%d", credentialRecord.SubjectID, credentialRecord.LastIssuedIndex),
-		Type:                 EntryType,
-		StatusPurpose:        StatusPurposeRevocation,
+		Type:                 entryType,
+		StatusPurpose:        string(purpose),
 		StatusListIndex:      strconv.Itoa(credentialRecord.LastIssuedIndex),
 		StatusListCredential: credentialRecord.SubjectID,
 	}, nil
 }
 
 func (s *sqlStore) Revoke(ctx context.Context, credentialID ssi.URI, entry Entry) error {
-	// parse StatusListIndex
-	statusListIndex, err := strconv.Atoi(entry.StatusListIndex)
+	return s.addStatus(ctx, credentialID, entry, StatusPurposeRevocation, errRevoked)
+}
+
+func (s *sqlStore) Suspend(ctx context.Context, credentialID ssi.URI, entry Entry) error {
+	return s.addStatus(ctx, credentialID, entry, StatusPurposeSuspension, errSuspended)
+}
+
+func (s *sqlStore) Unsuspend(ctx context.Context, entry Entry) error {
+	statuslist, statusListIndex, err := s.findStatus(ctx, entry, StatusPurposeSuspension)
 	if err != nil {
 		return err
 	}
 
-	// validate StatusPurpose
-	if entry.StatusPurpose != StatusPurposeRevocation {
-		return errUnsupportedPurpose
+	// DELETE FROM status_list_status
+	// WHERE status_list_credential = 'statuslist.ID' AND status_list_index = 'statusListIndex';
+	result := s.DB(ctx).
+		Where("status_list_credential = ? AND status_list_index = ?", statuslist.SubjectID, statusListIndex).
+		Delete(&revocationRecord{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errNotSuspended
 	}
 
-	// check if StatusList2021Credential is managed by this node
-	var statuslist statusListCredentialRecord
-	// SELECT * FROM status_list_credential WHERE id = 'entry.StatusListCredential' LIMIT 1;
-	err = s.DB(ctx).First(&statuslist, "subject_id = ?", entry.StatusListCredential).Error
+	// successful unsuspension
+	return nil
+}
+
+func (s *sqlStore) IsSet(ctx context.Context, entry Entry) (bool, error) {
+	statuslist, statusListIndex, err := s.findStatus(ctx, entry, StatusPurpose(entry.StatusPurpose))
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errNotFound // statusListCredential not managed by this node
-		}
-		return err
+		return false, err
 	}
 
-	// validate StatusListIndex
-	if statusListIndex < 0 || statusListIndex > statuslist.LastIssuedIndex {
-		return ErrIndexNotInBitstring
+	// SELECT count(*) FROM status_list_status
+	// WHERE status_list_credential = 'statuslist.ID' AND status_list_index = 'statusListIndex';
+	var count int64
+	err = s.DB(ctx).Model(&revocationRecord{}).
+		Where("status_list_credential = ? AND status_list_index = ?", statuslist.SubjectID, statusListIndex).
+		Count(&count).Error
+	if err != nil {
+		return false, err
 	}
+	return count > 0, nil
+}
 
-	// revoke
+// addStatus sets the status of the entry on its status list, which must have the given purpose.
+// errAlreadySet is returned if the status was already set.
+func (s *sqlStore) addStatus(ctx context.Context, credentialID ssi.URI, entry Entry, purpose StatusPurpose, errAlreadySet error) error {
+	statuslist, statusListIndex, err := s.findStatus(ctx, entry, purpose)
+	if err != nil {
+		return err
+	}
+
+	// revoke or suspend
 	revocation := revocationRecord{
 		StatusListCredential: statuslist.SubjectID,
 		StatusListIndex:      statusListIndex,
@@ -293,15 +374,55 @@ func (s *sqlStore) Revoke(ctx context.Context, credentialID ssi.URI, entry Entry
 	err = s.DB(ctx).Create(&revocation).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return errRevoked // already revoked
+			return errAlreadySet // already revoked or suspended
 		}
 		return err
 	}
 
-	// successful revocation
+	// successful revocation or suspension
 	return nil
 }
 
+// findStatus validates that the entry is listed on a status list with the given purpose that is managed by this node.
+// It returns the status list and the parsed StatusListIndex of the entry.
+func (s *sqlStore) findStatus(ctx context.Context, entry Entry, purpose StatusPurpose) (*statusListCredentialRecord, int, error) {
+	// parse StatusListIndex
+	statusListIndex, err := strconv.Atoi(entry.StatusListIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// validate StatusPurpose
+	if entry.StatusPurpose != string(purpose) {
+		return nil, 0, errUnsupportedPurpose
+	}
+
+	// check if StatusList2021Credential is managed by this node
+	var statuslist statusListCredentialRecord
+	// SELECT * FROM status_list_credential WHERE id = 'entry.StatusListCredential' LIMIT 1;
+	err = s.DB(ctx).First(&statuslist, "subject_id = ?", entry.StatusListCredential).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errNotFound // statusListCredential not managed by this node
+		}
+		return nil, 0, err
+	}
+
+	// validate entry type and purpose match the status list
+	if (statuslist.ListType == BitstringStatusListType) != (entry.Type == BitstringEntryType) {
+		return nil, 0, fmt.Errorf("status list: entry type '%s' does not match status list type '%s'", entry.Type, statuslist.ListType)
+	}
+	if statuslist.StatusPurpose != purpose {
+		return nil, 0, fmt.Errorf("status list: entry purpose '%s' does not match status list purpose '%s'", entry.StatusPurpose, statuslist.StatusPurpose)
+	}
+
+	// validate StatusListIndex
+	if statusListIndex < 0 || statusListIndex > statuslist.LastIssuedIndex {
+		return nil, 0, ErrIndexNotInBitstring
+	}
+	return &statuslist, statusListIndex, nil
+}
+
 func toStatusListCredential(issuer did.DID, page int) (string, error) {
 	switch issuer.Method {
 	case "web":
@@ -65,7 +65,7 @@ func TestSqlStore_Create(t *testing.T) {
 		t.Run("new issuer", func(t *testing.T) {
 			statusListCredential, _ := toStatusListCredential(aliceDID, 1)
 
-			entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation)
+			entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation, StatusList2021Type)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -79,7 +79,7 @@ func TestSqlStore_Create(t *testing.T) {
 		t.Run("second entry", func(t *testing.T) {
 			statusListCredential, _ := toStatusListCredential(aliceDID, 1)
 
-			entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation)
+			entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation, StatusList2021Type)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -97,7 +97,7 @@ func TestSqlStore_Create(t *testing.T) {
 				Update("last_issued_index", maxBitstringIndex)
 			statusListCredential, _ = toStatusListCredential(aliceDID, 2) // now expect page 2 to be used
 
-			entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation)
+			entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation, StatusList2021Type)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -107,7 +107,7 @@ func TestSqlStore_Create(t *testing.T) {
 			assert.Equal(t, fmt.Sprintf("%s#0", statusListCredential), entry.ID)
 		})
 		t.Run("second issuer", func(t *testing.T) {
-			entry, err = s.Create(nil, bobDID, StatusPurposeRevocation)
+			entry, err = s.Create(nil, bobDID, StatusPurposeRevocation, StatusList2021Type)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -115,14 +115,52 @@ func TestSqlStore_Create(t *testing.T) {
 			// alice#1, alice#2, bob#1; fails if only part of the 'ok' block is executed
 			assert.Equal(t, int64(3), s.db.Find(&[]statusListCredentialRecord{}).RowsAffected)
 		})
+		t.Run("BitstringStatusList gets its own page", func(t *testing.T) {
+			statusListCredential, _ := toStatusListCredential(aliceDID, 3) // alice#1 and alice#2 are StatusList2021 pages
+
+			entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation, BitstringStatusListType)
+
+			assert.NoError(t, err)
+			require.NotNil(t, entry)
+			assert.Equal(t, statusListCredential, entry.StatusListCredential)
+			assert.Equal(t, "0", entry.StatusListIndex)
+			assert.Equal(t, BitstringEntryType, entry.Type)
+
+			t.Run("StatusList2021 continues on its last page", func(t *testing.T) {
+				statusListCredential, _ := toStatusListCredential(aliceDID, 2)
+
+				entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation, StatusList2021Type)
+
+				assert.NoError(t, err)
+				require.NotNil(t, entry)
+				assert.Equal(t, statusListCredential, entry.StatusListCredential)
+				assert.Equal(t, "1", entry.StatusListIndex)
+			})
+		})
+		t.Run("suspension list gets its own page", func(t *testing.T) {
+			statusListCredential, _ := toStatusListCredential(aliceDID, 4) // alice#3 is the BitstringStatusList revocation page
+
+			entry, err = s.Create(nil, aliceDID, StatusPurposeSuspension, BitstringStatusListType)
+
+			assert.NoError(t, err)
+			require.NotNil(t, entry)
+			assert.Equal(t, statusListCredential, entry.StatusListCredential)
+			assert.Equal(t, "0", entry.StatusListIndex)
+			assert.Equal(t, StatusPurposeSuspension, entry.StatusPurpose)
+		})
+	})
+	t.Run("error - unsupported list type", func(t *testing.T) {
+		entry, err = s.Create(nil, aliceDID, StatusPurposeRevocation, "SomethingElse")
+		assert.EqualError(t, err, "status list: unsupported type: SomethingElse")
+		assert.Nil(t, entry)
 	})
 	t.Run("error - unsupported purpose", func(t *testing.T) {
-		entry, err = s.Create(nil, aliceDID, statusPurposeSuspension)
+		entry, err = s.Create(nil, aliceDID, "refresh", StatusList2021Type)
 		assert.ErrorIs(t, err, errUnsupportedPurpose)
 		assert.Nil(t, entry)
 	})
 	t.Run("error - unsupported DID method", func(t *testing.T) {
-		entry, err = s.Create(nil, did.MustParseDID("did:nuts:123"), StatusPurposeRevocation)
+		entry, err = s.Create(nil, did.MustParseDID("did:nuts:123"), StatusPurposeRevocation, StatusList2021Type)
 		assert.EqualError(t, err, "status list: unsupported DID method: nuts")
 		assert.Nil(t, entry)
 	})
@@ -138,7 +176,7 @@ func TestSqlStore_Create(t *testing.T) {
 			worker := func(dids <-chan did.DID, done chan<- struct{}) {
 				defer close(done) // senders close
 				for issuer := range dids {
-					_, _ = store.Create(nil, issuer, StatusPurposeRevocation)
+					_, _ = store.Create(nil, issuer, StatusPurposeRevocation, StatusList2021Type)
 					done <- struct{}{}
 				}
 			}
@@ -205,7 +243,7 @@ func TestSqlStore_Revoke(t *testing.T) {
 	require.NoError(t, err)
 	storage.AddDIDtoSQLDB(t, s.db, aliceDID, bobDID)
 
-	entryP, err := s.Create(nil, aliceDID, StatusPurposeRevocation)
+	entryP, err := s.Create(nil, aliceDID, StatusPurposeRevocation, StatusList2021Type)
 	require.NoError(t, err)
 	entry := *entryP
 
@@ -227,9 +265,16 @@ func TestSqlStore_Revoke(t *testing.T) {
 	})
 	t.Run("error - unsupportedPurpose", func(t *testing.T) {
 		cEntry := entry
-		cEntry.StatusPurpose = statusPurposeSuspension
+		cEntry.StatusPurpose = StatusPurposeSuspension
 		assert.ErrorIs(t, s.Revoke(nil, ssi.URI{}, cEntry), errUnsupportedPurpose)
 	})
+	t.Run("error - entry purpose does not match status list", func(t *testing.T) {
+		suspensionEntry, err := s.Create(nil, bobDID, StatusPurposeSuspension, StatusList2021Type)
+		require.NoError(t, err)
+		cEntry := *suspensionEntry
+		cEntry.StatusPurpose = StatusPurposeRevocation
+		assert.EqualError(t, s.Revoke(nil, ssi.URI{}, cEntry), "status list: entry purpose 'revocation' does not match status list purpose 'suspension'")
+	})
 	t.Run("error - ErrNotFound", func(t *testing.T) {
 		cEntry := entry
 		cEntry.StatusListCredential += "unknown"
@@ -245,6 +290,125 @@ func TestSqlStore_Revoke(t *testing.T) {
 		cEntry.StatusListIndex = "10"
 		assert.ErrorIs(t, s.Revoke(nil, ssi.URI{}, cEntry), ErrIndexNotInBitstring)
 	})
+	t.Run("error - entry type does not match status list", func(t *testing.T) {
+		cEntry := entry
+		cEntry.Type = BitstringEntryType
+		assert.EqualError(t, s.Revoke(nil, ssi.URI{}, cEntry), "status list: entry type 'BitstringStatusListEntry' does not match status list type 'StatusList2021'")
+	})
+}
+
+func TestSqlStore_Suspend(t *testing.T) {
+	s, err := NewStatusListStore(storage.NewTestStorageEngine(t).GetSQLDatabase())
+	require.NoError(t, err)
+	storage.AddDIDtoSQLDB(t, s.db, aliceDID, bobDID)
+
+	entryP, err := s.Create(nil, aliceDID, StatusPurposeSuspension, StatusList2021Type)
+	require.NoError(t, err)
+	entry := *entryP
+
+	t.Run("ok", func(t *testing.T) {
+		credentialID := bobDID.URI() // not alice
+		assert.NoError(t, s.Suspend(nil, credentialID, entry))
+		// confirm it is in the DB
+		var suspension revocationRecord
+		err = s.db.Where(&revocationRecord{
+			StatusListCredential: entry.StatusListCredential,
+			StatusListIndex:      0,
+			CredentialID:         credentialID.String(),
+		}).First(&suspension).Error
+		assert.NoError(t, err)
+	})
+	t.Run("error - ErrSuspended", func(t *testing.T) {
+		assert.ErrorIs(t, s.Suspend(nil, ssi.URI{}, entry), types.ErrSuspended)
+	})
+	t.Run("error - unsupportedPurpose", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusPurpose = StatusPurposeRevocation
+		assert.ErrorIs(t, s.Suspend(nil, ssi.URI{}, cEntry), errUnsupportedPurpose)
+	})
+	t.Run("error - ErrNotFound", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusListCredential += "unknown"
+		assert.ErrorIs(t, s.Suspend(nil, ssi.URI{}, cEntry), types.ErrNotFound)
+	})
+}
+
+func TestSqlStore_Unsuspend(t *testing.T) {
+	s, err := NewStatusListStore(storage.NewTestStorageEngine(t).GetSQLDatabase())
+	require.NoError(t, err)
+	storage.AddDIDtoSQLDB(t, s.db, aliceDID, bobDID)
+
+	entryP, err := s.Create(nil, aliceDID, StatusPurposeSuspension, StatusList2021Type)
+	require.NoError(t, err)
+	entry := *entryP
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, s.Suspend(nil, ssi.URI{}, entry))
+
+		assert.NoError(t, s.Unsuspend(nil, entry))
+
+		// confirm it is removed from the DB
+		assert.Equal(t, int64(0), s.db.Find(&[]revocationRecord{}).RowsAffected)
+		t.Run("can be suspended again", func(t *testing.T) {
+			assert.NoError(t, s.Suspend(nil, ssi.URI{}, entry))
+			assert.NoError(t, s.Unsuspend(nil, entry))
+		})
+	})
+	t.Run("error - ErrNotSuspended", func(t *testing.T) {
+		assert.ErrorIs(t, s.Unsuspend(nil, entry), types.ErrNotSuspended)
+	})
+	t.Run("error - unsupportedPurpose", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusPurpose = StatusPurposeRevocation
+		assert.ErrorIs(t, s.Unsuspend(nil, cEntry), errUnsupportedPurpose)
+	})
+	t.Run("error - ErrNotFound", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusListCredential += "unknown"
+		assert.ErrorIs(t, s.Unsuspend(nil, cEntry), types.ErrNotFound)
+	})
+}
+
+func TestSqlStore_IsSet(t *testing.T) {
+	s, err := NewStatusListStore(storage.NewTestStorageEngine(t).GetSQLDatabase())
+	require.NoError(t, err)
+	storage.AddDIDtoSQLDB(t, s.db, aliceDID)
+
+	revocationEntry, err := s.Create(nil, aliceDID, StatusPurposeRevocation, StatusList2021Type)
+	require.NoError(t, err)
+	suspensionEntry, err := s.Create(nil, aliceDID, StatusPurposeSuspension, BitstringStatusListType)
+	require.NoError(t, err)
+
+	t.Run("revocation", func(t *testing.T) {
+		isSet, err := s.IsSet(nil, *revocationEntry)
+		require.NoError(t, err)
+		assert.False(t, isSet)
+
+		require.NoError(t, s.Revoke(nil, ssi.URI{}, *revocationEntry))
+
+		isSet, err = s.IsSet(nil, *revocationEntry)
+		require.NoError(t, err)
+		assert.True(t, isSet)
+	})
+	t.Run("suspension", func(t *testing.T) {
+		require.NoError(t, s.Suspend(nil, ssi.URI{}, *suspensionEntry))
+
+		isSet, err := s.IsSet(nil, *suspensionEntry)
+		require.NoError(t, err)
+		assert.True(t, isSet)
+
+		require.NoError(t, s.Unsuspend(nil, *suspensionEntry))
+
+		isSet, err = s.IsSet(nil, *suspensionEntry)
+		require.NoError(t, err)
+		assert.False(t, isSet)
+	})
+	t.Run("error - ErrNotFound", func(t *testing.T) {
+		cEntry := *revocationEntry
+		cEntry.StatusListCredential += "unknown"
+		_, err := s.IsSet(nil, cEntry)
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
 }
 
 func TestSqlStore_CredentialSubject(t *testing.T) {
@@ -253,7 +417,7 @@ func TestSqlStore_CredentialSubject(t *testing.T) {
 	storage.AddDIDtoSQLDB(t, s.db, aliceDID, bobDID)
 
 	// create status list credential for alice
-	entryP, err := s.Create(nil, aliceDID, StatusPurposeRevocation)
+	entryP, err := s.Create(nil, aliceDID, StatusPurposeRevocation, StatusList2021Type)
 	require.NoError(t, err)
 	entry := *entryP
 
@@ -284,8 +448,38 @@ func TestSqlStore_CredentialSubject(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, bs)
 	})
-	t.Run("error - ErrNotFound", func(t *testing.T) {
+	t.Run("ok - BitstringStatusList", func(t *testing.T) {
+		entryP, err := s.Create(nil, bobDID, StatusPurposeRevocation, BitstringStatusListType)
+		require.NoError(t, err)
+		require.NoError(t, s.Revoke(nil, ssi.URI{}, *entryP))
+
+		cs, err := s.CredentialSubject(nil, bobDID, 1)
+
+		assert.NoError(t, err)
+		require.NotNil(t, cs)
+		assert.Equal(t, BitstringCredentialSubjectType, cs.Type)
+		bs, err := expandMultibase(cs.EncodedList)
+		require.NoError(t, err)
+		revoked, _ := bs.bit(0)
+		assert.True(t, revoked)
+	})
+	t.Run("ok - suspension list", func(t *testing.T) {
+		entryP, err := s.Create(nil, aliceDID, StatusPurposeSuspension, StatusList2021Type)
+		require.NoError(t, err)
+		require.NoError(t, s.Suspend(nil, ssi.URI{}, *entryP))
+
 		cs, err := s.CredentialSubject(nil, aliceDID, 2)
+
+		assert.NoError(t, err)
+		require.NotNil(t, cs)
+		assert.Equal(t, StatusPurposeSuspension, cs.StatusPurpose)
+		bs, err := expand(cs.EncodedList)
+		require.NoError(t, err)
+		suspended, _ := bs.bit(0)
+		assert.True(t, suspended)
+	})
+	t.Run("error - ErrNotFound", func(t *testing.T) {
+		cs, err := s.CredentialSubject(nil, aliceDID, 3)
 		assert.ErrorIs(t, err, types.ErrNotFound)
 		require.Nil(t, cs)
 	})
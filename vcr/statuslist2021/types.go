@@ -19,6 +19,8 @@
 package statuslist2021
 
 import (
+	"fmt"
+
 	"github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 )
@@ -32,15 +34,48 @@ const (
 	EntryType = "StatusList2021Entry"
 )
 
+const (
+	// BitstringCredentialType is the type of BitstringStatusListCredential
+	BitstringCredentialType = "BitstringStatusListCredential"
+	// BitstringCredentialSubjectType is the credentialSubject.type in a BitstringStatusListCredential
+	BitstringCredentialSubjectType = "BitstringStatusList"
+	// BitstringEntryType is the credentialStatus.type that lists the entry of that credential on a BitstringStatusList
+	BitstringEntryType = "BitstringStatusListEntry"
+)
+
+// StatusListType is the type of status list an issuer publishes credential statuses on.
+// Its value equals the credentialSubject.type of the status list credential.
+type StatusListType string
+
+const (
+	// StatusList2021Type selects the StatusList2021 specification (https://www.w3.org/TR/2023/WD-vc-status-list-20230427/)
+	StatusList2021Type StatusListType = CredentialSubjectType
+	// BitstringStatusListType selects the Bitstring Status List specification (https://www.w3.org/TR/vc-bitstring-status-list/)
+	BitstringStatusListType StatusListType = BitstringCredentialSubjectType
+)
+
+// maxStatusSize is the largest supported statusSize of a BitstringStatusListEntry.
+const maxStatusSize = 16
+
+// minBitstringEntries is the minimum number of entries in a BitstringStatusList, to provide herd privacy.
+const minBitstringEntries = defaultBitstringLengthInBytes * 8
+
 var ContextURI = ssi.MustParseURI(jsonld.W3cStatusList2021Context)
 var credentialTypeURI = ssi.MustParseURI(CredentialType)
 
+// BitstringContextURI is the JSON-LD context containing the Bitstring Status List terms: the W3C VC data model v2 context.
+var BitstringContextURI = ssi.MustParseURI(jsonld.BitstringStatusListContext)
+
+// VCContextV2URI is the JSON-LD context of the W3C VC data model v2.
+var VCContextV2URI = ssi.MustParseURI(jsonld.W3cVcContextV2)
+var bitstringCredentialTypeURI = ssi.MustParseURI(BitstringCredentialType)
+
 // Entry is the "credentialStatus" property used by issuers to enable VerifiableCredential status information.
 type Entry struct {
 	// ID is expected to be a URL that identifies the status information associated with the verifiable credential.
 	// It MUST NOT be the URL for the status list, which is in StatusListCredential.
 	ID string `json:"id,omitempty"`
-	// Type MUST be "StatusList2021Entry"
+	// Type MUST be "StatusList2021Entry" or "BitstringStatusListEntry"
 	Type string `json:"type,omitempty"`
 	// StatusPurpose indicates what it means if the VerifiableCredential is on the list.
 	// The value is arbitrary, with predefined values `revocation` and `suspension`.
@@ -52,16 +87,48 @@ type Entry struct {
 	// The statusListCredential property MUST be a URL to a verifiable credential.
 	// When the URL is dereferenced, the resulting verifiable credential MUST have type property that includes the "StatusList2021Credential" value.
 	StatusListCredential string `json:"statusListCredential,omitempty"`
+	// StatusSize is the size of the status entry in bits, defaults to 1. Only used by BitstringStatusListEntry.
+	// If it is greater than 1, StatusMessage MUST contain a message for every possible status value.
+	StatusSize int `json:"statusSize,omitempty"`
+	// StatusMessage describes the meaning of each status value. Only used by BitstringStatusListEntry.
+	StatusMessage []StatusMessage `json:"statusMessage,omitempty"`
+	// StatusReference is a URL to material related to the status. Only used by BitstringStatusListEntry.
+	StatusReference string `json:"statusReference,omitempty"`
+}
+
+// statusSize returns the size of the status entry in bits, and checks that a statusMessage is present for every status value.
+func (e Entry) statusSize() (int, error) {
+	if e.StatusSize == 0 {
+		return 1, nil
+	}
+	if e.StatusSize < 0 || e.StatusSize > maxStatusSize {
+		return 0, fmt.Errorf("credentialStatus.statusSize must be in range [1, %d]", maxStatusSize)
+	}
+	if e.StatusSize > 1 && len(e.StatusMessage) != 1<<e.StatusSize {
+		return 0, fmt.Errorf("credentialStatus.statusMessage must contain %d messages", 1<<e.StatusSize)
+	}
+	return e.StatusSize, nil
+}
+
+// StatusMessage describes the meaning of a status value of a BitstringStatusListEntry.
+type StatusMessage struct {
+	// Status is the hexadecimal status value, e.g. "0x2"
+	Status string `json:"status"`
+	// Message describes the status
+	Message string `json:"message"`
 }
 
 type CredentialSubject struct {
 	// ID for the credential subject
 	Id string `json:"id"`
-	// Type MUST be "StatusList2021Credential"
+	// Type MUST be "StatusList2021" or "BitstringStatusList"
 	Type string `json:"type"`
 	// StatusPurpose defines the reason credentials are listed. ('revocation', 'suspension')
 	StatusPurpose string `json:"statusPurpose"`
 	// EncodedList is the GZIP-compressed [RFC1952], base-64 encoded [RFC4648] bitstring values for the associated range
 	// of verifiable credential status values. The uncompressed bitstring MUST be at least 16KB in size.
+	// For BitstringStatusList it is multibase encoded, meaning it is prefixed with 'u' (base64url without padding).
 	EncodedList string `json:"encodedList"`
+	// TTL is the time in milliseconds the status list may be cached. Only used by BitstringStatusList.
+	TTL int `json:"ttl,omitempty"`
 }
@@ -33,7 +33,7 @@ func (d defaultCredentialValidator) Validate(credential vc.VerifiableCredential)
 		return errors.New("type 'VerifiableCredential' is required")
 	}
 
-	if !credential.ContainsContext(vc.VCContextV1URI()) {
+	if !credential.ContainsContext(vc.VCContextV1URI()) && !credential.ContainsContext(VCContextV2URI) {
 		return errors.New("default context is required")
 	}
 
@@ -59,7 +59,7 @@ func (d defaultCredentialValidator) Validate(credential vc.VerifiableCredential)
 	return nil
 }
 
-// credentialValidator validates that all required fields of a StatusList2021Credential are present
+// credentialValidator validates that all required fields of a StatusList2021Credential or BitstringStatusListCredential are present
 type credentialValidator struct{}
 
 func (d credentialValidator) Validate(credential vc.VerifiableCredential) error {
@@ -67,13 +67,18 @@ func (d credentialValidator) Validate(credential vc.VerifiableCredential) error
 		return err
 	}
 
+	credentialSubjectType := CredentialSubjectType
 	{ // Credential checks
-		if !credential.ContainsContext(ContextURI) {
+		if credential.IsType(bitstringCredentialTypeURI) {
+			credentialSubjectType = BitstringCredentialSubjectType
+			if !credential.ContainsContext(BitstringContextURI) {
+				return fmt.Errorf("context '%s' is required", BitstringContextURI)
+			}
+		} else if !credential.IsType(credentialTypeURI) {
+			return fmt.Errorf("type '%s' or '%s' is required", credentialTypeURI, bitstringCredentialTypeURI)
+		} else if !credential.ContainsContext(ContextURI) {
 			return fmt.Errorf("context '%s' is required", ContextURI)
 		}
-		if !credential.IsType(credentialTypeURI) {
-			return fmt.Errorf("type '%s' is required", credentialTypeURI)
-		}
 	}
 
 	{ // CredentialSubject checks
@@ -89,8 +94,8 @@ func (d credentialValidator) Validate(credential vc.VerifiableCredential) error
 		}
 		cs := target[0]
 
-		if cs.Type != CredentialSubjectType {
-			return fmt.Errorf("credentialSubject.type '%s' is required", CredentialSubjectType)
+		if cs.Type != credentialSubjectType {
+			return fmt.Errorf("credentialSubject.type '%s' is required", credentialSubjectType)
 		}
 		if cs.StatusPurpose == "" {
 			return errors.New("credentialSubject.statusPurpose is required")
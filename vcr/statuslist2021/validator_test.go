@@ -48,7 +48,7 @@ func TestStatusList2021CredentialValidator_Validate(t *testing.T) {
 		cred := test.ValidStatusList2021Credential(t)
 		cred.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI()}
 		err := credentialValidator{}.Validate(cred)
-		assert.EqualError(t, err, "type 'StatusList2021Credential' is required")
+		assert.EqualError(t, err, "type 'StatusList2021Credential' or 'BitstringStatusListCredential' is required")
 	})
 	t.Run("error - invalid credential subject", func(t *testing.T) {
 		cred := test.ValidStatusList2021Credential(t)
@@ -87,3 +87,23 @@ func TestStatusList2021CredentialValidator_Validate(t *testing.T) {
 		assert.EqualError(t, err, "credentialSubject.encodedList is required")
 	})
 }
+
+func TestBitstringStatusListCredentialValidator_Validate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		err := credentialValidator{}.Validate(cred)
+		assert.NoError(t, err)
+	})
+	t.Run("error - missing bitstring status list context", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.Context = []ssi.URI{vc.VCContextV1URI(), ContextURI}
+		err := credentialValidator{}.Validate(cred)
+		assert.EqualError(t, err, "context 'https://www.w3.org/ns/credentials/v2' is required")
+	})
+	t.Run("error - StatusList2021 credential subject", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.CredentialSubject[0].(map[string]any)["type"] = CredentialSubjectType
+		err := credentialValidator{}.Validate(cred)
+		assert.EqualError(t, err, "credentialSubject.type 'BitstringStatusList' is required")
+	})
+}
@@ -126,3 +126,19 @@ func ValidStatusList2021Credential(t testing.TB) vc.VerifiableCredential {
 		Proof: []any{vc.Proof{}},
 	}
 }
+
+func ValidBitstringStatusListCredential(t testing.TB) vc.VerifiableCredential {
+	cred := ValidStatusList2021Credential(t)
+	// Bitstring Status List credentials use the VC data model v2
+	cred.Context = []ssi.URI{ssi.MustParseURI("https://www.w3.org/ns/credentials/v2")}
+	cred.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("BitstringStatusListCredential")}
+	cred.CredentialSubject = []any{
+		map[string]any{
+			"id":            "https://example-com/status/3#list",
+			"type":          "BitstringStatusList",
+			"statusPurpose": "revocation",
+			"encodedList":   "uH4sIAAAAAAAA_-zAsQAAAAACsNDypwqjZ2sAAAAAAAAAAAAAAAAAAACAtwUAAP__NxdfzQBAAAA", // has bit 1 set to true
+		},
+	}
+	return cred
+}
@@ -56,7 +56,7 @@ func CreateJWTPresentation(t *testing.T, subjectDID did.DID, tokenVisitor func(t
 		tokenVisitor(unsignedToken)
 	}
 	keyStore := crypto.NewMemoryCryptoInstance()
-	key, err := keyStore.New(audit.TestContext(), crypto.StringNamingFunc(subjectDID.String()))
+	key, err := keyStore.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc(subjectDID.String()))
 	require.NoError(t, err)
 	claims, err = unsignedToken.AsMap(context.Background())
 	signedToken, err := keyStore.SignJWT(audit.TestContext(), claims, headers, key.KID())
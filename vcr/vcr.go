@@ -36,6 +36,7 @@ import (
 	"net/http"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
@@ -59,6 +60,9 @@ import (
 
 const credentialsBackupShelf = "credentials"
 
+// deferredIssuancePruneInterval is the interval at which expired deferred OpenID4VCI credential issuances are discarded.
+const deferredIssuancePruneInterval = time.Hour
+
 var _ core.Migratable = (*vcr)(nil)
 
 // NewVCRInstance creates a new vcr instance with default config and empty concept registry
@@ -74,7 +78,9 @@ func NewVCRInstance(keyStore crypto.KeyStore, vdrInstance vdr.VDR,
 		eventManager:  eventManager,
 		storageClient: storageClient,
 		pkiProvider:   pkiProvider,
+		routines:      new(sync.WaitGroup),
 	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
 	return r
 }
 
@@ -97,6 +103,8 @@ type vcr struct {
 	issuerStore         issuer.Store
 	verifierStore       verifier.Store
 	walletStore         stoabs.KVStore
+	offerStore          stoabs.KVStore
+	deferredStore       stoabs.KVStore
 	jsonldManager       jsonld.JSONLD
 	eventManager        events.Event
 	storageClient       storage.Engine
@@ -107,6 +115,10 @@ type vcr struct {
 	verifierHttpClient  core.HTTPRequestDoer
 	pkiProvider         pki.Provider
 	vdrInstance         vdr.VDR
+	// ctx is used for the background routines, cancelled on Shutdown
+	ctx      context.Context
+	cancel   context.CancelFunc
+	routines *sync.WaitGroup
 }
 
 func (c *vcr) GetOpenIDIssuer(ctx context.Context, id did.DID) (issuer.OpenIDHandler, error) {
@@ -114,7 +126,8 @@ func (c *vcr) GetOpenIDIssuer(ctx context.Context, id did.DID) (issuer.OpenIDHan
 	if err != nil {
 		return nil, err
 	}
-	return issuer.NewOpenIDHandler(id, identifier, c.config.OpenID4VCI.DefinitionsDIR, c.issuerHttpClient, c.keyResolver, c.openidSessionStore)
+	return issuer.NewOpenIDHandler(id, identifier, c.config.OpenID4VCI.DefinitionsDIR, c.issuerHttpClient, c.keyResolver, c.issuerStore, c.issuer, c.openidSessionStore,
+		c.deferredStore, c.config.OpenID4VCI.Deferred.Types)
 }
 
 func (c *vcr) GetOpenIDHolder(ctx context.Context, id did.DID) (holder.OpenIDHandler, error) {
@@ -122,7 +135,12 @@ func (c *vcr) GetOpenIDHolder(ctx context.Context, id did.DID) (holder.OpenIDHan
 	if err != nil {
 		return nil, err
 	}
-	return holder.NewOpenIDHandler(id, identifier, c.walletHttpClient, c, c.keyStore, c.keyResolver), nil
+	return c.newOpenIDHolder(id, identifier), nil
+}
+
+func (c *vcr) newOpenIDHolder(id did.DID, identifier string) holder.OpenIDHandler {
+	return holder.NewOpenIDHandler(id, identifier, c.walletHttpClient, c, c.keyStore, c.keyResolver, c.openidSessionStore,
+		c.offerStore, c.config.OpenID4VCI.Consent)
 }
 
 func (c *vcr) resolveOpenID4VCIIdentifier(ctx context.Context, id did.DID) (string, error) {
@@ -198,6 +216,15 @@ func (c *vcr) Verifier() verifier.Verifier {
 func (c *vcr) Configure(config core.ServerConfig) error {
 	var err error
 
+	switch c.config.Wallet.Sweep.Action {
+	case WalletSweepActionFlag, WalletSweepActionRemove:
+	default:
+		return fmt.Errorf("invalid wallet sweep action: %s", c.config.Wallet.Sweep.Action)
+	}
+	if c.config.OpenID4VCI.Consent.Required && c.config.OpenID4VCI.Consent.Expiry <= 0 {
+		return errors.New("vcr.openid4vci.consent.expiry must be greater than 0 when consent is required")
+	}
+
 	// store config parameters for use in Start()
 	c.datadir = config.Datadir
 
@@ -262,6 +289,16 @@ func (c *vcr) Configure(config core.ServerConfig) error {
 		c.issuerHttpClient = core.NewStrictHTTPClient(config.Strictmode, c.config.OpenID4VCI.Timeout, tlsConfig)
 		c.walletHttpClient = core.NewStrictHTTPClient(config.Strictmode, c.config.OpenID4VCI.Timeout, tlsConfig)
 		c.openidSessionStore = c.storageClient.GetSessionDatabase()
+		// credential offers awaiting the holder's consent
+		c.offerStore, err = c.storageClient.GetProvider(ModuleName).GetKVStore("credential-offers", storage.PersistentStorageClass)
+		if err != nil {
+			return err
+		}
+		// credential issuances deferred until completed or denied by the issuer
+		c.deferredStore, err = c.storageClient.GetProvider(ModuleName).GetKVStore("deferred-issuances", storage.PersistentStorageClass)
+		if err != nil {
+			return err
+		}
 	}
 	status, err := statuslist2021.NewStatusListStore(c.storageClient.GetSQLDatabase())
 	if err != nil {
@@ -323,10 +360,136 @@ func (c *vcr) Start() error {
 	// start listening for new credentials
 	_ = c.ambassador.Configure()
 
-	return c.ambassador.Start()
+	if err := c.ambassador.Start(); err != nil {
+		return err
+	}
+
+	if c.config.Wallet.Sweep.Interval > 0 {
+		c.routines.Add(1)
+		go func() {
+			defer c.routines.Done()
+			c.sweepWallets(c.config.Wallet.Sweep.Interval)
+		}()
+	}
+	if c.config.OpenID4VCI.Enabled && c.config.OpenID4VCI.Deferred.Interval > 0 {
+		c.routines.Add(1)
+		go func() {
+			defer c.routines.Done()
+			c.retrieveDeferredCredentials(c.config.OpenID4VCI.Deferred.Interval)
+		}()
+	}
+	if c.config.OpenID4VCI.Enabled && len(c.config.OpenID4VCI.Deferred.Types) > 0 {
+		c.routines.Add(1)
+		go func() {
+			defer c.routines.Done()
+			c.pruneDeferredIssuances(deferredIssuancePruneInterval)
+		}()
+	}
+	return nil
+}
+
+// sweepWallets periodically checks the credentials in the wallets of the node's DIDs for expiry and revocation.
+func (c *vcr) sweepWallets(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	remove := c.config.Wallet.Sweep.Action == WalletSweepActionRemove
+	do := func() {
+		ownedDIDs, err := c.vdrInstance.ListOwned(c.ctx)
+		if err != nil {
+			log.Logger().WithError(err).Error("Failed to list owned DIDs for wallet sweep")
+			return
+		}
+		for _, ownedDID := range ownedDIDs {
+			if _, err := c.wallet.Sweep(c.ctx, ownedDID, remove); err != nil {
+				log.Logger().
+					WithError(err).
+					WithField(core.LogFieldDID, ownedDID.String()).
+					Error("Failed to sweep wallet")
+			}
+		}
+	}
+	do()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			do()
+		}
+	}
+}
+
+// retrieveDeferredCredentials periodically tries to retrieve the credentials of which issuance was deferred by the issuer,
+// for the wallets of the node's DIDs.
+func (c *vcr) retrieveDeferredCredentials(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	do := func() {
+		ownedDIDs, err := c.vdrInstance.ListOwned(c.ctx)
+		if err != nil {
+			log.Logger().WithError(err).Error("Failed to list owned DIDs for retrieving deferred credentials")
+			return
+		}
+		for _, ownedDID := range ownedDIDs {
+			// The wallet's identifier is only needed for receiving offers, not for retrieving deferred credentials.
+			if err := c.newOpenIDHolder(ownedDID, "").RetrieveDeferredCredentials(c.ctx); err != nil {
+				log.Logger().
+					WithError(err).
+					WithField(core.LogFieldDID, ownedDID.String()).
+					Error("Failed to retrieve deferred credentials")
+			}
+		}
+	}
+	do()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			do()
+		}
+	}
+}
+
+// pruneDeferredIssuances periodically discards the expired deferred OpenID4VCI credential issuances of the node's DIDs.
+func (c *vcr) pruneDeferredIssuances(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	do := func() {
+		ownedDIDs, err := c.vdrInstance.ListOwned(c.ctx)
+		if err != nil {
+			log.Logger().WithError(err).Error("Failed to list owned DIDs for pruning deferred issuances")
+			return
+		}
+		for _, ownedDID := range ownedDIDs {
+			pruned, err := issuer.PruneDeferredIssuances(c.ctx, c.deferredStore, ownedDID)
+			if err != nil {
+				log.Logger().
+					WithError(err).
+					WithField(core.LogFieldDID, ownedDID.String()).
+					Error("Failed to prune deferred issuances")
+			} else if pruned > 0 {
+				log.Logger().
+					WithField(core.LogFieldDID, ownedDID.String()).
+					Debugf("Discarded %d expired deferred issuances", pruned)
+			}
+		}
+	}
+	do()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			do()
+		}
+	}
 }
 
 func (c *vcr) Shutdown() error {
+	c.cancel()
+	c.routines.Wait()
+
 	err := c.issuerStore.Close()
 	if err != nil {
 		log.Logger().
@@ -451,6 +614,8 @@ func (c *vcr) Resolve(ID ssi.URI, resolveTime *time.Time) (*vc.VerifiableCredent
 		switch err {
 		case types.ErrRevoked:
 			return &credential, types.ErrRevoked
+		case types.ErrSuspended:
+			return &credential, types.ErrSuspended
 		case types.ErrUntrusted:
 			return &credential, types.ErrUntrusted
 		default:
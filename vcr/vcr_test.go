@@ -114,6 +114,92 @@ func TestVCR_Configure(t *testing.T) {
 }
 
 func TestVCR_Start(t *testing.T) {
+	t.Run("wallet sweeper", func(t *testing.T) {
+		testDirectory := io.TestDirectory(t)
+		ctrl := gomock.NewController(t)
+		vdrInstance := vdr.NewMockVDR(ctrl)
+		vdrInstance.EXPECT().Resolver().AnyTimes()
+		instance := NewVCRInstance(
+			nil,
+			vdrInstance,
+			network.NewTestNetworkInstance(t),
+			jsonld.NewTestJSONLDManager(t),
+			events.NewTestManager(t),
+			storage.NewTestStorageEngine(t),
+			pki.New(),
+		).(*vcr)
+		instance.config.Wallet.Sweep.Interval = time.Hour
+		instance.config.Wallet.Sweep.Action = WalletSweepActionRemove
+		require.NoError(t, instance.Configure(core.TestServerConfig(func(config *core.ServerConfig) {
+			config.Datadir = testDirectory
+		})))
+		wallet := holder.NewMockWallet(ctrl)
+		instance.wallet = wallet
+		swept := make(chan struct{})
+		vdrInstance.EXPECT().ListOwned(gomock.Any()).Return([]did.DID{did.MustParseDID("did:web:example.com")}, nil)
+		wallet.EXPECT().Sweep(gomock.Any(), did.MustParseDID("did:web:example.com"), true).DoAndReturn(func(_ context.Context, _ did.DID, _ bool) (int, error) {
+			close(swept)
+			return 0, nil
+		})
+
+		require.NoError(t, instance.Start())
+		select {
+		case <-swept:
+		case <-time.After(5 * time.Second):
+			t.Fatal("wallet was not swept")
+		}
+		require.NoError(t, instance.Shutdown())
+	})
+	t.Run("deferred credential retrieval", func(t *testing.T) {
+		testDirectory := io.TestDirectory(t)
+		ctrl := gomock.NewController(t)
+		vdrInstance := vdr.NewMockVDR(ctrl)
+		vdrInstance.EXPECT().Resolver().AnyTimes()
+		instance := NewVCRInstance(
+			nil,
+			vdrInstance,
+			network.NewTestNetworkInstance(t),
+			jsonld.NewTestJSONLDManager(t),
+			events.NewTestManager(t),
+			storage.NewTestStorageEngine(t),
+			pki.New(),
+		).(*vcr)
+		instance.config.OpenID4VCI.Enabled = true
+		instance.config.OpenID4VCI.Deferred.Interval = time.Hour
+		require.NoError(t, instance.Configure(core.TestServerConfig(func(config *core.ServerConfig) {
+			config.Datadir = testDirectory
+		})))
+		retrieved := make(chan struct{})
+		vdrInstance.EXPECT().ListOwned(gomock.Any()).DoAndReturn(func(_ context.Context) ([]did.DID, error) {
+			close(retrieved)
+			return []did.DID{did.MustParseDID("did:web:example.com")}, nil
+		})
+
+		require.NoError(t, instance.Start())
+		select {
+		case <-retrieved:
+		case <-time.After(5 * time.Second):
+			t.Fatal("deferred credentials were not retrieved")
+		}
+		require.NoError(t, instance.Shutdown())
+	})
+	t.Run("invalid wallet sweep action", func(t *testing.T) {
+		instance := NewVCRInstance(nil, nil, nil, jsonld.NewTestJSONLDManager(t), nil, storage.NewTestStorageEngine(t), pki.New()).(*vcr)
+		instance.config.Wallet.Sweep.Action = "delete"
+
+		err := instance.Configure(core.TestServerConfig())
+
+		assert.EqualError(t, err, "invalid wallet sweep action: delete")
+	})
+	t.Run("consent required without offer expiry", func(t *testing.T) {
+		instance := NewVCRInstance(nil, nil, nil, jsonld.NewTestJSONLDManager(t), nil, storage.NewTestStorageEngine(t), pki.New()).(*vcr)
+		instance.config.OpenID4VCI.Consent.Required = true
+		instance.config.OpenID4VCI.Consent.Expiry = 0
+
+		err := instance.Configure(core.TestServerConfig())
+
+		assert.EqualError(t, err, "vcr.openid4vci.consent.expiry must be greater than 0 when consent is required")
+	})
 
 	t.Run("ok", func(t *testing.T) {
 		instance := NewTestVCRInstance(t)
@@ -270,6 +356,19 @@ func TestVCR_Resolve(t *testing.T) {
 		assert.Equal(t, testVC, *vc)
 	})
 
+	t.Run("ok - suspended", func(t *testing.T) {
+		ctx := testInstance(t)
+		ctx.vcr.trustConfig.AddTrust(ssi.MustParseURI("NutsOrganizationCredential"), testVC.Issuer)
+		mockVerifier := verifier.NewMockVerifier(ctx.ctrl)
+		ctx.vcr.verifier = mockVerifier
+		mockVerifier.EXPECT().Verify(testVC, false, false, gomock.Any()).Return(vcrTypes.ErrSuspended)
+
+		vc, err := ctx.vcr.Resolve(*testVC.ID, nil)
+
+		assert.Equal(t, vcrTypes.ErrSuspended, err)
+		assert.Equal(t, testVC, *vc)
+	})
+
 	t.Run("ok - untrusted", func(t *testing.T) {
 		ctx := testInstance(t)
 		ctx.vcr.trustConfig.RemoveTrust(testVC.Type[0], testVC.Issuer)
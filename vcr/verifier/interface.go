@@ -33,13 +33,15 @@ import (
 type Verifier interface {
 	// Verify checks credential on full correctness. It checks:
 	// validity of the signature (optional)
-	// if it has been revoked
+	// if it has been revoked (types.ErrRevoked) or suspended (types.ErrSuspended)
 	// if the issuer is registered as trusted (optional)
 	Verify(credential vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error
 	// VerifySignature checks that the signature on the verifiable credential is correct and valid at the given time and nothing else
 	VerifySignature(credentialToVerify vc.VerifiableCredential, at *time.Time) error
-	// IsRevoked checks if the credential is revoked
-	IsRevoked(credentialID ssi.URI) (bool, error)
+	// IsRevoked checks if the credential is revoked, either by a revocation published on the network or through its credentialStatus,
+	// and if it is suspended through its credentialStatus. A revoked credential is never reported as suspended.
+	// It only returns an error if the revocation status couldn't be looked up.
+	IsRevoked(credential vc.VerifiableCredential) (revoked bool, suspended bool, err error)
 	// GetRevocation returns the first revocation by credential ID
 	// Returns an ErrNotFound when the revocation is not in the store
 	GetRevocation(id ssi.URI) (*credential.Revocation, error)
@@ -59,18 +59,19 @@ func (mr *MockVerifierMockRecorder) GetRevocation(id any) *gomock.Call {
 }
 
 // IsRevoked mocks base method.
-func (m *MockVerifier) IsRevoked(credentialID ssi.URI) (bool, error) {
+func (m *MockVerifier) IsRevoked(credential vc.VerifiableCredential) (bool, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsRevoked", credentialID)
+	ret := m.ctrl.Call(m, "IsRevoked", credential)
 	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // IsRevoked indicates an expected call of IsRevoked.
-func (mr *MockVerifierMockRecorder) IsRevoked(credentialID any) *gomock.Call {
+func (mr *MockVerifierMockRecorder) IsRevoked(credential any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockVerifier)(nil).IsRevoked), credentialID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockVerifier)(nil).IsRevoked), credential)
 }
 
 // RegisterRevocation mocks base method.
@@ -30,6 +30,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
@@ -43,6 +44,10 @@ type signatureVerifier struct {
 
 // VerifySignature checks if the signature on a VP is valid at a given time
 func (sv *signatureVerifier) VerifySignature(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error {
+	// SD-JWT VCs are represented as JSON-LD credentials, but signed as JWT
+	if sdjwt.IsCredential(credentialToVerify) {
+		return sv.sdJWTSignature(credentialToVerify, validateAt)
+	}
 	switch credentialToVerify.Format() {
 	case vc.JSONLDCredentialProofFormat:
 		return sv.jsonldProof(credentialToVerify, credentialToVerify.Issuer.String(), validateAt)
@@ -132,6 +137,89 @@ func (sv *signatureVerifier) jwtSignature(jwtDocumentToVerify string, issuer str
 	return nil
 }
 
+// sdJWTSignature verifies the issuer signature of an SD-JWT VC. Its disclosures are verified when extracting the SD-JWT from the credential.
+func (sv *signatureVerifier) sdJWTSignature(credentialToVerify vc.VerifiableCredential, at *time.Time) error {
+	sdJWT, err := sdjwt.FromCredential(credentialToVerify)
+	if err != nil {
+		return newVerificationError("invalid SD-JWT VC: %w", err)
+	}
+	return sv.jwtSignature(sdJWT.IssuerJWT, credentialToVerify.Issuer.String(), at)
+}
+
+// verifyKeyBindings verifies the key binding JWTs of the SD-JWT VCs in the presentation.
+// A key binding JWT must be signed by the subject of the credential, proving the presenter holds the credential.
+// Its audience and nonce must match the audience (domain) and nonce (or challenge) of the presentation,
+// so it can't be replayed in another presentation.
+func (sv *signatureVerifier) verifyKeyBindings(presentation vc.VerifiablePresentation, at *time.Time) error {
+	validAt := time.Now()
+	if at != nil {
+		validAt = *at
+	}
+	var audience, nonce string
+	for _, current := range presentation.VerifiableCredential {
+		if !sdjwt.IsCredential(current) {
+			continue
+		}
+		if audience == "" {
+			var err error
+			audience, nonce, err = presentationAudienceAndNonce(presentation)
+			if err != nil {
+				return toVerificationError(err)
+			}
+		}
+		sdJWT, err := sdjwt.FromCredential(current)
+		if err != nil {
+			return newVerificationError("invalid SD-JWT VC (id=%s): %w", current.ID, err)
+		}
+		subjectDID, err := current.SubjectDID()
+		if err != nil {
+			return toVerificationError(err)
+		}
+		_, err = sdJWT.VerifyKeyBinding(func(kid string) (crypt.PublicKey, error) {
+			if strings.Split(kid, "#")[0] != subjectDID.String() {
+				return nil, errors.New("key binding JWT is not signed by credential subject")
+			}
+			return sv.keyResolver.ResolveKeyByID(kid, at, resolver.NutsSigningKeyType)
+		}, audience, nonce, validAt)
+		if err != nil {
+			return newVerificationError("invalid SD-JWT VC (id=%s): %w", current.ID, err)
+		}
+	}
+	return nil
+}
+
+// presentationAudienceAndNonce returns the audience and nonce of the presentation, which are protected by its signature.
+// For JSON-LD presentations, the challenge is used if the proof has no nonce.
+func presentationAudienceAndNonce(presentation vc.VerifiablePresentation) (string, string, error) {
+	var audience, nonce string
+	switch presentation.Format() {
+	case vc.JWTPresentationProofFormat:
+		token := presentation.JWT()
+		if len(token.Audience()) == 1 {
+			audience = token.Audience()[0]
+		}
+		nonceRaw, _ := token.Get("nonce")
+		nonce, _ = nonceRaw.(string)
+	case vc.JSONLDPresentationProofFormat:
+		ldProof, err := credential.ParseLDProof(presentation)
+		if err != nil {
+			return "", "", err
+		}
+		if ldProof.Domain != nil {
+			audience = *ldProof.Domain
+		}
+		if ldProof.Nonce != nil && *ldProof.Nonce != "" {
+			nonce = *ldProof.Nonce
+		} else if ldProof.Challenge != nil {
+			nonce = *ldProof.Challenge
+		}
+	}
+	if audience == "" || nonce == "" {
+		return "", "", errors.New("presentation containing SD-JWT VCs must have an audience and nonce")
+	}
+	return audience, nonce, nil
+}
+
 func (sv *signatureVerifier) resolveSigningKey(kid string, issuer string, at *time.Time) (crypt.PublicKey, error) {
 	// Compatibility: VC data model v1 puts key discovery out of scope and does not require the `kid` header.
 	// When `kid` isn't present use the JWT issuer as `kid`, then it is at least compatible with DID methods that contain a single verification method (did:jwk).
@@ -39,6 +39,8 @@ import (
 	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/assert"
@@ -67,7 +69,7 @@ func TestSignatureVerifier_VerifySignature(t *testing.T) {
 	t.Run("JWT", func(t *testing.T) {
 		// Create did:jwk for issuer, and sign credential
 		keyStore := nutsCrypto.NewMemoryCryptoInstance()
-		key, err := keyStore.New(audit.TestContext(), func(key crypto.PublicKey) (string, error) {
+		key, err := keyStore.New(audit.TestContext(), nutsCrypto.ECP256Key, func(key crypto.PublicKey) (string, error) {
 			keyAsJWK, _ := jwk.FromRaw(key)
 			keyJSON, _ := json.Marshal(keyAsJWK)
 			return "did:jwk:" + base64.RawStdEncoding.EncodeToString(keyJSON) + "#0", nil
@@ -143,6 +145,31 @@ func TestSignatureVerifier_VerifySignature(t *testing.T) {
 		})
 	})
 
+	t.Run("SD-JWT", func(t *testing.T) {
+		cred, issuerKey, _ := sdJWTTestCredential(t, nutsCrypto.NewMemoryCryptoInstance())
+
+		t.Run("ok", func(t *testing.T) {
+			sv, mockKeyResolver := signatureVerifierTestSetup(t)
+			mockKeyResolver.EXPECT().ResolveKeyByID(issuerKey.KID(), gomock.Any(), resolver.NutsSigningKeyType).Return(issuerKey.Public(), nil)
+
+			err := sv.VerifySignature(*cred, nil)
+
+			assert.NoError(t, err)
+		})
+		t.Run("disclosed claims do not match SD-JWT", func(t *testing.T) {
+			sv, _ := signatureVerifierTestSetup(t)
+			tampered := *cred
+			tampered.CredentialSubject = []interface{}{map[string]interface{}{
+				"id":   cred.CredentialSubject[0].(map[string]interface{})["id"],
+				"name": "Other hospital",
+			}}
+
+			err := sv.VerifySignature(tampered, nil)
+
+			assert.EqualError(t, err, "verification error: invalid SD-JWT VC: credential does not match its SD-JWT")
+		})
+	})
+
 	t.Run("error - invalid vm", func(t *testing.T) {
 		sv, _ := signatureVerifierTestSetup(t)
 
@@ -243,3 +270,142 @@ func signatureVerifierTestSetup(t testing.TB) (signatureVerifier, *resolver.Mock
 		jsonldManager: jsonld.NewTestJSONLDManager(t),
 	}, keyResolver
 }
+
+func TestSignatureVerifier_verifyKeyBindings(t *testing.T) {
+	keyStore := nutsCrypto.NewMemoryCryptoInstance()
+	cred, _, holderKey := sdJWTTestCredential(t, keyStore)
+	signer := func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		return keyStore.SignJWT(ctx, claims, headers, holderKey)
+	}
+	sdJWT, err := sdjwt.FromCredential(*cred)
+	require.NoError(t, err)
+	withKeyBinding := func(t *testing.T, sdJWT sdjwt.SDJWT) vc.VerifiableCredential {
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "did:example:verifier", "nonce")
+		require.NoError(t, err)
+		result, err := sdjwt.ToCredential(*bound)
+		require.NoError(t, err)
+		return *result
+	}
+	presentationWith := func(t *testing.T, domain string, nonce string, credentials ...vc.VerifiableCredential) vc.VerifiablePresentation {
+		presentation := vc.VerifiablePresentation{
+			Context:              []ssi.URI{vc.VCContextV1URI()},
+			Type:                 []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+			VerifiableCredential: credentials,
+			Proof:                []interface{}{proof.LDProof{ProofOptions: proof.ProofOptions{Domain: &domain, Nonce: &nonce}}},
+		}
+		data, _ := json.Marshal(presentation)
+		result, err := vc.ParseVerifiablePresentation(string(data))
+		require.NoError(t, err)
+		return *result
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		sv, mockKeyResolver := signatureVerifierTestSetup(t)
+		mockKeyResolver.EXPECT().ResolveKeyByID(holderKey.KID(), gomock.Any(), resolver.NutsSigningKeyType).Return(holderKey.Public(), nil)
+		presentation := presentationWith(t, "did:example:verifier", "nonce", withKeyBinding(t, *sdJWT))
+
+		err := sv.verifyKeyBindings(presentation, nil)
+
+		assert.NoError(t, err)
+	})
+	t.Run("other credentials are ignored", func(t *testing.T) {
+		sv, _ := signatureVerifierTestSetup(t)
+		presentation := vc.VerifiablePresentation{VerifiableCredential: []vc.VerifiableCredential{testCredential(t)}}
+
+		err := sv.verifyKeyBindings(presentation, nil)
+
+		assert.NoError(t, err)
+	})
+	t.Run("key binding JWT for other verifier", func(t *testing.T) {
+		sv, mockKeyResolver := signatureVerifierTestSetup(t)
+		mockKeyResolver.EXPECT().ResolveKeyByID(holderKey.KID(), gomock.Any(), resolver.NutsSigningKeyType).Return(holderKey.Public(), nil)
+		presentation := presentationWith(t, "did:example:other", "nonce", withKeyBinding(t, *sdJWT))
+
+		err := sv.verifyKeyBindings(presentation, nil)
+
+		assert.ErrorContains(t, err, "invalid key binding JWT: \"aud\" not satisfied")
+	})
+	t.Run("key binding JWT for other nonce", func(t *testing.T) {
+		sv, mockKeyResolver := signatureVerifierTestSetup(t)
+		mockKeyResolver.EXPECT().ResolveKeyByID(holderKey.KID(), gomock.Any(), resolver.NutsSigningKeyType).Return(holderKey.Public(), nil)
+		presentation := presentationWith(t, "did:example:verifier", "other", withKeyBinding(t, *sdJWT))
+
+		err := sv.verifyKeyBindings(presentation, nil)
+
+		assert.ErrorContains(t, err, "invalid key binding JWT: nonce does not match")
+	})
+	t.Run("presentation without nonce", func(t *testing.T) {
+		sv, _ := signatureVerifierTestSetup(t)
+		presentation := presentationWith(t, "did:example:verifier", "", withKeyBinding(t, *sdJWT))
+
+		err := sv.verifyKeyBindings(presentation, nil)
+
+		assert.ErrorContains(t, err, "presentation containing SD-JWT VCs must have an audience and nonce")
+	})
+	t.Run("no key binding", func(t *testing.T) {
+		sv, _ := signatureVerifierTestSetup(t)
+		presentation := presentationWith(t, "did:example:verifier", "nonce", *cred)
+
+		err := sv.verifyKeyBindings(presentation, nil)
+
+		assert.ErrorContains(t, err, "SD-JWT has no key binding JWT")
+	})
+	t.Run("not signed by credential subject", func(t *testing.T) {
+		sv, _ := signatureVerifierTestSetup(t)
+		otherKey, _ := keyStore.New(audit.TestContext(), nutsCrypto.ECP256Key, nutsCrypto.StringNamingFunc("did:example:other#1"))
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return keyStore.SignJWT(ctx, claims, headers, otherKey)
+		}, "did:example:verifier", "nonce")
+		require.NoError(t, err)
+		credential, _ := sdjwt.ToCredential(*bound)
+		presentation := presentationWith(t, "did:example:verifier", "nonce", *credential)
+
+		err = sv.verifyKeyBindings(presentation, nil)
+
+		assert.ErrorContains(t, err, "key binding JWT is not signed by credential subject")
+	})
+	t.Run("key binding JWT of other disclosures", func(t *testing.T) {
+		sv, mockKeyResolver := signatureVerifierTestSetup(t)
+		mockKeyResolver.EXPECT().ResolveKeyByID(holderKey.KID(), gomock.Any(), resolver.NutsSigningKeyType).Return(holderKey.Public(), nil)
+		bound, err := sdJWT.WithKeyBinding(audit.TestContext(), signer, "did:example:verifier", "nonce")
+		require.NoError(t, err)
+		// replay the key binding JWT with fewer disclosures
+		limited := bound.Without(sdJWT.Disclosures[0].Digest())
+		limited.KeyBindingJWT = bound.KeyBindingJWT
+		credential, err := sdjwt.ToCredential(limited)
+		require.NoError(t, err)
+		presentation := presentationWith(t, "did:example:verifier", "nonce", *credential)
+
+		err = sv.verifyKeyBindings(presentation, nil)
+
+		assert.ErrorContains(t, err, "sd_hash does not match")
+	})
+}
+
+// sdJWTTestCredential issues an SD-JWT VC, and returns it together with the key of the issuer and the key of the holder (credential subject).
+// Both keys are created in the given key store.
+func sdJWTTestCredential(t *testing.T, keyStore nutsCrypto.KeyStore) (*vc.VerifiableCredential, nutsCrypto.Key, nutsCrypto.Key) {
+	issuerKey, err := keyStore.New(audit.TestContext(), nutsCrypto.ECP256Key, nutsCrypto.StringNamingFunc("did:example:issuer#1"))
+	require.NoError(t, err)
+	holderKey, err := keyStore.New(audit.TestContext(), nutsCrypto.ECP256Key, nutsCrypto.StringNamingFunc("did:example:holder#1"))
+	require.NoError(t, err)
+	id := ssi.MustParseURI("did:example:issuer#credential-1")
+	issuanceDate := time.Now().Add(-time.Hour)
+	template := vc.VerifiableCredential{
+		Context:      []ssi.URI{vc.VCContextV1URI()},
+		ID:           &id,
+		Type:         []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("ExampleCredential")},
+		Issuer:       ssi.MustParseURI("did:example:issuer"),
+		IssuanceDate: &issuanceDate,
+		CredentialSubject: []interface{}{map[string]interface{}{
+			"id":   "did:example:holder",
+			"name": "Hospital",
+			"city": "Amsterdam",
+		}},
+	}
+	cred, err := sdjwt.IssueCredential(audit.TestContext(), template, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		return keyStore.SignJWT(ctx, claims, headers, issuerKey)
+	})
+	require.NoError(t, err)
+	return cred, issuerKey, holderKey
+}
@@ -71,6 +71,17 @@ func (e VerificationError) Is(other error) bool {
 	return is
 }
 
+// Unwrap returns the errors that caused the verification failure, so they can be inspected using errors.Is and errors.As.
+func (e VerificationError) Unwrap() []error {
+	var result []error
+	for _, arg := range e.args {
+		if err, ok := arg.(error); ok {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
 func newVerificationError(msg string, args ...interface{}) error {
 	return VerificationError{msg: msg, args: args}
 }
@@ -110,29 +121,16 @@ func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrus
 		return errors.New("verifiable credential must list at most 2 types")
 	}
 
-	// Check revocation status
-	if credentialToVerify.ID != nil {
-		revoked, err := v.IsRevoked(*credentialToVerify.ID)
-		if err != nil {
-			return err
-		}
-		if revoked {
-			return types.ErrRevoked
-		}
-
-	}
-
-	// Check the credentialStatus if the credential is revoked
-	err := v.credentialStatus.Verify(credentialToVerify)
+	// Check revocation and suspension status
+	revoked, suspended, err := v.IsRevoked(credentialToVerify)
 	if err != nil {
-		// soft fail, only return an error when revocation is confirmed and log everything else
-		if errors.Is(err, types.ErrRevoked) {
-			return err
-		} else {
-			// TODO: what log level
-			bs, _ := json.Marshal(credentialToVerify)
-			log.Logger().WithError(err).WithField("credential", string(bs)).Info("CredentialStatus verification failed")
-		}
+		return err
+	}
+	if revoked {
+		return types.ErrRevoked
+	}
+	if suspended {
+		return types.ErrSuspended
 	}
 
 	// Check trust status
@@ -171,15 +169,33 @@ func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrus
 	return nil
 }
 
-func (v *verifier) IsRevoked(credentialID ssi.URI) (bool, error) {
-	_, err := v.store.GetRevocations(credentialID)
+func (v *verifier) IsRevoked(credentialToVerify vc.VerifiableCredential) (bool, bool, error) {
+	// Check revocations published on the network
+	if credentialToVerify.ID != nil {
+		_, err := v.store.GetRevocations(*credentialToVerify.ID)
+		if err == nil {
+			return true, false, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return false, false, err
+		}
+	}
+
+	// Check the credentialStatus if the credential is revoked or suspended
+	err := v.credentialStatus.Verify(credentialToVerify)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			return false, nil
+		// soft fail, only return when revocation or suspension is confirmed and log everything else
+		if errors.Is(err, types.ErrRevoked) {
+			return true, false, nil
 		}
-		return false, err
+		if errors.Is(err, types.ErrSuspended) {
+			return false, true, nil
+		}
+		// TODO: what log level
+		bs, _ := json.Marshal(credentialToVerify)
+		log.Logger().WithError(err).WithField("credential", string(bs)).Info("CredentialStatus verification failed")
 	}
-	return true, nil
+	return false, false, nil
 }
 
 func (v *verifier) GetRevocation(credentialID ssi.URI) (*credential.Revocation, error) {
@@ -258,6 +274,10 @@ func (v verifier) doVerifyVP(vcVerifier Verifier, presentation vc.VerifiablePres
 	if err != nil {
 		return nil, err
 	}
+	// SD-JWT VCs must be bound to the presentation
+	if err = v.signatureVerifier.verifyKeyBindings(presentation, validAt); err != nil {
+		return nil, err
+	}
 
 	if verifyVCs {
 		for _, current := range presentation.VerifiableCredential {
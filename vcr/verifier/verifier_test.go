@@ -170,14 +170,35 @@ func TestVerifier_Verify(t *testing.T) {
 			assert.ErrorIs(t, validationErr, types.ErrRevoked)
 		})
 		t.Run("ignore other purpose", func(t *testing.T) {
+			slEntry := slEntry
 			slEntry.StatusListIndex = strconv.Itoa(statusListIndex)
-			slEntry.StatusPurpose = "suspension"
+			slEntry.StatusPurpose = "refresh"
 			cred.CredentialStatus = []any{slEntry}
 
 			validationErr := ctx.verifier.Verify(cred, true, false, nil)
 
 			assert.NoError(t, validationErr)
 		})
+		t.Run("is suspended", func(t *testing.T) {
+			// make StatusList2021Credential with statusPurpose 'suspension' and a suspension bit set
+			suspensionListCred := test.ValidStatusList2021Credential(t)
+			suspensionListCred.CredentialSubject[0].(map[string]any)["statusPurpose"] = "suspension"
+			suspensionListCredBytes, err := json.Marshal(suspensionListCred)
+			require.NoError(t, err)
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Write(suspensionListCredBytes)
+			}))
+			defer ts.Close()
+			slEntry := slEntry
+			slEntry.StatusPurpose = "suspension"
+			slEntry.StatusListIndex = strconv.Itoa(statusListIndex)
+			slEntry.StatusListCredential = ts.URL
+			cred.CredentialStatus = []any{slEntry}
+
+			validationErr := ctx.verifier.Verify(cred, true, false, nil)
+
+			assert.ErrorIs(t, validationErr, types.ErrSuspended)
+		})
 		t.Run("don't fail if error is other than types.ErrRevoked", func(t *testing.T) {
 			ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 				writer.WriteHeader(400)
@@ -622,26 +643,90 @@ func Test_verifier_IsRevoked(t *testing.T) {
 	revocation := credential.Revocation{}
 	assert.NoError(t, json.Unmarshal(rawRevocation, &revocation))
 
+	revokedCredential := vc.VerifiableCredential{ID: &revocation.Subject}
+
 	t.Run("it returns false if no revocation is found", func(t *testing.T) {
 		sut := newMockContext(t)
 		sut.store.EXPECT().GetRevocations(revocation.Subject).Return(nil, ErrNotFound)
-		result, err := sut.verifier.IsRevoked(revocation.Subject)
+		revoked, suspended, err := sut.verifier.IsRevoked(revokedCredential)
 		assert.NoError(t, err)
-		assert.False(t, result)
+		assert.False(t, revoked)
+		assert.False(t, suspended)
 	})
 	t.Run("it returns true if a revocation is found", func(t *testing.T) {
 		sut := newMockContext(t)
 		sut.store.EXPECT().GetRevocations(revocation.Subject).Return([]*credential.Revocation{&revocation}, nil)
-		result, err := sut.verifier.IsRevoked(revocation.Subject)
+		revoked, suspended, err := sut.verifier.IsRevoked(revokedCredential)
 		assert.NoError(t, err)
-		assert.True(t, result)
+		assert.True(t, revoked)
+		assert.False(t, suspended)
 	})
 	t.Run("it returns the error if the store returns an error", func(t *testing.T) {
 		sut := newMockContext(t)
 		sut.store.EXPECT().GetRevocations(revocation.Subject).Return(nil, errors.New("foo"))
-		result, err := sut.verifier.IsRevoked(revocation.Subject)
+		revoked, suspended, err := sut.verifier.IsRevoked(revokedCredential)
 		assert.EqualError(t, err, "foo")
-		assert.False(t, result)
+		assert.False(t, revoked)
+		assert.False(t, suspended)
+	})
+	t.Run("credentialStatus", func(t *testing.T) {
+		// make StatusList2021Credential with bit 1 set
+		statusListCred := test.ValidStatusList2021Credential(t)
+		statusListIndex := "1"
+		makeServer := func(statusPurpose string) *httptest.Server {
+			statusListCred.CredentialSubject[0].(map[string]any)["statusPurpose"] = statusPurpose
+			statusListCredBytes, err := json.Marshal(statusListCred)
+			require.NoError(t, err)
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Write(statusListCredBytes)
+			}))
+			t.Cleanup(ts.Close)
+			return ts
+		}
+		revocationServer := makeServer("revocation")
+		suspensionServer := makeServer("suspension")
+		newSUT := func(t *testing.T) *verifier {
+			sut := newMockContext(t).verifier
+			sut.credentialStatus = statuslist2021.NewCredentialStatus(revocationServer.Client(), func(_ vc.VerifiableCredential, _ *time.Time) error { return nil })
+			return sut
+		}
+		entry := func(statusPurpose string, ts *httptest.Server) statuslist2021.Entry {
+			return statuslist2021.Entry{
+				Type:                 statuslist2021.EntryType,
+				StatusPurpose:        statusPurpose,
+				StatusListIndex:      statusListIndex,
+				StatusListCredential: ts.URL,
+			}
+		}
+
+		t.Run("revoked", func(t *testing.T) {
+			cred := vc.VerifiableCredential{CredentialStatus: []any{entry("revocation", revocationServer)}}
+			revoked, suspended, err := newSUT(t).IsRevoked(cred)
+			assert.NoError(t, err)
+			assert.True(t, revoked)
+			assert.False(t, suspended)
+		})
+		t.Run("suspended", func(t *testing.T) {
+			cred := vc.VerifiableCredential{CredentialStatus: []any{entry("suspension", suspensionServer)}}
+			revoked, suspended, err := newSUT(t).IsRevoked(cred)
+			assert.NoError(t, err)
+			assert.False(t, revoked)
+			assert.True(t, suspended)
+		})
+		t.Run("revoked and suspended", func(t *testing.T) {
+			cred := vc.VerifiableCredential{CredentialStatus: []any{entry("suspension", suspensionServer), entry("revocation", revocationServer)}}
+			revoked, suspended, err := newSUT(t).IsRevoked(cred)
+			assert.NoError(t, err)
+			assert.True(t, revoked)
+			assert.False(t, suspended)
+		})
+		t.Run("soft fail on other errors", func(t *testing.T) {
+			cred := vc.VerifiableCredential{CredentialStatus: []any{entry("revocation", suspensionServer)}}
+			revoked, suspended, err := newSUT(t).IsRevoked(cred)
+			assert.NoError(t, err)
+			assert.False(t, revoked)
+			assert.False(t, suspended)
+		})
 	})
 }
 
@@ -678,6 +763,14 @@ func TestVerificationError_Is(t *testing.T) {
 	assert.False(t, VerificationError{}.Is(errors.New("other")))
 }
 
+func TestVerificationError_Unwrap(t *testing.T) {
+	err := newVerificationError("invalid VC (id=%s): %w", "did:nuts:123#1", types.ErrRevoked)
+
+	assert.ErrorIs(t, err, types.ErrRevoked)
+	assert.ErrorIs(t, err, VerificationError{})
+	assert.NotErrorIs(t, err, types.ErrSuspended)
+}
+
 type mockContext struct {
 	ctrl        *gomock.Controller
 	didResolver *resolver.MockDIDResolver
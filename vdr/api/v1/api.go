@@ -33,6 +33,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 )
 
@@ -55,6 +56,7 @@ func (a *Wrapper) ResolveStatusCode(err error) int {
 		resolver.ErrDuplicateService:        http.StatusBadRequest,
 		didnuts.ErrInvalidOptions:           http.StatusBadRequest,
 		did.ErrInvalidDID:                   http.StatusBadRequest,
+		crypto.ErrUnsupportedKeyType:        http.StatusBadRequest,
 	})
 }
 
@@ -135,6 +137,13 @@ func (a *Wrapper) CreateDID(ctx context.Context, request CreateDIDRequestObject)
 	if request.Body.SelfControl != nil {
 		options = options.With(didnuts.SelfControl(*request.Body.SelfControl))
 	}
+	if request.Body.KeyType != nil {
+		keyType, err := crypto.ParseKeyType(*request.Body.KeyType)
+		if err != nil {
+			return nil, core.InvalidInputError("%w", err)
+		}
+		options = options.With(management.KeyType(keyType))
+	}
 
 	doc, _, err := a.VDR.Create(ctx, options)
 	// if this operation leads to an error, it may return a 500
@@ -29,6 +29,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/vdr"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
@@ -79,6 +80,30 @@ func TestWrapper_CreateDID(t *testing.T) {
 		assert.Equal(t, *id, response.(CreateDID200JSONResponse).ID)
 	})
 
+	t.Run("ok - key type", func(t *testing.T) {
+		ctx := newMockContext(t)
+		keyType := "P-384"
+		request := DIDCreateRequest{KeyType: &keyType}
+		ctx.vdr.EXPECT().Create(gomock.Any(), didnuts.DefaultCreationOptions().With(management.KeyType(crypto.ECP384Key))).Return(didDoc, nil, nil)
+
+		response, err := ctx.client.CreateDID(nil, CreateDIDRequestObject{Body: &request})
+
+		require.NoError(t, err)
+		assert.Equal(t, *id, response.(CreateDID200JSONResponse).ID)
+	})
+
+	t.Run("error - unsupported key type", func(t *testing.T) {
+		ctx := newMockContext(t)
+		keyType := "X25519"
+		request := DIDCreateRequest{KeyType: &keyType}
+
+		response, err := ctx.client.CreateDID(nil, CreateDIDRequestObject{Body: &request})
+
+		assert.ErrorIs(t, err, crypto.ErrUnsupportedKeyType)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+		assert.Nil(t, response)
+	})
+
 	t.Run("error - invalid controller DID", func(t *testing.T) {
 		ctx := newMockContext(t)
 		controllers := []string{"not_a_did"}
@@ -42,6 +42,9 @@ type DIDCreateRequest struct {
 
 	// whether the generated DID Document can be altered with its own capabilityInvocation key.
 	SelfControl *bool `json:"selfControl,omitempty"`
+
+	// the type of the generated key pair: P-256 (default), P-384 or RSA.
+	KeyType *string `json:"keyType,omitempty"`
 }
 
 // VerificationMethodRelationship defines model for VerificationMethodRelationship.
@@ -26,6 +26,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/vdr"
 	"github.com/nuts-foundation/nuts-node/vdr/didweb"
 	"github.com/nuts-foundation/nuts-node/vdr/management"
@@ -49,6 +50,7 @@ func (w *Wrapper) ResolveStatusCode(err error) int {
 		did.ErrInvalidDID:                   http.StatusBadRequest,
 		management.ErrInvalidService:        http.StatusBadRequest,
 		management.ErrUnsupportedDIDMethod:  http.StatusBadRequest,
+		crypto.ErrUnsupportedKeyType:        http.StatusBadRequest,
 	})
 }
 
@@ -73,6 +75,13 @@ func (w Wrapper) CreateDID(ctx context.Context, request CreateDIDRequestObject)
 	if request.Body.Id != nil && *request.Body.Id != "" {
 		options = options.With(didweb.UserPath(*request.Body.Id))
 	}
+	if request.Body.KeyType != nil {
+		keyType, err := crypto.ParseKeyType(*request.Body.KeyType)
+		if err != nil {
+			return nil, err
+		}
+		options = options.With(management.KeyType(keyType))
+	}
 
 	doc, _, err := w.VDR.Create(ctx, options)
 	// if this operation leads to an error, it may return a 500
@@ -167,11 +176,29 @@ func (w Wrapper) UpdateService(ctx context.Context, request UpdateServiceRequest
 }
 
 func (w Wrapper) AddVerificationMethod(ctx context.Context, request AddVerificationMethodRequestObject) (AddVerificationMethodResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	targetDID, err := did.ParseDID(request.Did)
+	if err != nil {
+		return nil, err
+	}
+	verificationMethod, err := w.VDR.AddVerificationMethod(ctx, *targetDID, 0)
+	if err != nil {
+		return nil, err
+	}
+	return AddVerificationMethod200JSONResponse(*verificationMethod), nil
 }
 
 func (w Wrapper) DeleteVerificationMethod(ctx context.Context, request DeleteVerificationMethodRequestObject) (DeleteVerificationMethodResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	targetDID, err := did.ParseDID(request.Did)
+	if err != nil {
+		return nil, err
+	}
+	verificationMethodID, err := did.ParseDIDURL(request.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = w.VDR.RemoveVerificationMethod(ctx, *targetDID, *verificationMethodID)
+	if err != nil {
+		return nil, err
+	}
+	return DeleteVerificationMethod204Response{}, nil
 }
@@ -22,7 +22,10 @@ package v2
 import (
 	"context"
 	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/vdr/didweb"
+	"github.com/nuts-foundation/nuts-node/vdr/management"
+	"net/http"
 	"testing"
 
 	"github.com/nuts-foundation/go-did/did"
@@ -65,6 +68,35 @@ func TestWrapper_CreateDID(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, id, response.(CreateDID200JSONResponse).ID)
 	})
+	t.Run("with key type", func(t *testing.T) {
+		ctx := newMockContext(t)
+		opts := didweb.DefaultCreationOptions().With(management.KeyType(crypto.Ed25519Key))
+		ctx.vdr.EXPECT().Create(gomock.Any(), opts).Return(&didDoc, nil, nil)
+
+		keyType := "Ed25519"
+		response, err := ctx.client.CreateDID(nil, CreateDIDRequestObject{
+			Body: &CreateDIDJSONRequestBody{
+				KeyType: &keyType,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, id, response.(CreateDID200JSONResponse).ID)
+	})
+	t.Run("error - unsupported key type", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		keyType := "X25519"
+		response, err := ctx.client.CreateDID(nil, CreateDIDRequestObject{
+			Body: &CreateDIDJSONRequestBody{
+				KeyType: &keyType,
+			},
+		})
+
+		assert.ErrorIs(t, err, crypto.ErrUnsupportedKeyType)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+		assert.Nil(t, response)
+	})
 	t.Run("error - create fails", func(t *testing.T) {
 		ctx := newMockContext(t)
 		ctx.vdr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, nil, assert.AnError)
@@ -174,6 +206,81 @@ func TestWrapper_UpdateService(t *testing.T) {
 	})
 }
 
+func TestWrapper_AddVerificationMethod(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		verificationMethod := did.VerificationMethod{ID: did.MustParseDIDURL(id.String() + "#1")}
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().AddVerificationMethod(gomock.Any(), id, gomock.Any()).Return(&verificationMethod, nil)
+
+		response, err := ctx.client.AddVerificationMethod(nil, AddVerificationMethodRequestObject{
+			Did: id.String(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, verificationMethod.ID, response.(AddVerificationMethod200JSONResponse).ID)
+	})
+	t.Run("error - add fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().AddVerificationMethod(gomock.Any(), id, gomock.Any()).Return(nil, assert.AnError)
+
+		response, err := ctx.client.AddVerificationMethod(nil, AddVerificationMethodRequestObject{
+			Did: id.String(),
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+	t.Run("error - invalid DID", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		response, err := ctx.client.AddVerificationMethod(nil, AddVerificationMethodRequestObject{
+			Did: "invalid",
+		})
+
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+		assert.Nil(t, response)
+	})
+}
+
+func TestWrapper_DeleteVerificationMethod(t *testing.T) {
+	keyID := did.MustParseDIDURL(id.String() + "#1")
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().RemoveVerificationMethod(gomock.Any(), id, keyID).Return(nil)
+
+		response, err := ctx.client.DeleteVerificationMethod(nil, DeleteVerificationMethodRequestObject{
+			Did: id.String(),
+			Id:  keyID.String(),
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, DeleteVerificationMethod204Response{}, response)
+	})
+	t.Run("error - delete fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().RemoveVerificationMethod(gomock.Any(), id, keyID).Return(resolver.ErrNotFound)
+
+		response, err := ctx.client.DeleteVerificationMethod(nil, DeleteVerificationMethodRequestObject{
+			Did: id.String(),
+			Id:  keyID.String(),
+		})
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+		assert.Nil(t, response)
+	})
+	t.Run("error - invalid key ID", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		response, err := ctx.client.DeleteVerificationMethod(nil, DeleteVerificationMethodRequestObject{
+			Did: id.String(),
+			Id:  "invalid",
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
+
 func TestWrapper_ListDIDs(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctx := newMockContext(t)
@@ -46,10 +46,10 @@ func (hb HTTPClient) client() ClientInterface {
 
 // Create calls the server and creates a new DID Document
 // It does not parse a custom id but depends on the server to generate one
-func (hb HTTPClient) Create() (*did.Document, error) {
+func (hb HTTPClient) Create(createRequest CreateDIDOptions) (*did.Document, error) {
 	ctx := context.Background()
 
-	if response, err := hb.client().CreateDID(ctx, CreateDIDJSONRequestBody{}); err != nil {
+	if response, err := hb.client().CreateDID(ctx, createRequest); err != nil {
 		return nil, err
 	} else if err := core.TestResponseCode(http.StatusOK, response); err != nil {
 		return nil, err
@@ -58,6 +58,39 @@ func (hb HTTPClient) Create() (*did.Document, error) {
 	}
 }
 
+// AddVerificationMethod calls the server to generate a new key and add it as verification method to the DID document.
+func (hb HTTPClient) AddVerificationMethod(targetDID string) (*did.VerificationMethod, error) {
+	ctx := context.Background()
+
+	response, err := hb.client().AddVerificationMethod(ctx, targetDID)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read verification method response: %w", err)
+	}
+	verificationMethod := did.VerificationMethod{}
+	if err = json.Unmarshal(data, &verificationMethod); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal verification method response: %w, %s", err, string(data))
+	}
+	return &verificationMethod, nil
+}
+
+// DeleteVerificationMethod calls the server to remove a verification method from the DID document.
+func (hb HTTPClient) DeleteVerificationMethod(targetDID string, kid string) error {
+	ctx := context.Background()
+
+	response, err := hb.client().DeleteVerificationMethod(ctx, targetDID, kid)
+	if err != nil {
+		return err
+	}
+	return core.TestResponseCode(http.StatusNoContent, response)
+}
+
 func readDIDDocument(reader io.Reader) (*did.Document, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
@@ -41,7 +41,7 @@ func TestHTTPClient_Create(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: didDoc})
 		c := getClient(s.URL)
-		doc, err := c.Create()
+		doc, err := c.Create(CreateDIDOptions{})
 		require.NoError(t, err)
 		assert.NotNil(t, doc)
 	})
@@ -49,13 +49,51 @@ func TestHTTPClient_Create(t *testing.T) {
 	t.Run("error - server error", func(t *testing.T) {
 		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: ""})
 		c := getClient(s.URL)
-		_, err := c.Create()
+		_, err := c.Create(CreateDIDOptions{})
 		assert.Error(t, err)
 	})
 
 	t.Run("error - wrong address", func(t *testing.T) {
 		c := getClient("not_an_address")
-		_, err := c.Create()
+		_, err := c.Create(CreateDIDOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_AddVerificationMethod(t *testing.T) {
+	verificationMethod := did.VerificationMethod{
+		ID:         vdr.TestMethodDIDA,
+		Controller: vdr.TestDIDA,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: verificationMethod})
+		c := getClient(s.URL)
+		result, err := c.AddVerificationMethod(vdr.TestDIDA.String())
+		require.NoError(t, err)
+		assert.Equal(t, vdr.TestMethodDIDA.String(), result.ID.String())
+	})
+
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: ""})
+		c := getClient(s.URL)
+		_, err := c.AddVerificationMethod(vdr.TestDIDA.String())
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPClient_DeleteVerificationMethod(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNoContent})
+		c := getClient(s.URL)
+		err := c.DeleteVerificationMethod(vdr.TestDIDA.String(), vdr.TestMethodDIDA.String())
+		require.NoError(t, err)
+	})
+
+	t.Run("error - server error", func(t *testing.T) {
+		s := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNotFound, ResponseData: ""})
+		c := getClient(s.URL)
+		err := c.DeleteVerificationMethod(vdr.TestDIDA.String(), vdr.TestMethodDIDA.String())
 		assert.Error(t, err)
 	})
 }
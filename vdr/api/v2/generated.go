@@ -26,6 +26,9 @@ const (
 type CreateDIDOptions struct {
 	// Id The ID of the DID document. If not given, a random UUID is generated.
 	Id *string `json:"id,omitempty"`
+
+	// KeyType The type of the key that is generated for the DID document. Defaults to P-256.
+	KeyType *string `json:"keyType,omitempty"`
 }
 
 // DIDResolutionResult defines model for DIDResolutionResult.
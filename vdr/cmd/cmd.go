@@ -29,6 +29,7 @@ import (
 
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vdr"
 	api "github.com/nuts-foundation/nuts-node/vdr/api/v1"
 	apiv2 "github.com/nuts-foundation/nuts-node/vdr/api/v2"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
@@ -40,7 +41,20 @@ import (
 
 // FlagSet contains flags relevant for the VDR instance
 func FlagSet() *pflag.FlagSet {
+	defs := vdr.DefaultConfig()
 	flagSet := pflag.NewFlagSet("vdr", pflag.ContinueOnError)
+	flagSet.Duration("vdr.didweb.keygraceperiod", defs.DIDWeb.KeyGracePeriod,
+		"Period a removed did:web verification method stays published in the DID document, "+
+			"so credentials and presentations signed with its key can still be verified. "+
+			"After this period the verification method and its private key are deleted. "+
+			"Specified as Golang duration (e.g. 1m, 1h30m).")
+	flagSet.Int("vdr.didweb.cache.maxentries", defs.DIDWeb.Cache.MaxEntries,
+		"Maximum number of DID documents resolved over HTTP (did:web) that are cached. "+
+			"Caching respects the Cache-Control, Expires and ETag headers of the server hosting the DID document. "+
+			"Set to 0 to disable caching.")
+	flagSet.Duration("vdr.didweb.cache.maxstale", defs.DIDWeb.Cache.MaxStale,
+		"Period an expired cached did:web DID document may still be used when the server hosting it can't be reached. "+
+			"Specified as Golang duration (e.g. 1m, 1h30m).")
 	return flagSet
 }
 
@@ -78,11 +92,12 @@ func createCmd() *cobra.Command {
 	}
 	// todo should become default
 	var useV2 bool
+	var keyType string
 
 	result := &cobra.Command{
 		Use:   "create-did",
 		Short: "Registers a new DID",
-		Long:  "When using the V2 API, a did:web DID will be created. All the other options are ignored for did:web.",
+		Long:  "When using the V2 API, a did:web DID will be created. All the other options except keyType are ignored for did:web.",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientConfig := core.NewClientConfigForCommand(cmd)
@@ -90,8 +105,11 @@ func createCmd() *cobra.Command {
 				doc *did.Document
 				err error
 			)
+			if keyType != "" {
+				createRequest.KeyType = &keyType
+			}
 			if useV2 {
-				doc, err = httpClientV2(clientConfig).Create()
+				doc, err = httpClientV2(clientConfig).Create(apiv2.CreateDIDOptions{KeyType: createRequest.KeyType})
 			} else {
 				doc, err = httpClient(clientConfig).Create(createRequest)
 			}
@@ -120,6 +138,7 @@ func createCmd() *cobra.Command {
 	result.Flags().BoolVar(createRequest.SelfControl, "selfControl", true, setUsage(true, "Pass '%t' to %s DID Document control."))
 	result.Flags().BoolVar(&useV2, "v2", false, "Pass 'true' to use the V2 API and create a did:web DID.")
 	result.Flags().StringSliceVar(createRequest.Controllers, "controllers", []string{}, "Comma-separated list of DIDs that can control the generated DID Document.")
+	result.Flags().StringVar(&keyType, "keyType", "", "Type of the generated key: 'P-256' (default), 'P-384', 'Ed25519' or 'RSA'. Ed25519 is only supported for did:web.")
 
 	return result
 }
@@ -267,13 +286,25 @@ func deactivateCmd() *cobra.Command {
 }
 
 func addVerificationMethodCmd() *cobra.Command {
+	var useV2 bool
 	result := &cobra.Command{
 		Use:   "addvm [DID]",
 		Short: "Add a verification method key to the DID document.",
-		Args:  cobra.ExactArgs(1),
+		Long: "Add a verification method key to the DID document. " +
+			"When using the V2 API (required for did:web), the key can be used for all verification relationships. " +
+			"To rotate the key of a did:web DID, add a new verification method and then delete the old one using 'delvm'.",
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientConfig := core.NewClientConfigForCommand(cmd)
-			verificationMethod, err := httpClient(clientConfig).AddNewVerificationMethod(args[0])
+			var (
+				verificationMethod *did.VerificationMethod
+				err                error
+			)
+			if useV2 {
+				verificationMethod, err = httpClientV2(clientConfig).AddVerificationMethod(args[0])
+			} else {
+				verificationMethod, err = httpClient(clientConfig).AddNewVerificationMethod(args[0])
+			}
 			if err != nil {
 				return fmt.Errorf("failed to add a new verification method to DID document: %s", err.Error())
 			}
@@ -282,6 +313,7 @@ func addVerificationMethodCmd() *cobra.Command {
 			return nil
 		},
 	}
+	result.Flags().BoolVar(&useV2, "v2", false, "Pass 'true' to use the V2 API (for did:web DIDs).")
 
 	return result
 }
@@ -340,13 +372,21 @@ func addKeyAgreementKeyCmd() *cobra.Command {
 }
 
 func deleteVerificationMethodCmd() *cobra.Command {
+	var useV2 bool
 	result := &cobra.Command{
 		Use:   "delvm [DID] [kid]",
 		Short: "Deletes a verification method from the DID document.",
-		Args:  cobra.ExactArgs(2),
+		Long: "Deletes a verification method from the DID document. " +
+			"When using the V2 API (required for did:web), the verification method stays published until the configured key grace period has passed.",
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientConfig := core.NewClientConfigForCommand(cmd)
-			err := httpClient(clientConfig).DeleteVerificationMethod(args[0], args[1])
+			var err error
+			if useV2 {
+				err = httpClientV2(clientConfig).DeleteVerificationMethod(args[0], args[1])
+			} else {
+				err = httpClient(clientConfig).DeleteVerificationMethod(args[0], args[1])
+			}
 			if err != nil {
 				return fmt.Errorf("failed to delete the verification method from DID document: %s", err.Error())
 			}
@@ -354,6 +394,7 @@ func deleteVerificationMethodCmd() *cobra.Command {
 			return nil
 		},
 	}
+	result.Flags().BoolVar(&useV2, "v2", false, "Pass 'true' to use the V2 API (for did:web DIDs).")
 
 	return result
 }
@@ -35,6 +35,7 @@ import (
 	http2 "github.com/nuts-foundation/nuts-node/test/http"
 	"github.com/nuts-foundation/nuts-node/vdr"
 	v1 "github.com/nuts-foundation/nuts-node/vdr/api/v1"
+	v2 "github.com/nuts-foundation/nuts-node/vdr/api/v2"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -113,6 +114,38 @@ func TestEngine_Command(t *testing.T) {
 			assert.Empty(t, errBuf.Bytes())
 			assert.NoError(t, err)
 		})
+		t.Run("ok - v2 with key type", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				var createRequest v2.CreateDIDOptions
+				_ = json.NewDecoder(request.Body).Decode(&createRequest)
+				require.NotNil(t, createRequest.KeyType)
+				assert.Equal(t, "Ed25519", *createRequest.KeyType)
+				writer.WriteHeader(http.StatusOK)
+				bytes, _ := json.Marshal(exampleDIDDocument)
+				_, _ = writer.Write(bytes)
+			}))
+			cmd.SetArgs([]string{"create-did", "--v2", "--keyType", "Ed25519"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+			require.NoError(t, err)
+		})
+		t.Run("ok - v1 with key type", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				var createRequest v1.DIDCreateRequest
+				_ = json.NewDecoder(request.Body).Decode(&createRequest)
+				require.NotNil(t, createRequest.KeyType)
+				assert.Equal(t, "P-384", *createRequest.KeyType)
+				writer.WriteHeader(http.StatusOK)
+				bytes, _ := json.Marshal(exampleDIDDocument)
+				_, _ = writer.Write(bytes)
+			}))
+			cmd.SetArgs([]string{"create-did", "--keyType", "P-384"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+			require.NoError(t, err)
+		})
 		t.Run("error - server error", func(t *testing.T) {
 			cmd := newCmdWithServer(t, &http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: "b00m!"})
 			cmd.SetArgs([]string{"create-did"})
@@ -313,6 +346,25 @@ func TestEngine_Command(t *testing.T) {
 			assert.Empty(t, errBuf.Bytes())
 
 		})
+		t.Run("ok - v2", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/internal/vdr/v2/did/"+vdr.TestDIDA.String()+"/verificationmethod", request.URL.Path)
+				writer.WriteHeader(http.StatusOK)
+				bytes, _ := json.Marshal(verificationMethod)
+				_, _ = writer.Write(bytes)
+			}))
+
+			cmd.SetArgs([]string{"addvm", "--v2", vdr.TestDIDA.String()})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			resultingMethod := did.VerificationMethod{}
+			err = json.Unmarshal(buf.Bytes(), &resultingMethod)
+			assert.NoError(t, err)
+			assert.Equal(t, *verificationMethod, resultingMethod)
+			assert.Empty(t, errBuf.Bytes())
+		})
 
 		t.Run("error - DID document not found", func(t *testing.T) {
 			cmd := newCmdWithServer(t, &http2.Handler{StatusCode: http.StatusNotFound})
@@ -336,6 +388,19 @@ func TestEngine_Command(t *testing.T) {
 			require.NoError(t, err)
 			assert.Empty(t, errBuf.String())
 		})
+		t.Run("ok - v2", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, http.MethodDelete, request.Method)
+				assert.Equal(t, "/internal/vdr/v2/did/"+vdr.TestDIDA.String()+"/verificationmethod/"+vdr.TestMethodDIDA.String(), request.URL.Path)
+				writer.WriteHeader(http.StatusNoContent)
+			}))
+			cmd.SetArgs([]string{"delvm", "--v2", vdr.TestDIDA.String(), vdr.TestMethodDIDA.String()})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			assert.Empty(t, errBuf.String())
+		})
 
 		t.Run("error - DID document not found", func(t *testing.T) {
 			cmd := newCmdWithServer(t, &http2.Handler{StatusCode: http.StatusNotFound})
@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package vdr
+
+import "time"
+
+// Config holds the config of the VDR module.
+type Config struct {
+	DIDWeb DIDWebConfig `koanf:"didweb"`
+}
+
+// DIDWebConfig holds the config for managing did:web DID documents.
+type DIDWebConfig struct {
+	// KeyGracePeriod specifies how long a removed verification method stays published in the DID document,
+	// so that credentials and presentations signed with its key can still be verified.
+	KeyGracePeriod time.Duration `koanf:"keygraceperiod"`
+	// Cache holds the config for caching did:web DID documents resolved over HTTP.
+	Cache DIDWebCacheConfig `koanf:"cache"`
+}
+
+// DIDWebCacheConfig holds the config for caching did:web DID documents resolved over HTTP.
+type DIDWebCacheConfig struct {
+	// MaxEntries specifies the maximum number of DID documents in the cache. If 0, caching is disabled.
+	MaxEntries int `koanf:"maxentries"`
+	// MaxStale specifies how long an expired DID document may still be used when the server hosting it can't be reached.
+	MaxStale time.Duration `koanf:"maxstale"`
+}
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() Config {
+	return Config{
+		DIDWeb: DIDWebConfig{
+			KeyGracePeriod: 24 * time.Hour,
+			Cache: DIDWebCacheConfig{
+				MaxEntries: 1000,
+				MaxStale:   time.Hour,
+			},
+		},
+	}
+}
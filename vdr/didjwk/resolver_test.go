@@ -20,9 +20,13 @@ package didjwk
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"testing"
 
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -62,6 +66,29 @@ func TestResolver_Resolve(t *testing.T) {
 	// Ensure the canonical example from the DID JWK spec can be resolved
 	t.Run("resolve did:jwk", success(b64(canonicalJWK)))
 
+	// Ensure DIDs of keys generated by the node can be resolved, for every supported key type
+	t.Run("resolve generated keys", func(t *testing.T) {
+		keyStore := nutsCrypto.NewMemoryCryptoInstance()
+		for _, keyType := range nutsCrypto.SupportedKeyTypes() {
+			t.Run(string(keyType), func(t *testing.T) {
+				key, err := keyStore.New(audit.TestContext(), keyType, nutsCrypto.StringNamingFunc("kid-"+string(keyType)))
+				require.NoError(t, err)
+				publicKeyJWK, err := jwk.FromRaw(key.Public())
+				require.NoError(t, err)
+				publicKeyJSON, err := json.Marshal(publicKeyJWK)
+				require.NoError(t, err)
+
+				doc, _, err := resolver.Resolve(did.MustParseDID("did:jwk:"+base64.RawURLEncoding.EncodeToString(publicKeyJSON)), nil)
+
+				require.NoError(t, err)
+				require.Len(t, doc.VerificationMethod, 1)
+				actual, err := doc.VerificationMethod[0].PublicKey()
+				require.NoError(t, err)
+				assert.Equal(t, key.Public(), actual)
+			})
+		}
+	})
+
 	// Test the various failure modes of resolution
 	t.Run("resolution errors", func(t *testing.T) {
 		// Define a test function generator
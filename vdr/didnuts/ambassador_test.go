@@ -49,10 +49,13 @@ import (
 // mockKeyCreator creates a single new key
 type mockKeyCreator struct {
 	key crypto.Key
+	// keyType is the key type of the last New call
+	keyType crypto.KeyType
 }
 
 // New creates a new valid key with the correct KID
-func (m *mockKeyCreator) New(_ context.Context, fn crypto.KIDNamingFunc) (crypto.Key, error) {
+func (m *mockKeyCreator) New(_ context.Context, keyType crypto.KeyType, fn crypto.KIDNamingFunc) (crypto.Key, error) {
+	m.keyType = keyType
 	if m.key == nil {
 		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 		kid, _ := fn(privateKey.Public())
@@ -424,7 +427,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 
 		currentDoc, signingKey, _ := newDidDoc()
 		newDoc := did.Document{Context: []interface{}{did.DIDContextV1URI()}, ID: currentDoc.ID}
-		newCapInv, _ := CreateNewVerificationMethodForDID(audit.TestContext(), currentDoc.ID, &mockKeyCreator{})
+		newCapInv, _ := CreateNewVerificationMethodForDID(audit.TestContext(), currentDoc.ID, crypto.DefaultKeyType, &mockKeyCreator{})
 		newDoc.AddCapabilityInvocation(newCapInv)
 
 		didDocPayload, _ := json.Marshal(newDoc)
@@ -459,7 +462,7 @@ func TestAmbassador_handleUpdateDIDDocument(t *testing.T) {
 
 		currentDoc, signingKey, _ := newDidDoc()
 		newDoc := did.Document{Context: []interface{}{did.DIDContextV1URI()}, ID: currentDoc.ID}
-		newCapInv, _ := CreateNewVerificationMethodForDID(audit.TestContext(), currentDoc.ID, &mockKeyCreator{})
+		newCapInv, _ := CreateNewVerificationMethodForDID(audit.TestContext(), currentDoc.ID, crypto.DefaultKeyType, &mockKeyCreator{})
 		newDoc.AddCapabilityInvocation(newCapInv)
 
 		didDocPayload, _ := json.Marshal(newDoc)
@@ -797,7 +800,7 @@ func Test_uniqueTransactions(t *testing.T) {
 func newDidDocWithOptions(selfControl bool, controllers ...did.DID) (did.Document, jwk.Key, error) {
 	kc := &mockKeyCreator{}
 	docCreator := Creator{KeyStore: kc}
-	didDocument, key, err := docCreator.create(audit.TestContext(), DefaultKeyFlags(), selfControl, controllers)
+	didDocument, key, err := docCreator.create(audit.TestContext(), crypto.ECP256Key, DefaultKeyFlags(), selfControl, controllers)
 	signingKey, _ := jwk.FromRaw(key.Public())
 	thumbStr, _ := crypto.Thumbprint(signingKey)
 	didDocument.ID = did.MustParseDID(fmt.Sprintf("did:nuts:%s", thumbStr))
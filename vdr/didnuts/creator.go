@@ -164,10 +164,14 @@ var ErrInvalidOptions = errors.New("create request has invalid combination of op
 // The key is added to the verificationMethod list and referred to from the Authentication list
 // It also publishes the DID Document to the network.
 func (n Creator) Create(ctx context.Context, options management.CreationOptions) (*did.Document, nutsCrypto.Key, error) {
-	selfControl, controllers, keyFlags, err := parseOptions(options)
+	selfControl, controllers, keyFlags, keyType, err := parseOptions(options)
 	if err != nil {
 		return nil, nil, err
 	}
+	if keyType == nutsCrypto.Ed25519Key {
+		// transactions on the Nuts network can't be signed using EdDSA
+		return nil, nil, fmt.Errorf("%w for did:%s: %s", nutsCrypto.ErrUnsupportedKeyType, MethodName, keyType)
+	}
 
 	// for all controllers given in the options, we need to capture the metadata so the new transaction can reference to it
 	// holder for all metadata of the controllers
@@ -188,7 +192,7 @@ func (n Creator) Create(ctx context.Context, options management.CreationOptions)
 		return nil, nil, ErrInvalidOptions
 	}
 
-	doc, key, err := n.create(ctx, keyFlags, selfControl, controllers)
+	doc, key, err := n.create(ctx, keyType, keyFlags, selfControl, controllers)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -200,7 +204,8 @@ func (n Creator) Create(ctx context.Context, options management.CreationOptions)
 	return doc, key, nil
 }
 
-func parseOptions(options management.CreationOptions) (selfControl bool, controllers []did.DID, keyFlags management.DIDKeyFlags, err error) {
+func parseOptions(options management.CreationOptions) (selfControl bool, controllers []did.DID, keyFlags management.DIDKeyFlags, keyType nutsCrypto.KeyType, err error) {
+	keyType = nutsCrypto.DefaultKeyType
 	for _, opt := range options.All() {
 		switch o := opt.(type) {
 		case keyFlagCreationOption:
@@ -209,19 +214,21 @@ func parseOptions(options management.CreationOptions) (selfControl bool, control
 			selfControl = bool(o)
 		case controllersCreationOption:
 			controllers = o.controllers
+		case management.KeyTypeOption:
+			keyType = nutsCrypto.KeyType(o)
 		default:
-			return false, nil, 0, fmt.Errorf("unknown option: %T", opt)
+			return false, nil, 0, "", fmt.Errorf("unknown option: %T", opt)
 		}
 	}
 	return
 }
 
-func (n Creator) create(ctx context.Context, flags management.DIDKeyFlags, selfControl bool, controllers []did.DID) (*did.Document, nutsCrypto.Key, error) {
+func (n Creator) create(ctx context.Context, keyType nutsCrypto.KeyType, flags management.DIDKeyFlags, selfControl bool, controllers []did.DID) (*did.Document, nutsCrypto.Key, error) {
 	// First, generate a new keyPair with the correct kid
 	// Currently, always keep the key in the keystore. This allows us to change the transaction format and regenerate transactions at a later moment.
 	// Relevant issue:
 	// https://github.com/nuts-foundation/nuts-node/issues/1947
-	key, err := n.KeyStore.New(ctx, didKIDNamingFunc)
+	key, err := n.KeyStore.New(ctx, keyType, didKIDNamingFunc)
 	// } else {
 	// 	key, err = nutsCrypto.NewEphemeralKey(didKIDNamingFunc)
 	// }
@@ -253,7 +260,7 @@ func (n Creator) create(ctx context.Context, flags management.DIDKeyFlags, selfC
 		}
 	} else {
 		// Generate new key for other key capabilities, store the private key
-		capKey, err := n.KeyStore.New(ctx, didSubKIDNamingFunc(didID))
+		capKey, err := n.KeyStore.New(ctx, keyType, didSubKIDNamingFunc(didID))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -25,6 +25,7 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network"
 	"github.com/nuts-foundation/nuts-node/vdr/management"
@@ -45,8 +46,9 @@ var jwkString = `{"crv":"P-256","kid":"did:nuts:3gU9z3j7j4VCboc3qq3Vc5mVVGDNGjfg
 func TestDefaultCreationOptions(t *testing.T) {
 	ops := DefaultCreationOptions()
 
-	selfControl, controllers, keyFlags, err := parseOptions(ops)
+	selfControl, controllers, keyFlags, keyType, err := parseOptions(ops)
 	assert.NoError(t, err)
+	assert.Equal(t, nutsCrypto.DefaultKeyType, keyType)
 	assert.True(t, selfControl)
 	assert.Empty(t, controllers)
 	assert.True(t, keyFlags.Is(management.AssertionMethodUsage))
@@ -118,6 +120,23 @@ func TestCreator_Create(t *testing.T) {
 			assert.Len(t, doc.KeyAgreement, 1)
 		})
 
+		t.Run("key type", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			networkClient := network.NewMockTransactions(ctrl)
+			networkClient.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
+			mockKeyStore := nutsCrypto.NewMockKeyStore(ctrl)
+			mockKeyStore.EXPECT().New(gomock.Any(), nutsCrypto.ECP384Key, gomock.Any()).DoAndReturn(func(_ context.Context, keyType nutsCrypto.KeyType, namingFunc nutsCrypto.KIDNamingFunc) (nutsCrypto.Key, error) {
+				return nutsCrypto.NewMemoryCryptoInstance().New(audit.TestContext(), keyType, namingFunc)
+			})
+			creator := Creator{KeyStore: mockKeyStore, NetworkClient: networkClient}
+
+			doc, _, err := creator.Create(nil, DefaultCreationOptions().With(management.KeyType(nutsCrypto.ECP384Key)))
+
+			require.NoError(t, err)
+			require.Len(t, doc.VerificationMethod, 1)
+			assert.Equal(t, "P-384", doc.VerificationMethod[0].PublicKeyJwk["crv"])
+		})
+
 		t.Run("extra controller", func(t *testing.T) {
 			c, _ := did.ParseDID("did:nuts:controller")
 			ops := DefaultCreationOptions().With(Controllers(*c))
@@ -170,7 +189,7 @@ func TestCreator_Create(t *testing.T) {
 			keyCreator := nutsCrypto.NewMockKeyCreator(ctrl)
 			creator := Creator{KeyStore: keyCreator}
 
-			keyCreator.EXPECT().New(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn nutsCrypto.KIDNamingFunc) (nutsCrypto.Key, error) {
+			keyCreator.EXPECT().New(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ nutsCrypto.KeyType, fn nutsCrypto.KIDNamingFunc) (nutsCrypto.Key, error) {
 				key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 				keyName, _ := fn(key.Public())
 				return nutsCrypto.TestKey{
@@ -210,11 +229,20 @@ func TestCreator_Create(t *testing.T) {
 		assert.Equal(t, ErrInvalidOptions, err)
 	})
 
+	t.Run("error - Ed25519 is not supported", func(t *testing.T) {
+		creator := Creator{KeyStore: &mockKeyCreator{}}
+
+		_, _, err := creator.Create(nil, DefaultCreationOptions().With(management.KeyType(nutsCrypto.Ed25519Key)))
+
+		assert.ErrorIs(t, err, nutsCrypto.ErrUnsupportedKeyType)
+		assert.EqualError(t, err, "unsupported key type for did:nuts: Ed25519")
+	})
+
 	t.Run("error - failed to create key for selfcontrol", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockKeyStore := nutsCrypto.NewMockKeyStore(ctrl)
 		creator := Creator{KeyStore: mockKeyStore}
-		mockKeyStore.EXPECT().New(gomock.Any(), gomock.Any()).Return(nil, errors.New("b00m!"))
+		mockKeyStore.EXPECT().New(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("b00m!"))
 
 		_, _, err := creator.Create(nil, DefaultCreationOptions())
 
@@ -228,7 +256,7 @@ func TestCreator_Create(t *testing.T) {
 		ops := DefaultCreationOptions().
 			With(KeyFlag(management.AssertionMethodUsage)).
 			With(SelfControl(false))
-		mockKeyStore.EXPECT().New(gomock.Any(), gomock.Any()).Return(nil, errors.New("b00m!"))
+		mockKeyStore.EXPECT().New(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("b00m!"))
 
 		_, _, err := creator.Create(nil, ops)
 
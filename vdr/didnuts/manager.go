@@ -96,3 +96,11 @@ func (m Manager) UpdateService(_ context.Context, _ did.DID, _ ssi.URI, _ did.Se
 func (m Manager) DeleteService(_ context.Context, _ did.DID, _ ssi.URI) error {
 	return fmt.Errorf("DeleteService() is not supported for did:%s", MethodName)
 }
+
+func (m Manager) AddVerificationMethod(_ context.Context, _ did.DID, _ management.DIDKeyFlags) (*did.VerificationMethod, error) {
+	return nil, fmt.Errorf("AddVerificationMethod() is not supported for did:%s", MethodName)
+}
+
+func (m Manager) RemoveVerificationMethod(_ context.Context, _ did.DID, _ did.DIDURL) error {
+	return fmt.Errorf("RemoveVerificationMethod() is not supported for did:%s", MethodName)
+}
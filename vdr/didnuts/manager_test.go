@@ -140,3 +140,13 @@ func TestManager_UpdateService(t *testing.T) {
 	_, err := Manager{}.UpdateService(nil, did.DID{}, ssi.MustParseURI("https://example.com"), did.Service{})
 	assert.EqualError(t, err, "UpdateService() is not supported for did:nuts")
 }
+
+func TestManager_AddVerificationMethod(t *testing.T) {
+	_, err := Manager{}.AddVerificationMethod(nil, did.DID{}, 0)
+	assert.EqualError(t, err, "AddVerificationMethod() is not supported for did:nuts")
+}
+
+func TestManager_RemoveVerificationMethod(t *testing.T) {
+	err := Manager{}.RemoveVerificationMethod(nil, did.DID{}, did.DIDURL{})
+	assert.EqualError(t, err, "RemoveVerificationMethod() is not supported for did:nuts")
+}
@@ -54,6 +54,7 @@ func (u Manipulator) Deactivate(ctx context.Context, id did.DID) error {
 
 // AddVerificationMethod adds a new key as a VerificationMethod to the document.
 // The key is added to the VerficationMethod relationships specified by keyUsage.
+// The new key is of the same type as the key of the existing verification methods, so the DID keeps its key type when keys are rotated.
 func (u Manipulator) AddVerificationMethod(ctx context.Context, id did.DID, keyUsage management.DIDKeyFlags) (*did.VerificationMethod, error) {
 	doc, meta, err := u.Resolver.Resolve(id, &resolver.ResolveMetadata{AllowDeactivated: true})
 	if err != nil {
@@ -62,7 +63,21 @@ func (u Manipulator) AddVerificationMethod(ctx context.Context, id did.DID, keyU
 	if meta.Deactivated {
 		return nil, resolver.ErrDeactivated
 	}
-	method, err := CreateNewVerificationMethodForDID(ctx, doc.ID, u.KeyCreator)
+	keyType := nutsCrypto.DefaultKeyType
+	if len(doc.VerificationMethod) > 0 {
+		publicKey, err := doc.VerificationMethod[0].PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("public key of verification method '%s': %w", doc.VerificationMethod[0].ID, err)
+		}
+		if keyType, err = nutsCrypto.KeyTypeOf(publicKey); err != nil {
+			return nil, fmt.Errorf("key type of verification method '%s': %w", doc.VerificationMethod[0].ID, err)
+		}
+	}
+	if keyType == nutsCrypto.Ed25519Key {
+		// transactions on the Nuts network can't be signed using EdDSA
+		return nil, fmt.Errorf("%w for did:%s: %s", nutsCrypto.ErrUnsupportedKeyType, MethodName, keyType)
+	}
+	method, err := CreateNewVerificationMethodForDID(ctx, doc.ID, keyType, u.KeyCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -95,9 +110,9 @@ func (u Manipulator) RemoveVerificationMethod(ctx context.Context, id did.DID, k
 }
 
 // CreateNewVerificationMethodForDID creates a new VerificationMethod of type JsonWebKey2020
-// with a freshly generated key for a given DID.
-func CreateNewVerificationMethodForDID(ctx context.Context, id did.DID, keyCreator nutsCrypto.KeyCreator) (*did.VerificationMethod, error) {
-	key, err := keyCreator.New(ctx, didSubKIDNamingFunc(id))
+// with a freshly generated key of the given type for a given DID.
+func CreateNewVerificationMethodForDID(ctx context.Context, id did.DID, keyType nutsCrypto.KeyType, keyCreator nutsCrypto.KeyCreator) (*did.VerificationMethod, error) {
+	key, err := keyCreator.New(ctx, keyType, didSubKIDNamingFunc(id))
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +19,11 @@ package didnuts
 
 import (
 	"context"
+	crypto2 "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"errors"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/vdr/management"
@@ -115,7 +120,7 @@ func TestManipulator_CreateNewAuthenticationMethodForDID(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		// Prepare a document with an authenticationMethod:
 		document := &did.Document{ID: *id123}
-		method, err := CreateNewVerificationMethodForDID(audit.TestContext(), document.ID, kc)
+		method, err := CreateNewVerificationMethodForDID(audit.TestContext(), document.ID, crypto.DefaultKeyType, kc)
 		require.NoError(t, err)
 		document.AddCapabilityInvocation(method)
 
@@ -129,12 +134,18 @@ func TestManipulator_CreateNewAuthenticationMethodForDID(t *testing.T) {
 func TestManipulator_AddKey(t *testing.T) {
 	id, _ := did.ParseDID("did:nuts:123")
 	keyID, _ := did.ParseDIDURL("did:nuts:123#key-1")
+	newVerificationMethod := func(t *testing.T, publicKey crypto2.PublicKey) *did.VerificationMethod {
+		vm, err := did.NewVerificationMethod(*keyID, ssi.JsonWebKey2020, *id, publicKey)
+		require.NoError(t, err)
+		return vm
+	}
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 
 	t.Run("ok - add a new key", func(t *testing.T) {
 		ctx := newManipulatorTestContext(t)
 
 		currentDIDDocument := did.Document{ID: *id, Controller: []did.DID{*id}}
-		currentDIDDocument.AddCapabilityInvocation(&did.VerificationMethod{ID: *keyID})
+		currentDIDDocument.AddCapabilityInvocation(newVerificationMethod(t, p256Key.Public()))
 		ctx.mockResolver.EXPECT().Resolve(*id, &resolver.ResolveMetadata{AllowDeactivated: true}).Return(&currentDIDDocument, &resolver.DocumentMetadata{}, nil)
 		var updatedDocument did.Document
 		ctx.mockUpdater.EXPECT().Update(ctx.audit, *id, gomock.Any()).Do(func(_ context.Context, _ did.DID, doc did.Document) {
@@ -150,14 +161,51 @@ func TestManipulator_AddKey(t *testing.T) {
 		assert.Len(t, updatedDocument.Authentication, 1)
 		assert.Contains(t, updatedDocument.VerificationMethod, key)
 		assert.Equal(t, updatedDocument.Authentication[0].VerificationMethod, key)
+		assert.Equal(t, crypto.ECP256Key, ctx.mockKeyCreator.keyType)
 	})
+	t.Run("ok - new key has the key type of the existing verification method", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
+		p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		currentDIDDocument := did.Document{ID: *id, Controller: []did.DID{*id}}
+		currentDIDDocument.AddCapabilityInvocation(newVerificationMethod(t, p384Key.Public()))
+		ctx.mockResolver.EXPECT().Resolve(*id, &resolver.ResolveMetadata{AllowDeactivated: true}).Return(&currentDIDDocument, &resolver.DocumentMetadata{}, nil)
+		ctx.mockUpdater.EXPECT().Update(ctx.audit, *id, gomock.Any())
 
-	t.Run("error - vdr.update throws an error", func(t *testing.T) {
+		_, err := ctx.manipulator.AddVerificationMethod(ctx.audit, *id, management.AuthenticationUsage)
+
+		require.NoError(t, err)
+		assert.Equal(t, crypto.ECP384Key, ctx.mockKeyCreator.keyType)
+	})
+	t.Run("error - Ed25519 keys aren't supported on the Nuts network", func(t *testing.T) {
 		ctx := newManipulatorTestContext(t)
+		edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+		currentDIDDocument := did.Document{ID: *id, Controller: []did.DID{*id}}
+		currentDIDDocument.AddCapabilityInvocation(newVerificationMethod(t, edKey))
+		ctx.mockResolver.EXPECT().Resolve(*id, &resolver.ResolveMetadata{AllowDeactivated: true}).Return(&currentDIDDocument, &resolver.DocumentMetadata{}, nil)
+
+		key, err := ctx.manipulator.AddVerificationMethod(ctx.audit, *id, management.AuthenticationUsage)
 
+		assert.ErrorIs(t, err, crypto.ErrUnsupportedKeyType)
+		assert.Nil(t, key)
+	})
+	t.Run("error - existing verification method has no public key", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
 		currentDIDDocument := did.Document{ID: *id, Controller: []did.DID{*id}}
 		currentDIDDocument.AddCapabilityInvocation(&did.VerificationMethod{ID: *keyID})
 		ctx.mockResolver.EXPECT().Resolve(*id, &resolver.ResolveMetadata{AllowDeactivated: true}).Return(&currentDIDDocument, &resolver.DocumentMetadata{}, nil)
+
+		key, err := ctx.manipulator.AddVerificationMethod(ctx.audit, *id, management.AuthenticationUsage)
+
+		assert.ErrorContains(t, err, "public key of verification method 'did:nuts:123#key-1'")
+		assert.Nil(t, key)
+	})
+
+	t.Run("error - vdr.update throws an error", func(t *testing.T) {
+		ctx := newManipulatorTestContext(t)
+
+		currentDIDDocument := did.Document{ID: *id, Controller: []did.DID{*id}}
+		currentDIDDocument.AddCapabilityInvocation(newVerificationMethod(t, p256Key.Public()))
+		ctx.mockResolver.EXPECT().Resolve(*id, &resolver.ResolveMetadata{AllowDeactivated: true}).Return(&currentDIDDocument, &resolver.DocumentMetadata{}, nil)
 		ctx.mockUpdater.EXPECT().Update(ctx.audit, *id, gomock.Any()).Return(resolver.ErrNotFound)
 
 		key, err := ctx.manipulator.AddVerificationMethod(ctx.audit, *id, 0)
@@ -0,0 +1,172 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didweb
+
+import (
+	"container/list"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pquerna/cachecontrol/cacheobject"
+)
+
+// cacheEntry is a DID document fetched over HTTP, along with the information needed to determine its freshness
+// and to revalidate it with the origin server.
+type cacheEntry struct {
+	url  string
+	data []byte
+	// etag and lastModified are used to make conditional requests when the entry needs to be revalidated.
+	etag         string
+	lastModified string
+	// freshUntil is the moment the entry must be revalidated.
+	freshUntil time.Time
+	// mustRevalidate indicates the origin server doesn't allow the entry to be served when it's stale.
+	mustRevalidate bool
+}
+
+// fresh returns whether the entry can be served without contacting the origin server.
+func (e cacheEntry) fresh(now time.Time) bool {
+	return now.Before(e.freshUntil)
+}
+
+// usableWhenStale returns whether the entry may be served when the origin server can't be reached.
+func (e cacheEntry) usableWhenStale(now time.Time, maxStale time.Duration) bool {
+	return !e.mustRevalidate && now.Before(e.freshUntil.Add(maxStale))
+}
+
+// CacheStatistics contains the counters of the did:web document cache.
+type CacheStatistics struct {
+	// Hits is the number of resolutions served from cache without contacting the origin server.
+	Hits int
+	// Misses is the number of resolutions for which the document had to be downloaded.
+	Misses int
+	// Revalidations is the number of resolutions served from cache after the origin server indicated it was unchanged.
+	Revalidations int
+	// StaleHits is the number of resolutions served from an expired cache entry, because the origin server was unreachable.
+	StaleHits int
+	// Entries is the number of DID documents currently in the cache.
+	Entries int
+}
+
+// documentCache is a bounded, least-recently-used cache of DID documents, keyed on URL.
+type documentCache struct {
+	maxEntries int
+	maxStale   time.Duration
+	mux        sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	stats      CacheStatistics
+}
+
+func newDocumentCache(maxEntries int, maxStale time.Duration) *documentCache {
+	return &documentCache{
+		maxEntries: maxEntries,
+		maxStale:   maxStale,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns a copy of the entry for the given URL, or nil if it isn't cached.
+func (c *documentCache) get(url string) *cacheEntry {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	element, ok := c.entries[url]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(element)
+	entry := *element.Value.(*cacheEntry)
+	return &entry
+}
+
+// put adds or replaces the entry, evicting the least recently used entry if the cache is full.
+func (c *documentCache) put(entry cacheEntry) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if element, ok := c.entries[entry.url]; ok {
+		element.Value = &entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[entry.url] = c.order.PushFront(&entry)
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).url)
+	}
+}
+
+// remove deletes the entry for the given URL, if it exists.
+func (c *documentCache) remove(url string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if element, ok := c.entries[url]; ok {
+		c.order.Remove(element)
+		delete(c.entries, url)
+	}
+}
+
+// record updates the statistics using the given function.
+func (c *documentCache) record(fn func(stats *CacheStatistics)) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	fn(&c.stats)
+}
+
+// statistics returns a snapshot of the cache statistics.
+func (c *documentCache) statistics() CacheStatistics {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	result := c.stats
+	result.Entries = c.order.Len()
+	return result
+}
+
+// cachePolicy determines, according to RFC 9111, whether a response may be stored and until when it is fresh.
+// Responses without explicit freshness information are stored, but need to be revalidated on every use.
+func cachePolicy(header http.Header, now time.Time) (storable bool, freshUntil time.Time, mustRevalidate bool) {
+	directives, err := cacheobject.ParseResponseCacheControl(header.Get("Cache-Control"))
+	if err != nil {
+		// Invalid Cache-Control header, don't risk caching something the server didn't intend to be cached.
+		return false, time.Time{}, false
+	}
+	if directives.NoStore {
+		return false, time.Time{}, false
+	}
+	freshUntil = now
+	if directives.NoCachePresent {
+		// may be stored, but must always be revalidated
+	} else if directives.MaxAge != -1 {
+		lifetime := time.Duration(directives.MaxAge) * time.Second
+		if age, err := strconv.Atoi(header.Get("Age")); err == nil && age > 0 {
+			lifetime -= time.Duration(age) * time.Second
+		}
+		freshUntil = now.Add(lifetime)
+	} else if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = now
+		}
+		freshUntil = now.Add(expires.Sub(date))
+	}
+	return true, freshUntil, directives.MustRevalidate
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2024 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didweb
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_documentCache(t *testing.T) {
+	t.Run("least recently used entry is evicted", func(t *testing.T) {
+		cache := newDocumentCache(2, time.Hour)
+		cache.put(cacheEntry{url: "a"})
+		cache.put(cacheEntry{url: "b"})
+		_ = cache.get("a")
+		cache.put(cacheEntry{url: "c"})
+
+		assert.NotNil(t, cache.get("a"))
+		assert.Nil(t, cache.get("b"))
+		assert.NotNil(t, cache.get("c"))
+		assert.Equal(t, 2, cache.statistics().Entries)
+	})
+	t.Run("existing entry is replaced", func(t *testing.T) {
+		cache := newDocumentCache(2, time.Hour)
+		cache.put(cacheEntry{url: "a", etag: "1"})
+		cache.put(cacheEntry{url: "a", etag: "2"})
+
+		assert.Equal(t, "2", cache.get("a").etag)
+		assert.Equal(t, 1, cache.statistics().Entries)
+	})
+	t.Run("remove", func(t *testing.T) {
+		cache := newDocumentCache(2, time.Hour)
+		cache.put(cacheEntry{url: "a"})
+		cache.remove("a")
+		cache.remove("b")
+
+		assert.Nil(t, cache.get("a"))
+		assert.Equal(t, 0, cache.statistics().Entries)
+	})
+}
+
+func Test_cacheEntry(t *testing.T) {
+	now := time.Now()
+	entry := cacheEntry{freshUntil: now.Add(time.Minute)}
+
+	assert.True(t, entry.fresh(now))
+	assert.False(t, entry.fresh(now.Add(time.Minute)))
+	assert.True(t, entry.usableWhenStale(now.Add(2*time.Minute), time.Hour))
+	assert.False(t, entry.usableWhenStale(now.Add(2*time.Hour), time.Hour))
+	entry.mustRevalidate = true
+	assert.False(t, entry.usableWhenStale(now.Add(2*time.Minute), time.Hour))
+}
+
+func Test_cachePolicy(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name           string
+		header         http.Header
+		storable       bool
+		freshUntil     time.Time
+		mustRevalidate bool
+	}{
+		{
+			name:       "no caching headers",
+			header:     http.Header{},
+			storable:   true,
+			freshUntil: now,
+		},
+		{
+			name:       "max-age",
+			header:     http.Header{"Cache-Control": {"public, max-age=300"}},
+			storable:   true,
+			freshUntil: now.Add(5 * time.Minute),
+		},
+		{
+			name:       "max-age with age",
+			header:     http.Header{"Cache-Control": {"max-age=300"}, "Age": {"100"}},
+			storable:   true,
+			freshUntil: now.Add(200 * time.Second),
+		},
+		{
+			name:       "expires",
+			header:     http.Header{"Expires": {"Mon, 01 Jan 2024 13:00:00 GMT"}, "Date": {"Mon, 01 Jan 2024 12:30:00 GMT"}},
+			storable:   true,
+			freshUntil: now.Add(30 * time.Minute),
+		},
+		{
+			name:       "max-age takes precedence over expires",
+			header:     http.Header{"Cache-Control": {"max-age=60"}, "Expires": {"Mon, 01 Jan 2024 13:00:00 GMT"}},
+			storable:   true,
+			freshUntil: now.Add(time.Minute),
+		},
+		{
+			name:       "no-cache",
+			header:     http.Header{"Cache-Control": {"no-cache, max-age=60"}},
+			storable:   true,
+			freshUntil: now,
+		},
+		{
+			name:           "must-revalidate",
+			header:         http.Header{"Cache-Control": {"max-age=60, must-revalidate"}},
+			storable:       true,
+			freshUntil:     now.Add(time.Minute),
+			mustRevalidate: true,
+		},
+		{
+			name:   "no-store",
+			header: http.Header{"Cache-Control": {"no-store"}},
+		},
+		{
+			name:   "invalid Cache-Control",
+			header: http.Header{"Cache-Control": {"max-age=abc"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storable, freshUntil, mustRevalidate := cachePolicy(tc.header, now)
+
+			assert.Equal(t, tc.storable, storable)
+			assert.Equal(t, tc.freshUntil, freshUntil)
+			assert.Equal(t, tc.mustRevalidate, mustRevalidate)
+		})
+	}
+}
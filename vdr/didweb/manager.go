@@ -32,8 +32,12 @@ import (
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"gorm.io/gorm"
 	"net/url"
+	"time"
 )
 
+// nowFunc is used to determine the current time, so it can be overridden in tests.
+var nowFunc = time.Now
+
 func DefaultCreationOptions() management.CreationOptions {
 	return management.Create(MethodName)
 }
@@ -52,19 +56,23 @@ func UserPath(path string) management.CreationOption {
 var _ management.DocumentManager = (*Manager)(nil)
 
 // NewManager creates a new Manager to create and update did:web DID documents.
-func NewManager(baseURL url.URL, keyStore crypto.KeyStore, db *gorm.DB) *Manager {
+// keyGracePeriod specifies how long a removed verification method stays published in the DID document,
+// so that signatures created with its key can still be verified.
+func NewManager(baseURL url.URL, keyStore crypto.KeyStore, db *gorm.DB, keyGracePeriod time.Duration) *Manager {
 	return &Manager{
-		store:    &sqlStore{db: db},
-		baseURL:  baseURL,
-		keyStore: keyStore,
+		store:          &sqlStore{db: db},
+		baseURL:        baseURL,
+		keyStore:       keyStore,
+		keyGracePeriod: keyGracePeriod,
 	}
 }
 
 // Manager creates and updates did:web documents
 type Manager struct {
-	baseURL  url.URL
-	store    store
-	keyStore crypto.KeyStore
+	baseURL        url.URL
+	store          store
+	keyStore       crypto.KeyStore
+	keyGracePeriod time.Duration
 }
 
 func (m Manager) Deactivate(ctx context.Context, subjectDID did.DID) error {
@@ -87,34 +95,116 @@ func (m Manager) Deactivate(ctx context.Context, subjectDID did.DID) error {
 	return errors.Join(append([]error{errors.New("did:web DID deleted, but could not remove one or more private keys")}, deleteErrors...)...)
 }
 
-func (m Manager) RemoveVerificationMethod(ctx context.Context, id did.DID, keyID did.DIDURL) error {
-	return errors.New("RemoveVerificationMethod() is not yet supported for did:web")
+// RemoveVerificationMethod removes the verification method from the DID document.
+// If a key grace period is configured, the verification method stays published until the grace period has passed.
+// Its private key is deleted when the verification method is retired (see DeleteRetiredVerificationMethods).
+func (m Manager) RemoveVerificationMethod(ctx context.Context, subjectDID did.DID, keyID did.DIDURL) error {
+	activeMethods, err := m.store.listActive(subjectDID)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, curr := range activeMethods {
+		if curr.Equals(keyID) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errVerificationMethodNotFound
+	}
+	if len(activeMethods) == 1 {
+		return errors.New("can't remove the last active verification method of a did:web DID document, deactivate the DID instead")
+	}
+	if m.keyGracePeriod > 0 {
+		return m.store.retireVerificationMethod(subjectDID, keyID, nowFunc().Add(m.keyGracePeriod))
+	}
+	if err := m.store.deleteVerificationMethod(subjectDID, keyID); err != nil {
+		return err
+	}
+	return m.deletePrivateKey(ctx, keyID)
 }
 
-func (m Manager) AddVerificationMethod(_ context.Context, _ did.DID, _ management.DIDKeyFlags) (*did.VerificationMethod, error) {
-	return nil, errors.New("AddVerificationMethod() is not yet supported for did:web")
+// AddVerificationMethod generates a new key and adds it as verification method to the DID document.
+// The new key is of the same type as the key of the existing verification methods, so the DID keeps its key type when keys are rotated.
+// The key usage is ignored: did:web verification methods are added to all verification relationships.
+// The new verification method takes precedence over existing ones that are being retired.
+func (m Manager) AddVerificationMethod(ctx context.Context, subjectDID did.DID, _ management.DIDKeyFlags) (*did.VerificationMethod, error) {
+	verificationMethods, _, err := m.store.get(subjectDID)
+	if err != nil {
+		return nil, err
+	}
+	keyType := crypto.DefaultKeyType
+	if len(verificationMethods) > 0 {
+		publicKey, err := verificationMethods[0].PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("public key of verification method '%s': %w", verificationMethods[0].ID, err)
+		}
+		if keyType, err = crypto.KeyTypeOf(publicKey); err != nil {
+			return nil, fmt.Errorf("key type of verification method '%s': %w", verificationMethods[0].ID, err)
+		}
+	}
+	_, verificationMethod, err := m.createVerificationMethod(ctx, subjectDID, uuid.NewString(), keyType)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.createVerificationMethod(subjectDID, *verificationMethod); err != nil {
+		return nil, fmt.Errorf("store verification method: %w", err)
+	}
+	return verificationMethod, nil
+}
+
+// DeleteRetiredVerificationMethods deletes the verification methods (and their private keys) that have passed their grace period.
+func (m Manager) DeleteRetiredVerificationMethods(ctx context.Context) error {
+	retired, err := m.store.listRetired(nowFunc())
+	if err != nil {
+		return err
+	}
+	var deleteErrors []error
+	for _, keyID := range retired {
+		if err := m.store.deleteVerificationMethod(keyID.DID, keyID); err != nil {
+			deleteErrors = append(deleteErrors, fmt.Errorf("verification method '%s': %w", keyID, err))
+			continue
+		}
+		if err := m.deletePrivateKey(ctx, keyID); err != nil {
+			deleteErrors = append(deleteErrors, fmt.Errorf("verification method '%s': %w", keyID, err))
+		}
+	}
+	return errors.Join(deleteErrors...)
+}
+
+// deletePrivateKey deletes the private key of the given verification method. It ignores keys that don't exist (anymore).
+func (m Manager) deletePrivateKey(ctx context.Context, keyID did.DIDURL) error {
+	err := m.keyStore.Delete(ctx, keyID.String())
+	if errors.Is(err, crypto.ErrPrivateKeyNotFound) {
+		return nil
+	}
+	return err
 }
 
 // Create creates a new did:web document.
 func (m Manager) Create(ctx context.Context, opts management.CreationOptions) (*did.Document, crypto.Key, error) {
 	pathPart := uuid.NewString()
+	keyType := crypto.DefaultKeyType
 	for _, opt := range opts.All() {
 		switch option := opt.(type) {
 		case userPathOption:
 			pathPart = option.path
+		case management.KeyTypeOption:
+			keyType = crypto.KeyType(option)
 		default:
 			return nil, nil, fmt.Errorf("unknown option: %T", option)
 		}
 	}
-	return m.create(ctx, pathPart)
+	return m.create(ctx, pathPart, keyType)
 }
 
-func (m Manager) create(ctx context.Context, mostSignificantBits string) (*did.Document, crypto.Key, error) {
+func (m Manager) create(ctx context.Context, mostSignificantBits string, keyType crypto.KeyType) (*did.Document, crypto.Key, error) {
 	newDID, err := URLToDID(*m.baseURL.JoinPath(mostSignificantBits))
 	if err != nil {
 		return nil, nil, err
 	}
-	verificationMethodKey, verificationMethod, err := m.createVerificationMethod(ctx, *newDID)
+	verificationMethodKey, verificationMethod, err := m.createVerificationMethod(ctx, *newDID, "0", keyType)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -126,12 +216,12 @@ func (m Manager) create(ctx context.Context, mostSignificantBits string) (*did.D
 	return &document, verificationMethodKey, nil
 }
 
-func (m Manager) createVerificationMethod(ctx context.Context, ownerDID did.DID) (crypto.Key, *did.VerificationMethod, error) {
+func (m Manager) createVerificationMethod(ctx context.Context, ownerDID did.DID, fragment string, keyType crypto.KeyType) (crypto.Key, *did.VerificationMethod, error) {
 	verificationMethodID := did.DIDURL{
 		DID:      ownerDID,
-		Fragment: "0", // TODO: Which fragment should we use? Thumbprint, UUID, index, etc...
+		Fragment: fragment,
 	}
-	verificationMethodKey, err := m.keyStore.New(ctx, func(key crypt.PublicKey) (string, error) {
+	verificationMethodKey, err := m.keyStore.New(ctx, keyType, func(key crypt.PublicKey) (string, error) {
 		return verificationMethodID.String(), nil
 	})
 	if err != nil {
@@ -199,11 +289,6 @@ func (m Manager) DeleteService(_ context.Context, subjectDID did.DID, serviceID
 }
 
 func buildDocument(subject did.DID, verificationMethods []did.VerificationMethod, services []did.Service) did.Document {
-	var vms []*did.VerificationMethod
-	for _, verificationMethod := range verificationMethods {
-		vms = append(vms, &verificationMethod)
-	}
-
 	document := did.Document{
 		Context: []interface{}{
 			ssi.MustParseURI(jsonld.Jws2020Context),
@@ -212,12 +297,14 @@ func buildDocument(subject did.DID, verificationMethods []did.VerificationMethod
 		ID:      subject,
 		Service: services,
 	}
-	for _, verificationMethod := range verificationMethods {
-		document.AddAssertionMethod(&verificationMethod)
-		document.AddAuthenticationMethod(&verificationMethod)
-		document.AddKeyAgreement(&verificationMethod)
-		document.AddCapabilityDelegation(&verificationMethod)
-		document.AddCapabilityInvocation(&verificationMethod)
+	for i := range verificationMethods {
+		// take the address of the slice element, not the loop variable, since the document holds on to the pointer
+		verificationMethod := &verificationMethods[i]
+		document.AddAssertionMethod(verificationMethod)
+		document.AddAuthenticationMethod(verificationMethod)
+		document.AddKeyAgreement(verificationMethod)
+		document.AddCapabilityDelegation(verificationMethod)
+		document.AddCapabilityInvocation(verificationMethod)
 	}
 	return document
 }
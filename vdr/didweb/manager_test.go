@@ -29,12 +29,14 @@ import (
 	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/test"
+	"github.com/nuts-foundation/nuts-node/vdr/management"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"strings"
 	"testing"
+	"time"
 )
 
 var baseURL = test.MustParseURL("https://example.com")
@@ -60,12 +62,12 @@ func TestManager_Create(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		keyStore := nutsCrypto.NewMockKeyStore(ctrl)
-		keyStore.EXPECT().New(gomock.Any(), gomock.Any()).Return(nutsCrypto.TestPublicKey{
+		keyStore.EXPECT().New(gomock.Any(), gomock.Any(), gomock.Any()).Return(nutsCrypto.TestPublicKey{
 			PublicKey: publicKey,
 		}, nil)
-		m := NewManager(*baseURL, keyStore, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, keyStore, storageEngine.GetSQLDatabase(), 0)
 
-		document, key, err := m.create(audit.TestContext(), "e9d4b80d-59eb-4f35-ada8-c75f6e14bbc4")
+		document, key, err := m.create(audit.TestContext(), "e9d4b80d-59eb-4f35-ada8-c75f6e14bbc4", nutsCrypto.ECP256Key)
 		require.NoError(t, err)
 		require.NotNil(t, document)
 		require.NotNil(t, key)
@@ -113,10 +115,10 @@ func TestManager_Create(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		keyStore := nutsCrypto.NewMockKeyStore(ctrl)
-		keyStore.EXPECT().New(gomock.Any(), gomock.Any()).Return(nutsCrypto.TestPublicKey{
+		keyStore.EXPECT().New(gomock.Any(), gomock.Any(), gomock.Any()).Return(nutsCrypto.TestPublicKey{
 			PublicKey: publicKey,
 		}, nil)
-		m := NewManager(*baseURL, keyStore, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, keyStore, storageEngine.GetSQLDatabase(), 0)
 
 		document, key, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(UserPath("test")))
 		require.NoError(t, err)
@@ -124,11 +126,26 @@ func TestManager_Create(t *testing.T) {
 		require.NotNil(t, key)
 		assert.True(t, strings.HasSuffix(document.ID.String(), ":test"))
 	})
+	t.Run("with KeyType option", func(t *testing.T) {
+		for _, keyType := range nutsCrypto.SupportedKeyTypes() {
+			t.Run(string(keyType), func(t *testing.T) {
+				resetStore(t, storageEngine.GetSQLDatabase())
+				m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
+
+				document, key, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(management.KeyType(keyType)))
+				require.NoError(t, err)
+				require.Len(t, document.VerificationMethod, 1)
+				verificationMethodKey, err := document.VerificationMethod[0].PublicKey()
+				require.NoError(t, err)
+				assert.Equal(t, key.Public(), verificationMethodKey)
+			})
+		}
+	})
 	t.Run("with unknown option", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		keyStore := nutsCrypto.NewMockKeyStore(ctrl)
-		m := NewManager(*baseURL, keyStore, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, keyStore, storageEngine.GetSQLDatabase(), 0)
 
 		_, _, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(""))
 		require.EqualError(t, err, "unknown option: string")
@@ -141,7 +158,7 @@ func TestManager_IsOwner(t *testing.T) {
 
 	t.Run("not owned (empty store)", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 
 		owned, err := m.IsOwner(audit.TestContext(), subjectDID)
 		require.NoError(t, err)
@@ -149,7 +166,7 @@ func TestManager_IsOwner(t *testing.T) {
 	})
 	t.Run("not owned (other DID)", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 		_, _, err := m.Create(audit.TestContext(), DefaultCreationOptions())
 		require.NoError(t, err)
 
@@ -159,7 +176,7 @@ func TestManager_IsOwner(t *testing.T) {
 	})
 	t.Run("owned", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 		document, _, err := m.Create(audit.TestContext(), DefaultCreationOptions())
 		require.NoError(t, err)
 
@@ -175,7 +192,7 @@ func TestManager_ListOwned(t *testing.T) {
 
 	t.Run("empty store", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 
 		dids, err := m.ListOwned(audit.TestContext())
 		require.NoError(t, err)
@@ -183,7 +200,7 @@ func TestManager_ListOwned(t *testing.T) {
 	})
 	t.Run("single DID", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 		document, _, err := m.Create(audit.TestContext(), DefaultCreationOptions())
 		require.NoError(t, err)
 
@@ -193,7 +210,7 @@ func TestManager_ListOwned(t *testing.T) {
 	})
 	t.Run("multiple DIDs", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 		document1, _, err := m.Create(audit.TestContext(), DefaultCreationOptions())
 		require.NoError(t, err)
 		document2, _, err := m.Create(audit.TestContext(), DefaultCreationOptions())
@@ -211,7 +228,7 @@ func TestManager_Resolve(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 
 		document, _, err := m.Resolve(did.MustParseDID("did:web:example.com:1234"), nil)
 		require.ErrorIs(t, err, resolver.ErrNotFound)
@@ -219,7 +236,7 @@ func TestManager_Resolve(t *testing.T) {
 	})
 	t.Run("ok", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 		document, _, err := m.Create(audit.TestContext(), DefaultCreationOptions())
 		require.NoError(t, err)
 		expected, _ := document.MarshalJSON()
@@ -235,7 +252,7 @@ func TestManager_CreateService(t *testing.T) {
 	t.Run("with ID", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		store := NewMockstore(ctrl)
-		m := NewManager(*baseURL, nil, nil)
+		m := NewManager(*baseURL, nil, nil, 0)
 		m.store = store
 
 		expected := did.Service{
@@ -253,7 +270,7 @@ func TestManager_CreateService(t *testing.T) {
 	t.Run("random ID", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		store := NewMockstore(ctrl)
-		m := NewManager(*baseURL, nil, nil)
+		m := NewManager(*baseURL, nil, nil, 0)
 		m.store = store
 
 		input := did.Service{
@@ -279,7 +296,7 @@ func TestManager_UpdateService(t *testing.T) {
 	t.Run("ID not set", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		store := NewMockstore(ctrl)
-		m := NewManager(*baseURL, nil, nil)
+		m := NewManager(*baseURL, nil, nil, 0)
 		m.store = store
 
 		serviceID := ssi.MustParseURI(subjectDID.String() + "#api")
@@ -302,7 +319,7 @@ func TestManager_UpdateService(t *testing.T) {
 	t.Run("ID is set", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		store := NewMockstore(ctrl)
-		m := NewManager(*baseURL, nil, nil)
+		m := NewManager(*baseURL, nil, nil, 0)
 		m.store = store
 
 		serviceID := ssi.MustParseURI(subjectDID.String() + "#api")
@@ -326,7 +343,7 @@ func TestManager_UpdateService(t *testing.T) {
 func TestManager_DeleteService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	store := NewMockstore(ctrl)
-	m := NewManager(*baseURL, nil, nil)
+	m := NewManager(*baseURL, nil, nil, 0)
 	m.store = store
 
 	serviceID := ssi.MustParseURI(subjectDID.String() + "#api")
@@ -344,7 +361,7 @@ func TestManager_Deactivate(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
-		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
 
 		err := m.Deactivate(ctx, subjectDID)
 		require.ErrorIs(t, err, resolver.ErrNotFound)
@@ -352,7 +369,7 @@ func TestManager_Deactivate(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		cryptoInstance := nutsCrypto.NewMemoryCryptoInstance()
-		m := NewManager(*baseURL, cryptoInstance, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, cryptoInstance, storageEngine.GetSQLDatabase(), 0)
 		document, _, err := m.Create(ctx, DefaultCreationOptions())
 		require.NoError(t, err)
 
@@ -371,7 +388,7 @@ func TestManager_Deactivate(t *testing.T) {
 	t.Run("unable to delete private key", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		cryptoInstance := nutsCrypto.NewMemoryCryptoInstance()
-		m := NewManager(*baseURL, cryptoInstance, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, cryptoInstance, storageEngine.GetSQLDatabase(), 0)
 		document, _, err := m.Create(ctx, DefaultCreationOptions())
 		require.NoError(t, err)
 
@@ -383,3 +400,147 @@ func TestManager_Deactivate(t *testing.T) {
 		require.EqualError(t, err, "did:web DID deleted, but could not remove one or more private keys\nverification method '"+document.VerificationMethod[0].ID.String()+"': private key not found")
 	})
 }
+
+func TestManager_AddVerificationMethod(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	ctx := audit.TestContext()
+
+	t.Run("ok", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		cryptoInstance := nutsCrypto.NewMemoryCryptoInstance()
+		m := NewManager(*baseURL, cryptoInstance, storageEngine.GetSQLDatabase(), 0)
+		document, _, err := m.Create(ctx, DefaultCreationOptions())
+		require.NoError(t, err)
+
+		verificationMethod, err := m.AddVerificationMethod(ctx, document.ID, 0)
+
+		require.NoError(t, err)
+		require.NotNil(t, verificationMethod)
+		assert.True(t, cryptoInstance.Exists(ctx, verificationMethod.ID.String()))
+		resolvedDocument, _, err := m.Resolve(document.ID, nil)
+		require.NoError(t, err)
+		assert.Len(t, resolvedDocument.VerificationMethod, 2)
+		assert.Len(t, resolvedDocument.AssertionMethod, 2)
+	})
+	t.Run("new key has the key type of the existing verification method", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
+		document, _, err := m.Create(ctx, DefaultCreationOptions().With(management.KeyType(nutsCrypto.Ed25519Key)))
+		require.NoError(t, err)
+
+		verificationMethod, err := m.AddVerificationMethod(ctx, document.ID, 0)
+
+		require.NoError(t, err)
+		publicKey, err := verificationMethod.PublicKey()
+		require.NoError(t, err)
+		keyType, err := nutsCrypto.KeyTypeOf(publicKey)
+		require.NoError(t, err)
+		assert.Equal(t, nutsCrypto.Ed25519Key, keyType)
+	})
+	t.Run("DID does not exist", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
+
+		_, err := m.AddVerificationMethod(ctx, subjectDID, 0)
+
+		require.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+}
+
+func TestManager_RemoveVerificationMethod(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	ctx := audit.TestContext()
+
+	t.Run("without grace period", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		cryptoInstance := nutsCrypto.NewMemoryCryptoInstance()
+		m := NewManager(*baseURL, cryptoInstance, storageEngine.GetSQLDatabase(), 0)
+		document, _, err := m.Create(ctx, DefaultCreationOptions())
+		require.NoError(t, err)
+		oldKeyID := document.VerificationMethod[0].ID
+		newVerificationMethod, err := m.AddVerificationMethod(ctx, document.ID, 0)
+		require.NoError(t, err)
+
+		err = m.RemoveVerificationMethod(ctx, document.ID, oldKeyID)
+
+		require.NoError(t, err)
+		resolvedDocument, _, err := m.Resolve(document.ID, nil)
+		require.NoError(t, err)
+		require.Len(t, resolvedDocument.VerificationMethod, 1)
+		assert.Equal(t, newVerificationMethod.ID.String(), resolvedDocument.VerificationMethod[0].ID.String())
+		assert.False(t, cryptoInstance.Exists(ctx, oldKeyID.String()))
+	})
+	t.Run("with grace period", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		cryptoInstance := nutsCrypto.NewMemoryCryptoInstance()
+		m := NewManager(*baseURL, cryptoInstance, storageEngine.GetSQLDatabase(), time.Hour)
+		document, _, err := m.Create(ctx, DefaultCreationOptions())
+		require.NoError(t, err)
+		oldKeyID := document.VerificationMethod[0].ID
+		newVerificationMethod, err := m.AddVerificationMethod(ctx, document.ID, 0)
+		require.NoError(t, err)
+
+		err = m.RemoveVerificationMethod(ctx, document.ID, oldKeyID)
+		require.NoError(t, err)
+
+		t.Run("old verification method is still published, after the new one", func(t *testing.T) {
+			resolvedDocument, _, err := m.Resolve(document.ID, nil)
+			require.NoError(t, err)
+			require.Len(t, resolvedDocument.VerificationMethod, 2)
+			assert.Equal(t, newVerificationMethod.ID.String(), resolvedDocument.AssertionMethod[0].ID.String())
+			assert.Equal(t, oldKeyID.String(), resolvedDocument.AssertionMethod[1].ID.String())
+			assert.True(t, cryptoInstance.Exists(ctx, oldKeyID.String()))
+		})
+		t.Run("grace period has not passed", func(t *testing.T) {
+			require.NoError(t, m.DeleteRetiredVerificationMethods(ctx))
+
+			assert.True(t, cryptoInstance.Exists(ctx, oldKeyID.String()))
+		})
+		t.Run("grace period has passed", func(t *testing.T) {
+			nowFunc = func() time.Time {
+				return time.Now().Add(2 * time.Hour)
+			}
+			t.Cleanup(func() {
+				nowFunc = time.Now
+			})
+
+			require.NoError(t, m.DeleteRetiredVerificationMethods(ctx))
+
+			resolvedDocument, _, err := m.Resolve(document.ID, nil)
+			require.NoError(t, err)
+			require.Len(t, resolvedDocument.VerificationMethod, 1)
+			assert.Equal(t, newVerificationMethod.ID.String(), resolvedDocument.VerificationMethod[0].ID.String())
+			assert.False(t, cryptoInstance.Exists(ctx, oldKeyID.String()))
+		})
+	})
+	t.Run("last active verification method", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
+		document, _, err := m.Create(ctx, DefaultCreationOptions())
+		require.NoError(t, err)
+
+		err = m.RemoveVerificationMethod(ctx, document.ID, document.VerificationMethod[0].ID)
+
+		require.EqualError(t, err, "can't remove the last active verification method of a did:web DID document, deactivate the DID instead")
+	})
+	t.Run("verification method does not exist", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
+		document, _, err := m.Create(ctx, DefaultCreationOptions())
+		require.NoError(t, err)
+
+		err = m.RemoveVerificationMethod(ctx, document.ID, did.MustParseDIDURL(document.ID.String()+"#other"))
+
+		require.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+	t.Run("DID does not exist", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		m := NewManager(*baseURL, nutsCrypto.NewMemoryCryptoInstance(), storageEngine.GetSQLDatabase(), 0)
+
+		err := m.RemoveVerificationMethod(ctx, subjectDID, did.MustParseDIDURL(subjectDID.String()+"#0"))
+
+		require.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
+	"sort"
+	"time"
 )
 
 type store interface {
@@ -34,6 +36,16 @@ type store interface {
 	create(subjectDID did.DID, methods ...did.VerificationMethod) error
 	get(subjectDID did.DID) ([]did.VerificationMethod, []did.Service, error)
 	list() ([]did.DID, error)
+	createVerificationMethod(subjectDID did.DID, method did.VerificationMethod) error
+	// retireVerificationMethod schedules removal of the verification method at the given moment.
+	retireVerificationMethod(subjectDID did.DID, id did.DIDURL, retireAt time.Time) error
+	// deleteVerificationMethod removes the verification method from the DID document.
+	deleteVerificationMethod(subjectDID did.DID, id did.DIDURL) error
+	// listActive returns the IDs of the verification methods of the DID document that are not being retired.
+	// It returns resolver.ErrNotFound if the DID document does not exist.
+	listActive(subjectDID did.DID) ([]did.DIDURL, error)
+	// listRetired returns the IDs of all verification methods that were retired at the given moment.
+	listRetired(moment time.Time) ([]did.DIDURL, error)
 	createService(subjectDID did.DID, service did.Service) error
 	updateService(subjectDID did.DID, id ssi.URI, service did.Service) error
 	deleteService(subjectDID did.DID, id ssi.URI) error
@@ -42,6 +54,7 @@ type store interface {
 var errServiceNotFound = errors.Join(management.ErrInvalidService, errors.New("not found"))
 var errDuplicateService = errors.Join(management.ErrInvalidService, errors.New("service ID already exists"))
 var errServiceDIDNotFound = errors.Join(management.ErrInvalidService, errors.New("unknown DID"))
+var errVerificationMethodNotFound = errors.Join(resolver.ErrNotFound, errors.New("verification method not found"))
 
 var _ schema.Tabler = (*sqlDID)(nil)
 
@@ -61,6 +74,9 @@ type sqlVerificationMethod struct {
 	ID   string `gorm:"primaryKey"`
 	Did  string `gorm:"primaryKey"`
 	Data []byte
+	// RetireAt is the moment (seconds since UNIX epoch) the verification method is removed from the DID document.
+	// It is nil for active verification methods.
+	RetireAt *int64
 }
 
 func (v sqlVerificationMethod) TableName() string {
@@ -111,19 +127,26 @@ func (s *sqlStore) get(id did.DID) ([]did.VerificationMethod, []did.Service, err
 		return nil, nil, err
 	}
 
-	var verificationMethods []did.VerificationMethod
+	// Active verification methods go first, so they're used for signing. Retiring ones are still published,
+	// to allow verification of signatures that were created before the key was rotated.
+	now := nowFunc().Unix()
+	var methodRecords []sqlVerificationMethod
 	for _, curr := range record.VerificationMethods {
+		if curr.RetireAt != nil && *curr.RetireAt <= now {
+			continue
+		}
+		methodRecords = append(methodRecords, curr)
+	}
+	sort.SliceStable(methodRecords, func(i, j int) bool {
+		return methodRecords[i].RetireAt == nil && methodRecords[j].RetireAt != nil
+	})
+	var verificationMethods []did.VerificationMethod
+	for _, curr := range methodRecords {
 		var method did.VerificationMethod
 		if err := json.Unmarshal(curr.Data, &method); err != nil {
 			return nil, nil, err
 		}
 		verificationMethods = append(verificationMethods, method)
-		vmID, err := did.ParseDIDURL(curr.ID)
-		if err != nil {
-			// weird
-			return nil, nil, err
-		}
-		method.ID = *vmID
 	}
 
 	var services []did.Service
@@ -214,3 +237,79 @@ func (s *sqlStore) deleteService(subjectDID did.DID, id ssi.URI) error {
 	}
 	return nil
 }
+
+func (s *sqlStore) createVerificationMethod(subjectDID did.DID, method did.VerificationMethod) error {
+	data, _ := json.Marshal(method)
+	record := &sqlVerificationMethod{
+		ID:   method.ID.String(),
+		Did:  subjectDID.String(),
+		Data: data,
+	}
+	err := s.db.Create(record).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return resolver.ErrNotFound
+	}
+	return err
+}
+
+func (s *sqlStore) retireVerificationMethod(subjectDID did.DID, id did.DIDURL, retireAt time.Time) error {
+	result := s.db.Model(&sqlVerificationMethod{}).
+		Where("did = ? AND id = ? AND retire_at IS NULL", subjectDID.String(), id.String()).
+		Update("retire_at", retireAt.Unix())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errVerificationMethodNotFound
+	}
+	return nil
+}
+
+func (s *sqlStore) deleteVerificationMethod(subjectDID did.DID, id did.DIDURL) error {
+	result := s.db.Model(&sqlVerificationMethod{}).Where("did = ? AND id = ?", subjectDID.String(), id.String()).Delete(&sqlVerificationMethod{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errVerificationMethodNotFound
+	}
+	return nil
+}
+
+func (s *sqlStore) listActive(subjectDID did.DID) ([]did.DIDURL, error) {
+	var records []sqlVerificationMethod
+	err := s.db.Model(&sqlVerificationMethod{}).Select("id").
+		Where("did = ? AND retire_at IS NULL", subjectDID.String()).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		// DID documents always have at least one active verification method
+		return nil, resolver.ErrNotFound
+	}
+	return parseVerificationMethodIDs(records)
+}
+
+func (s *sqlStore) listRetired(moment time.Time) ([]did.DIDURL, error) {
+	var records []sqlVerificationMethod
+	err := s.db.Model(&sqlVerificationMethod{}).Select("id").
+		Where("retire_at IS NOT NULL AND retire_at <= ?", moment.Unix()).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return parseVerificationMethodIDs(records)
+}
+
+func parseVerificationMethodIDs(records []sqlVerificationMethod) ([]did.DIDURL, error) {
+	var result []did.DIDURL
+	for _, curr := range records {
+		id, err := did.ParseDIDURL(curr.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *id)
+	}
+	return result, nil
+}
@@ -11,6 +11,7 @@ package didweb
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	did "github.com/nuts-foundation/go-did/did"
@@ -73,6 +74,20 @@ func (mr *MockstoreMockRecorder) createService(subjectDID, service any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createService", reflect.TypeOf((*Mockstore)(nil).createService), subjectDID, service)
 }
 
+// createVerificationMethod mocks base method.
+func (m *Mockstore) createVerificationMethod(subjectDID did.DID, method did.VerificationMethod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createVerificationMethod", subjectDID, method)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// createVerificationMethod indicates an expected call of createVerificationMethod.
+func (mr *MockstoreMockRecorder) createVerificationMethod(subjectDID, method any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createVerificationMethod", reflect.TypeOf((*Mockstore)(nil).createVerificationMethod), subjectDID, method)
+}
+
 // delete mocks base method.
 func (m *Mockstore) delete(subjectDID did.DID) error {
 	m.ctrl.T.Helper()
@@ -101,6 +116,20 @@ func (mr *MockstoreMockRecorder) deleteService(subjectDID, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteService", reflect.TypeOf((*Mockstore)(nil).deleteService), subjectDID, id)
 }
 
+// deleteVerificationMethod mocks base method.
+func (m *Mockstore) deleteVerificationMethod(subjectDID did.DID, id did.DIDURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteVerificationMethod", subjectDID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// deleteVerificationMethod indicates an expected call of deleteVerificationMethod.
+func (mr *MockstoreMockRecorder) deleteVerificationMethod(subjectDID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteVerificationMethod", reflect.TypeOf((*Mockstore)(nil).deleteVerificationMethod), subjectDID, id)
+}
+
 // get mocks base method.
 func (m *Mockstore) get(subjectDID did.DID) ([]did.VerificationMethod, []did.Service, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +161,50 @@ func (mr *MockstoreMockRecorder) list() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "list", reflect.TypeOf((*Mockstore)(nil).list))
 }
 
+// listActive mocks base method.
+func (m *Mockstore) listActive(subjectDID did.DID) ([]did.DIDURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listActive", subjectDID)
+	ret0, _ := ret[0].([]did.DIDURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listActive indicates an expected call of listActive.
+func (mr *MockstoreMockRecorder) listActive(subjectDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listActive", reflect.TypeOf((*Mockstore)(nil).listActive), subjectDID)
+}
+
+// listRetired mocks base method.
+func (m *Mockstore) listRetired(moment time.Time) ([]did.DIDURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "listRetired", moment)
+	ret0, _ := ret[0].([]did.DIDURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// listRetired indicates an expected call of listRetired.
+func (mr *MockstoreMockRecorder) listRetired(moment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "listRetired", reflect.TypeOf((*Mockstore)(nil).listRetired), moment)
+}
+
+// retireVerificationMethod mocks base method.
+func (m *Mockstore) retireVerificationMethod(subjectDID did.DID, id did.DIDURL, retireAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "retireVerificationMethod", subjectDID, id, retireAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// retireVerificationMethod indicates an expected call of retireVerificationMethod.
+func (mr *MockstoreMockRecorder) retireVerificationMethod(subjectDID, id, retireAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "retireVerificationMethod", reflect.TypeOf((*Mockstore)(nil).retireVerificationMethod), subjectDID, id, retireAt)
+}
+
 // updateService mocks base method.
 func (m *Mockstore) updateService(subjectDID did.DID, id ssi.URI, service did.Service) error {
 	m.ctrl.T.Helper()
@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
 var (
@@ -237,6 +238,135 @@ func Test_sqlStore_deleteService(t *testing.T) {
 	})
 }
 
+func Test_sqlStore_createVerificationMethod(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	store := &sqlStore{db: storageEngine.GetSQLDatabase()}
+	vm1 := testVerificationMethod(t, testDID)
+	vm2 := testVerificationMethod(t, testDID)
+
+	t.Run("ok", func(t *testing.T) {
+		resetStore(t, store.db)
+		_ = store.create(testDID, vm1)
+
+		err := store.createVerificationMethod(testDID, vm2)
+		require.NoError(t, err)
+
+		verificationMethods, _, err := store.get(testDID)
+		require.NoError(t, err)
+		require.Len(t, verificationMethods, 2)
+	})
+	t.Run("DID does not exist", func(t *testing.T) {
+		resetStore(t, store.db)
+
+		err := store.createVerificationMethod(testDID, vm1)
+
+		require.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+}
+
+func Test_sqlStore_retireVerificationMethod(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	store := &sqlStore{db: storageEngine.GetSQLDatabase()}
+	vm1 := testVerificationMethod(t, testDID)
+	vm2 := testVerificationMethod(t, testDID)
+
+	t.Run("retiring verification methods are listed last", func(t *testing.T) {
+		resetStore(t, store.db)
+		_ = store.create(testDID, vm1, vm2)
+
+		err := store.retireVerificationMethod(testDID, vm1.ID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		verificationMethods, _, err := store.get(testDID)
+		require.NoError(t, err)
+		require.Len(t, verificationMethods, 2)
+		require.Equal(t, vm2.ID.String(), verificationMethods[0].ID.String())
+		require.Equal(t, vm1.ID.String(), verificationMethods[1].ID.String())
+		active, err := store.listActive(testDID)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		require.Equal(t, vm2.ID.String(), active[0].String())
+	})
+	t.Run("retired verification methods are not returned", func(t *testing.T) {
+		resetStore(t, store.db)
+		_ = store.create(testDID, vm1, vm2)
+
+		err := store.retireVerificationMethod(testDID, vm1.ID, time.Now().Add(-time.Second))
+		require.NoError(t, err)
+
+		verificationMethods, _, err := store.get(testDID)
+		require.NoError(t, err)
+		require.Len(t, verificationMethods, 1)
+		require.Equal(t, vm2.ID.String(), verificationMethods[0].ID.String())
+		retired, err := store.listRetired(time.Now())
+		require.NoError(t, err)
+		require.Len(t, retired, 1)
+		require.Equal(t, vm1.ID.String(), retired[0].String())
+	})
+	t.Run("already retiring", func(t *testing.T) {
+		resetStore(t, store.db)
+		_ = store.create(testDID, vm1, vm2)
+		_ = store.retireVerificationMethod(testDID, vm1.ID, time.Now().Add(time.Hour))
+
+		err := store.retireVerificationMethod(testDID, vm1.ID, time.Now().Add(time.Hour))
+
+		require.ErrorIs(t, err, errVerificationMethodNotFound)
+	})
+	t.Run("verification method does not exist", func(t *testing.T) {
+		resetStore(t, store.db)
+		_ = store.create(testDID, vm1)
+
+		err := store.retireVerificationMethod(testDID, vm2.ID, time.Now())
+
+		require.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+}
+
+func Test_sqlStore_deleteVerificationMethod(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	store := &sqlStore{db: storageEngine.GetSQLDatabase()}
+	vm1 := testVerificationMethod(t, testDID)
+	vm2 := testVerificationMethod(t, testDID)
+
+	t.Run("ok", func(t *testing.T) {
+		resetStore(t, store.db)
+		_ = store.create(testDID, vm1, vm2)
+
+		err := store.deleteVerificationMethod(testDID, vm1.ID)
+		require.NoError(t, err)
+
+		verificationMethods, _, err := store.get(testDID)
+		require.NoError(t, err)
+		require.Len(t, verificationMethods, 1)
+		require.Equal(t, vm2.ID.String(), verificationMethods[0].ID.String())
+	})
+	t.Run("verification method does not exist", func(t *testing.T) {
+		resetStore(t, store.db)
+		_ = store.create(testDID, vm1)
+
+		err := store.deleteVerificationMethod(testDID, vm2.ID)
+
+		require.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+}
+
+func Test_sqlStore_listActive(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	store := &sqlStore{db: storageEngine.GetSQLDatabase()}
+
+	t.Run("DID does not exist", func(t *testing.T) {
+		resetStore(t, store.db)
+
+		_, err := store.listActive(testDID)
+
+		require.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+}
+
 func resetStore(t *testing.T, db *gorm.DB) {
 	t.Cleanup(func() {
 		underlyingDB, err := db.DB()
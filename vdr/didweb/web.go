@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"io"
 	"mime"
@@ -38,6 +39,8 @@ var _ resolver.DIDResolver = (*Resolver)(nil)
 // Resolver is a DID resolver for the did:web method.
 type Resolver struct {
 	HttpClient *http.Client
+	// cache holds resolved DID documents. If nil, DID documents are downloaded on every resolution.
+	cache *documentCache
 }
 
 // NewResolver creates a new did:web Resolver with default TLS configuration.
@@ -59,6 +62,27 @@ func NewResolver() *Resolver {
 	}
 }
 
+// NewCachingResolver creates a new did:web Resolver that caches at most maxEntries DID documents.
+// It respects the caching headers (Cache-Control, Expires, ETag and Last-Modified) of the server hosting the DID document.
+// When that server can't be reached, an expired DID document is served for at most maxStale after it expired.
+// If maxEntries is 0, caching is disabled.
+func NewCachingResolver(maxEntries int, maxStale time.Duration) *Resolver {
+	result := NewResolver()
+	if maxEntries > 0 {
+		result.cache = newDocumentCache(maxEntries, maxStale)
+	}
+	return result
+}
+
+// CacheStatistics returns the statistics of the DID document cache, or nil if caching is disabled.
+func (w Resolver) CacheStatistics() *CacheStatistics {
+	if w.cache == nil {
+		return nil
+	}
+	result := w.cache.statistics()
+	return &result
+}
+
 // Resolve implements the DIDResolver interface.
 func (w Resolver) Resolve(id did.DID, _ *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
 	if id.Method != "web" {
@@ -76,19 +100,112 @@ func (w Resolver) Resolve(id did.DID, _ *resolver.ResolveMetadata) (*did.Documen
 	baseURL.Path = baseURL.Path + "/did.json"
 	targetURL := baseURL.String()
 
+	if w.cache != nil {
+		return w.resolveCached(id, targetURL)
+	}
+	result, _, err := w.fetch(targetURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseDocument(id, result.data)
+}
+
+// resolveCached resolves the DID document from cache if it's still fresh.
+// Otherwise, it's downloaded (or revalidated) and stored in the cache, if the server allows it.
+// If the server is unavailable, a stale DID document is served as long as it's within the max-stale window.
+func (w Resolver) resolveCached(id did.DID, targetURL string) (*did.Document, *resolver.DocumentMetadata, error) {
+	now := time.Now()
+	cached := w.cache.get(targetURL)
+	if cached != nil && cached.fresh(now) {
+		w.cache.record(func(stats *CacheStatistics) { stats.Hits++ })
+		return parseDocument(id, cached.data)
+	}
+
+	result, unavailable, err := w.fetch(targetURL, cached)
+	if err != nil {
+		if unavailable && cached != nil && cached.usableWhenStale(now, w.cache.maxStale) {
+			log.Logger().WithError(err).Warnf("Unable to refresh did:web document, using cached document (did=%s)", id)
+			w.cache.record(func(stats *CacheStatistics) { stats.StaleHits++ })
+			return parseDocument(id, cached.data)
+		}
+		if !unavailable {
+			// The server responded, but the document isn't valid (anymore)
+			w.cache.remove(targetURL)
+		}
+		return nil, nil, err
+	}
+
+	entry := cacheEntry{url: targetURL}
+	if result.notModified {
+		entry = *cached
+		w.cache.record(func(stats *CacheStatistics) { stats.Revalidations++ })
+	} else {
+		entry.data = result.data
+		w.cache.record(func(stats *CacheStatistics) { stats.Misses++ })
+	}
+	document, metadata, err := parseDocument(id, entry.data)
+	if err != nil {
+		w.cache.remove(targetURL)
+		return nil, nil, err
+	}
+	// a 304 response may contain updated validators
+	if etag := result.header.Get("ETag"); etag != "" {
+		entry.etag = etag
+	}
+	if lastModified := result.header.Get("Last-Modified"); lastModified != "" {
+		entry.lastModified = lastModified
+	}
+	var storable bool
+	storable, entry.freshUntil, entry.mustRevalidate = cachePolicy(result.header, now)
+	if storable {
+		w.cache.put(entry)
+	} else {
+		w.cache.remove(targetURL)
+	}
+	return document, metadata, nil
+}
+
+// fetchResult contains a DID document downloaded from a did:web server.
+type fetchResult struct {
+	data   []byte
+	header http.Header
+	// notModified indicates the server responded the cached DID document is still valid.
+	// In that case, data is empty.
+	notModified bool
+}
+
+// fetch downloads the DID document from the given URL. If a cached entry is given, the request is made conditional.
+// If the request fails, the returned bool indicates whether that's because the server is unavailable
+// (connection errors or server errors), as opposed to the server returning an invalid response.
+func (w Resolver) fetch(targetURL string, cached *cacheEntry) (*fetchResult, bool, error) {
+	request, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("did:web HTTP error: %w", err)
+	}
+	if cached != nil {
+		if cached.etag != "" {
+			request.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			request.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
 	// TODO: Support DNS over HTTPS (DOH), https://www.rfc-editor.org/rfc/rfc8484
-	httpResponse, err := w.HttpClient.Get(targetURL)
+	httpResponse, err := w.HttpClient.Do(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("did:web HTTP error: %w", err)
+		return nil, true, fmt.Errorf("did:web HTTP error: %w", err)
 	}
 	defer httpResponse.Body.Close()
+	if cached != nil && httpResponse.StatusCode == http.StatusNotModified {
+		return &fetchResult{header: httpResponse.Header, notModified: true}, false, nil
+	}
 	if !(httpResponse.StatusCode >= 200 && httpResponse.StatusCode < 300) {
-		return nil, nil, fmt.Errorf("did:web non-ok HTTP status: %s", httpResponse.Status)
+		return nil, httpResponse.StatusCode >= 500, fmt.Errorf("did:web non-ok HTTP status: %s", httpResponse.Status)
 	}
 
 	ct, _, err := mime.ParseMediaType(httpResponse.Header.Get("Content-Type"))
 	if err != nil {
-		return nil, nil, fmt.Errorf("did:web invalid content-type: %w", err)
+		return nil, false, fmt.Errorf("did:web invalid content-type: %w", err)
 	}
 	switch ct {
 	case "application/did+ld+json":
@@ -103,16 +220,21 @@ func (w Resolver) Resolve(id did.DID, _ *resolver.ResolveMetadata) (*did.Documen
 	case "application/json":
 		// This is OK
 	default:
-		return nil, nil, fmt.Errorf("did:web unsupported content-type: %s", ct)
+		return nil, false, fmt.Errorf("did:web unsupported content-type: %s", ct)
 	}
 
 	// Read document
 	data, err := io.ReadAll(httpResponse.Body)
 	if err != nil {
-		return nil, nil, fmt.Errorf("did:web HTTP response read error: %w", err)
+		return nil, true, fmt.Errorf("did:web HTTP response read error: %w", err)
 	}
+	return &fetchResult{data: data, header: httpResponse.Header}, false, nil
+}
+
+// parseDocument parses the DID document and checks it's the DID document of the given DID.
+func parseDocument(id did.DID, data []byte) (*did.Document, *resolver.DocumentMetadata, error) {
 	var document did.Document
-	err = document.UnmarshalJSON(data)
+	err := document.UnmarshalJSON(data)
 	if err != nil {
 		return nil, nil, fmt.Errorf("did:web JSON unmarshal error: %w", err)
 	}
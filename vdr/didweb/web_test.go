@@ -20,6 +20,7 @@ package didweb
 
 import (
 	"crypto/tls"
+	"errors"
 	"github.com/nuts-foundation/go-did/did"
 	http2 "github.com/nuts-foundation/nuts-node/test/http"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +30,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 const didDocTemplate = `
@@ -204,6 +206,159 @@ func TestResolver_Resolve(t *testing.T) {
 	})
 }
 
+func TestResolver_Resolve_Cache(t *testing.T) {
+	didToResolve := did.MustParseDID("did:web:example.com")
+	const document = `{"id": "did:web:example.com"}`
+	type response struct {
+		status int
+		header http.Header
+		err    error
+	}
+	// newResolver returns a caching resolver that responds with the given responses, in order.
+	// It returns the requests it received.
+	newResolver := func(t *testing.T, responses ...response) (*Resolver, *[]*http.Request) {
+		var requests []*http.Request
+		resolver := NewCachingResolver(10, time.Hour)
+		resolver.HttpClient = &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			requests = append(requests, r)
+			require.LessOrEqual(t, len(requests), len(responses), "unexpected request")
+			current := responses[len(requests)-1]
+			if current.err != nil {
+				return nil, current.err
+			}
+			header := current.header.Clone()
+			if header == nil {
+				header = http.Header{}
+			}
+			header.Set("Content-Type", "application/json")
+			body := document
+			if current.status == http.StatusNotModified {
+				body = ""
+			}
+			return &http.Response{
+				Header:     header,
+				StatusCode: current.status,
+				Status:     http.StatusText(current.status),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		})}
+		return resolver, &requests
+	}
+	resolve := func(t *testing.T, resolver *Resolver) {
+		t.Helper()
+		doc, md, err := resolver.Resolve(didToResolve, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, md)
+		require.NotNil(t, doc)
+		assert.Equal(t, didToResolve, doc.ID)
+	}
+
+	t.Run("fresh document is served from cache", func(t *testing.T) {
+		resolver, requests := newResolver(t, response{status: http.StatusOK, header: http.Header{"Cache-Control": {"max-age=60"}}})
+
+		resolve(t, resolver)
+		resolve(t, resolver)
+
+		assert.Len(t, *requests, 1)
+		assert.Equal(t, CacheStatistics{Hits: 1, Misses: 1, Entries: 1}, *resolver.CacheStatistics())
+	})
+	t.Run("expired document is revalidated using ETag", func(t *testing.T) {
+		resolver, requests := newResolver(t,
+			response{status: http.StatusOK, header: http.Header{"Cache-Control": {"max-age=0"}, "Etag": {`"v1"`}, "Last-Modified": {"Mon, 01 Jan 2024 00:00:00 GMT"}}},
+			response{status: http.StatusNotModified, header: http.Header{"Cache-Control": {"max-age=60"}}},
+		)
+
+		resolve(t, resolver)
+		resolve(t, resolver)
+		resolve(t, resolver)
+
+		require.Len(t, *requests, 2)
+		assert.Equal(t, `"v1"`, (*requests)[1].Header.Get("If-None-Match"))
+		assert.Equal(t, "Mon, 01 Jan 2024 00:00:00 GMT", (*requests)[1].Header.Get("If-Modified-Since"))
+		assert.Equal(t, CacheStatistics{Hits: 1, Misses: 1, Revalidations: 1, Entries: 1}, *resolver.CacheStatistics())
+	})
+	t.Run("document without caching headers is revalidated on every resolution", func(t *testing.T) {
+		resolver, requests := newResolver(t,
+			response{status: http.StatusOK},
+			response{status: http.StatusOK},
+		)
+
+		resolve(t, resolver)
+		resolve(t, resolver)
+
+		require.Len(t, *requests, 2)
+		assert.Equal(t, CacheStatistics{Misses: 2, Entries: 1}, *resolver.CacheStatistics())
+	})
+	t.Run("no-store", func(t *testing.T) {
+		resolver, requests := newResolver(t,
+			response{status: http.StatusOK, header: http.Header{"Cache-Control": {"no-store"}}},
+			response{status: http.StatusOK, header: http.Header{"Cache-Control": {"no-store"}}},
+		)
+
+		resolve(t, resolver)
+		resolve(t, resolver)
+
+		require.Len(t, *requests, 2)
+		assert.Empty(t, (*requests)[1].Header.Get("If-None-Match"))
+		assert.Equal(t, 0, resolver.CacheStatistics().Entries)
+	})
+	t.Run("stale document is served when server is unreachable", func(t *testing.T) {
+		resolver, requests := newResolver(t,
+			response{status: http.StatusOK, header: http.Header{"Cache-Control": {"max-age=0"}}},
+			response{err: errors.New("connection refused")},
+			response{status: http.StatusServiceUnavailable},
+		)
+
+		resolve(t, resolver)
+		resolve(t, resolver)
+		resolve(t, resolver)
+
+		require.Len(t, *requests, 3)
+		assert.Equal(t, CacheStatistics{Misses: 1, StaleHits: 2, Entries: 1}, *resolver.CacheStatistics())
+	})
+	t.Run("stale document isn't served after max-stale", func(t *testing.T) {
+		resolver, _ := newResolver(t,
+			response{status: http.StatusOK, header: http.Header{"Cache-Control": {"max-age=0"}}},
+			response{err: errors.New("connection refused")},
+		)
+		resolver.cache.maxStale = 0
+
+		resolve(t, resolver)
+		_, _, err := resolver.Resolve(didToResolve, nil)
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+	t.Run("stale document isn't served when server requires revalidation", func(t *testing.T) {
+		resolver, _ := newResolver(t,
+			response{status: http.StatusOK, header: http.Header{"Cache-Control": {"max-age=0, must-revalidate"}}},
+			response{err: errors.New("connection refused")},
+		)
+
+		resolve(t, resolver)
+		_, _, err := resolver.Resolve(didToResolve, nil)
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+	t.Run("document is evicted when server no longer serves it", func(t *testing.T) {
+		resolver, _ := newResolver(t,
+			response{status: http.StatusOK, header: http.Header{"Cache-Control": {"max-age=0"}}},
+			response{status: http.StatusNotFound},
+		)
+
+		resolve(t, resolver)
+		_, _, err := resolver.Resolve(didToResolve, nil)
+
+		assert.EqualError(t, err, "did:web non-ok HTTP status: Not Found")
+		assert.Equal(t, 0, resolver.CacheStatistics().Entries)
+	})
+	t.Run("caching disabled", func(t *testing.T) {
+		resolver := NewCachingResolver(0, time.Hour)
+
+		assert.Nil(t, resolver.cache)
+		assert.Nil(t, resolver.CacheStatistics())
+	})
+}
+
 type roundTripperFunc func(*http.Request) (*http.Response, error)
 
 func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
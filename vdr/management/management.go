@@ -52,6 +52,14 @@ type DocumentManager interface {
 	// DeleteService deletes a service in the DID document identified by subjectDID.
 	// It returns an error if the DID or service isn't found.
 	DeleteService(ctx context.Context, subjectDID did.DID, serviceID ssi.URI) error
+
+	// AddVerificationMethod generates a new key and adds it, wrapped as a VerificationMethod, to the DID document identified by subjectDID.
+	// It returns an ErrNotFound when the DID document could not be found.
+	AddVerificationMethod(ctx context.Context, subjectDID did.DID, keyUsage DIDKeyFlags) (*did.VerificationMethod, error)
+
+	// RemoveVerificationMethod removes a VerificationMethod from the DID document identified by subjectDID.
+	// It returns an ErrNotFound when the DID document or VerificationMethod could not be found.
+	RemoveVerificationMethod(ctx context.Context, subjectDID did.DID, keyID did.DIDURL) error
 }
 
 // DocCreator is the interface that wraps the Create method
@@ -150,6 +158,15 @@ func (d defaultDIDCreationOptions) Method() string {
 type CreationOption interface {
 }
 
+// KeyTypeOption is a CreationOption that specifies the type of the key pair(s) generated for a new DID document.
+type KeyTypeOption crypto.KeyType
+
+// KeyType is a DID document creation option that specifies the type of the key pair(s) generated for the DID document.
+// If not specified, crypto.DefaultKeyType is used.
+func KeyType(keyType crypto.KeyType) CreationOption {
+	return KeyTypeOption(keyType)
+}
+
 // DIDKeyFlags is a bitmask used for specifying for what purposes a key in a DID document can be used (a.k.a. Verification Method relationships).
 type DIDKeyFlags uint
 
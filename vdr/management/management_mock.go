@@ -43,6 +43,21 @@ func (m *MockDocumentManager) EXPECT() *MockDocumentManagerMockRecorder {
 	return m.recorder
 }
 
+// AddVerificationMethod mocks base method.
+func (m *MockDocumentManager) AddVerificationMethod(ctx context.Context, subjectDID did.DID, keyUsage DIDKeyFlags) (*did.VerificationMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVerificationMethod", ctx, subjectDID, keyUsage)
+	ret0, _ := ret[0].(*did.VerificationMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddVerificationMethod indicates an expected call of AddVerificationMethod.
+func (mr *MockDocumentManagerMockRecorder) AddVerificationMethod(ctx, subjectDID, keyUsage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVerificationMethod", reflect.TypeOf((*MockDocumentManager)(nil).AddVerificationMethod), ctx, subjectDID, keyUsage)
+}
+
 // Create mocks base method.
 func (m *MockDocumentManager) Create(ctx context.Context, options CreationOptions) (*did.Document, crypto.Key, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +147,20 @@ func (mr *MockDocumentManagerMockRecorder) ListOwned(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwned", reflect.TypeOf((*MockDocumentManager)(nil).ListOwned), ctx)
 }
 
+// RemoveVerificationMethod mocks base method.
+func (m *MockDocumentManager) RemoveVerificationMethod(ctx context.Context, subjectDID did.DID, keyID did.DIDURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVerificationMethod", ctx, subjectDID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVerificationMethod indicates an expected call of RemoveVerificationMethod.
+func (mr *MockDocumentManagerMockRecorder) RemoveVerificationMethod(ctx, subjectDID, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVerificationMethod", reflect.TypeOf((*MockDocumentManager)(nil).RemoveVerificationMethod), ctx, subjectDID, keyID)
+}
+
 // Resolve mocks base method.
 func (m *MockDocumentManager) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
 	m.ctrl.T.Helper()
@@ -44,6 +44,21 @@ func (m *MockVDR) EXPECT() *MockVDRMockRecorder {
 	return m.recorder
 }
 
+// AddVerificationMethod mocks base method.
+func (m *MockVDR) AddVerificationMethod(ctx context.Context, subjectDID did.DID, keyUsage management.DIDKeyFlags) (*did.VerificationMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVerificationMethod", ctx, subjectDID, keyUsage)
+	ret0, _ := ret[0].(*did.VerificationMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddVerificationMethod indicates an expected call of AddVerificationMethod.
+func (mr *MockVDRMockRecorder) AddVerificationMethod(ctx, subjectDID, keyUsage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVerificationMethod", reflect.TypeOf((*MockVDR)(nil).AddVerificationMethod), ctx, subjectDID, keyUsage)
+}
+
 // ConflictedDocuments mocks base method.
 func (m *MockVDR) ConflictedDocuments() ([]did.Document, []resolver.DocumentMetadata, error) {
 	m.ctrl.T.Helper()
@@ -149,6 +164,20 @@ func (mr *MockVDRMockRecorder) ListOwned(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwned", reflect.TypeOf((*MockVDR)(nil).ListOwned), ctx)
 }
 
+// RemoveVerificationMethod mocks base method.
+func (m *MockVDR) RemoveVerificationMethod(ctx context.Context, subjectDID did.DID, keyID did.DIDURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVerificationMethod", ctx, subjectDID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVerificationMethod indicates an expected call of RemoveVerificationMethod.
+func (mr *MockVDRMockRecorder) RemoveVerificationMethod(ctx, subjectDID, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVerificationMethod", reflect.TypeOf((*MockVDR)(nil).RemoveVerificationMethod), ctx, subjectDID, keyID)
+}
+
 // Resolve mocks base method.
 func (m *MockVDR) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
 	m.ctrl.T.Helper()
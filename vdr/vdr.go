@@ -28,9 +28,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/events"
@@ -53,11 +56,16 @@ const ModuleName = "VDR"
 var _ VDR = (*Module)(nil)
 var _ core.Named = (*Module)(nil)
 var _ core.Configurable = (*Module)(nil)
+var _ core.Injectable = (*Module)(nil)
+
+// didwebKeyPruneInterval specifies how often retired did:web verification methods are deleted.
+const didwebKeyPruneInterval = time.Hour
 
 // Module implements VDR, which stands for the Verifiable Data Registry. It is the public entrypoint to work with W3C DID documents.
 // It connects the Resolve, Create and Update DID methods to the network, and receives events back from the network which are processed in the store.
 // It is also a Runnable, Diagnosable and Configurable Nuts Engine.
 type Module struct {
+	config            Config
 	store             didnutsStore.Store
 	network           network.Transactions
 	networkAmbassador didnuts.Ambassador
@@ -67,6 +75,11 @@ type Module struct {
 	keyStore          crypto.KeyStore
 	storageInstance   storage.Engine
 	eventManager      events.Event
+	didwebManager     *didweb.Manager
+	didwebResolver    *didweb.Resolver
+	ctx               context.Context
+	cancel            context.CancelFunc
+	routines          *sync.WaitGroup
 }
 
 // ResolveManaged resolves a DID document that is managed by the local node.
@@ -94,6 +107,7 @@ func NewVDR(cryptoClient crypto.KeyStore, networkClient network.Transactions,
 	didStore didnutsStore.Store, eventManager events.Event, storageInstance storage.Engine) *Module {
 	didResolver := &resolver.DIDResolverRouter{}
 	return &Module{
+		config:          DefaultConfig(),
 		network:         networkClient,
 		eventManager:    eventManager,
 		didResolver:     didResolver,
@@ -101,6 +115,7 @@ func NewVDR(cryptoClient crypto.KeyStore, networkClient network.Transactions,
 		serviceResolver: resolver.DIDServiceResolver{Resolver: didResolver},
 		keyStore:        cryptoClient,
 		storageInstance: storageInstance,
+		routines:        new(sync.WaitGroup),
 	}
 }
 
@@ -108,6 +123,10 @@ func (r *Module) Name() string {
 	return ModuleName
 }
 
+func (r *Module) Config() interface{} {
+	return &r.config
+}
+
 // Configure configures the Module engine.
 func (r *Module) Configure(config core.ServerConfig) error {
 	r.networkAmbassador = didnuts.NewAmbassador(r.network, r.store, r.eventManager)
@@ -129,13 +148,15 @@ func (r *Module) Configure(config core.ServerConfig) error {
 	if err != nil {
 		return err
 	}
-	manager := didweb.NewManager(*publicURL.JoinPath("iam"), r.keyStore, r.storageInstance.GetSQLDatabase())
+	manager := didweb.NewManager(*publicURL.JoinPath("iam"), r.keyStore, r.storageInstance.GetSQLDatabase(), r.config.DIDWeb.KeyGracePeriod)
 	r.documentManagers[didweb.MethodName] = manager
+	r.didwebManager = manager
 	// did:web resolver should first look in own database, then resolve over the web
+	r.didwebResolver = didweb.NewCachingResolver(r.config.DIDWeb.Cache.MaxEntries, r.config.DIDWeb.Cache.MaxStale)
 	webResolver := resolver.ChainedDIDResolver{
 		Resolvers: []resolver.DIDResolver{
 			manager,
-			didweb.NewResolver(),
+			r.didwebResolver,
 		},
 	}
 
@@ -156,6 +177,15 @@ func (r *Module) Start() error {
 		return err
 	}
 
+	if r.didwebManager != nil {
+		r.ctx, r.cancel = context.WithCancel(context.Background())
+		r.routines.Add(1)
+		go func() {
+			defer r.routines.Done()
+			r.pruneRetiredKeys()
+		}()
+	}
+
 	// VDR migration needs to be started after ambassador has started!
 	count, err := r.store.DocumentCount()
 	if err != nil {
@@ -170,9 +200,30 @@ func (r *Module) Start() error {
 }
 
 func (r *Module) Shutdown() error {
+	if r.cancel != nil {
+		r.cancel()
+		r.routines.Wait()
+	}
 	return nil
 }
 
+// pruneRetiredKeys periodically deletes did:web verification methods (and their private keys) that have been retired.
+func (r *Module) pruneRetiredKeys() {
+	ticker := time.NewTicker(didwebKeyPruneInterval)
+	defer ticker.Stop()
+	ctx := audit.Context(r.ctx, "app", ModuleName, "PruneRetiredKeys")
+	for {
+		if err := r.didwebManager.DeleteRetiredVerificationMethods(ctx); err != nil {
+			log.Logger().WithError(err).Error("Failed to delete retired did:web verification methods")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (r *Module) ConflictedDocuments() ([]did.Document, []resolver.DocumentMetadata, error) {
 	conflictedDocs := make([]did.Document, 0)
 	conflictedMeta := make([]resolver.DocumentMetadata, 0)
@@ -257,7 +308,7 @@ func (r *Module) Diagnostics() []core.DiagnosticResult {
 		return count
 	}
 
-	return []core.DiagnosticResult{
+	results := []core.DiagnosticResult{
 		core.DiagnosticResultMap{
 			Title: "conflicted_did_documents",
 			Items: []core.DiagnosticResult{
@@ -276,6 +327,21 @@ func (r *Module) Diagnostics() []core.DiagnosticResult {
 			Outcome: docCount,
 		},
 	}
+	if r.didwebResolver != nil {
+		if stats := r.didwebResolver.CacheStatistics(); stats != nil {
+			results = append(results, core.DiagnosticResultMap{
+				Title: "didweb_cache",
+				Items: []core.DiagnosticResult{
+					&core.GenericDiagnosticResult{Title: "entries", Outcome: stats.Entries},
+					&core.GenericDiagnosticResult{Title: "hits", Outcome: stats.Hits},
+					&core.GenericDiagnosticResult{Title: "misses", Outcome: stats.Misses},
+					&core.GenericDiagnosticResult{Title: "revalidations", Outcome: stats.Revalidations},
+					&core.GenericDiagnosticResult{Title: "stale_hits", Outcome: stats.StaleHits},
+				},
+			})
+		}
+	}
+	return results
 }
 
 // Create generates a new DID Document
@@ -400,6 +466,24 @@ func (r *Module) UpdateService(ctx context.Context, subjectDID did.DID, serviceI
 	return manager.UpdateService(ctx, subjectDID, serviceID, service)
 }
 
+// AddVerificationMethod generates a new key and adds it as verification method to the DID document identified by subjectDID.
+func (r *Module) AddVerificationMethod(ctx context.Context, subjectDID did.DID, keyUsage management.DIDKeyFlags) (*did.VerificationMethod, error) {
+	manager := r.documentManagers[subjectDID.Method]
+	if manager == nil {
+		return nil, fmt.Errorf("unsupported method: %s", subjectDID.Method)
+	}
+	return manager.AddVerificationMethod(ctx, subjectDID, keyUsage)
+}
+
+// RemoveVerificationMethod removes a verification method from the DID document identified by subjectDID.
+func (r *Module) RemoveVerificationMethod(ctx context.Context, subjectDID did.DID, keyID did.DIDURL) error {
+	manager := r.documentManagers[subjectDID.Method]
+	if manager == nil {
+		return fmt.Errorf("unsupported method: %s", subjectDID.Method)
+	}
+	return manager.RemoveVerificationMethod(ctx, subjectDID, keyID)
+}
+
 // DeleteService removes a service from the DID document identified by subjectDID.
 func (r *Module) DeleteService(ctx context.Context, subjectDID did.DID, serviceID ssi.URI) error {
 	manager := r.documentManagers[subjectDID.Method]
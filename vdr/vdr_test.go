@@ -190,6 +190,7 @@ func TestVDR_Update(t *testing.T) {
 func TestNewVDR(t *testing.T) {
 	vdr := NewVDR(nil, nil, nil, nil, nil)
 	assert.IsType(t, &Module{}, vdr)
+	assert.Equal(t, DefaultConfig(), *vdr.Config().(*Config))
 }
 
 func TestVDR_Start(t *testing.T) {
@@ -257,7 +258,7 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 			client := crypto.NewMemoryCryptoInstance()
 			keyID := did.DIDURL{DID: TestDIDA}
 			keyID.Fragment = "1"
-			_, _ = client.New(audit.TestContext(), crypto.StringNamingFunc(keyID.String()))
+			_, _ = client.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc(keyID.String()))
 			vdr := NewVDR(client, nil, didstore.NewTestStore(t), nil, storage.NewTestStorageEngine(t))
 			_ = vdr.Configure(core.TestServerConfig())
 			//vdr.didResolver.Register(didnuts.MethodName, didnuts.Resolver{Store: vdr.store})
@@ -268,9 +269,10 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 			_ = vdr.store.Add(didDocument, didstore.TestTransaction(didDocument))
 			results := vdr.Diagnostics()
 
-			require.Len(t, results, 2)
+			require.Len(t, results, 3)
 			assert.Equal(t, "map[owned_count:1 total_count:1]", results[0].String())
 			assert.Equal(t, "1", results[1].String())
+			assert.Equal(t, "map[entries:0 hits:0 misses:0 revalidations:0 stale_hits:0]", results[2].String())
 		})
 
 		t.Run("ok - 1 owned conflict in controlled document", func(t *testing.T) {
@@ -303,8 +305,8 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 			require.NoError(t, err)
 
 			client := crypto.NewMemoryCryptoInstance()
-			_, _ = client.New(audit.TestContext(), crypto.StringNamingFunc(keyVendor.KID()))
-			_, _ = client.New(audit.TestContext(), crypto.StringNamingFunc(keyOrg.KID()))
+			_, _ = client.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc(keyVendor.KID()))
+			_, _ = client.New(audit.TestContext(), crypto.ECP256Key, crypto.StringNamingFunc(keyOrg.KID()))
 			vdr := NewVDR(client, nil, didstore.NewTestStore(t), nil, storage.NewTestStorageEngine(t))
 			_ = vdr.Configure(*core.NewServerConfig())
 			vdr.didResolver.Register(didnuts.MethodName, didnuts.Resolver{Store: vdr.store})
@@ -561,6 +563,47 @@ func TestModule_Deactivate(t *testing.T) {
 	})
 }
 
+func TestModule_AddVerificationMethod(t *testing.T) {
+	t.Run("unsupported DID method", func(t *testing.T) {
+		test := newVDRTestCtx(t)
+
+		_, err := test.vdr.AddVerificationMethod(context.Background(), did.MustParseDID("did:example:123"), 0)
+
+		assert.EqualError(t, err, "unsupported method: example")
+	})
+	t.Run("ok", func(t *testing.T) {
+		id := did.MustParseDID("did:nuts:123")
+		expected := &did.VerificationMethod{ID: did.MustParseDIDURL("did:nuts:123#1")}
+		test := newVDRTestCtx(t)
+		test.mockDocumentManager.EXPECT().AddVerificationMethod(gomock.Any(), id, management.AssertionMethodUsage).Return(expected, nil)
+
+		actual, err := test.vdr.AddVerificationMethod(context.Background(), id, management.AssertionMethodUsage)
+
+		require.NoError(t, err)
+		assert.Same(t, expected, actual)
+	})
+}
+
+func TestModule_RemoveVerificationMethod(t *testing.T) {
+	t.Run("unsupported DID method", func(t *testing.T) {
+		test := newVDRTestCtx(t)
+
+		err := test.vdr.RemoveVerificationMethod(context.Background(), did.MustParseDID("did:example:123"), did.MustParseDIDURL("did:example:123#1"))
+
+		assert.EqualError(t, err, "unsupported method: example")
+	})
+	t.Run("manager returns error", func(t *testing.T) {
+		id := did.MustParseDID("did:nuts:123")
+		keyID := did.MustParseDIDURL("did:nuts:123#1")
+		test := newVDRTestCtx(t)
+		test.mockDocumentManager.EXPECT().RemoveVerificationMethod(gomock.Any(), id, keyID).Return(assert.AnError)
+
+		err := test.vdr.RemoveVerificationMethod(context.Background(), id, keyID)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
 type roundTripperFunc func(*http.Request) (*http.Response, error)
 
 func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {